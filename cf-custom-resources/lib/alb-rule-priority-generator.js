@@ -118,6 +118,39 @@ const calculateNextRulePriority = async function (listenerArn) {
   return nextRulePriority;
 };
 
+/**
+ * Calculates the priorities of the listener rules of a service. The additional rules
+ * are evaluated in order before the rule of the service's path, which is the last one.
+ *
+ * @param {string} listenerArn the ARN of the ALB listener.
+ * @param {number} additionalRuleCount the number of additional rules of the service.
+
+ * @returns {object} The priority of the path's rule as "Priority", and the priorities of
+ * the additional rules as "AdditionalPriority0", "AdditionalPriority1", etc.
+ */
+const calculateRulePriorities = async function (
+  listenerArn,
+  additionalRuleCount
+) {
+  const nextRulePriority = await calculateNextRulePriority(listenerArn);
+  var priorities = {};
+  for (let i = 0; i < additionalRuleCount; i++) {
+    priorities[`AdditionalPriority${i}`] = nextRulePriority + i;
+  }
+  priorities.Priority = nextRulePriority + additionalRuleCount;
+  return priorities;
+};
+
+/**
+ * Returns the number of additional rules in the properties of the custom resource.
+ *
+ * @param {object} properties the properties of the custom resource.
+ * @returns {number} The number of additional rules, 0 if there are none.
+ */
+const additionalRuleCount = function (properties) {
+  return parseInt((properties || {}).AdditionalRuleCount || "0");
+};
+
 /**
  * Next Available ALB Rule Priority handler, invoked by Lambda
  */
@@ -125,18 +158,28 @@ exports.nextAvailableRulePriorityHandler = async function (event, context) {
   var responseData = {};
   const physicalResourceId =
     event.PhysicalResourceId || `alb-rule-priority-${event.LogicalResourceId}`;
-  var rulePriority;
 
   try {
     switch (event.RequestType) {
       case "Create":
-        rulePriority = await calculateNextRulePriority(
-          event.ResourceProperties.ListenerArn
+        responseData = await calculateRulePriorities(
+          event.ResourceProperties.ListenerArn,
+          additionalRuleCount(event.ResourceProperties)
         );
-        responseData.Priority = rulePriority;
         break;
-      // Do nothing on update and delete, since this isn't a "real" resource.
       case "Update":
+        // Recalculate the priorities only if the additional rules changed, since this isn't a "real" resource.
+        if (
+          additionalRuleCount(event.ResourceProperties) !==
+          additionalRuleCount(event.OldResourceProperties)
+        ) {
+          responseData = await calculateRulePriorities(
+            event.ResourceProperties.ListenerArn,
+            additionalRuleCount(event.ResourceProperties)
+          );
+        }
+        break;
+      // Do nothing on delete, since this isn't a "real" resource.
       case "Delete":
         break;
       default:
@@ -15,6 +15,13 @@ const updateStackWaiter = {
 };
 
 const AliasParamKey = "Aliases";
+const ExecLoggingParamKeys = {
+  Workload: "ExecLoggingWorkload",
+  CloudWatchLogGroup: "ExecLogGroupName",
+  CloudWatchEncryptionEnabled: "ExecLogEncryption",
+  S3Bucket: "ExecS3BucketName",
+  S3KeyPrefix: "ExecS3KeyPrefix",
+};
 
 /**
  * Upload a CloudFormation response object to S3.
@@ -82,6 +89,7 @@ let report = function (
  * @param {string} stackName Name of the stack.
  * @param {string} workload Name of the copilot workload.
  * @param {string[]} envControllerParameters List of parameters from the environment stack to update.
+ * @param {object} [execLogging] Destinations of the ECS Exec logs of the cluster configured by the workload, if any.
  *
  * @returns {parameters} The updated parameters.
  */
@@ -89,7 +97,8 @@ const controlEnv = async function (
   stackName,
   workload,
   aliases,
-  envControllerParameters
+  envControllerParameters,
+  execLogging
 ) {
   var cfn = new aws.CloudFormation();
   aliases = aliases || [];
@@ -119,14 +128,24 @@ const controlEnv = async function (
     const exportedValues = getExportedValues(updatedEnvStack);
     // Return if there are no parameter changes.
     const shouldUpdateAliases = needUpdateAliases(envParams, workload, aliases);
+    const execLoggingUpdates = execLoggingParamUpdates(
+      envParams,
+      workload,
+      execLogging
+    );
     if (
       parametersToRemove.length + parametersToAdd.length === 0 &&
-      !shouldUpdateAliases
+      !shouldUpdateAliases &&
+      Object.keys(execLoggingUpdates).length === 0
     ) {
       return exportedValues;
     }
 
     for (const envParam of envParams) {
+      if (envParam.ParameterKey in execLoggingUpdates) {
+        envParam.ParameterValue = execLoggingUpdates[envParam.ParameterKey];
+        continue;
+      }
       if (envParam.ParameterKey === AliasParamKey) {
         if (shouldUpdateAliases) {
           envParam.ParameterValue = updateAliases(
@@ -219,7 +238,8 @@ exports.handler = async function (event, context) {
             props.EnvStack,
             props.Workload,
             props.Aliases,
-            props.Parameters
+            props.Parameters,
+            props.ExecLogging
           ),
         ]);
         break;
@@ -230,7 +250,8 @@ exports.handler = async function (event, context) {
             props.EnvStack,
             props.Workload,
             props.Aliases,
-            props.Parameters
+            props.Parameters,
+            props.ExecLogging
           ),
         ]);
         break;
@@ -305,6 +326,57 @@ const updateAliases = function (cfnAliases, workload, aliases) {
   return updatedAliases === "{}" ? "" : updatedAliases;
 };
 
+/**
+ * Returns the new values of the environment stack parameters that configure the ECS Exec logging of the cluster,
+ * keyed by parameter name. Only the workload that configured the logging can change or remove it.
+ *
+ * @param {object[]} cfnParams Parameters of the environment stack.
+ * @param {string} workload Name of the copilot workload.
+ * @param {object} [execLogging] Destinations of the ECS Exec logs configured by the workload, if any.
+ *
+ * @returns {object} The parameters to update, empty if there are no changes.
+ */
+function execLoggingParamUpdates(cfnParams, workload, execLogging) {
+  const current = {};
+  for (const param of cfnParams) {
+    current[param.ParameterKey] = param.ParameterValue;
+  }
+  if (!(ExecLoggingParamKeys.Workload in current)) {
+    if (execLogging) {
+      throw new Error(
+        "The environment stack must be upgraded to configure the logging of ECS Exec"
+      );
+    }
+    return {};
+  }
+  const owner = current[ExecLoggingParamKeys.Workload];
+  if (owner && owner !== workload) {
+    if (execLogging) {
+      throw new Error(
+        `The logging of ECS Exec is already configured by the workload ${owner}`
+      );
+    }
+    return {};
+  }
+  const logging = execLogging || {};
+  const wanted = {
+    [ExecLoggingParamKeys.Workload]: execLogging ? workload : "",
+    [ExecLoggingParamKeys.CloudWatchLogGroup]: logging.CloudWatchLogGroup || "",
+    [ExecLoggingParamKeys.CloudWatchEncryptionEnabled]: String(
+      `${logging.CloudWatchEncryptionEnabled}` === "true"
+    ),
+    [ExecLoggingParamKeys.S3Bucket]: logging.S3Bucket || "",
+    [ExecLoggingParamKeys.S3KeyPrefix]: logging.S3KeyPrefix || "",
+  };
+  const updates = {};
+  for (const [key, value] of Object.entries(wanted)) {
+    if (key in current && current[key] !== value) {
+      updates[key] = value;
+    }
+  }
+  return updates;
+}
+
 const getExportedValues = function (stack) {
   const exportedValues = {};
   stack.Outputs.forEach((output) => {
@@ -368,4 +368,94 @@ describe("ALB Rule Priority Generator", () => {
         expect(request.isDone()).toBe(true);
       });
   });
+
+  test("Create operation returns the priorities of the additional rules before the priority of the path's rule", () => {
+    const describeRulesFake = sinon.fake.resolves({
+      Rules: [
+        {
+          Priority: "default",
+          Conditions: [],
+          RuleArn:
+            "arn:aws:elasticloadbalancing:us-west-2:000000000:listener-rule/app/rule",
+          IsDefault: true,
+        },
+        {
+          Priority: "3",
+          Conditions: [],
+          RuleArn:
+            "arn:aws:elasticloadbalancing:us-west-2:000000000:listener-rule/app/rule",
+          IsDefault: false,
+        },
+      ],
+    });
+
+    AWS.mock("ELBv2", "describeRules", describeRulesFake);
+    const request = nock(ResponseURL)
+      .put("/", (body) => {
+        return (
+          body.Status === "SUCCESS" &&
+          body.Data.AdditionalPriority0 == 4 &&
+          body.Data.AdditionalPriority1 == 5 &&
+          body.Data.Priority == 6
+        );
+      })
+      .reply(200);
+
+    return LambdaTester(albRulePriorityHandler.nextAvailableRulePriorityHandler)
+      .event({
+        RequestType: "Create",
+        RequestId: testRequestId,
+        ResourceProperties: {
+          ListenerArn: testALBListenerArn,
+          AdditionalRuleCount: "2",
+        },
+      })
+      .expectResolve(() => {
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test("Update operation recalculates the priorities if the number of additional rules changed", () => {
+    const describeRulesFake = sinon.fake.resolves({
+      Rules: [
+        {
+          Priority: "7",
+          Conditions: [],
+          RuleArn:
+            "arn:aws:elasticloadbalancing:us-west-2:000000000:listener-rule/app/rule",
+          IsDefault: false,
+        },
+      ],
+    });
+
+    AWS.mock("ELBv2", "describeRules", describeRulesFake);
+    const request = nock(ResponseURL)
+      .put("/", (body) => {
+        return (
+          body.Status === "SUCCESS" &&
+          body.Data.AdditionalPriority0 == 8 &&
+          body.Data.Priority == 9 &&
+          body.PhysicalResourceId === "mockPhysicalID"
+        );
+      })
+      .reply(200);
+
+    return LambdaTester(albRulePriorityHandler.nextAvailableRulePriorityHandler)
+      .event({
+        RequestType: "Update",
+        RequestId: testRequestId,
+        LogicalResourceId: "mockID",
+        PhysicalResourceId: "mockPhysicalID",
+        ResourceProperties: {
+          ListenerArn: testALBListenerArn,
+          AdditionalRuleCount: "1",
+        },
+        OldResourceProperties: {
+          ListenerArn: testALBListenerArn,
+        },
+      })
+      .expectResolve(() => {
+        expect(request.isDone()).toBe(true);
+      });
+  });
 });
@@ -490,6 +490,165 @@ describe("Env Controller Handler", () => {
     });
   });
 
+  test("Configure the ECS Exec logging of the cluster", () => {
+    // GIVEN
+    const fakeDescribeStacks = sinon.fake.resolves({
+      Stacks: [
+        {
+          StackName: "mockEnvStack",
+          Parameters: [
+            {
+              ParameterKey: "ALBWorkloads",
+              ParameterValue: "",
+            },
+            {
+              ParameterKey: "ExecLoggingWorkload",
+              ParameterValue: "",
+            },
+            {
+              ParameterKey: "ExecLogGroupName",
+              ParameterValue: "",
+            },
+            {
+              ParameterKey: "ExecLogEncryption",
+              ParameterValue: "false",
+            },
+            {
+              ParameterKey: "ExecS3BucketName",
+              ParameterValue: "",
+            },
+            {
+              ParameterKey: "ExecS3KeyPrefix",
+              ParameterValue: "",
+            },
+          ],
+          Outputs: testOutputs,
+        },
+      ],
+    });
+    const fakeUpdateStack = sinon.fake.resolves({});
+    const fakeWaitFor = sinon.fake.resolves({});
+
+    AWS.mock("CloudFormation", "describeStacks", fakeDescribeStacks);
+    AWS.mock("CloudFormation", "updateStack", fakeUpdateStack);
+    AWS.mock("CloudFormation", "waitFor", fakeWaitFor);
+
+    const wantedRequest = nock(ResponseURL)
+      .put("/", (body) => {
+        return body.Status === "SUCCESS";
+      })
+      .reply(200);
+
+    // WHEN
+    const lambda = LambdaTester(EnvController.handler).event({
+      RequestType: "Update",
+      RequestId: testRequestId,
+      ResponseURL: ResponseURL,
+      ResourceProperties: {
+        EnvStack: "mockEnvStack",
+        Workload: "api",
+        Parameters: [],
+        ExecLogging: {
+          CloudWatchLogGroup: "exec-audit",
+          CloudWatchEncryptionEnabled: "true",
+          S3Bucket: "audit-bucket",
+        },
+      },
+    });
+
+    // THEN
+    return lambda.expectResolve(() => {
+      sinon.assert.calledWith(
+        fakeUpdateStack,
+        sinon.match({
+          Parameters: [
+            {
+              ParameterKey: "ALBWorkloads",
+              ParameterValue: "",
+            },
+            {
+              ParameterKey: "ExecLoggingWorkload",
+              ParameterValue: "api",
+            },
+            {
+              ParameterKey: "ExecLogGroupName",
+              ParameterValue: "exec-audit",
+            },
+            {
+              ParameterKey: "ExecLogEncryption",
+              ParameterValue: "true",
+            },
+            {
+              ParameterKey: "ExecS3BucketName",
+              ParameterValue: "audit-bucket",
+            },
+            {
+              ParameterKey: "ExecS3KeyPrefix",
+              ParameterValue: "",
+            },
+          ],
+          StackName: "mockEnvStack",
+          UsePreviousTemplate: true,
+        })
+      );
+      expect(wantedRequest.isDone()).toBe(true);
+    });
+  });
+
+  test("Fail to configure the ECS Exec logging of the cluster if another workload configured it", () => {
+    // GIVEN
+    const fakeDescribeStacks = sinon.fake.resolves({
+      Stacks: [
+        {
+          StackName: "mockEnvStack",
+          Parameters: [
+            {
+              ParameterKey: "ExecLoggingWorkload",
+              ParameterValue: "frontend",
+            },
+          ],
+          Outputs: testOutputs,
+        },
+      ],
+    });
+    const fakeUpdateStack = sinon.fake.resolves({});
+
+    AWS.mock("CloudFormation", "describeStacks", fakeDescribeStacks);
+    AWS.mock("CloudFormation", "updateStack", fakeUpdateStack);
+
+    const wantedRequest = nock(ResponseURL)
+      .put("/", (body) => {
+        return (
+          body.Status === "FAILED" &&
+          body.Reason.startsWith(
+            "The logging of ECS Exec is already configured by the workload frontend"
+          )
+        );
+      })
+      .reply(200);
+
+    // WHEN
+    const lambda = LambdaTester(EnvController.handler).event({
+      RequestType: "Update",
+      RequestId: testRequestId,
+      ResponseURL: ResponseURL,
+      ResourceProperties: {
+        EnvStack: "mockEnvStack",
+        Workload: "api",
+        Parameters: [],
+        ExecLogging: {
+          S3Bucket: "audit-bucket",
+        },
+      },
+    });
+
+    // THEN
+    return lambda.expectResolve(() => {
+      sinon.assert.notCalled(fakeUpdateStack);
+      expect(wantedRequest.isDone()).toBe(true);
+    });
+  });
+
   test("Wait if the stack is updating in progress", () => {
     const describeStacksFake = sinon.fake.resolves({
       Stacks: [
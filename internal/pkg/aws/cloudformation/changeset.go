@@ -164,7 +164,7 @@ func (cs *changeSet) describe() (*ChangeSetDescription, error) {
 }
 
 // execute executes a created change set.
-func (cs *changeSet) execute() error {
+func (cs *changeSet) execute(conf *stackConfig) error {
 	descr, err := cs.describe()
 	if err != nil {
 		return err
@@ -183,8 +183,9 @@ func (cs *changeSet) execute() error {
 		}
 	}
 	_, err = cs.client.ExecuteChangeSet(&cloudformation.ExecuteChangeSetInput{
-		ChangeSetName: aws.String(cs.name),
-		StackName:     aws.String(cs.stackName),
+		ChangeSetName:   aws.String(cs.name),
+		StackName:       aws.String(cs.stackName),
+		DisableRollback: conf.DisableRollback,
 	})
 	if err != nil {
 		return fmt.Errorf("execute %s: %w", cs, err)
@@ -215,7 +216,7 @@ func (cs *changeSet) createAndExecute(conf *stackConfig) error {
 		}
 		return fmt.Errorf("%w: %s", err, descr.StatusReason)
 	}
-	return cs.execute()
+	return cs.execute(conf)
 }
 
 // delete removes the change set.
@@ -153,6 +153,16 @@ func (c *CloudFormation) Delete(stackName string) error {
 	return nil
 }
 
+// CancelUpdate cancels an update of a stack that's in progress, which rolls the stack back to its previous configuration.
+func (c *CloudFormation) CancelUpdate(stackName string) error {
+	if _, err := c.client.CancelUpdateStack(&cloudformation.CancelUpdateStackInput{
+		StackName: aws.String(stackName),
+	}); err != nil {
+		return fmt.Errorf("cancel update of stack %s: %w", stackName, err)
+	}
+	return nil
+}
+
 // DeleteAndWait calls Delete then blocks until the stack is deleted or until the max attempt window expires.
 func (c *CloudFormation) DeleteAndWait(stackName string) error {
 	return c.deleteAndWait(&cloudformation.DeleteStackInput{
@@ -259,6 +259,7 @@ func TestCloudFormation_Update(t *testing.T) {
 		mockChangeSetName = "copilot-31323334-3536-4738-b930-313233333435"
 	)
 	testCases := map[string]struct {
+		inStack    *Stack
 		createMock func(ctrl *gomock.Controller) client
 		wantedErr  error
 	}{
@@ -479,6 +480,29 @@ func TestCloudFormation_Update(t *testing.T) {
 				return m
 			},
 		},
+		"success with rollback disabled": {
+			inStack: NewStack(mockStackName, "template", WithDisableRollback()),
+			createMock: func(ctrl *gomock.Controller) client {
+				m := mocks.NewMockclient(ctrl)
+				m.EXPECT().DescribeStacks(gomock.Any()).Return(&cloudformation.DescribeStacksOutput{
+					Stacks: []*cloudformation.Stack{{StackStatus: aws.String(cloudformation.StackStatusUpdateComplete)}},
+				}, nil)
+				m.EXPECT().CreateChangeSet(gomock.Any()).Return(&cloudformation.CreateChangeSetOutput{
+					Id: aws.String(mockChangeSetName),
+				}, nil)
+				m.EXPECT().WaitUntilChangeSetCreateCompleteWithContext(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().DescribeChangeSet(gomock.Any()).
+					Return(&cloudformation.DescribeChangeSetOutput{
+						ExecutionStatus: aws.String(cloudformation.ExecutionStatusAvailable),
+					}, nil)
+				m.EXPECT().ExecuteChangeSet(&cloudformation.ExecuteChangeSetInput{
+					ChangeSetName:   aws.String(mockChangeSetName),
+					StackName:       aws.String(mockStackName),
+					DisableRollback: aws.Bool(true),
+				}).Return(&cloudformation.ExecuteChangeSetOutput{}, nil)
+				return m
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -494,8 +518,13 @@ func TestCloudFormation_Update(t *testing.T) {
 				client: tc.createMock(ctrl),
 			}
 
+			stack := mockStack
+			if tc.inStack != nil {
+				stack = tc.inStack
+			}
+
 			// WHEN
-			id, err := c.Update(mockStack)
+			id, err := c.Update(stack)
 
 			// THEN
 			if tc.wantedErr != nil {
@@ -554,6 +583,48 @@ func TestCloudFormation_UpdateAndWait(t *testing.T) {
 	}
 }
 
+func TestCloudFormation_CancelUpdate(t *testing.T) {
+	testCases := map[string]struct {
+		createMock func(ctrl *gomock.Controller) client
+		wantedErr  error
+	}{
+		"fails on unexpected error": {
+			createMock: func(ctrl *gomock.Controller) client {
+				m := mocks.NewMockclient(ctrl)
+				m.EXPECT().CancelUpdateStack(gomock.Any()).Return(nil, errors.New("some error"))
+				return m
+			},
+			wantedErr: fmt.Errorf("cancel update of stack %s: %w", mockStack.Name, errors.New("some error")),
+		},
+		"cancels the update of the stack": {
+			createMock: func(ctrl *gomock.Controller) client {
+				m := mocks.NewMockclient(ctrl)
+				m.EXPECT().CancelUpdateStack(&cloudformation.CancelUpdateStackInput{
+					StackName: aws.String(mockStack.Name),
+				}).Return(&cloudformation.CancelUpdateStackOutput{}, nil)
+				return m
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := CloudFormation{
+				client: tc.createMock(ctrl),
+			}
+
+			// WHEN
+			err := c.CancelUpdate(mockStack.Name)
+
+			// THEN
+			require.Equal(t, tc.wantedErr, err)
+		})
+	}
+}
+
 func TestCloudFormation_Delete(t *testing.T) {
 	testCases := map[string]struct {
 		createMock func(ctrl *gomock.Controller) client
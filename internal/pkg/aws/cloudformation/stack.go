@@ -4,6 +4,8 @@
 package cloudformation
 
 import (
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 )
@@ -20,6 +22,11 @@ type stackConfig struct {
 	Parameters   []*cloudformation.Parameter
 	Tags         []*cloudformation.Tag
 	RoleARN      *string
+
+	// DisableRollback preserves the successfully provisioned resources if the stack operation fails.
+	DisableRollback *bool
+	// Timeout is how long to wait for the stack operation to complete. If zero, the caller's default is used.
+	Timeout time.Duration
 }
 
 // StackOption allows you to initialize a Stack with additional properties.
@@ -88,6 +95,20 @@ func WithRoleARN(roleARN string) StackOption {
 	}
 }
 
+// WithDisableRollback preserves the state of successfully provisioned resources if the stack operation fails.
+func WithDisableRollback() StackOption {
+	return func(s *Stack) {
+		s.DisableRollback = aws.Bool(true)
+	}
+}
+
+// WithTimeout sets how long to wait for the stack operation to complete.
+func WithTimeout(timeout time.Duration) StackOption {
+	return func(s *Stack) {
+		s.Timeout = timeout
+	}
+}
+
 // StackEvent is an alias the SDK's StackEvent type.
 type StackEvent cloudformation.StackEvent
 
@@ -5,6 +5,7 @@ package cloudformation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -20,7 +21,9 @@ func TestNewStack(t *testing.T) {
 		WithTags(map[string]string{
 			"copilot-application": "phonetool",
 		}),
-		WithRoleARN("arn"))
+		WithRoleARN("arn"),
+		WithDisableRollback(),
+		WithTimeout(30*time.Minute))
 
 	// THEN
 	require.Equal(t, "hello", s.Name)
@@ -38,6 +41,8 @@ func TestNewStack(t *testing.T) {
 		},
 	}, s.Tags)
 	require.Equal(t, aws.String("arn"), s.RoleARN)
+	require.Equal(t, aws.Bool(true), s.DisableRollback)
+	require.Equal(t, 30*time.Minute, s.Timeout)
 }
 
 func TestNewStackWithURL(t *testing.T) {
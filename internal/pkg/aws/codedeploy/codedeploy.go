@@ -0,0 +1,147 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package codedeploy provides a client to make API requests to AWS CodeDeploy.
+package codedeploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/codedeploy"
+)
+
+const (
+	appSpecVersion         = "0.0"
+	revisionTypeAppSpec    = "AppSpecContent"
+	ecsServiceType         = "AWS::ECS::Service"
+	waitForDeploymentDelay = 15 * time.Second
+)
+
+// lifecycleEvents are the lifecycle events of an ECS deployment that can run hooks, in the order they happen.
+var lifecycleEvents = []string{
+	"BeforeInstall",
+	"AfterInstall",
+	"AfterAllowTestTraffic",
+	"BeforeAllowTraffic",
+	"AfterAllowTraffic",
+}
+
+type api interface {
+	CreateDeployment(input *codedeploy.CreateDeploymentInput) (*codedeploy.CreateDeploymentOutput, error)
+	WaitUntilDeploymentSuccessfulWithContext(ctx aws.Context, input *codedeploy.GetDeploymentInput, opts ...request.WaiterOption) error
+}
+
+// CodeDeploy wraps an AWS CodeDeploy client.
+type CodeDeploy struct {
+	client api
+}
+
+// New returns a CodeDeploy client configured against the input session.
+func New(s *session.Session) *CodeDeploy {
+	return &CodeDeploy{
+		client: codedeploy.New(s),
+	}
+}
+
+// ECSDeployment holds the configuration of a blue/green deployment of an ECS service.
+type ECSDeployment struct {
+	ApplicationName     string
+	DeploymentGroupName string
+	TaskDefinitionARN   string            // ARN of the task definition of the replacement tasks.
+	ContainerName       string            // Name of the container that receives the traffic of the load balancer.
+	ContainerPort       int               // Port of the container that receives the traffic of the load balancer.
+	Hooks               map[string]string // ARNs of the Lambda functions keyed by the name of their lifecycle event.
+}
+
+// DeployECSService creates a deployment of the ECS service of the deployment group
+// with an AppSpec built from the input, and returns the ID of the deployment.
+func (c *CodeDeploy) DeployECSService(in ECSDeployment) (string, error) {
+	content, err := in.appSpec()
+	if err != nil {
+		return "", err
+	}
+	out, err := c.client.CreateDeployment(&codedeploy.CreateDeploymentInput{
+		ApplicationName:     aws.String(in.ApplicationName),
+		DeploymentGroupName: aws.String(in.DeploymentGroupName),
+		Revision: &codedeploy.RevisionLocation{
+			RevisionType: aws.String(revisionTypeAppSpec),
+			AppSpecContent: &codedeploy.AppSpecContent{
+				Content: aws.String(content),
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("create deployment of deployment group %s: %w", in.DeploymentGroupName, err)
+	}
+	return aws.StringValue(out.DeploymentId), nil
+}
+
+// WaitUntilDeploymentSucceeded waits until the deployment succeeds.
+// It returns an error if the deployment fails, is stopped, or doesn't succeed within the timeout.
+func (c *CodeDeploy) WaitUntilDeploymentSucceeded(id string, timeout time.Duration) error {
+	if err := c.client.WaitUntilDeploymentSuccessfulWithContext(context.Background(), &codedeploy.GetDeploymentInput{
+		DeploymentId: aws.String(id),
+	}, request.WithWaiterDelay(request.ConstantWaiterDelay(waitForDeploymentDelay)),
+		request.WithWaiterMaxAttempts(int(timeout/waitForDeploymentDelay)+1)); err != nil {
+		return fmt.Errorf("wait for deployment %s to succeed: %w", id, err)
+	}
+	return nil
+}
+
+type appSpec struct {
+	Version   string                       `json:"version"`
+	Resources []map[string]appSpecResource `json:"Resources"`
+	Hooks     []map[string]string          `json:"Hooks,omitempty"`
+}
+
+type appSpecResource struct {
+	Type       string                    `json:"Type"`
+	Properties appSpecResourceProperties `json:"Properties"`
+}
+
+type appSpecResourceProperties struct {
+	TaskDefinition   string                  `json:"TaskDefinition"`
+	LoadBalancerInfo appSpecLoadBalancerInfo `json:"LoadBalancerInfo"`
+}
+
+type appSpecLoadBalancerInfo struct {
+	ContainerName string `json:"ContainerName"`
+	ContainerPort int    `json:"ContainerPort"`
+}
+
+// appSpec returns the JSON AppSpec of the deployment.
+func (in ECSDeployment) appSpec() (string, error) {
+	spec := appSpec{
+		Version: appSpecVersion,
+		Resources: []map[string]appSpecResource{
+			{
+				"TargetService": {
+					Type: ecsServiceType,
+					Properties: appSpecResourceProperties{
+						TaskDefinition: in.TaskDefinitionARN,
+						LoadBalancerInfo: appSpecLoadBalancerInfo{
+							ContainerName: in.ContainerName,
+							ContainerPort: in.ContainerPort,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, event := range lifecycleEvents {
+		if arn, ok := in.Hooks[event]; ok {
+			spec.Hooks = append(spec.Hooks, map[string]string{event: arn})
+		}
+	}
+	content, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("marshal AppSpec: %w", err)
+	}
+	return string(content), nil
+}
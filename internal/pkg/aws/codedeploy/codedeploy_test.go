@@ -0,0 +1,158 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package codedeploy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codedeploy"
+	"github.com/aws/copilot-cli/internal/pkg/aws/codedeploy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeDeploy_DeployECSService(t *testing.T) {
+	testCases := map[string]struct {
+		in       ECSDeployment
+		mockAPI  func(m *mocks.Mockapi)
+		wantedID string
+		wantErr  error
+	}{
+		"should wrap the error of the API": {
+			in: ECSDeployment{
+				ApplicationName:     "app",
+				DeploymentGroupName: "group",
+			},
+			mockAPI: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateDeployment(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantErr: errors.New("create deployment of deployment group group: some error"),
+		},
+		"should deploy the task definition with the hooks in the order of their lifecycle events": {
+			in: ECSDeployment{
+				ApplicationName:     "app",
+				DeploymentGroupName: "group",
+				TaskDefinitionARN:   "arn:aws:ecs:us-west-2:123456789012:task-definition/phonetool-test-fe:2",
+				ContainerName:       "fe",
+				ContainerPort:       80,
+				Hooks: map[string]string{
+					"BeforeAllowTraffic":    "arn:aws:lambda:us-west-2:123456789012:function:smoke-test",
+					"AfterAllowTestTraffic": "arn:aws:lambda:us-west-2:123456789012:function:integ-test",
+				},
+			},
+			mockAPI: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateDeployment(&codedeploy.CreateDeploymentInput{
+					ApplicationName:     aws.String("app"),
+					DeploymentGroupName: aws.String("group"),
+					Revision: &codedeploy.RevisionLocation{
+						RevisionType: aws.String("AppSpecContent"),
+						AppSpecContent: &codedeploy.AppSpecContent{
+							Content: aws.String(`{"version":"0.0","Resources":[{"TargetService":{"Type":"AWS::ECS::Service","Properties":{"TaskDefinition":"arn:aws:ecs:us-west-2:123456789012:task-definition/phonetool-test-fe:2","LoadBalancerInfo":{"ContainerName":"fe","ContainerPort":80}}}}],"Hooks":[{"AfterAllowTestTraffic":"arn:aws:lambda:us-west-2:123456789012:function:integ-test"},{"BeforeAllowTraffic":"arn:aws:lambda:us-west-2:123456789012:function:smoke-test"}]}`),
+						},
+					},
+				}).Return(&codedeploy.CreateDeploymentOutput{
+					DeploymentId: aws.String("d-ABCDEF"),
+				}, nil)
+			},
+			wantedID: "d-ABCDEF",
+		},
+		"should omit the hooks if there are none": {
+			in: ECSDeployment{
+				ApplicationName:     "app",
+				DeploymentGroupName: "group",
+				TaskDefinitionARN:   "arn:aws:ecs:us-west-2:123456789012:task-definition/phonetool-test-fe:2",
+				ContainerName:       "fe",
+				ContainerPort:       80,
+			},
+			mockAPI: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateDeployment(&codedeploy.CreateDeploymentInput{
+					ApplicationName:     aws.String("app"),
+					DeploymentGroupName: aws.String("group"),
+					Revision: &codedeploy.RevisionLocation{
+						RevisionType: aws.String("AppSpecContent"),
+						AppSpecContent: &codedeploy.AppSpecContent{
+							Content: aws.String(`{"version":"0.0","Resources":[{"TargetService":{"Type":"AWS::ECS::Service","Properties":{"TaskDefinition":"arn:aws:ecs:us-west-2:123456789012:task-definition/phonetool-test-fe:2","LoadBalancerInfo":{"ContainerName":"fe","ContainerPort":80}}}}]}`),
+						},
+					},
+				}).Return(&codedeploy.CreateDeploymentOutput{
+					DeploymentId: aws.String("d-ABCDEF"),
+				}, nil)
+			},
+			wantedID: "d-ABCDEF",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := mocks.NewMockapi(ctrl)
+			tc.mockAPI(m)
+			cd := CodeDeploy{
+				client: m,
+			}
+
+			// WHEN
+			id, err := cd.DeployECSService(tc.in)
+
+			// THEN
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedID, id)
+		})
+	}
+}
+
+func TestCodeDeploy_WaitUntilDeploymentSucceeded(t *testing.T) {
+	testCases := map[string]struct {
+		mockAPI func(m *mocks.Mockapi)
+		wantErr error
+	}{
+		"should wrap the error of the waiter": {
+			mockAPI: func(m *mocks.Mockapi) {
+				m.EXPECT().WaitUntilDeploymentSuccessfulWithContext(gomock.Any(), &codedeploy.GetDeploymentInput{
+					DeploymentId: aws.String("d-ABCDEF"),
+				}, gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+			},
+			wantErr: errors.New("wait for deployment d-ABCDEF to succeed: some error"),
+		},
+		"should return nil once the deployment succeeds": {
+			mockAPI: func(m *mocks.Mockapi) {
+				m.EXPECT().WaitUntilDeploymentSuccessfulWithContext(gomock.Any(), &codedeploy.GetDeploymentInput{
+					DeploymentId: aws.String("d-ABCDEF"),
+				}, gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := mocks.NewMockapi(ctrl)
+			tc.mockAPI(m)
+			cd := CodeDeploy{
+				client: m,
+			}
+
+			// WHEN
+			err := cd.WaitUntilDeploymentSucceeded("d-ABCDEF", time.Hour)
+
+			// THEN
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -73,7 +73,9 @@ type VPC struct {
 // Subnet contains the ID and name of a subnet.
 type Subnet struct {
 	Resource
-	CIDRBlock string
+	CIDRBlock        string
+	IPv6CIDRBlock    string // Empty if the subnet doesn't have an associated IPv6 CIDR block.
+	AvailabilityZone string
 }
 
 // String formats the elements of a VPC into a display-ready string.
@@ -245,7 +247,14 @@ func (c *EC2) ListVPCSubnets(vpcID string) (*VPCSubnets, error) {
 				ID:   aws.StringValue(subnet.SubnetId),
 				Name: name,
 			},
-			CIDRBlock: aws.StringValue(subnet.CidrBlock),
+			CIDRBlock:        aws.StringValue(subnet.CidrBlock),
+			AvailabilityZone: aws.StringValue(subnet.AvailabilityZone),
+		}
+		for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+			if aws.StringValue(association.Ipv6CidrBlockState.State) == ec2.SubnetCidrBlockStateCodeAssociated {
+				s.IPv6CIDRBlock = aws.StringValue(association.Ipv6CidrBlock)
+				break
+			}
 		}
 		if _, ok := publicSubnetMap[s.ID]; ok {
 			publicSubnets = append(publicSubnets, s)
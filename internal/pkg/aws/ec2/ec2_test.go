@@ -241,12 +241,14 @@ func TestEC2_ListVPCSubnets(t *testing.T) {
 				}).Return(&ec2.DescribeSubnetsOutput{
 					Subnets: []*ec2.Subnet{
 						{
-							SubnetId:  aws.String("subnet1"),
-							CidrBlock: aws.String("10.0.0.0/24"),
+							SubnetId:         aws.String("subnet1"),
+							CidrBlock:        aws.String("10.0.0.0/24"),
+							AvailabilityZone: aws.String("us-west-2a"),
 						},
 						{
-							SubnetId:  aws.String("subnet2"),
-							CidrBlock: aws.String("10.0.1.0/24"),
+							SubnetId:         aws.String("subnet2"),
+							CidrBlock:        aws.String("10.0.1.0/24"),
+							AvailabilityZone: aws.String("us-west-2a"),
 						},
 						{
 							SubnetId: aws.String("subnet3"),
@@ -256,7 +258,16 @@ func TestEC2_ListVPCSubnets(t *testing.T) {
 									Value: aws.String("mySubnet"),
 								},
 							},
-							CidrBlock: aws.String("10.0.2.0/24"),
+							CidrBlock:        aws.String("10.0.2.0/24"),
+							AvailabilityZone: aws.String("us-west-2b"),
+							Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+								{
+									Ipv6CidrBlock: aws.String("2600:1f14:abc:de01::/64"),
+									Ipv6CidrBlockState: &ec2.SubnetCidrBlockState{
+										State: aws.String(ec2.SubnetCidrBlockStateCodeAssociated),
+									},
+								},
+							},
 						},
 					},
 				}, nil)
@@ -266,14 +277,17 @@ func TestEC2_ListVPCSubnets(t *testing.T) {
 					Resource: Resource{
 						ID: "subnet2",
 					},
-					CIDRBlock: "10.0.1.0/24",
+					CIDRBlock:        "10.0.1.0/24",
+					AvailabilityZone: "us-west-2a",
 				},
 				{
 					Resource: Resource{
 						ID:   "subnet3",
 						Name: "mySubnet",
 					},
-					CIDRBlock: "10.0.2.0/24",
+					CIDRBlock:        "10.0.2.0/24",
+					IPv6CIDRBlock:    "2600:1f14:abc:de01::/64",
+					AvailabilityZone: "us-west-2b",
 				},
 			},
 			wantedPrivateSubnets: []Subnet{
@@ -281,7 +295,8 @@ func TestEC2_ListVPCSubnets(t *testing.T) {
 					Resource: Resource{
 						ID: "subnet1",
 					},
-					CIDRBlock: "10.0.0.0/24",
+					CIDRBlock:        "10.0.0.0/24",
+					AvailabilityZone: "us-west-2a",
 				},
 			},
 		},
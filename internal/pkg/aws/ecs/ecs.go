@@ -23,6 +23,8 @@ const (
 	waitServiceStablePollingInterval = 15 * time.Second
 	waitServiceStableMaxTry          = 80
 	stableServiceDeploymentNum       = 1
+	waitTasksStoppedPollingInterval  = 6 * time.Second
+	waitTasksStoppedMaxTry           = 900 // Wait for at most 90 mins for the tasks to stop, like for stack updates.
 )
 
 type api interface {
@@ -36,6 +38,7 @@ type api interface {
 	StopTask(input *ecs.StopTaskInput) (*ecs.StopTaskOutput, error)
 	UpdateService(input *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error)
 	WaitUntilTasksRunning(input *ecs.DescribeTasksInput) error
+	WaitUntilTasksStoppedWithContext(ctx aws.Context, input *ecs.DescribeTasksInput, opts ...request.WaiterOption) error
 }
 
 type ssmSessionStarter interface {
@@ -123,6 +126,13 @@ func WithForceUpdate() UpdateServiceOpts {
 	}
 }
 
+// WithExecuteCommand sets EnableExecuteCommand to turn ECS Exec on or off for the tasks that the service starts.
+func WithExecuteCommand(enable bool) UpdateServiceOpts {
+	return func(in *ecs.UpdateServiceInput) {
+		in.EnableExecuteCommand = aws.Bool(enable)
+	}
+}
+
 // UpdateService calls ECS API and updates the specific service running in the cluster.
 func (e *ECS) UpdateService(clusterName, serviceName string, opts ...UpdateServiceOpts) error {
 	in := &ecs.UpdateServiceInput{
@@ -367,6 +377,18 @@ func (e *ECS) RunTask(input RunTaskInput) ([]*Task, error) {
 	return tasks, nil
 }
 
+// WaitUntilTasksStopped waits for the tasks with the taskARNs in the cluster to stop, and returns the stopped tasks.
+func (e *ECS) WaitUntilTasksStopped(cluster string, taskARNs []string) ([]*Task, error) {
+	if err := e.client.WaitUntilTasksStoppedWithContext(aws.BackgroundContext(), &ecs.DescribeTasksInput{
+		Cluster: aws.String(cluster),
+		Tasks:   aws.StringSlice(taskARNs),
+	}, request.WithWaiterDelay(request.ConstantWaiterDelay(waitTasksStoppedPollingInterval)),
+		request.WithWaiterMaxAttempts(waitTasksStoppedMaxTry)); err != nil {
+		return nil, fmt.Errorf("wait for tasks to stop: %w", err)
+	}
+	return e.DescribeTasks(cluster, taskARNs)
+}
+
 // DescribeTasks returns the tasks with the taskARNs in the cluster.
 func (e *ECS) DescribeTasks(cluster string, taskARNs []string) ([]*Task, error) {
 	resp, err := e.client.DescribeTasks(&ecs.DescribeTasksInput{
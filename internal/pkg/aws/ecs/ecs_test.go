@@ -194,6 +194,7 @@ func TestECS_UpdateService(t *testing.T) {
 	)
 	testCases := map[string]struct {
 		forceUpdate   bool
+		enableExec    *bool
 		maxTryNum     int
 		mockECSClient func(m *mocks.Mockapi)
 
@@ -262,6 +263,19 @@ func TestECS_UpdateService(t *testing.T) {
 			},
 			wantErr: fmt.Errorf("wait until service mockService becomes stable: describe service mockService: some error"),
 		},
+		"errors if failed to turn on execute command": {
+			forceUpdate: true,
+			enableExec:  aws.Bool(true),
+			mockECSClient: func(m *mocks.Mockapi) {
+				m.EXPECT().UpdateService(&ecs.UpdateServiceInput{
+					Cluster:              aws.String(clusterName),
+					Service:              aws.String(serviceName),
+					ForceNewDeployment:   aws.Bool(true),
+					EnableExecuteCommand: aws.Bool(true),
+				}).Return(nil, errors.New("some error"))
+			},
+			wantErr: fmt.Errorf("update service mockService from cluster mockCluster: some error"),
+		},
 		"success": {
 			forceUpdate: true,
 			mockECSClient: func(m *mocks.Mockapi) {
@@ -328,6 +342,9 @@ func TestECS_UpdateService(t *testing.T) {
 			if tc.forceUpdate {
 				opts = append(opts, WithForceUpdate())
 			}
+			if tc.enableExec != nil {
+				opts = append(opts, WithExecuteCommand(aws.BoolValue(tc.enableExec)))
+			}
 
 			gotErr := service.UpdateService(clusterName, serviceName, opts...)
 
@@ -1151,6 +1168,74 @@ func TestECS_DescribeTasks(t *testing.T) {
 	}
 }
 
+func TestECS_WaitUntilTasksStopped(t *testing.T) {
+	inCluster := "my-cluster"
+	inTaskARNs := []string{"task-1"}
+	testCases := map[string]struct {
+		mockAPI     func(m *mocks.Mockapi)
+		wantedError error
+		wantedTasks []*Task
+	}{
+		"error waiting for tasks to stop": {
+			mockAPI: func(m *mocks.Mockapi) {
+				m.EXPECT().WaitUntilTasksStoppedWithContext(gomock.Any(), &ecs.DescribeTasksInput{
+					Cluster: aws.String(inCluster),
+					Tasks:   aws.StringSlice(inTaskARNs),
+				}, gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+			},
+			wantedError: errors.New("wait for tasks to stop: some error"),
+		},
+		"returns the stopped tasks": {
+			mockAPI: func(m *mocks.Mockapi) {
+				m.EXPECT().WaitUntilTasksStoppedWithContext(gomock.Any(), &ecs.DescribeTasksInput{
+					Cluster: aws.String(inCluster),
+					Tasks:   aws.StringSlice(inTaskARNs),
+				}, gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().DescribeTasks(&ecs.DescribeTasksInput{
+					Cluster: aws.String(inCluster),
+					Tasks:   aws.StringSlice(inTaskARNs),
+					Include: aws.StringSlice([]string{ecs.TaskFieldTags}),
+				}).Return(&ecs.DescribeTasksOutput{
+					Tasks: []*ecs.Task{
+						{
+							TaskArn:    aws.String("task-1"),
+							LastStatus: aws.String("STOPPED"),
+						},
+					},
+				}, nil)
+			},
+			wantedTasks: []*Task{
+				{
+					TaskArn:    aws.String("task-1"),
+					LastStatus: aws.String("STOPPED"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAPI := mocks.NewMockapi(ctrl)
+			tc.mockAPI(mockAPI)
+
+			ecs := ECS{
+				client: mockAPI,
+			}
+
+			tasks, err := ecs.WaitUntilTasksStopped(inCluster, inTaskARNs)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedTasks, tasks)
+			}
+		})
+	}
+}
+
 func TestECS_ExecuteCommand(t *testing.T) {
 	mockExecCmdIn := &ecs.ExecuteCommandInput{
 		Cluster:     aws.String("mockCluster"),
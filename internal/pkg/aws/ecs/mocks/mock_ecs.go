@@ -7,6 +7,8 @@ package mocks
 import (
 	reflect "reflect"
 
+	aws "github.com/aws/aws-sdk-go/aws"
+	request "github.com/aws/aws-sdk-go/aws/request"
 	ecs "github.com/aws/aws-sdk-go/service/ecs"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -183,6 +185,25 @@ func (mr *MockapiMockRecorder) WaitUntilTasksRunning(input interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilTasksRunning", reflect.TypeOf((*Mockapi)(nil).WaitUntilTasksRunning), input)
 }
 
+// WaitUntilTasksStoppedWithContext mocks base method.
+func (m *Mockapi) WaitUntilTasksStoppedWithContext(ctx aws.Context, input *ecs.DescribeTasksInput, opts ...request.WaiterOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitUntilTasksStoppedWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilTasksStoppedWithContext indicates an expected call of WaitUntilTasksStoppedWithContext.
+func (mr *MockapiMockRecorder) WaitUntilTasksStoppedWithContext(ctx, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilTasksStoppedWithContext", reflect.TypeOf((*Mockapi)(nil).WaitUntilTasksStoppedWithContext), varargs...)
+}
+
 // MockssmSessionStarter is a mock of ssmSessionStarter interface.
 type MockssmSessionStarter struct {
 	ctrl     *gomock.Controller
@@ -213,6 +213,29 @@ func (t *TaskDefinition) Secrets() []*ContainerSecret {
 	return secrets
 }
 
+// LogGroupName returns the log group of the first container that sends its logs to CloudWatch Logs with the awslogs driver.
+// It returns an empty string if no container uses the awslogs driver.
+func (t *TaskDefinition) LogGroupName() string {
+	for _, container := range t.ContainerDefinitions {
+		if container.LogConfiguration == nil || aws.StringValue(container.LogConfiguration.LogDriver) != ecs.LogDriverAwslogs {
+			continue
+		}
+		if group := aws.StringValue(container.LogConfiguration.Options["awslogs-group"]); group != "" {
+			return group
+		}
+	}
+	return ""
+}
+
+// MainContainerName returns the name of the main container of the task definition.
+// Copilot defines the main container first, before the sidecars.
+func (t *TaskDefinition) MainContainerName() string {
+	if len(t.ContainerDefinitions) == 0 {
+		return ""
+	}
+	return aws.StringValue(t.ContainerDefinitions[0].Name)
+}
+
 // Image returns the container's image of the task definition.
 func (t *TaskDefinition) Image(containerName string) (string, error) {
 	for _, container := range t.ContainerDefinitions {
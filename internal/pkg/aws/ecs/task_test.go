@@ -507,6 +507,57 @@ func TestTaskDefinition_Image(t *testing.T) {
 	}
 }
 
+func TestTaskDefinition_LogGroupName(t *testing.T) {
+	testCases := map[string]struct {
+		inContainers []*ecs.ContainerDefinition
+
+		wanted string
+	}{
+		"should return the log group of the first container using awslogs": {
+			inContainers: []*ecs.ContainerDefinition{
+				{
+					Name: aws.String("web"),
+					LogConfiguration: &ecs.LogConfiguration{
+						LogDriver: aws.String(ecs.LogDriverAwsfirelens),
+					},
+				},
+				{
+					Name: aws.String("firelens_log_router"),
+					LogConfiguration: &ecs.LogConfiguration{
+						LogDriver: aws.String(ecs.LogDriverAwslogs),
+						Options: map[string]*string{
+							"awslogs-group": aws.String("/my/logs"),
+						},
+					},
+				},
+			},
+			wanted: "/my/logs",
+		},
+		"should return an empty string if no container uses awslogs": {
+			inContainers: []*ecs.ContainerDefinition{
+				{
+					Name: aws.String("web"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			taskDefinition := TaskDefinition{
+				ContainerDefinitions: tc.inContainers,
+			}
+
+			// WHEN
+			got := taskDefinition.LogGroupName()
+
+			// THEN
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
 func TestTaskDefinition_Command(t *testing.T) {
 	testCases := map[string]struct {
 		inContainers    []*ecs.ContainerDefinition
@@ -700,3 +751,39 @@ func Test_TaskDefinitionVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskDefinition_MainContainerName(t *testing.T) {
+	testCases := map[string]struct {
+		inContainers []*ecs.ContainerDefinition
+
+		wanted string
+	}{
+		"should return the name of the first container": {
+			inContainers: []*ecs.ContainerDefinition{
+				{
+					Name: aws.String("api"),
+				},
+				{
+					Name: aws.String("nginx"),
+				},
+			},
+			wanted: "api",
+		},
+		"should return an empty string if there are no containers": {},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			taskDefinition := TaskDefinition{
+				ContainerDefinitions: tc.inContainers,
+			}
+
+			// WHEN
+			got := taskDefinition.MainContainerName()
+
+			// THEN
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
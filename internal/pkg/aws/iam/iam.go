@@ -25,6 +25,8 @@ type api interface {
 	ListRolePolicies(input *iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error)
 	DeleteRole(input *iam.DeleteRoleInput) (*iam.DeleteRoleOutput, error)
 	CreateServiceLinkedRole(input *iam.CreateServiceLinkedRoleInput) (*iam.CreateServiceLinkedRoleOutput, error)
+	ListOpenIDConnectProviders(input *iam.ListOpenIDConnectProvidersInput) (*iam.ListOpenIDConnectProvidersOutput, error)
+	CreateOpenIDConnectProvider(input *iam.CreateOpenIDConnectProviderInput) (*iam.CreateOpenIDConnectProviderOutput, error)
 }
 
 // IAM wraps the AWS SDK's IAM client.
@@ -98,6 +100,36 @@ func (c *IAM) CreateECSServiceLinkedRole() error {
 	return nil
 }
 
+// CreateOIDCProviderIfNotExists creates an OpenID Connect identity provider for the issuer url, unless the account
+// already has one for the issuer, and returns the ARN of the provider.
+// The url must start with "https://", and the thumbprints are the SHA-1 fingerprints of the issuer's certificates.
+func (c *IAM) CreateOIDCProviderIfNotExists(url string, audiences, thumbprints []string) (string, error) {
+	out, err := c.client.ListOpenIDConnectProviders(&iam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return "", fmt.Errorf("list OIDC providers: %w", err)
+	}
+	// The resource of the provider ARN is the url without its scheme, for example: oidc-provider/example.com.
+	resource := "oidc-provider/" + strings.TrimPrefix(url, "https://")
+	for _, provider := range out.OpenIDConnectProviderList {
+		parsed, err := arn.Parse(aws.StringValue(provider.Arn))
+		if err != nil {
+			continue
+		}
+		if parsed.Resource == resource {
+			return aws.StringValue(provider.Arn), nil
+		}
+	}
+	created, err := c.client.CreateOpenIDConnectProvider(&iam.CreateOpenIDConnectProviderInput{
+		Url:            aws.String(url),
+		ClientIDList:   aws.StringSlice(audiences),
+		ThumbprintList: aws.StringSlice(thumbprints),
+	})
+	if err != nil {
+		return "", fmt.Errorf("create OIDC provider for %s: %w", url, err)
+	}
+	return aws.StringValue(created.OpenIDConnectProviderArn), nil
+}
+
 func (c *IAM) deleteRolePolicies(roleName string) error {
 	policyNames, err := c.listRolePolicyNames(roleName)
 	if err != nil {
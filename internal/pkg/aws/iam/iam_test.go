@@ -242,3 +242,86 @@ func TestIAM_CreateECSServiceLinkedRole(t *testing.T) {
 		})
 	}
 }
+
+func TestIAM_CreateOIDCProviderIfNotExists(t *testing.T) {
+	const mockURL = "https://token.actions.githubusercontent.com"
+	testCases := map[string]struct {
+		inClient func(ctrl *gomock.Controller) *mocks.Mockapi
+
+		wantedARN string
+		wantedErr error
+	}{
+		"wraps error if providers can't be listed": {
+			inClient: func(ctrl *gomock.Controller) *mocks.Mockapi {
+				m := mocks.NewMockapi(ctrl)
+				m.EXPECT().ListOpenIDConnectProviders(gomock.Any()).Return(nil, errors.New("some error"))
+				return m
+			},
+			wantedErr: errors.New("list OIDC providers: some error"),
+		},
+		"returns the existing provider": {
+			inClient: func(ctrl *gomock.Controller) *mocks.Mockapi {
+				m := mocks.NewMockapi(ctrl)
+				m.EXPECT().ListOpenIDConnectProviders(gomock.Any()).Return(&iam.ListOpenIDConnectProvidersOutput{
+					OpenIDConnectProviderList: []*iam.OpenIDConnectProviderListEntry{
+						{Arn: aws.String("arn:aws:iam::1111:oidc-provider/example.com")},
+						{Arn: aws.String("arn:aws:iam::1111:oidc-provider/token.actions.githubusercontent.com")},
+					},
+				}, nil)
+				m.EXPECT().CreateOpenIDConnectProvider(gomock.Any()).Times(0)
+				return m
+			},
+			wantedARN: "arn:aws:iam::1111:oidc-provider/token.actions.githubusercontent.com",
+		},
+		"creates the provider if it doesn't exist": {
+			inClient: func(ctrl *gomock.Controller) *mocks.Mockapi {
+				m := mocks.NewMockapi(ctrl)
+				m.EXPECT().ListOpenIDConnectProviders(gomock.Any()).Return(&iam.ListOpenIDConnectProvidersOutput{
+					OpenIDConnectProviderList: []*iam.OpenIDConnectProviderListEntry{
+						{Arn: aws.String("arn:aws:iam::1111:oidc-provider/example.com")},
+					},
+				}, nil)
+				m.EXPECT().CreateOpenIDConnectProvider(&iam.CreateOpenIDConnectProviderInput{
+					Url:            aws.String(mockURL),
+					ClientIDList:   aws.StringSlice([]string{"sts.amazonaws.com"}),
+					ThumbprintList: aws.StringSlice([]string{"abcd"}),
+				}).Return(&iam.CreateOpenIDConnectProviderOutput{
+					OpenIDConnectProviderArn: aws.String("arn:aws:iam::1111:oidc-provider/token.actions.githubusercontent.com"),
+				}, nil)
+				return m
+			},
+			wantedARN: "arn:aws:iam::1111:oidc-provider/token.actions.githubusercontent.com",
+		},
+		"wraps error if the provider can't be created": {
+			inClient: func(ctrl *gomock.Controller) *mocks.Mockapi {
+				m := mocks.NewMockapi(ctrl)
+				m.EXPECT().ListOpenIDConnectProviders(gomock.Any()).Return(&iam.ListOpenIDConnectProvidersOutput{}, nil)
+				m.EXPECT().CreateOpenIDConnectProvider(gomock.Any()).Return(nil, errors.New("some error"))
+				return m
+			},
+			wantedErr: errors.New("create OIDC provider for https://token.actions.githubusercontent.com: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			iam := &IAM{
+				client: tc.inClient(ctrl),
+			}
+
+			// WHEN
+			got, err := iam.CreateOIDCProviderIfNotExists(mockURL, []string{"sts.amazonaws.com"}, []string{"abcd"})
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedARN, got)
+		})
+	}
+}
@@ -5,7 +5,9 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/aws/copilot-cli/internal/pkg/deploy"
 	"github.com/aws/copilot-cli/internal/pkg/describe"
@@ -29,8 +31,15 @@ const (
 	jobWkldType = "job"
 )
 
+const (
+	dryRunMinCellWidth     = 20
+	dryRunTabWidth         = 4
+	dryRunCellPaddingWidth = 2
+)
+
 type deployOpts struct {
 	deployWkldVars
+	dryRun bool
 
 	deployWkld     actionCommand
 	setupDeployCmd func(*deployOpts, string)
@@ -40,6 +49,10 @@ type deployOpts struct {
 	ws     wsWlDirReader
 	prompt prompter
 
+	newInterpolator func(app, env string) interpolator
+	unmarshal       func([]byte) (manifest.WorkloadManifest, error)
+	w               io.Writer
+
 	// values for logging
 	wlType string
 }
@@ -59,11 +72,14 @@ func newDeployOpts(vars deployWkldVars) (*deployOpts, error) {
 	}
 	prompter := prompt.New()
 	return &deployOpts{
-		deployWkldVars: vars,
-		store:          store,
-		sel:            selector.NewWorkspaceSelect(prompter, store, ws),
-		ws:             ws,
-		prompt:         prompter,
+		deployWkldVars:  vars,
+		store:           store,
+		sel:             selector.NewWorkspaceSelect(prompter, store, ws),
+		ws:              ws,
+		prompt:          prompter,
+		newInterpolator: newManifestInterpolator,
+		unmarshal:       manifest.UnmarshalWorkload,
+		w:               log.OutputWriter,
 
 		setupDeployCmd: func(o *deployOpts, workloadType string) {
 			switch {
@@ -107,6 +123,12 @@ func newDeployOpts(vars deployWkldVars) (*deployOpts, error) {
 }
 
 func (o *deployOpts) Run() error {
+	if o.dryRun {
+		return o.reportImageSources()
+	}
+	if err := validateStackTimeout(o.stackTimeout); err != nil {
+		return err
+	}
 	if err := o.askName(); err != nil {
 		return err
 	}
@@ -122,6 +144,50 @@ func (o *deployOpts) Run() error {
 	return nil
 }
 
+// reportImageSources writes, for the workload named with --name or every workload in the workspace, whether its
+// container image would be built from a local Dockerfile or pulled from an existing location.
+// If --env is set, the environment overrides of the manifest are applied first.
+func (o *deployOpts) reportImageSources() error {
+	names := []string{o.name}
+	if o.name == "" {
+		var err error
+		if names, err = o.ws.ListWorkloads(); err != nil {
+			return fmt.Errorf("list workloads in workspace: %w", err)
+		}
+	}
+	writer := tabwriter.NewWriter(o.w, dryRunMinCellWidth, dryRunTabWidth, dryRunCellPaddingWidth, ' ', 0)
+	fmt.Fprintf(writer, "Name\tImage\n")
+	fmt.Fprintf(writer, "----\t-----\n")
+	for _, name := range names {
+		raw, err := o.ws.ReadWorkloadManifest(name)
+		if err != nil {
+			return fmt.Errorf("read manifest file for %s: %w", name, err)
+		}
+		raw, err = manifest.Load(workloadManifestPath(name), raw, workspaceFileReader(o.ws))
+		if err != nil {
+			return fmt.Errorf("load manifest for %s: %w", name, err)
+		}
+		interpolated, err := o.newInterpolator(o.appName, o.envName).Interpolate(string(raw))
+		if err != nil {
+			return fmt.Errorf("interpolate environment variables for %s manifest: %w", name, err)
+		}
+		mft, err := o.unmarshal([]byte(interpolated))
+		if err != nil {
+			return fmt.Errorf("unmarshal manifest for %s: %w", name, err)
+		}
+		envMft, err := mft.ApplyEnv(o.envName)
+		if err != nil {
+			return fmt.Errorf("apply environment %s override for %s: %w", o.envName, name, err)
+		}
+		source, err := manifest.WorkloadImageSource(envMft)
+		if err != nil {
+			return fmt.Errorf("classify image of %s: %w", name, err)
+		}
+		fmt.Fprintf(writer, "%s\t%s\n", name, source)
+	}
+	return writer.Flush()
+}
+
 func (o *deployOpts) askName() error {
 	if o.name != "" {
 		return nil
@@ -164,6 +230,7 @@ func (o *deployOpts) loadWkldCmd() error {
 // BuildDeployCmd is the deploy command.
 func BuildDeployCmd() *cobra.Command {
 	vars := deployWkldVars{}
+	var dryRun bool
 	cmd := &cobra.Command{
 		Use:   "deploy",
 		Short: "Deploy a Copilot job or service.",
@@ -172,12 +239,17 @@ func BuildDeployCmd() *cobra.Command {
   Deploys a service named "frontend" to a "test" environment.
   /code $ copilot deploy --name frontend --env test
   Deploys a job named "mailer" with additional resource tags to a "prod" environment.
-  /code $ copilot deploy -n mailer -e prod --resource-tags source/revision=bb133e7,deployment/initiator=manual`,
+  /code $ copilot deploy -n mailer -e prod --resource-tags source/revision=bb133e7,deployment/initiator=manual
+  Lists which workloads in the workspace would build their image and which would pull it.
+  /code $ copilot deploy --dry-run
+  Shows whether the "frontend" service would build or pull its image in a "prod" environment.
+  /code $ copilot deploy --dry-run --name frontend --env prod`,
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			opts, err := newDeployOpts(vars)
 			if err != nil {
 				return err
 			}
+			opts.dryRun = dryRun
 			if err := opts.Run(); err != nil {
 				return err
 			}
@@ -190,6 +262,9 @@ func BuildDeployCmd() *cobra.Command {
 	cmd.Flags().StringVar(&vars.imageTag, imageTagFlag, "", imageTagFlagDescription)
 	cmd.Flags().StringToStringVar(&vars.resourceTags, resourceTagsFlag, nil, resourceTagsFlagDescription)
 	cmd.Flags().BoolVar(&vars.forceNewUpdate, forceFlag, false, forceFlagDescription)
+	cmd.Flags().BoolVar(&dryRun, dryRunFlag, false, dryRunFlagDescription)
+	cmd.Flags().BoolVar(&vars.disableRollback, disableRollbackFlag, false, disableRollbackFlagDescription)
+	cmd.Flags().DurationVar(&vars.stackTimeout, timeoutFlag, 0, stackTimeoutFlagDescription)
 
 	cmd.SetUsageTemplate(template.Usage)
 	cmd.Annotations = map[string]string{
@@ -4,11 +4,14 @@
 package cli
 
 import (
+	"bytes"
 	"errors"
 	"testing"
 
 	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
 	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/aws/copilot-cli/internal/pkg/workspace"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
@@ -164,3 +167,167 @@ func TestDeployOpts_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestDeployOpts_RunDryRun(t *testing.T) {
+	const (
+		buildMft = `name: fe
+type: Load Balanced Web Service
+image:
+  build: fe/Dockerfile
+  port: 80
+`
+		locationMft = `name: api
+type: Backend Service
+image:
+  location: nginx:1.21
+  port: 8080
+`
+		jobMft = `name: mailer
+type: Scheduled Job
+image:
+  build:
+    dockerfile: mailer/Dockerfile
+on:
+  schedule: "@daily"
+`
+		noImageMft = `name: broken
+type: Worker Service
+`
+		extendsMft = `name: fe
+extends: ../base.yml
+`
+		overriddenMft = `name: fe
+type: Load Balanced Web Service
+image:
+  build: fe/Dockerfile
+  port: 80
+environments:
+  prod:
+    image:
+      location: ${REGISTRY}/fe:${COPILOT_ENVIRONMENT_NAME}
+`
+	)
+	testCases := map[string]struct {
+		inName string
+		inEnv  string
+		setEnv func(t *testing.T)
+		mockWs func(m *mocks.MockwsWlDirReader)
+
+		wanted    string
+		wantedErr string
+	}{
+		"fail to list workloads": {
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListWorkloads().Return(nil, errors.New("some error"))
+			},
+			wantedErr: "list workloads in workspace: some error",
+		},
+		"fail to read manifest": {
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListWorkloads().Return([]string{"fe"}, nil)
+				m.EXPECT().ReadWorkloadManifest("fe").Return(nil, errors.New("some error"))
+			},
+			wantedErr: "read manifest file for fe: some error",
+		},
+		"fail to read the base manifest of an extended manifest": {
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListWorkloads().Return([]string{"fe"}, nil)
+				m.EXPECT().ReadWorkloadManifest("fe").Return(workspace.WorkloadManifest(extendsMft), nil)
+				m.EXPECT().CopilotDirPath().Return("", errors.New("some error"))
+			},
+			wantedErr: "load manifest for fe: read base manifest copilot/base.yml: get copilot directory: some error",
+		},
+		"fail to classify a workload without an image": {
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListWorkloads().Return([]string{"broken"}, nil)
+				m.EXPECT().ReadWorkloadManifest("broken").Return(workspace.WorkloadManifest(noImageMft), nil)
+			},
+			wantedErr: `classify image of broken: check if workload requires building from local Dockerfile: either "image.build" or "image.location" needs to be specified in the manifest`,
+		},
+		"reports only the workload named with --name": {
+			inName: "api",
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ReadWorkloadManifest("api").Return(workspace.WorkloadManifest(locationMft), nil)
+			},
+			wanted: `Name                Image
+----                -----
+api                 location
+`,
+		},
+		"applies the overrides of the environment named with --env": {
+			inEnv: "prod",
+			setEnv: func(t *testing.T) {
+				t.Setenv("REGISTRY", "public.ecr.aws/acme")
+			},
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListWorkloads().Return([]string{"fe"}, nil)
+				m.EXPECT().ReadWorkloadManifest("fe").Return(workspace.WorkloadManifest(overriddenMft), nil)
+			},
+			wanted: `Name                Image
+----                -----
+fe                  location
+`,
+		},
+		"fail to interpolate an undefined variable": {
+			inEnv: "prod",
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListWorkloads().Return([]string{"fe"}, nil)
+				m.EXPECT().ReadWorkloadManifest("fe").Return(workspace.WorkloadManifest(overriddenMft), nil)
+			},
+			wantedErr: `interpolate environment variables for fe manifest: environment variable "REGISTRY" is not defined`,
+		},
+		"reports a mix of built and pulled images": {
+			mockWs: func(m *mocks.MockwsWlDirReader) {
+				m.EXPECT().ListWorkloads().Return([]string{"fe", "api", "mailer"}, nil)
+				m.EXPECT().ReadWorkloadManifest("fe").Return(workspace.WorkloadManifest(buildMft), nil)
+				m.EXPECT().ReadWorkloadManifest("api").Return(workspace.WorkloadManifest(locationMft), nil)
+				m.EXPECT().ReadWorkloadManifest("mailer").Return(workspace.WorkloadManifest(jobMft), nil)
+			},
+			wanted: `Name                Image
+----                -----
+fe                  build
+api                 location
+mailer              build
+`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWs := mocks.NewMockwsWlDirReader(ctrl)
+			tc.mockWs(mockWs)
+			if tc.setEnv != nil {
+				tc.setEnv(t)
+			}
+			b := &bytes.Buffer{}
+			opts := &deployOpts{
+				deployWkldVars: deployWkldVars{
+					appName: "phonetool",
+					name:    tc.inName,
+					envName: tc.inEnv,
+				},
+				dryRun: true,
+				ws:     mockWs,
+				newInterpolator: func(app, env string) interpolator {
+					return manifest.NewInterpolator(app, env)
+				},
+				unmarshal: manifest.UnmarshalWorkload,
+				w:         b,
+			}
+
+			// WHEN
+			err := opts.Run()
+
+			// THEN
+			if tc.wantedErr != "" {
+				require.EqualError(t, err, tc.wantedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, b.String())
+		})
+	}
+}
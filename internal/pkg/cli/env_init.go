@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
 	"github.com/aws/copilot-cli/internal/pkg/aws/ec2"
@@ -69,6 +70,18 @@ https://aws.github.io/copilot-cli/docs/credentials/#environment-credentials`
 	fmtAddEnvToAppComplete   = "Linked account %s and region %s to application %s.\n\n"
 )
 
+// GitHub Actions OIDC provider.
+// See https://docs.github.com/en/actions/deployment/security-hardening-your-deployments/configuring-openid-connect-in-amazon-web-services
+const (
+	githubOIDCProviderURL      = "https://token.actions.githubusercontent.com"
+	githubOIDCAudience         = "sts.amazonaws.com"
+	fmtGitHubDeployRoleName    = "%s-GitHubDeployRole"
+	fmtGitHubDeployRoleCreated = "GitHub Actions workflows of %s on branches matching %s can deploy to environment %s by assuming role %s.\n"
+)
+
+// githubOIDCThumbprints are the SHA-1 fingerprints of the certificates of GitHub's OIDC provider.
+var githubOIDCThumbprints = []string{"6938fd4d98bab03faadb97b34396831e3780aea1", "1c58a3a8518e8759bf075b76b750d4f2df264fcd"}
+
 var (
 	envInitAppNamePrompt                  = fmt.Sprintf("In which %s would you like to create the environment?", color.Emphasize("application"))
 	envInitDefaultConfigSelectOption      = "Yes, use default."
@@ -103,6 +116,15 @@ func (v adjustVPCVars) isSet() bool {
 	return len(v.PublicSubnetCIDRs) != 0 || len(v.PrivateSubnetCIDRs) != 0
 }
 
+type githubDeployVars struct {
+	Repository string // Repository of the GitHub Actions workflows, of the form owner/repo.
+	Branch     string // Branch name or pattern of the workflows allowed to assume the deploy role.
+}
+
+func (v githubDeployVars) isSet() bool {
+	return v.Repository != "" || v.Branch != ""
+}
+
 type tempCredsVars struct {
 	AccessKeyID     string
 	SecretAccessKey string
@@ -123,6 +145,8 @@ type initEnvVars struct {
 	importVPC importVPCVars // Existing VPC resources to use instead of creating new ones.
 	adjustVPC adjustVPCVars // Configure parameters for VPC resources generated while initializing an environment.
 
+	githubDeploy githubDeployVars // Optional GitHub repository and branch whose workflows can deploy to the environment.
+
 	tempCreds tempCredsVars // Temporary credentials to initialize the environment. Mutually exclusive with the profile.
 	region    string        // The region to create the environment in.
 }
@@ -208,6 +232,9 @@ func (o *initEnvOpts) Validate() error {
 	if err := o.validateCustomizedResources(); err != nil {
 		return err
 	}
+	if err := o.validateGitHubDeploy(); err != nil {
+		return err
+	}
 	return o.validateCredentials()
 }
 
@@ -253,7 +280,15 @@ func (o *initEnvOpts) Execute() error {
 	// If the call fails because the user doesn't have permissions, then the role must be created outside of Copilot.
 	_ = o.iam.CreateECSServiceLinkedRole()
 
-	// 2. Add the stack set instance to the app stackset.
+	// 2. Create the OIDC provider that the GitHub deploy role trusts if the account doesn't have it.
+	// The provider is shared by every environment in the account, so it isn't part of the environment stack.
+	if o.githubDeploy.isSet() {
+		if _, err := o.iam.CreateOIDCProviderIfNotExists(githubOIDCProviderURL, []string{githubOIDCAudience}, githubOIDCThumbprints); err != nil {
+			return fmt.Errorf("create GitHub OIDC provider: %w", err)
+		}
+	}
+
+	// 3. Add the stack set instance to the app stackset.
 	if err := o.addToStackset(&deploycfn.AddEnvToAppOpts{
 		App:          app,
 		EnvName:      o.name,
@@ -263,7 +298,7 @@ func (o *initEnvOpts) Execute() error {
 		return err
 	}
 
-	// 3. Upload environment custom resource scripts to the S3 bucket, because of the 4096 characters limit (see
+	// 4. Upload environment custom resource scripts to the S3 bucket, because of the 4096 characters limit (see
 	// https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-properties-lambda-function-code.html#cfn-lambda-function-code-zipfile)
 	envRegion := aws.StringValue(o.sess.Config.Region)
 	resources, err := o.appCFN.GetAppResourcesByRegion(app, envRegion)
@@ -281,25 +316,33 @@ func (o *initEnvOpts) Execute() error {
 		return fmt.Errorf("upload custom resources to bucket %s: %w", resources.S3Bucket, err)
 	}
 
-	// 4. Start creating the CloudFormation stack for the environment.
+	// 5. Start creating the CloudFormation stack for the environment.
 	if err := o.deployEnv(app, urls); err != nil {
 		return err
 	}
 
-	// 5. Get the environment
+	// 6. Get the environment
 	env, err := o.envDeployer.GetEnvironment(o.appName, o.name)
 	if err != nil {
 		return fmt.Errorf("get environment struct for %s: %w", o.name, err)
 	}
 	env.Prod = o.isProduction
-	env.CustomConfig = config.NewCustomizeEnv(o.importVPCConfig(), o.adjustVPCConfig())
+	env.CustomConfig = config.NewCustomizeEnv(o.importVPCConfig(), o.adjustVPCConfig(), o.githubOIDCConfig())
 
-	// 6. Store the environment in SSM.
+	// 7. Store the environment in SSM.
 	if err := o.store.CreateEnvironment(env); err != nil {
 		return fmt.Errorf("store environment: %w", err)
 	}
 	log.Successf("Created environment %s in region %s under application %s.\n",
 		color.HighlightUserInput(env.Name), color.Emphasize(env.Region), color.HighlightUserInput(env.App))
+	if o.githubDeploy.isSet() {
+		roleARN, err := githubDeployRoleARN(envCaller.RootUserARN, o.appName, o.name)
+		if err != nil {
+			return err
+		}
+		log.Infof(fmtGitHubDeployRoleCreated, color.HighlightUserInput(o.githubDeploy.Repository),
+			color.HighlightUserInput(o.githubDeploy.Branch), color.HighlightUserInput(o.name), color.HighlightResource(roleARN))
+	}
 	return nil
 }
 
@@ -343,6 +386,25 @@ func (o *initEnvOpts) validateCustomizedResources() error {
 	return nil
 }
 
+func (o *initEnvOpts) validateGitHubDeploy() error {
+	if !o.githubDeploy.isSet() {
+		return nil
+	}
+	if o.githubDeploy.Repository == "" {
+		return fmt.Errorf("--%s must be specified with --%s", githubDeployRepoFlag, githubDeployBranchFlag)
+	}
+	if o.githubDeploy.Branch == "" {
+		return fmt.Errorf("--%s must be specified with --%s", githubDeployBranchFlag, githubDeployRepoFlag)
+	}
+	if err := validateGitHubRepoSlug(o.githubDeploy.Repository); err != nil {
+		return fmt.Errorf("repository %s is invalid: %w", o.githubDeploy.Repository, err)
+	}
+	if err := validateGitHubBranchGlob(o.githubDeploy.Branch); err != nil {
+		return fmt.Errorf("branch %s is invalid: %w", o.githubDeploy.Branch, err)
+	}
+	return nil
+}
+
 func (o *initEnvOpts) askAppName() error {
 	if o.appName != "" {
 		return nil
@@ -587,6 +649,30 @@ func (o *initEnvOpts) adjustVPCConfig() *config.AdjustVPC {
 	}
 }
 
+func (o *initEnvOpts) githubOIDCConfig() *config.GitHubOIDC {
+	if !o.githubDeploy.isSet() {
+		return nil
+	}
+	return &config.GitHubOIDC{
+		Repository: o.githubDeploy.Repository,
+		Branch:     o.githubDeploy.Branch,
+	}
+}
+
+// githubDeployRoleARN returns the ARN of the role that the environment stack creates for GitHub Actions workflows.
+func githubDeployRoleARN(rootUserARN, app, env string) (string, error) {
+	parsed, err := arn.Parse(rootUserARN)
+	if err != nil {
+		return "", fmt.Errorf("parse caller ARN %s: %w", rootUserARN, err)
+	}
+	return arn.ARN{
+		Partition: parsed.Partition,
+		Service:   "iam",
+		AccountID: parsed.AccountID,
+		Resource:  "role/" + fmt.Sprintf(fmtGitHubDeployRoleName, stack.NameForEnv(app, env)),
+	}.String(), nil
+}
+
 func (o *initEnvOpts) deployEnv(app *config.Application, customResourcesURLs map[string]string) error {
 	caller, err := o.identity.Get()
 	if err != nil {
@@ -604,6 +690,7 @@ func (o *initEnvOpts) deployEnv(app *config.Application, customResourcesURLs map
 		CustomResourcesURLs: customResourcesURLs,
 		AdjustVPCConfig:     o.adjustVPCConfig(),
 		ImportVPCConfig:     o.importVPCConfig(),
+		GitHubOIDCConfig:    o.githubOIDCConfig(),
 		Version:             deploy.LatestEnvTemplateVersion,
 	}
 
@@ -723,7 +810,10 @@ func buildEnvInitCmd() *cobra.Command {
   Creates an environment with overridden CIDRs.
   /code $ copilot env init --override-vpc-cidr 10.1.0.0/16 \
   /code --override-public-cidrs 10.1.0.0/24,10.1.1.0/24 \
-  /code --override-private-cidrs 10.1.2.0/24,10.1.3.0/24`,
+  /code --override-private-cidrs 10.1.2.0/24,10.1.3.0/24
+
+  Creates an environment with a role that GitHub Actions workflows on the main branch can deploy with.
+  /code $ copilot env init --name test --github-deploy-repo my-org/my-repo --github-deploy-branch main`,
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			opts, err := newInitEnvOpts(vars)
 			if err != nil {
@@ -752,6 +842,9 @@ func buildEnvInitCmd() *cobra.Command {
 	cmd.Flags().StringSliceVar(&vars.adjustVPC.PrivateSubnetCIDRs, privateSubnetCIDRsFlag, nil, privateSubnetCIDRsFlagDescription)
 	cmd.Flags().BoolVar(&vars.defaultConfig, defaultConfigFlag, false, defaultConfigFlagDescription)
 
+	cmd.Flags().StringVar(&vars.githubDeploy.Repository, githubDeployRepoFlag, "", githubDeployRepoFlagDescription)
+	cmd.Flags().StringVar(&vars.githubDeploy.Branch, githubDeployBranchFlag, "", githubDeployBranchFlagDescription)
+
 	flags := pflag.NewFlagSet("Common", pflag.ContinueOnError)
 	flags.AddFlag(cmd.Flags().Lookup(appFlag))
 	flags.AddFlag(cmd.Flags().Lookup(nameFlag))
@@ -762,6 +855,8 @@ func buildEnvInitCmd() *cobra.Command {
 	flags.AddFlag(cmd.Flags().Lookup(regionFlag))
 	flags.AddFlag(cmd.Flags().Lookup(defaultConfigFlag))
 	flags.AddFlag(cmd.Flags().Lookup(prodEnvFlag))
+	flags.AddFlag(cmd.Flags().Lookup(githubDeployRepoFlag))
+	flags.AddFlag(cmd.Flags().Lookup(githubDeployBranchFlag))
 
 	resourcesImportFlag := pflag.NewFlagSet("Import Existing Resources", pflag.ContinueOnError)
 	resourcesImportFlag.AddFlag(cmd.Flags().Lookup(vpcIDFlag))
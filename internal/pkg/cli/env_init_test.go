@@ -6,6 +6,7 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"testing"
 
@@ -48,6 +49,9 @@ func TestInitEnvOpts_Validate(t *testing.T) {
 		inVPCCIDR     net.IPNet
 		inPublicCIDRs []string
 
+		inGitHubRepo   string
+		inGitHubBranch string
+
 		inProfileName     string
 		inAccessKeyID     string
 		inSecretAccessKey string
@@ -159,6 +163,32 @@ func TestInitEnvOpts_Validate(t *testing.T) {
 			inPublicIDs:  []string{"mockID", "anotherMockID", "yetAnotherMockID"},
 			inPrivateIDs: []string{"mockID", "anotherMockID"},
 		},
+		"github deploy branch must be specified with the repository": {
+			inGitHubRepo: "my-org/my-repo",
+
+			wantedErrMsg: "--github-deploy-branch must be specified with --github-deploy-repo",
+		},
+		"github deploy repository must be specified with the branch": {
+			inGitHubBranch: "main",
+
+			wantedErrMsg: "--github-deploy-repo must be specified with --github-deploy-branch",
+		},
+		"invalid github deploy repository": {
+			inGitHubRepo:   "https://github.com/my-org/my-repo",
+			inGitHubBranch: "main",
+
+			wantedErrMsg: fmt.Sprintf("repository https://github.com/my-org/my-repo is invalid: %s", errGitHubRepoSlugBadFormat),
+		},
+		"invalid github deploy branch": {
+			inGitHubRepo:   "my-org/my-repo",
+			inGitHubBranch: "release/../main",
+
+			wantedErrMsg: fmt.Sprintf("branch release/../main is invalid: %s", errGitHubBranchGlobBadFormat),
+		},
+		"valid github deploy repository and branch pattern": {
+			inGitHubRepo:   "my-org/my.repo",
+			inGitHubBranch: "release/*",
+		},
 	}
 
 	for name, tc := range testCases {
@@ -186,6 +216,10 @@ func TestInitEnvOpts_Validate(t *testing.T) {
 						PrivateSubnetIDs: tc.inPrivateIDs,
 						ID:               tc.inVPCID,
 					},
+					githubDeploy: githubDeployVars{
+						Repository: tc.inGitHubRepo,
+						Branch:     tc.inGitHubBranch,
+					},
 					appName: tc.inAppName,
 					profile: tc.inProfileName,
 					tempCreds: tempCredsVars{
@@ -736,7 +770,8 @@ func TestInitEnvOpts_Ask(t *testing.T) {
 
 func TestInitEnvOpts_Execute(t *testing.T) {
 	testCases := map[string]struct {
-		inProd bool
+		inProd         bool
+		inGitHubDeploy githubDeployVars
 
 		expectStore             func(m *mocks.Mockstore)
 		expectDeployer          func(m *mocks.Mockdeployer)
@@ -1052,6 +1087,87 @@ func TestInitEnvOpts_Execute(t *testing.T) {
 			},
 			wantedErrorS: "granting DNS permissions: some error",
 		},
+		"returns error if the GitHub OIDC provider can't be created": {
+			inGitHubDeploy: githubDeployVars{
+				Repository: "my-org/my-repo",
+				Branch:     "main",
+			},
+			expectStore: func(m *mocks.Mockstore) {
+				m.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+			},
+			expectIdentity: func(m *mocks.MockidentityService) {
+				m.EXPECT().Get().Return(identity.Caller{RootUserARN: "arn:aws:iam::1234:root", Account: "1234"}, nil)
+			},
+			expectIAM: func(m *mocks.MockroleManager) {
+				m.EXPECT().CreateECSServiceLinkedRole().Return(nil)
+				m.EXPECT().CreateOIDCProviderIfNotExists(githubOIDCProviderURL, []string{githubOIDCAudience}, githubOIDCThumbprints).
+					Return("", errors.New("some error"))
+			},
+			wantedErrorS: "create GitHub OIDC provider: some error",
+		},
+		"success with a GitHub deploy role": {
+			inGitHubDeploy: githubDeployVars{
+				Repository: "my-org/my-repo",
+				Branch:     "main",
+			},
+			expectStore: func(m *mocks.Mockstore) {
+				m.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+				m.EXPECT().CreateEnvironment(&config.Environment{
+					App:       "phonetool",
+					Name:      "test",
+					AccountID: "1234",
+					Region:    "us-west-2",
+					CustomConfig: &config.CustomizeEnv{
+						GitHubOIDC: &config.GitHubOIDC{
+							Repository: "my-org/my-repo",
+							Branch:     "main",
+						},
+					},
+				}).Return(nil)
+			},
+			expectIdentity: func(m *mocks.MockidentityService) {
+				m.EXPECT().Get().Return(identity.Caller{RootUserARN: "arn:aws:iam::1234:root", Account: "1234"}, nil).Times(2)
+			},
+			expectIAM: func(m *mocks.MockroleManager) {
+				m.EXPECT().CreateECSServiceLinkedRole().Return(nil)
+				m.EXPECT().CreateOIDCProviderIfNotExists(githubOIDCProviderURL, []string{githubOIDCAudience}, githubOIDCThumbprints).
+					Return("arn:aws:iam::1234:oidc-provider/token.actions.githubusercontent.com", nil)
+				m.EXPECT().ListRoleTags(gomock.Any()).
+					Return(nil, errors.New("does not exist")).AnyTimes()
+			},
+			expectCFN: func(m *mocks.MockstackExistChecker) {
+				m.EXPECT().Exists("phonetool-test").Return(false, nil)
+			},
+			expectProgress: func(m *mocks.Mockprogress) {
+				m.EXPECT().Start(fmt.Sprintf(fmtAddEnvToAppStart, "1234", "us-west-2", "phonetool"))
+				m.EXPECT().Stop(log.Ssuccessf(fmtAddEnvToAppComplete, "1234", "us-west-2", "phonetool"))
+			},
+			expectDeployer: func(m *mocks.Mockdeployer) {
+				m.EXPECT().AddEnvToApp(gomock.Any()).Return(nil)
+				m.EXPECT().DeployAndRenderEnvironment(gomock.Any(), gomock.Any()).DoAndReturn(func(_ io.Writer, in *deploy.CreateEnvironmentInput) error {
+					require.Equal(t, &config.GitHubOIDC{
+						Repository: "my-org/my-repo",
+						Branch:     "main",
+					}, in.GitHubOIDCConfig)
+					return nil
+				})
+				m.EXPECT().GetEnvironment("phonetool", "test").Return(&config.Environment{
+					AccountID: "1234",
+					Region:    "us-west-2",
+					Name:      "test",
+					App:       "phonetool",
+				}, nil)
+			},
+			expectAppCFN: func(m *mocks.MockappResourcesGetter) {
+				m.EXPECT().GetAppResourcesByRegion(&config.Application{Name: "phonetool"}, "us-west-2").
+					Return(&stack.AppRegionalResources{
+						S3Bucket: "mockBucket",
+					}, nil)
+			},
+			expectResourcesUploader: func(m *mocks.MockcustomResourcesUploader) {
+				m.EXPECT().UploadEnvironmentCustomResources(gomock.Any()).Return(nil, nil)
+			},
+		},
 		"success with DNS Delegation (app has Domain and env and app are different)": {
 			expectStore: func(m *mocks.Mockstore) {
 				m.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool", AccountID: "1234", Domain: "amazon.com"}, nil)
@@ -1154,6 +1270,7 @@ func TestInitEnvOpts_Execute(t *testing.T) {
 					name:         "test",
 					appName:      "phonetool",
 					isProduction: tc.inProd,
+					githubDeploy: tc.inGitHubDeploy,
 				},
 				store:       mockStore,
 				envDeployer: mockDeployer,
@@ -266,6 +266,10 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 							ImportVPC: &config.ImportVPC{
 								ID: "abc",
 							},
+							GitHubOIDC: &config.GitHubOIDC{
+								Repository: "my-org/my-repo",
+								Branch:     "main",
+							},
 						},
 					}, nil)
 				mockStore.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
@@ -287,6 +291,10 @@ func TestEnvUpgradeOpts_Execute(t *testing.T) {
 					ImportVPCConfig: &config.ImportVPC{
 						ID: "abc",
 					},
+					GitHubOIDCConfig: &config.GitHubOIDC{
+						Repository: "my-org/my-repo",
+						Branch:     "main",
+					},
 					CFNServiceRoleARN:   "execARN",
 					CustomResourcesURLs: map[string]string{"mockCustomResource": "mockURL"},
 				}).Return(nil)
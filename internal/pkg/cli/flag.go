@@ -38,6 +38,8 @@ const (
 	sinceFlag             = "since"
 	startTimeFlag         = "start-time"
 	endTimeFlag           = "end-time"
+	dryRunFlag            = "dry-run"
+	disableRollbackFlag   = "disable-rollback"
 	tasksFlag             = "tasks"
 	logGroupFlag          = "log-group"
 	prodEnvFlag           = "prod"
@@ -52,6 +54,10 @@ const (
 	localFlag             = "local"
 	deleteSecretFlag      = "delete-secret"
 	svcPortFlag           = "port"
+	enableExecFlag        = "enable"
+	disableExecFlag       = "disable"
+	policyFlag            = "policy"
+	requirePlacementFlag  = "require-placement"
 
 	noSubscriptionFlag  = "no-subscribe"
 	subscribeTopicsFlag = "subscribe-topics"
@@ -95,6 +101,9 @@ const (
 
 	defaultConfigFlag = "default-config"
 
+	githubDeployRepoFlag   = "github-deploy-repo"
+	githubDeployBranchFlag = "github-deploy-branch"
+
 	accessKeyIDFlag     = "aws-access-key-id"
 	secretAccessKeyFlag = "aws-secret-access-key"
 	sessionTokenFlag    = "aws-session-token"
@@ -194,12 +203,26 @@ const (
 	execYesFlagDescription  = "Optional. Whether to update the Session Manager Plugin."
 	jsonFlagDescription     = "Optional. Outputs in JSON format."
 	forceFlagDescription    = "Optional. Force a new service deployment using the existing image."
+	dryRunFlagDescription   = "Optional. List whether each workload's image would be built or pulled, without deploying."
+
+	disableRollbackFlagDescription = `Optional. Preserve the successfully provisioned resources
+if the stack deployment fails, instead of rolling them back.`
+	stackTimeoutFlagDescription = `Optional. How long the stack deployment can take, like 30m or 2h.
+An update that runs longer is canceled and rolled back, unless
+--disable-rollback is set. The creation of a new stack can't be
+canceled and keeps going. Defaults to 1h30m.`
 
 	imageTagFlagDescription     = `Optional. The container image tag.`
 	resourceTagsFlagDescription = `Optional. Labels with a key and value separated by commas.
 Allows you to categorize resources.`
 	stackOutputDirFlagDescription = "Optional. Writes the stack template and template configuration to a directory."
 	prodEnvFlagDescription        = "If the environment contains production services."
+	enableExecFlagDescription     = "Optional. Turn on ECS Exec for the service."
+	disableExecFlagDescription    = "Optional. Turn off ECS Exec for the service."
+	policyFlagDescription         = `Optional. Path to an organization policy file.
+The deployment fails if the service violates any of its rules.`
+	requirePlacementFlagDescription = `Optional. Fail if the manifest doesn't specify "network.vpc.placement"
+instead of placing the tasks in public subnets.`
 
 	limitFlagDescription = `Optional. The maximum number of log events returned. Default is 10
 unless any time filtering flags are set.`
@@ -275,6 +298,11 @@ Cannot be specified with any other flags.`
 
 	defaultConfigFlagDescription = "Optional. Skip prompting and use default environment configuration."
 
+	githubDeployRepoFlagDescription = `Optional. GitHub repository, of the form owner/repo, whose GitHub Actions workflows
+can assume an IAM role to deploy to the environment.`
+	githubDeployBranchFlagDescription = `Optional. Branch name or pattern, such as "main" or "release/*",
+of the workflows that can assume the deploy role.`
+
 	accessKeyIDFlagDescription     = "Optional. An AWS access key."
 	secretAccessKeyFlagDescription = "Optional. An AWS secret access key."
 	sessionTokenFlagDescription    = "Optional. An AWS session token for temporary credentials."
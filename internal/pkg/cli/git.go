@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/aws/copilot-cli/internal/pkg/exec"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
 )
 
 func describeGitChanges(r runner) (string, error) {
@@ -20,6 +22,15 @@ func describeGitChanges(r runner) (string, error) {
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+func gitCommitSHA(r runner) (string, error) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	if err := r.Run("git", []string{"rev-parse", "HEAD"}, exec.Stdout(&stdout), exec.Stderr(&stderr)); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
 func hasUncommitedGitChanges(r runner) (bool, error) {
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -47,3 +58,28 @@ func imageTagFromGit(r runner, userTag string) string {
 	}
 	return commit
 }
+
+// gitSHATags returns the tag holding the git commit SHA to deploy the workload with if its manifest enables "git_sha_tag".
+// If the commit can't be read, for example outside a git repository, the tag is skipped with a warning.
+func gitSHATags(r runner, mft interface{}) map[string]string {
+	type gitSHATagger interface {
+		GitSHATagConfig() manifest.GitSHATag
+	}
+	tagger, ok := mft.(gitSHATagger)
+	if !ok {
+		return nil
+	}
+	cfg := tagger.GitSHATagConfig()
+	key, enabled := cfg.TagKey()
+	if !enabled {
+		return nil
+	}
+	sha, err := gitCommitSHA(r)
+	if err != nil || sha == "" {
+		log.Warningf("Skip tagging with %q since the git commit SHA can't be read; is the workspace a git repository?\n", key)
+		return nil
+	}
+	return map[string]string{
+		key: sha,
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	osexec "os/exec"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
+	"github.com/aws/copilot-cli/internal/pkg/exec"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitSHATags(t *testing.T) {
+	const mockSHA = "4e5c4d1f6b0c2a0e8f3b5d7c9a1e2f3a4b5c6d7e"
+	resolvesSHA := func(m *mocks.Mockrunner) {
+		m.EXPECT().Run("git", []string{"rev-parse", "HEAD"}, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(name string, args []string, opts ...exec.CmdOption) error {
+				cmd := &osexec.Cmd{}
+				for _, opt := range opts {
+					opt(cmd)
+				}
+				_, err := cmd.Stdout.Write([]byte(mockSHA + "\n"))
+				return err
+			})
+	}
+	testCases := map[string]struct {
+		mft        interface{}
+		setupMocks func(m *mocks.Mockrunner)
+
+		wanted map[string]string
+	}{
+		"no tags if the workload doesn't support the option": {
+			mft:        &manifest.RequestDrivenWebService{},
+			setupMocks: func(m *mocks.Mockrunner) {},
+		},
+		"no tags if the option isn't set": {
+			mft:        &manifest.BackendService{},
+			setupMocks: func(m *mocks.Mockrunner) {},
+		},
+		"no tags if the option is disabled": {
+			mft: &manifest.BackendService{
+				BackendServiceConfig: manifest.BackendServiceConfig{
+					GitSHATag: manifest.GitSHATag{Enabled: aws.Bool(false)},
+				},
+			},
+			setupMocks: func(m *mocks.Mockrunner) {},
+		},
+		"default tag key": {
+			mft: &manifest.BackendService{
+				BackendServiceConfig: manifest.BackendServiceConfig{
+					GitSHATag: manifest.GitSHATag{Enabled: aws.Bool(true)},
+				},
+			},
+			setupMocks: resolvesSHA,
+
+			wanted: map[string]string{
+				"copilot-git-sha": mockSHA,
+			},
+		},
+		"user-named tag key": {
+			mft: &manifest.LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: manifest.LoadBalancedWebServiceConfig{
+					GitSHATag: manifest.GitSHATag{Key: aws.String("build-sha")},
+				},
+			},
+			setupMocks: resolvesSHA,
+
+			wanted: map[string]string{
+				"build-sha": mockSHA,
+			},
+		},
+		"no tags outside of a git repository": {
+			mft: &manifest.WorkerService{
+				WorkerServiceConfig: manifest.WorkerServiceConfig{
+					GitSHATag: manifest.GitSHATag{Enabled: aws.Bool(true)},
+				},
+			},
+			setupMocks: func(m *mocks.Mockrunner) {
+				m.EXPECT().Run("git", []string{"rev-parse", "HEAD"}, gomock.Any(), gomock.Any()).
+					Return(errors.New("fatal: not a git repository (or any of the parent directories): .git"))
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRunner := mocks.NewMockrunner(ctrl)
+			tc.setupMocks(mockRunner)
+
+			// WHEN
+			got := gitSHATags(mockRunner, tc.mft)
+
+			// THEN
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
@@ -6,6 +6,7 @@ package cli
 import (
 	"encoding"
 	"io"
+	"time"
 
 	"github.com/aws/copilot-cli/internal/pkg/aws/ec2"
 
@@ -17,6 +18,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	awscloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/aws/codedeploy"
 	"github.com/aws/copilot-cli/internal/pkg/aws/codepipeline"
 	awsecs "github.com/aws/copilot-cli/internal/pkg/aws/ecs"
 	"github.com/aws/copilot-cli/internal/pkg/aws/s3"
@@ -162,7 +164,7 @@ type secretDeleter interface {
 }
 
 type imageBuilderPusher interface {
-	BuildAndPush(docker repository.ContainerLoginBuildPusher, args *dockerengine.BuildArguments) (string, error)
+	BuildAndPush(docker repository.ContainerLoginBuildPusher, args ...*dockerengine.BuildArguments) (string, error)
 }
 
 type repositoryURIGetter interface {
@@ -390,6 +392,28 @@ type taskRunner interface {
 	Run() ([]*task.Task, error)
 }
 
+type hookRunner interface {
+	Run(in task.HookInput) error
+}
+
+type stackResourcesGetter interface {
+	Exists(name string) (bool, error)
+	StackResources(name string) ([]*awscloudformation.StackResource, error)
+}
+
+type stackDescriber interface {
+	Describe(name string) (*awscloudformation.StackDescription, error)
+}
+
+type blueGreenDeployer interface {
+	DeployECSService(in codedeploy.ECSDeployment) (string, error)
+	WaitUntilDeploymentSucceeded(id string, timeout time.Duration) error
+}
+
+type readinessWaiter interface {
+	Wait(url string, status int) error
+}
+
 type defaultClusterGetter interface {
 	HasDefaultCluster() (bool, error)
 }
@@ -530,6 +554,7 @@ type ec2Client interface {
 
 type vpcSubnetLister interface {
 	ListVPCSubnets(vpcID string) (*ec2.VPCSubnets, error)
+	SubnetIDs(filters ...ec2.Filter) ([]string, error)
 }
 
 type serviceResumer interface {
@@ -550,12 +575,17 @@ type roleDeleter interface {
 
 type serviceDescriber interface {
 	DescribeService(app, env, svc string) (*ecs.ServiceDesc, error)
+	TaskDefinition(app, env, svc string) (*awsecs.TaskDefinition, error)
 }
 
 type serviceUpdater interface {
 	ForceUpdateService(app, env, svc string) error
 }
 
+type serviceExecUpdater interface {
+	UpdateServiceExecuteCommand(app, env, svc string, enable bool) error
+}
+
 type serviceDeployer interface {
 	DeployService(out termprogress.FileWriter, conf cloudformation.StackConfiguration, opts ...awscloudformation.StackOption) error
 }
@@ -587,10 +617,15 @@ type roleTagsLister interface {
 	ListRoleTags(string) (map[string]string, error)
 }
 
+type oidcProviderCreator interface {
+	CreateOIDCProviderIfNotExists(url string, audiences, thumbprints []string) (string, error)
+}
+
 type roleManager interface {
 	roleTagsLister
 	roleDeleter
 	serviceLinkedRoleCreator
+	oidcProviderCreator
 }
 
 type stackExistChecker interface {
@@ -19,7 +19,7 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/repository"
 	"github.com/aws/copilot-cli/internal/pkg/term/log"
 
-	awscloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/aws/ec2"
 	"github.com/aws/copilot-cli/internal/pkg/aws/ecr"
 	"github.com/aws/copilot-cli/internal/pkg/aws/s3"
 	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
@@ -52,6 +52,9 @@ type deployJobOpts struct {
 	s3                 artifactUploader
 	envUpgradeCmd      actionCommand
 	endpointGetter     endpointGetter
+	envDescriber       envDescriber
+	subnetLister       vpcSubnetLister
+	dockerEngine       dockerEngine
 
 	spinner progress
 	sel     wsSelector
@@ -90,6 +93,7 @@ func newJobDeployOpts(vars deployWkldVars) (*deployJobOpts, error) {
 		cmd:             exec.NewCmd(),
 		sessProvider:    sessions.NewProvider(),
 		newInterpolator: newManifestInterpolator,
+		dockerEngine:    dockerengine.New(exec.NewCmd()),
 	}, nil
 }
 
@@ -108,7 +112,7 @@ func (o *deployJobOpts) Validate() error {
 			return err
 		}
 	}
-	return nil
+	return validateStackTimeout(o.stackTimeout)
 }
 
 // Ask prompts the user for any required fields that are not provided.
@@ -147,6 +151,14 @@ func (o *deployJobOpts) Execute() error {
 		return err
 	}
 
+	mft, err := o.manifest()
+	if err != nil {
+		return err
+	}
+	if err := manifest.ValidatePlatformInRegion(mft, o.targetEnvironment.Region); err != nil {
+		return fmt.Errorf("validate manifest against environment %s: %w", o.envName, err)
+	}
+
 	if err := o.envUpgradeCmd.Execute(); err != nil {
 		return fmt.Errorf(`execute "env upgrade --app %s --name %s": %v`, o.appName, o.targetEnvironment.Name, err)
 	}
@@ -212,7 +224,7 @@ func (o *deployJobOpts) configureClients() error {
 
 	// CF client against env account profile AND target environment region
 	o.jobCFN = cloudformation.New(envSession)
-	o.endpointGetter, err = describe.NewEnvDescriber(describe.NewEnvDescriberConfig{
+	d, err := describe.NewEnvDescriber(describe.NewEnvDescriberConfig{
 		App:         o.appName,
 		Env:         o.envName,
 		ConfigStore: o.store,
@@ -220,6 +232,9 @@ func (o *deployJobOpts) configureClients() error {
 	if err != nil {
 		return fmt.Errorf("initiate environment describer: %w", err)
 	}
+	o.endpointGetter = d
+	o.envDescriber = d
+	o.subnetLister = ec2.New(envSession)
 
 	addonsSvc, err := addon.New(o.name)
 	if err != nil {
@@ -258,11 +273,11 @@ func (o *deployJobOpts) configureContainerImage() error {
 		return nil
 	}
 	// If it is built from local Dockerfile, build and push to the ECR repo.
-	buildArg, err := o.dfBuildArgs(job)
+	buildArgs, err := o.dfBuildArgs(job)
 	if err != nil {
 		return err
 	}
-	digest, err := o.imageBuilderPusher.BuildAndPush(dockerengine.New(exec.NewCmd()), buildArg)
+	digest, err := o.imageBuilderPusher.BuildAndPush(dockerengine.New(exec.NewCmd()), buildArgs...)
 	if err != nil {
 		return fmt.Errorf("build and push image: %w", err)
 	}
@@ -271,12 +286,16 @@ func (o *deployJobOpts) configureContainerImage() error {
 	return nil
 }
 
-func (o *deployJobOpts) dfBuildArgs(job interface{}) (*dockerengine.BuildArguments, error) {
+func (o *deployJobOpts) dfBuildArgs(job interface{}) ([]*dockerengine.BuildArguments, error) {
 	copilotDir, err := o.ws.CopilotDirPath()
 	if err != nil {
 		return nil, fmt.Errorf("get copilot directory: %w", err)
 	}
-	return buildArgs(o.name, o.imageTag, copilotDir, job)
+	hostPlatform, err := enginePlatform(o.dockerEngine)
+	if err != nil {
+		return nil, err
+	}
+	return buildArgs(o.name, o.envName, o.imageTag, copilotDir, hostPlatform, job)
 }
 
 func (o *deployJobOpts) deployJob(addonsURL string) error {
@@ -284,7 +303,7 @@ func (o *deployJobOpts) deployJob(addonsURL string) error {
 	if err != nil {
 		return err
 	}
-	if err := o.jobCFN.DeployService(os.Stderr, conf, awscloudformation.WithRoleARN(o.targetEnvironment.ExecutionRoleARN)); err != nil {
+	if err := o.jobCFN.DeployService(os.Stderr, conf, o.stackOpts(o.targetEnvironment.ExecutionRoleARN)...); err != nil {
 		return fmt.Errorf("deploy job: %w", err)
 	}
 	log.Successf("Deployed %s.\n", color.HighlightUserInput(o.name))
@@ -296,7 +315,7 @@ func (o *deployJobOpts) stackConfiguration(addonsURL string) (cloudformation.Sta
 	if err != nil {
 		return nil, err
 	}
-	rc, err := o.runtimeConfig(addonsURL)
+	rc, err := o.runtimeConfig(mft, addonsURL)
 	if err != nil {
 		return nil, err
 	}
@@ -313,16 +332,21 @@ func (o *deployJobOpts) stackConfiguration(addonsURL string) (cloudformation.Sta
 	return conf, nil
 }
 
-func (o *deployJobOpts) runtimeConfig(addonsURL string) (*stack.RuntimeConfig, error) {
+func (o *deployJobOpts) runtimeConfig(mft interface{}, addonsURL string) (*stack.RuntimeConfig, error) {
 	endpoint, err := o.endpointGetter.ServiceDiscoveryEndpoint()
 	if err != nil {
 		return nil, err
 	}
+	subnetIDs, err := subnetsFromTags(o.envDescriber, o.subnetLister, mft)
+	if err != nil {
+		return nil, err
+	}
 	if !o.buildRequired {
 		return &stack.RuntimeConfig{
 			AddonsTemplateURL:        addonsURL,
 			AdditionalTags:           tags.Merge(o.targetApp.Tags, o.resourceTags),
 			ServiceDiscoveryEndpoint: endpoint,
+			SubnetIDs:                subnetIDs,
 			AccountID:                o.targetEnvironment.AccountID,
 			Region:                   o.targetEnvironment.Region,
 		}, nil
@@ -348,6 +372,7 @@ func (o *deployJobOpts) runtimeConfig(addonsURL string) (*stack.RuntimeConfig, e
 		AddonsTemplateURL:        addonsURL,
 		AdditionalTags:           tags.Merge(o.targetApp.Tags, o.resourceTags),
 		ServiceDiscoveryEndpoint: endpoint,
+		SubnetIDs:                subnetIDs,
 		AccountID:                o.targetEnvironment.AccountID,
 		Region:                   o.targetEnvironment.Region,
 	}, nil
@@ -358,6 +383,10 @@ func (o *deployJobOpts) manifest() (interface{}, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read job %s manifest: %w", o.name, err)
 	}
+	raw, err = manifest.Load(workloadManifestPath(o.name), raw, workspaceFileReader(o.ws))
+	if err != nil {
+		return nil, fmt.Errorf("load job %s manifest: %w", o.name, err)
+	}
 	interpolated, err := o.newInterpolator(o.appName, o.envName).Interpolate(string(raw))
 	if err != nil {
 		return nil, fmt.Errorf("interpolate environment variables for %s manifest: %w", o.name, err)
@@ -370,6 +399,9 @@ func (o *deployJobOpts) manifest() (interface{}, error) {
 	if err != nil {
 		return nil, fmt.Errorf("apply environment %s override: %s", o.envName, err)
 	}
+	if err := manifest.LoadWorkloadVariables(envMft, workspaceFileReader(o.ws)); err != nil {
+		return nil, fmt.Errorf("load variables for %s manifest: %w", o.name, err)
+	}
 	if err := envMft.Validate(); err != nil {
 		return nil, fmt.Errorf("validate manifest against environment %s: %s", o.envName, err)
 	}
@@ -452,6 +484,8 @@ func buildJobDeployCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&vars.envName, envFlag, envFlagShort, "", envFlagDescription)
 	cmd.Flags().StringVar(&vars.imageTag, imageTagFlag, "", imageTagFlagDescription)
 	cmd.Flags().StringToStringVar(&vars.resourceTags, resourceTagsFlag, nil, resourceTagsFlagDescription)
+	cmd.Flags().BoolVar(&vars.disableRollback, disableRollbackFlag, false, disableRollbackFlagDescription)
+	cmd.Flags().DurationVar(&vars.stackTimeout, timeoutFlag, 0, stackTimeoutFlagDescription)
 
 	return cmd
 }
@@ -22,6 +22,7 @@ type deployJobMocks struct {
 	mockWs                 *mocks.MockwsJobDirReader
 	mockimageBuilderPusher *mocks.MockimageBuilderPusher
 	mockInterpolator       *mocks.Mockinterpolator
+	mockDockerEngine       *mocks.MockdockerEngine
 }
 
 func TestJobDeployOpts_Validate(t *testing.T) {
@@ -280,6 +281,7 @@ on:
 					m.mockWs.EXPECT().ReadWorkloadManifest("mailer").Return(mockManifest, nil),
 					m.mockInterpolator.EXPECT().Interpolate(string(mockManifest)).Return(string(mockManifest), nil),
 					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("linux", "amd64", nil),
 					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), gomock.Any()).Return("", mockError),
 				)
 			},
@@ -292,6 +294,7 @@ on:
 					m.mockWs.EXPECT().ReadWorkloadManifest("mailer").Return(mockManifest, nil),
 					m.mockInterpolator.EXPECT().Interpolate(string(mockManifest)).Return(string(mockManifest), nil),
 					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("linux", "amd64", nil),
 					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
 						Dockerfile: filepath.Join("/ws", "root", "path", "to", "Dockerfile"),
 						Context:    filepath.Join("/ws", "root", "path"),
@@ -307,6 +310,7 @@ on:
 					m.mockWs.EXPECT().ReadWorkloadManifest("mailer").Return(mockMftBuildString, nil),
 					m.mockInterpolator.EXPECT().Interpolate(string(mockMftBuildString)).Return(string(mockMftBuildString), nil),
 					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("linux", "amd64", nil),
 					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
 						Dockerfile: filepath.Join("/ws", "root", "path", "to", "Dockerfile"),
 						Context:    filepath.Join("/ws", "root", "path", "to"),
@@ -322,6 +326,7 @@ on:
 					m.mockWs.EXPECT().ReadWorkloadManifest("mailer").Return(mockMftNoContext, nil),
 					m.mockInterpolator.EXPECT().Interpolate(string(mockMftNoContext)).Return(string(mockMftNoContext), nil),
 					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("linux", "amd64", nil),
 					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
 						Dockerfile: filepath.Join("/ws", "root", "path", "to", "Dockerfile"),
 						Context:    filepath.Join("/ws", "root", "path", "to"),
@@ -340,10 +345,12 @@ on:
 			mockWorkspace := mocks.NewMockwsJobDirReader(ctrl)
 			mockimageBuilderPusher := mocks.NewMockimageBuilderPusher(ctrl)
 			mockInterpolator := mocks.NewMockinterpolator(ctrl)
+			mockDockerEngine := mocks.NewMockdockerEngine(ctrl)
 			mocks := deployJobMocks{
 				mockWs:                 mockWorkspace,
 				mockimageBuilderPusher: mockimageBuilderPusher,
 				mockInterpolator:       mockInterpolator,
+				mockDockerEngine:       mockDockerEngine,
 			}
 			test.setupMocks(mocks)
 			opts := deployJobOpts{
@@ -353,6 +360,7 @@ on:
 				unmarshal:          manifest.UnmarshalWorkload,
 				imageBuilderPusher: mockimageBuilderPusher,
 				ws:                 mockWorkspace,
+				dockerEngine:       mockDockerEngine,
 				newInterpolator: func(app, env string) interpolator {
 					return mockInterpolator
 				},
@@ -11,8 +11,10 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/describe"
 	"github.com/aws/copilot-cli/internal/pkg/exec"
 
+	"github.com/aws/copilot-cli/internal/pkg/aws/ec2"
 	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
 	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/deploy"
 	"github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation"
 	"github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation/stack"
 	"github.com/aws/copilot-cli/internal/pkg/manifest"
@@ -61,6 +63,10 @@ func newPackageJobOpts(vars packageJobVars) (*packageJobOpts, error) {
 	if err != nil {
 		return nil, fmt.Errorf("connect to config store: %w", err)
 	}
+	deployStore, err := deploy.NewStore(store)
+	if err != nil {
+		return nil, fmt.Errorf("new deploy store: %w", err)
+	}
 	p := sessions.NewProvider()
 	sess, err := p.Default()
 	if err != nil {
@@ -117,6 +123,25 @@ func newPackageJobOpts(vars packageJobVars) (*packageJobOpts, error) {
 				}
 				return d, nil
 			},
+			newEnvDescriber: func(app, env string) (envDescriber, error) {
+				d, err := describe.NewEnvDescriber(describe.NewEnvDescriberConfig{
+					App:         app,
+					Env:         env,
+					ConfigStore: store,
+					DeployStore: deployStore,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("new env describer for environment %s in app %s: %v", env, app, err)
+				}
+				return d, nil
+			},
+			newSubnetLister: func(env *config.Environment) (vpcSubnetLister, error) {
+				envSess, err := p.FromRole(env.ManagerRoleARN, env.Region)
+				if err != nil {
+					return nil, fmt.Errorf("assume role for environment %s: %w", env.ManagerRoleARN, err)
+				}
+				return ec2.New(envSess), nil
+			},
 		}
 	}
 	return opts, nil
@@ -8,9 +8,11 @@ import (
 	encoding "encoding"
 	io "io"
 	reflect "reflect"
+	time "time"
 
 	session "github.com/aws/aws-sdk-go/aws/session"
 	cloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	codedeploy "github.com/aws/copilot-cli/internal/pkg/aws/codedeploy"
 	codepipeline "github.com/aws/copilot-cli/internal/pkg/aws/codepipeline"
 	ec2 "github.com/aws/copilot-cli/internal/pkg/aws/ec2"
 	ecs "github.com/aws/copilot-cli/internal/pkg/aws/ecs"
@@ -1478,18 +1480,23 @@ func (m *MockimageBuilderPusher) EXPECT() *MockimageBuilderPusherMockRecorder {
 }
 
 // BuildAndPush mocks base method.
-func (m *MockimageBuilderPusher) BuildAndPush(docker repository.ContainerLoginBuildPusher, args *dockerengine.BuildArguments) (string, error) {
+func (m *MockimageBuilderPusher) BuildAndPush(docker repository.ContainerLoginBuildPusher, args ...*dockerengine.BuildArguments) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BuildAndPush", docker, args)
+	varargs := []interface{}{docker}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BuildAndPush", varargs...)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BuildAndPush indicates an expected call of BuildAndPush.
-func (mr *MockimageBuilderPusherMockRecorder) BuildAndPush(docker, args interface{}) *gomock.Call {
+func (mr *MockimageBuilderPusherMockRecorder) BuildAndPush(docker interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildAndPush", reflect.TypeOf((*MockimageBuilderPusher)(nil).BuildAndPush), docker, args)
+	varargs := append([]interface{}{docker}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildAndPush", reflect.TypeOf((*MockimageBuilderPusher)(nil).BuildAndPush), varargs...)
 }
 
 // MockrepositoryURIGetter is a mock of repositoryURIGetter interface.
@@ -1553,18 +1560,23 @@ func (m *MockrepositoryService) EXPECT() *MockrepositoryServiceMockRecorder {
 }
 
 // BuildAndPush mocks base method.
-func (m *MockrepositoryService) BuildAndPush(docker repository.ContainerLoginBuildPusher, args *dockerengine.BuildArguments) (string, error) {
+func (m *MockrepositoryService) BuildAndPush(docker repository.ContainerLoginBuildPusher, args ...*dockerengine.BuildArguments) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BuildAndPush", docker, args)
+	varargs := []interface{}{docker}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BuildAndPush", varargs...)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BuildAndPush indicates an expected call of BuildAndPush.
-func (mr *MockrepositoryServiceMockRecorder) BuildAndPush(docker, args interface{}) *gomock.Call {
+func (mr *MockrepositoryServiceMockRecorder) BuildAndPush(docker interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildAndPush", reflect.TypeOf((*MockrepositoryService)(nil).BuildAndPush), docker, args)
+	varargs := append([]interface{}{docker}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildAndPush", reflect.TypeOf((*MockrepositoryService)(nil).BuildAndPush), varargs...)
 }
 
 // URI mocks base method.
@@ -3878,6 +3890,223 @@ func (mr *MocktaskRunnerMockRecorder) Run() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MocktaskRunner)(nil).Run))
 }
 
+// MockhookRunner is a mock of hookRunner interface.
+type MockhookRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockhookRunnerMockRecorder
+}
+
+// MockhookRunnerMockRecorder is the mock recorder for MockhookRunner.
+type MockhookRunnerMockRecorder struct {
+	mock *MockhookRunner
+}
+
+// NewMockhookRunner creates a new mock instance.
+func NewMockhookRunner(ctrl *gomock.Controller) *MockhookRunner {
+	mock := &MockhookRunner{ctrl: ctrl}
+	mock.recorder = &MockhookRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhookRunner) EXPECT() *MockhookRunnerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockhookRunner) Run(in task.HookInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockhookRunnerMockRecorder) Run(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockhookRunner)(nil).Run), in)
+}
+
+// MockstackResourcesGetter is a mock of stackResourcesGetter interface.
+type MockstackResourcesGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockstackResourcesGetterMockRecorder
+}
+
+// MockstackResourcesGetterMockRecorder is the mock recorder for MockstackResourcesGetter.
+type MockstackResourcesGetterMockRecorder struct {
+	mock *MockstackResourcesGetter
+}
+
+// NewMockstackResourcesGetter creates a new mock instance.
+func NewMockstackResourcesGetter(ctrl *gomock.Controller) *MockstackResourcesGetter {
+	mock := &MockstackResourcesGetter{ctrl: ctrl}
+	mock.recorder = &MockstackResourcesGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstackResourcesGetter) EXPECT() *MockstackResourcesGetterMockRecorder {
+	return m.recorder
+}
+
+// Exists mocks base method.
+func (m *MockstackResourcesGetter) Exists(name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockstackResourcesGetterMockRecorder) Exists(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockstackResourcesGetter)(nil).Exists), name)
+}
+
+// StackResources mocks base method.
+func (m *MockstackResourcesGetter) StackResources(name string) ([]*cloudformation.StackResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StackResources", name)
+	ret0, _ := ret[0].([]*cloudformation.StackResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StackResources indicates an expected call of StackResources.
+func (mr *MockstackResourcesGetterMockRecorder) StackResources(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StackResources", reflect.TypeOf((*MockstackResourcesGetter)(nil).StackResources), name)
+}
+
+// MockstackDescriber is a mock of stackDescriber interface.
+type MockstackDescriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockstackDescriberMockRecorder
+}
+
+// MockstackDescriberMockRecorder is the mock recorder for MockstackDescriber.
+type MockstackDescriberMockRecorder struct {
+	mock *MockstackDescriber
+}
+
+// NewMockstackDescriber creates a new mock instance.
+func NewMockstackDescriber(ctrl *gomock.Controller) *MockstackDescriber {
+	mock := &MockstackDescriber{ctrl: ctrl}
+	mock.recorder = &MockstackDescriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstackDescriber) EXPECT() *MockstackDescriberMockRecorder {
+	return m.recorder
+}
+
+// Describe mocks base method.
+func (m *MockstackDescriber) Describe(name string) (*cloudformation.StackDescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Describe", name)
+	ret0, _ := ret[0].(*cloudformation.StackDescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Describe indicates an expected call of Describe.
+func (mr *MockstackDescriberMockRecorder) Describe(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Describe", reflect.TypeOf((*MockstackDescriber)(nil).Describe), name)
+}
+
+// MockblueGreenDeployer is a mock of blueGreenDeployer interface.
+type MockblueGreenDeployer struct {
+	ctrl     *gomock.Controller
+	recorder *MockblueGreenDeployerMockRecorder
+}
+
+// MockblueGreenDeployerMockRecorder is the mock recorder for MockblueGreenDeployer.
+type MockblueGreenDeployerMockRecorder struct {
+	mock *MockblueGreenDeployer
+}
+
+// NewMockblueGreenDeployer creates a new mock instance.
+func NewMockblueGreenDeployer(ctrl *gomock.Controller) *MockblueGreenDeployer {
+	mock := &MockblueGreenDeployer{ctrl: ctrl}
+	mock.recorder = &MockblueGreenDeployerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockblueGreenDeployer) EXPECT() *MockblueGreenDeployerMockRecorder {
+	return m.recorder
+}
+
+// DeployECSService mocks base method.
+func (m *MockblueGreenDeployer) DeployECSService(in codedeploy.ECSDeployment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeployECSService", in)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeployECSService indicates an expected call of DeployECSService.
+func (mr *MockblueGreenDeployerMockRecorder) DeployECSService(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployECSService", reflect.TypeOf((*MockblueGreenDeployer)(nil).DeployECSService), in)
+}
+
+// WaitUntilDeploymentSucceeded mocks base method.
+func (m *MockblueGreenDeployer) WaitUntilDeploymentSucceeded(id string, timeout time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitUntilDeploymentSucceeded", id, timeout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilDeploymentSucceeded indicates an expected call of WaitUntilDeploymentSucceeded.
+func (mr *MockblueGreenDeployerMockRecorder) WaitUntilDeploymentSucceeded(id, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilDeploymentSucceeded", reflect.TypeOf((*MockblueGreenDeployer)(nil).WaitUntilDeploymentSucceeded), id, timeout)
+}
+
+// MockreadinessWaiter is a mock of readinessWaiter interface.
+type MockreadinessWaiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockreadinessWaiterMockRecorder
+}
+
+// MockreadinessWaiterMockRecorder is the mock recorder for MockreadinessWaiter.
+type MockreadinessWaiterMockRecorder struct {
+	mock *MockreadinessWaiter
+}
+
+// NewMockreadinessWaiter creates a new mock instance.
+func NewMockreadinessWaiter(ctrl *gomock.Controller) *MockreadinessWaiter {
+	mock := &MockreadinessWaiter{ctrl: ctrl}
+	mock.recorder = &MockreadinessWaiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreadinessWaiter) EXPECT() *MockreadinessWaiterMockRecorder {
+	return m.recorder
+}
+
+// Wait mocks base method.
+func (m *MockreadinessWaiter) Wait(url string, status int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait", url, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockreadinessWaiterMockRecorder) Wait(url, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockreadinessWaiter)(nil).Wait), url, status)
+}
+
 // MockdefaultClusterGetter is a mock of defaultClusterGetter interface.
 type MockdefaultClusterGetter struct {
 	ctrl     *gomock.Controller
@@ -5634,6 +5863,25 @@ func (mr *MockvpcSubnetListerMockRecorder) ListVPCSubnets(vpcID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVPCSubnets", reflect.TypeOf((*MockvpcSubnetLister)(nil).ListVPCSubnets), vpcID)
 }
 
+// SubnetIDs mocks base method.
+func (m *MockvpcSubnetLister) SubnetIDs(filters ...ec2.Filter) ([]string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range filters {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SubnetIDs", varargs...)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubnetIDs indicates an expected call of SubnetIDs.
+func (mr *MockvpcSubnetListerMockRecorder) SubnetIDs(filters ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetIDs", reflect.TypeOf((*MockvpcSubnetLister)(nil).SubnetIDs), filters...)
+}
+
 // MockserviceResumer is a mock of serviceResumer interface.
 type MockserviceResumer struct {
 	ctrl     *gomock.Controller
@@ -5822,6 +6070,21 @@ func (mr *MockserviceDescriberMockRecorder) DescribeService(app, env, svc interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeService", reflect.TypeOf((*MockserviceDescriber)(nil).DescribeService), app, env, svc)
 }
 
+// TaskDefinition mocks base method.
+func (m *MockserviceDescriber) TaskDefinition(app, env, svc string) (*ecs.TaskDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TaskDefinition", app, env, svc)
+	ret0, _ := ret[0].(*ecs.TaskDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TaskDefinition indicates an expected call of TaskDefinition.
+func (mr *MockserviceDescriberMockRecorder) TaskDefinition(app, env, svc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskDefinition", reflect.TypeOf((*MockserviceDescriber)(nil).TaskDefinition), app, env, svc)
+}
+
 // MockserviceUpdater is a mock of serviceUpdater interface.
 type MockserviceUpdater struct {
 	ctrl     *gomock.Controller
@@ -5859,6 +6122,43 @@ func (mr *MockserviceUpdaterMockRecorder) ForceUpdateService(app, env, svc inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceUpdateService", reflect.TypeOf((*MockserviceUpdater)(nil).ForceUpdateService), app, env, svc)
 }
 
+// MockserviceExecUpdater is a mock of serviceExecUpdater interface.
+type MockserviceExecUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceExecUpdaterMockRecorder
+}
+
+// MockserviceExecUpdaterMockRecorder is the mock recorder for MockserviceExecUpdater.
+type MockserviceExecUpdaterMockRecorder struct {
+	mock *MockserviceExecUpdater
+}
+
+// NewMockserviceExecUpdater creates a new mock instance.
+func NewMockserviceExecUpdater(ctrl *gomock.Controller) *MockserviceExecUpdater {
+	mock := &MockserviceExecUpdater{ctrl: ctrl}
+	mock.recorder = &MockserviceExecUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockserviceExecUpdater) EXPECT() *MockserviceExecUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdateServiceExecuteCommand mocks base method.
+func (m *MockserviceExecUpdater) UpdateServiceExecuteCommand(app, env, svc string, enable bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateServiceExecuteCommand", app, env, svc, enable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateServiceExecuteCommand indicates an expected call of UpdateServiceExecuteCommand.
+func (mr *MockserviceExecUpdaterMockRecorder) UpdateServiceExecuteCommand(app, env, svc, enable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceExecuteCommand", reflect.TypeOf((*MockserviceExecUpdater)(nil).UpdateServiceExecuteCommand), app, env, svc, enable)
+}
+
 // MockserviceDeployer is a mock of serviceDeployer interface.
 type MockserviceDeployer struct {
 	ctrl     *gomock.Controller
@@ -6167,6 +6467,44 @@ func (mr *MockroleTagsListerMockRecorder) ListRoleTags(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoleTags", reflect.TypeOf((*MockroleTagsLister)(nil).ListRoleTags), arg0)
 }
 
+// MockoidcProviderCreator is a mock of oidcProviderCreator interface.
+type MockoidcProviderCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockoidcProviderCreatorMockRecorder
+}
+
+// MockoidcProviderCreatorMockRecorder is the mock recorder for MockoidcProviderCreator.
+type MockoidcProviderCreatorMockRecorder struct {
+	mock *MockoidcProviderCreator
+}
+
+// NewMockoidcProviderCreator creates a new mock instance.
+func NewMockoidcProviderCreator(ctrl *gomock.Controller) *MockoidcProviderCreator {
+	mock := &MockoidcProviderCreator{ctrl: ctrl}
+	mock.recorder = &MockoidcProviderCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockoidcProviderCreator) EXPECT() *MockoidcProviderCreatorMockRecorder {
+	return m.recorder
+}
+
+// CreateOIDCProviderIfNotExists mocks base method.
+func (m *MockoidcProviderCreator) CreateOIDCProviderIfNotExists(url string, audiences, thumbprints []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOIDCProviderIfNotExists", url, audiences, thumbprints)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOIDCProviderIfNotExists indicates an expected call of CreateOIDCProviderIfNotExists.
+func (mr *MockoidcProviderCreatorMockRecorder) CreateOIDCProviderIfNotExists(url, audiences, thumbprints interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOIDCProviderIfNotExists", reflect.TypeOf((*MockoidcProviderCreator)(nil).CreateOIDCProviderIfNotExists), url, audiences, thumbprints)
+}
+
 // MockroleManager is a mock of roleManager interface.
 type MockroleManager struct {
 	ctrl     *gomock.Controller
@@ -6204,6 +6542,21 @@ func (mr *MockroleManagerMockRecorder) CreateECSServiceLinkedRole() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateECSServiceLinkedRole", reflect.TypeOf((*MockroleManager)(nil).CreateECSServiceLinkedRole))
 }
 
+// CreateOIDCProviderIfNotExists mocks base method.
+func (m *MockroleManager) CreateOIDCProviderIfNotExists(url string, audiences, thumbprints []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOIDCProviderIfNotExists", url, audiences, thumbprints)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOIDCProviderIfNotExists indicates an expected call of CreateOIDCProviderIfNotExists.
+func (mr *MockroleManagerMockRecorder) CreateOIDCProviderIfNotExists(url, audiences, thumbprints interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOIDCProviderIfNotExists", reflect.TypeOf((*MockroleManager)(nil).CreateOIDCProviderIfNotExists), url, audiences, thumbprints)
+}
+
 // DeleteRole mocks base method.
 func (m *MockroleManager) DeleteRole(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -9,7 +9,10 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/copilot-cli/internal/pkg/aws/ec2"
 
@@ -20,13 +23,18 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/template"
 
 	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
+	"github.com/aws/copilot-cli/internal/pkg/task"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/dustin/go-humanize/english"
 	"golang.org/x/mod/semver"
 
 	"github.com/aws/copilot-cli/internal/pkg/addon"
 	awscloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/aws/codedeploy"
 	"github.com/aws/copilot-cli/internal/pkg/aws/ecr"
+	awsecs "github.com/aws/copilot-cli/internal/pkg/aws/ecs"
 	"github.com/aws/copilot-cli/internal/pkg/aws/s3"
 	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
 	"github.com/aws/copilot-cli/internal/pkg/aws/tags"
@@ -37,6 +45,7 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/describe"
 	"github.com/aws/copilot-cli/internal/pkg/exec"
 	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/aws/copilot-cli/internal/pkg/readiness"
 	"github.com/aws/copilot-cli/internal/pkg/repository"
 	"github.com/aws/copilot-cli/internal/pkg/term/color"
 	"github.com/aws/copilot-cli/internal/pkg/term/log"
@@ -44,6 +53,7 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
 	"github.com/aws/copilot-cli/internal/pkg/term/selector"
 	"github.com/aws/copilot-cli/internal/pkg/workspace"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
@@ -53,6 +63,10 @@ const (
 	fmtForceUpdateSvcComplete = "Forced an update for service %s from environment %s.\n"
 )
 
+// blueGreenTrafficShiftTimeout is how long a CodeDeploy deployment may take to start the replacement tasks
+// and shift the traffic to them, on top of the termination wait of the original tasks.
+const blueGreenTrafficShiftTimeout = time.Hour
+
 var aliasUsedWithoutDomainFriendlyText = fmt.Sprintf("To use %s, your application must be associated with a domain: %s.\n",
 	color.HighlightCode("http.alias"),
 	color.HighlightCode("copilot app init --domain example.com"))
@@ -64,6 +78,24 @@ type deployWkldVars struct {
 	imageTag       string
 	resourceTags   map[string]string
 	forceNewUpdate bool
+
+	disableRollback bool
+	stackTimeout    time.Duration
+	policyPath      string
+	// requirePlacement makes the deployment fail if the manifest doesn't specify the placement of the tasks.
+	requirePlacement bool
+}
+
+// stackOpts returns the options to apply to the workload stack for the deployment.
+func (v deployWkldVars) stackOpts(roleARN string) []awscloudformation.StackOption {
+	opts := []awscloudformation.StackOption{awscloudformation.WithRoleARN(roleARN)}
+	if v.disableRollback {
+		opts = append(opts, awscloudformation.WithDisableRollback())
+	}
+	if v.stackTimeout != 0 {
+		opts = append(opts, awscloudformation.WithTimeout(v.stackTimeout))
+	}
+	return opts
 }
 
 type uploadCustomResourcesOpts struct {
@@ -77,6 +109,7 @@ type deploySvcOpts struct {
 	store               store
 	deployStore         *deploy.Store
 	ws                  wsSvcDirReader
+	fs                  afero.Fs
 	imageBuilderPusher  imageBuilderPusher
 	unmarshal           func([]byte) (manifest.WorkloadManifest, error)
 	newInterpolator     func(app, env string) interpolator
@@ -93,7 +126,13 @@ type deploySvcOpts struct {
 	snsTopicGetter      deployedEnvironmentLister
 	identity            identityService
 	subnetLister        vpcSubnetLister
+	dockerEngine        dockerEngine
 	envDescriber        envDescriber
+	preDeployRunner     hookRunner
+	svcStackResources   stackResourcesGetter
+	svcStackDescriber   stackDescriber
+	blueGreenDeployer   blueGreenDeployer
+	readinessWaiter     readinessWaiter
 
 	spinner progress
 	sel     wsSelector
@@ -104,11 +143,15 @@ type deploySvcOpts struct {
 	targetEnvironment *config.Environment
 	targetSvc         *config.Workload
 	appliedManifest   interface{}
+	scalingCalendars  map[string]manifest.ScalingCalendar
 	imageDigest       string
 	buildRequired     bool
+	initImageDigest   string
+	initBuildRequired bool
 	appEnvResources   *stack.AppRegionalResources
 	rdSvcAlias        string
 	svcUpdater        serviceUpdater
+	deployedBlueGreen *blueGreenStack
 
 	subscriptions []manifest.TopicSubscription
 
@@ -150,13 +193,17 @@ func newSvcDeployOpts(vars deployWkldVars) (*deploySvcOpts, error) {
 		cmd:             exec.NewCmd(),
 		sessProvider:    sessions.NewProvider(),
 		snsTopicGetter:  deployStore,
+		fs:              &afero.Afero{Fs: afero.NewOsFs()},
+		dockerEngine:    dockerengine.New(exec.NewCmd()),
 	}
 	opts.uploadOpts = newUploadCustomResourcesOpts(opts)
 	return opts, err
 }
 
 func newManifestInterpolator(app, env string) interpolator {
-	return manifest.NewInterpolator(app, env)
+	return manifest.NewInterpolator(app, env).WithGitSHA(func() (string, error) {
+		return gitCommitSHA(exec.NewCmd())
+	})
 }
 
 // Validate returns an error if the user inputs are invalid.
@@ -174,7 +221,7 @@ func (o *deploySvcOpts) Validate() error {
 			return err
 		}
 	}
-	return nil
+	return validateStackTimeout(o.stackTimeout)
 }
 
 // Ask prompts the user for any required fields that are not provided.
@@ -212,6 +259,23 @@ func (o *deploySvcOpts) Execute() error {
 		return err
 	}
 
+	mft, err := o.manifest()
+	if err != nil {
+		return err
+	}
+	if err := manifest.ValidatePlatformInRegion(mft, o.targetEnvironment.Region); err != nil {
+		return fmt.Errorf("validate manifest against environment %s: %w", o.envName, err)
+	}
+	if err := validateAZRebalancing(o.envDescriber, o.subnetLister, mft); err != nil {
+		return fmt.Errorf("validate manifest against environment %s: %w", o.envName, err)
+	}
+	if err := validateIPFamily(o.envDescriber, o.subnetLister, mft); err != nil {
+		return fmt.Errorf("validate manifest against environment %s: %w", o.envName, err)
+	}
+	if err := o.evaluatePolicy(mft); err != nil {
+		return err
+	}
+
 	if err := o.envUpgradeCmd.Execute(); err != nil {
 		return fmt.Errorf(`execute "env upgrade --app %s --name %s": %v`, o.appName, o.targetEnvironment.Name, err)
 	}
@@ -340,6 +404,26 @@ func (o *deploySvcOpts) configureClients() error {
 	// CF client against env account profile AND target environment region.
 	o.svcCFN = cloudformation.New(envSession)
 
+	// Runs the one-off task of "deployment.pre_deploy" in the target environment.
+	ecsSvc := awsecs.New(envSession)
+	o.preDeployRunner = &task.HookRunner{
+		App:     o.appName,
+		Env:     o.envName,
+		RoleARN: o.targetEnvironment.ExecutionRoleARN,
+
+		Out:                  os.Stderr,
+		Deployer:             cloudformation.New(envSession),
+		VPCGetter:            ec2.New(envSession),
+		ClusterGetter:        ecs.New(envSession),
+		Starter:              ecsSvc,
+		EnvironmentDescriber: d,
+		Waiter:               ecsSvc,
+	}
+	o.svcStackResources = awscloudformation.New(envSession)
+	o.svcStackDescriber = awscloudformation.New(envSession)
+	o.blueGreenDeployer = codedeploy.New(envSession)
+	o.readinessWaiter = readiness.New()
+
 	o.endpointGetter, err = describe.NewEnvDescriber(describe.NewEnvDescriberConfig{
 		App:         o.appName,
 		Env:         o.envName,
@@ -393,11 +477,165 @@ func (o *deploySvcOpts) publicCIDRBlocks() ([]string, error) {
 	return cidrBlocks, nil
 }
 
+// subnetsFromTags returns the IDs of the subnets of the environment VPC selected by "network.vpc.subnets.from_tags",
+// or nil if the workload doesn't select its subnets by tags.
+func subnetsFromTags(describer envDescriber, lister vpcSubnetLister, mft interface{}) ([]string, error) {
+	tags := subnetTags(mft)
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	envDescription, err := describer.Describe()
+	if err != nil {
+		return nil, fmt.Errorf("describe environment: %w", err)
+	}
+	vpcID := envDescription.EnvironmentVPC.ID
+	filters := []ec2.Filter{
+		{
+			Name:   "vpc-id",
+			Values: []string{vpcID},
+		},
+	}
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		filters = append(filters, ec2.Filter{
+			Name:   fmt.Sprintf(ec2.TagFilterName, key),
+			Values: []string{tags[key]},
+		})
+	}
+	ids, err := lister.SubnetIDs(filters...)
+	if err != nil {
+		return nil, fmt.Errorf("list subnets of vpc %s: %w", vpcID, err)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf(`no subnets of vpc %s match the tags of "network.vpc.subnets.from_tags"`, vpcID)
+	}
+	return ids, nil
+}
+
+// subnetTags returns the tags of "network.vpc.subnets.from_tags", or nil if the workload doesn't select its subnets by tags.
+func subnetTags(mft interface{}) map[string]string {
+	type networker interface {
+		NetworkConfig() manifest.NetworkConfig
+	}
+	n, ok := mft.(networker)
+	if !ok {
+		return nil
+	}
+	return n.NetworkConfig().VPC.Subnets.FromTags
+}
+
+// validateAZRebalancing returns an error if the service turns on "availability_zone_rebalancing" with fewer tasks
+// than the availability zones of the subnets that it's placed in.
+func validateAZRebalancing(describer envDescriber, lister vpcSubnetLister, mft interface{}) error {
+	if !manifest.IsAZRebalancingEnabled(mft) {
+		return nil
+	}
+	count, err := subnetAZCount(describer, lister, mft)
+	if err != nil {
+		return err
+	}
+	return manifest.ValidateAZRebalancing(mft, count)
+}
+
+// subnetAZCount returns the number of availability zones of the subnets that the workload is placed in.
+func subnetAZCount(describer envDescriber, lister vpcSubnetLister, mft interface{}) (int, error) {
+	subnets, err := workloadSubnets(describer, lister, mft)
+	if err != nil {
+		return 0, err
+	}
+	zones := make(map[string]bool)
+	for _, subnet := range subnets {
+		zones[subnet.AvailabilityZone] = true
+	}
+	return len(zones), nil
+}
+
+// validateIPFamily returns an error if the workload sets "network.vpc.ip_family" to "ipv6" or "dualstack",
+// but the subnets that it's placed in don't have IPv6 CIDR blocks.
+func validateIPFamily(describer envDescriber, lister vpcSubnetLister, mft interface{}) error {
+	type networker interface {
+		NetworkConfig() manifest.NetworkConfig
+	}
+	n, ok := mft.(networker)
+	if !ok {
+		return nil
+	}
+	family := strings.ToLower(aws.StringValue(n.NetworkConfig().VPC.IPFamily))
+	if family != manifest.IPFamilyIPv6 && family != manifest.IPFamilyDualStack {
+		return nil
+	}
+	subnets, err := workloadSubnets(describer, lister, mft)
+	if err != nil {
+		return err
+	}
+	var ipv4Only []string
+	for _, subnet := range subnets {
+		if subnet.IPv6CIDRBlock == "" {
+			ipv4Only = append(ipv4Only, subnet.ID)
+		}
+	}
+	if len(ipv4Only) != 0 {
+		return fmt.Errorf(`"network.vpc.ip_family" %s requires subnets with an IPv6 CIDR block, but %s %s`,
+			family, english.WordSeries(ipv4Only, "and"), english.PluralWord(len(ipv4Only), "doesn't have one", "don't have one"))
+	}
+	return nil
+}
+
+// workloadSubnets returns the subnets of the environment VPC that the workload is placed in.
+func workloadSubnets(describer envDescriber, lister vpcSubnetLister, mft interface{}) ([]ec2.Subnet, error) {
+	envDescription, err := describer.Describe()
+	if err != nil {
+		return nil, fmt.Errorf("describe environment: %w", err)
+	}
+	vpc := envDescription.EnvironmentVPC
+	subnetIDs := vpc.PublicSubnetIDs
+	type networker interface {
+		NetworkConfig() manifest.NetworkConfig
+	}
+	if n, ok := mft.(networker); ok {
+		network := n.NetworkConfig().VPC
+		if network.Placement != nil && *network.Placement == manifest.PrivateSubnetPlacement {
+			subnetIDs = vpc.PrivateSubnetIDs
+		}
+		if len(network.Subnets.IDs) != 0 {
+			subnetIDs = network.Subnets.IDs
+		}
+		if len(network.Subnets.FromTags) != 0 {
+			if subnetIDs, err = subnetsFromTags(describer, lister, mft); err != nil {
+				return nil, err
+			}
+		}
+	}
+	vpcSubnets, err := lister.ListVPCSubnets(vpc.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list subnets of vpc %s: %w", vpc.ID, err)
+	}
+	byID := make(map[string]ec2.Subnet)
+	for _, subnet := range append(vpcSubnets.Public, vpcSubnets.Private...) {
+		byID[subnet.ID] = subnet
+	}
+	var subnets []ec2.Subnet
+	for _, id := range subnetIDs {
+		if subnet, ok := byID[id]; ok {
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets, nil
+}
+
 func (o *deploySvcOpts) configureContainerImage() error {
 	svc, err := o.manifest()
 	if err != nil {
 		return err
 	}
+	// Build the init container image first so that the "latest" tag of the repository points to the main image.
+	if err := o.configureInitContainerImage(svc); err != nil {
+		return err
+	}
 	required, err := manifest.ServiceDockerfileBuildRequired(svc)
 	if err != nil {
 		return err
@@ -406,12 +644,12 @@ func (o *deploySvcOpts) configureContainerImage() error {
 		return nil
 	}
 	// If it is built from local Dockerfile, build and push to the ECR repo.
-	buildArg, err := o.dfBuildArgs(svc)
+	buildArgs, err := o.dfBuildArgs(svc)
 	if err != nil {
 		return err
 	}
 
-	digest, err := o.imageBuilderPusher.BuildAndPush(dockerengine.New(exec.NewCmd()), buildArg)
+	digest, err := o.imageBuilderPusher.BuildAndPush(dockerengine.New(exec.NewCmd()), buildArgs...)
 	if err != nil {
 		return fmt.Errorf("build and push image: %w", err)
 	}
@@ -420,17 +658,60 @@ func (o *deploySvcOpts) configureContainerImage() error {
 	return nil
 }
 
-func (o *deploySvcOpts) dfBuildArgs(svc interface{}) (*dockerengine.BuildArguments, error) {
+// configureInitContainerImage builds the image of the service's init container from its own Dockerfile or target,
+// and pushes it to the service's ECR repository.
+func (o *deploySvcOpts) configureInitContainerImage(svc interface{}) error {
+	required, err := initContainerBuildRequired(svc)
+	if err != nil {
+		return err
+	}
+	if !required {
+		return nil
+	}
+	mft := svc.(initContainerProvider)
+	copilotDir, err := o.ws.CopilotDirPath()
+	if err != nil {
+		return fmt.Errorf("get copilot directory: %w", err)
+	}
+	buildArg, err := initContainerBuildArgs(mft.InitContainerConfig(), o.envName, o.imageTag, copilotDir, mft.ContainerPlatform())
+	if err != nil {
+		return err
+	}
+	digest, err := o.imageBuilderPusher.BuildAndPush(dockerengine.New(exec.NewCmd()), buildArg)
+	if err != nil {
+		return fmt.Errorf("build and push %s container image: %w", manifest.InitContainerName, err)
+	}
+	o.initImageDigest = digest
+	o.initBuildRequired = true
+	return nil
+}
+
+func (o *deploySvcOpts) dfBuildArgs(svc interface{}) ([]*dockerengine.BuildArguments, error) {
 	copilotDir, err := o.ws.CopilotDirPath()
 	if err != nil {
 		return nil, fmt.Errorf("get copilot directory: %w", err)
 	}
-	return buildArgs(o.name, o.imageTag, copilotDir, svc)
+	hostPlatform, err := enginePlatform(o.dockerEngine)
+	if err != nil {
+		return nil, err
+	}
+	return buildArgs(o.name, o.envName, o.imageTag, copilotDir, hostPlatform, svc)
+}
+
+// enginePlatform returns the "os/arch" platform of the Docker engine.
+func enginePlatform(engine dockerEngine) (string, error) {
+	engineOS, engineArch, err := engine.GetPlatform()
+	if err != nil {
+		return "", fmt.Errorf("get docker engine platform: %w", err)
+	}
+	return dockerengine.PlatformString(engineOS, engineArch), nil
 }
 
-func buildArgs(name, imageTag, copilotDir string, unmarshaledManifest interface{}) (*dockerengine.BuildArguments, error) {
+// buildArgs returns the arguments to build the image of the workload for the platform that its tasks run on.
+// If the image is built for more than one platform with "build.platforms", it returns the arguments of every platform.
+func buildArgs(name, envName, imageTag, copilotDir, hostPlatform string, unmarshaledManifest interface{}) ([]*dockerengine.BuildArguments, error) {
 	type dfArgs interface {
-		BuildArgs(rootDirectory string) *manifest.DockerBuildArgs
+		BuildArgs(rootDirectory, envName, hostPlatform string) ([]*manifest.DockerBuildArgs, error)
 		ContainerPlatform() string
 	}
 	mf, ok := unmarshaledManifest.(dfArgs)
@@ -441,18 +722,178 @@ func buildArgs(name, imageTag, copilotDir string, unmarshaledManifest interface{
 	if imageTag != "" {
 		tags = append(tags, imageTag)
 	}
-	args := mf.BuildArgs(filepath.Dir(copilotDir))
+	configs, err := mf.BuildArgs(filepath.Dir(copilotDir), envName, hostPlatform)
+	if err != nil {
+		return nil, fmt.Errorf("resolve build arguments for %s: %w", name, err)
+	}
+	if len(configs) > 1 {
+		var args []*dockerengine.BuildArguments
+		for _, config := range configs {
+			arg, err := dockerBuildArgs(config, aws.StringValue(config.Platform), tags)
+			if err != nil {
+				return nil, fmt.Errorf("resolve build arguments for %s: %w", name, err)
+			}
+			args = append(args, arg)
+		}
+		return args, nil
+	}
+	config, platform, err := buildConfigForPlatform(configs, mf.ContainerPlatform())
+	if err != nil {
+		return nil, fmt.Errorf("resolve build arguments for %s: %w", name, err)
+	}
+	if host, taskPlatform, mismatch := hostPlatformMismatch(config, platform); mismatch {
+		log.Warningf("The image of %s is built for the platform %s of the Docker engine, but its tasks run on %s.\nSet \"platform: %s\" in the manifest to build the image for the tasks.\n", name, host, taskPlatform, taskPlatform)
+	}
+	arg, err := dockerBuildArgs(config, platform, tags)
+	if err != nil {
+		return nil, fmt.Errorf("resolve build arguments for %s: %w", name, err)
+	}
+	return []*dockerengine.BuildArguments{arg}, nil
+}
+
+// dockerBuildArgs converts the resolved build configuration of the manifest into the arguments of docker build.
+// The "cache_from" images can reference the first of the tags.
+func dockerBuildArgs(config *manifest.DockerBuildArgs, platform string, tags []string) (*dockerengine.BuildArguments, error) {
+	var tag string
+	if len(tags) > 0 {
+		tag = tags[0]
+	}
+	cacheFrom, err := config.CacheFromImages(tag)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := buildSecrets(config.Secrets)
+	if err != nil {
+		return nil, fmt.Errorf("resolve build secrets: %w", err)
+	}
 	return &dockerengine.BuildArguments{
-		Dockerfile: *args.Dockerfile,
-		Context:    *args.Context,
-		Args:       args.Args,
-		CacheFrom:  args.CacheFrom,
-		Target:     aws.StringValue(args.Target),
-		Platform:   mf.ContainerPlatform(),
+		Dockerfile: *config.Dockerfile,
+		Context:    *config.Context,
+		Args:       config.Args,
+		CacheFrom:  cacheFrom,
+		Target:     aws.StringValue(config.Target),
+		Platform:   platform,
+		SSH:        config.SSH,
+		Secrets:    secrets,
+		Network:    aws.StringValue(config.Network),
+		Labels:     config.Labels,
 		Tags:       tags,
 	}, nil
 }
 
+type initContainerProvider interface {
+	InitContainerConfig() manifest.InitContainer
+	ContainerPlatform() string
+}
+
+// initContainerBuildRequired returns true if the workload has an init container whose image is built from a local Dockerfile.
+func initContainerBuildRequired(wkld interface{}) (bool, error) {
+	mft, ok := wkld.(initContainerProvider)
+	if !ok {
+		return false, nil
+	}
+	required, err := mft.InitContainerConfig().BuildRequired()
+	if err != nil {
+		return false, fmt.Errorf("check if the %s container requires building from local Dockerfile: %w", manifest.InitContainerName, err)
+	}
+	return required, nil
+}
+
+// initContainerBuildArgs returns the arguments to build the init container image for the platform that the tasks run on.
+// The image is tagged with initImageTag so that it doesn't overwrite the tags of the main container image.
+func initContainerBuildArgs(init manifest.InitContainer, envName, imageTag, copilotDir, platform string) (*dockerengine.BuildArguments, error) {
+	configs, err := init.Image.BuildConfig(filepath.Dir(copilotDir), envName)
+	if err != nil {
+		return nil, fmt.Errorf("resolve build arguments for %s container: %w", manifest.InitContainerName, err)
+	}
+	config, platform, err := buildConfigForPlatform(configs, platform)
+	if err != nil {
+		return nil, fmt.Errorf("resolve build arguments for %s container: %w", manifest.InitContainerName, err)
+	}
+	args, err := dockerBuildArgs(config, platform, []string{initImageTag(imageTag)})
+	if err != nil {
+		return nil, fmt.Errorf("resolve build arguments for %s container: %w", manifest.InitContainerName, err)
+	}
+	return args, nil
+}
+
+// initImageTag returns the tag of the init container image in the workload's repository given the tag of the main image.
+func initImageTag(imageTag string) string {
+	if imageTag == "" {
+		return manifest.InitContainerName
+	}
+	return fmt.Sprintf("%s-%s", imageTag, manifest.InitContainerName)
+}
+
+// buildConfigForPlatform returns the build configuration for the platform that the tasks run on.
+// If the image is built for a single configuration without an explicit platform, it is returned as is.
+func buildConfigForPlatform(configs []*manifest.DockerBuildArgs, platform string) (*manifest.DockerBuildArgs, string, error) {
+	if len(configs) == 1 && configs[0].Platform == nil {
+		return configs[0], platform, nil
+	}
+	wanted := platform
+	if wanted == "" {
+		wanted = dockerengine.PlatformString(manifest.OSLinux, manifest.ArchAMD64)
+	}
+	for _, config := range configs {
+		if isSamePlatform(aws.StringValue(config.Platform), wanted) {
+			return config, aws.StringValue(config.Platform), nil
+		}
+	}
+	return nil, "", fmt.Errorf(`no "build.platforms" entry matches the task platform %s`, wanted)
+}
+
+// hostPlatformMismatch returns the platform of the Docker engine and the platform of the tasks, and true,
+// if the image is built for the Docker engine because it has no target platform, and the tasks run on a different one.
+func hostPlatformMismatch(args *manifest.DockerBuildArgs, platform string) (host, taskPlatform string, mismatch bool) {
+	if platform != "" || args.HostPlatform == nil {
+		return "", "", false
+	}
+	host = aws.StringValue(args.HostPlatform)
+	taskPlatform = dockerengine.PlatformString(manifest.OSLinux, manifest.ArchAMD64)
+	if isSamePlatform(host, taskPlatform) {
+		return "", "", false
+	}
+	return host, taskPlatform, true
+}
+
+// buildSecrets converts the build secrets keyed by id into "docker build --secret" values, sorted by id.
+// A secret is read either from an environment variable, which must be set, or from a file, which must be readable.
+func buildSecrets(secrets map[string]string) ([]string, error) {
+	ids := make([]string, 0, len(secrets))
+	for id := range secrets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var out []string
+	for _, id := range ids {
+		src := secrets[id]
+		if strings.HasPrefix(src, manifest.BuildSecretEnvPrefix) {
+			name := strings.TrimPrefix(src, manifest.BuildSecretEnvPrefix)
+			if _, ok := os.LookupEnv(name); !ok {
+				return nil, fmt.Errorf("environment variable %s of secret %s is not set", name, id)
+			}
+			out = append(out, fmt.Sprintf("id=%s,env=%s", id, name))
+			continue
+		}
+		f, err := os.Open(src)
+		if err != nil {
+			return nil, fmt.Errorf("read file of secret %s: %w", id, err)
+		}
+		f.Close()
+		out = append(out, fmt.Sprintf("id=%s,src=%s", id, src))
+	}
+	return out, nil
+}
+
+// isSamePlatform returns true if both "os/arch" platforms are equal, treating "amd64" and "x86_64" as the same architecture.
+func isSamePlatform(a, b string) bool {
+	normalize := func(platform string) string {
+		return strings.Replace(strings.ToLower(platform), "/"+manifest.ArchX86, "/"+manifest.ArchAMD64, 1)
+	}
+	return normalize(a) == normalize(b)
+}
+
 // pushAddonsTemplateToS3Bucket generates the addons template for the service and pushes it to S3.
 // If the service doesn't have any addons, it returns the empty string and no errors.
 // If the service has addons, it returns the URL of the S3 object storing the addons template.
@@ -488,10 +929,19 @@ func (o *deploySvcOpts) manifest() (interface{}, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read service %s manifest file: %w", o.name, err)
 	}
+	raw, err = manifest.Load(workloadManifestPath(o.name), raw, workspaceFileReader(o.ws))
+	if err != nil {
+		return nil, fmt.Errorf("load service %s manifest: %w", o.name, err)
+	}
 	interpolated, err := o.newInterpolator(o.appName, o.envName).Interpolate(string(raw))
 	if err != nil {
 		return nil, fmt.Errorf("interpolate environment variables for %s manifest: %w", o.name, err)
 	}
+	if o.requirePlacement {
+		if err := manifest.ValidatePlacementSpecified([]byte(interpolated)); err != nil {
+			return nil, fmt.Errorf("validate service %s manifest: %w", o.name, err)
+		}
+	}
 	mft, err := o.unmarshal([]byte(interpolated))
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal service %s manifest: %w", o.name, err)
@@ -500,26 +950,147 @@ func (o *deploySvcOpts) manifest() (interface{}, error) {
 	if err != nil {
 		return nil, fmt.Errorf("apply environment %s override: %s", o.envName, err)
 	}
+	if err := manifest.LoadWorkloadVariables(envMft, workspaceFileReader(o.ws)); err != nil {
+		return nil, fmt.Errorf("load variables for %s manifest: %w", o.name, err)
+	}
+	if err := manifest.LoadWorkloadCollectorConfig(envMft, workspaceFileReader(o.ws)); err != nil {
+		return nil, fmt.Errorf("load collector config for %s manifest: %w", o.name, err)
+	}
 	if err := envMft.Validate(); err != nil {
 		return nil, fmt.Errorf("validate manifest against environment %s: %s", o.envName, err)
 	}
+	calendars, err := scalingCalendars(envMft, o.envName, workspaceFileReader(o.ws))
+	if err != nil {
+		return nil, err
+	}
+	o.scalingCalendars = calendars
+
+	if count, ok := manifest.RecreatedTaskCount(envMft); ok && count > 1 {
+		log.Warningf("Service %s stops all of its %d tasks before starting new ones during a deployment.\nSet \"deployment.strategy: %s\" to keep serving traffic while the tasks are replaced.\n", o.name, count, manifest.DeploymentStrategyRolling)
+	}
 	o.appliedManifest = envMft // cache the results.
 	return envMft, nil
 }
 
+// evaluatePolicy returns an error listing the rules of the organization policy file that the service violates.
+// It's a no-op if no policy file is provided.
+func (o *deploySvcOpts) evaluatePolicy(mft interface{}) error {
+	if o.policyPath == "" {
+		return nil
+	}
+	raw, err := afero.ReadFile(o.fs, o.policyPath)
+	if err != nil {
+		return fmt.Errorf("read policy file %s: %w", o.policyPath, err)
+	}
+	policy, err := manifest.UnmarshalPolicy(raw)
+	if err != nil {
+		return fmt.Errorf("parse policy file %s: %w", o.policyPath, err)
+	}
+	var violations []manifest.Violation
+	if wl, ok := mft.(manifest.WorkloadManifest); ok {
+		violations = append(violations, manifest.EvaluatePolicy(wl, *policy)...)
+	}
+	violations = append(violations, policy.EvaluateTarget(o.targetEnvironment.Region, tags.Merge(o.targetApp.Tags, o.resourceTags))...)
+	if len(violations) == 0 {
+		return nil
+	}
+	return &errPolicyViolations{
+		svcName:    o.name,
+		policyPath: o.policyPath,
+		violations: violations,
+	}
+}
+
+type errPolicyViolations struct {
+	svcName    string
+	policyPath string
+	violations []manifest.Violation
+}
+
+func (e *errPolicyViolations) Error() string {
+	out := []string{
+		fmt.Sprintf("service %s violates policy %s:", e.svcName, e.policyPath),
+	}
+	for _, v := range e.violations {
+		out = append(out, fmt.Sprintf("- %s", v))
+	}
+	return strings.Join(out, "\n")
+}
+
+// environmentManifestPath returns the path to the manifest of the environment relative to the root of the workspace.
+func environmentManifestPath(envName string) string {
+	return filepath.Join(workspace.CopilotDirName, "environments", envName, "manifest.yml")
+}
+
+// scalingCalendars returns the scaling calendars of the environment manifest if the service references any from "count.scheduled".
+// It returns an error if a referenced calendar isn't defined.
+func scalingCalendars(mft interface{}, envName string, read func(path string) ([]byte, error)) (map[string]manifest.ScalingCalendar, error) {
+	if len(manifest.ScalingCalendarReferences(mft)) == 0 {
+		return nil, nil
+	}
+	var calendars map[string]manifest.ScalingCalendar
+	raw, err := read(environmentManifestPath(envName))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("read environment %s manifest: %w", envName, err)
+	default:
+		env, err := manifest.UnmarshalEnvironment(raw)
+		if err != nil {
+			return nil, err
+		}
+		if err := env.Validate(); err != nil {
+			return nil, fmt.Errorf("validate environment %s manifest: %w", envName, err)
+		}
+		calendars = env.ScalingCalendars
+	}
+	if err := manifest.ValidateScalingCalendars(mft, calendars); err != nil {
+		return nil, fmt.Errorf("validate manifest against environment %s: %w", envName, err)
+	}
+	return calendars, nil
+}
+
+// workloadManifestPath returns the path to the manifest of the workload relative to the root of the workspace.
+func workloadManifestPath(name string) string {
+	return filepath.Join(workspace.CopilotDirName, name, "manifest.yml")
+}
+
+// workspaceFileReader returns a function that reads files relative to the root of the workspace.
+func workspaceFileReader(ws copilotDirGetter) func(path string) ([]byte, error) {
+	return func(path string) ([]byte, error) {
+		copilotDir, err := ws.CopilotDirPath()
+		if err != nil {
+			return nil, fmt.Errorf("get copilot directory: %w", err)
+		}
+		return os.ReadFile(filepath.Join(filepath.Dir(copilotDir), path))
+	}
+}
+
 func (o *deploySvcOpts) runtimeConfig(addonsURL string) (*stack.RuntimeConfig, error) {
 	endpoint, err := o.endpointGetter.ServiceDiscoveryEndpoint()
 	if err != nil {
 		return nil, err
 	}
+	mft, err := o.manifest()
+	if err != nil {
+		return nil, err
+	}
+	additionalTags := tags.Merge(o.targetApp.Tags, o.resourceTags, gitSHATags(o.cmd, mft))
+	subnetIDs, err := subnetsFromTags(o.envDescriber, o.subnetLister, mft)
+	if err != nil {
+		return nil, err
+	}
 
-	if !o.buildRequired {
+	if !o.buildRequired && !o.initBuildRequired {
 		return &stack.RuntimeConfig{
 			AddonsTemplateURL:        addonsURL,
-			AdditionalTags:           tags.Merge(o.targetApp.Tags, o.resourceTags),
+			AdditionalTags:           additionalTags,
 			ServiceDiscoveryEndpoint: endpoint,
+			SubnetIDs:                subnetIDs,
+			ScalingCalendars:         o.scalingCalendars,
 			AccountID:                o.targetEnvironment.AccountID,
 			Region:                   o.targetEnvironment.Region,
+			ServiceTaskDefinitionARN: o.deployedBlueGreen.serviceTaskDefinitionARN(),
 		}, nil
 	}
 
@@ -535,18 +1106,33 @@ func (o *deploySvcOpts) runtimeConfig(addonsURL string) (*stack.RuntimeConfig, e
 			appAccountID: o.targetApp.AccountID,
 		}
 	}
-	return &stack.RuntimeConfig{
-		AddonsTemplateURL: addonsURL,
-		AdditionalTags:    tags.Merge(o.targetApp.Tags, o.resourceTags),
-		Image: &stack.ECRImage{
-			RepoURL:  repoURL,
-			ImageTag: o.imageTag,
-			Digest:   o.imageDigest,
-		},
+	rc := &stack.RuntimeConfig{
+		AddonsTemplateURL:        addonsURL,
+		AdditionalTags:           additionalTags,
 		ServiceDiscoveryEndpoint: endpoint,
+		SubnetIDs:                subnetIDs,
+		ScalingCalendars:         o.scalingCalendars,
 		AccountID:                o.targetEnvironment.AccountID,
 		Region:                   o.targetEnvironment.Region,
-	}, nil
+		ServiceTaskDefinitionARN: o.deployedBlueGreen.serviceTaskDefinitionARN(),
+	}
+	if o.buildRequired {
+		rc.Image = &stack.ECRImage{
+			RepoURL:  repoURL,
+			ImageTag: o.imageTag,
+			Digest:   o.imageDigest,
+		}
+	}
+	if o.initBuildRequired {
+		rc.InitImage = &stack.ECRImage{
+			RepoURL: repoURL,
+			Digest:  o.initImageDigest,
+		}
+		if o.imageTag != "" {
+			rc.InitImage.ImageTag = initImageTag(o.imageTag)
+		}
+	}
+	return rc, nil
 }
 
 func uploadCustomResources(o *uploadCustomResourcesOpts, appEnvResources *stack.AppRegionalResources) (map[string]string, error) {
@@ -676,7 +1262,7 @@ func (o *deploySvcOpts) stackConfiguration(addonsURL string) (cloudformation.Sta
 		// Cache the subscriptions for later.
 		o.subscriptions = subscriptionGetter.Subscriptions()
 
-		if err = validateTopicsExist(o.subscriptions, topicARNs, o.appName, o.envName); err != nil {
+		if err = validateTopicsExist(t.Subscribe, topicARNs, o.appName, o.envName); err != nil {
 			return nil, err
 		}
 		conf, err = stack.NewWorkerService(t, o.targetEnvironment.Name, o.targetEnvironment.App, *rc)
@@ -691,25 +1277,310 @@ func (o *deploySvcOpts) stackConfiguration(addonsURL string) (cloudformation.Sta
 }
 
 func (o *deploySvcOpts) deploySvc(addonsURL string) error {
+	if err := o.retrieveDeployedBlueGreenStack(); err != nil {
+		return err
+	}
 	conf, err := o.stackConfiguration(addonsURL)
 	if err != nil {
 		return err
 	}
 
-	if err := o.svcCFN.DeployService(os.Stderr, conf, awscloudformation.WithRoleARN(o.targetEnvironment.ExecutionRoleARN)); err != nil {
+	if err := o.runPreDeployTask(); err != nil {
+		return err
+	}
+
+	if err := o.svcCFN.DeployService(os.Stderr, conf, o.stackOpts(o.targetEnvironment.ExecutionRoleARN)...); err != nil {
 		var errEmptyCS *awscloudformation.ErrChangeSetEmpty
 		if errors.As(err, &errEmptyCS) {
 			if o.forceNewUpdate {
-				return o.forceDeploy()
+				if err := o.forceDeploy(); err != nil {
+					return err
+				}
+				return o.waitUntilReady()
 			}
 			log.Warningf("Set --%s to force an update for the service.\n", forceFlag)
 		}
 		return fmt.Errorf("deploy service: %w", err)
 	}
+	if err := o.deployBlueGreen(false); err != nil {
+		return err
+	}
+	return o.waitUntilReady()
+}
+
+// blueGreenStack holds the outputs and parameters of the stack of a service deployed with blue/green.
+type blueGreenStack struct {
+	serviceTaskDefinition string // ARN of the task definition that the service was created with.
+	taskDefinition        string // ARN of the latest task definition of the stack.
+	application           string
+	deploymentGroup       string
+	targetContainer       string
+	targetPort            string
+}
+
+// serviceTaskDefinitionARN returns the ARN of the task definition that the deployed service was created with,
+// or an empty string if the service isn't deployed with blue/green yet.
+func (s *blueGreenStack) serviceTaskDefinitionARN() string {
+	if s == nil {
+		return ""
+	}
+	return s.serviceTaskDefinition
+}
+
+// blueGreenDeployment returns the blue/green configuration of the service, and false if it's deployed with an ECS rolling update.
+func (o *deploySvcOpts) blueGreenDeployment() (manifest.BlueGreenConfigOrBool, bool) {
+	mft, ok := o.appliedManifest.(interface {
+		BlueGreenDeployment() manifest.BlueGreenConfigOrBool
+	})
+	if !ok || !mft.BlueGreenDeployment().Enabled() {
+		return manifest.BlueGreenConfigOrBool{}, false
+	}
+	return mft.BlueGreenDeployment(), true
+}
+
+// retrieveDeployedBlueGreenStack caches the stack of the service if the service is already deployed with blue/green.
+// ECS only lets CodeDeploy change the task definition of such a service, so the stack must keep the one the service was created with.
+func (o *deploySvcOpts) retrieveDeployedBlueGreenStack() error {
+	if _, err := o.manifest(); err != nil {
+		return err
+	}
+	if _, ok := o.blueGreenDeployment(); !ok {
+		return nil
+	}
+	deployed, err := o.blueGreenStack()
+	if err != nil {
+		return err
+	}
+	o.deployedBlueGreen = deployed
+	return nil
+}
+
+// blueGreenStack returns the stack of the service, or nil if the service isn't deployed with blue/green.
+func (o *deploySvcOpts) blueGreenStack() (*blueGreenStack, error) {
+	stackName := stack.NameForService(o.appName, o.envName, o.name)
+	descr, err := o.svcStackDescriber.Describe(stackName)
+	if err != nil {
+		var errNotFound *awscloudformation.ErrStackNotFound
+		if errors.As(err, &errNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	outputs := make(map[string]string)
+	for _, out := range descr.Outputs {
+		outputs[aws.StringValue(out.OutputKey)] = aws.StringValue(out.OutputValue)
+	}
+	serviceTaskDef, ok := outputs[stack.LBWebServiceServiceTaskDefinitionOutputKey]
+	if !ok {
+		return nil, nil
+	}
+	params := make(map[string]string)
+	for _, param := range descr.Parameters {
+		params[aws.StringValue(param.ParameterKey)] = aws.StringValue(param.ParameterValue)
+	}
+	return &blueGreenStack{
+		serviceTaskDefinition: serviceTaskDef,
+		taskDefinition:        outputs[stack.LBWebServiceTaskDefinitionOutputKey],
+		application:           outputs[stack.LBWebServiceCodeDeployApplicationOutputKey],
+		deploymentGroup:       outputs[stack.LBWebServiceCodeDeployDeploymentGroupOutputKey],
+		targetContainer:       params[stack.LBWebServiceTargetContainerParamKey],
+		targetPort:            params[stack.LBWebServiceTargetPortParamKey],
+	}, nil
+}
+
+// deployBlueGreen rolls out the latest task definition of a service that was already deployed with blue/green
+// through a CodeDeploy deployment, with the hooks of the manifest in its AppSpec.
+// The task definition is only rolled out if the stack update changed it, unless force is true.
+func (o *deploySvcOpts) deployBlueGreen(force bool) error {
+	bg, ok := o.blueGreenDeployment()
+	if !ok || o.deployedBlueGreen == nil {
+		// CloudFormation creates the service with the task definition of the stack.
+		return nil
+	}
+	updated, err := o.blueGreenStack()
+	if err != nil {
+		return err
+	}
+	if updated == nil {
+		return nil
+	}
+	if !force && updated.taskDefinition == o.deployedBlueGreen.taskDefinition {
+		return nil
+	}
+	port, err := strconv.Atoi(updated.targetPort)
+	if err != nil {
+		return fmt.Errorf("parse target port %q of service %s: %w", updated.targetPort, o.name, err)
+	}
+	wait := manifest.DefaultBlueGreenTerminationWait
+	if bg.Config.TerminationWait != nil {
+		wait = *bg.Config.TerminationWait
+	}
+	o.spinner.Start(fmt.Sprintf("Deploying service %s with CodeDeploy blue/green.", color.HighlightUserInput(o.name)))
+	id, err := o.blueGreenDeployer.DeployECSService(codedeploy.ECSDeployment{
+		ApplicationName:     updated.application,
+		DeploymentGroupName: updated.deploymentGroup,
+		TaskDefinitionARN:   updated.taskDefinition,
+		ContainerName:       updated.targetContainer,
+		ContainerPort:       port,
+		Hooks:               bg.Config.Hooks.FunctionARNs(),
+	})
+	if err != nil {
+		o.spinner.Stop(log.Serrorf("Failed to start the blue/green deployment of service %s.\n", color.HighlightUserInput(o.name)))
+		return fmt.Errorf("deploy service %s with CodeDeploy: %w", o.name, err)
+	}
+	if err := o.blueGreenDeployer.WaitUntilDeploymentSucceeded(id, wait+blueGreenTrafficShiftTimeout); err != nil {
+		o.spinner.Stop(log.Serrorf("Blue/green deployment %s of service %s did not succeed.\n", id, color.HighlightUserInput(o.name)))
+		return fmt.Errorf("deploy service %s with CodeDeploy: %w", o.name, err)
+	}
+	o.spinner.Stop(log.Ssuccessf("Blue/green deployment %s of service %s succeeded.\n", id, color.HighlightUserInput(o.name)))
+	return nil
+}
+
+// waitUntilReady polls the "readiness" URL of the service, if any, until it returns the expected status code.
+func (o *deploySvcOpts) waitUntilReady() error {
+	mft, ok := o.appliedManifest.(interface {
+		ReadinessCheck() manifest.Readiness
+	})
+	if !ok {
+		return nil
+	}
+	check := mft.ReadinessCheck()
+	if check.IsEmpty() {
+		return nil
+	}
+	url, status := aws.StringValue(check.URL), readiness.DefaultStatus
+	if check.Status != nil {
+		status = aws.IntValue(check.Status)
+	}
+	o.spinner.Start(fmt.Sprintf("Waiting for %s to return status %d.", color.HighlightUserInput(url), status))
+	if err := o.readinessWaiter.Wait(url, status); err != nil {
+		o.spinner.Stop(log.Serrorf("Service %s is not ready.\n", color.HighlightUserInput(o.name)))
+		return fmt.Errorf("wait for service %s to be ready: %w", o.name, err)
+	}
+	o.spinner.Stop(log.Ssuccessf("Service %s is ready.\n", color.HighlightUserInput(o.name)))
 	return nil
 }
 
+// runPreDeployTask runs the "deployment.pre_deploy" task of the service, if any, and waits for it to succeed.
+// The task inherits the size, variables and secrets of the service, as well as its roles once the service is deployed.
+func (o *deploySvcOpts) runPreDeployTask() error {
+	mft, ok := o.appliedManifest.(interface {
+		PreDeployTask() manifest.PreDeployTask
+		PreDeployTaskConfig() manifest.TaskConfig
+	})
+	if !ok {
+		return nil
+	}
+	hook := mft.PreDeployTask()
+	if hook.IsEmpty() {
+		return nil
+	}
+	command, err := hook.Command.ToStringSlice()
+	if err != nil {
+		return fmt.Errorf(`convert 'deployment.pre_deploy.command' to string slice: %w`, err)
+	}
+	taskRole, executionRole, err := o.deployedServiceRoles()
+	if err != nil {
+		return err
+	}
+	cfg := mft.PreDeployTaskConfig()
+	log.Infof("Running the pre-deploy task of service %s in environment %s.\n", color.HighlightUserInput(o.name), color.HighlightUserInput(o.envName))
+	if err := o.preDeployRunner.Run(task.HookInput{
+		Name:          fmt.Sprintf("%s-%s-%s-pre-deploy", o.appName, o.envName, o.name),
+		Image:         aws.StringValue(hook.Image),
+		Command:       command,
+		CPU:           aws.IntValue(cfg.CPU),
+		Memory:        aws.IntValue(cfg.Memory),
+		EnvVars:       preDeployTaskEnvVars(cfg.Variables),
+		Secrets:       preDeployTaskSecrets(cfg.Secrets),
+		TaskRole:      taskRole,
+		ExecutionRole: executionRole,
+	}); err != nil {
+		return fmt.Errorf("run pre-deploy task of service %s: %w", o.name, err)
+	}
+	log.Successf("The pre-deploy task of service %s succeeded.\n", color.HighlightUserInput(o.name))
+	return nil
+}
+
+// deployedServiceRoles returns the ARNs of the task role and the execution role of the service in the environment.
+// It returns empty ARNs if the service isn't deployed yet.
+func (o *deploySvcOpts) deployedServiceRoles() (taskRole, executionRole string, err error) {
+	stackName := stack.NameForService(o.appName, o.envName, o.name)
+	exists, err := o.svcStackResources.Exists(stackName)
+	if err != nil {
+		return "", "", fmt.Errorf("check if stack %s exists: %w", stackName, err)
+	}
+	if !exists {
+		return "", "", nil
+	}
+	resources, err := o.svcStackResources.StackResources(stackName)
+	if err != nil {
+		return "", "", err
+	}
+	envRole, err := arn.Parse(o.targetEnvironment.ExecutionRoleARN)
+	if err != nil {
+		return "", "", fmt.Errorf("parse execution role ARN of environment %s: %w", o.envName, err)
+	}
+	roleARN := func(name string) string {
+		return arn.ARN{
+			Partition: envRole.Partition,
+			Service:   envRole.Service,
+			AccountID: envRole.AccountID,
+			Resource:  "role/" + name,
+		}.String()
+	}
+	for _, r := range resources {
+		switch aws.StringValue(r.LogicalResourceId) {
+		case "TaskRole":
+			taskRole = roleARN(aws.StringValue(r.PhysicalResourceId))
+		case "ExecutionRole":
+			executionRole = roleARN(aws.StringValue(r.PhysicalResourceId))
+		}
+	}
+	return taskRole, executionRole, nil
+}
+
+// preDeployTaskEnvVars returns the variables of the service that can be passed to the pre-deploy task.
+// Parameter-backed variables are resolved by CloudFormation, while imported variables are skipped.
+func preDeployTaskEnvVars(vars manifest.Variables) map[string]string {
+	if len(vars.Values) == 0 && len(vars.FromSSM) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(vars.Values)+len(vars.FromSSM))
+	for key, val := range vars.Values {
+		m[key] = val
+	}
+	for key, name := range vars.FromSSM {
+		m[key] = fmt.Sprintf("{{resolve:ssm:%s}}", name)
+	}
+	for key := range vars.Imports {
+		log.Warningf("Variable %s is imported from a CloudFormation export and is not passed to the pre-deploy task.\n", key)
+	}
+	return m
+}
+
+// preDeployTaskSecrets returns the secrets of the service that can be passed to the pre-deploy task.
+// Secrets served by the AWS AppConfig agent sidecar are skipped.
+func preDeployTaskSecrets(secrets map[string]manifest.Secret) map[string]string {
+	var m map[string]string
+	for name, secret := range secrets {
+		if secret.From == nil {
+			log.Warningf("Secret %s is served by the AWS AppConfig agent and is not passed to the pre-deploy task.\n", name)
+			continue
+		}
+		if m == nil {
+			m = make(map[string]string)
+		}
+		m[name] = aws.StringValue(secret.From)
+	}
+	return m
+}
+
 func (o *deploySvcOpts) forceDeploy() error {
+	if _, ok := o.blueGreenDeployment(); ok && o.deployedBlueGreen != nil {
+		// ECS rejects forced deployments of services deployed by CodeDeploy.
+		return o.deployBlueGreen(true)
+	}
 	// Force update the service if --force is set and change set is empty.
 	o.spinner.Start(fmt.Sprintf(fmtForceUpdateSvcStart, color.HighlightUserInput(o.name), color.HighlightUserInput(o.envName)))
 	if err := o.svcUpdater.ForceUpdateService(o.appName, o.envName, o.name); err != nil {
@@ -739,7 +1610,17 @@ func validateLBSvcAliasAndAppVersion(svcName string, aliases manifest.Alias, app
 		logAppVersionOutdatedError(svcName)
 		return err
 	}
+	// Aliases with a hosted zone have their records created in that zone, so they don't need to be under the app's domain.
+	inHostedZone := make(map[string]bool)
+	for _, hzAliases := range aliases.HostedZoneAliases() {
+		for _, alias := range hzAliases {
+			inHostedZone[alias] = true
+		}
+	}
 	for _, alias := range aliasList {
+		if inHostedZone[alias] {
+			continue
+		}
 		// Alias should be within either env, app, or root hosted zone.
 		var regEnvHostedZone, regAppHostedZone, regRootHostedZone *regexp.Regexp
 		var err error
@@ -923,11 +1804,13 @@ func (o *deploySvcOpts) subscribeRecommendedActions() []string {
 	if _, ok := o.appliedManifest.(subscriber); !ok {
 		return nil
 	}
-	retrieveEnvVarCode := "const eventsQueueURI = process.env.COPILOT_QUEUE_URI"
+	prefix := injectedEnvVarPrefix(o.appliedManifest)
+	retrieveEnvVarCode := fmt.Sprintf("const eventsQueueURI = process.env.%sQUEUE_URI", prefix)
 	actionRetrieveEnvVar := fmt.Sprintf(
-		`Update %s's code to leverage the injected environment variable "COPILOT_QUEUE_URI".
+		`Update %s's code to leverage the injected environment variable "%sQUEUE_URI".
     In JavaScript you can write %s.`,
 		o.name,
+		prefix,
 		color.HighlightCode(retrieveEnvVarCode),
 	)
 	recs := []string{actionRetrieveEnvVar}
@@ -935,7 +1818,7 @@ func (o *deploySvcOpts) subscribeRecommendedActions() []string {
 	if topicQueueNames == "" {
 		return recs
 	}
-	retrieveTopicQueueEnvVarCode := fmt.Sprintf("const {%s} = JSON.parse(process.env.COPILOT_TOPIC_QUEUE_URIS)", topicQueueNames)
+	retrieveTopicQueueEnvVarCode := fmt.Sprintf("const {%s} = JSON.parse(process.env.%sTOPIC_QUEUE_URIS)", topicQueueNames, prefix)
 	actionRetrieveTopicQueues := fmt.Sprintf(
 		`You can retrieve topic-specific queues by writing
     %s.`,
@@ -957,12 +1840,26 @@ func (o *deploySvcOpts) publishRecommendedActions() []string {
 		return nil
 	}
 
+	prefix := injectedEnvVarPrefix(o.appliedManifest)
 	return []string{
-		fmt.Sprintf(`Update %s's code to leverage the injected environment variable "COPILOT_SNS_TOPIC_ARNS".
+		fmt.Sprintf(`Update %s's code to leverage the injected environment variable "%sSNS_TOPIC_ARNS".
     In JavaScript you can write %s.`,
 			o.name,
-			color.HighlightCode("const {<topicName>} = JSON.parse(process.env.COPILOT_SNS_TOPIC_ARNS)")),
+			prefix,
+			color.HighlightCode(fmt.Sprintf("const {<topicName>} = JSON.parse(process.env.%sSNS_TOPIC_ARNS)", prefix))),
+	}
+}
+
+// injectedEnvVarPrefix returns the prefix of the environment variables that Copilot injects in the containers of the workload,
+// which is "env_var_prefix" if set in the manifest.
+func injectedEnvVarPrefix(mft interface{}) string {
+	type envVarPrefixer interface {
+		InjectedEnvVarPrefix() string
+	}
+	if wkld, ok := mft.(envVarPrefixer); ok {
+		return wkld.InjectedEnvVarPrefix()
 	}
+	return "COPILOT_"
 }
 
 func (o *deploySvcOpts) buildWorkerQueueNames() string {
@@ -973,7 +1870,7 @@ func (o *deploySvcOpts) buildWorkerQueueNames() string {
 			continue
 		}
 		topicSvc := template.StripNonAlphaNumFunc(aws.StringValue(subscription.Service))
-		topicName := template.StripNonAlphaNumFunc(aws.StringValue(subscription.Name))
+		topicName := template.StripNonAlphaNumFunc(subscription.TopicName())
 		subName := fmt.Sprintf("%s%sEventsQueue", topicSvc, strings.Title(topicName))
 		if first {
 			sb.WriteString(subName)
@@ -996,7 +1893,11 @@ func buildSvcDeployCmd() *cobra.Command {
   Deploys a service named "frontend" to a "test" environment.
   /code $ copilot svc deploy --name frontend --env test
   Deploys a service with additional resource tags.
-  /code $ copilot svc deploy --resource-tags source/revision=bb133e7,deployment/initiator=manual`,
+  /code $ copilot svc deploy --resource-tags source/revision=bb133e7,deployment/initiator=manual
+  Deploys a service only if it complies with an organization policy file.
+  /code $ copilot svc deploy --name frontend --env prod --policy policy.yml
+  Deploys a service only if its manifest specifies the subnets to place its tasks in.
+  /code $ copilot svc deploy --name frontend --env prod --require-placement`,
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			opts, err := newSvcDeployOpts(vars)
 			if err != nil {
@@ -1011,6 +1912,10 @@ func buildSvcDeployCmd() *cobra.Command {
 	cmd.Flags().StringVar(&vars.imageTag, imageTagFlag, "", imageTagFlagDescription)
 	cmd.Flags().StringToStringVar(&vars.resourceTags, resourceTagsFlag, nil, resourceTagsFlagDescription)
 	cmd.Flags().BoolVar(&vars.forceNewUpdate, forceFlag, false, forceFlagDescription)
+	cmd.Flags().BoolVar(&vars.disableRollback, disableRollbackFlag, false, disableRollbackFlagDescription)
+	cmd.Flags().DurationVar(&vars.stackTimeout, timeoutFlag, 0, stackTimeoutFlagDescription)
+	cmd.Flags().StringVar(&vars.policyPath, policyFlag, "", policyFlagDescription)
+	cmd.Flags().BoolVar(&vars.requirePlacement, requirePlacementFlag, false, requirePlacementFlagDescription)
 
 	return cmd
 }
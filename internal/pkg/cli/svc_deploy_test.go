@@ -6,8 +6,10 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/aws/copilot-cli/internal/pkg/describe"
 
@@ -16,17 +18,22 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/term/color"
 	"github.com/aws/copilot-cli/internal/pkg/term/log"
 
+	"github.com/aws/copilot-cli/internal/pkg/aws/ec2"
 	"github.com/aws/copilot-cli/internal/pkg/aws/ecs"
 	"github.com/aws/copilot-cli/internal/pkg/aws/identity"
 
 	"github.com/aws/aws-sdk-go/aws"
+	awscfn "github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/copilot-cli/internal/pkg/addon"
 	"github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/aws/codedeploy"
 	"github.com/aws/copilot-cli/internal/pkg/config"
 	"github.com/aws/copilot-cli/internal/pkg/deploy"
 	"github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation/stack"
 	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/aws/copilot-cli/internal/pkg/task"
 	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 
 	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
@@ -45,6 +52,12 @@ type deploySvcMocks struct {
 	mockDeployStore        *mocks.MockdeployedEnvironmentLister
 	mockEnvDescriber       *mocks.MockenvDescriber
 	mockSubnetLister       *mocks.MockvpcSubnetLister
+	mockDockerEngine       *mocks.MockdockerEngine
+	mockPreDeployRunner    *mocks.MockhookRunner
+	mockSvcStackResources  *mocks.MockstackResourcesGetter
+	mockSvcStackDescriber  *mocks.MockstackDescriber
+	mockBlueGreenDeployer  *mocks.MockblueGreenDeployer
+	mockReadinessWaiter    *mocks.MockreadinessWaiter
 }
 
 func TestSvcDeployOpts_Validate(t *testing.T) {
@@ -52,6 +65,7 @@ func TestSvcDeployOpts_Validate(t *testing.T) {
 		inAppName string
 		inEnvName string
 		inSvcName string
+		inTimeout time.Duration
 
 		mockWs    func(m *mocks.MockwsSvcDirReader)
 		mockStore func(m *mocks.Mockstore)
@@ -95,10 +109,19 @@ func TestSvcDeployOpts_Validate(t *testing.T) {
 
 			wantedError: errors.New("get environment test configuration: unknown env"),
 		},
+		"with a stack timeout that is too short": {
+			inAppName: "phonetool",
+			inTimeout: time.Minute,
+			mockWs:    func(m *mocks.MockwsSvcDirReader) {},
+			mockStore: func(m *mocks.Mockstore) {},
+
+			wantedError: errors.New("stack timeout value 1m0s is invalid: duration must be between 5m0s and 12h0m0s"),
+		},
 		"successful validation": {
 			inAppName: "phonetool",
 			inSvcName: "frontend",
 			inEnvName: "test",
+			inTimeout: 2 * time.Hour,
 			mockWs: func(m *mocks.MockwsSvcDirReader) {
 				m.EXPECT().ListServices().Return([]string{"frontend"}, nil)
 			},
@@ -124,6 +147,8 @@ func TestSvcDeployOpts_Validate(t *testing.T) {
 					appName: tc.inAppName,
 					name:    tc.inSvcName,
 					envName: tc.inEnvName,
+
+					stackTimeout: tc.inTimeout,
 				},
 				ws:    mockWs,
 				store: mockStore,
@@ -251,6 +276,20 @@ type: 'Load Balanced Web Service'
 image:
   location: foo/bar
   port: 80
+`)
+	mockMftBuildPlatforms := []byte(`name: serviceA
+type: 'Load Balanced Web Service'
+image:
+  build:
+    dockerfile: path/to/Dockerfile
+    context: path
+    platforms:
+      - osfamily: linux
+        architecture: x86_64
+      - osfamily: linux
+        architecture: arm64
+        dockerfile: path/to/arm/Dockerfile
+  port: 80
 `)
 	mockMftBuildString := []byte(`name: serviceA
 type: 'Load Balanced Web Service'
@@ -264,13 +303,28 @@ image:
   build:
     dockerfile: path/to/Dockerfile
   port: 80`)
+	mockMftInitContainer := []byte(`name: serviceA
+type: 'Load Balanced Web Service'
+image:
+  build:
+    dockerfile: path/to/Dockerfile
+    target: server
+  port: 80
+init_container:
+  image:
+    build:
+      dockerfile: path/to/Dockerfile
+      target: migrate
+  command: ./migrate up`)
 
 	tests := map[string]struct {
-		inputSvc   string
-		setupMocks func(mocks deploySvcMocks)
+		inputSvc           string
+		inRequirePlacement bool
+		setupMocks         func(mocks deploySvcMocks)
 
-		wantErr      error
-		wantedDigest string
+		wantErr          error
+		wantedDigest     string
+		wantedInitDigest string
 	}{
 		"should return error if ws ReadFile returns error": {
 			inputSvc: "serviceA",
@@ -291,6 +345,17 @@ image:
 			},
 			wantErr: fmt.Errorf("interpolate environment variables for serviceA manifest: %w", mockError),
 		},
+		"should return error if the placement is required but not specified": {
+			inputSvc:           "serviceA",
+			inRequirePlacement: true,
+			setupMocks: func(m deploySvcMocks) {
+				gomock.InOrder(
+					m.mockWs.EXPECT().ReadWorkloadManifest(gomock.Any()).Return(mockManifest, nil),
+					m.mockInterpolator.EXPECT().Interpolate(string(mockManifest)).Return(string(mockManifest), nil),
+				)
+			},
+			wantErr: errors.New(`validate service serviceA manifest: "network.vpc.placement" must be specified`),
+		},
 		"should return error if workspace methods fail": {
 			inputSvc: "serviceA",
 			setupMocks: func(m deploySvcMocks) {
@@ -319,6 +384,7 @@ image:
 					m.mockWs.EXPECT().ReadWorkloadManifest("serviceA").Return(mockManifestWithGoodPlatform, nil),
 					m.mockInterpolator.EXPECT().Interpolate(string(mockManifestWithGoodPlatform)).Return(string(mockManifestWithGoodPlatform), nil),
 					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("linux", "amd64", nil),
 					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
 						Dockerfile: filepath.Join("/ws", "root", "path", "to", "Dockerfile"),
 						Context:    filepath.Join("/ws", "root", "path"),
@@ -328,6 +394,27 @@ image:
 			},
 			wantedDigest: "sha256:741d3e95eefa2c3b594f970a938ed6e497b50b3541a5fdc28af3ad8959e76b49",
 		},
+		"success with an image built for every build platform": {
+			inputSvc: "serviceA",
+			setupMocks: func(m deploySvcMocks) {
+				gomock.InOrder(
+					m.mockWs.EXPECT().ReadWorkloadManifest("serviceA").Return(mockMftBuildPlatforms, nil),
+					m.mockInterpolator.EXPECT().Interpolate(string(mockMftBuildPlatforms)).Return(string(mockMftBuildPlatforms), nil),
+					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("linux", "amd64", nil),
+					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
+						Dockerfile: filepath.Join("/ws", "root", "path", "to", "Dockerfile"),
+						Context:    filepath.Join("/ws", "root", "path"),
+						Platform:   "linux/x86_64",
+					}, &dockerengine.BuildArguments{
+						Dockerfile: filepath.Join("/ws", "root", "path", "to", "arm", "Dockerfile"),
+						Context:    filepath.Join("/ws", "root", "path"),
+						Platform:   "linux/arm64",
+					}).Return("sha256:741d3e95eefa2c3b594f970a938ed6e497b50b3541a5fdc28af3ad8959e76b49", nil),
+				)
+			},
+			wantedDigest: "sha256:741d3e95eefa2c3b594f970a938ed6e497b50b3541a5fdc28af3ad8959e76b49",
+		},
 		"success without building and pushing": {
 			inputSvc: "serviceA",
 			setupMocks: func(m deploySvcMocks) {
@@ -339,6 +426,72 @@ image:
 				)
 			},
 		},
+		"should return error if fail to build and push the init container image": {
+			inputSvc: "serviceA",
+			setupMocks: func(m deploySvcMocks) {
+				gomock.InOrder(
+					m.mockWs.EXPECT().ReadWorkloadManifest("serviceA").Return(mockMftInitContainer, nil),
+					m.mockInterpolator.EXPECT().Interpolate(string(mockMftInitContainer)).Return(string(mockMftInitContainer), nil),
+					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), gomock.Any()).Return("", mockError),
+				)
+			},
+			wantErr: fmt.Errorf("build and push init container image: mockError"),
+		},
+		"success with an init container": {
+			inputSvc: "serviceA",
+			setupMocks: func(m deploySvcMocks) {
+				gomock.InOrder(
+					m.mockWs.EXPECT().ReadWorkloadManifest("serviceA").Return(mockMftInitContainer, nil),
+					m.mockInterpolator.EXPECT().Interpolate(string(mockMftInitContainer)).Return(string(mockMftInitContainer), nil),
+					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
+						Dockerfile: filepath.Join("/ws", "root", "path", "to", "Dockerfile"),
+						Context:    filepath.Join("/ws", "root", "path", "to"),
+						Target:     "migrate",
+						Tags:       []string{"init"},
+					}).Return("sha256:1111111111111111111111111111111111111111111111111111111111111111", nil),
+					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("linux", "amd64", nil),
+					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
+						Dockerfile: filepath.Join("/ws", "root", "path", "to", "Dockerfile"),
+						Context:    filepath.Join("/ws", "root", "path", "to"),
+						Target:     "server",
+					}).Return("sha256:741d3e95eefa2c3b594f970a938ed6e497b50b3541a5fdc28af3ad8959e76b49", nil),
+				)
+			},
+			wantedDigest:     "sha256:741d3e95eefa2c3b594f970a938ed6e497b50b3541a5fdc28af3ad8959e76b49",
+			wantedInitDigest: "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+		},
+		"should return error if fail to get the platform of the docker engine": {
+			inputSvc: "serviceA",
+			setupMocks: func(m deploySvcMocks) {
+				gomock.InOrder(
+					m.mockWs.EXPECT().ReadWorkloadManifest("serviceA").Return(mockManifest, nil),
+					m.mockInterpolator.EXPECT().Interpolate(string(mockManifest)).Return(string(mockManifest), nil),
+					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("", "", mockError),
+					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), gomock.Any()).Times(0),
+				)
+			},
+			wantErr: fmt.Errorf("get docker engine platform: mockError"),
+		},
+		"success on a host whose platform differs from the tasks": {
+			inputSvc: "serviceA",
+			setupMocks: func(m deploySvcMocks) {
+				gomock.InOrder(
+					m.mockWs.EXPECT().ReadWorkloadManifest("serviceA").Return(mockManifest, nil),
+					m.mockInterpolator.EXPECT().Interpolate(string(mockManifest)).Return(string(mockManifest), nil),
+					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("linux", "arm64", nil),
+					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
+						Dockerfile: filepath.Join("/ws", "root", "path", "to", "Dockerfile"),
+						Context:    filepath.Join("/ws", "root", "path"),
+					}).Return("sha256:741d3e95eefa2c3b594f970a938ed6e497b50b3541a5fdc28af3ad8959e76b49", nil),
+				)
+			},
+			wantedDigest: "sha256:741d3e95eefa2c3b594f970a938ed6e497b50b3541a5fdc28af3ad8959e76b49",
+		},
 		"should return error if fail to build and push": {
 			inputSvc: "serviceA",
 			setupMocks: func(m deploySvcMocks) {
@@ -346,6 +499,7 @@ image:
 					m.mockWs.EXPECT().ReadWorkloadManifest("serviceA").Return(mockManifest, nil),
 					m.mockInterpolator.EXPECT().Interpolate(string(mockManifest)).Return(string(mockManifest), nil),
 					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("linux", "amd64", nil),
 					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), gomock.Any()).Return("", mockError),
 				)
 			},
@@ -358,6 +512,7 @@ image:
 					m.mockWs.EXPECT().ReadWorkloadManifest("serviceA").Return(mockManifest, nil),
 					m.mockInterpolator.EXPECT().Interpolate(string(mockManifest)).Return(string(mockManifest), nil),
 					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("linux", "amd64", nil),
 					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
 						Dockerfile: filepath.Join("/ws", "root", "path", "to", "Dockerfile"),
 						Context:    filepath.Join("/ws", "root", "path"),
@@ -373,6 +528,7 @@ image:
 					m.mockWs.EXPECT().ReadWorkloadManifest("serviceA").Return(mockMftBuildString, nil),
 					m.mockInterpolator.EXPECT().Interpolate(string(mockMftBuildString)).Return(string(mockMftBuildString), nil),
 					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("linux", "amd64", nil),
 					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
 						Dockerfile: filepath.Join("/ws", "root", "path", "to", "Dockerfile"),
 						Context:    filepath.Join("/ws", "root", "path", "to"),
@@ -388,6 +544,7 @@ image:
 					m.mockWs.EXPECT().ReadWorkloadManifest("serviceA").Return(mockMftNoContext, nil),
 					m.mockInterpolator.EXPECT().Interpolate(string(mockMftNoContext)).Return(string(mockMftNoContext), nil),
 					m.mockWs.EXPECT().CopilotDirPath().Return("/ws/root/copilot", nil),
+					m.mockDockerEngine.EXPECT().GetPlatform().Return("linux", "amd64", nil),
 					m.mockimageBuilderPusher.EXPECT().BuildAndPush(gomock.Any(), &dockerengine.BuildArguments{
 						Dockerfile: filepath.Join("/ws", "root", "path", "to", "Dockerfile"),
 						Context:    filepath.Join("/ws", "root", "path", "to"),
@@ -406,19 +563,23 @@ image:
 			mockWorkspace := mocks.NewMockwsSvcDirReader(ctrl)
 			mockimageBuilderPusher := mocks.NewMockimageBuilderPusher(ctrl)
 			mockInterpolator := mocks.NewMockinterpolator(ctrl)
+			mockDockerEngine := mocks.NewMockdockerEngine(ctrl)
 			mocks := deploySvcMocks{
 				mockWs:                 mockWorkspace,
 				mockimageBuilderPusher: mockimageBuilderPusher,
 				mockInterpolator:       mockInterpolator,
+				mockDockerEngine:       mockDockerEngine,
 			}
 			test.setupMocks(mocks)
 			opts := deploySvcOpts{
 				deployWkldVars: deployWkldVars{
-					name: test.inputSvc,
+					name:             test.inputSvc,
+					requirePlacement: test.inRequirePlacement,
 				},
 				unmarshal:          manifest.UnmarshalWorkload,
 				imageBuilderPusher: mockimageBuilderPusher,
 				ws:                 mockWorkspace,
+				dockerEngine:       mockDockerEngine,
 				newInterpolator: func(app, env string) interpolator {
 					return mockInterpolator
 				},
@@ -431,6 +592,7 @@ image:
 			} else {
 				require.NoError(t, gotErr)
 				require.Equal(t, test.wantedDigest, opts.imageDigest)
+				require.Equal(t, test.wantedInitDigest, opts.initImageDigest)
 			}
 		})
 	}
@@ -596,15 +758,66 @@ func TestSvcDeployOpts_deploySvc(t *testing.T) {
 		mockEnvName   = "mockEnv"
 		mockSvcName   = "mockSvc"
 		mockAddonsURL = "mockAddonsURL"
+
+		mockServiceTaskDef  = "arn:aws:ecs:us-west-2:123456789012:task-definition/mockApp-mockEnv-mockSvc:1"
+		mockDeployedTaskDef = "arn:aws:ecs:us-west-2:123456789012:task-definition/mockApp-mockEnv-mockSvc:2"
+		mockUpdatedTaskDef  = "arn:aws:ecs:us-west-2:123456789012:task-definition/mockApp-mockEnv-mockSvc:3"
 	)
+	blueGreenStack := func(taskDef string) *cloudformation.StackDescription {
+		return &cloudformation.StackDescription{
+			Outputs: []*awscfn.Output{
+				{OutputKey: aws.String("ServiceTaskDefinition"), OutputValue: aws.String(mockServiceTaskDef)},
+				{OutputKey: aws.String("TaskDefinition"), OutputValue: aws.String(taskDef)},
+				{OutputKey: aws.String("CodeDeployApplication"), OutputValue: aws.String("mockApplication")},
+				{OutputKey: aws.String("CodeDeployDeploymentGroup"), OutputValue: aws.String("mockDeploymentGroup")},
+			},
+			Parameters: []*awscfn.Parameter{
+				{ParameterKey: aws.String("TargetContainer"), ParameterValue: aws.String(mockSvcName)},
+				{ParameterKey: aws.String("TargetPort"), ParameterValue: aws.String("80")},
+			},
+		}
+	}
+	blueGreen := manifest.DeploymentConfig{
+		BlueGreen: manifest.BlueGreenConfigOrBool{
+			Enable: aws.Bool(true),
+			Config: manifest.BlueGreenConfig{
+				Hooks: manifest.BlueGreenHooks{
+					AfterAllowTestTraffic: aws.String("arn:aws:lambda:us-west-2:123456789012:function:integ-test"),
+				},
+			},
+		},
+	}
+	mockEnv := &config.Environment{
+		Name:   mockEnvName,
+		Region: "us-west-2",
+	}
+	mockApp := &config.Application{
+		Name: mockAppName,
+	}
+	wantedECSDeployment := codedeploy.ECSDeployment{
+		ApplicationName:     "mockApplication",
+		DeploymentGroupName: "mockDeploymentGroup",
+		TaskDefinitionARN:   mockUpdatedTaskDef,
+		ContainerName:       mockSvcName,
+		ContainerPort:       80,
+		Hooks: map[string]string{
+			"AfterAllowTestTraffic": "arn:aws:lambda:us-west-2:123456789012:function:integ-test",
+		},
+	}
 	tests := map[string]struct {
 		inAliases      manifest.Alias
 		inNLB          manifest.NetworkLoadBalancerConfiguration
+		inDeployment   manifest.DeploymentConfig
+		inTaskConfig   manifest.TaskConfig
+		inReadiness    manifest.Readiness
 		inApp          *config.Application
 		inEnvironment  *config.Environment
 		inBuildRequire bool
 		inForceDeploy  bool
 
+		inDisableRollback bool
+		inStackTimeout    time.Duration
+
 		mock func(m *deploySvcMocks)
 
 		wantErr error
@@ -779,6 +992,34 @@ func TestSvcDeployOpts_deploySvc(t *testing.T) {
 			},
 			wantErr: fmt.Errorf(`alias "v1.v2.mockDomain" is not supported in hosted zones managed by Copilot`),
 		},
+		"fail to enable https alias only because of invalid aliases without a hosted zone": {
+			inAliases: manifest.Alias{
+				AdvancedAliases: []manifest.AdvancedAlias{
+					{
+						Alias:      aws.String("api.example.com"),
+						HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+					},
+					{
+						Alias: aws.String("v1.v2.mockDomain"),
+					},
+				},
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+			},
+			inApp: &config.Application{
+				Name:   mockAppName,
+				Domain: "mockDomain",
+			},
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockAppVersionGetter.EXPECT().Version().Return("v1.0.0", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+			},
+			wantErr: fmt.Errorf(`alias "v1.v2.mockDomain" is not supported in hosted zones managed by Copilot`),
+		},
 		"error if fail to deploy service": {
 			inEnvironment: &config.Environment{
 				Name:   mockEnvName,
@@ -905,6 +1146,314 @@ func TestSvcDeployOpts_deploySvc(t *testing.T) {
 				m.mockSpinner.EXPECT().Stop(log.Ssuccessf(fmtForceUpdateSvcComplete, mockSvcName, mockEnvName))
 			},
 		},
+		"error if the pre-deploy task fails": {
+			inDeployment: manifest.DeploymentConfig{
+				PreDeploy: manifest.PreDeployTask{
+					Image:   aws.String("migrate/migrate:v4"),
+					Command: manifest.CommandOverride{StringSlice: []string{"up"}},
+				},
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+			},
+			inApp: &config.Application{
+				Name: mockAppName,
+			},
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockSvcStackResources.EXPECT().Exists("mockApp-mockEnv-mockSvc").Return(false, nil)
+				m.mockPreDeployRunner.EXPECT().Run(gomock.Any()).Return(mockError)
+				m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: fmt.Errorf("run pre-deploy task of service mockSvc: some error"),
+		},
+		"error if fail to get the roles of the service for the pre-deploy task": {
+			inDeployment: manifest.DeploymentConfig{
+				PreDeploy: manifest.PreDeployTask{
+					Image:   aws.String("migrate/migrate:v4"),
+					Command: manifest.CommandOverride{StringSlice: []string{"up"}},
+				},
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+			},
+			inApp: &config.Application{
+				Name: mockAppName,
+			},
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockSvcStackResources.EXPECT().Exists("mockApp-mockEnv-mockSvc").Return(false, mockError)
+				m.mockPreDeployRunner.EXPECT().Run(gomock.Any()).Times(0)
+			},
+			wantErr: fmt.Errorf("check if stack mockApp-mockEnv-mockSvc exists: some error"),
+		},
+		"success with a pre-deploy task before the first deployment": {
+			inDeployment: manifest.DeploymentConfig{
+				PreDeploy: manifest.PreDeployTask{
+					Image:   aws.String("migrate/migrate:v4"),
+					Command: manifest.CommandOverride{StringSlice: []string{"up"}},
+				},
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+			},
+			inApp: &config.Application{
+				Name: mockAppName,
+			},
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockSvcStackResources.EXPECT().Exists("mockApp-mockEnv-mockSvc").Return(false, nil)
+				m.mockPreDeployRunner.EXPECT().Run(task.HookInput{
+					Name:    "mockApp-mockEnv-mockSvc-pre-deploy",
+					Image:   "migrate/migrate:v4",
+					Command: []string{"up"},
+				}).Return(nil)
+				m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		"success with a pre-deploy task": {
+			inDeployment: manifest.DeploymentConfig{
+				PreDeploy: manifest.PreDeployTask{
+					Image:   aws.String("migrate/migrate:v4"),
+					Command: manifest.CommandOverride{String: aws.String("up 1")},
+				},
+			},
+			inTaskConfig: manifest.TaskConfig{
+				CPU:    aws.Int(1024),
+				Memory: aws.Int(2048),
+				Variables: manifest.Variables{
+					Values:  map[string]string{"DB_NAME": "orders"},
+					FromSSM: map[string]string{"DB_HOST": "/orders/db-host"},
+				},
+				Secrets: map[string]manifest.Secret{
+					"DB_PASSWORD": {From: aws.String("/copilot/mockApp/mockEnv/secrets/db-password")},
+				},
+			},
+			inEnvironment: &config.Environment{
+				Name:             mockEnvName,
+				Region:           "us-west-2",
+				ExecutionRoleARN: "arn:aws:iam::123456789012:role/mockApp-mockEnv-CFNExecutionRole",
+			},
+			inApp: &config.Application{
+				Name: mockAppName,
+			},
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockSvcStackResources.EXPECT().Exists("mockApp-mockEnv-mockSvc").Return(true, nil)
+				m.mockSvcStackResources.EXPECT().StackResources("mockApp-mockEnv-mockSvc").Return([]*cloudformation.StackResource{
+					{
+						LogicalResourceId:  aws.String("TaskRole"),
+						PhysicalResourceId: aws.String("mockApp-mockEnv-mockSvc-TaskRole-1A2B3C"),
+					},
+					{
+						LogicalResourceId:  aws.String("ExecutionRole"),
+						PhysicalResourceId: aws.String("mockApp-mockEnv-mockSvc-ExecutionRole-4D5E6F"),
+					},
+					{
+						LogicalResourceId:  aws.String("Service"),
+						PhysicalResourceId: aws.String("arn:aws:ecs:us-west-2:123456789012:service/mockApp-mockEnv-Cluster/mockApp-mockEnv-mockSvc"),
+					},
+				}, nil)
+				gomock.InOrder(
+					m.mockPreDeployRunner.EXPECT().Run(task.HookInput{
+						Name:    "mockApp-mockEnv-mockSvc-pre-deploy",
+						Image:   "migrate/migrate:v4",
+						Command: []string{"up", "1"},
+						CPU:     1024,
+						Memory:  2048,
+						EnvVars: map[string]string{
+							"DB_NAME": "orders",
+							"DB_HOST": "{{resolve:ssm:/orders/db-host}}",
+						},
+						Secrets: map[string]string{
+							"DB_PASSWORD": "/copilot/mockApp/mockEnv/secrets/db-password",
+						},
+						TaskRole:      "arn:aws:iam::123456789012:role/mockApp-mockEnv-mockSvc-TaskRole-1A2B3C",
+						ExecutionRole: "arn:aws:iam::123456789012:role/mockApp-mockEnv-mockSvc-ExecutionRole-4D5E6F",
+					}).Return(nil),
+					m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				)
+			},
+		},
+		"error if the service does not become ready": {
+			inReadiness: manifest.Readiness{
+				URL: aws.String("https://example.com/ready"),
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+			},
+			inApp: &config.Application{
+				Name: mockAppName,
+			},
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.mockSpinner.EXPECT().Start(gomock.Any())
+				m.mockReadinessWaiter.EXPECT().Wait("https://example.com/ready", 200).Return(mockError)
+				m.mockSpinner.EXPECT().Stop(log.Serrorf("Service %s is not ready.\n", mockSvcName))
+			},
+			wantErr: fmt.Errorf("wait for service mockSvc to be ready: some error"),
+		},
+		"success with a readiness check": {
+			inReadiness: manifest.Readiness{
+				URL:    aws.String("https://example.com/ready"),
+				Status: aws.Int(204),
+			},
+			inEnvironment: &config.Environment{
+				Name:   mockEnvName,
+				Region: "us-west-2",
+			},
+			inApp: &config.Application{
+				Name: mockAppName,
+			},
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				gomock.InOrder(
+					m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					m.mockSpinner.EXPECT().Start(gomock.Any()),
+					m.mockReadinessWaiter.EXPECT().Wait("https://example.com/ready", 204).Return(nil),
+					m.mockSpinner.EXPECT().Stop(log.Ssuccessf("Service %s is ready.\n", mockSvcName)),
+				)
+			},
+		},
+		"should let CloudFormation create a new blue/green service": {
+			inDeployment:  blueGreen,
+			inEnvironment: mockEnv,
+			inApp:         mockApp,
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockSvcStackDescriber.EXPECT().Describe("mockApp-mockEnv-mockSvc").Return(nil, &cloudformation.ErrStackNotFound{})
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		"error if the stack of a blue/green service cannot be described": {
+			inDeployment:  blueGreen,
+			inEnvironment: mockEnv,
+			inApp:         mockApp,
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockSvcStackDescriber.EXPECT().Describe("mockApp-mockEnv-mockSvc").Return(nil, mockError)
+			},
+			wantErr: mockError,
+		},
+		"should not create a CodeDeploy deployment if the task definition did not change": {
+			inDeployment:  blueGreen,
+			inEnvironment: mockEnv,
+			inApp:         mockApp,
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				gomock.InOrder(
+					m.mockSvcStackDescriber.EXPECT().Describe("mockApp-mockEnv-mockSvc").Return(blueGreenStack(mockDeployedTaskDef), nil),
+					m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					m.mockSvcStackDescriber.EXPECT().Describe("mockApp-mockEnv-mockSvc").Return(blueGreenStack(mockDeployedTaskDef), nil),
+				)
+			},
+		},
+		"error if the CodeDeploy deployment fails": {
+			inDeployment:  blueGreen,
+			inEnvironment: mockEnv,
+			inApp:         mockApp,
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				gomock.InOrder(
+					m.mockSvcStackDescriber.EXPECT().Describe("mockApp-mockEnv-mockSvc").Return(blueGreenStack(mockDeployedTaskDef), nil),
+					m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					m.mockSvcStackDescriber.EXPECT().Describe("mockApp-mockEnv-mockSvc").Return(blueGreenStack(mockUpdatedTaskDef), nil),
+					m.mockSpinner.EXPECT().Start("Deploying service mockSvc with CodeDeploy blue/green."),
+					m.mockBlueGreenDeployer.EXPECT().DeployECSService(wantedECSDeployment).Return("d-ABCDEF", nil),
+					m.mockBlueGreenDeployer.EXPECT().WaitUntilDeploymentSucceeded("d-ABCDEF", 65*time.Minute).Return(mockError),
+					m.mockSpinner.EXPECT().Stop(log.Serrorf("Blue/green deployment d-ABCDEF of service mockSvc did not succeed.\n")),
+				)
+			},
+			wantErr: fmt.Errorf("deploy service mockSvc with CodeDeploy: some error"),
+		},
+		"should roll out the updated task definition of a blue/green service with CodeDeploy": {
+			inDeployment:  blueGreen,
+			inEnvironment: mockEnv,
+			inApp:         mockApp,
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				gomock.InOrder(
+					m.mockSvcStackDescriber.EXPECT().Describe("mockApp-mockEnv-mockSvc").Return(blueGreenStack(mockDeployedTaskDef), nil),
+					m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					m.mockSvcStackDescriber.EXPECT().Describe("mockApp-mockEnv-mockSvc").Return(blueGreenStack(mockUpdatedTaskDef), nil),
+					m.mockSpinner.EXPECT().Start("Deploying service mockSvc with CodeDeploy blue/green."),
+					m.mockBlueGreenDeployer.EXPECT().DeployECSService(wantedECSDeployment).Return("d-ABCDEF", nil),
+					m.mockBlueGreenDeployer.EXPECT().WaitUntilDeploymentSucceeded("d-ABCDEF", 65*time.Minute).Return(nil),
+					m.mockSpinner.EXPECT().Stop(log.Ssuccessf("Blue/green deployment d-ABCDEF of service mockSvc succeeded.\n")),
+				)
+			},
+		},
+		"should force a CodeDeploy deployment of a blue/green service if the change set is empty": {
+			inDeployment:  blueGreen,
+			inForceDeploy: true,
+			inEnvironment: mockEnv,
+			inApp:         mockApp,
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				gomock.InOrder(
+					m.mockSvcStackDescriber.EXPECT().Describe("mockApp-mockEnv-mockSvc").Return(blueGreenStack(mockUpdatedTaskDef), nil),
+					m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), gomock.Any()).Return(cloudformation.NewMockErrChangeSetEmpty()),
+					m.mockSvcStackDescriber.EXPECT().Describe("mockApp-mockEnv-mockSvc").Return(blueGreenStack(mockUpdatedTaskDef), nil),
+					m.mockSpinner.EXPECT().Start("Deploying service mockSvc with CodeDeploy blue/green."),
+					m.mockBlueGreenDeployer.EXPECT().DeployECSService(wantedECSDeployment).Return("d-ABCDEF", nil),
+					m.mockBlueGreenDeployer.EXPECT().WaitUntilDeploymentSucceeded("d-ABCDEF", 65*time.Minute).Return(nil),
+					m.mockSpinner.EXPECT().Stop(log.Ssuccessf("Blue/green deployment d-ABCDEF of service mockSvc succeeded.\n")),
+				)
+			},
+		},
+		"success with rollback disabled and a stack timeout": {
+			inDisableRollback: true,
+			inStackTimeout:    30 * time.Minute,
+			inEnvironment: &config.Environment{
+				Name:             mockEnvName,
+				Region:           "us-west-2",
+				ExecutionRoleARN: "mockExecutionRoleARN",
+			},
+			inApp: &config.Application{
+				Name: mockAppName,
+			},
+			mock: func(m *deploySvcMocks) {
+				m.mockWs.EXPECT().ReadWorkloadManifest(mockSvcName).Return([]byte{}, nil)
+				m.mockInterpolator.EXPECT().Interpolate("").Return("", nil)
+				m.mockEndpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return("mockApp.local", nil)
+				m.mockServiceDeployer.EXPECT().DeployService(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_, _ interface{}, opts ...cloudformation.StackOption) error {
+						s := cloudformation.NewStack("mockStack", "", opts...)
+						require.Equal(t, aws.String("mockExecutionRoleARN"), s.RoleARN)
+						require.Equal(t, aws.Bool(true), s.DisableRollback)
+						require.Equal(t, 30*time.Minute, s.Timeout)
+						return nil
+					})
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -923,6 +1472,11 @@ func TestSvcDeployOpts_deploySvc(t *testing.T) {
 				mockInterpolator:       mocks.NewMockinterpolator(ctrl),
 				mockEnvDescriber:       mocks.NewMockenvDescriber(ctrl),
 				mockSubnetLister:       mocks.NewMockvpcSubnetLister(ctrl),
+				mockPreDeployRunner:    mocks.NewMockhookRunner(ctrl),
+				mockSvcStackResources:  mocks.NewMockstackResourcesGetter(ctrl),
+				mockSvcStackDescriber:  mocks.NewMockstackDescriber(ctrl),
+				mockBlueGreenDeployer:  mocks.NewMockblueGreenDeployer(ctrl),
+				mockReadinessWaiter:    mocks.NewMockreadinessWaiter(ctrl),
 			}
 			tc.mock(m)
 
@@ -932,6 +1486,9 @@ func TestSvcDeployOpts_deploySvc(t *testing.T) {
 					appName:        mockAppName,
 					envName:        mockEnvName,
 					forceNewUpdate: tc.inForceDeploy,
+
+					disableRollback: tc.inDisableRollback,
+					stackTimeout:    tc.inStackTimeout,
 				},
 				ws:            m.mockWs,
 				buildRequired: tc.inBuildRequire,
@@ -959,19 +1516,27 @@ func TestSvcDeployOpts_deploySvc(t *testing.T) {
 									Port: aws.Uint16(80),
 								},
 							},
+							TaskConfig: tc.inTaskConfig,
 							RoutingRule: manifest.RoutingRule{
 								Alias: tc.inAliases,
 							},
-							NLBConfig: tc.inNLB,
+							NLBConfig:    tc.inNLB,
+							DeployConfig: tc.inDeployment,
+							Readiness:    tc.inReadiness,
 						},
 					}, nil
 				},
-				svcCFN:        m.mockServiceDeployer,
-				svcUpdater:    m.mockServiceUpdater,
-				newSvcUpdater: func(f func(*session.Session) serviceUpdater) {},
-				spinner:       m.mockSpinner,
-				envDescriber:  m.mockEnvDescriber,
-				subnetLister:  m.mockSubnetLister,
+				svcCFN:            m.mockServiceDeployer,
+				svcUpdater:        m.mockServiceUpdater,
+				newSvcUpdater:     func(f func(*session.Session) serviceUpdater) {},
+				spinner:           m.mockSpinner,
+				envDescriber:      m.mockEnvDescriber,
+				subnetLister:      m.mockSubnetLister,
+				preDeployRunner:   m.mockPreDeployRunner,
+				svcStackResources: m.mockSvcStackResources,
+				svcStackDescriber: m.mockSvcStackDescriber,
+				blueGreenDeployer: m.mockBlueGreenDeployer,
+				readinessWaiter:   m.mockReadinessWaiter,
 			}
 
 			gotErr := opts.deploySvc(mockAddonsURL)
@@ -1416,3 +1981,594 @@ func TestSvcDeployOpts_stackConfiguration_worker(t *testing.T) {
 		})
 	}
 }
+
+func Test_scalingCalendars(t *testing.T) {
+	const envManifest = `
+scaling_calendars:
+  business-hours:
+    - schedule: cron(0 8 ? * MON-FRI *)
+      range: 2-10
+`
+	businessHours := manifest.IntRangeBand("2-10")
+	newWorker := func(names ...string) *manifest.WorkerService {
+		var scheduled []manifest.ScheduledScaling
+		for _, name := range names {
+			scheduled = append(scheduled, manifest.ScheduledScaling{Calendar: aws.String(name)})
+		}
+		mft := &manifest.WorkerService{}
+		mft.Count.AdvancedCount.Scheduled = scheduled
+		return mft
+	}
+	testCases := map[string]struct {
+		mft  interface{}
+		read func(path string) ([]byte, error)
+
+		wanted    map[string]manifest.ScalingCalendar
+		wantedErr error
+	}{
+		"should not read the environment manifest without references": {
+			mft: newWorker(),
+			read: func(path string) ([]byte, error) {
+				return nil, errors.New("unexpected read")
+			},
+		},
+		"should return the calendars of the environment manifest": {
+			mft: newWorker("business-hours"),
+			read: func(path string) ([]byte, error) {
+				require.Equal(t, filepath.Join("copilot", "environments", "test", "manifest.yml"), path)
+				return []byte(envManifest), nil
+			},
+			wanted: map[string]manifest.ScalingCalendar{
+				"business-hours": {
+					{Schedule: aws.String("cron(0 8 ? * MON-FRI *)"), Range: &businessHours},
+				},
+			},
+		},
+		"should return an error if a referenced calendar is not defined": {
+			mft: newWorker("holidays"),
+			read: func(path string) ([]byte, error) {
+				return []byte(envManifest), nil
+			},
+			wantedErr: errors.New(`validate manifest against environment test: scaling calendar "holidays" referenced by "count.scheduled" is not defined in the environment manifest`),
+		},
+		"should return an error if the environment manifest doesn't exist": {
+			mft: newWorker("business-hours"),
+			read: func(path string) ([]byte, error) {
+				return nil, fmt.Errorf("open %s: %w", path, os.ErrNotExist)
+			},
+			wantedErr: errors.New(`validate manifest against environment test: scaling calendar "business-hours" referenced by "count.scheduled" is not defined in the environment manifest`),
+		},
+		"should return an error if the environment manifest is invalid": {
+			mft: newWorker("business-hours"),
+			read: func(path string) ([]byte, error) {
+				return []byte("scaling_calendars:\n  business-hours: []\n"), nil
+			},
+			wantedErr: errors.New(`validate environment test manifest: validate "scaling_calendars.business-hours": must have at least one scheduled action`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := scalingCalendars(tc.mft, "test", tc.read)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func Test_buildConfigForPlatform(t *testing.T) {
+	amd64Config := &manifest.DockerBuildArgs{
+		Dockerfile: aws.String("Dockerfile"),
+		Platform:   aws.String("linux/x86_64"),
+	}
+	arm64Config := &manifest.DockerBuildArgs{
+		Dockerfile: aws.String("arm/Dockerfile"),
+		Platform:   aws.String("linux/arm64"),
+	}
+	testCases := map[string]struct {
+		inConfigs  []*manifest.DockerBuildArgs
+		inPlatform string
+
+		wantedConfig   *manifest.DockerBuildArgs
+		wantedPlatform string
+		wantedErr      error
+	}{
+		"should return the only configuration without build platforms": {
+			inConfigs: []*manifest.DockerBuildArgs{
+				{
+					Dockerfile: aws.String("Dockerfile"),
+				},
+			},
+			inPlatform: "linux/arm64",
+
+			wantedConfig: &manifest.DockerBuildArgs{
+				Dockerfile: aws.String("Dockerfile"),
+			},
+			wantedPlatform: "linux/arm64",
+		},
+		"should fall back to the default platform": {
+			inConfigs: []*manifest.DockerBuildArgs{arm64Config, amd64Config},
+
+			wantedConfig:   amd64Config,
+			wantedPlatform: "linux/x86_64",
+		},
+		"should select the configuration matching the task platform": {
+			inConfigs:  []*manifest.DockerBuildArgs{amd64Config, arm64Config},
+			inPlatform: "linux/arm64",
+
+			wantedConfig:   arm64Config,
+			wantedPlatform: "linux/arm64",
+		},
+		"should return an error if no configuration matches": {
+			inConfigs: []*manifest.DockerBuildArgs{arm64Config},
+
+			wantedErr: errors.New(`no "build.platforms" entry matches the task platform linux/amd64`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config, platform, err := buildConfigForPlatform(tc.inConfigs, tc.inPlatform)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedConfig, config)
+			require.Equal(t, tc.wantedPlatform, platform)
+		})
+	}
+}
+
+func Test_hostPlatformMismatch(t *testing.T) {
+	testCases := map[string]struct {
+		inArgs     *manifest.DockerBuildArgs
+		inPlatform string
+
+		wantedHost         string
+		wantedTaskPlatform string
+		wantedMismatch     bool
+	}{
+		"no mismatch if the platform of the host is unknown": {
+			inArgs: &manifest.DockerBuildArgs{},
+		},
+		"no mismatch if the image is built for the platform of the tasks": {
+			inArgs: &manifest.DockerBuildArgs{
+				HostPlatform: aws.String("linux/arm64"),
+			},
+			inPlatform: "linux/arm64",
+		},
+		"no mismatch if the host runs the default platform of the tasks": {
+			inArgs: &manifest.DockerBuildArgs{
+				HostPlatform: aws.String("linux/x86_64"),
+			},
+		},
+		"mismatch if the host doesn't run the default platform of the tasks": {
+			inArgs: &manifest.DockerBuildArgs{
+				HostPlatform: aws.String("linux/arm64"),
+			},
+			wantedHost:         "linux/arm64",
+			wantedTaskPlatform: "linux/amd64",
+			wantedMismatch:     true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			host, taskPlatform, mismatch := hostPlatformMismatch(tc.inArgs, tc.inPlatform)
+
+			require.Equal(t, tc.wantedHost, host)
+			require.Equal(t, tc.wantedTaskPlatform, taskPlatform)
+			require.Equal(t, tc.wantedMismatch, mismatch)
+		})
+	}
+}
+
+func Test_subnetsFromTags(t *testing.T) {
+	mftWithTags := func() *manifest.BackendService {
+		mft := &manifest.BackendService{}
+		mft.Network.VPC.Subnets.FromTags = map[string]string{
+			"tier": "private",
+			"team": "payments",
+		}
+		return mft
+	}
+	testCases := map[string]struct {
+		inManifest interface{}
+		setUpMocks func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister)
+
+		wanted    []string
+		wantedErr error
+	}{
+		"should return nil if the manifest doesn't select subnets by tags": {
+			inManifest: &manifest.BackendService{},
+			setUpMocks: func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister) {},
+		},
+		"should return nil for a manifest without network configuration": {
+			inManifest: &manifest.RequestDrivenWebService{},
+			setUpMocks: func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister) {},
+		},
+		"should return an error if the environment can't be described": {
+			inManifest: mftWithTags(),
+			setUpMocks: func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister) {
+				describer.EXPECT().Describe().Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("describe environment: some error"),
+		},
+		"should return an error if no subnet matches the tags": {
+			inManifest: mftWithTags(),
+			setUpMocks: func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister) {
+				describer.EXPECT().Describe().Return(&describe.EnvDescription{
+					EnvironmentVPC: describe.EnvironmentVPC{
+						ID: "vpc-1234",
+					},
+				}, nil)
+				lister.EXPECT().SubnetIDs(gomock.Any()).Return(nil, nil)
+			},
+			wantedErr: errors.New(`no subnets of vpc vpc-1234 match the tags of "network.vpc.subnets.from_tags"`),
+		},
+		"should return the subnets of the environment VPC that match every tag": {
+			inManifest: mftWithTags(),
+			setUpMocks: func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister) {
+				describer.EXPECT().Describe().Return(&describe.EnvDescription{
+					EnvironmentVPC: describe.EnvironmentVPC{
+						ID: "vpc-1234",
+					},
+				}, nil)
+				lister.EXPECT().SubnetIDs(
+					ec2.Filter{Name: "vpc-id", Values: []string{"vpc-1234"}},
+					ec2.Filter{Name: "tag:team", Values: []string{"payments"}},
+					ec2.Filter{Name: "tag:tier", Values: []string{"private"}},
+				).Return([]string{"subnet-0123abcd", "subnet-4567cdef"}, nil)
+			},
+			wanted: []string{"subnet-0123abcd", "subnet-4567cdef"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			describer := mocks.NewMockenvDescriber(ctrl)
+			lister := mocks.NewMockvpcSubnetLister(ctrl)
+			tc.setUpMocks(describer, lister)
+
+			got, err := subnetsFromTags(describer, lister, tc.inManifest)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
+func Test_validateAZRebalancing(t *testing.T) {
+	newBackend := func(count int, placement manifest.Placement) *manifest.BackendService {
+		mft := &manifest.BackendService{}
+		mft.Count.Value = aws.Int(count)
+		mft.AZRebalancing = aws.Bool(true)
+		mft.Network.VPC.Placement = &placement
+		return mft
+	}
+	envDescription := &describe.EnvDescription{
+		EnvironmentVPC: describe.EnvironmentVPC{
+			ID:               "vpc-1234",
+			PublicSubnetIDs:  []string{"subnet-public-a", "subnet-public-b"},
+			PrivateSubnetIDs: []string{"subnet-private-a", "subnet-private-b", "subnet-private-c"},
+		},
+	}
+	vpcSubnets := &ec2.VPCSubnets{
+		Public: []ec2.Subnet{
+			{Resource: ec2.Resource{ID: "subnet-public-a"}, AvailabilityZone: "us-west-2a"},
+			{Resource: ec2.Resource{ID: "subnet-public-b"}, AvailabilityZone: "us-west-2b"},
+		},
+		Private: []ec2.Subnet{
+			{Resource: ec2.Resource{ID: "subnet-private-a"}, AvailabilityZone: "us-west-2a"},
+			{Resource: ec2.Resource{ID: "subnet-private-b"}, AvailabilityZone: "us-west-2b"},
+			{Resource: ec2.Resource{ID: "subnet-private-c"}, AvailabilityZone: "us-west-2c"},
+		},
+	}
+	testCases := map[string]struct {
+		inManifest interface{}
+		setUpMocks func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister)
+
+		wantedErr error
+	}{
+		"should not describe the environment if rebalancing is off": {
+			inManifest: &manifest.BackendService{},
+			setUpMocks: func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister) {},
+		},
+		"should return an error if the environment can't be described": {
+			inManifest: newBackend(2, manifest.PublicSubnetPlacement),
+			setUpMocks: func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister) {
+				describer.EXPECT().Describe().Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("describe environment: some error"),
+		},
+		"should accept a task in each availability zone of the public subnets": {
+			inManifest: newBackend(2, manifest.PublicSubnetPlacement),
+			setUpMocks: func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister) {
+				describer.EXPECT().Describe().Return(envDescription, nil)
+				lister.EXPECT().ListVPCSubnets("vpc-1234").Return(vpcSubnets, nil)
+			},
+		},
+		"should return an error if the private subnets span more availability zones than tasks": {
+			inManifest: newBackend(2, manifest.PrivateSubnetPlacement),
+			setUpMocks: func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister) {
+				describer.EXPECT().Describe().Return(envDescription, nil)
+				lister.EXPECT().ListVPCSubnets("vpc-1234").Return(vpcSubnets, nil)
+			},
+			wantedErr: errors.New(`validate "availability_zone_rebalancing": "count" must be at least 3 to place a task in each availability zone`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			describer := mocks.NewMockenvDescriber(ctrl)
+			lister := mocks.NewMockvpcSubnetLister(ctrl)
+			tc.setUpMocks(describer, lister)
+
+			err := validateAZRebalancing(describer, lister, tc.inManifest)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_validateIPFamily(t *testing.T) {
+	newWorker := func(family string) *manifest.WorkerService {
+		mft := &manifest.WorkerService{}
+		mft.Network.VPC.IPFamily = aws.String(family)
+		return mft
+	}
+	envDescription := &describe.EnvDescription{
+		EnvironmentVPC: describe.EnvironmentVPC{
+			ID:              "vpc-1234",
+			PublicSubnetIDs: []string{"subnet-a", "subnet-b"},
+		},
+	}
+	testCases := map[string]struct {
+		inManifest interface{}
+		setUpMocks func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister)
+
+		wantedErr error
+	}{
+		"should not describe the environment for ipv4 tasks": {
+			inManifest: newWorker("ipv4"),
+			setUpMocks: func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister) {},
+		},
+		"should accept dual-stack subnets": {
+			inManifest: newWorker("dualstack"),
+			setUpMocks: func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister) {
+				describer.EXPECT().Describe().Return(envDescription, nil)
+				lister.EXPECT().ListVPCSubnets("vpc-1234").Return(&ec2.VPCSubnets{
+					Public: []ec2.Subnet{
+						{Resource: ec2.Resource{ID: "subnet-a"}, IPv6CIDRBlock: "2600:1f14:abc:de00::/64"},
+						{Resource: ec2.Resource{ID: "subnet-b"}, IPv6CIDRBlock: "2600:1f14:abc:de01::/64"},
+					},
+				}, nil)
+			},
+		},
+		"should return an error if a subnet has no IPv6 CIDR block": {
+			inManifest: newWorker("IPv6"),
+			setUpMocks: func(describer *mocks.MockenvDescriber, lister *mocks.MockvpcSubnetLister) {
+				describer.EXPECT().Describe().Return(envDescription, nil)
+				lister.EXPECT().ListVPCSubnets("vpc-1234").Return(&ec2.VPCSubnets{
+					Public: []ec2.Subnet{
+						{Resource: ec2.Resource{ID: "subnet-a"}, IPv6CIDRBlock: "2600:1f14:abc:de00::/64"},
+						{Resource: ec2.Resource{ID: "subnet-b"}},
+					},
+				}, nil)
+			},
+			wantedErr: errors.New(`"network.vpc.ip_family" ipv6 requires subnets with an IPv6 CIDR block, but subnet-b doesn't have one`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			describer := mocks.NewMockenvDescriber(ctrl)
+			lister := mocks.NewMockvpcSubnetLister(ctrl)
+			tc.setUpMocks(describer, lister)
+
+			err := validateIPFamily(describer, lister, tc.inManifest)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_buildSecrets(t *testing.T) {
+	dir := t.TempDir()
+	npmrc := filepath.Join(dir, ".npmrc")
+	require.NoError(t, os.WriteFile(npmrc, []byte("//registry.npmjs.org/:_authToken=abc"), 0600))
+	t.Setenv("COPILOT_TEST_GITHUB_TOKEN", "ghp_123")
+
+	testCases := map[string]struct {
+		in map[string]string
+
+		wanted    []string
+		wantedErr error
+	}{
+		"should return nil without secrets": {},
+		"should sort the secrets by id": {
+			in: map[string]string{
+				"token": "env:COPILOT_TEST_GITHUB_TOKEN",
+				"npmrc": npmrc,
+			},
+			wanted: []string{
+				fmt.Sprintf("id=npmrc,src=%s", npmrc),
+				"id=token,env=COPILOT_TEST_GITHUB_TOKEN",
+			},
+		},
+		"should return an error if the environment variable is not set": {
+			in: map[string]string{
+				"token": "env:COPILOT_TEST_UNSET_TOKEN",
+			},
+			wantedErr: errors.New("environment variable COPILOT_TEST_UNSET_TOKEN of secret token is not set"),
+		},
+		"should return an error if the file is not readable": {
+			in: map[string]string{
+				"npmrc": filepath.Join(dir, "missing"),
+			},
+			wantedErr: fmt.Errorf("read file of secret npmrc: open %s: no such file or directory", filepath.Join(dir, "missing")),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := buildSecrets(tc.in)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
+func TestSvcDeployOpts_evaluatePolicy(t *testing.T) {
+	const policy = `allowed_regions: [us-west-2]
+required_tags: [cost-center]
+forbid_latest_tag: true
+max_cpu: 1024
+`
+	mft := &manifest.BackendService{
+		Workload: manifest.Workload{Name: aws.String("api")},
+		BackendServiceConfig: manifest.BackendServiceConfig{
+			ImageConfig: manifest.ImageWithHealthcheckAndOptionalPort{
+				ImageWithOptionalPort: manifest.ImageWithOptionalPort{
+					Image: manifest.Image{
+						Location: aws.String("my-org/api:v1"),
+					},
+				},
+			},
+			TaskConfig: manifest.TaskConfig{
+				CPU: aws.Int(512),
+			},
+		},
+	}
+	testCases := map[string]struct {
+		inPolicyPath   string
+		inRegion       string
+		inAppTags      map[string]string
+		inResourceTags map[string]string
+
+		wantedErr error
+	}{
+		"should do nothing without a policy file": {
+			inRegion: "us-east-1",
+		},
+		"should return an error if the policy file doesn't exist": {
+			inPolicyPath: "missing.yml",
+			wantedErr:    errors.New("read policy file missing.yml: open missing.yml: file does not exist"),
+		},
+		"should return nil if the service complies with the policy": {
+			inPolicyPath:   "policy.yml",
+			inRegion:       "us-west-2",
+			inResourceTags: map[string]string{"cost-center": "1234"},
+		},
+		"should list the violations of the policy": {
+			inPolicyPath: "policy.yml",
+			inRegion:     "us-east-1",
+			inAppTags:    map[string]string{"owner": "payments"},
+			wantedErr: errors.New(`service api violates policy policy.yml:
+- allowed_regions: region us-east-1 is not allowed
+- required_tags: tag "cost-center" is required`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "policy.yml", []byte(policy), 0644))
+			opts := &deploySvcOpts{
+				deployWkldVars: deployWkldVars{
+					name:         "api",
+					resourceTags: tc.inResourceTags,
+					policyPath:   tc.inPolicyPath,
+				},
+				fs:                fs,
+				targetApp:         &config.Application{Tags: tc.inAppTags},
+				targetEnvironment: &config.Environment{Region: tc.inRegion},
+			}
+
+			err := opts.evaluatePolicy(mft)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSvcDeployOpts_publishRecommendedActions(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest interface{}
+
+		wanted string
+	}{
+		"no recommendations if the service doesn't publish": {
+			inManifest: &manifest.WorkerService{},
+		},
+		"uses the default prefix of the injected variables": {
+			inManifest: &manifest.BackendService{
+				BackendServiceConfig: manifest.BackendServiceConfig{
+					PublishConfig: manifest.PublishConfig{
+						Topics: []manifest.Topic{{Name: aws.String("orders")}},
+					},
+				},
+			},
+			wanted: "COPILOT_SNS_TOPIC_ARNS",
+		},
+		"uses env_var_prefix": {
+			inManifest: &manifest.BackendService{
+				BackendServiceConfig: manifest.BackendServiceConfig{
+					TaskConfig: manifest.TaskConfig{
+						EnvVarPrefix: aws.String("ACME_"),
+					},
+					PublishConfig: manifest.PublishConfig{
+						Topics: []manifest.Topic{{Name: aws.String("orders")}},
+					},
+				},
+			},
+			wanted: "ACME_SNS_TOPIC_ARNS",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			opts := deploySvcOpts{
+				deployWkldVars: deployWkldVars{
+					name: "api",
+				},
+				appliedManifest: tc.inManifest,
+			}
+
+			got := opts.publishRecommendedActions()
+
+			if tc.wanted == "" {
+				require.Empty(t, got)
+				return
+			}
+			require.Len(t, got, 1)
+			require.Contains(t, got[0], fmt.Sprintf(`"%s"`, tc.wanted))
+			require.Contains(t, got[0], fmt.Sprintf("process.env.%s", tc.wanted))
+		})
+	}
+}
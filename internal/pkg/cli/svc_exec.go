@@ -128,7 +128,8 @@ func (o *svcExecOpts) Execute() error {
 	if err != nil {
 		return err
 	}
-	svcDesc, err := o.newSvcDescriber(sess).DescribeService(o.appName, o.envName, o.name)
+	describer := o.newSvcDescriber(sess)
+	svcDesc, err := describer.DescribeService(o.appName, o.envName, o.name)
 	if err != nil {
 		return fmt.Errorf("describe ECS service for %s in environment %s: %w", o.name, o.envName, err)
 	}
@@ -136,7 +137,10 @@ func (o *svcExecOpts) Execute() error {
 	if err != nil {
 		return err
 	}
-	container := o.selectContainer()
+	container, err := o.selectContainer(describer)
+	if err != nil {
+		return err
+	}
 	log.Infof("Execute %s in container %s in task %s.\n", color.HighlightCode(o.command),
 		color.HighlightUserInput(container), color.HighlightResource(taskID))
 	if err = o.newCommandExecutor(sess).ExecuteCommand(awsecs.ExecuteCommandInput{
@@ -207,12 +211,19 @@ func (o *svcExecOpts) selectTask(tasks []*awsecs.Task) (string, error) {
 	return taskID, nil
 }
 
-func (o *svcExecOpts) selectContainer() string {
+func (o *svcExecOpts) selectContainer(describer serviceDescriber) (string, error) {
 	if o.containerName != "" {
-		return o.containerName
+		return o.containerName, nil
+	}
+	// The main container is named with the workload name, unless "container_name" is set in the manifest.
+	taskDef, err := describer.TaskDefinition(o.appName, o.envName, o.name)
+	if err != nil {
+		return "", fmt.Errorf("get task definition of service %s: %w", o.name, err)
+	}
+	if name := taskDef.MainContainerName(); name != "" {
+		return name, nil
 	}
-	// The first essential container is named with the workload name.
-	return o.name
+	return o.name, nil
 }
 
 func validateSSMBinary(prompt prompter, manager ssmPluginManager, skipConfirmation *bool) error {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	sdkecs "github.com/aws/aws-sdk-go/service/ecs"
 	awsecs "github.com/aws/copilot-cli/internal/pkg/aws/ecs"
 	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
 	"github.com/aws/copilot-cli/internal/pkg/config"
@@ -614,6 +615,27 @@ func TestSvcExec_Execute(t *testing.T) {
 			},
 			wantedError: fmt.Errorf("execute command mockCommand in container hello: some error"),
 		},
+		"return error if fail to get the task definition": {
+			setupMocks: func(m execSvcMocks) {
+				gomock.InOrder(
+					m.storeSvc.EXPECT().GetWorkload("mockApp", "mockSvc").Return(&mockWl, nil),
+					m.storeSvc.EXPECT().GetEnvironment("mockApp", "mockEnv").Return(&config.Environment{
+						Name: "my-env",
+					}, nil),
+					m.ecsSvcDescriber.EXPECT().DescribeService("mockApp", "mockEnv", "mockSvc").Return(&ecs.ServiceDesc{
+						ClusterName: "mockCluster",
+						Tasks: []*awsecs.Task{
+							{
+								TaskArn:    aws.String(mockTaskARN),
+								LastStatus: aws.String("RUNNING"),
+							},
+						},
+					}, nil),
+					m.ecsSvcDescriber.EXPECT().TaskDefinition("mockApp", "mockEnv", "mockSvc").Return(nil, mockError),
+				)
+			},
+			wantedError: fmt.Errorf("get task definition of service mockSvc: some error"),
+		},
 		"success": {
 			setupMocks: func(m execSvcMocks) {
 				gomock.InOrder(
@@ -634,9 +656,19 @@ func TestSvcExec_Execute(t *testing.T) {
 							},
 						},
 					}, nil),
+					m.ecsSvcDescriber.EXPECT().TaskDefinition("mockApp", "mockEnv", "mockSvc").Return(&awsecs.TaskDefinition{
+						ContainerDefinitions: []*sdkecs.ContainerDefinition{
+							{
+								Name: aws.String("api"),
+							},
+							{
+								Name: aws.String("nginx"),
+							},
+						},
+					}, nil),
 					m.ecsCommandExecutor.EXPECT().ExecuteCommand(awsecs.ExecuteCommandInput{
 						Cluster:   "mockCluster",
-						Container: "mockSvc",
+						Container: "api",
 						Task:      "mockTaskID",
 						Command:   "mockCommand",
 					}).Return(nil),
@@ -478,13 +478,17 @@ func legitimizePlatform(engine dockerEngine, wkldType string) (manifest.Platform
 	if wkldType == manifest.RequestDrivenWebServiceType && detectedOs == manifest.OSWindows {
 		return "", manifest.ErrAppRunnerInvalidPlatformWindows
 	}
+	platform := manifest.PlatformString(redirectedPlatform)
+	if err := (manifest.PlatformArgsOrString{PlatformString: &platform}).IsValid(); err != nil {
+		return "", fmt.Errorf("validate docker engine platform: %w", err)
+	}
 	// Messages are logged only if the platform was redirected.
 	msg := fmt.Sprintf("Architecture type %s has been detected. We will set platform '%s' instead. If you'd rather build and run as architecture type %s, please change the 'platform' field in your workload manifest to '%s'.\n", detectedArch, redirectedPlatform, manifest.ArchARM64, dockerengine.PlatformString(detectedOs, manifest.ArchARM64))
 	if manifest.IsArmArch(detectedArch) && wkldType == manifest.RequestDrivenWebServiceType {
 		msg = fmt.Sprintf("Architecture type %s has been detected. At this time, %s architectures are not supported for App Runner workloads. We will set platform '%s' instead.\n", detectedArch, detectedArch, redirectedPlatform)
 	}
 	log.Warningf(msg)
-	return manifest.PlatformString(redirectedPlatform), nil
+	return platform, nil
 }
 
 func (o *initSvcOpts) askSvcPublishers() (err error) {
@@ -787,6 +787,21 @@ func TestSvcInitOpts_Execute(t *testing.T) {
 
 			wantedErr: errors.New("redirect docker engine platform: Windows is not supported for App Runner services"),
 		},
+		"return error if the detected platform is not supported": {
+			inAppName:        "sample",
+			inSvcName:        "frontend",
+			inDockerfilePath: "./Dockerfile",
+			inSvcType:        manifest.BackendServiceType,
+
+			mockDockerfile: func(m *mocks.MockdockerfileParser) {
+				m.EXPECT().GetHealthCheck().Return(nil, nil)
+			},
+			mockDockerEngine: func(m *mocks.MockdockerEngine) {
+				m.EXPECT().GetPlatform().Return("freebsd", "amd64", nil)
+			},
+
+			wantedErr: errors.New("validate docker engine platform: platform 'freebsd/x86_64' is invalid; valid platforms are: linux/amd64, linux/x86_64, linux/arm, linux/arm64, windows/amd64 and windows/x86_64"),
+		},
 		"failure": {
 			mockDockerEngine: func(m *mocks.MockdockerEngine) {
 				m.EXPECT().GetPlatform().Return("linux", "amd64", nil)
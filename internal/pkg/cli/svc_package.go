@@ -22,8 +22,10 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/deploy"
 
 	"github.com/aws/copilot-cli/internal/pkg/addon"
+	"github.com/aws/copilot-cli/internal/pkg/aws/ec2"
 	"github.com/aws/copilot-cli/internal/pkg/aws/s3"
 	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
+	"github.com/aws/copilot-cli/internal/pkg/aws/tags"
 	"github.com/aws/copilot-cli/internal/pkg/config"
 	"github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation"
 	"github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation/stack"
@@ -62,7 +64,7 @@ type packageSvcOpts struct {
 	// Interfaces to interact with dependencies.
 	addonsClient      templater
 	initAddonsClient  func(*packageSvcOpts) error // Overridden in tests.
-	ws                wsSvcReader
+	ws                wsSvcDirReader
 	store             store
 	appCFN            appResourcesGetter
 	stackWriter       io.Writer
@@ -76,6 +78,8 @@ type packageSvcOpts struct {
 	newInterpolator   func(app, env string) interpolator
 	stackSerializer   func(mft interface{}, env *config.Environment, app *config.Application, rc stack.RuntimeConfig) (stackSerializer, error)
 	newEndpointGetter func(app, env string) (endpointGetter, error)
+	newEnvDescriber   func(app, env string) (envDescriber, error)
+	newSubnetLister   func(env *config.Environment) (vpcSubnetLister, error)
 	snsTopicGetter    deployedEnvironmentLister
 }
 
@@ -194,17 +198,7 @@ func newPackageSvcOpts(vars packageSvcVars) (*packageSvcOpts, error) {
 			for _, topic := range topics {
 				topicARNs = append(topicARNs, topic.ARN())
 			}
-			type subscriptions interface {
-				Subscriptions() []manifest.TopicSubscription
-			}
-
-			subscriptionGetter, ok := mft.(subscriptions)
-			if !ok {
-				return nil, errors.New("manifest does not have required method Subscriptions")
-			}
-			// Cache the subscriptions for later.
-			topicSubs := subscriptionGetter.Subscriptions()
-			if err = validateTopicsExist(topicSubs, topicARNs, app.Name, env.Name); err != nil {
+			if err = validateTopicsExist(t.Subscribe, topicARNs, app.Name, env.Name); err != nil {
 				return nil, err
 			}
 			serializer, err = stack.NewWorkerService(t, env.Name, app.Name, rc)
@@ -227,6 +221,25 @@ func newPackageSvcOpts(vars packageSvcVars) (*packageSvcOpts, error) {
 		}
 		return d, nil
 	}
+	opts.newEnvDescriber = func(app, env string) (envDescriber, error) {
+		d, err := describe.NewEnvDescriber(describe.NewEnvDescriberConfig{
+			App:         app,
+			Env:         env,
+			ConfigStore: store,
+			DeployStore: deployStore,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("new env describer for environment %s in app %s: %v", env, app, err)
+		}
+		return d, nil
+	}
+	opts.newSubnetLister = func(env *config.Environment) (vpcSubnetLister, error) {
+		envSess, err := p.FromRole(env.ManagerRoleARN, env.Region)
+		if err != nil {
+			return nil, fmt.Errorf("assume role for environment %s: %w", env.ManagerRoleARN, err)
+		}
+		return ec2.New(envSess), nil
+	}
 	return opts, nil
 }
 
@@ -353,6 +366,10 @@ func (o *packageSvcOpts) getSvcTemplates(env *config.Environment) (*svcCfnTempla
 	if err != nil {
 		return nil, fmt.Errorf("read service manifest: %w", err)
 	}
+	raw, err = manifest.Load(workloadManifestPath(o.name), raw, workspaceFileReader(o.ws))
+	if err != nil {
+		return nil, fmt.Errorf("load service %s manifest: %w", o.name, err)
+	}
 	interpolated, err := o.newInterpolator(o.appName, env.Name).Interpolate(string(raw))
 	if err != nil {
 		return nil, fmt.Errorf("interpolate environment variables for %s manifest: %w", o.name, err)
@@ -365,13 +382,30 @@ func (o *packageSvcOpts) getSvcTemplates(env *config.Environment) (*svcCfnTempla
 	if err != nil {
 		return nil, fmt.Errorf("apply environment %s override: %s", o.envName, err)
 	}
+	if err := manifest.LoadWorkloadVariables(envMft, workspaceFileReader(o.ws)); err != nil {
+		return nil, fmt.Errorf("load variables for %s manifest: %w", o.name, err)
+	}
+	if err := manifest.LoadWorkloadCollectorConfig(envMft, workspaceFileReader(o.ws)); err != nil {
+		return nil, fmt.Errorf("load collector config for %s manifest: %w", o.name, err)
+	}
 	if err := envMft.Validate(); err != nil {
 		return nil, fmt.Errorf("validate manifest against environment %s: %s", o.envName, err)
 	}
+	if err := manifest.ValidatePlatformInRegion(envMft, env.Region); err != nil {
+		return nil, fmt.Errorf("validate manifest against environment %s: %w", o.envName, err)
+	}
+	calendars, err := scalingCalendars(envMft, o.envName, workspaceFileReader(o.ws))
+	if err != nil {
+		return nil, err
+	}
 	imgNeedsBuild, err := manifest.ServiceDockerfileBuildRequired(envMft)
 	if err != nil {
 		return nil, err
 	}
+	initImgNeedsBuild, err := initContainerBuildRequired(envMft)
+	if err != nil {
+		return nil, err
+	}
 	app, err := o.store.GetApplication(o.appName)
 	if err != nil {
 		return nil, err
@@ -384,14 +418,20 @@ func (o *packageSvcOpts) getSvcTemplates(env *config.Environment) (*svcCfnTempla
 	if err != nil {
 		return nil, err
 	}
+	subnetIDs, err := o.subnetIDs(env, envMft)
+	if err != nil {
+		return nil, err
+	}
 	rc := stack.RuntimeConfig{
-		AdditionalTags:           app.Tags,
+		AdditionalTags:           tags.Merge(app.Tags, gitSHATags(o.runner, envMft)),
 		ServiceDiscoveryEndpoint: endpoint,
+		SubnetIDs:                subnetIDs,
+		ScalingCalendars:         calendars,
 		AccountID:                env.AccountID,
 		Region:                   env.Region,
 	}
 
-	if imgNeedsBuild {
+	if imgNeedsBuild || initImgNeedsBuild {
 		resources, err := o.appCFN.GetAppResourcesByRegion(app, env.Region)
 		if err != nil {
 			return nil, err
@@ -404,9 +444,17 @@ func (o *packageSvcOpts) getSvcTemplates(env *config.Environment) (*svcCfnTempla
 				appAccountID: app.AccountID,
 			}
 		}
-		rc.Image = &stack.ECRImage{
-			RepoURL:  repoURL,
-			ImageTag: o.tag,
+		if imgNeedsBuild {
+			rc.Image = &stack.ECRImage{
+				RepoURL:  repoURL,
+				ImageTag: o.tag,
+			}
+		}
+		if initImgNeedsBuild {
+			rc.InitImage = &stack.ECRImage{
+				RepoURL:  repoURL,
+				ImageTag: initImageTag(o.tag),
+			}
 		}
 	}
 	serializer, err := o.stackSerializer(envMft, env, app, rc)
@@ -424,6 +472,23 @@ func (o *packageSvcOpts) getSvcTemplates(env *config.Environment) (*svcCfnTempla
 	return &svcCfnTemplates{stack: tpl, configuration: params}, nil
 }
 
+// subnetIDs returns the IDs of the subnets of the environment VPC selected by "network.vpc.subnets.from_tags".
+// The environment clients are only created if the workload selects its subnets by tags.
+func (o *packageSvcOpts) subnetIDs(env *config.Environment, mft interface{}) ([]string, error) {
+	if len(subnetTags(mft)) == 0 {
+		return nil, nil
+	}
+	describer, err := o.newEnvDescriber(o.appName, env.Name)
+	if err != nil {
+		return nil, err
+	}
+	lister, err := o.newSubnetLister(env)
+	if err != nil {
+		return nil, err
+	}
+	return subnetsFromTags(describer, lister, mft)
+}
+
 // setOutputFileWriters creates the output directory, and updates the template and param writers to file writers in the directory.
 func (o *packageSvcOpts) setOutputFileWriters() error {
 	if err := o.fs.MkdirAll(o.outputDir, 0755); err != nil {
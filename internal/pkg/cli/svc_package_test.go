@@ -13,13 +13,14 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
 	"github.com/aws/copilot-cli/internal/pkg/config"
 	"github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation/stack"
+	"github.com/aws/copilot-cli/internal/pkg/describe"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
 func TestPackageSvcOpts_Validate(t *testing.T) {
 	var (
-		mockWorkspace *mocks.MockwsSvcReader
+		mockWorkspace *mocks.MockwsSvcDirReader
 		mockStore     *mocks.Mockstore
 	)
 
@@ -84,7 +85,7 @@ func TestPackageSvcOpts_Validate(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			mockWorkspace = mocks.NewMockwsSvcReader(ctrl)
+			mockWorkspace = mocks.NewMockwsSvcDirReader(ctrl)
 			mockStore = mocks.NewMockstore(ctrl)
 
 			tc.setupMocks()
@@ -231,6 +232,15 @@ http:
 cpu: 256
 memory: 512
 count: 1`
+	backendMft := `name: api
+type: Backend Service
+image:
+  location: nginx
+network:
+  vpc:
+    subnets:
+      from_tags:
+        tier: private`
 	testCases := map[string]struct {
 		inVars packageSvcVars
 
@@ -269,7 +279,7 @@ count: 1`
 					GetApplication("ecs-kudos").
 					Return(mockApp, nil)
 
-				mockWs := mocks.NewMockwsSvcReader(ctrl)
+				mockWs := mocks.NewMockwsSvcDirReader(ctrl)
 				mockWs.EXPECT().
 					ReadWorkloadManifest("api").
 					Return([]byte(lbwsMft), nil)
@@ -346,7 +356,7 @@ count: 1`
 					GetApplication("ecs-kudos").
 					Return(mockApp, nil)
 
-				mockWs := mocks.NewMockwsSvcReader(ctrl)
+				mockWs := mocks.NewMockwsSvcDirReader(ctrl)
 				mockWs.EXPECT().
 					ReadWorkloadManifest("api").
 					Return([]byte(rdwsMft), nil)
@@ -390,6 +400,82 @@ count: 1`
 				}
 			},
 
+			wantedStack:  "mystack",
+			wantedParams: "myparams",
+		},
+		"writes service template with the subnets selected by tags": {
+			inVars: packageSvcVars{
+				appName: "ecs-kudos",
+				name:    "api",
+				envName: "test",
+				tag:     "1234",
+			},
+			mockDependencies: func(ctrl *gomock.Controller, opts *packageSvcOpts) {
+				mockStore := mocks.NewMockstore(ctrl)
+				mockStore.EXPECT().
+					GetEnvironment("ecs-kudos", "test").
+					Return(&config.Environment{
+						App:       "ecs-kudos",
+						Name:      "test",
+						Region:    "us-west-2",
+						AccountID: "1111",
+					}, nil)
+				mockStore.EXPECT().
+					GetApplication("ecs-kudos").
+					Return(&config.Application{
+						Name:      "ecs-kudos",
+						AccountID: "1112",
+					}, nil)
+
+				mockWs := mocks.NewMockwsSvcDirReader(ctrl)
+				mockWs.EXPECT().
+					ReadWorkloadManifest("api").
+					Return([]byte(backendMft), nil)
+
+				mockItpl := mocks.NewMockinterpolator(ctrl)
+				mockItpl.EXPECT().Interpolate(backendMft).Return(backendMft, nil)
+
+				mockAddons := mocks.NewMocktemplater(ctrl)
+				mockAddons.EXPECT().Template().
+					Return("", &addon.ErrAddonsNotFound{})
+
+				opts.store = mockStore
+				opts.ws = mockWs
+				opts.initAddonsClient = func(opts *packageSvcOpts) error {
+					opts.addonsClient = mockAddons
+					return nil
+				}
+				opts.newInterpolator = func(app, env string) interpolator {
+					return mockItpl
+				}
+				opts.stackSerializer = func(_ interface{}, _ *config.Environment, _ *config.Application, rc stack.RuntimeConfig) (stackSerializer, error) {
+					mockStackSerializer := mocks.NewMockstackSerializer(ctrl)
+					mockStackSerializer.EXPECT().Template().Return("mystack", nil)
+					mockStackSerializer.EXPECT().SerializedParameters().Return("myparams", nil)
+					require.Equal(t, []string{"subnet-1", "subnet-2"}, rc.SubnetIDs)
+					return mockStackSerializer, nil
+				}
+				opts.newEndpointGetter = func(app, env string) (endpointGetter, error) {
+					mockendpointGetter := mocks.NewMockendpointGetter(ctrl)
+					mockendpointGetter.EXPECT().ServiceDiscoveryEndpoint().Return(fmt.Sprintf("%s.%s.local", env, app), nil)
+					return mockendpointGetter, nil
+				}
+				opts.newEnvDescriber = func(app, env string) (envDescriber, error) {
+					mockEnvDescriber := mocks.NewMockenvDescriber(ctrl)
+					mockEnvDescriber.EXPECT().Describe().Return(&describe.EnvDescription{
+						EnvironmentVPC: describe.EnvironmentVPC{
+							ID: "vpc-1234",
+						},
+					}, nil)
+					return mockEnvDescriber, nil
+				}
+				opts.newSubnetLister = func(env *config.Environment) (vpcSubnetLister, error) {
+					mockSubnetLister := mocks.NewMockvpcSubnetLister(ctrl)
+					mockSubnetLister.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{"subnet-1", "subnet-2"}, nil)
+					return mockSubnetLister, nil
+				}
+			},
+
 			wantedStack:  "mystack",
 			wantedParams: "myparams",
 		},
@@ -0,0 +1,261 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/aws/copilot-cli/internal/pkg/aws/sessions"
+	"github.com/aws/copilot-cli/internal/pkg/config"
+	"github.com/aws/copilot-cli/internal/pkg/deploy"
+	"github.com/aws/copilot-cli/internal/pkg/ecs"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/aws/copilot-cli/internal/pkg/term/color"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
+	termprogress "github.com/aws/copilot-cli/internal/pkg/term/progress"
+	"github.com/aws/copilot-cli/internal/pkg/term/prompt"
+	"github.com/aws/copilot-cli/internal/pkg/term/selector"
+	"github.com/aws/copilot-cli/internal/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+const (
+	svcSetExecAppNamePrompt     = "Which application is the service in?"
+	svcSetExecNamePrompt        = "Which service of %s would you like to update?"
+	svcSetExecSvcNameHelpPrompt = "ECS Exec will be turned on or off for the selected service without updating its task definition."
+
+	fmtSvcSetExecStart   = "Turning %s ECS Exec for service %s in environment %s."
+	fmtSvcSetExecFailed  = "Failed to turn %s ECS Exec for service %s in environment %s.\n"
+	fmtSvcSetExecSucceed = "Turned %s ECS Exec for service %s in environment %s.\n"
+)
+
+type svcSetExecVars struct {
+	svcName string
+	envName string
+	appName string
+	enable  bool
+	disable bool
+}
+
+type svcSetExecOpts struct {
+	svcSetExecVars
+	store           store
+	ws              wsSvcDirReader
+	sel             deploySelector
+	unmarshal       func([]byte) (manifest.WorkloadManifest, error)
+	newInterpolator func(app, env string) interpolator
+	client          serviceExecUpdater
+	initClient      func() error
+	prog            progress
+}
+
+func newSvcSetExecOpts(vars svcSetExecVars) (*svcSetExecOpts, error) {
+	configStore, err := config.NewStore()
+	if err != nil {
+		return nil, fmt.Errorf("connect to environment datastore: %w", err)
+	}
+	deployStore, err := deploy.NewStore(configStore)
+	if err != nil {
+		return nil, fmt.Errorf("connect to deploy store: %w", err)
+	}
+	ws, err := workspace.New()
+	if err != nil {
+		return nil, fmt.Errorf("new workspace: %w", err)
+	}
+	opts := &svcSetExecOpts{
+		svcSetExecVars:  vars,
+		store:           configStore,
+		ws:              ws,
+		sel:             selector.NewDeploySelect(prompt.New(), configStore, deployStore),
+		unmarshal:       manifest.UnmarshalWorkload,
+		newInterpolator: newManifestInterpolator,
+		prog:            termprogress.NewSpinner(log.DiagnosticWriter),
+	}
+	opts.initClient = func() error {
+		env, err := opts.store.GetEnvironment(opts.appName, opts.envName)
+		if err != nil {
+			return fmt.Errorf("get environment %s: %w", opts.envName, err)
+		}
+		sess, err := sessions.NewProvider().FromRole(env.ManagerRoleARN, env.Region)
+		if err != nil {
+			return err
+		}
+		opts.client = ecs.New(sess)
+		return nil
+	}
+	return opts, nil
+}
+
+// Validate returns an error if the values provided by the user are invalid.
+func (o *svcSetExecOpts) Validate() error {
+	if o.enable && o.disable {
+		return fmt.Errorf("cannot specify both --%s and --%s", enableExecFlag, disableExecFlag)
+	}
+	if o.appName == "" {
+		return nil
+	}
+	if _, err := o.store.GetApplication(o.appName); err != nil {
+		return err
+	}
+	if o.svcName != "" {
+		if _, err := o.store.GetService(o.appName, o.svcName); err != nil {
+			return err
+		}
+	}
+	if o.envName != "" {
+		if _, err := o.store.GetEnvironment(o.appName, o.envName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Ask asks for fields that are required but not passed in.
+func (o *svcSetExecOpts) Ask() error {
+	if err := o.askApp(); err != nil {
+		return err
+	}
+	return o.askSvcEnvName()
+}
+
+func (o *svcSetExecOpts) askApp() error {
+	if o.appName != "" {
+		return nil
+	}
+	app, err := o.sel.Application(svcSetExecAppNamePrompt, svcAppNameHelpPrompt)
+	if err != nil {
+		return fmt.Errorf("select application: %w", err)
+	}
+	o.appName = app
+	return nil
+}
+
+func (o *svcSetExecOpts) askSvcEnvName() error {
+	deployedService, err := o.sel.DeployedService(
+		fmt.Sprintf(svcSetExecNamePrompt, color.HighlightUserInput(o.appName)),
+		svcSetExecSvcNameHelpPrompt,
+		o.appName,
+		selector.WithEnv(o.envName),
+		selector.WithSvc(o.svcName),
+		selector.WithServiceTypesFilter([]string{manifest.LoadBalancedWebServiceType, manifest.BackendServiceType, manifest.WorkerServiceType}),
+	)
+	if err != nil {
+		return fmt.Errorf("select deployed services for application %s: %w", o.appName, err)
+	}
+	o.svcName = deployedService.Svc
+	o.envName = deployedService.Env
+	return nil
+}
+
+// Execute turns ECS Exec on or off for the service and redeploys its tasks with the current task definition.
+// Without --enable or --disable, the setting from the manifest is restored.
+func (o *svcSetExecOpts) Execute() error {
+	inManifest, err := o.manifestExecEnabled()
+	if err != nil {
+		return err
+	}
+	enable := inManifest
+	switch {
+	case o.enable:
+		enable = true
+	case o.disable:
+		enable = false
+	}
+	if enable && !inManifest {
+		log.Warningf("The task role of service %s only grants the permissions that ECS Exec needs if %s is set in the manifest.\n",
+			o.svcName, color.HighlightCode("exec: true"))
+	}
+	if err := o.initClient(); err != nil {
+		return err
+	}
+	state := onOrOff(enable)
+	o.prog.Start(fmt.Sprintf(fmtSvcSetExecStart, state, o.svcName, o.envName))
+	if err := o.client.UpdateServiceExecuteCommand(o.appName, o.envName, o.svcName, enable); err != nil {
+		o.prog.Stop(log.Serrorf(fmtSvcSetExecFailed, state, o.svcName, o.envName))
+		return fmt.Errorf("update ECS Exec for service %s: %w", o.svcName, err)
+	}
+	o.prog.Stop(log.Ssuccessf(fmtSvcSetExecSucceed, state, o.svcName, o.envName))
+	return nil
+}
+
+// manifestExecEnabled returns true if the manifest of the service turns on ECS Exec in the environment.
+func (o *svcSetExecOpts) manifestExecEnabled() (bool, error) {
+	raw, err := o.ws.ReadWorkloadManifest(o.svcName)
+	if err != nil {
+		return false, fmt.Errorf("read service %s manifest file: %w", o.svcName, err)
+	}
+	raw, err = manifest.Load(workloadManifestPath(o.svcName), raw, workspaceFileReader(o.ws))
+	if err != nil {
+		return false, fmt.Errorf("load service %s manifest: %w", o.svcName, err)
+	}
+	interpolated, err := o.newInterpolator(o.appName, o.envName).Interpolate(string(raw))
+	if err != nil {
+		return false, fmt.Errorf("interpolate environment variables for %s manifest: %w", o.svcName, err)
+	}
+	mft, err := o.unmarshal([]byte(interpolated))
+	if err != nil {
+		return false, fmt.Errorf("unmarshal service %s manifest: %w", o.svcName, err)
+	}
+	envMft, err := mft.ApplyEnv(o.envName)
+	if err != nil {
+		return false, fmt.Errorf("apply environment %s override: %w", o.envName, err)
+	}
+	switch t := envMft.(type) {
+	case *manifest.LoadBalancedWebService:
+		return t.ExecuteCommand.Enabled(), nil
+	case *manifest.BackendService:
+		return t.ExecuteCommand.Enabled(), nil
+	case *manifest.WorkerService:
+		return t.ExecuteCommand.Enabled(), nil
+	}
+	return false, fmt.Errorf("ECS Exec is only supported for services with type: %s, %s, or %s",
+		manifest.LoadBalancedWebServiceType, manifest.BackendServiceType, manifest.WorkerServiceType)
+}
+
+// RecommendActions returns follow-up actions the user can take after successfully executing the command.
+func (o *svcSetExecOpts) RecommendActions() error {
+	logRecommendedActions([]string{
+		fmt.Sprintf("Run %s to restore the ECS Exec setting from your manifest.",
+			color.HighlightCode(fmt.Sprintf("copilot svc set-exec -n %s -e %s", o.svcName, o.envName))),
+	})
+	return nil
+}
+
+func onOrOff(enable bool) string {
+	if enable {
+		return "on"
+	}
+	return "off"
+}
+
+// buildSvcSetExecCmd builds the command for turning ECS Exec on or off for a deployed service.
+func buildSvcSetExecCmd() *cobra.Command {
+	vars := svcSetExecVars{}
+	cmd := &cobra.Command{
+		Use:   "set-exec",
+		Short: "Turn ECS Exec on or off for a deployed service.",
+		Long: `Turn ECS Exec on or off for a deployed service without updating its task definition.
+The service's tasks are replaced so that the new tasks pick up the setting.
+Without --enable or --disable, the setting from the manifest is restored.`,
+
+		Example: `
+  Turn on ECS Exec for the service "my-svc" in the "prod" environment.
+  /code $ copilot svc set-exec -n my-svc -e prod --enable
+  Restore the ECS Exec setting from the manifest.
+  /code $ copilot svc set-exec -n my-svc -e prod`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts, err := newSvcSetExecOpts(vars)
+			if err != nil {
+				return err
+			}
+			return run(opts)
+		}),
+	}
+	cmd.Flags().StringVarP(&vars.svcName, nameFlag, nameFlagShort, "", svcFlagDescription)
+	cmd.Flags().StringVarP(&vars.envName, envFlag, envFlagShort, "", envFlagDescription)
+	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, tryReadingAppName(), appFlagDescription)
+	cmd.Flags().BoolVar(&vars.enable, enableExecFlag, false, enableExecFlagDescription)
+	cmd.Flags().BoolVar(&vars.disable, disableExecFlag, false, disableExecFlagDescription)
+	return cmd
+}
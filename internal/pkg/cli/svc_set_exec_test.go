@@ -0,0 +1,163 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/copilot-cli/internal/pkg/cli/mocks"
+	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/aws/copilot-cli/internal/pkg/term/log"
+	"github.com/aws/copilot-cli/internal/pkg/workspace"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSvcSetExec_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		inEnable  bool
+		inDisable bool
+
+		wantedError error
+	}{
+		"errors if both --enable and --disable are set": {
+			inEnable:  true,
+			inDisable: true,
+
+			wantedError: errors.New("cannot specify both --enable and --disable"),
+		},
+		"skip validation if app flag is not set": {
+			inEnable: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			opts := &svcSetExecOpts{
+				svcSetExecVars: svcSetExecVars{
+					enable:  tc.inEnable,
+					disable: tc.inDisable,
+				},
+			}
+
+			err := opts.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSvcSetExec_Execute(t *testing.T) {
+	const (
+		execOnMft = `name: mock-svc
+type: Backend Service
+image:
+  location: nginx
+exec: true
+environments:
+  mock-env:
+    exec: false
+`
+		execOffMft = `name: mock-svc
+type: Backend Service
+image:
+  location: nginx
+`
+	)
+	mockError := errors.New("some error")
+	testCases := map[string]struct {
+		inEnable  bool
+		inDisable bool
+		inMft     string
+
+		mocking     func(mockUpdater *mocks.MockserviceExecUpdater, mockProgress *mocks.Mockprogress)
+		wantedError error
+	}{
+		"restores the setting of the manifest for the environment": {
+			inMft: execOnMft,
+			mocking: func(mockUpdater *mocks.MockserviceExecUpdater, mockProgress *mocks.Mockprogress) {
+				mockProgress.EXPECT().Start("Turning off ECS Exec for service mock-svc in environment mock-env.")
+				mockUpdater.EXPECT().UpdateServiceExecuteCommand("mock-app", "mock-env", "mock-svc", false).Return(nil)
+				mockProgress.EXPECT().Stop(log.Ssuccessf("Turned off ECS Exec for service mock-svc in environment mock-env.\n"))
+			},
+		},
+		"turns on ECS Exec over the manifest": {
+			inEnable: true,
+			inMft:    execOffMft,
+			mocking: func(mockUpdater *mocks.MockserviceExecUpdater, mockProgress *mocks.Mockprogress) {
+				mockProgress.EXPECT().Start("Turning on ECS Exec for service mock-svc in environment mock-env.")
+				mockUpdater.EXPECT().UpdateServiceExecuteCommand("mock-app", "mock-env", "mock-svc", true).Return(nil)
+				mockProgress.EXPECT().Stop(log.Ssuccessf("Turned on ECS Exec for service mock-svc in environment mock-env.\n"))
+			},
+		},
+		"errors if failed to update the service": {
+			inDisable: true,
+			inMft:     execOffMft,
+			mocking: func(mockUpdater *mocks.MockserviceExecUpdater, mockProgress *mocks.Mockprogress) {
+				mockProgress.EXPECT().Start("Turning off ECS Exec for service mock-svc in environment mock-env.")
+				mockUpdater.EXPECT().UpdateServiceExecuteCommand("mock-app", "mock-env", "mock-svc", false).Return(mockError)
+				mockProgress.EXPECT().Stop(log.Serrorf("Failed to turn off ECS Exec for service mock-svc in environment mock-env.\n"))
+			},
+			wantedError: fmt.Errorf("update ECS Exec for service mock-svc: some error"),
+		},
+		"errors if the service is not an ECS service": {
+			inEnable: true,
+			inMft: `name: mock-svc
+type: Request-Driven Web Service
+image:
+  location: nginx
+  port: 80
+`,
+			mocking:     func(mockUpdater *mocks.MockserviceExecUpdater, mockProgress *mocks.Mockprogress) {},
+			wantedError: errors.New("ECS Exec is only supported for services with type: Load Balanced Web Service, Backend Service, or Worker Service"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWs := mocks.NewMockwsSvcDirReader(ctrl)
+			mockWs.EXPECT().ReadWorkloadManifest("mock-svc").Return(workspace.WorkloadManifest(tc.inMft), nil)
+			mockInterpolator := mocks.NewMockinterpolator(ctrl)
+			mockInterpolator.EXPECT().Interpolate(tc.inMft).Return(tc.inMft, nil)
+			mockUpdater := mocks.NewMockserviceExecUpdater(ctrl)
+			mockProgress := mocks.NewMockprogress(ctrl)
+			tc.mocking(mockUpdater, mockProgress)
+
+			opts := &svcSetExecOpts{
+				svcSetExecVars: svcSetExecVars{
+					svcName: "mock-svc",
+					envName: "mock-env",
+					appName: "mock-app",
+					enable:  tc.inEnable,
+					disable: tc.inDisable,
+				},
+				ws:        mockWs,
+				unmarshal: manifest.UnmarshalWorkload,
+				newInterpolator: func(app, env string) interpolator {
+					return mockInterpolator
+				},
+				client:     mockUpdater,
+				initClient: func() error { return nil },
+				prog:       mockProgress,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
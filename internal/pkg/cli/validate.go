@@ -72,10 +72,18 @@ var (
 	errSubscribeBadFormat       = errors.New("value must be of the form <serviceName>:<topicName>")
 
 	fmtErrTopicSubscriptionNotAllowed = "SNS topic %s does not exist in environment %s"
+	fmtErrFIFOQueueStandardTopic      = "SNS topic %s is a standard topic and can't deliver to a FIFO queue"
+	fmtErrStandardQueueFIFOTopic      = "SNS topic %s is a FIFO topic and can only deliver to a FIFO queue, set \"queue.fifo\" to true"
 )
 
 const fmtErrValueBadSize = "value must be between %d and %d characters in length"
 
+// Bounds for how long a deployment waits on a stack operation.
+const (
+	minStackTimeout = 5 * time.Minute
+	maxStackTimeout = 12 * time.Hour
+)
+
 // App Runner validation errors.
 var (
 	errAppRunnerSvcNameTooLong    = errors.New("value must not exceed 40 characters")
@@ -156,6 +164,16 @@ var (
 	regexpMatchSubscription = regexp.MustCompile(`^(\S+):(\S+)`)     // Validates that an expression contains the format serviceName:topicName
 )
 
+// GitHub repository and branch validation expressions.
+// https://docs.github.com/en/actions/deployment/security-hardening-your-deployments/about-security-hardening-with-openid-connect
+var (
+	githubRepoSlugRegexp   = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,38})/[a-zA-Z0-9._-]{1,100}$`) // Validates that an expression is of the form owner/repo.
+	githubBranchGlobRegexp = regexp.MustCompile(`^[a-zA-Z0-9._/*?-]+$`)                                      // Validates that an expression contains only branch name characters and the * and ? wildcards.
+
+	errGitHubRepoSlugBadFormat   = errors.New("value must be a GitHub repository of the form owner/repo")
+	errGitHubBranchGlobBadFormat = errors.New("value must be a branch name or a pattern that contains only letters, numbers, ._/- and the wildcards * and ?")
+)
+
 var resourceNameFormat = "%s-%s-%s-%s" // Format for copilot resource names of form app-env-svc-name
 
 const regexpFindAllMatches = -1
@@ -248,6 +266,19 @@ func validateTimeout(timeout interface{}) error {
 	return nil
 }
 
+func validateStackTimeout(timeout time.Duration) error {
+	if timeout == 0 {
+		return nil
+	}
+	if timeout > timeout.Truncate(time.Second) {
+		return fmt.Errorf("stack timeout value %s is invalid: %w", timeout, errDurationBadUnits)
+	}
+	if timeout < minStackTimeout || timeout > maxStackTimeout {
+		return fmt.Errorf("stack timeout value %s is invalid: duration must be between %v and %v", timeout, minStackTimeout, maxStackTimeout)
+	}
+	return nil
+}
+
 func validateRate(rate interface{}) error {
 	r, ok := rate.(string)
 	if !ok {
@@ -740,7 +771,7 @@ func validatePubSubName(name string) error {
 	return nil
 }
 
-func validateTopicsExist(subscriptions []manifest.TopicSubscription, topicARNs []string, app, env string) error {
+func validateTopicsExist(subscribe manifest.SubscribeConfig, topicARNs []string, app, env string) error {
 	validTopicResources := make([]string, 0, len(topicARNs))
 	for _, topic := range topicARNs {
 		parsedTopic, err := arn.Parse(topic)
@@ -750,11 +781,24 @@ func validateTopicsExist(subscriptions []manifest.TopicSubscription, topicARNs [
 		validTopicResources = append(validTopicResources, parsedTopic.Resource)
 	}
 
-	for _, ts := range subscriptions {
+	for _, ts := range subscribe.Topics {
+		if ts.ImportedARN != nil {
+			// Imported topics aren't published by services in the environment.
+			continue
+		}
 		topicName := fmt.Sprintf(resourceNameFormat, app, env, aws.StringValue(ts.Service), aws.StringValue(ts.Name))
-		if !contains(topicName, validTopicResources) {
-			return fmt.Errorf(fmtErrTopicSubscriptionNotAllowed, topicName, env)
+		// The names of FIFO topics end with a ".fifo" suffix, and only FIFO queues can subscribe to them.
+		fifoTopicName := topicName + ".fifo"
+		fifoQueue := subscribe.DeliversToFIFOQueue(ts)
+		switch {
+		case fifoQueue && contains(fifoTopicName, validTopicResources), !fifoQueue && contains(topicName, validTopicResources):
+			continue
+		case fifoQueue && contains(topicName, validTopicResources):
+			return fmt.Errorf(fmtErrFIFOQueueStandardTopic, topicName)
+		case !fifoQueue && contains(fifoTopicName, validTopicResources):
+			return fmt.Errorf(fmtErrStandardQueueFIFOTopic, fifoTopicName)
 		}
+		return fmt.Errorf(fmtErrTopicSubscriptionNotAllowed, topicName, env)
 	}
 	return nil
 }
@@ -793,6 +837,36 @@ func validateCIDRSlice(val interface{}) error {
 	return nil
 }
 
+func validateGitHubRepoSlug(val interface{}) error {
+	s, ok := val.(string)
+	if !ok {
+		return errValueNotAString
+	}
+	if !githubRepoSlugRegexp.MatchString(s) {
+		return errGitHubRepoSlugBadFormat
+	}
+	if repo := s[strings.Index(s, "/")+1:]; repo == "." || repo == ".." {
+		return errGitHubRepoSlugBadFormat
+	}
+	return nil
+}
+
+func validateGitHubBranchGlob(val interface{}) error {
+	s, ok := val.(string)
+	if !ok {
+		return errValueNotAString
+	}
+	if !githubBranchGlobRegexp.MatchString(s) {
+		return errGitHubBranchGlobBadFormat
+	}
+	// Git ref names can't begin or end with a slash, or contain consecutive slashes or periods.
+	// https://git-scm.com/docs/git-check-ref-format
+	if strings.HasPrefix(s, "/") || strings.HasSuffix(s, "/") || strings.Contains(s, "//") || strings.Contains(s, "..") {
+		return errGitHubBranchGlobBadFormat
+	}
+	return nil
+}
+
 func validateSecretName(val interface{}) error {
 	const minSecretNameLength = 1
 	const maxSecretNameLength = 2048 - (len("/copilot/") + len("/") + len("/secrets/"))
@@ -739,6 +739,91 @@ func TestValidateSecretName(t *testing.T) {
 	}
 }
 
+func TestValidateGitHubRepoSlug(t *testing.T) {
+	testCases := map[string]testCase{
+		"not a string": {
+			input: 123,
+			want:  errValueNotAString,
+		},
+		"missing owner": {
+			input: "my-repo",
+			want:  errGitHubRepoSlugBadFormat,
+		},
+		"url instead of slug": {
+			input: "github.com/my-org/my-repo",
+			want:  errGitHubRepoSlugBadFormat,
+		},
+		"owner starts with a hyphen": {
+			input: "-my-org/my-repo",
+			want:  errGitHubRepoSlugBadFormat,
+		},
+		"repository is a relative path": {
+			input: "my-org/..",
+			want:  errGitHubRepoSlugBadFormat,
+		},
+		"valid slug": {
+			input: "my-org/my_repo.go",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := validateGitHubRepoSlug(tc.input)
+			if tc.want != nil {
+				require.EqualError(t, got, tc.want.Error())
+			} else {
+				require.NoError(t, got)
+			}
+		})
+	}
+}
+
+func TestValidateGitHubBranchGlob(t *testing.T) {
+	testCases := map[string]testCase{
+		"not a string": {
+			input: 123,
+			want:  errValueNotAString,
+		},
+		"empty": {
+			input: "",
+			want:  errGitHubBranchGlobBadFormat,
+		},
+		"contains a space": {
+			input: "my branch",
+			want:  errGitHubBranchGlobBadFormat,
+		},
+		"contains a quote": {
+			input: "main'",
+			want:  errGitHubBranchGlobBadFormat,
+		},
+		"consecutive periods": {
+			input: "release/..",
+			want:  errGitHubBranchGlobBadFormat,
+		},
+		"trailing slash": {
+			input: "release/",
+			want:  errGitHubBranchGlobBadFormat,
+		},
+		"valid branch name": {
+			input: "main",
+		},
+		"valid branch pattern": {
+			input: "release/v?.*",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := validateGitHubBranchGlob(tc.input)
+			if tc.want != nil {
+				require.EqualError(t, got, tc.want.Error())
+			} else {
+				require.NoError(t, got)
+			}
+		})
+	}
+}
+
 func Test_validatePubSubTopicName(t *testing.T) {
 	testCases := map[string]struct {
 		inName string
@@ -874,6 +959,7 @@ func Test_validateTopicsExist(t *testing.T) {
 	}
 	testCases := map[string]struct {
 		inTopics    []manifest.TopicSubscription
+		inQueue     manifest.SQSQueue
 		inTopicARNs []string
 
 		wantErr string
@@ -891,10 +977,70 @@ func Test_validateTopicsExist(t *testing.T) {
 			inTopicARNs: []string{},
 			wantErr:     "SNS topic app-env-database-events does not exist in environment env",
 		},
+		"FIFO topics are valid": {
+			inTopics: []manifest.TopicSubscription{
+				{
+					Name:    aws.String("orders"),
+					Service: aws.String("database"),
+				},
+			},
+			inQueue: manifest.SQSQueue{
+				FIFO: aws.Bool(true),
+			},
+			inTopicARNs: []string{"arn:aws:sqs:us-west-2:123456789012:app-env-database-orders.fifo"},
+		},
+		"FIFO topic with a dedicated FIFO queue is valid": {
+			inTopics: []manifest.TopicSubscription{
+				{
+					Name:    aws.String("orders"),
+					Service: aws.String("database"),
+					Queue: manifest.SQSQueueOrBool{
+						Advanced: manifest.SQSQueue{
+							FIFO: aws.Bool(true),
+						},
+					},
+				},
+			},
+			inTopicARNs: []string{"arn:aws:sqs:us-west-2:123456789012:app-env-database-orders.fifo"},
+		},
+		"error if a standard queue subscribes to a FIFO topic": {
+			inTopics: []manifest.TopicSubscription{
+				{
+					Name:    aws.String("orders"),
+					Service: aws.String("database"),
+				},
+			},
+			inTopicARNs: []string{"arn:aws:sqs:us-west-2:123456789012:app-env-database-orders.fifo"},
+			wantErr:     `SNS topic app-env-database-orders.fifo is a FIFO topic and can only deliver to a FIFO queue, set "queue.fifo" to true`,
+		},
+		"error if a FIFO queue subscribes to a standard topic": {
+			inTopics: []manifest.TopicSubscription{
+				{
+					Name:    aws.String("orders"),
+					Service: aws.String("database"),
+				},
+			},
+			inQueue: manifest.SQSQueue{
+				FIFO: aws.Bool(true),
+			},
+			inTopicARNs: mockAllowedTopics,
+			wantErr:     "SNS topic app-env-database-orders is a standard topic and can't deliver to a FIFO queue",
+		},
+		"imported topics are skipped": {
+			inTopics: []manifest.TopicSubscription{
+				{
+					ImportedARN: aws.String("arn:aws:sns:us-west-2:123456789012:external-events"),
+				},
+			},
+			inTopicARNs: mockAllowedTopics,
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := validateTopicsExist(tc.inTopics, tc.inTopicARNs, mockApp, mockEnv)
+			err := validateTopicsExist(manifest.SubscribeConfig{
+				Topics: tc.inTopics,
+				Queue:  tc.inQueue,
+			}, tc.inTopicARNs, mockApp, mockEnv)
 			if tc.wantErr != "" {
 				require.EqualError(t, err, tc.wantErr)
 			} else {
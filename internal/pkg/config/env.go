@@ -28,18 +28,20 @@ type Environment struct {
 
 // CustomizeEnv represents the custom environment config.
 type CustomizeEnv struct {
-	ImportVPC *ImportVPC `json:"importVPC,omitempty"`
-	VPCConfig *AdjustVPC `json:"adjustVPC,omitempty"`
+	ImportVPC  *ImportVPC  `json:"importVPC,omitempty"`
+	VPCConfig  *AdjustVPC  `json:"adjustVPC,omitempty"`
+	GitHubOIDC *GitHubOIDC `json:"githubOIDC,omitempty"`
 }
 
 // NewCustomizeEnv returns a new CustomizeEnv struct.
-func NewCustomizeEnv(importVPC *ImportVPC, adjustVPC *AdjustVPC) *CustomizeEnv {
-	if importVPC == nil && adjustVPC == nil {
+func NewCustomizeEnv(importVPC *ImportVPC, adjustVPC *AdjustVPC, githubOIDC *GitHubOIDC) *CustomizeEnv {
+	if importVPC == nil && adjustVPC == nil && githubOIDC == nil {
 		return nil
 	}
 	return &CustomizeEnv{
-		ImportVPC: importVPC,
-		VPCConfig: adjustVPC,
+		ImportVPC:  importVPC,
+		VPCConfig:  adjustVPC,
+		GitHubOIDC: githubOIDC,
 	}
 }
 
@@ -57,6 +59,12 @@ type AdjustVPC struct {
 	PrivateSubnetCIDRs []string `json:"privateSubnetCIDRs"`
 }
 
+// GitHubOIDC holds the fields to create a deploy role that GitHub Actions workflows can assume through OIDC.
+type GitHubOIDC struct {
+	Repository string `json:"repository"` // Repository in the "owner/repo" format.
+	Branch     string `json:"branch"`     // Branch name or glob pattern of the branches allowed to assume the role.
+}
+
 // CreateEnvironment instantiates a new environment within an existing App. Skip if
 // the environment already exists in the App.
 func (s *Store) CreateEnvironment(environment *Environment) error {
@@ -60,6 +60,7 @@ type cfnClient interface {
 	Update(*cloudformation.Stack) (string, error)
 	UpdateAndWait(*cloudformation.Stack) error
 	WaitForUpdate(ctx context.Context, stackName string) error
+	CancelUpdate(stackName string) error
 	Delete(stackName string) error
 	DeleteAndWait(stackName string) error
 	DeleteAndWaitWithRoleARN(stackName, roleARN string) error
@@ -147,6 +148,8 @@ type renderStackChangesInput struct {
 	w                progress.FileWriter
 	stackName        string
 	stackDescription string
+	timeout          time.Duration
+	disableRollback  bool
 	createChangeSet  func() (string, error)
 }
 
@@ -155,6 +158,8 @@ func (cf CloudFormation) newRenderWorkloadInput(w progress.FileWriter, stack *cl
 		w:                w,
 		stackName:        stack.Name,
 		stackDescription: fmt.Sprintf("Creating the infrastructure for stack %s", stack.Name),
+		timeout:          stack.Timeout,
+		disableRollback:  aws.BoolValue(stack.DisableRollback),
 	}
 	in.createChangeSet = func() (changeSetID string, err error) {
 		spinner := progress.NewSpinner(w)
@@ -197,7 +202,11 @@ func (cf CloudFormation) renderStackChanges(in *renderStackChangesInput) error {
 	if err != nil {
 		return err
 	}
-	waitCtx, cancelWait := context.WithTimeout(context.Background(), waitForStackTimeout)
+	timeout := waitForStackTimeout
+	if in.timeout != 0 {
+		timeout = in.timeout
+	}
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), timeout)
 	defer cancelWait()
 	g, ctx := errgroup.WithContext(waitCtx)
 
@@ -209,6 +218,9 @@ func (cf CloudFormation) renderStackChanges(in *renderStackChangesInput) error {
 		return progress.Render(ctx, progress.NewTabbedFileWriter(in.w), renderer)
 	})
 	if err := g.Wait(); err != nil {
+		if in.timeout != 0 && errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			return cf.cancelTimedOutUpdate(in.stackName, in.timeout, in.disableRollback)
+		}
 		return err
 	}
 	if err := cf.errOnFailedStack(in.stackName); err != nil {
@@ -217,6 +229,27 @@ func (cf CloudFormation) renderStackChanges(in *renderStackChangesInput) error {
 	return nil
 }
 
+// cancelTimedOutUpdate rolls back the update of a stack that didn't complete within the timeout of the deployment.
+// The creation of a stack can't be canceled, so it keeps going after the timeout.
+// Canceling an update always rolls it back, so updates that disable rollbacks keep going as well.
+func (cf CloudFormation) cancelTimedOutUpdate(stackName string, timeout time.Duration, disableRollback bool) error {
+	stack, err := cf.cfnClient.Describe(stackName)
+	if err != nil {
+		return err
+	}
+	status := aws.StringValue(stack.StackStatus)
+	if status != sdkcloudformation.StackStatusUpdateInProgress {
+		return fmt.Errorf("stack %s did not complete within %s and is in status %s", stackName, timeout, status)
+	}
+	if disableRollback {
+		return fmt.Errorf("stack %s did not complete within %s: the update keeps going because rollbacks are disabled", stackName, timeout)
+	}
+	if err := cf.cfnClient.CancelUpdate(stackName); err != nil {
+		return err
+	}
+	return fmt.Errorf("stack %s did not complete within %s: canceled the update, the stack is rolling back", stackName, timeout)
+}
+
 func (cf CloudFormation) createChangeSetRenderer(group *errgroup.Group, ctx context.Context, changeSetID, stackName, description string, opts progress.RenderOptions) (progress.DynamicRenderer, error) {
 	changeSet, err := cf.cfnClient.DescribeChangeSet(changeSetID, stackName)
 	if err != nil {
@@ -187,6 +187,20 @@ func (mr *MockcfnClientMockRecorder) CreateAndWait(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAndWait", reflect.TypeOf((*MockcfnClient)(nil).CreateAndWait), arg0)
 }
 
+// CancelUpdate mocks base method.
+func (m *MockcfnClient) CancelUpdate(stackName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUpdate", stackName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelUpdate indicates an expected call of CancelUpdate.
+func (mr *MockcfnClientMockRecorder) CancelUpdate(stackName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUpdate", reflect.TypeOf((*MockcfnClient)(nil).CancelUpdate), stackName)
+}
+
 // Delete mocks base method.
 func (m *MockcfnClient) Delete(stackName string) error {
 	m.ctrl.T.Helper()
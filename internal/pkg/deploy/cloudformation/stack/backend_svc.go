@@ -17,7 +17,11 @@ import (
 
 // Parameter logical IDs for a backend service.
 const (
-	BackendServiceContainerPortParamKey = "ContainerPort"
+	BackendServiceContainerPortParamKey   = "ContainerPort"
+	BackendServiceRulePathParamKey        = "RulePath"
+	BackendServiceTargetContainerParamKey = "TargetContainer"
+	BackendServiceTargetPortParamKey      = "TargetPort"
+	BackendServiceStickinessParamKey      = "Stickiness"
 )
 
 const (
@@ -88,12 +92,19 @@ func (s *BackendService) Template() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("convert the sidecar configuration for service %s: %w", s.name, err)
 	}
+	initContainer, err := convertInitContainer(s.manifest.InitContainer, s.rc.InitImage)
+	if err != nil {
+		return "", fmt.Errorf("convert the init container configuration for service %s: %w", s.name, err)
+	}
+	if initContainer != nil {
+		sidecars = append([]*template.SidecarOpts{initContainer}, sidecars...)
+	}
 	publishers, err := convertPublish(s.manifest.Publish(), s.rc.AccountID, s.rc.Region, s.app, s.env, s.name)
 	if err != nil {
 		return "", fmt.Errorf(`convert "publish" field for service %s: %w`, s.name, err)
 	}
 
-	advancedCount, err := convertAdvancedCount(s.manifest.Count.AdvancedCount)
+	advancedCount, err := convertAdvancedCount(s.manifest.Count.AdvancedCount, s.rc.ScalingCalendars)
 	if err != nil {
 		return "", fmt.Errorf("convert the advanced count configuration for service %s: %w", s.name, err)
 	}
@@ -115,31 +126,79 @@ func (s *BackendService) Template() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	tags, err := convertPropagateTags(s.manifest.PropagateTags, s.rc.AdditionalTags)
+	if err != nil {
+		return "", err
+	}
+	network, err := convertNetworkConfig(s.manifest.Network, s.rc.SubnetIDs)
+	if err != nil {
+		return "", fmt.Errorf(`convert "network" field for service %s: %w`, s.name, err)
+	}
+
+	var rulePriorityLambda string
+	var httpHealthCheck template.HTTPHealthCheckOpts
+	var deregistrationDelay *int64
+	var allowedSourceIPs []string
+	if s.internalALBEnabled() {
+		lambda, err := s.parser.Read(lbWebSvcRulePriorityGeneratorPath)
+		if err != nil {
+			return "", fmt.Errorf("read rule priority lambda: %w", err)
+		}
+		rulePriorityLambda = lambda.String()
+		httpHealthCheck = convertHTTPHealthCheck(&s.manifest.RoutingRule.HealthCheck)
+		deregistrationDelay = aws.Int64(60)
+		if s.manifest.RoutingRule.DeregistrationDelay != nil {
+			deregistrationDelay = aws.Int64(int64(s.manifest.RoutingRule.DeregistrationDelay.Seconds()))
+		}
+		for _, ipNet := range s.manifest.RoutingRule.AllowedSourceIps {
+			allowedSourceIPs = append(allowedSourceIPs, string(ipNet))
+		}
+	}
 	content, err := s.parser.ParseBackendService(template.WorkloadOpts{
-		Variables:                s.manifest.BackendServiceConfig.Variables,
-		Secrets:                  s.manifest.BackendServiceConfig.Secrets,
+		Variables:                convertVariables(s.manifest.BackendServiceConfig.Variables),
+		VariableImports:          s.manifest.BackendServiceConfig.Variables.Imports,
+		Secrets:                  convertSecrets(s.manifest.BackendServiceConfig.Secrets),
+		AppConfigSecrets:         convertAppConfigSecrets(s.manifest.BackendServiceConfig.Secrets),
 		NestedStack:              addonsOutputs,
 		AddonsExtraParams:        addonsParams,
 		Sidecars:                 sidecars,
 		Autoscaling:              autoscaling,
+		RollbackAlarms:           convertRollbackAlarms(s.manifest.DeployConfig.RollbackAlarms),
+		DeploymentConfiguration:  convertDeploymentConfiguration(s.manifest.DeployConfig),
 		CapacityProviders:        capacityProviders,
 		DesiredCountOnSpot:       desiredCountOnSpot,
+		AZRebalancing:            aws.BoolValue(s.manifest.AZRebalancing),
+		Tags:                     tags,
 		ExecuteCommand:           convertExecuteCommand(&s.manifest.ExecuteCommand),
 		WorkloadType:             manifest.BackendServiceType,
 		HealthCheck:              convertContainerHealthCheck(s.manifest.BackendServiceConfig.ImageConfig.HealthCheck),
+		HTTPHealthCheck:          httpHealthCheck,
+		DeregistrationDelay:      deregistrationDelay,
+		AllowedSourceIps:         allowedSourceIPs,
+		HTTPVersion:              convertHTTPVersion(s.manifest.RoutingRule.ProtocolVersion),
+		InternalALB:              s.internalALBEnabled(),
+		RulePriorityLambda:       rulePriorityLambda,
 		LogConfig:                convertLogging(s.manifest.Logging),
+		LogGroupName:             aws.StringValue(s.manifest.Logging.LogGroup),
+		ImportLogGroup:           aws.BoolValue(s.manifest.Logging.ImportLogGroup),
+		ContainerName:            aws.StringValue(s.manifest.ContainerName),
+		EnvVarPrefix:             aws.StringValue(s.manifest.EnvVarPrefix),
 		DockerLabels:             s.manifest.ImageConfig.Image.DockerLabels,
 		DesiredCountLambda:       desiredCountLambda.String(),
 		EnvControllerLambda:      envControllerLambda.String(),
 		Storage:                  convertStorageOpts(s.manifest.Name, s.manifest.Storage),
-		Network:                  convertNetworkConfig(s.manifest.Network),
+		Network:                  network,
 		EntryPoint:               entrypoint,
 		Command:                  command,
-		DependsOn:                convertDependsOn(s.manifest.ImageConfig.Image.DependsOn),
+		PseudoTerminal:           s.manifest.PseudoTerminal,
+		Interactive:              s.manifest.Interactive,
+		Ulimits:                  convertUlimits(s.manifest.Ulimits),
+		DependsOn:                convertMainContainerDependsOn(s.manifest.ImageConfig.Image.DependsOn, s.manifest.InitContainer),
 		CredentialsParameter:     aws.StringValue(s.manifest.ImageConfig.Image.Credentials),
 		ServiceDiscoveryEndpoint: s.rc.ServiceDiscoveryEndpoint,
+		ServiceConnect:           convertServiceConnect(s.manifest.Network.Connect, s.name, s.manifest.MainContainerName(s.name), s.manifest.ImageConfig.Port, s.manifest.Sidecars),
 		Publish:                  publishers,
-		Platform:                 convertPlatform(s.manifest.Platform),
+		Platform:                 convertPlatform(s.manifest.ContainerOSArch()),
 	})
 	if err != nil {
 		return "", fmt.Errorf("parse backend service template: %w", err)
@@ -161,14 +220,59 @@ func (s *BackendService) Parameters() ([]*cloudformation.Parameter, error) {
 	if s.manifest.BackendServiceConfig.ImageConfig.Port != nil {
 		containerPort = strconv.FormatUint(uint64(aws.Uint16Value(s.manifest.BackendServiceConfig.ImageConfig.Port)), 10)
 	}
+	svcParams = append(svcParams, &cloudformation.Parameter{
+		ParameterKey:   aws.String(BackendServiceContainerPortParamKey),
+		ParameterValue: aws.String(containerPort),
+	})
+	if !s.internalALBEnabled() {
+		return svcParams, nil
+	}
+	targetContainer, targetPort := s.httpLoadBalancerTarget()
 	return append(svcParams, []*cloudformation.Parameter{
 		{
-			ParameterKey:   aws.String(BackendServiceContainerPortParamKey),
-			ParameterValue: aws.String(containerPort),
+			ParameterKey:   aws.String(BackendServiceRulePathParamKey),
+			ParameterValue: s.manifest.RoutingRule.Path,
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceTargetContainerParamKey),
+			ParameterValue: targetContainer,
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceTargetPortParamKey),
+			ParameterValue: targetPort,
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceStickinessParamKey),
+			ParameterValue: aws.String(strconv.FormatBool(aws.BoolValue(s.manifest.RoutingRule.Stickiness))),
 		},
 	}...), nil
 }
 
+// internalALBEnabled returns true if the service receives HTTP traffic from the environment's internal load balancer.
+func (s *BackendService) internalALBEnabled() bool {
+	return !s.manifest.RoutingRule.IsEmpty()
+}
+
+func (s *BackendService) httpLoadBalancerTarget() (targetContainer *string, targetPort *string) {
+	containerName := s.manifest.MainContainerName(s.name)
+	// Route load balancer traffic to main container by default.
+	targetContainer = aws.String(containerName)
+	targetPort = aws.String(strconv.FormatUint(uint64(aws.Uint16Value(s.manifest.ImageConfig.Port)), 10))
+	if s.manifest.RoutingRule.TargetContainer != nil {
+		targetContainer = s.manifest.RoutingRule.TargetContainer
+	}
+	if s.manifest.RoutingRule.TargetContainerCamelCase != nil {
+		targetContainer = s.manifest.RoutingRule.TargetContainerCamelCase
+	}
+	if aws.StringValue(targetContainer) != containerName {
+		targetPort = s.manifest.Sidecars[aws.StringValue(targetContainer)].Port
+	}
+	if s.manifest.RoutingRule.TargetPort != nil {
+		targetPort = aws.String(strconv.FormatUint(uint64(aws.Uint16Value(s.manifest.RoutingRule.TargetPort)), 10))
+	}
+	return
+}
+
 // SerializedParameters returns the CloudFormation stack's parameters serialized
 // to a YAML document annotated with comments for readability to users.
 func (s *BackendService) SerializedParameters() (string, error) {
@@ -230,6 +230,28 @@ Outputs:
 			},
 			wantedTemplate: "template",
 		},
+		"render template with a custom awslogs log group": {
+			setUpManifest: func(svc *BackendService) {
+				svc.manifest = manifest.NewBackendService(baseProps)
+				svc.manifest.Logging = manifest.Logging{
+					Retention: aws.Int(14),
+					LogGroup:  aws.String("/central/payments/api"),
+				}
+			},
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, svc *BackendService) {
+				m := mocks.NewMockbackendSvcReadParser(ctrl)
+				m.EXPECT().Read(desiredCountGeneratorPath).Return(&template.Content{Buffer: bytes.NewBufferString("something")}, nil)
+				m.EXPECT().Read(envControllerPath).Return(&template.Content{Buffer: bytes.NewBufferString("something")}, nil)
+				m.EXPECT().ParseBackendService(gomock.Any()).DoAndReturn(func(opts template.WorkloadOpts) (*template.Content, error) {
+					require.Equal(t, "/central/payments/api", opts.LogGroupName)
+					require.Nil(t, opts.LogConfig, "a custom log group should not enable FireLens")
+					return &template.Content{Buffer: bytes.NewBufferString("template")}, nil
+				})
+				svc.parser = m
+				svc.addons = mockAddons{tplErr: &addon.ErrAddonsNotFound{}, paramsErr: &addon.ErrAddonsNotFound{}}
+			},
+			wantedTemplate: "template",
+		},
 	}
 
 	for name, tc := range testCases {
@@ -258,7 +280,7 @@ Outputs:
 				tc.setUpManifest(conf)
 				privatePlacement := manifest.Placement(manifest.PrivateSubnetPlacement)
 				conf.manifest.Network.VPC.Placement = &privatePlacement
-				conf.manifest.Network.VPC.SecurityGroups = []string{"sg-1234"}
+				conf.manifest.Network.VPC.SecurityGroups = []manifest.SecurityGroup{{ID: aws.String("sg-1234")}}
 			}
 
 			tc.mockDependencies(t, ctrl, conf)
@@ -355,3 +377,61 @@ func TestBackendService_Parameters(t *testing.T) {
 		},
 	}, params)
 }
+
+func TestBackendService_ParametersWithInternalALB(t *testing.T) {
+	testBackendSvcManifest := manifest.NewBackendService(manifest.BackendServiceProps{
+		WorkloadProps: manifest.WorkloadProps{
+			Name:       testServiceName,
+			Dockerfile: testDockerfile,
+		},
+		Port: 8080,
+	})
+	testBackendSvcManifest.RoutingRule = manifest.RoutingRule{
+		Path:       aws.String("api"),
+		TargetPort: aws.Uint16(8081),
+		Stickiness: aws.Bool(true),
+	}
+
+	conf := &BackendService{
+		ecsWkld: &ecsWkld{
+			wkld: &wkld{
+				name: aws.StringValue(testBackendSvcManifest.Name),
+				env:  testEnvName,
+				app:  testAppName,
+				image: manifest.Image{
+					Location: aws.String("mockLocation"),
+				},
+			},
+			tc: testBackendSvcManifest.BackendServiceConfig.TaskConfig,
+		},
+		manifest: testBackendSvcManifest,
+	}
+
+	// WHEN
+	params, err := conf.Parameters()
+
+	// THEN
+	require.NoError(t, err)
+	require.Subset(t, params, []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(BackendServiceContainerPortParamKey),
+			ParameterValue: aws.String("8080"),
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceRulePathParamKey),
+			ParameterValue: aws.String("api"),
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceTargetContainerParamKey),
+			ParameterValue: aws.String("frontend"),
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceTargetPortParamKey),
+			ParameterValue: aws.String("8081"),
+		},
+		{
+			ParameterKey:   aws.String(BackendServiceStickinessParamKey),
+			ParameterValue: aws.String("true"),
+		},
+	})
+}
@@ -96,6 +96,7 @@ func (e *EnvStackConfig) Template() (string, error) {
 		ScriptBucketName:       bucket,
 		ImportVPC:              e.in.ImportVPCConfig,
 		VPCConfig:              vpcConf,
+		GitHubOIDC:             e.in.GitHubOIDCConfig,
 		Version:                e.in.Version,
 		LatestVersion:          deploy.LatestEnvTemplateVersion,
 	}, template.WithFuncs(map[string]interface{}{
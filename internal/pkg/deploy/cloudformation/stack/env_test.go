@@ -17,6 +17,7 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/template"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestEnv_Template(t *testing.T) {
@@ -72,6 +73,57 @@ func TestEnv_Template(t *testing.T) {
 	}
 }
 
+func TestEnv_Template_GitHubOIDC(t *testing.T) {
+	in := mockDeployEnvironmentInput()
+	in.Version = deploy.LatestEnvTemplateVersion
+	in.GitHubOIDCConfig = &config.GitHubOIDC{
+		Repository: "octo-org/octo-repo",
+		Branch:     "release/*",
+	}
+
+	tpl, err := NewEnvStackConfig(in).Template()
+	require.NoError(t, err)
+
+	var got struct {
+		Resources struct {
+			GitHubDeployRole struct {
+				Properties struct {
+					AssumeRolePolicyDocument struct {
+						Statement []struct {
+							Action    string                       `yaml:"Action"`
+							Condition map[string]map[string]string `yaml:"Condition"`
+						} `yaml:"Statement"`
+					} `yaml:"AssumeRolePolicyDocument"`
+				} `yaml:"Properties"`
+			} `yaml:"GitHubDeployRole"`
+		} `yaml:"Resources"`
+		Outputs map[string]interface{} `yaml:"Outputs"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(tpl), &got))
+	statements := got.Resources.GitHubDeployRole.Properties.AssumeRolePolicyDocument.Statement
+	require.Len(t, statements, 1)
+	require.Equal(t, "sts:AssumeRoleWithWebIdentity", statements[0].Action)
+	require.Equal(t, map[string]map[string]string{
+		"StringEquals": {
+			"token.actions.githubusercontent.com:aud": "sts.amazonaws.com",
+		},
+		"StringLike": {
+			"token.actions.githubusercontent.com:sub": "repo:octo-org/octo-repo:ref:refs/heads/release/*",
+		},
+	}, statements[0].Condition)
+	require.Contains(t, got.Outputs, "GitHubDeployRoleARN")
+}
+
+func TestEnv_Template_WithoutGitHubOIDC(t *testing.T) {
+	in := mockDeployEnvironmentInput()
+	in.Version = deploy.LatestEnvTemplateVersion
+
+	tpl, err := NewEnvStackConfig(in).Template()
+
+	require.NoError(t, err)
+	require.NotContains(t, tpl, "GitHubDeployRole")
+}
+
 func TestEnv_Parameters(t *testing.T) {
 	deploymentInput := mockDeployEnvironmentInput()
 	deploymentInputWithDNS := mockDeployEnvironmentInput()
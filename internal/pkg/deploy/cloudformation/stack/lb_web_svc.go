@@ -6,6 +6,7 @@ package stack
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -33,6 +34,20 @@ const (
 	LBWebServiceDNSDelegatedParamKey    = "DNSDelegated"
 )
 
+// Output keys of a load balanced web service deployed with blue/green.
+const (
+	LBWebServiceTaskDefinitionOutputKey            = "TaskDefinition"
+	LBWebServiceServiceTaskDefinitionOutputKey     = "ServiceTaskDefinition"
+	LBWebServiceCodeDeployApplicationOutputKey     = "CodeDeployApplication"
+	LBWebServiceCodeDeployDeploymentGroupOutputKey = "CodeDeployDeploymentGroup"
+)
+
+// Protocols of the network load balancer listeners.
+const (
+	nlbDefaultProtocol = "TCP"
+	nlbProtocolTLS     = "TLS"
+)
+
 type loadBalancedWebSvcReadParser interface {
 	template.ReadParser
 	ParseLoadBalancedWebService(template.WorkloadOpts) (*template.Content, error)
@@ -141,12 +156,22 @@ func (s *LoadBalancedWebService) Template() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("convert the sidecar configuration for service %s: %w", s.name, err)
 	}
+	initContainer, err := convertInitContainer(s.manifest.InitContainer, s.rc.InitImage)
+	if err != nil {
+		return "", fmt.Errorf("convert the init container configuration for service %s: %w", s.name, err)
+	}
+	if initContainer != nil {
+		sidecars = append([]*template.SidecarOpts{initContainer}, sidecars...)
+	}
+	if tracing := convertTracingSidecar(s.manifest.Observability); tracing != nil {
+		sidecars = append(sidecars, tracing)
+	}
 	publishers, err := convertPublish(s.manifest.Publish(), s.rc.AccountID, s.rc.Region, s.app, s.env, s.name)
 	if err != nil {
 		return "", fmt.Errorf(`convert "publish" field for service %s: %w`, s.name, err)
 	}
 
-	advancedCount, err := convertAdvancedCount(s.manifest.Count.AdvancedCount)
+	advancedCount, err := convertAdvancedCount(s.manifest.Count.AdvancedCount, s.rc.ScalingCalendars)
 	if err != nil {
 		return "", fmt.Errorf("convert the advanced count configuration for service %s: %w", s.name, err)
 	}
@@ -169,12 +194,21 @@ func (s *LoadBalancedWebService) Template() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	tags, err := convertPropagateTags(s.manifest.PropagateTags, s.rc.AdditionalTags)
+	if err != nil {
+		return "", err
+	}
 
 	var aliases []string
+	var hostedZoneAliases map[string][]string
 	if s.httpsEnabled {
 		if aliases, err = convertAlias(s.manifest.Alias); err != nil {
 			return "", err
 		}
+		hostedZoneAliases = s.manifest.Alias.HostedZoneAliases()
+	}
+	if !s.httpsEnabled && aws.BoolValue(s.manifest.RedirectToHTTPS) {
+		return "", fmt.Errorf(`"http.redirect_to_https" cannot be enabled for service %s because its load balancer has no certificate: associate the application with a domain to enable HTTPS`, s.name)
 	}
 
 	var deregistrationDelay *int64 = aws.Int64(60)
@@ -186,37 +220,71 @@ func (s *LoadBalancedWebService) Template() (string, error) {
 	for _, ipNet := range s.manifest.AllowedSourceIps {
 		allowedSourceIPs = append(allowedSourceIPs, string(ipNet))
 	}
+	additionalRules, err := convertAdditionalRules(s.manifest.AdditionalRules, s.httpsEnabled)
+	if err != nil {
+		return "", err
+	}
 
+	network, err := convertNetworkConfig(s.manifest.Network, s.rc.SubnetIDs)
+	if err != nil {
+		return "", fmt.Errorf(`convert "network" field for service %s: %w`, s.name, err)
+	}
+	nlb, err := s.convertNetworkLoadBalancer()
+	if err != nil {
+		return "", fmt.Errorf(`convert "nlb" field for service %s: %w`, s.name, err)
+	}
 	content, err := s.parser.ParseLoadBalancedWebService(template.WorkloadOpts{
-		Variables:                s.manifest.TaskConfig.Variables,
-		Secrets:                  s.manifest.TaskConfig.Secrets,
+		Variables:                convertVariables(s.manifest.TaskConfig.Variables),
+		VariableImports:          s.manifest.TaskConfig.Variables.Imports,
+		Secrets:                  convertSecrets(s.manifest.TaskConfig.Secrets),
+		AppConfigSecrets:         convertAppConfigSecrets(s.manifest.TaskConfig.Secrets),
 		Aliases:                  aliases,
+		HostedZoneAliases:        hostedZoneAliases,
 		NestedStack:              addonsOutputs,
 		AddonsExtraParams:        addonsParams,
 		Sidecars:                 sidecars,
 		LogConfig:                convertLogging(s.manifest.Logging),
+		LogGroupName:             aws.StringValue(s.manifest.Logging.LogGroup),
+		ImportLogGroup:           aws.BoolValue(s.manifest.Logging.ImportLogGroup),
+		ContainerName:            aws.StringValue(s.manifest.ContainerName),
+		EnvVarPrefix:             aws.StringValue(s.manifest.EnvVarPrefix),
 		DockerLabels:             s.manifest.ImageConfig.Image.DockerLabels,
 		Autoscaling:              autoscaling,
+		RollbackAlarms:           convertRollbackAlarms(s.manifest.DeployConfig.RollbackAlarms),
+		DeploymentConfiguration:  convertDeploymentConfiguration(s.manifest.DeployConfig),
+		BlueGreen:                convertBlueGreen(s.manifest.DeployConfig.BlueGreen, s.rc.ServiceTaskDefinitionARN),
 		CapacityProviders:        capacityProviders,
 		DesiredCountOnSpot:       desiredCountOnSpot,
+		AZRebalancing:            aws.BoolValue(s.manifest.AZRebalancing),
+		Tags:                     tags,
 		ExecuteCommand:           convertExecuteCommand(&s.manifest.ExecuteCommand),
 		WorkloadType:             manifest.LoadBalancedWebServiceType,
 		HealthCheck:              convertContainerHealthCheck(s.manifest.ImageConfig.HealthCheck),
 		HTTPHealthCheck:          convertHTTPHealthCheck(&s.manifest.HealthCheck),
 		DeregistrationDelay:      deregistrationDelay,
 		AllowedSourceIps:         allowedSourceIPs,
+		AdditionalRules:          additionalRules,
+		DisableHTTPSRedirect:     s.manifest.RedirectToHTTPS != nil && !aws.BoolValue(s.manifest.RedirectToHTTPS),
+		HostnameVariable:         convertHostnameVariable(s.manifest.HostnameVariable, aliases),
+		AliasRouting:             convertAliasRouting(s.manifest.AliasRouting),
+		Observability:            convertObservability(s.manifest.Observability),
 		RulePriorityLambda:       rulePriorityLambda.String(),
 		DesiredCountLambda:       desiredCountLambda.String(),
 		EnvControllerLambda:      envControllerLambda.String(),
 		Storage:                  convertStorageOpts(s.manifest.Name, s.manifest.Storage),
-		Network:                  convertNetworkConfig(s.manifest.Network),
+		Network:                  network,
+		NLB:                      nlb,
 		EntryPoint:               entrypoint,
 		Command:                  command,
-		DependsOn:                convertDependsOn(s.manifest.ImageConfig.Image.DependsOn),
+		PseudoTerminal:           s.manifest.PseudoTerminal,
+		Interactive:              s.manifest.Interactive,
+		Ulimits:                  convertUlimits(s.manifest.Ulimits),
+		DependsOn:                convertMainContainerDependsOn(s.manifest.ImageConfig.Image.DependsOn, s.manifest.InitContainer),
 		CredentialsParameter:     aws.StringValue(s.manifest.ImageConfig.Image.Credentials),
 		ServiceDiscoveryEndpoint: s.rc.ServiceDiscoveryEndpoint,
+		ServiceConnect:           convertServiceConnect(s.manifest.Network.Connect, s.name, s.manifest.MainContainerName(s.name), s.manifest.ImageConfig.Port, s.manifest.Sidecars),
 		Publish:                  publishers,
-		Platform:                 convertPlatform(s.manifest.Platform),
+		Platform:                 convertPlatform(s.manifest.ContainerOSArch()),
 		HTTPVersion:              convertHTTPVersion(s.manifest.ProtocolVersion),
 	})
 	if err != nil {
@@ -230,7 +298,7 @@ func (s *LoadBalancedWebService) Template() (string, error) {
 }
 
 func (s *LoadBalancedWebService) httpLoadBalancerTarget() (targetContainer *string, targetPort *string) {
-	containerName := s.name
+	containerName := s.manifest.MainContainerName(s.name)
 	containerPort := strconv.FormatUint(uint64(aws.Uint16Value(s.manifest.ImageConfig.Port)), 10)
 	// Route load balancer traffic to main container by default.
 	targetContainer = aws.String(containerName)
@@ -244,9 +312,116 @@ func (s *LoadBalancedWebService) httpLoadBalancerTarget() (targetContainer *stri
 	if aws.StringValue(targetContainer) != containerName {
 		targetPort = s.manifest.Sidecars[aws.StringValue(targetContainer)].Port
 	}
+	if s.manifest.TargetPort != nil {
+		targetPort = aws.String(strconv.FormatUint(uint64(aws.Uint16Value(s.manifest.TargetPort)), 10))
+	}
 	return
 }
 
+// convertNetworkLoadBalancer returns nil if the service has no network load balancer.
+func (s *LoadBalancedWebService) convertNetworkLoadBalancer() (*template.NetworkLoadBalancer, error) {
+	nlb := s.manifest.NLBConfig
+	if nlb.IsEmpty() {
+		return nil, nil
+	}
+	listener, err := s.convertNLBListener(nlb.Port, nlb.TargetContainer, nlb.TargetPort, &nlb.HealthCheck)
+	if err != nil {
+		return nil, err
+	}
+	listener.SSLPolicy = nlb.SSLPolicy
+	listener.Stickiness = nlb.Stickiness
+	listener.PreserveClientIP = nlb.PreserveClientIP
+	listener.ProxyProtocolV2 = aws.BoolValue(nlb.ProxyProtocolV2)
+	var additionalListeners []template.NetworkLoadBalancerListener
+	for _, l := range nlb.AdditionalListeners {
+		additional, err := s.convertNLBListener(l.Port, l.TargetContainer, l.TargetPort, &l.HealthCheck)
+		if err != nil {
+			return nil, err
+		}
+		additional.Stickiness = l.Stickiness
+		additionalListeners = append(additionalListeners, additional)
+	}
+	mainPortMappings, sidecarPortMappings := s.nlbPortMappings(append([]template.NetworkLoadBalancerListener{listener}, additionalListeners...))
+	return &template.NetworkLoadBalancer{
+		PublicSubnetCIDRs:         s.publicSubnetCIDRBlocks,
+		Listener:                  listener,
+		AdditionalListeners:       additionalListeners,
+		MainContainerPortMappings: mainPortMappings,
+		SidecarPortMappings:       sidecarPortMappings,
+	}, nil
+}
+
+// nlbPortMappings returns the ports and protocols that the listeners target but that the containers don't expose yet.
+// A container has to map each target port for each IP protocol, for example both "tcp" and "udp" for a TCP_UDP listener.
+func (s *LoadBalancedWebService) nlbPortMappings(listeners []template.NetworkLoadBalancerListener) (main []template.PortMapping, sidecars map[string][]template.PortMapping) {
+	mainContainerName := s.manifest.MainContainerName(s.name)
+	exposed := map[string]map[template.PortMapping]bool{
+		mainContainerName: {
+			{ContainerPort: strconv.FormatUint(uint64(aws.Uint16Value(s.manifest.ImageConfig.Port)), 10), Protocol: "tcp"}: true,
+		},
+	}
+	for name, sidecar := range s.manifest.Sidecars {
+		port, protocol, err := parsePortMapping(sidecar.Port)
+		if err != nil || port == nil {
+			continue
+		}
+		mapping := template.PortMapping{ContainerPort: aws.StringValue(port), Protocol: "tcp"}
+		if protocol != nil {
+			mapping.Protocol = strings.ToLower(aws.StringValue(protocol))
+		}
+		exposed[name] = map[template.PortMapping]bool{mapping: true}
+	}
+	for _, listener := range listeners {
+		if exposed[listener.TargetContainer] == nil {
+			continue
+		}
+		for _, protocol := range listener.IngressProtocols() {
+			mapping := template.PortMapping{ContainerPort: listener.TargetPort, Protocol: protocol}
+			if exposed[listener.TargetContainer][mapping] {
+				continue
+			}
+			exposed[listener.TargetContainer][mapping] = true
+			if listener.TargetContainer == mainContainerName {
+				main = append(main, mapping)
+				continue
+			}
+			if sidecars == nil {
+				sidecars = make(map[string][]template.PortMapping)
+			}
+			sidecars[listener.TargetContainer] = append(sidecars[listener.TargetContainer], mapping)
+		}
+	}
+	return main, sidecars
+}
+
+// convertNLBListener routes the traffic of the listener to the port of the main container by default.
+func (s *LoadBalancedWebService) convertNLBListener(portMapping *string, targetContainer *string, targetPort *int, hc *manifest.HealthCheckArgsOrString) (template.NetworkLoadBalancerListener, error) {
+	port, protocol, err := parsePortMapping(portMapping)
+	if err != nil {
+		return template.NetworkLoadBalancerListener{}, err
+	}
+	listener := template.NetworkLoadBalancerListener{
+		Port:            aws.StringValue(port),
+		Protocol:        strings.ToUpper(aws.StringValue(protocol)),
+		TargetContainer: s.manifest.MainContainerName(s.name),
+		TargetPort:      aws.StringValue(port),
+		HealthCheck:     convertHTTPHealthCheck(hc),
+	}
+	if listener.Protocol == "" {
+		listener.Protocol = nlbDefaultProtocol
+	}
+	if listener.Protocol == nlbProtocolTLS && !s.httpsEnabled {
+		return template.NetworkLoadBalancerListener{}, fmt.Errorf("TLS listener of port %s requires a certificate: associate the application with a domain to enable TLS", listener.Port)
+	}
+	if targetContainer != nil {
+		listener.TargetContainer = aws.StringValue(targetContainer)
+	}
+	if targetPort != nil {
+		listener.TargetPort = strconv.Itoa(aws.IntValue(targetPort))
+	}
+	return listener, nil
+}
+
 // Parameters returns the list of CloudFormation parameters used by the template.
 func (s *LoadBalancedWebService) Parameters() ([]*cloudformation.Parameter, error) {
 	wkldParams, err := s.ecsWkld.Parameters()
@@ -349,6 +349,380 @@ Outputs:
 	}
 }
 
+func TestLoadBalancedWebService_TemplateRedirectToHTTPS(t *testing.T) {
+	testCases := map[string]struct {
+		inRedirectToHTTPS *bool
+		inHTTPSEnabled    bool
+
+		wantedDisableHTTPSRedirect bool
+		wantedError                error
+	}{
+		"redirects to HTTPS by default if the load balancer has a certificate": {
+			inHTTPSEnabled: true,
+		},
+		"forwards HTTP traffic if the redirect is turned off": {
+			inRedirectToHTTPS: aws.Bool(false),
+			inHTTPSEnabled:    true,
+
+			wantedDisableHTTPSRedirect: true,
+		},
+		"keeps HTTP traffic unchanged if the field is omitted and there is no certificate": {},
+		"error if the redirect is turned on and there is no certificate": {
+			inRedirectToHTTPS: aws.Bool(true),
+
+			wantedError: errors.New(`"http.redirect_to_https" cannot be enabled for service frontend because its load balancer has no certificate: associate the application with a domain to enable HTTPS`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mft := manifest.NewLoadBalancedWebService(&manifest.LoadBalancedWebServiceProps{
+				WorkloadProps: &manifest.WorkloadProps{
+					Name:       "frontend",
+					Dockerfile: "frontend/Dockerfile",
+				},
+				Path: "frontend",
+				Port: 80,
+			})
+			mft.RedirectToHTTPS = tc.inRedirectToHTTPS
+			m := mocks.NewMockloadBalancedWebSvcReadParser(ctrl)
+			m.EXPECT().Read(gomock.Any()).Return(&template.Content{Buffer: bytes.NewBufferString("lambda")}, nil).AnyTimes()
+			m.EXPECT().ParseLoadBalancedWebService(gomock.Any()).DoAndReturn(func(opts template.WorkloadOpts) (*template.Content, error) {
+				require.Equal(t, tc.wantedDisableHTTPSRedirect, opts.DisableHTTPSRedirect)
+				return &template.Content{Buffer: bytes.NewBufferString("template")}, nil
+			}).AnyTimes()
+			conf := &LoadBalancedWebService{
+				ecsWkld: &ecsWkld{
+					wkld: &wkld{
+						name: aws.StringValue(mft.Name),
+						env:  testEnvName,
+						app:  testAppName,
+						rc: RuntimeConfig{
+							Image: &ECRImage{
+								RepoURL:  testImageRepoURL,
+								ImageTag: testImageTag,
+							},
+						},
+						addons: mockAddons{tplErr: &addon.ErrAddonsNotFound{}, paramsErr: &addon.ErrAddonsNotFound{}},
+					},
+					taskDefOverrideFunc: mockCloudFormationOverrideFunc,
+				},
+				manifest:     mft,
+				httpsEnabled: tc.inHTTPSEnabled,
+				parser:       m,
+			}
+
+			// WHEN
+			_, err := conf.Template()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLoadBalancedWebService_TemplateHostedZoneAliases(t *testing.T) {
+	testCases := map[string]struct {
+		inHTTPSEnabled bool
+
+		wantedAliases           []string
+		wantedHostedZoneAliases map[string][]string
+	}{
+		"creates the records of the aliases in their hosted zones": {
+			inHTTPSEnabled: true,
+
+			wantedAliases: []string{"example.com", "api.example.com"},
+			wantedHostedZoneAliases: map[string][]string{
+				"Z0873220N255IR3MTNR4": {"example.com"},
+			},
+		},
+		"ignores the aliases if the load balancer has no certificate": {},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mft := manifest.NewLoadBalancedWebService(&manifest.LoadBalancedWebServiceProps{
+				WorkloadProps: &manifest.WorkloadProps{
+					Name:       "frontend",
+					Dockerfile: "frontend/Dockerfile",
+				},
+				Path: "frontend",
+				Port: 80,
+			})
+			mft.Alias = manifest.Alias{
+				AdvancedAliases: []manifest.AdvancedAlias{
+					{
+						Alias:      aws.String("example.com"),
+						HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+					},
+					{
+						Alias: aws.String("api.example.com"),
+					},
+				},
+			}
+			m := mocks.NewMockloadBalancedWebSvcReadParser(ctrl)
+			m.EXPECT().Read(gomock.Any()).Return(&template.Content{Buffer: bytes.NewBufferString("lambda")}, nil).AnyTimes()
+			m.EXPECT().ParseLoadBalancedWebService(gomock.Any()).DoAndReturn(func(opts template.WorkloadOpts) (*template.Content, error) {
+				require.Equal(t, tc.wantedAliases, opts.Aliases)
+				require.Equal(t, tc.wantedHostedZoneAliases, opts.HostedZoneAliases)
+				return &template.Content{Buffer: bytes.NewBufferString("template")}, nil
+			})
+			conf := &LoadBalancedWebService{
+				ecsWkld: &ecsWkld{
+					wkld: &wkld{
+						name: aws.StringValue(mft.Name),
+						env:  testEnvName,
+						app:  testAppName,
+						rc: RuntimeConfig{
+							Image: &ECRImage{
+								RepoURL:  testImageRepoURL,
+								ImageTag: testImageTag,
+							},
+						},
+						addons: mockAddons{tplErr: &addon.ErrAddonsNotFound{}, paramsErr: &addon.ErrAddonsNotFound{}},
+					},
+					taskDefOverrideFunc: mockCloudFormationOverrideFunc,
+				},
+				manifest:     mft,
+				httpsEnabled: tc.inHTTPSEnabled,
+				parser:       m,
+			}
+
+			// WHEN
+			_, err := conf.Template()
+
+			// THEN
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLoadBalancedWebService_TemplateNLB(t *testing.T) {
+	testCases := map[string]struct {
+		inNLB          manifest.NetworkLoadBalancerConfiguration
+		inSidecars     map[string]*manifest.SidecarConfig
+		inHTTPSEnabled bool
+
+		wantedNLB   *template.NetworkLoadBalancer
+		wantedError string
+	}{
+		"no network load balancer": {},
+		"routes the listener to the main container by default": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Port: aws.String("443/tls"),
+				HealthCheck: manifest.HealthCheckArgsOrString{
+					HealthCheckArgs: manifest.HTTPHealthCheckArgs{
+						HealthyThreshold: aws.Int64(3),
+					},
+				},
+				SSLPolicy:        aws.String("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+				PreserveClientIP: aws.Bool(false),
+				ProxyProtocolV2:  aws.Bool(true),
+			},
+			inHTTPSEnabled: true,
+
+			wantedNLB: &template.NetworkLoadBalancer{
+				PublicSubnetCIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"},
+				Listener: template.NetworkLoadBalancerListener{
+					Port:             "443",
+					Protocol:         "TLS",
+					TargetContainer:  "frontend",
+					TargetPort:       "443",
+					SSLPolicy:        aws.String("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+					PreserveClientIP: aws.Bool(false),
+					ProxyProtocolV2:  true,
+					HealthCheck: template.HTTPHealthCheckOpts{
+						HealthCheckPath:  manifest.DefaultHealthCheckPath,
+						HealthyThreshold: aws.Int64(3),
+						GracePeriod:      aws.Int64(manifest.DefaultHealthCheckGracePeriod),
+					},
+				},
+				MainContainerPortMappings: []template.PortMapping{
+					{
+						ContainerPort: "443",
+						Protocol:      "tcp",
+					},
+				},
+			},
+		},
+		"routes the listeners to their target containers and ports": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Port:            aws.String("80"),
+				TargetContainer: aws.String("envoy"),
+				TargetPort:      aws.Int(8080),
+				Stickiness:      aws.Bool(true),
+				AdditionalListeners: []manifest.NetworkLoadBalancerListener{
+					{
+						Port:            aws.String("53/udp"),
+						TargetContainer: aws.String("dns"),
+						TargetPort:      aws.Int(8053),
+					},
+					{
+						Port:       aws.String("8443"),
+						Stickiness: aws.Bool(false),
+					},
+				},
+			},
+			inSidecars: map[string]*manifest.SidecarConfig{
+				"envoy": {
+					Port: aws.String("8080"),
+				},
+				"dns": {
+					Port: aws.String("8053"),
+				},
+			},
+
+			wantedNLB: &template.NetworkLoadBalancer{
+				PublicSubnetCIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"},
+				Listener: template.NetworkLoadBalancerListener{
+					Port:            "80",
+					Protocol:        "TCP",
+					TargetContainer: "envoy",
+					TargetPort:      "8080",
+					Stickiness:      aws.Bool(true),
+					HealthCheck: template.HTTPHealthCheckOpts{
+						HealthCheckPath: manifest.DefaultHealthCheckPath,
+						GracePeriod:     aws.Int64(manifest.DefaultHealthCheckGracePeriod),
+					},
+				},
+				AdditionalListeners: []template.NetworkLoadBalancerListener{
+					{
+						Port:            "53",
+						Protocol:        "UDP",
+						TargetContainer: "dns",
+						TargetPort:      "8053",
+						HealthCheck: template.HTTPHealthCheckOpts{
+							HealthCheckPath: manifest.DefaultHealthCheckPath,
+							GracePeriod:     aws.Int64(manifest.DefaultHealthCheckGracePeriod),
+						},
+					},
+					{
+						Port:            "8443",
+						Protocol:        "TCP",
+						TargetContainer: "frontend",
+						TargetPort:      "8443",
+						Stickiness:      aws.Bool(false),
+						HealthCheck: template.HTTPHealthCheckOpts{
+							HealthCheckPath: manifest.DefaultHealthCheckPath,
+							GracePeriod:     aws.Int64(manifest.DefaultHealthCheckGracePeriod),
+						},
+					},
+				},
+				MainContainerPortMappings: []template.PortMapping{
+					{
+						ContainerPort: "8443",
+						Protocol:      "tcp",
+					},
+				},
+				SidecarPortMappings: map[string][]template.PortMapping{
+					"dns": {
+						{
+							ContainerPort: "8053",
+							Protocol:      "udp",
+						},
+					},
+				},
+			},
+		},
+		"maps the udp port of a TCP_UDP listener to the main container": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Port: aws.String("80/tcp_udp"),
+			},
+
+			wantedNLB: &template.NetworkLoadBalancer{
+				PublicSubnetCIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"},
+				Listener: template.NetworkLoadBalancerListener{
+					Port:            "80",
+					Protocol:        "TCP_UDP",
+					TargetContainer: "frontend",
+					TargetPort:      "80",
+					HealthCheck: template.HTTPHealthCheckOpts{
+						HealthCheckPath: manifest.DefaultHealthCheckPath,
+						GracePeriod:     aws.Int64(manifest.DefaultHealthCheckGracePeriod),
+					},
+				},
+				MainContainerPortMappings: []template.PortMapping{
+					{
+						ContainerPort: "80",
+						Protocol:      "udp",
+					},
+				},
+			},
+		},
+		"errors if a TLS listener has no certificate": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Port: aws.String("443/tls"),
+			},
+
+			wantedError: `convert "nlb" field for service frontend: TLS listener of port 443 requires a certificate: associate the application with a domain to enable TLS`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mft := manifest.NewLoadBalancedWebService(&manifest.LoadBalancedWebServiceProps{
+				WorkloadProps: &manifest.WorkloadProps{
+					Name:       "frontend",
+					Dockerfile: "frontend/Dockerfile",
+				},
+				Path: "frontend",
+				Port: 80,
+			})
+			mft.NLBConfig = tc.inNLB
+			mft.Sidecars = tc.inSidecars
+			m := mocks.NewMockloadBalancedWebSvcReadParser(ctrl)
+			m.EXPECT().Read(gomock.Any()).Return(&template.Content{Buffer: bytes.NewBufferString("lambda")}, nil).AnyTimes()
+			if tc.wantedError == "" {
+				m.EXPECT().ParseLoadBalancedWebService(gomock.Any()).DoAndReturn(func(opts template.WorkloadOpts) (*template.Content, error) {
+					require.Equal(t, tc.wantedNLB, opts.NLB)
+					return &template.Content{Buffer: bytes.NewBufferString("template")}, nil
+				})
+			}
+			conf := &LoadBalancedWebService{
+				ecsWkld: &ecsWkld{
+					wkld: &wkld{
+						name: aws.StringValue(mft.Name),
+						env:  testEnvName,
+						app:  testAppName,
+						rc: RuntimeConfig{
+							Image: &ECRImage{
+								RepoURL:  testImageRepoURL,
+								ImageTag: testImageTag,
+							},
+						},
+						addons: mockAddons{tplErr: &addon.ErrAddonsNotFound{}, paramsErr: &addon.ErrAddonsNotFound{}},
+					},
+					taskDefOverrideFunc: mockCloudFormationOverrideFunc,
+				},
+				manifest:               mft,
+				httpsEnabled:           tc.inHTTPSEnabled,
+				publicSubnetCIDRBlocks: []string{"10.0.0.0/24", "10.0.1.0/24"},
+				parser:                 m,
+			}
+
+			// WHEN
+			_, err := conf.Template()
+
+			// THEN
+			if tc.wantedError != "" {
+				require.EqualError(t, err, tc.wantedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestLoadBalancedWebService_Parameters(t *testing.T) {
 	baseProps := &manifest.LoadBalancedWebServiceProps{
 		WorkloadProps: &manifest.WorkloadProps{
@@ -384,6 +758,14 @@ func TestLoadBalancedWebService_Parameters(t *testing.T) {
 			Port: aws.String("5000"),
 		},
 	}
+	testLBWebServiceManifestWithTargetPort := manifest.NewLoadBalancedWebService(baseProps)
+	testLBWebServiceManifestWithTargetPort.TargetContainer = aws.String("nginx")
+	testLBWebServiceManifestWithTargetPort.TargetPort = aws.Uint16(8080)
+	testLBWebServiceManifestWithTargetPort.Sidecars = map[string]*manifest.SidecarConfig{
+		"nginx": {
+			Port: aws.String("8080/tcp"),
+		},
+	}
 	testLBWebServiceManifestWithStickiness := manifest.NewLoadBalancedWebService(baseProps)
 	testLBWebServiceManifestWithStickiness.Stickiness = aws.Bool(true)
 	testLBWebServiceManifestWithExecEnabled := manifest.NewLoadBalancedWebService(baseProps)
@@ -393,6 +775,8 @@ func TestLoadBalancedWebService_Parameters(t *testing.T) {
 			Enable: aws.Bool(true),
 		},
 	}
+	testLBWebServiceManifestWithContainerName := manifest.NewLoadBalancedWebService(baseProps)
+	testLBWebServiceManifestWithContainerName.ContainerName = aws.String("web")
 	expectedParams := []*cloudformation.Parameter{
 		{
 			ParameterKey:   aws.String(WorkloadAppNameParamKey),
@@ -536,6 +920,37 @@ func TestLoadBalancedWebService_Parameters(t *testing.T) {
 				},
 			}...),
 		},
+		"with target port of sidecar container": {
+			httpsEnabled: true,
+			manifest:     testLBWebServiceManifestWithTargetPort,
+
+			expectedParams: append(expectedParams, []*cloudformation.Parameter{
+				{
+					ParameterKey:   aws.String(LBWebServiceHTTPSParamKey),
+					ParameterValue: aws.String("true"),
+				},
+				{
+					ParameterKey:   aws.String(LBWebServiceTargetContainerParamKey),
+					ParameterValue: aws.String("nginx"),
+				},
+				{
+					ParameterKey:   aws.String(LBWebServiceTargetPortParamKey),
+					ParameterValue: aws.String("8080"),
+				},
+				{
+					ParameterKey:   aws.String(WorkloadTaskCountParamKey),
+					ParameterValue: aws.String("1"),
+				},
+				{
+					ParameterKey:   aws.String(LBWebServiceStickinessParamKey),
+					ParameterValue: aws.String("false"),
+				},
+				{
+					ParameterKey:   aws.String(LBWebServiceDNSDelegatedParamKey),
+					ParameterValue: aws.String("true"),
+				},
+			}...),
+		},
 		"Stickiness enabled": {
 			httpsEnabled: false,
 			manifest:     testLBWebServiceManifestWithStickiness,
@@ -631,6 +1046,37 @@ func TestLoadBalancedWebService_Parameters(t *testing.T) {
 				},
 			}...),
 		},
+		"custom container name": {
+			httpsEnabled: false,
+			manifest:     testLBWebServiceManifestWithContainerName,
+
+			expectedParams: append(expectedParams, []*cloudformation.Parameter{
+				{
+					ParameterKey:   aws.String(LBWebServiceHTTPSParamKey),
+					ParameterValue: aws.String("false"),
+				},
+				{
+					ParameterKey:   aws.String(LBWebServiceTargetContainerParamKey),
+					ParameterValue: aws.String("web"),
+				},
+				{
+					ParameterKey:   aws.String(LBWebServiceTargetPortParamKey),
+					ParameterValue: aws.String("80"),
+				},
+				{
+					ParameterKey:   aws.String(WorkloadTaskCountParamKey),
+					ParameterValue: aws.String("1"),
+				},
+				{
+					ParameterKey:   aws.String(LBWebServiceStickinessParamKey),
+					ParameterValue: aws.String("false"),
+				},
+				{
+					ParameterKey:   aws.String(LBWebServiceDNSDelegatedParamKey),
+					ParameterValue: aws.String("false"),
+				},
+			}...),
+		},
 		"with bad count": {
 			httpsEnabled: true,
 			manifest:     testLBWebServiceManifestWithBadCount,
@@ -126,13 +126,16 @@ func (s *RequestDrivenWebService) Template() (string, error) {
 		return "", fmt.Errorf(`convert "publish" field for service %s: %w`, s.name, err)
 	}
 	content, err := s.parser.ParseRequestDrivenWebService(template.WorkloadOpts{
-		Variables:         s.manifest.Variables,
+		Variables:         convertVariables(s.manifest.Variables),
+		VariableImports:   s.manifest.Variables.Imports,
 		StartCommand:      s.manifest.StartCommand,
 		Tags:              s.manifest.Tags,
 		NestedStack:       addonsOutputs,
 		AddonsExtraParams: addonsParams,
 		EnableHealthCheck: !s.healthCheckConfig.IsEmpty(),
 
+		AppRunnerAutoScaling: convertAppRunnerAutoScaling(s.manifest.AutoScaling),
+
 		Alias:                s.manifest.Alias,
 		ScriptBucketName:     bucket,
 		EnvControllerLambda:  envControllerLambda,
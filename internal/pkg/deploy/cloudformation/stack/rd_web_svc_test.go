@@ -34,10 +34,10 @@ var testRDWebServiceManifest = &manifest.RequestDrivenWebService{
 			CPU:    aws.Int(256),
 			Memory: aws.Int(512),
 		},
-		Variables: map[string]string{
+		Variables: manifest.Variables{Values: map[string]string{
 			"LOG_LEVEL": "info",
 			"NODE_ENV":  "development",
-		},
+		}},
 		RequestDrivenWebServiceHttpConfig: manifest.RequestDrivenWebServiceHttpConfig{
 			HealthCheckConfiguration: manifest.HealthCheckArgsOrString{
 				HealthCheckPath: aws.String("/"),
@@ -256,7 +256,7 @@ func TestRequestDrivenWebService_Template(t *testing.T) {
 				addons := mockAddons{tplErr: &addon.ErrAddonsNotFound{}}
 				mockBucket, mockCustomDomainLambda := "mockbucket", "mockURL1"
 				mockParser.EXPECT().ParseRequestDrivenWebService(template.WorkloadOpts{
-					Variables:           c.manifest.Variables,
+					Variables:           c.manifest.Variables.Values,
 					Tags:                c.manifest.Tags,
 					EnableHealthCheck:   true,
 					Alias:               aws.String("convex.domain.com"),
@@ -279,7 +279,7 @@ func TestRequestDrivenWebService_Template(t *testing.T) {
 				mockParser := mocks.NewMockrequestDrivenWebSvcReadParser(ctrl)
 				addons := mockAddons{tplErr: &addon.ErrAddonsNotFound{}, paramsErr: &addon.ErrAddonsNotFound{}}
 				mockParser.EXPECT().ParseRequestDrivenWebService(template.WorkloadOpts{
-					Variables:                c.manifest.Variables,
+					Variables:                c.manifest.Variables.Values,
 					Tags:                     c.manifest.Tags,
 					ServiceDiscoveryEndpoint: mockSD,
 					EnableHealthCheck:        true,
@@ -315,7 +315,7 @@ Outputs:
     Value: hello`,
 				}
 				mockParser.EXPECT().ParseRequestDrivenWebService(template.WorkloadOpts{
-					Variables:                c.manifest.Variables,
+					Variables:                c.manifest.Variables.Values,
 					Tags:                     c.manifest.Tags,
 					ServiceDiscoveryEndpoint: mockSD,
 					NestedStack: &template.WorkloadNestedStackOpts{
@@ -335,7 +335,7 @@ Outputs:
 				mockParser := mocks.NewMockrequestDrivenWebSvcReadParser(ctrl)
 				addons := mockAddons{tplErr: &addon.ErrAddonsNotFound{}}
 				mockParser.EXPECT().ParseRequestDrivenWebService(template.WorkloadOpts{
-					Variables:                c.manifest.Variables,
+					Variables:                c.manifest.Variables.Values,
 					Tags:                     c.manifest.Tags,
 					ServiceDiscoveryEndpoint: mockSD,
 					EnableHealthCheck:        true,
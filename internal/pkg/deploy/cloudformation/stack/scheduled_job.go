@@ -156,9 +156,15 @@ func (j *ScheduledJob) Template() (string, error) {
 		return "", err
 	}
 
+	network, err := convertNetworkConfig(j.manifest.Network, j.rc.SubnetIDs)
+	if err != nil {
+		return "", fmt.Errorf(`convert "network" field for job %s: %w`, j.name, err)
+	}
 	content, err := j.parser.ParseScheduledJob(template.WorkloadOpts{
-		Variables:                j.manifest.Variables,
-		Secrets:                  j.manifest.Secrets,
+		Variables:                convertVariables(j.manifest.Variables),
+		VariableImports:          j.manifest.Variables.Imports,
+		Secrets:                  convertSecrets(j.manifest.Secrets),
+		AppConfigSecrets:         convertAppConfigSecrets(j.manifest.Secrets),
 		NestedStack:              addonsOutputs,
 		AddonsExtraParams:        addonsParams,
 		Sidecars:                 sidecars,
@@ -166,16 +172,23 @@ func (j *ScheduledJob) Template() (string, error) {
 		StateMachine:             stateMachine,
 		HealthCheck:              convertContainerHealthCheck(j.manifest.ImageConfig.HealthCheck),
 		LogConfig:                convertLogging(j.manifest.Logging),
+		LogGroupName:             aws.StringValue(j.manifest.Logging.LogGroup),
+		ImportLogGroup:           aws.BoolValue(j.manifest.Logging.ImportLogGroup),
+		ContainerName:            aws.StringValue(j.manifest.ContainerName),
+		EnvVarPrefix:             aws.StringValue(j.manifest.EnvVarPrefix),
 		DockerLabels:             j.manifest.ImageConfig.Image.DockerLabels,
 		Storage:                  convertStorageOpts(j.manifest.Name, j.manifest.Storage),
-		Network:                  convertNetworkConfig(j.manifest.Network),
+		Network:                  network,
 		EntryPoint:               entrypoint,
 		Command:                  command,
+		PseudoTerminal:           j.manifest.PseudoTerminal,
+		Interactive:              j.manifest.Interactive,
+		Ulimits:                  convertUlimits(j.manifest.Ulimits),
 		DependsOn:                convertDependsOn(j.manifest.ImageConfig.Image.DependsOn),
 		CredentialsParameter:     aws.StringValue(j.manifest.ImageConfig.Image.Credentials),
 		ServiceDiscoveryEndpoint: j.rc.ServiceDiscoveryEndpoint,
 		Publish:                  publishers,
-		Platform:                 convertPlatform(j.manifest.Platform),
+		Platform:                 convertPlatform(j.manifest.ContainerOSArch()),
 
 		EnvControllerLambda: envControllerLambda.String(),
 	})
@@ -4,8 +4,11 @@
 package stack
 
 import (
+	"errors"
 	"fmt"
 	"hash/crc32"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +29,22 @@ const (
 	disabled = "DISABLED"
 )
 
+// Condition of the main container's dependency on the init container.
+const dependsOnComplete = "COMPLETE"
+
+// Container options of the sidecars injected by "observability.tracing".
+const (
+	xrayDaemonImage = "public.ecr.aws/xray/aws-xray-daemon:3.3.7"
+	xrayDaemonPort  = "2000"
+
+	otelCollectorImage = "public.ecr.aws/aws-observability/aws-otel-collector:v0.27.0"
+	// otelCollectorDefaultConfig is the configuration bundled in the collector image that receives OTLP
+	// and X-Ray traffic and sends traces to X-Ray and metrics to CloudWatch.
+	otelCollectorDefaultConfig = "/etc/ecs/ecs-default-config.yaml"
+	// otelCollectorConfigContentEnvVar is read by the collector instead of a configuration file.
+	otelCollectorConfigContentEnvVar = "AOT_CONFIG_CONTENT"
+)
+
 // Default values for EFS options
 const (
 	defaultRootDirectory   = "/"
@@ -40,6 +59,12 @@ const (
 	capacityProviderFargate     = "FARGATE"
 )
 
+// Values of the FifoThroughputScope property of FIFO SNS topics.
+const (
+	fifoThroughputScopeTopic        = "Topic"
+	fifoThroughputScopeMessageGroup = "MessageGroup"
+)
+
 var (
 	taskDefOverrideRulePrefixes = []string{"Resources", "TaskDefinition", "Properties"}
 )
@@ -76,24 +101,75 @@ func convertSidecar(s map[string]*manifest.SidecarConfig) ([]*template.SidecarOp
 			Storage: template.SidecarStorageOpts{
 				MountPoints: mp,
 			},
-			DockerLabels: config.DockerLabels,
-			DependsOn:    convertDependsOn(config.DependsOn),
-			EntryPoint:   entrypoint,
-			HealthCheck:  convertContainerHealthCheck(config.HealthCheck),
-			Command:      command,
+			DockerLabels:   config.DockerLabels,
+			DependsOn:      convertDependsOn(config.DependsOn),
+			EntryPoint:     entrypoint,
+			HealthCheck:    convertContainerHealthCheck(config.HealthCheck),
+			Command:        command,
+			PseudoTerminal: config.PseudoTerminal,
+			Interactive:    config.Interactive,
+			Ulimits:        convertUlimits(config.Ulimits),
 		})
 	}
 	return sidecars, nil
 }
 
+// convertInitContainer converts the init container into a non-essential sidecar that exits before the main container starts.
+// The image built from a local Dockerfile takes precedence over the image location in the manifest.
+func convertInitContainer(c manifest.InitContainer, image *ECRImage) (*template.SidecarOpts, error) {
+	if c.IsEmpty() {
+		return nil, nil
+	}
+	location := c.Image.Location
+	if image != nil {
+		location = aws.String(image.GetLocation())
+	}
+	if location == nil {
+		return nil, fmt.Errorf("image of the %s container is not built", manifest.InitContainerName)
+	}
+	command, err := convertCommand(c.Command)
+	if err != nil {
+		return nil, err
+	}
+	return &template.SidecarOpts{
+		Name:         aws.String(manifest.InitContainerName),
+		Image:        location,
+		Essential:    aws.Bool(false),
+		CredsParam:   c.Image.Credentials,
+		Variables:    c.Variables,
+		DockerLabels: c.Image.DockerLabels,
+		DependsOn:    convertDependsOn(c.Image.DependsOn),
+		Command:      command,
+	}, nil
+}
+
+// convertMainContainerDependsOn converts the dependencies of the main container.
+// If the workload has an init container, the main container waits for it to complete unless the manifest says otherwise.
+func convertMainContainerDependsOn(d manifest.DependsOn, init manifest.InitContainer) map[string]string {
+	dependsOn := convertDependsOn(d)
+	if init.IsEmpty() {
+		return dependsOn
+	}
+	if dependsOn == nil {
+		dependsOn = make(map[string]string)
+	}
+	if _, ok := dependsOn[manifest.InitContainerName]; !ok {
+		dependsOn[manifest.InitContainerName] = dependsOnComplete
+	}
+	return dependsOn
+}
+
 func convertContainerHealthCheck(hc manifest.ContainerHealthCheck) *template.ContainerHealthCheck {
 	if hc.IsEmpty() {
 		return nil
 	}
-	// Make sure that unset fields in the healthcheck gets a default value.
-	hc.ApplyIfNotSet(manifest.NewDefaultContainerHealthCheck())
+	hc = hc.Defaulted()
+	command := hc.Command
+	if hc.GRPC != nil {
+		command = convertGRPCHealthCheck(hc.GRPC)
+	}
 	return &template.ContainerHealthCheck{
-		Command:     hc.Command,
+		Command:     command,
 		Interval:    aws.Int64(int64(hc.Interval.Seconds())),
 		Retries:     aws.Int64(int64(aws.IntValue(hc.Retries))),
 		StartPeriod: aws.Int64(int64(hc.StartPeriod.Seconds())),
@@ -101,6 +177,18 @@ func convertContainerHealthCheck(hc manifest.ContainerHealthCheck) *template.Con
 	}
 }
 
+// convertGRPCHealthCheck converts the gRPC health check into a shell command that invokes grpc_health_probe.
+// See https://github.com/grpc-ecosystem/grpc-health-probe.
+func convertGRPCHealthCheck(g *manifest.GRPCHealthCheck) []string {
+	probe := fmt.Sprintf("grpc_health_probe -addr=localhost:%d", aws.Uint16Value(g.Port))
+	if g.Service != nil {
+		// Quote the service name so that the shell passes it to the probe verbatim.
+		service := strings.ReplaceAll(aws.StringValue(g.Service), "'", `'\''`)
+		probe = fmt.Sprintf("%s -service='%s'", probe, service)
+	}
+	return []string{"CMD-SHELL", fmt.Sprintf("%s || exit 1", probe)}
+}
+
 // convertDependsOn converts image and sidecar depends on fields to have upper case statuses.
 func convertDependsOn(d manifest.DependsOn) map[string]string {
 	if d == nil {
@@ -129,11 +217,11 @@ func parsePortMapping(s *string) (port *string, protocol *string, err error) {
 	}
 }
 
-func convertAdvancedCount(a manifest.AdvancedCount) (*template.AdvancedCount, error) {
+func convertAdvancedCount(a manifest.AdvancedCount, calendars map[string]manifest.ScalingCalendar) (*template.AdvancedCount, error) {
 	if a.IsEmpty() {
 		return nil, nil
 	}
-	autoscaling, err := convertAutoscaling(a)
+	autoscaling, err := convertAutoscaling(a, calendars)
 	if err != nil {
 		return nil, err
 	}
@@ -150,6 +238,9 @@ func convertCapacityProviders(a manifest.AdvancedCount) []*template.CapacityProv
 	if a.IsEmpty() {
 		return nil
 	}
+	if !a.CapacityProviders.IsEmpty() {
+		return convertCapacityProviderSplit(a.CapacityProviders)
+	}
 	// return if autoscaling range specified without spot scaling
 	if !a.Range.IsEmpty() && a.Range.Value != nil {
 		return nil
@@ -185,9 +276,32 @@ func convertCapacityProviders(a manifest.AdvancedCount) []*template.CapacityProv
 	return cps
 }
 
+// convertCapacityProviderSplit returns the strategy of the on-demand and spot capacity providers set in the manifest.
+// A capacity provider without a weight doesn't receive tasks beyond its base.
+func convertCapacityProviderSplit(c manifest.CapacityProviders) []*template.CapacityProviderStrategy {
+	var cps []*template.CapacityProviderStrategy
+	for _, provider := range []struct {
+		name     string
+		strategy manifest.CapacityProviderStrategy
+	}{
+		{name: capacityProviderFargate, strategy: c.OnDemand},
+		{name: capacityProviderFargateSpot, strategy: c.Spot},
+	} {
+		if provider.strategy.IsEmpty() {
+			continue
+		}
+		cps = append(cps, &template.CapacityProviderStrategy{
+			Base:             provider.strategy.Base,
+			Weight:           aws.Int(aws.IntValue(provider.strategy.Weight)),
+			CapacityProvider: provider.name,
+		})
+	}
+	return cps
+}
+
 // convertAutoscaling converts the service's Auto Scaling configuration into a format parsable
 // by the templates pkg.
-func convertAutoscaling(a manifest.AdvancedCount) (*template.AutoscalingOpts, error) {
+func convertAutoscaling(a manifest.AdvancedCount, calendars map[string]manifest.ScalingCalendar) (*template.AutoscalingOpts, error) {
 	if a.IsEmpty() {
 		return nil, nil
 	}
@@ -225,9 +339,144 @@ func convertAutoscaling(a manifest.AdvancedCount) (*template.AutoscalingOpts, er
 			AcceptableBacklogPerTask: acceptableBacklog,
 		}
 	}
+	if !a.CustomMetric.IsEmpty() {
+		autoscalingOpts.CustomMetric = convertCustomMetric(a.CustomMetric)
+	}
+	if a.Cooldown.ScaleInCooldown != nil {
+		autoscalingOpts.ScaleInCooldown = aws.Int64(int64(a.Cooldown.ScaleInCooldown.Seconds()))
+	}
+	if a.Cooldown.ScaleOutCooldown != nil {
+		autoscalingOpts.ScaleOutCooldown = aws.Int64(int64(a.Cooldown.ScaleOutCooldown.Seconds()))
+	}
+	scheduled, err := convertScheduledActions(a.Scheduled, calendars)
+	if err != nil {
+		return nil, err
+	}
+	autoscalingOpts.ScheduledActions = scheduled
 	return &autoscalingOpts, nil
 }
 
+// convertScheduledActions returns the scheduled actions of "count.scheduled" in order, with each reference to a
+// scaling calendar replaced by the actions of the calendar.
+func convertScheduledActions(scheduled []manifest.ScheduledScaling, calendars map[string]manifest.ScalingCalendar) ([]template.AutoscalingScheduledActionOpts, error) {
+	var opts []template.AutoscalingScheduledActionOpts
+	for i, s := range scheduled {
+		if s.Calendar == nil {
+			action, err := convertScheduledAction(fmt.Sprintf("scheduled-%d", i+1), s.ScheduledAction)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, action)
+			continue
+		}
+		name := aws.StringValue(s.Calendar)
+		calendar, ok := calendars[name]
+		if !ok {
+			return nil, fmt.Errorf(`scaling calendar %q referenced by "count.scheduled" is not defined in the environment manifest`, name)
+		}
+		for j, a := range calendar {
+			// Include the position of the reference so that a calendar referenced twice yields unique action names.
+			action, err := convertScheduledAction(fmt.Sprintf("%s-%d-%d", name, i+1, j+1), a)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, action)
+		}
+	}
+	return opts, nil
+}
+
+func convertScheduledAction(name string, a manifest.ScheduledAction) (template.AutoscalingScheduledActionOpts, error) {
+	var r manifest.IntRangeBand
+	if a.Range != nil {
+		r = *a.Range
+	}
+	min, max, err := r.Parse()
+	if err != nil {
+		return template.AutoscalingScheduledActionOpts{}, err
+	}
+	return template.AutoscalingScheduledActionOpts{
+		Name:        name,
+		Schedule:    aws.StringValue(a.Schedule),
+		Timezone:    a.Timezone,
+		MinCapacity: min,
+		MaxCapacity: max,
+	}, nil
+}
+
+// convertCustomMetric converts the custom autoscaling metric into a format parsable by the templates pkg.
+// Dimensions are sorted by name so that the generated template is stable across deployments.
+func convertCustomMetric(m manifest.CustomMetric) *template.AutoscalingCustomMetricOpts {
+	dimensions := make([]template.AutoscalingMetricDimension, 0, len(m.Dimensions))
+	for name, value := range m.Dimensions {
+		dimensions = append(dimensions, template.AutoscalingMetricDimension{
+			Name:  name,
+			Value: value,
+		})
+	}
+	sort.Slice(dimensions, func(i, j int) bool {
+		return dimensions[i].Name < dimensions[j].Name
+	})
+	return &template.AutoscalingCustomMetricOpts{
+		Namespace:  aws.StringValue(m.Namespace),
+		MetricName: aws.StringValue(m.MetricName),
+		Dimensions: dimensions,
+		Statistic:  m.MetricStatistic(),
+		Target:     aws.Float64Value(m.Target),
+	}
+}
+
+// convertDeploymentConfiguration converts the bounds of the number of running tasks during a deployment
+// into a format parsable by the templates pkg.
+// The "recreate" strategy stops all the running tasks before starting new ones.
+func convertDeploymentConfiguration(d manifest.DeploymentConfig) template.DeploymentConfigurationOpts {
+	if d.IsRecreate() {
+		return template.DeploymentConfigurationOpts{
+			MinHealthyPercent: aws.Int(0),
+			MaxPercent:        aws.Int(100),
+		}
+	}
+	return template.DeploymentConfigurationOpts{
+		MinHealthyPercent: d.MinHealthyPercent,
+		MaxPercent:        d.MaxPercent,
+	}
+}
+
+// convertBlueGreen converts the blue/green deployment of a service into a format parsable by the templates pkg.
+// It returns nil if the service is deployed with an ECS rolling update.
+func convertBlueGreen(b manifest.BlueGreenConfigOrBool, serviceTaskDefinitionARN string) *template.BlueGreenOpts {
+	if !b.Enabled() {
+		return nil
+	}
+	wait := manifest.DefaultBlueGreenTerminationWait
+	if b.Config.TerminationWait != nil {
+		wait = *b.Config.TerminationWait
+	}
+	var hooks []string
+	for _, arn := range b.Config.Hooks.FunctionARNs() {
+		hooks = append(hooks, arn)
+	}
+	sort.Strings(hooks)
+	return &template.BlueGreenOpts{
+		TerminationWaitMinutes: int(wait.Minutes()),
+		TestListenerPort:       b.Config.TestTrafficRoute,
+		HookFunctionARNs:       hooks,
+		ServiceTaskDefinition:  serviceTaskDefinitionARN,
+	}
+}
+
+// convertRollbackAlarms converts the deployment's rollback alarms into a format parsable by the templates pkg.
+func convertRollbackAlarms(a manifest.AlarmArgsOrNames) *template.RollbackAlarmsOpts {
+	if a.IsEmpty() {
+		return nil
+	}
+	return &template.RollbackAlarmsOpts{
+		AlarmNames:        a.AlarmNames,
+		CPUUtilization:    a.AlarmArgs.CPUUtilization,
+		MemoryUtilization: a.AlarmArgs.MemoryUtilization,
+	}
+}
+
 // convertHTTPHealthCheck converts the ALB health check configuration into a format parsable by the templates pkg.
 func convertHTTPHealthCheck(hc *manifest.HealthCheckArgsOrString) template.HTTPHealthCheckOpts {
 	opts := template.HTTPHealthCheckOpts{
@@ -241,6 +490,9 @@ func convertHTTPHealthCheck(hc *manifest.HealthCheckArgsOrString) template.HTTPH
 	} else if hc.HealthCheckPath != nil {
 		opts.HealthCheckPath = *hc.HealthCheckPath
 	}
+	if hc.HealthCheckArgs.Port != nil {
+		opts.Port = strconv.FormatUint(uint64(aws.Uint16Value(hc.HealthCheckArgs.Port)), 10)
+	}
 	if hc.HealthCheckArgs.SuccessCodes != nil {
 		opts.SuccessCodes = *hc.HealthCheckArgs.SuccessCodes
 	}
@@ -257,10 +509,24 @@ func convertHTTPHealthCheck(hc *manifest.HealthCheckArgsOrString) template.HTTPH
 }
 
 func convertExecuteCommand(e *manifest.ExecuteCommand) *template.ExecuteCommandOpts {
-	if e.Config.IsEmpty() && !aws.BoolValue(e.Enable) {
+	if !e.Enabled() {
 		return nil
 	}
-	return &template.ExecuteCommandOpts{}
+	return &template.ExecuteCommandOpts{
+		Logging: convertExecLogging(e.Config.Logging),
+	}
+}
+
+func convertExecLogging(l *manifest.ExecLogging) *template.ExecuteCommandLoggingOpts {
+	if l.IsEmpty() {
+		return nil
+	}
+	return &template.ExecuteCommandLoggingOpts{
+		CloudWatchLogGroup:          aws.StringValue(l.CloudWatchLogGroup),
+		CloudWatchEncryptionEnabled: aws.BoolValue(l.CloudWatchEncryptionEnabled),
+		S3Bucket:                    aws.StringValue(l.S3Bucket),
+		S3KeyPrefix:                 aws.StringValue(l.S3KeyPrefix),
+	}
 }
 
 func convertLogging(lc manifest.Logging) *template.LogConfigOpts {
@@ -269,12 +535,13 @@ func convertLogging(lc manifest.Logging) *template.LogConfigOpts {
 	}
 	return &template.LogConfigOpts{
 		Image:          lc.LogImage(),
+		ConfigType:     lc.FirelensConfigType(),
 		ConfigFile:     lc.ConfigFile,
 		EnableMetadata: lc.GetEnableMetadata(),
 		Destination:    lc.Destination,
 		SecretOptions:  lc.SecretOptions,
 		Variables:      lc.Variables,
-		Secrets:        lc.Secrets,
+		Secrets:        convertSecrets(lc.Secrets),
 	}
 }
 
@@ -475,25 +742,97 @@ func convertEFSConfiguration(in manifest.EFSVolumeConfiguration) *template.EFSVo
 	}
 }
 
-func convertNetworkConfig(network manifest.NetworkConfig) template.NetworkOpts {
+// convertNetworkConfig returns the network configuration of the tasks.
+// taggedSubnetIDs are the IDs of the subnets selected by "network.vpc.subnets.from_tags".
+func convertNetworkConfig(network manifest.NetworkConfig, taggedSubnetIDs []string) (template.NetworkOpts, error) {
 	if network.IsEmpty() {
 		return template.NetworkOpts{
 			AssignPublicIP: template.EnablePublicIP,
 			SubnetsType:    template.PublicSubnetsPlacement,
-		}
+		}, nil
 	}
 	opts := template.NetworkOpts{
-		AssignPublicIP: template.EnablePublicIP,
-		SubnetsType:    template.PublicSubnetsPlacement,
-		SecurityGroups: network.VPC.SecurityGroups,
+		AssignPublicIP:           template.EnablePublicIP,
+		SubnetsType:              template.PublicSubnetsPlacement,
+		DenyDefaultSecurityGroup: aws.BoolValue(network.VPC.DenyDefaultSecurityGroup),
+	}
+	for _, sg := range network.VPC.SecurityGroups {
+		if sg.FromCFN != nil {
+			opts.SecurityGroupImports = append(opts.SecurityGroupImports, aws.StringValue(sg.FromCFN))
+			continue
+		}
+		opts.SecurityGroups = append(opts.SecurityGroups, aws.StringValue(sg.ID))
+	}
+	if subnets := network.VPC.Subnets; !subnets.IsEmpty() {
+		// Explicit subnets aren't known to be public, so tasks are launched without a public IP.
+		opts.AssignPublicIP = template.DisablePublicIP
+		opts.SubnetIDs = subnets.IDs
+		if !subnets.SubnetArgs.IsEmpty() {
+			if len(taggedSubnetIDs) == 0 {
+				return template.NetworkOpts{}, errors.New(`no subnet IDs resolved for "network.vpc.subnets.from_tags"`)
+			}
+			opts.SubnetIDs = taggedSubnetIDs
+		}
+		return opts, nil
 	}
 	if network.VPC.Placement == nil {
-		return opts
+		return opts, nil
 	}
 	if *network.VPC.Placement != manifest.PublicSubnetPlacement {
 		opts.AssignPublicIP = template.DisablePublicIP
 		opts.SubnetsType = template.PrivateSubnetsPlacement
 	}
+	return opts, nil
+}
+
+// convertServiceConnect returns the Service Connect configuration of a service.
+// The port of the main container is reachable under the alias, or the name of the workload if there is no alias,
+// and each additional port is reachable under its own name.
+func convertServiceConnect(connect manifest.ServiceConnect, wkldName, mainContainerName string, mainPort *uint16, sidecars map[string]*manifest.SidecarConfig) *template.ServiceConnectOpts {
+	if !connect.IsEnabled() {
+		return nil
+	}
+	opts := &template.ServiceConnectOpts{}
+	portNames := make(map[uint16]string)
+	for name, sidecar := range sidecars {
+		port, _, err := parsePortMapping(sidecar.Port)
+		if err != nil || port == nil {
+			continue
+		}
+		val, err := strconv.ParseUint(aws.StringValue(port), 10, 16)
+		if err != nil {
+			continue
+		}
+		portNames[uint16(val)] = name
+	}
+	if mainPort != nil {
+		port := aws.Uint16Value(mainPort)
+		portNames[port] = mainContainerName
+		opts.MainPortName = mainContainerName
+		dnsName := wkldName
+		if connect.Alias != nil {
+			dnsName = aws.StringValue(connect.Alias)
+		}
+		opts.Services = append(opts.Services, template.ServiceConnectServiceOpts{
+			PortName:      mainContainerName,
+			DiscoveryName: fmt.Sprintf("%s-%d", wkldName, port),
+			DNSName:       dnsName,
+			Port:          port,
+		})
+	}
+	for _, p := range connect.Ports {
+		port := aws.Uint16Value(p.Port)
+		portName, ok := portNames[port]
+		if !ok {
+			continue
+		}
+		opts.Services = append(opts.Services, template.ServiceConnectServiceOpts{
+			PortName:      portName,
+			DiscoveryName: fmt.Sprintf("%s-%s", wkldName, aws.StringValue(p.Name)),
+			DNSName:       aws.StringValue(p.Name),
+			Port:          port,
+		})
+	}
 	return opts
 }
 
@@ -511,6 +850,17 @@ func convertRDWSNetworkConfig(network manifest.RequestDrivenWebServiceNetworkCon
 	return opts
 }
 
+func convertAppRunnerAutoScaling(autoscaling manifest.AppRunnerAutoScalingConfig) *template.AppRunnerAutoScalingOpts {
+	if autoscaling.IsEmpty() {
+		return nil
+	}
+	return &template.AppRunnerAutoScalingOpts{
+		MinSize:        autoscaling.MinSize,
+		MaxSize:        autoscaling.MaxSize,
+		MaxConcurrency: autoscaling.MaxConcurrency,
+	}
+}
+
 func convertAlias(alias manifest.Alias) ([]string, error) {
 	out, err := alias.ToStringSlice()
 	if err != nil {
@@ -519,6 +869,174 @@ func convertAlias(alias manifest.Alias) ([]string, error) {
 	return out, nil
 }
 
+// convertAdditionalRules returns the listener rules that route requests to the service in addition to the rule of its path.
+// The aliases of the rules are only used if the load balancer has a certificate, like the aliases of the service.
+func convertAdditionalRules(rules []manifest.RoutingRule, httpsEnabled bool) ([]template.RoutingRuleOpts, error) {
+	var opts []template.RoutingRuleOpts
+	for i, rule := range rules {
+		var aliases []string
+		if httpsEnabled {
+			var err error
+			if aliases, err = rule.Alias.ToStringSlice(); err != nil {
+				return nil, fmt.Errorf(`convert "http.additional_rules[%d].alias" to string slice: %w`, i, err)
+			}
+		}
+		var allowedSourceIPs []string
+		for _, ipNet := range rule.AllowedSourceIps {
+			allowedSourceIPs = append(allowedSourceIPs, string(ipNet))
+		}
+		opts = append(opts, template.RoutingRuleOpts{
+			Path:             strings.Trim(aws.StringValue(rule.Path), "/"),
+			Aliases:          aliases,
+			AllowedSourceIps: allowedSourceIPs,
+		})
+	}
+	return opts, nil
+}
+
+// convertAliasRouting returns the routing options for the Route 53 records of the service's aliases.
+func convertAliasRouting(routing manifest.AliasRouting) *template.AliasRoutingOpts {
+	if routing.IsEmpty() {
+		return nil
+	}
+	opts := &template.AliasRoutingOpts{
+		HostedZoneID:  aws.StringValue(routing.HostedZone),
+		SetIdentifier: routing.SetIdentifier,
+		HealthCheckID: routing.HealthCheck,
+	}
+	switch aws.StringValue(routing.Policy) {
+	case manifest.AliasRoutingPolicyWeighted:
+		opts.Weight = routing.Weight
+	case manifest.AliasRoutingPolicyFailover:
+		opts.Failover = strings.ToUpper(aws.StringValue(routing.Failover))
+	}
+	return opts
+}
+
+// convertPropagateTags returns the stack-level tags to apply to the ECS service so that they're propagated to its tasks.
+func convertPropagateTags(source *string, stackTags map[string]string) (map[string]string, error) {
+	if aws.StringValue(source) != manifest.PropagateTagsStack {
+		return nil, nil
+	}
+	if len(stackTags) == 0 {
+		return nil, fmt.Errorf(`"propagate_tags" is set to "%s" but no stack-level tags are configured`, manifest.PropagateTagsStack)
+	}
+	return stackTags, nil
+}
+
+// convertHostnameVariable returns the options for the environment variable holding the public hostname of the service.
+// The first alias is preferred over the DNS name of the load balancer if the service has any.
+func convertHostnameVariable(name *string, aliases []string) *template.HostnameVariableOpts {
+	if name == nil {
+		return nil
+	}
+	opts := &template.HostnameVariableOpts{
+		Name: aws.StringValue(name),
+	}
+	if len(aliases) > 0 {
+		opts.Alias = aliases[0]
+	}
+	return opts
+}
+
+// convertObservability returns the tracing options of the service.
+// The trace header format is exposed to the application through the standard OTEL_PROPAGATORS variable.
+func convertObservability(o manifest.Observability) *template.ObservabilityOpts {
+	if o.IsEmpty() {
+		return nil
+	}
+	opts := &template.ObservabilityOpts{
+		Tracing: aws.StringValue(o.Tracing),
+	}
+	switch aws.StringValue(o.TracePropagation) {
+	case manifest.TracePropagationAWSXRay:
+		opts.TracePropagators = "xray"
+	case manifest.TracePropagationW3C:
+		opts.TracePropagators = "tracecontext"
+	}
+	return opts
+}
+
+// convertTracingSidecar returns the sidecar that receives the traces of the application and sends them to AWS,
+// or nil if tracing isn't enabled.
+func convertTracingSidecar(o manifest.Observability) *template.SidecarOpts {
+	switch {
+	case o.XRayTracingEnabled():
+		return &template.SidecarOpts{
+			Name:      aws.String(manifest.XRayContainerName),
+			Image:     aws.String(xrayDaemonImage),
+			Essential: aws.Bool(false),
+			Port:      aws.String(xrayDaemonPort),
+			Protocol:  aws.String("udp"),
+		}
+	case o.OTelTracingEnabled():
+		sidecar := &template.SidecarOpts{
+			Name:      aws.String(manifest.OTelCollectorContainerName),
+			Image:     aws.String(otelCollectorImage),
+			Essential: aws.Bool(false),
+		}
+		if o.CollectorConfigContent != nil {
+			sidecar.Variables = map[string]string{
+				otelCollectorConfigContentEnvVar: aws.StringValue(o.CollectorConfigContent),
+			}
+		} else {
+			sidecar.Command = []string{fmt.Sprintf("--config=%s", otelCollectorDefaultConfig)}
+		}
+		return sidecar
+	}
+	return nil
+}
+
+// convertSecrets returns the secrets that ECS injects from SSM or Secrets Manager.
+func convertSecrets(secrets map[string]manifest.Secret) map[string]string {
+	var m map[string]string
+	for name, secret := range secrets {
+		if secret.From == nil {
+			continue
+		}
+		if m == nil {
+			m = make(map[string]string)
+		}
+		m[name] = aws.StringValue(secret.From)
+	}
+	return m
+}
+
+// convertVariables returns the environment variables of the main container, where the parameter-backed variables
+// are resolved by CloudFormation with a dynamic reference to the SSM parameter.
+func convertVariables(vars manifest.Variables) map[string]string {
+	if len(vars.FromSSM) == 0 {
+		return vars.Values
+	}
+	m := make(map[string]string, len(vars.Values)+len(vars.FromSSM))
+	for key, val := range vars.Values {
+		m[key] = val
+	}
+	for key, name := range vars.FromSSM {
+		m[key] = fmt.Sprintf("{{resolve:ssm:%s}}", name)
+	}
+	return m
+}
+
+// convertAppConfigSecrets returns the secrets that are served by the AWS AppConfig agent sidecar.
+func convertAppConfigSecrets(secrets map[string]manifest.Secret) map[string]*template.AppConfigSecretOpts {
+	var m map[string]*template.AppConfigSecretOpts
+	for name, secret := range secrets {
+		if secret.AppConfig == nil {
+			continue
+		}
+		if m == nil {
+			m = make(map[string]*template.AppConfigSecretOpts)
+		}
+		m[name] = &template.AppConfigSecretOpts{
+			Application: aws.StringValue(secret.AppConfig.Application),
+			Environment: aws.StringValue(secret.AppConfig.Environment),
+			Profile:     aws.StringValue(secret.AppConfig.Profile),
+		}
+	}
+	return m
+}
+
 func convertEntryPoint(entrypoint manifest.EntryPointOverride) ([]string, error) {
 	out, err := entrypoint.ToStringSlice()
 	if err != nil {
@@ -527,6 +1045,27 @@ func convertEntryPoint(entrypoint manifest.EntryPointOverride) ([]string, error)
 	return out, nil
 }
 
+// convertUlimits converts the manifest ulimits into a list of template ulimits sorted by name.
+func convertUlimits(in map[string]manifest.Ulimit) []*template.Ulimit {
+	if len(in) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(in))
+	for name := range in {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	ulimits := make([]*template.Ulimit, len(names))
+	for i, name := range names {
+		ulimits[i] = &template.Ulimit{
+			Name:      name,
+			SoftLimit: aws.IntValue(in[name].Soft),
+			HardLimit: aws.IntValue(in[name].Hard),
+		}
+	}
+	return ulimits
+}
+
 func convertCommand(command manifest.CommandOverride) ([]string, error) {
 	out, err := command.ToStringSlice()
 	if err != nil {
@@ -547,19 +1086,44 @@ func convertPublish(topics []manifest.Topic, accountID, region, app, env, svc st
 	// convert the topics to template Topics
 	for _, topic := range topics {
 		publishers.Topics = append(publishers.Topics, &template.Topic{
-			Name:      topic.Name,
-			AccountID: accountID,
-			Partition: partition.ID(),
-			Region:    region,
-			App:       app,
-			Env:       env,
-			Svc:       svc,
+			Name:            topic.Name,
+			FIFOTopicConfig: convertFIFOTopicConfig(topic.FIFO),
+			AccountID:       accountID,
+			Partition:       partition.ID(),
+			Region:          region,
+			App:             app,
+			Env:             env,
+			Svc:             svc,
 		})
 	}
 
 	return &publishers, nil
 }
 
+// convertFIFOTopicConfig returns nil for standard topics. The ".fifo" suffix of FIFO topic names is added by the template.
+func convertFIFOTopicConfig(fifo manifest.FIFOTopicAdvanceConfigOrBool) *template.FIFOTopicConfig {
+	if !fifo.IsEnabled() {
+		return nil
+	}
+	return &template.FIFOTopicConfig{
+		ContentBasedDeduplication: fifo.Advanced.ContentBasedDeduplication,
+		ThroughputScope:           convertFIFOThroughputScope(fifo.Advanced),
+	}
+}
+
+// convertFIFOThroughputScope returns the scope that SNS applies to both the deduplication and the throughput of a FIFO topic.
+func convertFIFOThroughputScope(fifo manifest.FIFOTopicAdvanceConfig) *string {
+	switch {
+	case aws.StringValue(fifo.DeduplicationScope) == manifest.FIFODeduplicationScopeMessageGroup,
+		aws.StringValue(fifo.ThroughputLimit) == manifest.FIFOThroughputLimitPerMessageGroupID:
+		return aws.String(fifoThroughputScopeMessageGroup)
+	case fifo.DeduplicationScope != nil, fifo.ThroughputLimit != nil:
+		return aws.String(fifoThroughputScopeTopic)
+	default:
+		return nil
+	}
+}
+
 func convertSubscribe(s manifest.SubscribeConfig, accountID, region, app, env, svc string) (*template.SubscribeOpts, error) {
 	if s.Topics == nil {
 		return nil, nil
@@ -571,6 +1135,7 @@ func convertSubscribe(s manifest.SubscribeConfig, accountID, region, app, env, s
 	var subscriptions template.SubscribeOpts
 	for _, sb := range s.Topics {
 		ts := convertTopicSubscription(sb, sqsEndpoint.URL, accountID, app, env, svc)
+		ts.FIFO = s.DeliversToFIFOQueue(sb)
 		subscriptions.Topics = append(subscriptions.Topics, ts)
 	}
 	subscriptions.Queue = convertQueue(s.Queue)
@@ -578,18 +1143,21 @@ func convertSubscribe(s manifest.SubscribeConfig, accountID, region, app, env, s
 }
 
 func convertTopicSubscription(t manifest.TopicSubscription, url, accountID, app, env, svc string) *template.TopicSubscription {
-	if aws.BoolValue(t.Queue.Enabled) {
-		return &template.TopicSubscription{
-			Name:    t.Name,
-			Service: t.Service,
-			Queue:   &template.SQSQueue{},
-		}
-	}
-	return &template.TopicSubscription{
+	sub := &template.TopicSubscription{
 		Name:    t.Name,
 		Service: t.Service,
 		Queue:   convertQueue(t.Queue.Advanced),
 	}
+	if t.ImportedARN != nil {
+		// Imported topics aren't published by a service, so their resources are named after the topic alone.
+		sub.Name = aws.String(t.TopicName())
+		sub.Service = aws.String("")
+		sub.ARN = t.ImportedARN
+	}
+	if aws.BoolValue(t.Queue.Enabled) {
+		sub.Queue = &template.SQSQueue{}
+	}
+	return sub
 }
 
 func convertQueue(q manifest.SQSQueue) *template.SQSQueue {
@@ -601,6 +1169,7 @@ func convertQueue(q manifest.SQSQueue) *template.SQSQueue {
 		Delay:      convertDelay(q.Delay),
 		Timeout:    convertTimeout(q.Timeout),
 		DeadLetter: convertDeadLetter(q.DeadLetter),
+		FIFO:       aws.BoolValue(q.FIFO),
 	}
 }
 
@@ -660,26 +1229,30 @@ func convertAppInformation(app deploy.AppInformation) (delegationRole *string, d
 	return
 }
 
-func convertPlatform(platform manifest.PlatformArgsOrString) template.RuntimePlatformOpts {
-	if platform.IsEmpty() {
+func convertPlatform(osFamily, arch string) template.RuntimePlatformOpts {
+	if osFamily == "" {
 		return template.RuntimePlatformOpts{}
 	}
 
 	os := template.OSLinux
-	switch platform.OS() {
+	switch osFamily {
 	case manifest.OSWindows, manifest.OSWindowsServer2019Core:
 		os = template.OSWindowsServerCore
 	case manifest.OSWindowsServer2019Full:
 		os = template.OSWindowsServerFull
+	case manifest.OSWindowsServer2022Core:
+		os = template.OSWindowsServer2022Core
+	case manifest.OSWindowsServer2022Full:
+		os = template.OSWindowsServer2022Full
 	}
 
-	arch := template.ArchX86
-	if manifest.IsArmArch(platform.Arch()) {
-		arch = template.ArchARM64
+	runtimeArch := template.ArchX86
+	if manifest.IsArmArch(arch) {
+		runtimeArch = template.ArchARM64
 	}
 	return template.RuntimePlatformOpts{
 		OS:   os,
-		Arch: arch,
+		Arch: runtimeArch,
 	}
 }
 
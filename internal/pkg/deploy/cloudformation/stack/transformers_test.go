@@ -4,6 +4,7 @@
 package stack
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -150,6 +151,40 @@ func Test_convertSidecar(t *testing.T) {
 				Command:    nil,
 			},
 		},
+		"specify entrypoint and command with shell quoting": {
+			inImageOverride: manifest.ImageOverride{
+				EntryPoint: manifest.EntryPointOverride{String: aws.String(`/bin/sh -c`)},
+				Command:    manifest.CommandOverride{String: aws.String(`"echo 'hello world'"`)},
+			},
+
+			wanted: &template.SidecarOpts{
+				Name:       aws.String("foo"),
+				CredsParam: mockCredsParam,
+				Image:      mockImage,
+				Secrets:    mockMap,
+				Variables:  mockMap,
+				Essential:  aws.Bool(false),
+				EntryPoint: []string{"/bin/sh", "-c"},
+				Command:    []string{"echo 'hello world'"},
+			},
+		},
+		"specify tty and stdin_open": {
+			inImageOverride: manifest.ImageOverride{
+				PseudoTerminal: aws.Bool(true),
+				Interactive:    aws.Bool(true),
+			},
+
+			wanted: &template.SidecarOpts{
+				Name:           aws.String("foo"),
+				CredsParam:     mockCredsParam,
+				Image:          mockImage,
+				Secrets:        mockMap,
+				Variables:      mockMap,
+				Essential:      aws.Bool(false),
+				PseudoTerminal: aws.Bool(true),
+				Interactive:    aws.Bool(true),
+			},
+		},
 		"specify command as a string": {
 			inImageOverride: manifest.ImageOverride{
 				Command: manifest.CommandOverride{String: aws.String("arg")},
@@ -182,6 +217,27 @@ func Test_convertSidecar(t *testing.T) {
 				Command:    []string{"arg1", "arg2"},
 			},
 		},
+		"specify ulimits": {
+			inImageOverride: manifest.ImageOverride{
+				Ulimits: map[string]manifest.Ulimit{
+					"nproc":  {Soft: aws.Int(1024), Hard: aws.Int(4096)},
+					"nofile": {Soft: aws.Int(65536), Hard: aws.Int(65536)},
+				},
+			},
+
+			wanted: &template.SidecarOpts{
+				Name:       aws.String("foo"),
+				CredsParam: mockCredsParam,
+				Image:      mockImage,
+				Secrets:    mockMap,
+				Variables:  mockMap,
+				Essential:  aws.Bool(false),
+				Ulimits: []*template.Ulimit{
+					{Name: "nofile", SoftLimit: 65536, HardLimit: 65536},
+					{Name: "nproc", SoftLimit: 1024, HardLimit: 4096},
+				},
+			},
+		},
 		"with health check": {
 			inHealthCheck: manifest.ContainerHealthCheck{
 				Command: []string{"foo", "bar"},
@@ -232,6 +288,198 @@ func Test_convertSidecar(t *testing.T) {
 	}
 }
 
+func Test_convertInitContainer(t *testing.T) {
+	testCases := map[string]struct {
+		in      manifest.InitContainer
+		inImage *ECRImage
+
+		wanted    *template.SidecarOpts
+		wantedErr error
+	}{
+		"should return nil if there is no init container": {},
+		"should use the built image of the init container": {
+			in: manifest.InitContainer{
+				Image: manifest.Image{
+					Build: manifest.BuildArgsOrString{
+						BuildArgs: manifest.DockerBuildArgs{
+							Target: aws.String("migrate"),
+						},
+					},
+					DependsOn: manifest.DependsOn{
+						"proxy": "healthy",
+					},
+				},
+				Command:   manifest.CommandOverride{String: aws.String("./migrate up")},
+				Variables: map[string]string{"LOG_LEVEL": "info"},
+			},
+			inImage: &ECRImage{
+				RepoURL:  "123456789012.dkr.ecr.us-west-2.amazonaws.com/app/api",
+				ImageTag: "v1-init",
+			},
+			wanted: &template.SidecarOpts{
+				Name:      aws.String("init"),
+				Image:     aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/app/api:v1-init"),
+				Essential: aws.Bool(false),
+				Variables: map[string]string{"LOG_LEVEL": "info"},
+				DependsOn: map[string]string{"proxy": "HEALTHY"},
+				Command:   []string{"./migrate", "up"},
+			},
+		},
+		"should use the image location of the init container": {
+			in: manifest.InitContainer{
+				Image: manifest.Image{
+					Location: aws.String("migrate/migrate:v4"),
+				},
+			},
+			wanted: &template.SidecarOpts{
+				Name:      aws.String("init"),
+				Image:     aws.String("migrate/migrate:v4"),
+				Essential: aws.Bool(false),
+			},
+		},
+		"should return an error if the init container image is not built": {
+			in: manifest.InitContainer{
+				Image: manifest.Image{
+					Build: manifest.BuildArgsOrString{
+						BuildString: aws.String("Dockerfile.migrate"),
+					},
+				},
+			},
+			wantedErr: errors.New("image of the init container is not built"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := convertInitContainer(tc.in, tc.inImage)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
+func Test_convertMainContainerDependsOn(t *testing.T) {
+	initContainer := manifest.InitContainer{
+		Image: manifest.Image{
+			Location: aws.String("migrate/migrate:v4"),
+		},
+	}
+	testCases := map[string]struct {
+		in     manifest.DependsOn
+		inInit manifest.InitContainer
+
+		wanted map[string]string
+	}{
+		"should return nil without dependencies": {},
+		"should wait for the proxy sidecar to be healthy": {
+			in: manifest.DependsOn{"envoy": "healthy"},
+			wanted: map[string]string{
+				"envoy": "HEALTHY",
+			},
+		},
+		"should wait for the init container to complete": {
+			in:     manifest.DependsOn{"proxy": "start"},
+			inInit: initContainer,
+			wanted: map[string]string{
+				"proxy": "START",
+				"init":  "COMPLETE",
+			},
+		},
+		"should keep the dependency on the init container from the manifest": {
+			in:     manifest.DependsOn{"init": "success"},
+			inInit: initContainer,
+			wanted: map[string]string{
+				"init": "SUCCESS",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertMainContainerDependsOn(tc.in, tc.inInit))
+		})
+	}
+}
+
+func Test_convertContainerHealthCheck(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.ContainerHealthCheck
+		wanted *template.ContainerHealthCheck
+	}{
+		"empty health check": {
+			in:     manifest.ContainerHealthCheck{},
+			wanted: nil,
+		},
+		"command health check with defaults": {
+			in: manifest.ContainerHealthCheck{
+				Command: []string{"CMD", "pwd"},
+			},
+			wanted: &template.ContainerHealthCheck{
+				Command:     []string{"CMD", "pwd"},
+				Interval:    aws.Int64(10),
+				Retries:     aws.Int64(2),
+				StartPeriod: aws.Int64(0),
+				Timeout:     aws.Int64(5),
+			},
+		},
+		"gRPC health check with a service name": {
+			in: manifest.ContainerHealthCheck{
+				GRPC: &manifest.GRPCHealthCheck{
+					Port:    aws.Uint16(50051),
+					Service: aws.String("grpc.health.v1.Health"),
+				},
+				Retries: aws.Int(3),
+			},
+			wanted: &template.ContainerHealthCheck{
+				Command:     []string{"CMD-SHELL", "grpc_health_probe -addr=localhost:50051 -service='grpc.health.v1.Health' || exit 1"},
+				Interval:    aws.Int64(10),
+				Retries:     aws.Int64(3),
+				StartPeriod: aws.Int64(0),
+				Timeout:     aws.Int64(5),
+			},
+		},
+		"gRPC health check with a service name that contains a quote": {
+			in: manifest.ContainerHealthCheck{
+				GRPC: &manifest.GRPCHealthCheck{
+					Port:    aws.Uint16(50051),
+					Service: aws.String("it's; rm -rf /"),
+				},
+			},
+			wanted: &template.ContainerHealthCheck{
+				Command:     []string{"CMD-SHELL", `grpc_health_probe -addr=localhost:50051 -service='it'\''s; rm -rf /' || exit 1`},
+				Interval:    aws.Int64(10),
+				Retries:     aws.Int64(2),
+				StartPeriod: aws.Int64(0),
+				Timeout:     aws.Int64(5),
+			},
+		},
+		"gRPC health check without a service name": {
+			in: manifest.ContainerHealthCheck{
+				GRPC: &manifest.GRPCHealthCheck{
+					Port: aws.Uint16(8080),
+				},
+			},
+			wanted: &template.ContainerHealthCheck{
+				Command:     []string{"CMD-SHELL", "grpc_health_probe -addr=localhost:8080 || exit 1"},
+				Interval:    aws.Int64(10),
+				Retries:     aws.Int64(2),
+				StartPeriod: aws.Int64(0),
+				Timeout:     aws.Int64(5),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := convertContainerHealthCheck(tc.in)
+
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
 func Test_convertAdvancedCount(t *testing.T) {
 	mockRange := manifest.IntRangeBand("1-10")
 	mockPerc := manifest.Percentage(70)
@@ -302,7 +550,7 @@ func Test_convertAdvancedCount(t *testing.T) {
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			actual, err := convertAdvancedCount(tc.input)
+			actual, err := convertAdvancedCount(tc.input, nil)
 
 			if tc.expectedErr != nil {
 				require.EqualError(t, err, tc.expectedErr.Error())
@@ -383,6 +631,61 @@ func Test_convertCapacityProviders(t *testing.T) {
 			},
 			expected: nil,
 		},
+		"with an on-demand and spot split": {
+			input: manifest.AdvancedCount{
+				Range: manifest.Range{
+					Value: &mockRange,
+				},
+				CapacityProviders: manifest.CapacityProviders{
+					OnDemand: manifest.CapacityProviderStrategy{
+						Base:   aws.Int(2),
+						Weight: aws.Int(1),
+					},
+					Spot: manifest.CapacityProviderStrategy{
+						Weight: aws.Int(3),
+					},
+				},
+			},
+
+			expected: []*template.CapacityProviderStrategy{
+				{
+					Base:             aws.Int(2),
+					Weight:           aws.Int(1),
+					CapacityProvider: capacityProviderFargate,
+				},
+				{
+					Weight:           aws.Int(3),
+					CapacityProvider: capacityProviderFargateSpot,
+				},
+			},
+		},
+		"with a base on demand and the rest on spot": {
+			input: manifest.AdvancedCount{
+				Range: manifest.Range{
+					Value: &mockRange,
+				},
+				CapacityProviders: manifest.CapacityProviders{
+					OnDemand: manifest.CapacityProviderStrategy{
+						Base: aws.Int(1),
+					},
+					Spot: manifest.CapacityProviderStrategy{
+						Weight: aws.Int(1),
+					},
+				},
+			},
+
+			expected: []*template.CapacityProviderStrategy{
+				{
+					Base:             aws.Int(1),
+					Weight:           aws.Int(0),
+					CapacityProvider: capacityProviderFargate,
+				},
+				{
+					Weight:           aws.Int(1),
+					CapacityProvider: capacityProviderFargateSpot,
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -405,6 +708,8 @@ func Test_convertAutoscaling(t *testing.T) {
 
 	testAcceptableLatency := 10 * time.Minute
 	testAvgProcessingTime := 250 * time.Millisecond
+	mockScaleInCooldown := 5 * time.Minute
+	mockScaleOutCooldown := 30 * time.Second
 	testCases := map[string]struct {
 		input manifest.AdvancedCount
 
@@ -486,6 +791,57 @@ func Test_convertAutoscaling(t *testing.T) {
 				},
 			},
 		},
+		"success with a custom metric with dimensions sorted by name": {
+			input: manifest.AdvancedCount{
+				Range: manifest.Range{
+					Value: &mockRange,
+				},
+				CustomMetric: manifest.CustomMetric{
+					Namespace:  aws.String("MyApp"),
+					MetricName: aws.String("PendingJobs"),
+					Dimensions: map[string]string{
+						"Service": "api",
+						"Env":     "test",
+						"Region":  "us-west-2",
+					},
+					Target: aws.Float64(50),
+				},
+			},
+			wanted: &template.AutoscalingOpts{
+				MaxCapacity: aws.Int(100),
+				MinCapacity: aws.Int(1),
+				CustomMetric: &template.AutoscalingCustomMetricOpts{
+					Namespace:  "MyApp",
+					MetricName: "PendingJobs",
+					Dimensions: []template.AutoscalingMetricDimension{
+						{Name: "Env", Value: "test"},
+						{Name: "Region", Value: "us-west-2"},
+						{Name: "Service", Value: "api"},
+					},
+					Statistic: "Average",
+					Target:    50,
+				},
+			},
+		},
+		"success with cooldowns": {
+			input: manifest.AdvancedCount{
+				Range: manifest.Range{
+					Value: &mockRange,
+				},
+				CPU: &mockCPU,
+				Cooldown: manifest.Cooldown{
+					ScaleInCooldown:  &mockScaleInCooldown,
+					ScaleOutCooldown: &mockScaleOutCooldown,
+				},
+			},
+			wanted: &template.AutoscalingOpts{
+				MaxCapacity:      aws.Int(100),
+				MinCapacity:      aws.Int(1),
+				CPU:              aws.Float64(70),
+				ScaleInCooldown:  aws.Int64(300),
+				ScaleOutCooldown: aws.Int64(30),
+			},
+		},
 		"returns nil if spot specified": {
 			input: manifest.AdvancedCount{
 				Spot: aws.Int(5),
@@ -495,7 +851,7 @@ func Test_convertAutoscaling(t *testing.T) {
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			got, err := convertAutoscaling(tc.input)
+			got, err := convertAutoscaling(tc.input, nil)
 
 			if tc.wantedErr != nil {
 				require.EqualError(t, err, tc.wantedErr.Error())
@@ -543,6 +899,7 @@ func Test_convertHTTPHealthCheck(t *testing.T) {
 	duration60Seconds := 60 * time.Second
 	testCases := map[string]struct {
 		inputPath               *string
+		inputPort               *uint16
 		inputSuccessCodes       *string
 		inputHealthyThreshold   *int64
 		inputUnhealthyThreshold *int64
@@ -641,8 +998,18 @@ func Test_convertHTTPHealthCheck(t *testing.T) {
 				GracePeriod:     aws.Int64(60),
 			},
 		},
+		"just Port": {
+			inputPort: aws.Uint16(8081),
+
+			wantedOpts: template.HTTPHealthCheckOpts{
+				HealthCheckPath: "/",
+				Port:            "8081",
+				GracePeriod:     aws.Int64(60),
+			},
+		},
 		"all values changed in manifest": {
 			inputPath:               aws.String("/road/to/nowhere"),
+			inputPort:               aws.Uint16(8081),
 			inputSuccessCodes:       aws.String("200-299"),
 			inputHealthyThreshold:   aws.Int64(3),
 			inputUnhealthyThreshold: aws.Int64(3),
@@ -652,6 +1019,7 @@ func Test_convertHTTPHealthCheck(t *testing.T) {
 
 			wantedOpts: template.HTTPHealthCheckOpts{
 				HealthCheckPath:    "/road/to/nowhere",
+				Port:               "8081",
 				SuccessCodes:       "200-299",
 				HealthyThreshold:   aws.Int64(3),
 				UnhealthyThreshold: aws.Int64(3),
@@ -668,6 +1036,7 @@ func Test_convertHTTPHealthCheck(t *testing.T) {
 				HealthCheckPath: tc.inputPath,
 				HealthCheckArgs: manifest.HTTPHealthCheckArgs{
 					Path:               tc.inputPath,
+					Port:               tc.inputPort,
 					SuccessCodes:       tc.inputSuccessCodes,
 					HealthyThreshold:   tc.inputHealthyThreshold,
 					UnhealthyThreshold: tc.inputUnhealthyThreshold,
@@ -770,6 +1139,7 @@ func Test_convertManagedFSInfo(t *testing.T) {
 		})
 	}
 }
+
 func Test_convertStorageOpts(t *testing.T) {
 	testCases := map[string]struct {
 		inVolumes   map[string]*manifest.Volume
@@ -960,6 +1330,36 @@ func Test_convertStorageOpts(t *testing.T) {
 				},
 			},
 		},
+		"managed EFS with the managed shorthand": {
+			inVolumes: map[string]*manifest.Volume{
+				"efs": {
+					EFS: manifest.EFSConfigOrBool{
+						Advanced: manifest.EFSVolumeConfiguration{
+							Managed: aws.Bool(true),
+						},
+					},
+					MountPointOpts: manifest.MountPointOpts{
+						ContainerPath: aws.String("/var/www"),
+						ReadOnly:      aws.Bool(true),
+					},
+				},
+			},
+			wantOpts: template.StorageOpts{
+				ManagedVolumeInfo: &template.ManagedVolumeCreationInfo{
+					Name:    aws.String("efs"),
+					DirName: aws.String("fe"),
+					UID:     aws.Uint32(1336298249),
+					GID:     aws.Uint32(1336298249),
+				},
+				MountPoints: []*template.MountPoint{
+					{
+						ContainerPath: aws.String("/var/www"),
+						ReadOnly:      aws.Bool(true),
+						SourceVolume:  aws.String("efs"),
+					},
+				},
+			},
+		},
 		"managed EFS with config": {
 			inVolumes: map[string]*manifest.Volume{
 				"efs": {
@@ -1151,6 +1551,37 @@ func Test_convertExecuteCommand(t *testing.T) {
 			},
 			wanted: &template.ExecuteCommandOpts{},
 		},
+		"exec disabled with config": {
+			inConfig: manifest.ExecuteCommand{
+				Config: manifest.ExecuteCommandConfig{
+					Enable: aws.Bool(false),
+					Logging: &manifest.ExecLogging{
+						CloudWatchLogGroup: aws.String("exec-audit"),
+					},
+				},
+			},
+			wanted: nil,
+		},
+		"exec enabled with logging": {
+			inConfig: manifest.ExecuteCommand{
+				Config: manifest.ExecuteCommandConfig{
+					Logging: &manifest.ExecLogging{
+						CloudWatchLogGroup:          aws.String("exec-audit"),
+						CloudWatchEncryptionEnabled: aws.Bool(true),
+						S3Bucket:                    aws.String("audit-bucket"),
+						S3KeyPrefix:                 aws.String("exec/"),
+					},
+				},
+			},
+			wanted: &template.ExecuteCommandOpts{
+				Logging: &template.ExecuteCommandLoggingOpts{
+					CloudWatchLogGroup:          "exec-audit",
+					CloudWatchEncryptionEnabled: true,
+					S3Bucket:                    "audit-bucket",
+					S3KeyPrefix:                 "exec/",
+				},
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -1162,6 +1593,51 @@ func Test_convertExecuteCommand(t *testing.T) {
 	}
 }
 
+func Test_convertLogging(t *testing.T) {
+	testCases := map[string]struct {
+		in manifest.Logging
+
+		wanted *template.LogConfigOpts
+	}{
+		"without logging": {
+			in:     manifest.Logging{},
+			wanted: nil,
+		},
+		"with variables and secrets for the log router": {
+			in: manifest.Logging{
+				Destination: map[string]string{
+					"Name": "datadog",
+				},
+				Variables: map[string]string{
+					"DD_SITE": "datadoghq.com",
+				},
+				Secrets: map[string]manifest.Secret{
+					"DD_API_KEY": {From: aws.String("/copilot/datadog/api-key")},
+				},
+			},
+			wanted: &template.LogConfigOpts{
+				Image:          aws.String("amazon/aws-for-fluent-bit:latest"),
+				ConfigType:     "fluentbit",
+				EnableMetadata: aws.String("true"),
+				Destination: map[string]string{
+					"Name": "datadog",
+				},
+				Variables: map[string]string{
+					"DD_SITE": "datadoghq.com",
+				},
+				Secrets: map[string]string{
+					"DD_API_KEY": "/copilot/datadog/api-key",
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertLogging(tc.in))
+		})
+	}
+}
+
 func Test_convertSidecarMountPoints(t *testing.T) {
 	testCases := map[string]struct {
 		inMountPoints  []manifest.SidecarMountPoint
@@ -1293,17 +1769,116 @@ func Test_convertPublish(t *testing.T) {
 				},
 			},
 		},
-	}
-	for name, tc := range testCases {
-		t.Run(name, func(t *testing.T) {
-			got, err := convertPublish(tc.inTopics, accountId, region, app, env, svc)
-			if tc.wantedError != nil {
-				require.EqualError(t, err, tc.wantedError.Error())
-			} else {
-				require.Equal(t, got, tc.wanted)
-			}
-		})
-	}
+		"valid FIFO publish": {
+			inTopics: []manifest.Topic{
+				{
+					Name: aws.String("orders"),
+					FIFO: manifest.FIFOTopicAdvanceConfigOrBool{
+						Enable: aws.Bool(true),
+					},
+				},
+				{
+					Name: aws.String("payments"),
+					FIFO: manifest.FIFOTopicAdvanceConfigOrBool{
+						Advanced: manifest.FIFOTopicAdvanceConfig{
+							ContentBasedDeduplication: aws.Bool(true),
+						},
+					},
+				},
+				{
+					Name: aws.String("events"),
+					FIFO: manifest.FIFOTopicAdvanceConfigOrBool{
+						Enable: aws.Bool(false),
+					},
+				},
+				{
+					Name: aws.String("refunds"),
+					FIFO: manifest.FIFOTopicAdvanceConfigOrBool{
+						Advanced: manifest.FIFOTopicAdvanceConfig{
+							ThroughputLimit: aws.String("perMessageGroupId"),
+						},
+					},
+				},
+				{
+					Name: aws.String("invoices"),
+					FIFO: manifest.FIFOTopicAdvanceConfigOrBool{
+						Advanced: manifest.FIFOTopicAdvanceConfig{
+							DeduplicationScope: aws.String("topic"),
+						},
+					},
+				},
+			},
+			wanted: &template.PublishOpts{
+				Topics: []*template.Topic{
+					{
+						Name:            aws.String("orders"),
+						FIFOTopicConfig: &template.FIFOTopicConfig{},
+						AccountID:       accountId,
+						Partition:       partition,
+						Region:          region,
+						App:             app,
+						Env:             env,
+						Svc:             svc,
+					},
+					{
+						Name: aws.String("payments"),
+						FIFOTopicConfig: &template.FIFOTopicConfig{
+							ContentBasedDeduplication: aws.Bool(true),
+						},
+						AccountID: accountId,
+						Partition: partition,
+						Region:    region,
+						App:       app,
+						Env:       env,
+						Svc:       svc,
+					},
+					{
+						Name:      aws.String("events"),
+						AccountID: accountId,
+						Partition: partition,
+						Region:    region,
+						App:       app,
+						Env:       env,
+						Svc:       svc,
+					},
+					{
+						Name: aws.String("refunds"),
+						FIFOTopicConfig: &template.FIFOTopicConfig{
+							ThroughputScope: aws.String("MessageGroup"),
+						},
+						AccountID: accountId,
+						Partition: partition,
+						Region:    region,
+						App:       app,
+						Env:       env,
+						Svc:       svc,
+					},
+					{
+						Name: aws.String("invoices"),
+						FIFOTopicConfig: &template.FIFOTopicConfig{
+							ThroughputScope: aws.String("Topic"),
+						},
+						AccountID: accountId,
+						Partition: partition,
+						Region:    region,
+						App:       app,
+						Env:       env,
+						Svc:       svc,
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := convertPublish(tc.inTopics, accountId, region, app, env, svc)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.Equal(t, got, tc.wanted)
+			}
+		})
+	}
 }
 
 func Test_convertSubscribe(t *testing.T) {
@@ -1380,6 +1955,82 @@ func Test_convertSubscribe(t *testing.T) {
 				Queue: nil,
 			},
 		},
+		"valid subscribe to FIFO topics": {
+			inSubscribe: manifest.SubscribeConfig{
+				Topics: []manifest.TopicSubscription{
+					{
+						Name:    aws.String("orders"),
+						Service: aws.String("svc"),
+					},
+					{
+						Name:    aws.String("payments"),
+						Service: aws.String("svc"),
+						Queue: manifest.SQSQueueOrBool{
+							Advanced: manifest.SQSQueue{
+								FIFO: aws.Bool(true),
+							},
+						},
+					},
+					{
+						Name:    aws.String("events"),
+						Service: aws.String("svc"),
+						Queue: manifest.SQSQueueOrBool{
+							Enabled: aws.Bool(true),
+						},
+					},
+				},
+				Queue: manifest.SQSQueue{
+					FIFO: aws.Bool(true),
+				},
+			},
+			wanted: &template.SubscribeOpts{
+				Topics: []*template.TopicSubscription{
+					{
+						Name:    aws.String("orders"),
+						Service: aws.String("svc"),
+						FIFO:    true,
+					},
+					{
+						Name:    aws.String("payments"),
+						Service: aws.String("svc"),
+						Queue: &template.SQSQueue{
+							FIFO: true,
+						},
+						FIFO: true,
+					},
+					{
+						Name:    aws.String("events"),
+						Service: aws.String("svc"),
+						Queue:   &template.SQSQueue{},
+					},
+				},
+				Queue: &template.SQSQueue{
+					FIFO: true,
+				},
+			},
+		},
+		"valid subscribe with imported topic": {
+			inSubscribe: manifest.SubscribeConfig{
+				Topics: []manifest.TopicSubscription{
+					{
+						ImportedARN: aws.String("arn:aws:sns:us-west-2:123456789012:events"),
+						Queue: manifest.SQSQueueOrBool{
+							Enabled: aws.Bool(true),
+						},
+					},
+				},
+			},
+			wanted: &template.SubscribeOpts{
+				Topics: []*template.TopicSubscription{
+					{
+						Name:    aws.String("events"),
+						Service: aws.String(""),
+						ARN:     aws.String("arn:aws:sns:us-west-2:123456789012:events"),
+						Queue:   &template.SQSQueue{},
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -1408,6 +2059,18 @@ func Test_convertPlatform(t *testing.T) {
 				Arch: template.ArchX86,
 			},
 		},
+		"should return windows server 2022 core and x86_64 when advanced config specifies 2022 core": {
+			in: manifest.PlatformArgsOrString{
+				PlatformArgs: manifest.PlatformArgs{
+					OSFamily: aws.String(manifest.OSWindowsServer2022Core),
+					Arch:     aws.String(manifest.ArchX86),
+				},
+			},
+			out: template.RuntimePlatformOpts{
+				OS:   template.OSWindowsServer2022Core,
+				Arch: template.ArchX86,
+			},
+		},
 		"should return windows server core and x86_64 when platform is 'windows/x86_64'": {
 			in: manifest.PlatformArgsOrString{
 				PlatformString: (*manifest.PlatformString)(aws.String("windows/amd64")),
@@ -1439,7 +2102,64 @@ func Test_convertPlatform(t *testing.T) {
 
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			require.Equal(t, tc.out, convertPlatform(tc.in))
+			require.Equal(t, tc.out, convertPlatform(manifest.TaskConfig{Platform: tc.in}.ContainerOSArch()))
+		})
+	}
+}
+
+func Test_convertPlatform_environmentOverrides(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest string
+
+		wanted map[string]template.RuntimePlatformOpts
+	}{
+		"amd64 overridden to arm64 with a string in prod": {
+			inManifest: `name: api
+type: Backend Service
+image:
+  location: nginx:1.21
+platform: linux/amd64
+environments:
+  prod:
+    platform: linux/arm64
+`,
+			wanted: map[string]template.RuntimePlatformOpts{
+				"test": {OS: template.OSLinux, Arch: template.ArchX86},
+				"prod": {OS: template.OSLinux, Arch: template.ArchARM64},
+			},
+		},
+		"amd64 overridden to arm64 with only the architecture in prod": {
+			inManifest: `name: api
+type: Backend Service
+image:
+  location: nginx:1.21
+platform: linux/amd64
+environments:
+  prod:
+    platform:
+      architecture: arm64
+`,
+			wanted: map[string]template.RuntimePlatformOpts{
+				"test": {OS: template.OSLinux, Arch: template.ArchX86},
+				"prod": {OS: template.OSLinux, Arch: template.ArchARM64},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mft, err := manifest.UnmarshalWorkload([]byte(tc.inManifest))
+			require.NoError(t, err)
+
+			for env, wanted := range tc.wanted {
+				envMft, err := mft.ApplyEnv(env)
+				require.NoError(t, err)
+				require.NoError(t, envMft.Validate())
+
+				svc, ok := envMft.(*manifest.BackendService)
+				require.True(t, ok)
+				require.Equal(t, wanted, convertPlatform(svc.ContainerOSArch()), "unexpected runtime platform in %s", env)
+			}
 		})
 	}
 }
@@ -1461,3 +2181,736 @@ func Test_convertHTTPVersion(t *testing.T) {
 		})
 	}
 }
+
+func Test_convertScheduledActions(t *testing.T) {
+	businessHours := manifest.IntRangeBand("2-10")
+	offHours := manifest.IntRangeBand("1-2")
+	holiday := manifest.IntRangeBand("0-0")
+	calendars := map[string]manifest.ScalingCalendar{
+		"business-hours": {
+			{Schedule: aws.String("cron(0 8 ? * MON-FRI *)"), Timezone: aws.String("Europe/Paris"), Range: &businessHours},
+			{Schedule: aws.String("cron(0 18 ? * MON-FRI *)"), Timezone: aws.String("Europe/Paris"), Range: &offHours},
+		},
+	}
+	testCases := map[string]struct {
+		in        []manifest.ScheduledScaling
+		calendars map[string]manifest.ScalingCalendar
+
+		wanted    []template.AutoscalingScheduledActionOpts
+		wantedErr error
+	}{
+		"should return nil without scheduled actions": {},
+		"should expand a calendar and keep the inline actions in order": {
+			in: []manifest.ScheduledScaling{
+				{Calendar: aws.String("business-hours")},
+				{ScheduledAction: manifest.ScheduledAction{Schedule: aws.String("at(2026-12-25T00:00:00)"), Range: &holiday}},
+			},
+			calendars: calendars,
+			wanted: []template.AutoscalingScheduledActionOpts{
+				{Name: "business-hours-1-1", Schedule: "cron(0 8 ? * MON-FRI *)", Timezone: aws.String("Europe/Paris"), MinCapacity: 2, MaxCapacity: 10},
+				{Name: "business-hours-1-2", Schedule: "cron(0 18 ? * MON-FRI *)", Timezone: aws.String("Europe/Paris"), MinCapacity: 1, MaxCapacity: 2},
+				{Name: "scheduled-2", Schedule: "at(2026-12-25T00:00:00)", MinCapacity: 0, MaxCapacity: 0},
+			},
+		},
+		"should name the actions of a calendar referenced twice uniquely": {
+			in: []manifest.ScheduledScaling{
+				{Calendar: aws.String("business-hours")},
+				{Calendar: aws.String("business-hours")},
+			},
+			calendars: calendars,
+			wanted: []template.AutoscalingScheduledActionOpts{
+				{Name: "business-hours-1-1", Schedule: "cron(0 8 ? * MON-FRI *)", Timezone: aws.String("Europe/Paris"), MinCapacity: 2, MaxCapacity: 10},
+				{Name: "business-hours-1-2", Schedule: "cron(0 18 ? * MON-FRI *)", Timezone: aws.String("Europe/Paris"), MinCapacity: 1, MaxCapacity: 2},
+				{Name: "business-hours-2-1", Schedule: "cron(0 8 ? * MON-FRI *)", Timezone: aws.String("Europe/Paris"), MinCapacity: 2, MaxCapacity: 10},
+				{Name: "business-hours-2-2", Schedule: "cron(0 18 ? * MON-FRI *)", Timezone: aws.String("Europe/Paris"), MinCapacity: 1, MaxCapacity: 2},
+			},
+		},
+		"should return an error if the calendar is not defined": {
+			in: []manifest.ScheduledScaling{
+				{Calendar: aws.String("holidays")},
+			},
+			calendars: calendars,
+			wantedErr: errors.New(`scaling calendar "holidays" referenced by "count.scheduled" is not defined in the environment manifest`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := convertScheduledActions(tc.in, tc.calendars)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func Test_convertHostnameVariable(t *testing.T) {
+	testCases := map[string]struct {
+		inName    *string
+		inAliases []string
+		wanted    *template.HostnameVariableOpts
+	}{
+		"should return nil if there is no user input": {
+			inAliases: []string{"example.com"},
+		},
+		"should fall back to the load balancer DNS name without aliases": {
+			inName: aws.String("PUBLIC_HOST"),
+			wanted: &template.HostnameVariableOpts{
+				Name: "PUBLIC_HOST",
+			},
+		},
+		"should use the first alias": {
+			inName:    aws.String("PUBLIC_HOST"),
+			inAliases: []string{"example.com", "v1.example.com"},
+			wanted: &template.HostnameVariableOpts{
+				Name:  "PUBLIC_HOST",
+				Alias: "example.com",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertHostnameVariable(tc.inName, tc.inAliases))
+		})
+	}
+}
+
+func Test_convertObservability(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.Observability
+		wanted *template.ObservabilityOpts
+	}{
+		"should return nil if there is no user input": {},
+		"should propagate X-Ray trace headers": {
+			in: manifest.Observability{
+				TracePropagation: aws.String(manifest.TracePropagationAWSXRay),
+			},
+			wanted: &template.ObservabilityOpts{
+				TracePropagators: "xray",
+			},
+		},
+		"should propagate W3C trace context headers": {
+			in: manifest.Observability{
+				TracePropagation: aws.String(manifest.TracePropagationW3C),
+			},
+			wanted: &template.ObservabilityOpts{
+				TracePropagators: "tracecontext",
+			},
+		},
+		"should trace with X-Ray without setting the propagators": {
+			in: manifest.Observability{
+				Tracing: aws.String(manifest.TracingAWSXRay),
+			},
+			wanted: &template.ObservabilityOpts{
+				Tracing: "awsxray",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertObservability(tc.in))
+		})
+	}
+}
+
+func Test_convertTracingSidecar(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.Observability
+		wanted *template.SidecarOpts
+	}{
+		"should return nil if tracing is not enabled": {
+			in: manifest.Observability{
+				TracePropagation: aws.String(manifest.TracePropagationAWSXRay),
+			},
+		},
+		"should return the X-Ray daemon sidecar": {
+			in: manifest.Observability{
+				Tracing: aws.String(manifest.TracingAWSXRay),
+			},
+			wanted: &template.SidecarOpts{
+				Name:      aws.String("xray"),
+				Image:     aws.String("public.ecr.aws/xray/aws-xray-daemon:3.3.7"),
+				Essential: aws.Bool(false),
+				Port:      aws.String("2000"),
+				Protocol:  aws.String("udp"),
+			},
+		},
+		"should return the OpenTelemetry collector sidecar with the default config": {
+			in: manifest.Observability{
+				Tracing: aws.String(manifest.TracingAWSOTel),
+			},
+			wanted: &template.SidecarOpts{
+				Name:      aws.String("aws-otel-collector"),
+				Image:     aws.String("public.ecr.aws/aws-observability/aws-otel-collector:v0.27.0"),
+				Essential: aws.Bool(false),
+				Command:   []string{"--config=/etc/ecs/ecs-default-config.yaml"},
+			},
+		},
+		"should pass a custom config to the OpenTelemetry collector": {
+			in: manifest.Observability{
+				Tracing:                aws.String(manifest.TracingAWSOTel),
+				CollectorConfig:        aws.String("otel/config.yaml"),
+				CollectorConfigContent: aws.String("receivers:\n  otlp:\n"),
+			},
+			wanted: &template.SidecarOpts{
+				Name:      aws.String("aws-otel-collector"),
+				Image:     aws.String("public.ecr.aws/aws-observability/aws-otel-collector:v0.27.0"),
+				Essential: aws.Bool(false),
+				Variables: map[string]string{
+					"AOT_CONFIG_CONTENT": "receivers:\n  otlp:\n",
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertTracingSidecar(tc.in))
+		})
+	}
+}
+
+func Test_convertRollbackAlarms(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.AlarmArgsOrNames
+		wanted *template.RollbackAlarmsOpts
+	}{
+		"should return nil if there is no user input": {},
+		"should roll back on existing alarms": {
+			in: manifest.AlarmArgsOrNames{
+				AlarmNames: []string{"latency", "errors"},
+			},
+			wanted: &template.RollbackAlarmsOpts{
+				AlarmNames: []string{"latency", "errors"},
+			},
+		},
+		"should create alarms on utilization thresholds": {
+			in: manifest.AlarmArgsOrNames{
+				AlarmArgs: manifest.AlarmArgs{
+					CPUUtilization: aws.Float64(70),
+				},
+			},
+			wanted: &template.RollbackAlarmsOpts{
+				CPUUtilization: aws.Float64(70),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertRollbackAlarms(tc.in))
+		})
+	}
+}
+
+func Test_convertSecrets(t *testing.T) {
+	testCases := map[string]struct {
+		in map[string]manifest.Secret
+
+		wantedSecrets          map[string]string
+		wantedAppConfigSecrets map[string]*template.AppConfigSecretOpts
+	}{
+		"should return nil if there is no user input": {},
+		"should split SSM or Secrets Manager secrets from AppConfig secrets": {
+			in: map[string]manifest.Secret{
+				"GITHUB_TOKEN": {From: aws.String("GH_TOKEN_SECRET")},
+				"FLAGS": {
+					AppConfig: &manifest.AppConfigSecret{
+						Application: aws.String("phonetool"),
+						Environment: aws.String("test"),
+						Profile:     aws.String("flags"),
+					},
+				},
+			},
+			wantedSecrets: map[string]string{
+				"GITHUB_TOKEN": "GH_TOKEN_SECRET",
+			},
+			wantedAppConfigSecrets: map[string]*template.AppConfigSecretOpts{
+				"FLAGS": {
+					Application: "phonetool",
+					Environment: "test",
+					Profile:     "flags",
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wantedSecrets, convertSecrets(tc.in))
+			require.Equal(t, tc.wantedAppConfigSecrets, convertAppConfigSecrets(tc.in))
+		})
+	}
+}
+
+func Test_convertVariables(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.Variables
+		wanted map[string]string
+	}{
+		"should return nil if there is no user input": {},
+		"should return the inline values as is": {
+			in: manifest.Variables{
+				Values: map[string]string{
+					"DEBUG": "true",
+				},
+			},
+			wanted: map[string]string{
+				"DEBUG": "true",
+			},
+		},
+		"should resolve parameter-backed variables with a dynamic reference": {
+			in: manifest.Variables{
+				Values: map[string]string{
+					"DEBUG": "true",
+				},
+				FromSSM: map[string]string{
+					"DB_HOST": "/phonetool/test/db_host",
+				},
+				Imports: map[string]string{
+					"QUEUE_URL": "phonetool-test-QueueURL",
+				},
+			},
+			wanted: map[string]string{
+				"DEBUG":   "true",
+				"DB_HOST": "{{resolve:ssm:/phonetool/test/db_host}}",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertVariables(tc.in))
+		})
+	}
+}
+
+func Test_convertAliasRouting(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.AliasRouting
+		wanted *template.AliasRoutingOpts
+	}{
+		"should return nil if there is no user input": {},
+		"weighted routing": {
+			in: manifest.AliasRouting{
+				HostedZone:    aws.String("Z0123456789"),
+				Policy:        aws.String(manifest.AliasRoutingPolicyWeighted),
+				SetIdentifier: aws.String("us-west-2"),
+				Weight:        aws.Int(0),
+			},
+			wanted: &template.AliasRoutingOpts{
+				HostedZoneID:  "Z0123456789",
+				SetIdentifier: aws.String("us-west-2"),
+				Weight:        aws.Int(0),
+			},
+		},
+		"failover routing with a health check": {
+			in: manifest.AliasRouting{
+				HostedZone:  aws.String("Z0123456789"),
+				Policy:      aws.String(manifest.AliasRoutingPolicyFailover),
+				Failover:    aws.String(manifest.AliasFailoverSecondary),
+				HealthCheck: aws.String("abcdef11-2222-3333-4444-555555fedcba"),
+			},
+			wanted: &template.AliasRoutingOpts{
+				HostedZoneID:  "Z0123456789",
+				Failover:      "SECONDARY",
+				HealthCheckID: aws.String("abcdef11-2222-3333-4444-555555fedcba"),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertAliasRouting(tc.in))
+		})
+	}
+}
+
+func Test_convertNetworkConfig(t *testing.T) {
+	privatePlacement := manifest.PrivateSubnetPlacement
+	testCases := map[string]struct {
+		setUpManifest     func(n *manifest.NetworkConfig)
+		inTaggedSubnetIDs []string
+
+		wanted    template.NetworkOpts
+		wantedErr error
+	}{
+		"should place tasks in public subnets by default": {
+			setUpManifest: func(n *manifest.NetworkConfig) {},
+			wanted: template.NetworkOpts{
+				AssignPublicIP: template.EnablePublicIP,
+				SubnetsType:    template.PublicSubnetsPlacement,
+			},
+		},
+		"should place tasks in private subnets": {
+			setUpManifest: func(n *manifest.NetworkConfig) {
+				n.VPC.Placement = &privatePlacement
+				n.VPC.SecurityGroups = []manifest.SecurityGroup{{ID: aws.String("sg-1234")}}
+			},
+			wanted: template.NetworkOpts{
+				AssignPublicIP: template.DisablePublicIP,
+				SubnetsType:    template.PrivateSubnetsPlacement,
+				SecurityGroups: []string{"sg-1234"},
+			},
+		},
+		"should import the security groups exported by other stacks": {
+			setUpManifest: func(n *manifest.NetworkConfig) {
+				n.VPC.Placement = &privatePlacement
+				n.VPC.SecurityGroups = []manifest.SecurityGroup{
+					{FromCFN: aws.String("shared-db-SecurityGroupID")},
+					{ID: aws.String("sg-1234")},
+				}
+			},
+			wanted: template.NetworkOpts{
+				AssignPublicIP:       template.DisablePublicIP,
+				SubnetsType:          template.PrivateSubnetsPlacement,
+				SecurityGroups:       []string{"sg-1234"},
+				SecurityGroupImports: []string{"shared-db-SecurityGroupID"},
+			},
+		},
+		"should omit the security group of the environment if it's denied": {
+			setUpManifest: func(n *manifest.NetworkConfig) {
+				n.VPC.SecurityGroups = []manifest.SecurityGroup{{ID: aws.String("sg-1234")}}
+				n.VPC.DenyDefaultSecurityGroup = aws.Bool(true)
+			},
+			wanted: template.NetworkOpts{
+				AssignPublicIP:           template.EnablePublicIP,
+				SubnetsType:              template.PublicSubnetsPlacement,
+				SecurityGroups:           []string{"sg-1234"},
+				DenyDefaultSecurityGroup: true,
+			},
+		},
+		"should place tasks in explicit subnets": {
+			setUpManifest: func(n *manifest.NetworkConfig) {
+				n.VPC.Subnets.IDs = []string{"subnet-0123abcd"}
+			},
+			wanted: template.NetworkOpts{
+				AssignPublicIP: template.DisablePublicIP,
+				SubnetsType:    template.PublicSubnetsPlacement,
+				SubnetIDs:      []string{"subnet-0123abcd"},
+			},
+		},
+		"should place tasks in the subnets selected by tags": {
+			setUpManifest: func(n *manifest.NetworkConfig) {
+				n.VPC.Subnets.FromTags = map[string]string{"tier": "private"}
+			},
+			inTaggedSubnetIDs: []string{"subnet-0123abcd", "subnet-4567cdef"},
+			wanted: template.NetworkOpts{
+				AssignPublicIP: template.DisablePublicIP,
+				SubnetsType:    template.PublicSubnetsPlacement,
+				SubnetIDs:      []string{"subnet-0123abcd", "subnet-4567cdef"},
+			},
+		},
+		"should return an error if the subnets selected by tags aren't resolved": {
+			setUpManifest: func(n *manifest.NetworkConfig) {
+				n.VPC.Subnets.FromTags = map[string]string{"tier": "private"}
+			},
+			wantedErr: errors.New(`no subnet IDs resolved for "network.vpc.subnets.from_tags"`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var in manifest.NetworkConfig
+			tc.setUpManifest(&in)
+
+			got, err := convertNetworkConfig(in, tc.inTaggedSubnetIDs)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
+func Test_convertServiceConnect(t *testing.T) {
+	testCases := map[string]struct {
+		inConnect  manifest.ServiceConnect
+		inMainPort *uint16
+		inSidecars map[string]*manifest.SidecarConfig
+		wanted     *template.ServiceConnectOpts
+	}{
+		"should return nil if service connect is disabled": {
+			inConnect: manifest.ServiceConnect{
+				Enabled: aws.Bool(false),
+			},
+			inMainPort: aws.Uint16(8080),
+		},
+		"should name the main port after the workload by default": {
+			inConnect: manifest.ServiceConnect{
+				Enabled: aws.Bool(true),
+			},
+			inMainPort: aws.Uint16(8080),
+			wanted: &template.ServiceConnectOpts{
+				MainPortName: "frontend",
+				Services: []template.ServiceConnectServiceOpts{
+					{
+						PortName:      "frontend",
+						DiscoveryName: "frontend-8080",
+						DNSName:       "frontend",
+						Port:          8080,
+					},
+				},
+			},
+		},
+		"should alias the main port and add the ports of sidecars": {
+			inConnect: manifest.ServiceConnect{
+				Alias: aws.String("web"),
+				Ports: []manifest.ServiceConnectPort{
+					{
+						Name: aws.String("admin"),
+						Port: aws.Uint16(9090),
+					},
+				},
+			},
+			inMainPort: aws.Uint16(8080),
+			inSidecars: map[string]*manifest.SidecarConfig{
+				"envoy": {
+					Port: aws.String("9090/tcp"),
+				},
+			},
+			wanted: &template.ServiceConnectOpts{
+				MainPortName: "frontend",
+				Services: []template.ServiceConnectServiceOpts{
+					{
+						PortName:      "frontend",
+						DiscoveryName: "frontend-8080",
+						DNSName:       "web",
+						Port:          8080,
+					},
+					{
+						PortName:      "envoy",
+						DiscoveryName: "frontend-admin",
+						DNSName:       "admin",
+						Port:          9090,
+					},
+				},
+			},
+		},
+		"should only configure the client side without a main port": {
+			inConnect: manifest.ServiceConnect{
+				Enabled: aws.Bool(true),
+			},
+			wanted: &template.ServiceConnectOpts{},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := convertServiceConnect(tc.inConnect, "frontend", "frontend", tc.inMainPort, tc.inSidecars)
+
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
+func Test_convertAdditionalRules(t *testing.T) {
+	rules := []manifest.RoutingRule{
+		{
+			Path: aws.String("/api/"),
+		},
+		{
+			Path:             aws.String("admin"),
+			Alias:            manifest.Alias{String: aws.String("admin.example.com")},
+			AllowedSourceIps: []manifest.IPNet{"10.1.0.0/24", "10.1.1.0/24"},
+		},
+	}
+	testCases := map[string]struct {
+		inRules        []manifest.RoutingRule
+		inHTTPSEnabled bool
+
+		wanted []template.RoutingRuleOpts
+	}{
+		"should return nil if there are no rules": {},
+		"should keep the aliases of the rules if the load balancer has a certificate": {
+			inRules:        rules,
+			inHTTPSEnabled: true,
+			wanted: []template.RoutingRuleOpts{
+				{
+					Path: "api",
+				},
+				{
+					Path:             "admin",
+					Aliases:          []string{"admin.example.com"},
+					AllowedSourceIps: []string{"10.1.0.0/24", "10.1.1.0/24"},
+				},
+			},
+		},
+		"should drop the aliases of the rules if the load balancer has no certificate": {
+			inRules: rules,
+			wanted: []template.RoutingRuleOpts{
+				{
+					Path: "api",
+				},
+				{
+					Path:             "admin",
+					AllowedSourceIps: []string{"10.1.0.0/24", "10.1.1.0/24"},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := convertAdditionalRules(tc.inRules, tc.inHTTPSEnabled)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
+func Test_convertPropagateTags(t *testing.T) {
+	testCases := map[string]struct {
+		inSource    *string
+		inStackTags map[string]string
+
+		wanted    map[string]string
+		wantedErr error
+	}{
+		"should return nil if there is no user input": {
+			inStackTags: map[string]string{"owner": "frontend"},
+		},
+		"should return the stack-level tags": {
+			inSource:    aws.String(manifest.PropagateTagsStack),
+			inStackTags: map[string]string{"owner": "frontend"},
+			wanted:      map[string]string{"owner": "frontend"},
+		},
+		"should return an error if there are no stack-level tags": {
+			inSource:  aws.String(manifest.PropagateTagsStack),
+			wantedErr: fmt.Errorf(`"propagate_tags" is set to "stack" but no stack-level tags are configured`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := convertPropagateTags(tc.inSource, tc.inStackTags)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
+func Test_convertDeploymentConfiguration(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.DeploymentConfig
+		wanted template.DeploymentConfigurationOpts
+	}{
+		"should leave the bounds unset by default": {},
+		"should keep the bounds of a rolling deployment": {
+			in: manifest.DeploymentConfig{
+				Strategy:          aws.String(manifest.DeploymentStrategyRolling),
+				MinHealthyPercent: aws.Int(50),
+				MaxPercent:        aws.Int(150),
+			},
+			wanted: template.DeploymentConfigurationOpts{
+				MinHealthyPercent: aws.Int(50),
+				MaxPercent:        aws.Int(150),
+			},
+		},
+		"should stop all the tasks before starting new ones for a recreate deployment": {
+			in: manifest.DeploymentConfig{
+				Strategy: aws.String(manifest.DeploymentStrategyRecreate),
+			},
+			wanted: template.DeploymentConfigurationOpts{
+				MinHealthyPercent: aws.Int(0),
+				MaxPercent:        aws.Int(100),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertDeploymentConfiguration(tc.in))
+		})
+	}
+}
+
+func Test_convertBlueGreen(t *testing.T) {
+	duration30Minutes := 30 * time.Minute
+	testCases := map[string]struct {
+		in                  manifest.BlueGreenConfigOrBool
+		inServiceTaskDefARN string
+		wanted              *template.BlueGreenOpts
+	}{
+		"should return nil for a rolling update": {},
+		"should return nil if blue/green is turned off": {
+			in: manifest.BlueGreenConfigOrBool{Enable: aws.Bool(false)},
+		},
+		"should use the default termination wait": {
+			in: manifest.BlueGreenConfigOrBool{Enable: aws.Bool(true)},
+			wanted: &template.BlueGreenOpts{
+				TerminationWaitMinutes: 5,
+			},
+		},
+		"should convert all the fields": {
+			in: manifest.BlueGreenConfigOrBool{
+				Enable: aws.Bool(true),
+				Config: manifest.BlueGreenConfig{
+					TerminationWait:  &duration30Minutes,
+					TestTrafficRoute: aws.Uint16(8080),
+					Hooks: manifest.BlueGreenHooks{
+						BeforeAllowTraffic:    aws.String("arn:aws:lambda:us-west-2:123456789012:function:smoke-test"),
+						AfterAllowTestTraffic: aws.String("arn:aws:lambda:us-west-2:123456789012:function:integ-test"),
+					},
+				},
+			},
+			wanted: &template.BlueGreenOpts{
+				TerminationWaitMinutes: 30,
+				TestListenerPort:       aws.Uint16(8080),
+				HookFunctionARNs: []string{
+					"arn:aws:lambda:us-west-2:123456789012:function:integ-test",
+					"arn:aws:lambda:us-west-2:123456789012:function:smoke-test",
+				},
+			},
+		},
+		"should keep the task definition of an existing service": {
+			in:                  manifest.BlueGreenConfigOrBool{Enable: aws.Bool(true)},
+			inServiceTaskDefARN: "arn:aws:ecs:us-west-2:123456789012:task-definition/phonetool-test-fe:1",
+			wanted: &template.BlueGreenOpts{
+				TerminationWaitMinutes: 5,
+				ServiceTaskDefinition:  "arn:aws:ecs:us-west-2:123456789012:task-definition/phonetool-test-fe:1",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertBlueGreen(tc.in, tc.inServiceTaskDefARN))
+		})
+	}
+}
+
+func Test_convertAppRunnerAutoScaling(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.AppRunnerAutoScalingConfig
+		wanted *template.AppRunnerAutoScalingOpts
+	}{
+		"should return nil to keep the App Runner defaults": {},
+		"should only set the configured fields": {
+			in: manifest.AppRunnerAutoScalingConfig{
+				MaxConcurrency: aws.Int(50),
+			},
+			wanted: &template.AppRunnerAutoScalingOpts{
+				MaxConcurrency: aws.Int(50),
+			},
+		},
+		"should convert all the fields": {
+			in: manifest.AppRunnerAutoScalingConfig{
+				MinSize:        aws.Int(2),
+				MaxSize:        aws.Int(10),
+				MaxConcurrency: aws.Int(80),
+			},
+			wanted: &template.AppRunnerAutoScalingOpts{
+				MinSize:        aws.Int(2),
+				MaxSize:        aws.Int(10),
+				MaxConcurrency: aws.Int(80),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, convertAppRunnerAutoScaling(tc.in))
+		})
+	}
+}
@@ -86,7 +86,14 @@ func (s *WorkerService) Template() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("convert the sidecar configuration for service %s: %w", s.name, err)
 	}
-	advancedCount, err := convertAdvancedCount(s.manifest.Count.AdvancedCount)
+	initContainer, err := convertInitContainer(s.manifest.InitContainer, s.rc.InitImage)
+	if err != nil {
+		return "", fmt.Errorf("convert the init container configuration for service %s: %w", s.name, err)
+	}
+	if initContainer != nil {
+		sidecars = append([]*template.SidecarOpts{initContainer}, sidecars...)
+	}
+	advancedCount, err := convertAdvancedCount(s.manifest.Count.AdvancedCount, s.rc.ScalingCalendars)
 	if err != nil {
 		return "", fmt.Errorf("convert the advanced count configuration for service %s: %w", s.name, err)
 	}
@@ -108,6 +115,10 @@ func (s *WorkerService) Template() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	tags, err := convertPropagateTags(s.manifest.PropagateTags, s.rc.AdditionalTags)
+	if err != nil {
+		return "", err
+	}
 	subscribe, err := convertSubscribe(s.manifest.Subscribe, s.rc.AccountID, s.rc.Region, s.app, s.env, s.name)
 	if err != nil {
 		return "", err
@@ -116,33 +127,51 @@ func (s *WorkerService) Template() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf(`convert "publish" field for service %s: %w`, s.name, err)
 	}
+	network, err := convertNetworkConfig(s.manifest.Network, s.rc.SubnetIDs)
+	if err != nil {
+		return "", fmt.Errorf(`convert "network" field for service %s: %w`, s.name, err)
+	}
 	content, err := s.parser.ParseWorkerService(template.WorkloadOpts{
-		Variables:                      s.manifest.WorkerServiceConfig.Variables,
-		Secrets:                        s.manifest.WorkerServiceConfig.Secrets,
+		Variables:                      convertVariables(s.manifest.WorkerServiceConfig.Variables),
+		VariableImports:                s.manifest.WorkerServiceConfig.Variables.Imports,
+		Secrets:                        convertSecrets(s.manifest.WorkerServiceConfig.Secrets),
+		AppConfigSecrets:               convertAppConfigSecrets(s.manifest.WorkerServiceConfig.Secrets),
 		NestedStack:                    addonsOutputs,
 		AddonsExtraParams:              addonsParams,
 		Sidecars:                       sidecars,
 		Autoscaling:                    autoscaling,
+		RollbackAlarms:                 convertRollbackAlarms(s.manifest.DeployConfig.RollbackAlarms),
+		DeploymentConfiguration:        convertDeploymentConfiguration(s.manifest.DeployConfig),
 		CapacityProviders:              capacityProviders,
 		DesiredCountOnSpot:             desiredCountOnSpot,
+		AZRebalancing:                  aws.BoolValue(s.manifest.AZRebalancing),
+		Tags:                           tags,
 		ExecuteCommand:                 convertExecuteCommand(&s.manifest.ExecuteCommand),
 		WorkloadType:                   manifest.WorkerServiceType,
 		HealthCheck:                    convertContainerHealthCheck(s.manifest.WorkerServiceConfig.ImageConfig.HealthCheck),
 		LogConfig:                      convertLogging(s.manifest.Logging),
+		LogGroupName:                   aws.StringValue(s.manifest.Logging.LogGroup),
+		ImportLogGroup:                 aws.BoolValue(s.manifest.Logging.ImportLogGroup),
+		ContainerName:                  aws.StringValue(s.manifest.ContainerName),
+		EnvVarPrefix:                   aws.StringValue(s.manifest.EnvVarPrefix),
 		DockerLabels:                   s.manifest.ImageConfig.Image.DockerLabels,
 		DesiredCountLambda:             desiredCountLambda.String(),
 		EnvControllerLambda:            envControllerLambda.String(),
 		BacklogPerTaskCalculatorLambda: backlogPerTaskLambda.String(),
 		Storage:                        convertStorageOpts(s.manifest.Name, s.manifest.Storage),
-		Network:                        convertNetworkConfig(s.manifest.Network),
+		Network:                        network,
 		EntryPoint:                     entrypoint,
 		Command:                        command,
-		DependsOn:                      convertDependsOn(s.manifest.ImageConfig.Image.DependsOn),
+		PseudoTerminal:                 s.manifest.PseudoTerminal,
+		Interactive:                    s.manifest.Interactive,
+		Ulimits:                        convertUlimits(s.manifest.Ulimits),
+		DependsOn:                      convertMainContainerDependsOn(s.manifest.ImageConfig.Image.DependsOn, s.manifest.InitContainer),
 		CredentialsParameter:           aws.StringValue(s.manifest.ImageConfig.Image.Credentials),
 		ServiceDiscoveryEndpoint:       s.rc.ServiceDiscoveryEndpoint,
+		ServiceConnect:                 convertServiceConnect(s.manifest.Network.Connect, s.name, s.manifest.MainContainerName(s.name), nil, s.manifest.Sidecars),
 		Subscribe:                      subscribe,
 		Publish:                        publishers,
-		Platform:                       convertPlatform(s.manifest.Platform),
+		Platform:                       convertPlatform(s.manifest.ContainerOSArch()),
 	})
 	if err != nil {
 		return "", fmt.Errorf("parse worker service template: %w", err)
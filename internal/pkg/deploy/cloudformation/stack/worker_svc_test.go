@@ -262,7 +262,7 @@ Outputs:
 			if tc.setUpManifest != nil {
 				tc.setUpManifest(conf)
 				conf.manifest.Network.VPC.Placement = &manifest.PrivateSubnetPlacement
-				conf.manifest.Network.VPC.SecurityGroups = []string{"sg-1234"}
+				conf.manifest.Network.VPC.SecurityGroups = []manifest.SecurityGroup{{ID: aws.String("sg-1234")}}
 			}
 
 			tc.mockDependencies(t, ctrl, conf)
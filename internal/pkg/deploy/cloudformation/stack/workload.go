@@ -62,11 +62,18 @@ const (
 // that is needed to create a CloudFormation stack.
 type RuntimeConfig struct {
 	Image             *ECRImage         // Optional. Image location in an ECR repository.
+	InitImage         *ECRImage         // Optional. Init container image location in an ECR repository.
 	AddonsTemplateURL string            // Optional. S3 object URL for the addons template.
 	AdditionalTags    map[string]string // AdditionalTags are labels applied to resources in the workload stack.
 
+	// ARN of the task definition that a service deployed with blue/green was created with.
+	// ECS only lets CodeDeploy change it, so the stack must keep it once the service exists.
+	ServiceTaskDefinitionARN string
+
 	// The target environment metadata.
-	ServiceDiscoveryEndpoint string // Endpoint for the service discovery namespace in the environment.
+	ServiceDiscoveryEndpoint string                              // Endpoint for the service discovery namespace in the environment.
+	SubnetIDs                []string                            // IDs of the subnets of the environment VPC selected by "network.vpc.subnets.from_tags".
+	ScalingCalendars         map[string]manifest.ScalingCalendar // Scaling calendars of the environment manifest referenced by "count.scheduled".
 	AccountID                string
 	Region                   string
 }
@@ -4,10 +4,13 @@
 package cloudformation
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	sdkcloudformation "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
 	"github.com/aws/copilot-cli/internal/pkg/deploy"
 	"github.com/aws/copilot-cli/internal/pkg/deploy/cloudformation/mocks"
 	"github.com/aws/copilot-cli/internal/pkg/term/progress"
@@ -96,6 +99,67 @@ func TestCloudFormation_DeployService(t *testing.T) {
 	})
 }
 
+func TestCloudFormation_DeployService_Timeout(t *testing.T) {
+	const stackName = "myapp-myenv-mysvc"
+	serviceConfig := &mockStackConfig{
+		name:     stackName,
+		template: "template",
+	}
+	testCases := map[string]struct {
+		inStatus          string
+		inDisableRollback bool
+		setUpMock         func(m *mocks.MockcfnClient)
+		wantedErrMsg      string
+	}{
+		"cancels the update of the stack": {
+			inStatus: sdkcloudformation.StackStatusUpdateInProgress,
+			setUpMock: func(m *mocks.MockcfnClient) {
+				m.EXPECT().CancelUpdate(stackName).Return(nil)
+			},
+			wantedErrMsg: "stack myapp-myenv-mysvc did not complete within 1ms: canceled the update, the stack is rolling back",
+		},
+		"does not cancel an update that disables rollbacks": {
+			inStatus:          sdkcloudformation.StackStatusUpdateInProgress,
+			inDisableRollback: true,
+			setUpMock:         func(m *mocks.MockcfnClient) {},
+			wantedErrMsg:      "stack myapp-myenv-mysvc did not complete within 1ms: the update keeps going because rollbacks are disabled",
+		},
+		"does not cancel a stack that isn't updating": {
+			inStatus:     sdkcloudformation.StackStatusCreateInProgress,
+			setUpMock:    func(m *mocks.MockcfnClient) {},
+			wantedErrMsg: "stack myapp-myenv-mysvc did not complete within 1ms and is in status CREATE_IN_PROGRESS",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := mocks.NewMockcfnClient(ctrl)
+			m.EXPECT().Create(gomock.Any()).Return("", &cloudformation.ErrStackAlreadyExists{})
+			m.EXPECT().Update(gomock.Any()).Return("1234", nil)
+			m.EXPECT().DescribeChangeSet("1234", stackName).Return(&cloudformation.ChangeSetDescription{}, nil)
+			m.EXPECT().TemplateBodyFromChangeSet("1234", stackName).Return("", nil)
+			m.EXPECT().DescribeStackEvents(gomock.Any()).Return(&sdkcloudformation.DescribeStackEventsOutput{}, nil).AnyTimes()
+			m.EXPECT().Describe(stackName).Return(&cloudformation.StackDescription{
+				StackStatus: aws.String(tc.inStatus),
+			}, nil)
+			tc.setUpMock(m)
+			client := CloudFormation{cfnClient: m}
+			opts := []cloudformation.StackOption{cloudformation.WithTimeout(time.Millisecond)}
+			if tc.inDisableRollback {
+				opts = append(opts, cloudformation.WithDisableRollback())
+			}
+
+			// WHEN
+			err := client.DeployService(mockFileWriter{Writer: new(strings.Builder)}, serviceConfig, opts...)
+
+			// THEN
+			require.EqualError(t, err, tc.wantedErrMsg)
+		})
+	}
+}
+
 func TestCloudFormation_DeleteWorkload(t *testing.T) {
 	testCases := map[string]struct {
 		in         deploy.DeleteWorkloadInput
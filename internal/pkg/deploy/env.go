@@ -13,7 +13,7 @@ const (
 	// LegacyEnvTemplateVersion is the version associated with the environment template before we started versioning.
 	LegacyEnvTemplateVersion = "v0.0.0"
 	// LatestEnvTemplateVersion is the latest version number available for environment templates.
-	LatestEnvTemplateVersion = "v1.7.0"
+	LatestEnvTemplateVersion = "v1.8.0"
 )
 
 // CreateEnvironmentInput holds the fields required to deploy an environment.
@@ -21,13 +21,14 @@ type CreateEnvironmentInput struct {
 	// The version of the environment template to create the stack. If empty, creates the legacy stack.
 	Version string
 
-	App                 AppInformation    // Information about the application that the environment belongs to, include app name, DNS name, the principal ARN of the account.
-	Name                string            // Name of the environment, must be unique within an application.
-	Prod                bool              // Whether or not this environment is a production environment.
-	AdditionalTags      map[string]string // AdditionalTags are labels applied to resources under the application.
-	CustomResourcesURLs map[string]string // Environment custom resource script S3 object URLs.
-	ImportVPCConfig     *config.ImportVPC // Optional configuration if users have an existing VPC.
-	AdjustVPCConfig     *config.AdjustVPC // Optional configuration if users want to override default VPC configuration.
+	App                 AppInformation     // Information about the application that the environment belongs to, include app name, DNS name, the principal ARN of the account.
+	Name                string             // Name of the environment, must be unique within an application.
+	Prod                bool               // Whether or not this environment is a production environment.
+	AdditionalTags      map[string]string  // AdditionalTags are labels applied to resources under the application.
+	CustomResourcesURLs map[string]string  // Environment custom resource script S3 object URLs.
+	ImportVPCConfig     *config.ImportVPC  // Optional configuration if users have an existing VPC.
+	AdjustVPCConfig     *config.AdjustVPC  // Optional configuration if users want to override default VPC configuration.
+	GitHubOIDCConfig    *config.GitHubOIDC // Optional configuration if users want a role for GitHub Actions to deploy with.
 
 	CFNServiceRoleARN string // Optional. A service role ARN that CloudFormation should use to make calls to resources in the stack.
 }
@@ -65,6 +65,10 @@ type BuildArguments struct {
 	CacheFrom  []string          // Optional. Images to consider as cache sources to pass to `docker build`
 	Platform   string            // Optional. OS/Arch to pass to `docker build`.
 	Args       map[string]string // Optional. Build args to pass via `--build-arg` flags. Equivalent to ARG directives in dockerfile.
+	SSH        []string          // Optional. SSH agent sockets or keys to expose to the build via `--ssh` flags. Requires BuildKit.
+	Secrets    []string          // Optional. Secrets to expose to the build via `--secret` flags, such as "id=npmrc,src=.npmrc". Requires BuildKit.
+	Network    string            // Optional. Networking mode of the RUN instructions to pass via `--network`.
+	Labels     map[string]string // Optional. Labels to apply to the image via `--label` flags.
 }
 
 type dockerConfig struct {
@@ -102,6 +106,11 @@ func (c CmdClient) Build(in *BuildArguments) error {
 		args = append(args, "--platform", in.Platform)
 	}
 
+	// Add network option.
+	if in.Network != "" {
+		args = append(args, "--network", in.Network)
+	}
+
 	// Add the "args:" override section from manifest to the docker build call.
 
 	// Collect the keys in a slice to sort for test stability.
@@ -114,12 +123,35 @@ func (c CmdClient) Build(in *BuildArguments) error {
 		args = append(args, "--build-arg", fmt.Sprintf("%s=%s", k, in.Args[k]))
 	}
 
+	// Add the labels of the image, sorted for test stability.
+	keys = nil
+	for k := range in.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--label", fmt.Sprintf("%s=%s", k, in.Labels[k]))
+	}
+
+	// Add the SSH agent sockets or keys and the secrets that RUN --mount instructions can use.
+	for _, ssh := range in.SSH {
+		args = append(args, "--ssh", ssh)
+	}
+	for _, secret := range in.Secrets {
+		args = append(args, "--secret", secret)
+	}
+
 	args = append(args, dfDir, "-f", in.Dockerfile)
 	// If host platform is not linux/amd64, show the user how the container image is being built; if the build fails (if their docker server doesn't have multi-platform-- and therefore `--platform` capability, for instance) they may see why.
 	if in.Platform != "" {
 		log.Infof("Building your container image: docker %s\n", strings.Join(args, " "))
 	}
-	if err := c.runner.Run("docker", args); err != nil {
+	var opts []exec.CmdOption
+	if len(in.SSH) > 0 || len(in.Secrets) > 0 {
+		// The --ssh and --secret flags are only available with BuildKit.
+		opts = append(opts, exec.Env("DOCKER_BUILDKIT=1"))
+	}
+	if err := c.runner.Run("docker", args, opts...); err != nil {
 		return fmt.Errorf("building image: %w", err)
 	}
 
@@ -163,6 +195,31 @@ func (c CmdClient) Push(uri string, tags ...string) (digest string, err error) {
 	return parts[1], nil
 }
 
+// PushManifestList creates a manifest list of the images, which must already be pushed to the repository,
+// and pushes it with the specified tags and ecr repository URI. It returns the digest of the manifest list on success.
+func (c CmdClient) PushManifestList(uri string, images []string, tags ...string) (digest string, err error) {
+	lists := []string{uri}
+	for _, tag := range tags {
+		lists = append(lists, imageName(uri, tag))
+	}
+
+	for _, list := range lists {
+		if err := c.runner.Run("docker", append([]string{"manifest", "create", "--amend", list}, images...)); err != nil {
+			return "", fmt.Errorf("docker manifest create %s: %w", list, err)
+		}
+		buf := new(strings.Builder)
+		if err := c.runner.Run("docker", []string{"manifest", "push", "--purge", list}, exec.Stdout(buf)); err != nil {
+			return "", fmt.Errorf("docker manifest push %s: %w", list, err)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		digest = strings.TrimSpace(lines[len(lines)-1]) // the digest is the last line of the output
+	}
+	if !strings.HasPrefix(digest, "sha256:") {
+		return "", fmt.Errorf("parse the digest of the manifest list from '%s'", digest)
+	}
+	return digest, nil
+}
+
 // CheckDockerEngineRunning will run `docker info` command to check if the docker engine is running.
 func (c CmdClient) CheckDockerEngineRunning() error {
 	if _, err := osexec.LookPath("docker"); err != nil {
@@ -38,6 +38,10 @@ func TestDockerCommand_Build(t *testing.T) {
 		args       map[string]string
 		target     string
 		cacheFrom  []string
+		ssh        []string
+		secrets    []string
+		network    string
+		labels     map[string]string
 		setupMocks func(controller *gomock.Controller)
 
 		wantedError error
@@ -138,6 +142,51 @@ func TestDockerCommand_Build(t *testing.T) {
 					"mockPath/to", "-f", "mockPath/to/mockDockerfile"}).Return(nil)
 			},
 		},
+		"runs with the host network": {
+			path:    mockPath,
+			network: "host",
+			setupMocks: func(c *gomock.Controller) {
+				mockCmd = NewMockCmd(c)
+				mockCmd.EXPECT().Run("docker", []string{"build",
+					"-t", mockURI,
+					"--network", "host",
+					"mockPath/to", "-f", "mockPath/to/mockDockerfile"}).Return(nil)
+			},
+		},
+		"runs with labels in sorted order": {
+			path: mockPath,
+			args: map[string]string{
+				"GO_VERSION": "1.19",
+			},
+			labels: map[string]string{
+				"org.opencontainers.image.revision": "abc123",
+				"com.example.team":                  "payments",
+			},
+			setupMocks: func(c *gomock.Controller) {
+				mockCmd = NewMockCmd(c)
+				mockCmd.EXPECT().Run("docker", []string{"build",
+					"-t", mockURI,
+					"--build-arg", "GO_VERSION=1.19",
+					"--label", "com.example.team=payments",
+					"--label", "org.opencontainers.image.revision=abc123",
+					"mockPath/to", "-f", "mockPath/to/mockDockerfile"}).Return(nil)
+			},
+		},
+		"runs with ssh and secrets": {
+			path:    mockPath,
+			ssh:     []string{"default", "github=/root/.ssh/id_ed25519"},
+			secrets: []string{"id=npmrc,src=/root/.npmrc", "id=token,env=GITHUB_TOKEN"},
+			setupMocks: func(c *gomock.Controller) {
+				mockCmd = NewMockCmd(c)
+				mockCmd.EXPECT().Run("docker", []string{"build",
+					"-t", mockURI,
+					"--ssh", "default",
+					"--ssh", "github=/root/.ssh/id_ed25519",
+					"--secret", "id=npmrc,src=/root/.npmrc",
+					"--secret", "id=token,env=GITHUB_TOKEN",
+					"mockPath/to", "-f", "mockPath/to/mockDockerfile"}, gomock.Any()).Return(nil)
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -154,6 +203,10 @@ func TestDockerCommand_Build(t *testing.T) {
 				Args:       tc.args,
 				Target:     tc.target,
 				CacheFrom:  tc.cacheFrom,
+				SSH:        tc.ssh,
+				Secrets:    tc.secrets,
+				Network:    tc.network,
+				Labels:     tc.labels,
 				Tags:       tc.tags,
 			}
 			got := s.Build(&buildInput)
@@ -297,6 +350,69 @@ func TestDockerCommand_Push(t *testing.T) {
 	})
 }
 
+func TestDockerCommand_PushManifestList(t *testing.T) {
+	const uri = "aws_account_id.dkr.ecr.region.amazonaws.com/my-web-app"
+	images := []string{uri + ":g123bfc-linux-amd64", uri + ":g123bfc-linux-arm64"}
+	t.Run("pushes a manifest list with multiple tags and returns its digest", func(t *testing.T) {
+		// GIVEN
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		m := NewMockCmd(ctrl)
+		for _, list := range []string{uri, uri + ":g123bfc"} {
+			m.EXPECT().Run("docker", append([]string{"manifest", "create", "--amend", list}, images...)).Return(nil)
+			m.EXPECT().Run("docker", []string{"manifest", "push", "--purge", list}, gomock.Any()).
+				Do(func(_ string, _ []string, opt exec.CmdOption) {
+					cmd := &osexec.Cmd{}
+					opt(cmd)
+					_, _ = cmd.Stdout.Write([]byte("sha256:f1d4ae3f7261a72e98c6ebefe9985cf10a0ea5bd762585a43e0700ed99863807\n"))
+				}).Return(nil)
+		}
+
+		// WHEN
+		cmd := CmdClient{
+			runner: m,
+		}
+		digest, err := cmd.PushManifestList(uri, images, "g123bfc")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "sha256:f1d4ae3f7261a72e98c6ebefe9985cf10a0ea5bd762585a43e0700ed99863807", digest)
+	})
+	t.Run("returns a wrapped error on failure to create the manifest list", func(t *testing.T) {
+		// GIVEN
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		m := NewMockCmd(ctrl)
+		m.EXPECT().Run(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+
+		// WHEN
+		cmd := CmdClient{
+			runner: m,
+		}
+		_, err := cmd.PushManifestList("uri", []string{"uri:linux-amd64"})
+
+		// THEN
+		require.EqualError(t, err, "docker manifest create uri: some error")
+	})
+	t.Run("returns an error if the digest cannot be parsed for the pushed manifest list", func(t *testing.T) {
+		// GIVEN
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		m := NewMockCmd(ctrl)
+		m.EXPECT().Run("docker", []string{"manifest", "create", "--amend", "uri", "uri:linux-amd64"}).Return(nil)
+		m.EXPECT().Run("docker", []string{"manifest", "push", "--purge", "uri"}, gomock.Any()).Return(nil)
+
+		// WHEN
+		cmd := CmdClient{
+			runner: m,
+		}
+		_, err := cmd.PushManifestList("uri", []string{"uri:linux-amd64"})
+
+		// THEN
+		require.EqualError(t, err, "parse the digest of the manifest list from ''")
+	})
+}
+
 func TestDockerCommand_CheckDockerEngineRunning(t *testing.T) {
 	mockError := errors.New("some error")
 	var mockCmd *MockCmd
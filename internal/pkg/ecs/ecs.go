@@ -86,6 +86,16 @@ func (c Client) ForceUpdateService(app, env, svc string) error {
 	return c.ecsClient.UpdateService(clusterName, serviceName, ecs.WithForceUpdate())
 }
 
+// UpdateServiceExecuteCommand turns ECS Exec on or off for an ECS service given Copilot service info.
+// The service is redeployed with its current task definition so that the new tasks pick up the setting.
+func (c Client) UpdateServiceExecuteCommand(app, env, svc string, enable bool) error {
+	clusterName, serviceName, err := c.fetchAndParseServiceARN(app, env, svc)
+	if err != nil {
+		return err
+	}
+	return c.ecsClient.UpdateService(clusterName, serviceName, ecs.WithExecuteCommand(enable), ecs.WithForceUpdate())
+}
+
 // DescribeService returns the description of an ECS service given Copilot service info.
 func (c Client) DescribeService(app, env, svc string) (*ServiceDesc, error) {
 	clusterName, serviceName, err := c.fetchAndParseServiceARN(app, env, svc)
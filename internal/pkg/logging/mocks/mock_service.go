@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	cloudwatchlogs "github.com/aws/copilot-cli/internal/pkg/aws/cloudwatchlogs"
+	ecs "github.com/aws/copilot-cli/internal/pkg/aws/ecs"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -48,3 +49,41 @@ func (mr *MocklogGetterMockRecorder) LogEvents(opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogEvents", reflect.TypeOf((*MocklogGetter)(nil).LogEvents), opts)
 }
+
+// MocktaskDefinitionGetter is a mock of taskDefinitionGetter interface.
+type MocktaskDefinitionGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MocktaskDefinitionGetterMockRecorder
+}
+
+// MocktaskDefinitionGetterMockRecorder is the mock recorder for MocktaskDefinitionGetter.
+type MocktaskDefinitionGetterMockRecorder struct {
+	mock *MocktaskDefinitionGetter
+}
+
+// NewMocktaskDefinitionGetter creates a new mock instance.
+func NewMocktaskDefinitionGetter(ctrl *gomock.Controller) *MocktaskDefinitionGetter {
+	mock := &MocktaskDefinitionGetter{ctrl: ctrl}
+	mock.recorder = &MocktaskDefinitionGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktaskDefinitionGetter) EXPECT() *MocktaskDefinitionGetterMockRecorder {
+	return m.recorder
+}
+
+// TaskDefinition mocks base method.
+func (m *MocktaskDefinitionGetter) TaskDefinition(app, env, svc string) (*ecs.TaskDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TaskDefinition", app, env, svc)
+	ret0, _ := ret[0].(*ecs.TaskDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TaskDefinition indicates an expected call of TaskDefinition.
+func (mr *MocktaskDefinitionGetterMockRecorder) TaskDefinition(app, env, svc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskDefinition", reflect.TypeOf((*MocktaskDefinitionGetter)(nil).TaskDefinition), app, env, svc)
+}
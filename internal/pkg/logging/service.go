@@ -14,7 +14,9 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/copilot-cli/internal/pkg/aws/apprunner"
 	"github.com/aws/copilot-cli/internal/pkg/aws/cloudwatchlogs"
+	awsecs "github.com/aws/copilot-cli/internal/pkg/aws/ecs"
 	"github.com/aws/copilot-cli/internal/pkg/describe"
+	"github.com/aws/copilot-cli/internal/pkg/ecs"
 	"github.com/aws/copilot-cli/internal/pkg/manifest"
 	"github.com/aws/copilot-cli/internal/pkg/term/log"
 )
@@ -30,6 +32,10 @@ type logGetter interface {
 	LogEvents(opts cloudwatchlogs.LogEventsOpts) (*cloudwatchlogs.LogEventsOutput, error)
 }
 
+type taskDefinitionGetter interface {
+	TaskDefinition(app, env, svc string) (*awsecs.TaskDefinition, error)
+}
+
 // ServiceClient retrieves the logs of an Amazon ECS or AppRunner service.
 type ServiceClient struct {
 	logGroupName        string
@@ -79,18 +85,39 @@ func NewServiceClient(opts *NewServiceLogsConfig) (*ServiceClient, error) {
 	if opts.WkldType == manifest.RequestDrivenWebServiceType {
 		return newAppRunnerServiceClient(opts)
 	}
-	logGroup := fmt.Sprintf(fmtSvclogGroupName, opts.App, opts.Env, opts.Svc)
+	logGroup, logStreamNamePrefix, err := deployedLogConfig(ecs.New(opts.Sess), opts.App, opts.Env, opts.Svc)
+	if err != nil {
+		return nil, err
+	}
 	if opts.LogGroup != "" {
 		logGroup = opts.LogGroup
 	}
 	return &ServiceClient{
 		logGroupName:        logGroup,
-		logStreamNamePrefix: fmt.Sprintf(fmtSvcLogStreamPrefix, opts.Svc),
+		logStreamNamePrefix: logStreamNamePrefix,
 		eventsGetter:        cloudwatchlogs.New(opts.Sess),
 		w:                   log.OutputWriter,
 	}, nil
 }
 
+// deployedLogConfig returns the log group and the log stream name prefix of the deployed task definition.
+// The log group is "logging.log_group" if set in the manifest, and the prefix is named after the main container, which is "container_name" if set.
+func deployedLogConfig(getter taskDefinitionGetter, app, env, svc string) (logGroup, logStreamNamePrefix string, err error) {
+	taskDef, err := getter.TaskDefinition(app, env, svc)
+	if err != nil {
+		return "", "", fmt.Errorf("get task definition of %s: %w", svc, err)
+	}
+	logGroup = taskDef.LogGroupName()
+	if logGroup == "" {
+		logGroup = fmt.Sprintf(fmtSvclogGroupName, app, env, svc)
+	}
+	container := taskDef.MainContainerName()
+	if container == "" {
+		container = svc
+	}
+	return logGroup, fmt.Sprintf(fmtSvcLogStreamPrefix, container), nil
+}
+
 func newAppRunnerServiceClient(opts *NewServiceLogsConfig) (*ServiceClient, error) {
 	if opts.TaskIDs != nil {
 		return nil, fmt.Errorf("cannot use --tasks for App Runner service logs")
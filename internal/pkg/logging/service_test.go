@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/copilot-cli/internal/pkg/aws/cloudwatchlogs"
+	awsecs "github.com/aws/copilot-cli/internal/pkg/aws/ecs"
 	"github.com/aws/copilot-cli/internal/pkg/logging/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -300,3 +302,67 @@ instance/4e66ee07f2034a7c Server is running on port 4055
 		})
 	}
 }
+
+func TestDeployedLogConfig(t *testing.T) {
+	testCases := map[string]struct {
+		setupMocks func(m *mocks.MocktaskDefinitionGetter)
+
+		wantedLogGroup     string
+		wantedStreamPrefix string
+		wantedError        error
+	}{
+		"error if fail to get the task definition": {
+			setupMocks: func(m *mocks.MocktaskDefinitionGetter) {
+				m.EXPECT().TaskDefinition("phonetool", "test", "api").Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("get task definition of api: some error"),
+		},
+		"return the log group and the main container of the task definition": {
+			setupMocks: func(m *mocks.MocktaskDefinitionGetter) {
+				m.EXPECT().TaskDefinition("phonetool", "test", "api").Return(&awsecs.TaskDefinition{
+					ContainerDefinitions: []*ecs.ContainerDefinition{
+						{
+							Name: aws.String("web"),
+							LogConfiguration: &ecs.LogConfiguration{
+								LogDriver: aws.String(ecs.LogDriverAwslogs),
+								Options: map[string]*string{
+									"awslogs-group": aws.String("/my/logs"),
+								},
+							},
+						},
+					},
+				}, nil)
+			},
+			wantedLogGroup:     "/my/logs",
+			wantedStreamPrefix: "copilot/web",
+		},
+		"fall back to the default log group and the service name": {
+			setupMocks: func(m *mocks.MocktaskDefinitionGetter) {
+				m.EXPECT().TaskDefinition("phonetool", "test", "api").Return(&awsecs.TaskDefinition{}, nil)
+			},
+			wantedLogGroup:     "/copilot/phonetool-test-api",
+			wantedStreamPrefix: "copilot/api",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := mocks.NewMocktaskDefinitionGetter(ctrl)
+			tc.setupMocks(m)
+
+			// WHEN
+			gotLogGroup, gotStreamPrefix, err := deployedLogConfig(m, "phonetool", "test", "api")
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedLogGroup, gotLogGroup)
+				require.Equal(t, tc.wantedStreamPrefix, gotStreamPrefix)
+			}
+		})
+	}
+}
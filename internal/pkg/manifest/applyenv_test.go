@@ -651,49 +651,74 @@ func TestApplyEnv_MapToString(t *testing.T) {
 	}{
 		"map upserted": {
 			inSvc: func(svc *LoadBalancedWebService) {
-				svc.TaskConfig.Secrets = map[string]string{
-					"secret1": "the secret sauce is mole",
-					"secret2": "the secret agent is johnny rivers",
+				svc.TaskConfig.Secrets = map[string]Secret{
+					"secret1": {From: aws.String("the secret sauce is mole")},
+					"secret2": {From: aws.String("the secret agent is johnny rivers")},
 				}
-				svc.Environments["test"].TaskConfig.Secrets = map[string]string{
-					"secret1": "the secret sauce is blue cheese which has mold in it",
-					"secret3": "the secret route is through egypt",
+				svc.Environments["test"].TaskConfig.Secrets = map[string]Secret{
+					"secret1": {From: aws.String("the secret sauce is blue cheese which has mold in it")},
+					"secret3": {From: aws.String("the secret route is through egypt")},
 				}
 			},
 			wanted: func(svc *LoadBalancedWebService) {
-				svc.TaskConfig.Secrets = map[string]string{
-					"secret1": "the secret sauce is blue cheese which has mold in it", // Overridden.
-					"secret2": "the secret agent is johnny rivers",                    // Kept.
-					"secret3": "the secret route is through egypt",                    // Appended
+				svc.TaskConfig.Secrets = map[string]Secret{
+					"secret1": {From: aws.String("the secret sauce is blue cheese which has mold in it")}, // Overridden.
+					"secret2": {From: aws.String("the secret agent is johnny rivers")},                    // Kept.
+					"secret3": {From: aws.String("the secret route is through egypt")},                    // Appended
 				}
 			},
 		},
 		"map not overridden by zero map": {
 			inSvc: func(svc *LoadBalancedWebService) {
-				svc.TaskConfig.Secrets = map[string]string{
-					"secret1": "the secret sauce is mole",
-					"secret2": "the secret agent man is johnny rivers",
+				svc.TaskConfig.Secrets = map[string]Secret{
+					"secret1": {From: aws.String("the secret sauce is mole")},
+					"secret2": {From: aws.String("the secret agent man is johnny rivers")},
 				}
-				svc.Environments["test"].TaskConfig.Secrets = map[string]string{}
+				svc.Environments["test"].TaskConfig.Secrets = map[string]Secret{}
 			},
 			wanted: func(svc *LoadBalancedWebService) {
-				svc.TaskConfig.Secrets = map[string]string{
-					"secret1": "the secret sauce is mole",
-					"secret2": "the secret agent man is johnny rivers",
+				svc.TaskConfig.Secrets = map[string]Secret{
+					"secret1": {From: aws.String("the secret sauce is mole")},
+					"secret2": {From: aws.String("the secret agent man is johnny rivers")},
+				}
+			},
+		},
+		"variables file overridden and inline variables upserted": {
+			inSvc: func(svc *LoadBalancedWebService) {
+				svc.TaskConfig.Variables = Variables{
+					FromFile: aws.String("./config/app.env"),
+					Values: map[string]string{
+						"LOG_LEVEL": "info",
+					},
+				}
+				svc.Environments["test"].TaskConfig.Variables = Variables{
+					FromFile: aws.String("./config/test.env"),
+					Values: map[string]string{
+						"NODE_ENV": "test",
+					},
+				}
+			},
+			wanted: func(svc *LoadBalancedWebService) {
+				svc.TaskConfig.Variables = Variables{
+					FromFile: aws.String("./config/test.env"), // Overridden.
+					Values: map[string]string{
+						"LOG_LEVEL": "info", // Kept.
+						"NODE_ENV":  "test", // Appended.
+					},
 				}
 			},
 		},
 		"map not overridden": {
 			inSvc: func(svc *LoadBalancedWebService) {
-				svc.TaskConfig.Secrets = map[string]string{
-					"secret1": "the secret sauce is mole",
-					"secret2": "the secret agent man is johnny rivers",
+				svc.TaskConfig.Secrets = map[string]Secret{
+					"secret1": {From: aws.String("the secret sauce is mole")},
+					"secret2": {From: aws.String("the secret agent man is johnny rivers")},
 				}
 			},
 			wanted: func(svc *LoadBalancedWebService) {
-				svc.TaskConfig.Secrets = map[string]string{
-					"secret1": "the secret sauce is mole",
-					"secret2": "the secret agent man is johnny rivers",
+				svc.TaskConfig.Secrets = map[string]Secret{
+					"secret1": {From: aws.String("the secret sauce is mole")},
+					"secret2": {From: aws.String("the secret agent man is johnny rivers")},
 				}
 			},
 		},
@@ -888,3 +913,205 @@ func TestApplyEnv_MapToPStruct(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyEnv_Platform(t *testing.T) {
+	testCases := map[string]struct {
+		inSvc  func(svc *LoadBalancedWebService)
+		wanted func(svc *LoadBalancedWebService)
+
+		wantedPlatform string
+	}{
+		"string overridden by string": {
+			inSvc: func(svc *LoadBalancedWebService) {
+				svc.Platform = PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/amd64"))}
+				svc.Environments["prod"].Platform = PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/arm64"))}
+			},
+			wanted: func(svc *LoadBalancedWebService) {
+				svc.Platform = PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/arm64"))}
+			},
+			wantedPlatform: "linux/arm64",
+		},
+		"args overridden by string": {
+			inSvc: func(svc *LoadBalancedWebService) {
+				svc.Platform = PlatformArgsOrString{PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("linux"),
+					Arch:     aws.String("x86_64"),
+				}}
+				svc.Environments["prod"].Platform = PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/arm64"))}
+			},
+			wanted: func(svc *LoadBalancedWebService) {
+				svc.Platform = PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/arm64"))}
+			},
+			wantedPlatform: "linux/arm64",
+		},
+		"string overridden by args": {
+			inSvc: func(svc *LoadBalancedWebService) {
+				svc.Platform = PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/amd64"))}
+				svc.Environments["prod"].Platform = PlatformArgsOrString{PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("linux"),
+					Arch:     aws.String("arm64"),
+				}}
+			},
+			wanted: func(svc *LoadBalancedWebService) {
+				svc.Platform = PlatformArgsOrString{PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("linux"),
+					Arch:     aws.String("arm64"),
+				}}
+			},
+			wantedPlatform: "linux/arm64",
+		},
+		"string not overridden": {
+			inSvc: func(svc *LoadBalancedWebService) {
+				svc.Platform = PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/amd64"))}
+			},
+			wanted: func(svc *LoadBalancedWebService) {
+				svc.Platform = PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/amd64"))}
+			},
+			wantedPlatform: "linux/amd64",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var inSvc, wantedSvc LoadBalancedWebService
+			inSvc.Environments = map[string]*LoadBalancedWebServiceConfig{
+				"prod": {},
+			}
+
+			tc.inSvc(&inSvc)
+			tc.wanted(&wantedSvc)
+
+			got, err := inSvc.ApplyEnv("prod")
+
+			require.NoError(t, err)
+			require.Equal(t, &wantedSvc, got)
+			require.Equal(t, tc.wantedPlatform, got.(*LoadBalancedWebService).ContainerPlatform())
+		})
+	}
+}
+
+func TestApplyEnv_EnvNameInImageOverride(t *testing.T) {
+	testCases := map[string]struct {
+		inSvc  func(svc *LoadBalancedWebService)
+		wanted func(svc *LoadBalancedWebService)
+	}{
+		"string command overridden in the environment": {
+			inSvc: func(svc *LoadBalancedWebService) {
+				svc.Command = CommandOverride{String: aws.String("serve")}
+				svc.Environments["prod"].Command = CommandOverride{String: aws.String("serve --env ${COPILOT_ENVIRONMENT_NAME}")}
+			},
+			wanted: func(svc *LoadBalancedWebService) {
+				svc.Command = CommandOverride{String: aws.String("serve --env prod")}
+			},
+		},
+		"slice entrypoint not overridden": {
+			inSvc: func(svc *LoadBalancedWebService) {
+				svc.EntryPoint = EntryPointOverride{StringSlice: []string{"/bin/start", "--env=$COPILOT_ENVIRONMENT_NAME", "${UNKNOWN}"}}
+			},
+			wanted: func(svc *LoadBalancedWebService) {
+				svc.EntryPoint = EntryPointOverride{StringSlice: []string{"/bin/start", "--env=prod", "${UNKNOWN}"}}
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var inSvc, wantedSvc LoadBalancedWebService
+			inSvc.Environments = map[string]*LoadBalancedWebServiceConfig{
+				"prod": {},
+			}
+
+			tc.inSvc(&inSvc)
+			tc.wanted(&wantedSvc)
+
+			got, err := inSvc.ApplyEnv("prod")
+
+			require.NoError(t, err)
+			require.Equal(t, &wantedSvc, got)
+		})
+	}
+}
+
+func TestApplyEnv_NetworkVPC(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest string
+		wanted     vpcConfig
+	}{
+		"subnets kept if an override only sets security groups": {
+			inManifest: `
+name: api
+type: Backend Service
+image:
+  location: nginx
+network:
+  vpc:
+    subnets: ['subnet-0123abcd', 'subnet-4567cdef']
+environments:
+  prod:
+    network:
+      vpc:
+        security_groups: ['sg-0123abcd']
+`,
+			wanted: vpcConfig{
+				Subnets: SubnetListOrArgs{
+					IDs: []string{"subnet-0123abcd", "subnet-4567cdef"},
+				},
+				SecurityGroups: []SecurityGroup{{ID: aws.String("sg-0123abcd")}},
+			},
+		},
+		"placement replaced if an override sets subnets": {
+			inManifest: `
+name: api
+type: Backend Service
+image:
+  location: nginx
+network:
+  vpc:
+    placement: private
+environments:
+  prod:
+    network:
+      vpc:
+        subnets:
+          from_tags:
+            tier: payments
+`,
+			wanted: vpcConfig{
+				Subnets: SubnetListOrArgs{
+					SubnetArgs: SubnetArgs{
+						FromTags: map[string]string{"tier": "payments"},
+					},
+				},
+			},
+		},
+		"subnets replaced if an override sets a placement": {
+			inManifest: `
+name: api
+type: Backend Service
+image:
+  location: nginx
+network:
+  vpc:
+    subnets: ['subnet-0123abcd']
+environments:
+  prod:
+    network:
+      vpc:
+        placement: private
+`,
+			wanted: vpcConfig{
+				Placement: &PrivateSubnetPlacement,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mft, err := UnmarshalWorkload([]byte(tc.inManifest))
+			require.NoError(t, err)
+
+			got, err := mft.ApplyEnv("prod")
+			require.NoError(t, err)
+
+			require.NoError(t, got.Validate())
+			require.Equal(t, tc.wanted, got.(*BackendService).Network.VPC)
+		})
+	}
+}
@@ -27,12 +27,19 @@ type BackendService struct {
 type BackendServiceConfig struct {
 	ImageConfig      ImageWithHealthcheckAndOptionalPort `yaml:"image,flow"`
 	ImageOverride    `yaml:",inline"`
+	RoutingRule      RoutingRule `yaml:"http,flow"` // Routes the requests of the environment's internal load balancer to the service.
 	TaskConfig       `yaml:",inline"`
 	Logging          Logging                   `yaml:"logging,flow"`
 	Sidecars         map[string]*SidecarConfig `yaml:"sidecars"` // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
+	Flags            Flags                     `yaml:"flags"`
 	Network          NetworkConfig             `yaml:"network"`
 	PublishConfig    PublishConfig             `yaml:"publish"`
 	TaskDefOverrides []OverrideRule            `yaml:"taskdef_overrides"`
+	AZRebalancing    *bool                     `yaml:"availability_zone_rebalancing"`
+	PropagateTags    *string                   `yaml:"propagate_tags"`
+	GitSHATag        GitSHATag                 `yaml:"git_sha_tag"`
+	DeployConfig     DeploymentConfig          `yaml:"deployment"`
+	InitContainer    InitContainer             `yaml:"init_container"`
 }
 
 // BackendServiceProps represents the configuration needed to create a backend service.
@@ -90,25 +97,50 @@ func (s *BackendService) Publish() []Topic {
 	return s.BackendServiceConfig.PublishConfig.Topics
 }
 
+// PreDeployTask returns the task to run to completion before the service is updated.
+func (s *BackendService) PreDeployTask() PreDeployTask {
+	return s.BackendServiceConfig.DeployConfig.PreDeploy
+}
+
+// PreDeployTaskConfig returns the task configuration of the service, such as its size and variables,
+// that the pre-deploy task inherits.
+func (s *BackendService) PreDeployTaskConfig() TaskConfig {
+	return s.BackendServiceConfig.TaskConfig
+}
+
+// GitSHATagConfig returns the option to tag the service with the git commit SHA it's deployed from.
+func (s *BackendService) GitSHATagConfig() GitSHATag {
+	return s.GitSHATag
+}
+
+// NetworkConfig returns the network configuration of the tasks of the service.
+func (s *BackendService) NetworkConfig() NetworkConfig {
+	return s.Network
+}
+
+// InitContainerConfig returns the container to run to completion before the main container starts.
+func (s *BackendService) InitContainerConfig() InitContainer {
+	return s.BackendServiceConfig.InitContainer
+}
+
 // BuildRequired returns if the service requires building from the local Dockerfile.
 func (s *BackendService) BuildRequired() (bool, error) {
 	return requiresBuild(s.ImageConfig.Image)
 }
 
-// BuildArgs returns a docker.BuildArguments object for the service given a workspace root directory.
-func (s *BackendService) BuildArgs(wsRoot string) *DockerBuildArgs {
-	return s.ImageConfig.Image.BuildConfig(wsRoot)
+// BuildArgs returns the docker.BuildArguments objects, one per build platform, for the service given a workspace root directory and an environment name.
+// hostPlatform is the "os/arch" of the Docker engine that builds the image, if known.
+func (s *BackendService) BuildArgs(wsRoot, envName, hostPlatform string) ([]*DockerBuildArgs, error) {
+	return s.ImageConfig.Image.BuildConfigWithPlatform(wsRoot, envName, hostPlatform)
 }
 
 // ApplyEnv returns the service manifest with environment overrides.
 // If the environment passed in does not have any overrides then it returns itself.
 func (s BackendService) ApplyEnv(envName string) (WorkloadManifest, error) {
 	overrideConfig, ok := s.Environments[envName]
-	if !ok {
-		return &s, nil
-	}
-
-	if overrideConfig == nil {
+	if !ok || overrideConfig == nil {
+		s.Sidecars, s.Variables = applyFlags(s.Flags, s.Sidecars, s.Variables)
+		s.ImageOverride = s.ImageOverride.withEnvName(envName)
 		return &s, nil
 	}
 
@@ -122,6 +154,8 @@ func (s BackendService) ApplyEnv(envName string) (WorkloadManifest, error) {
 		}
 	}
 	s.Environments = nil
+	s.Sidecars, s.Variables = applyFlags(s.Flags, s.Sidecars, s.Variables)
+	s.ImageOverride = s.ImageOverride.withEnvName(envName)
 	return &s, nil
 }
 
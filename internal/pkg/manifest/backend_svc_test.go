@@ -428,9 +428,9 @@ func TestBackendSvc_ApplyEnv(t *testing.T) {
 						},
 					},
 					CPU: aws.Int(512),
-					Variables: map[string]string{
+					Variables: Variables{Values: map[string]string{
 						"LOG_LEVEL": "",
-					},
+					}},
 				},
 				Sidecars: map[string]*SidecarConfig{
 					"xray": {
@@ -623,9 +623,9 @@ func TestBackendSvc_ApplyEnv(t *testing.T) {
 								CPU: &mockPercentage,
 							},
 						},
-						Variables: map[string]string{
+						Variables: Variables{Values: map[string]string{
 							"LOG_LEVEL": "",
-						},
+						}},
 					},
 					Sidecars: map[string]*SidecarConfig{
 						"xray": {
@@ -905,3 +905,135 @@ func TestBackendSvc_ApplyEnv_CountOverrides(t *testing.T) {
 		})
 	}
 }
+
+func TestBackendSvc_ApplyEnv_Flags(t *testing.T) {
+	const mft = `
+name: api
+type: Backend Service
+image:
+  build: ./Dockerfile
+flags:
+  tracing: false
+sidecars:
+  xray:
+    image: amazon/aws-xray-daemon
+    when: tracing
+  nginx:
+    image: public.ecr.aws/nginx/nginx
+variables:
+  LOG_LEVEL: info
+  TRACING:
+    value: "on"
+    when: tracing
+environments:
+  test:
+    flags:
+      tracing: true
+  prod:
+    variables:
+      LOG_LEVEL: warn
+`
+	testCases := map[string]struct {
+		envName string
+
+		wantedSidecars  []string
+		wantedVariables map[string]string
+	}{
+		"flag turned on in the environment": {
+			envName: "test",
+
+			wantedSidecars: []string{"nginx", "xray"},
+			wantedVariables: map[string]string{
+				"LOG_LEVEL": "info",
+				"TRACING":   "on",
+			},
+		},
+		"flag left off in the environment": {
+			envName: "prod",
+
+			wantedSidecars: []string{"nginx"},
+			wantedVariables: map[string]string{
+				"LOG_LEVEL": "warn",
+			},
+		},
+		"flag left off without environment overrides": {
+			envName: "staging",
+
+			wantedSidecars: []string{"nginx"},
+			wantedVariables: map[string]string{
+				"LOG_LEVEL": "info",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			in, err := UnmarshalWorkload([]byte(mft))
+			require.NoError(t, err)
+
+			// WHEN
+			got, err := in.ApplyEnv(tc.envName)
+
+			// THEN
+			require.NoError(t, err)
+			svc := got.(*BackendService)
+			var sidecars []string
+			for name := range svc.Sidecars {
+				sidecars = append(sidecars, name)
+			}
+			require.ElementsMatch(t, tc.wantedSidecars, sidecars)
+			require.Equal(t, tc.wantedVariables, svc.Variables.Values)
+			require.NoError(t, svc.Validate())
+		})
+	}
+}
+
+func TestBackendService_ApplyEnv_IPFamily(t *testing.T) {
+	mft := `name: api
+type: Backend Service
+image:
+  location: nginx
+network:
+  vpc:
+    ip_family: dualstack
+environments:
+  test:
+    network:
+      vpc:
+        security_groups: ['sg-1234']
+  prod:
+    network:
+      vpc:
+        ip_family: ipv4
+`
+	testCases := map[string]struct {
+		envName string
+
+		wantedIPFamily string
+	}{
+		"keeps the ip family of the service if the environment overrides other network fields": {
+			envName: "test",
+
+			wantedIPFamily: IPFamilyDualStack,
+		},
+		"overrides the ip family of the service": {
+			envName: "prod",
+
+			wantedIPFamily: IPFamilyIPv4,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			in, err := UnmarshalWorkload([]byte(mft))
+			require.NoError(t, err)
+
+			// WHEN
+			got, err := in.ApplyEnv(tc.envName)
+
+			// THEN
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedIPFamily, aws.StringValue(got.(*BackendService).Network.VPC.IPFamily))
+		})
+	}
+}
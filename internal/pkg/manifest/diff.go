@@ -0,0 +1,298 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of changes to a manifest field.
+const (
+	FieldAdded   = "added"
+	FieldRemoved = "removed"
+	FieldChanged = "changed"
+)
+
+var yamlUnmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+// FieldChange is a field of a workload manifest that is different between two versions of the manifest.
+type FieldChange struct {
+	Path string      // YAML path of the field, such as "image.build.dockerfile" or "sidecars[nginx].port".
+	Kind string      // One of FieldAdded, FieldRemoved or FieldChanged.
+	Old  interface{} // Nil if the field is added.
+	New  interface{} // Nil if the field is removed.
+}
+
+// String implements the fmt.Stringer interface.
+func (c FieldChange) String() string {
+	switch c.Kind {
+	case FieldAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, formatDiffValue(c.New))
+	case FieldRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, formatDiffValue(c.Old))
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", c.Path, formatDiffValue(c.Old), formatDiffValue(c.New))
+}
+
+// Diff returns the leaf fields that are added, removed or changed in the new workload manifest compared to the old one.
+// The old manifest is nil if the workload was never deployed.
+// Fields that hold one of several forms, such as "image.build" as a string or a map, are reported as a single
+// change of the field when the form changes, and field by field otherwise.
+func Diff(old, new WorkloadManifest) ([]FieldChange, error) {
+	if new == nil {
+		return nil, fmt.Errorf("new manifest cannot be nil")
+	}
+	newVal := reflect.ValueOf(new)
+	oldVal := reflect.Zero(newVal.Type())
+	if old != nil {
+		oldVal = reflect.ValueOf(old)
+	}
+	if oldVal.Type() != newVal.Type() {
+		return nil, fmt.Errorf("cannot diff a manifest of type %s with a manifest of type %s", oldVal.Type(), newVal.Type())
+	}
+	var d differ
+	d.walk("", oldVal, newVal)
+	return d.changes, nil
+}
+
+type differ struct {
+	changes []FieldChange
+}
+
+func (d *differ) walk(path string, old, new reflect.Value) {
+	old, new = indirect(old), indirect(new)
+	typ := valueType(old, new)
+	if typ == nil {
+		return
+	}
+	if !old.IsValid() {
+		old = reflect.Zero(typ)
+	}
+	if !new.IsValid() {
+		new = reflect.Zero(typ)
+	}
+	switch {
+	case isUnionType(typ):
+		d.walkUnion(path, old, new)
+	case typ.Kind() == reflect.Struct:
+		d.walkStruct(path, old, new)
+	case typ.Kind() == reflect.Map:
+		d.walkMap(path, old, new)
+	default:
+		d.compareLeaf(path, old, new)
+	}
+}
+
+func (d *differ) walkStruct(path string, old, new reflect.Value) {
+	typ := old.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue // Unexported fields aren't part of the manifest.
+		}
+		name, inline, skip := yamlFieldName(field)
+		if skip {
+			continue
+		}
+		fieldPath := path
+		if !inline {
+			fieldPath = joinYAMLPath(path, name)
+		}
+		d.walk(fieldPath, old.Field(i), new.Field(i))
+	}
+}
+
+// walkUnion compares a field that holds one of several forms, such as BuildArgsOrString, under the path of the field.
+func (d *differ) walkUnion(path string, old, new reflect.Value) {
+	oldSet, newSet := setFields(old), setFields(new)
+	if len(oldSet) == 0 || len(newSet) == 0 || !overlaps(oldSet, newSet) {
+		d.compareLeaf(path, unionValue(old, oldSet), unionValue(new, newSet))
+		return
+	}
+	leafChanged := false
+	for i := 0; i < old.NumField(); i++ {
+		if old.Type().Field(i).PkgPath != "" {
+			continue
+		}
+		oldField, newField := old.Field(i), new.Field(i)
+		if isComposite(valueType(indirect(oldField), indirect(newField))) {
+			d.walk(path, oldField, newField)
+			continue
+		}
+		if !reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+			leafChanged = true
+		}
+	}
+	if leafChanged {
+		d.compareLeaf(path, unionValue(old, oldSet), unionValue(new, newSet))
+	}
+}
+
+func (d *differ) walkMap(path string, old, new reflect.Value) {
+	keys := make(map[string]reflect.Value)
+	for _, m := range []reflect.Value{old, new} {
+		for _, key := range m.MapKeys() {
+			keys[fmt.Sprint(key.Interface())] = key
+		}
+	}
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key := keys[name]
+		d.walk(fmt.Sprintf("%s[%s]", path, name), old.MapIndex(key), new.MapIndex(key))
+	}
+}
+
+func (d *differ) compareLeaf(path string, old, new reflect.Value) {
+	oldSet, newSet := isSet(old), isSet(new)
+	switch {
+	case !oldSet && !newSet:
+		return
+	case !oldSet:
+		d.changes = append(d.changes, FieldChange{Path: path, Kind: FieldAdded, New: new.Interface()})
+	case !newSet:
+		d.changes = append(d.changes, FieldChange{Path: path, Kind: FieldRemoved, Old: old.Interface()})
+	case !reflect.DeepEqual(old.Interface(), new.Interface()):
+		d.changes = append(d.changes, FieldChange{Path: path, Kind: FieldChanged, Old: old.Interface(), New: new.Interface()})
+	}
+}
+
+// isUnionType returns true if the type is unmarshaled from one of several forms into its untagged fields.
+func isUnionType(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct || !reflect.PtrTo(typ).Implements(yamlUnmarshalerType) {
+		return false
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath == "" && !field.Anonymous && field.Tag.Get("yaml") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func isComposite(typ reflect.Type) bool {
+	return typ != nil && (typ.Kind() == reflect.Struct || typ.Kind() == reflect.Map)
+}
+
+// setFields returns the indices of the exported fields of the struct that are set.
+func setFields(v reflect.Value) []int {
+	var set []int
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).PkgPath == "" && !v.Field(i).IsZero() {
+			set = append(set, i)
+		}
+	}
+	return set
+}
+
+func overlaps(a, b []int) bool {
+	for _, i := range a {
+		for _, j := range b {
+			if i == j {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// unionValue returns the form of the union that is set, or the union itself if several of its fields are set.
+func unionValue(v reflect.Value, set []int) reflect.Value {
+	if len(set) == 1 {
+		return indirect(v.Field(set[0]))
+	}
+	return v
+}
+
+func isSet(v reflect.Value) bool {
+	v = indirect(v)
+	if !v.IsValid() {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() != 0
+	}
+	return !v.IsZero()
+}
+
+// indirect dereferences pointers and interfaces until it reaches a value, or returns the zero Value if any of them is nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func valueType(old, new reflect.Value) reflect.Type {
+	if old.IsValid() {
+		return old.Type()
+	}
+	if new.IsValid() {
+		return new.Type()
+	}
+	return nil
+}
+
+// yamlFieldName returns the key of the struct field in the manifest, whether its fields are inlined
+// in the parent's, and whether the field is not part of the manifest.
+func yamlFieldName(field reflect.StructField) (name string, inline bool, skip bool) {
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "inline" {
+			return "", true, false
+		}
+	}
+	if parts[0] != "" {
+		return parts[0], false, false
+	}
+	if field.Anonymous {
+		return "", true, false
+	}
+	return strings.ToLower(field.Name), false, false
+}
+
+func joinYAMLPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// formatDiffValue returns the value in YAML flow style, such as "[a, b]" or "{dockerfile: Dockerfile}".
+func formatDiffValue(v interface{}) string {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	setFlowStyle(&node)
+	out, err := yaml.Marshal(&node)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func setFlowStyle(node *yaml.Node) {
+	node.Style |= yaml.FlowStyle
+	for _, child := range node.Content {
+		setFlowStyle(child)
+	}
+}
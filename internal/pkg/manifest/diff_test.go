@@ -0,0 +1,143 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	const base = `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+cpu: 256
+variables:
+  LOG_LEVEL: info
+  REGION: us-west-2
+`
+	testCases := map[string]struct {
+		inOld string
+		inNew string
+
+		wanted    []string
+		wantedErr error
+	}{
+		"no changes": {
+			inOld: base,
+			inNew: base,
+		},
+		"reports changed, added and removed leaf fields": {
+			inOld: base,
+			inNew: `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+cpu: 512
+memory: 1024
+variables:
+  LOG_LEVEL: debug
+sidecars:
+  nginx:
+    image: public.ecr.aws/nginx/nginx:1.25
+    port: 80
+`,
+			wanted: []string{
+				"~ cpu: 256 -> 512",
+				"~ memory: 512 -> 1024",
+				"~ variables[LOG_LEVEL]: info -> debug",
+				"- variables[REGION]: us-west-2",
+				"+ sidecars[nginx].port: \"80\"",
+				"+ sidecars[nginx].image: public.ecr.aws/nginx/nginx:1.25",
+			},
+		},
+		"reports a change of form of a union field as a single change": {
+			inOld: base,
+			inNew: `name: api
+type: Backend Service
+image:
+  build:
+    dockerfile: api/Dockerfile
+    context: .
+  port: 8080
+cpu: 256
+variables:
+  LOG_LEVEL: info
+  REGION: us-west-2
+`,
+			wanted: []string{
+				"~ image.build: api/Dockerfile -> {context: ., dockerfile: api/Dockerfile}",
+			},
+		},
+		"walks into a union field that keeps its form": {
+			inOld: `name: api
+type: Backend Service
+image:
+  build:
+    dockerfile: api/Dockerfile
+  port: 8080
+`,
+			inNew: `name: api
+type: Backend Service
+image:
+  build:
+    dockerfile: api/Dockerfile
+    target: prod
+  port: 8080
+`,
+			wanted: []string{
+				"+ image.build.target: prod",
+			},
+		},
+		"errors if the manifests are of different types": {
+			inOld: `name: api
+type: Worker Service
+image:
+  build: api/Dockerfile
+`,
+			inNew:     base,
+			wantedErr: errors.New("cannot diff a manifest of type *manifest.WorkerService with a manifest of type *manifest.BackendService"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			oldMft, err := UnmarshalWorkload([]byte(tc.inOld))
+			require.NoError(t, err)
+			newMft, err := UnmarshalWorkload([]byte(tc.inNew))
+			require.NoError(t, err)
+
+			changes, err := Diff(oldMft, newMft)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			var got []string
+			for _, change := range changes {
+				got = append(got, change.String())
+			}
+			require.ElementsMatch(t, tc.wanted, got)
+		})
+	}
+}
+
+func TestDiff_FirstDeployment(t *testing.T) {
+	newMft, err := UnmarshalWorkload([]byte(`name: api
+type: Backend Service
+image:
+  location: nginx:1.25
+`))
+	require.NoError(t, err)
+
+	changes, err := Diff(nil, newMft)
+
+	require.NoError(t, err)
+	require.Contains(t, changes, FieldChange{Path: "image.location", Kind: FieldAdded, New: "nginx:1.25"})
+}
@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"gopkg.in/yaml.v3"
+)
+
+// Environment holds the configuration of an environment shared by the workloads deployed to it.
+type Environment struct {
+	ScalingCalendars map[string]ScalingCalendar `yaml:"scaling_calendars"`
+}
+
+// ScalingCalendar is a named list of scheduled actions that services reference from "count.scheduled".
+type ScalingCalendar []ScheduledAction
+
+// ScheduledAction represents a change of the range of the number of tasks of a service on a schedule.
+type ScheduledAction struct {
+	Schedule *string       `yaml:"schedule"`
+	Timezone *string       `yaml:"timezone"`
+	Range    *IntRangeBand `yaml:"range"`
+}
+
+// IsEmpty returns true if none of the fields of the scheduled action are set.
+func (a *ScheduledAction) IsEmpty() bool {
+	return a.Schedule == nil && a.Timezone == nil && a.Range == nil
+}
+
+// ScheduledScaling represents an entry of "count.scheduled".
+// It either references a scaling calendar of the environment or holds a scheduled action of the service.
+type ScheduledScaling struct {
+	Calendar        *string `yaml:"calendar"`
+	ScheduledAction `yaml:",inline"`
+}
+
+// UnmarshalEnvironment deserializes the YAML input stream into an environment manifest object.
+func UnmarshalEnvironment(in []byte) (*Environment, error) {
+	var env Environment
+	if err := yaml.Unmarshal(in, &env); err != nil {
+		return nil, fmt.Errorf("unmarshal environment manifest: %w", err)
+	}
+	return &env, nil
+}
+
+// ScalingCalendarReferences returns the sorted names of the scaling calendars that the workload
+// references from "count.scheduled".
+func ScalingCalendarReferences(mft interface{}) []string {
+	var count Count
+	switch t := mft.(type) {
+	case *LoadBalancedWebService:
+		count = t.Count
+	case *BackendService:
+		count = t.Count
+	case *WorkerService:
+		count = t.Count
+	default:
+		return nil
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, s := range count.AdvancedCount.Scheduled {
+		name := aws.StringValue(s.Calendar)
+		if s.Calendar == nil || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
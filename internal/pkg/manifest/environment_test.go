@@ -0,0 +1,62 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalEnvironment(t *testing.T) {
+	in := `
+scaling_calendars:
+  business-hours:
+    - schedule: cron(0 8 ? * MON-FRI *)
+      timezone: Europe/Paris
+      range: 2-10
+    - schedule: cron(0 18 ? * MON-FRI *)
+      timezone: Europe/Paris
+      range: 1-2
+`
+	businessHours := IntRangeBand("2-10")
+	offHours := IntRangeBand("1-2")
+
+	got, err := UnmarshalEnvironment([]byte(in))
+
+	require.NoError(t, err)
+	require.Equal(t, &Environment{
+		ScalingCalendars: map[string]ScalingCalendar{
+			"business-hours": {
+				{Schedule: aws.String("cron(0 8 ? * MON-FRI *)"), Timezone: aws.String("Europe/Paris"), Range: &businessHours},
+				{Schedule: aws.String("cron(0 18 ? * MON-FRI *)"), Timezone: aws.String("Europe/Paris"), Range: &offHours},
+			},
+		},
+	}, got)
+}
+
+func TestScalingCalendarReferences(t *testing.T) {
+	in := `
+name: api
+type: Load Balanced Web Service
+image:
+  location: nginx
+  port: 80
+http:
+  path: /
+count:
+  range: 1-10
+  scheduled:
+    - calendar: holidays
+    - calendar: business-hours
+    - schedule: at(2026-12-31T20:00:00)
+      range: 4-10
+    - calendar: holidays
+`
+	mft, err := UnmarshalWorkload([]byte(in))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"business-hours", "holidays"}, ScalingCalendarReferences(mft))
+}
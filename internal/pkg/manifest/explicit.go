@@ -0,0 +1,140 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"gopkg.in/yaml.v3"
+)
+
+// Explicit returns the YAML document of the workload manifest with the defaults that Copilot applies
+// implicitly written out, such as the image of the log router, the platform, or the placement of the tasks.
+// Fields that are neither set nor defaulted are omitted.
+// Environment overrides are written as they are, call ApplyEnv first to expand the manifest of an environment.
+func Explicit(wl WorkloadManifest) ([]byte, error) {
+	var mft interface{}
+	switch t := wl.(type) {
+	case *LoadBalancedWebService:
+		explicit := *t
+		explicit.TaskConfig = explicitTaskConfig(explicit.TaskConfig, aws.StringValue(explicit.Name))
+		explicit.Logging = explicitLogging(explicit.Logging)
+		explicit.Network = explicitNetwork(explicit.Network)
+		mft = &explicit
+	case *BackendService:
+		explicit := *t
+		explicit.TaskConfig = explicitTaskConfig(explicit.TaskConfig, aws.StringValue(explicit.Name))
+		explicit.Logging = explicitLogging(explicit.Logging)
+		explicit.Network = explicitNetwork(explicit.Network)
+		mft = &explicit
+	case *WorkerService:
+		explicit := *t
+		explicit.TaskConfig = explicitTaskConfig(explicit.TaskConfig, aws.StringValue(explicit.Name))
+		explicit.Logging = explicitLogging(explicit.Logging)
+		explicit.Network = explicitNetwork(explicit.Network)
+		mft = &explicit
+	case *ScheduledJob:
+		explicit := *t
+		explicit.TaskConfig = explicitTaskConfig(explicit.TaskConfig, aws.StringValue(explicit.Name))
+		explicit.Logging = explicitLogging(explicit.Logging)
+		explicit.Network = explicitNetwork(explicit.Network)
+		mft = &explicit
+	case *RequestDrivenWebService:
+		explicit := *t
+		if explicit.InstanceConfig.Platform.IsEmpty() {
+			explicit.InstanceConfig.Platform = PlatformArgsOrString{
+				PlatformString: (*PlatformString)(aws.String(explicit.ContainerPlatform())),
+			}
+		}
+		mft = &explicit
+	default:
+		return nil, fmt.Errorf("render explicit manifest: unsupported manifest type %T", wl)
+	}
+
+	var node yaml.Node
+	if err := node.Encode(mft); err != nil {
+		return nil, fmt.Errorf("encode manifest: %w", err)
+	}
+	pruneEmptyNodes(&node)
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("marshal manifest: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+func explicitTaskConfig(t TaskConfig, wkldName string) TaskConfig {
+	if t.Platform.IsEmpty() {
+		t.Platform = PlatformArgsOrString{
+			PlatformString: (*PlatformString)(aws.String(defaultPlatform)),
+		}
+	}
+	if t.ExecuteCommand.Enable == nil && t.ExecuteCommand.Config.IsEmpty() {
+		t.ExecuteCommand.Enable = aws.Bool(false)
+	}
+	t.ContainerName = aws.String(t.MainContainerName(wkldName))
+	t.EnvVarPrefix = aws.String(t.InjectedEnvVarPrefix())
+	return t
+}
+
+func explicitLogging(lc Logging) Logging {
+	if lc.IsEmpty() {
+		// The log router sidecar is only added if logging is configured.
+		return lc
+	}
+	lc.Image = lc.LogImage()
+	enableMetadata, _ := strconv.ParseBool(aws.StringValue(lc.GetEnableMetadata()))
+	lc.EnableMetadata = aws.Bool(enableMetadata)
+	lc.ConfigType = aws.String(lc.FirelensConfigType())
+	return lc
+}
+
+func explicitNetwork(n NetworkConfig) NetworkConfig {
+	if n.VPC.Placement == nil && n.VPC.Subnets.IsEmpty() {
+		placement := PublicSubnetPlacement
+		n.VPC.Placement = &placement
+	}
+	if n.VPC.IPFamily == nil {
+		n.VPC.IPFamily = aws.String(IPFamilyIPv4)
+	}
+	return n
+}
+
+// pruneEmptyNodes removes the keys of the mapping nodes whose values are null or empty collections,
+// and writes the remaining collections in block style.
+func pruneEmptyNodes(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle
+	for _, child := range node.Content {
+		pruneEmptyNodes(child)
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	var content []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if isEmptyNode(node.Content[i+1]) {
+			continue
+		}
+		content = append(content, node.Content[i], node.Content[i+1])
+	}
+	node.Content = content
+}
+
+func isEmptyNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Tag == "!!null"
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(node.Content) == 0
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplicit(t *testing.T) {
+	testCases := map[string]struct {
+		in string
+
+		wanted string
+	}{
+		"writes out the implicit defaults": {
+			in: `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+logging:
+  destination:
+    Name: cloudwatch
+`,
+			wanted: `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+cpu: 256
+memory: 512
+platform: linux/amd64
+count: 1
+exec: false
+container_name: api
+env_var_prefix: COPILOT_
+logging:
+  image: 'amazon/aws-for-fluent-bit:latest'
+  destination:
+    Name: cloudwatch
+  enableMetadata: true
+  configType: fluentbit
+network:
+  vpc:
+    placement: public
+    ip_family: ipv4
+`,
+		},
+		"keeps the configured values over the defaults": {
+			in: `name: worker
+type: Worker Service
+image:
+  location: public.ecr.aws/my-org/worker:v1
+platform: linux/arm64
+exec: true
+container_name: main
+logging:
+  configType: fluentd
+  enableMetadata: false
+network:
+  vpc:
+    placement: private
+`,
+			wanted: `name: worker
+type: Worker Service
+image:
+  location: 'public.ecr.aws/my-org/worker:v1'
+cpu: 256
+memory: 512
+platform: linux/arm64
+count: 1
+exec: true
+container_name: main
+env_var_prefix: COPILOT_
+logging:
+  image: 'fluent/fluentd:latest'
+  enableMetadata: false
+  configType: fluentd
+network:
+  vpc:
+    placement: private
+    ip_family: ipv4
+`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mft, err := UnmarshalWorkload([]byte(tc.in))
+			require.NoError(t, err)
+
+			got, err := Explicit(mft)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, string(got))
+		})
+	}
+}
+
+func TestExplicit_RoundTrip(t *testing.T) {
+	in := `name: frontend
+type: Load Balanced Web Service
+image:
+  build:
+    dockerfile: frontend/Dockerfile
+    args:
+      GO_VERSION: "1.17"
+  port: 8080
+  healthcheck:
+    command: ["CMD-SHELL", "curl -f http://localhost/ || exit 1"]
+http:
+  path: /
+  alias: [example.com, www.example.com]
+  healthcheck: /healthz
+entrypoint: "/bin/sh -c"
+command: [serve, --verbose]
+count:
+  range: 1-10
+  cpu_percentage: 70
+variables:
+  LOG_LEVEL: info
+  BETA_API:
+    value: enabled
+    when: beta
+secrets:
+  GITHUB_TOKEN: GITHUB_TOKEN
+  FEATURE_FLAGS:
+    appconfig:
+      application: my-app
+      environment: prod
+      configuration: flags
+storage:
+  volumes:
+    data:
+      path: /data
+      efs: true
+git_sha_tag: commit
+`
+	mft, err := UnmarshalWorkload([]byte(in))
+	require.NoError(t, err)
+	explicit, err := Explicit(mft)
+	require.NoError(t, err)
+
+	// The explicit manifest is a valid manifest that expands to itself.
+	roundTrip, err := UnmarshalWorkload(explicit)
+	require.NoError(t, err)
+	got, err := Explicit(roundTrip)
+
+	require.NoError(t, err)
+	require.Equal(t, string(explicit), string(got))
+	require.Equal(t, mft.(*LoadBalancedWebService).LoadBalancedWebServiceConfig.TaskConfig.Variables,
+		roundTrip.(*LoadBalancedWebService).LoadBalancedWebServiceConfig.TaskConfig.Variables)
+	require.Equal(t, mft.(*LoadBalancedWebService).LoadBalancedWebServiceConfig.TaskConfig.Secrets,
+		roundTrip.(*LoadBalancedWebService).LoadBalancedWebServiceConfig.TaskConfig.Secrets)
+	require.Equal(t, mft.(*LoadBalancedWebService).LoadBalancedWebServiceConfig.ImageConfig,
+		roundTrip.(*LoadBalancedWebService).LoadBalancedWebServiceConfig.ImageConfig)
+	require.Equal(t, mft.(*LoadBalancedWebService).LoadBalancedWebServiceConfig.Count,
+		roundTrip.(*LoadBalancedWebService).LoadBalancedWebServiceConfig.Count)
+}
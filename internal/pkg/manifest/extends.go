@@ -0,0 +1,122 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const extendsKey = "extends"
+
+// Load returns the manifest document at path with the base documents named by its "extends" key merged under it.
+// The keys of a manifest override the keys of the base it extends, maps are merged key by key, and lists replace
+// the lists of the base instead of being appended to them. A base can extend another base.
+// Paths in "extends" are relative to the directory of the manifest that names them, and are read with read.
+// The document is returned as is if it doesn't extend a base.
+func Load(path string, in []byte, read func(path string) ([]byte, error)) ([]byte, error) {
+	l := extendsLoader{
+		read: read,
+	}
+	doc, err := l.load(filepath.Clean(path), in)
+	if err != nil {
+		return nil, err
+	}
+	if len(l.chain) == 1 {
+		return in, nil
+	}
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal manifest %s: %w", path, err)
+	}
+	return out, nil
+}
+
+type extendsLoader struct {
+	read  func(path string) ([]byte, error)
+	chain []string // Paths of the manifests loaded so far, from the one that extends to its last base.
+}
+
+func (l *extendsLoader) load(path string, in []byte) (map[string]interface{}, error) {
+	for _, loaded := range l.chain {
+		if loaded == path {
+			return nil, fmt.Errorf(`"extends" cycle detected: %s`, strings.Join(append(l.chain, path), " -> "))
+		}
+	}
+	l.chain = append(l.chain, path)
+
+	// Decoding into a map resolves the anchors, aliases and merge keys of the document.
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(in, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshal manifest %s: %w", path, err)
+	}
+	for key, val := range doc {
+		doc[key] = stringKeyedYAML(val)
+	}
+	extends, ok := doc[extendsKey]
+	if !ok {
+		return doc, nil
+	}
+	delete(doc, extendsKey)
+	rel, ok := extends.(string)
+	if !ok || rel == "" {
+		return nil, fmt.Errorf(`"extends" in manifest %s must be the path to a base manifest`, path)
+	}
+	basePath := filepath.Join(filepath.Dir(path), rel)
+	raw, err := l.read(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("read base manifest %s: %w", basePath, err)
+	}
+	base, err := l.load(basePath, raw)
+	if err != nil {
+		return nil, err
+	}
+	return mergeYAMLMaps(base, doc), nil
+}
+
+// stringKeyedYAML returns the decoded YAML value with its maps keyed by strings.
+// Maps built from merge keys are decoded with keys of type interface{}.
+func stringKeyedYAML(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			m[fmt.Sprint(key)] = stringKeyedYAML(elem)
+		}
+		return m
+	case map[string]interface{}:
+		for key, elem := range v {
+			v[key] = stringKeyedYAML(elem)
+		}
+		return v
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = stringKeyedYAML(elem)
+		}
+		return v
+	}
+	return val
+}
+
+// mergeYAMLMaps returns a map with the keys of override merged into the keys of base.
+// The maps aren't modified since aliases of the same anchor share their decoded map.
+func mergeYAMLMaps(base, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(override))
+	for key, val := range base {
+		merged[key] = val
+	}
+	for key, val := range override {
+		baseMap, baseIsMap := merged[key].(map[string]interface{})
+		overrideMap, overrideIsMap := val.(map[string]interface{})
+		if baseIsMap && overrideIsMap {
+			merged[key] = mergeYAMLMaps(baseMap, overrideMap)
+			continue
+		}
+		merged[key] = val
+	}
+	return merged
+}
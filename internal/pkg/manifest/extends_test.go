@@ -0,0 +1,219 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	const base = `type: Backend Service
+image:
+  port: 8080
+cpu: 256
+memory: 512
+variables:
+  LOG_LEVEL: info
+  REGION: us-west-2
+network:
+  vpc:
+    security_groups: [sg-1, sg-2]
+`
+	testCases := map[string]struct {
+		inPath  string
+		inFiles map[string]string
+
+		wanted    string
+		wantedErr error
+	}{
+		"returns the document as is if it doesn't extend a base": {
+			inPath: "copilot/api/manifest.yml",
+			inFiles: map[string]string{
+				"copilot/api/manifest.yml": `name: api
+type: Backend Service
+`,
+			},
+			wanted: `name: api
+type: Backend Service
+`,
+		},
+		"inline keys override the base and lists replace": {
+			inPath: "copilot/api/manifest.yml",
+			inFiles: map[string]string{
+				"copilot/common/base.yml": base,
+				"copilot/api/manifest.yml": `extends: ../common/base.yml
+name: api
+cpu: 512
+variables:
+  LOG_LEVEL: debug
+network:
+  vpc:
+    security_groups: [sg-3]
+`,
+			},
+			wanted: `cpu: 512
+image:
+    port: 8080
+memory: 512
+name: api
+network:
+    vpc:
+        security_groups:
+            - sg-3
+type: Backend Service
+variables:
+    LOG_LEVEL: debug
+    REGION: us-west-2
+`,
+		},
+		"resolves a chain of bases relative to each manifest": {
+			inPath: "copilot/api/manifest.yml",
+			inFiles: map[string]string{
+				"copilot/common/base.yml": base,
+				"copilot/common/backend/base.yml": `extends: ../base.yml
+memory: 1024
+`,
+				"copilot/api/manifest.yml": `extends: ../common/backend/base.yml
+name: api
+`,
+			},
+			wanted: `cpu: 256
+image:
+    port: 8080
+memory: 1024
+name: api
+network:
+    vpc:
+        security_groups:
+            - sg-1
+            - sg-2
+type: Backend Service
+variables:
+    LOG_LEVEL: info
+    REGION: us-west-2
+`,
+		},
+		"resolves anchors and merge keys of each document": {
+			inPath: "copilot/api/manifest.yml",
+			inFiles: map[string]string{
+				"copilot/common/base.yml": `type: Backend Service
+x-defaults: &defaults
+  cpu: 256
+  memory: 512
+environments:
+  test: *defaults
+  staging: *defaults
+  prod:
+    <<: *defaults
+    cpu: 1024
+`,
+				"copilot/api/manifest.yml": `extends: ../common/base.yml
+name: api
+environments:
+  test:
+    cpu: 512
+  prod:
+    memory: 2048
+`,
+			},
+			wanted: `environments:
+    prod:
+        cpu: 1024
+        memory: 2048
+    staging:
+        cpu: 256
+        memory: 512
+    test:
+        cpu: 512
+        memory: 512
+name: api
+type: Backend Service
+x-defaults:
+    cpu: 256
+    memory: 512
+`,
+		},
+		"error if the extends chain has a cycle": {
+			inPath: "copilot/api/manifest.yml",
+			inFiles: map[string]string{
+				"copilot/common/a.yml": `extends: b.yml`,
+				"copilot/common/b.yml": `extends: ../api/manifest.yml`,
+				"copilot/api/manifest.yml": `extends: ../common/a.yml
+name: api
+`,
+			},
+			wantedErr: errors.New(`"extends" cycle detected: copilot/api/manifest.yml -> copilot/common/a.yml -> copilot/common/b.yml -> copilot/api/manifest.yml`),
+		},
+		"error if the base can't be read": {
+			inPath: "copilot/api/manifest.yml",
+			inFiles: map[string]string{
+				"copilot/api/manifest.yml": `extends: ../common/base.yml`,
+			},
+			wantedErr: errors.New("read base manifest copilot/common/base.yml: file copilot/common/base.yml does not exist"),
+		},
+		"error if extends isn't a path": {
+			inPath: "copilot/api/manifest.yml",
+			inFiles: map[string]string{
+				"copilot/api/manifest.yml": `extends: [../common/base.yml]`,
+			},
+			wantedErr: errors.New(`"extends" in manifest copilot/api/manifest.yml must be the path to a base manifest`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			read := func(path string) ([]byte, error) {
+				content, ok := tc.inFiles[path]
+				if !ok {
+					return nil, fmt.Errorf("file %s does not exist", path)
+				}
+				return []byte(content), nil
+			}
+
+			got, err := Load(tc.inPath, []byte(tc.inFiles[tc.inPath]), read)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, string(got))
+		})
+	}
+}
+
+func TestLoad_UnmarshalWorkload(t *testing.T) {
+	files := map[string]string{
+		"copilot/common/base.yml": `type: Backend Service
+image:
+  port: 8080
+cpu: 256
+exec: true
+`,
+		"copilot/api/manifest.yml": `extends: ../common/base.yml
+name: api
+image:
+  location: nginx
+`,
+	}
+	read := func(path string) ([]byte, error) {
+		return []byte(files[path]), nil
+	}
+	loaded, err := Load("copilot/api/manifest.yml", []byte(files["copilot/api/manifest.yml"]), read)
+	require.NoError(t, err)
+
+	mft, err := UnmarshalWorkload(loaded)
+
+	require.NoError(t, err)
+	svc := mft.(*BackendService)
+	require.Equal(t, "api", aws.StringValue(svc.Name))
+	require.Equal(t, "nginx", aws.StringValue(svc.ImageConfig.Image.Location))
+	require.Equal(t, aws.Uint16(8080), svc.ImageConfig.Port)
+	require.Equal(t, 256, aws.IntValue(svc.CPU))
+	require.True(t, svc.ExecuteCommand.Enabled())
+}
@@ -16,6 +16,7 @@ import (
 const (
 	reservedEnvVarKeyForAppName = "COPILOT_APPLICATION_NAME"
 	reservedEnvVarKeyForEnvName = "COPILOT_ENVIRONMENT_NAME"
+	envVarKeyForGitSHA          = "GIT_SHA"
 )
 
 var (
@@ -28,6 +29,11 @@ var (
 // Interpolator substitutes variables in a manifest.
 type Interpolator struct {
 	predefinedEnvVars map[string]string
+
+	gitSHA       func() (string, error)
+	gitSHAValue  string
+	gitSHAErr    error
+	gitSHACalled bool
 }
 
 // NewInterpolator initiates a new Interpolator.
@@ -40,13 +46,20 @@ func NewInterpolator(appName, envName string) *Interpolator {
 	}
 }
 
+// WithGitSHA returns the interpolator with "${GIT_SHA}" substituted by the commit SHA returned by sha,
+// unless the GIT_SHA environment variable is set. sha is called once, only if the manifest references "${GIT_SHA}".
+func (i *Interpolator) WithGitSHA(sha func() (string, error)) *Interpolator {
+	i.gitSHA = sha
+	return i
+}
+
 // Interpolate substitutes environment variables in a string.
 func (i *Interpolator) Interpolate(s string) (string, error) {
 	content, err := unmarshalYAML([]byte(s))
 	if err != nil {
 		return "", err
 	}
-	if err := i.applyInterpolation(content); err != nil {
+	if err := i.applyInterpolation(content, false); err != nil {
 		return "", err
 	}
 	out, err := marshalYAML(content)
@@ -56,26 +69,37 @@ func (i *Interpolator) Interpolate(s string) (string, error) {
 	return string(out), nil
 }
 
-func (i *Interpolator) applyInterpolation(node *yaml.Node) error {
+// applyInterpolation substitutes the variables of every string in node.
+// inBuild is true for nodes under a "build" field, where "${ENV}" is left for BuildConfig to resolve per environment,
+// and "${TAG}" is left in "cache_from" images for the image tag.
+func (i *Interpolator) applyInterpolation(node *yaml.Node, inBuild bool) error {
 	switch node.Tag {
 	case "!!map":
 		// The content of a map always come in pairs. If the node pair exists, return the map node.
 		// Note that the rest of code massively uses yaml node tree.
 		// Please refer to https://www.efekarakus.com/2020/05/30/deep-dive-go-yaml-cfn.html
 		for idx := 0; idx < len(node.Content); idx += 2 {
-			if err := i.applyInterpolation(node.Content[idx+1]); err != nil {
+			key := node.Content[idx].Value
+			if inBuild && key == "cache_from" {
+				if err := i.applyCacheFromInterpolation(node.Content[idx+1]); err != nil {
+					return err
+				}
+				continue
+			}
+			isBuild := inBuild || key == "build"
+			if err := i.applyInterpolation(node.Content[idx+1], isBuild); err != nil {
 				return err
 			}
 		}
 	case "!!str":
-		interpolated, err := i.interpolatePart(node.Value)
+		interpolated, err := i.interpolatePart(node.Value, inBuild)
 		if err != nil {
 			return err
 		}
 		node.Value = interpolated
 	default:
 		for _, content := range node.Content {
-			if err := i.applyInterpolation(content); err != nil {
+			if err := i.applyInterpolation(content, inBuild); err != nil {
 				return err
 			}
 		}
@@ -83,7 +107,25 @@ func (i *Interpolator) applyInterpolation(node *yaml.Node) error {
 	return nil
 }
 
-func (i *Interpolator) interpolatePart(s string) (string, error) {
+// applyCacheFromInterpolation substitutes the variables of the "cache_from" images in node, except for "${ENV}" and "${TAG}".
+func (i *Interpolator) applyCacheFromInterpolation(node *yaml.Node) error {
+	if node.Tag == "!!str" {
+		interpolated, err := i.interpolatePart(node.Value, true, cacheFromTagToken)
+		if err != nil {
+			return err
+		}
+		node.Value = interpolated
+		return nil
+	}
+	for _, content := range node.Content {
+		if err := i.applyCacheFromInterpolation(content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Interpolator) interpolatePart(s string, inBuild bool, skipped ...string) (string, error) {
 	matches := interpolatorEnvVarRegExp.FindAllStringSubmatch(s, -1)
 	if len(matches) == 0 {
 		return s, nil
@@ -93,6 +135,13 @@ func (i *Interpolator) interpolatePart(s string) (string, error) {
 		// https://pkg.go.dev/regexp#Regexp.FindAllStringSubmatch
 		key := match[1]
 		currSegment := fmt.Sprintf("${%s}", key)
+		if inBuild && currSegment == buildEnvToken {
+			// Resolved against the environment name when the image is built.
+			continue
+		}
+		if contains(currSegment, skipped) {
+			continue
+		}
 		predefinedVal, isPredefined := i.predefinedEnvVars[key]
 		osVal, isEnvVarSet := os.LookupEnv(key)
 		if isPredefined && isEnvVarSet && predefinedVal != osVal {
@@ -106,11 +155,33 @@ func (i *Interpolator) interpolatePart(s string) (string, error) {
 			replaced = strings.ReplaceAll(replaced, currSegment, osVal)
 			continue
 		}
+		if key == envVarKeyForGitSHA && i.gitSHA != nil {
+			sha, err := i.resolveGitSHA()
+			if err != nil {
+				return "", fmt.Errorf(`resolve "%s": %w`, currSegment, err)
+			}
+			replaced = strings.ReplaceAll(replaced, currSegment, sha)
+			continue
+		}
 		return "", fmt.Errorf(`environment variable "%s" is not defined`, key)
 	}
 	return replaced, nil
 }
 
+// predefinedEnvVar returns the value of a variable that Copilot defines, such as "COPILOT_ENVIRONMENT_NAME".
+func (i *Interpolator) predefinedEnvVar(key string) (string, bool) {
+	val, ok := i.predefinedEnvVars[key]
+	return val, ok
+}
+
+func (i *Interpolator) resolveGitSHA() (string, error) {
+	if !i.gitSHACalled {
+		i.gitSHAValue, i.gitSHAErr = i.gitSHA()
+		i.gitSHACalled = true
+	}
+	return i.gitSHAValue, i.gitSHAErr
+}
+
 func unmarshalYAML(temp []byte) (*yaml.Node, error) {
 	var node yaml.Node
 	if err := yaml.Unmarshal(temp, &node); err != nil {
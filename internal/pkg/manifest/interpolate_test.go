@@ -32,6 +32,62 @@ func TestInterpolator_Interpolate(t *testing.T) {
 
 			wantedErr: fmt.Errorf(`predefined environment variable "COPILOT_ENVIRONMENT_NAME" cannot be overridden by OS environment variable with the same name`),
 		},
+		"should leave the build environment token untouched under build": {
+			inputStr: `image:
+  build:
+    dockerfile: ./${ENV}/Dockerfile
+    cache_from:
+      - repo/app:${ENV}
+`,
+
+			wanted: `image:
+  build:
+    dockerfile: ./${ENV}/Dockerfile
+    cache_from:
+      - repo/app:${ENV}
+`,
+		},
+		"should leave the tag token untouched in cache_from images": {
+			inputStr: `image:
+  build:
+    cache_from:
+      - repo/app:${TAG}
+      - repo/app:${CACHE}
+    args:
+      VERSION: ${TAG}
+`,
+			inputEnvVar: map[string]string{
+				"TAG":   "local",
+				"CACHE": "latest",
+			},
+
+			wanted: `image:
+  build:
+    cache_from:
+      - repo/app:${TAG}
+      - repo/app:latest
+    args:
+      VERSION: local
+`,
+		},
+		"should leave the build environment token untouched in a build string": {
+			inputStr: "build: ./${ENV}/Dockerfile",
+
+			wanted: "build: ./${ENV}/Dockerfile\n",
+		},
+		"should substitute ENV outside of build": {
+			inputStr: "location: repo/app:${ENV}",
+			inputEnvVar: map[string]string{
+				"ENV": "prod",
+			},
+
+			wanted: "location: repo/app:prod\n",
+		},
+		"should return error if ENV is not defined outside of build": {
+			inputStr: "location: repo/app:${ENV}",
+
+			wantedErr: fmt.Errorf(`environment variable "ENV" is not defined`),
+		},
 		"success with no matches": {
 			inputStr: "1234567890.dkr.ecr.us-west-2.amazonaws.com/vault/test:latest",
 
@@ -118,3 +174,70 @@ variables:
 		})
 	}
 }
+
+func TestInterpolator_WithGitSHA(t *testing.T) {
+	testCases := map[string]struct {
+		inputEnvVar map[string]string
+		inputSHA    func() (string, error)
+		inputStr    string
+
+		wanted    string
+		wantedErr error
+	}{
+		"substitutes the commit SHA": {
+			inputSHA: func() (string, error) {
+				return "abc123", nil
+			},
+			inputStr: `image:
+  labels:
+    org.opencontainers.image.revision: ${GIT_SHA}
+`,
+
+			wanted: `image:
+  labels:
+    org.opencontainers.image.revision: abc123
+`,
+		},
+		"OS environment variable takes precedence over the commit SHA": {
+			inputEnvVar: map[string]string{
+				"GIT_SHA": "fromenv",
+			},
+			inputSHA: func() (string, error) {
+				return "abc123", nil
+			},
+			inputStr: "revision: ${GIT_SHA}",
+
+			wanted: "revision: fromenv\n",
+		},
+		"should return error if the commit SHA can't be resolved": {
+			inputSHA: func() (string, error) {
+				return "", fmt.Errorf("not a git repository")
+			},
+			inputStr: "revision: ${GIT_SHA}",
+
+			wantedErr: fmt.Errorf(`resolve "${GIT_SHA}": not a git repository`),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			itpl := NewInterpolator("myApp", "test").WithGitSHA(tc.inputSHA)
+			for k, v := range tc.inputEnvVar {
+				require.NoError(t, os.Setenv(k, v))
+				defer func(key string) {
+					require.NoError(t, os.Unsetenv(key))
+				}(k)
+			}
+			actual, actualErr := itpl.Interpolate(tc.inputStr)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, actualErr, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, actualErr)
+				require.Equal(t, tc.wanted, actual)
+			}
+		})
+	}
+}
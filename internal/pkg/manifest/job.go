@@ -41,6 +41,7 @@ type ScheduledJobConfig struct {
 	TaskConfig              `yaml:",inline"`
 	Logging                 Logging                   `yaml:"logging,flow"`
 	Sidecars                map[string]*SidecarConfig `yaml:"sidecars"` // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
+	Flags                   Flags                     `yaml:"flags"`
 	On                      JobTriggerConfig          `yaml:"on,flow"`
 	JobFailureHandlerConfig `yaml:",inline"`
 	Network                 NetworkConfig  `yaml:"network"`
@@ -105,6 +106,8 @@ func (j *ScheduledJob) MarshalBinary() ([]byte, error) {
 func (j ScheduledJob) ApplyEnv(envName string) (WorkloadManifest, error) {
 	overrideConfig, ok := j.Environments[envName]
 	if !ok {
+		j.Sidecars, j.Variables = applyFlags(j.Flags, j.Sidecars, j.Variables)
+		j.ImageOverride = j.ImageOverride.withEnvName(envName)
 		return &j, nil
 	}
 
@@ -118,6 +121,8 @@ func (j ScheduledJob) ApplyEnv(envName string) (WorkloadManifest, error) {
 		}
 	}
 	j.Environments = nil
+	j.Sidecars, j.Variables = applyFlags(j.Flags, j.Sidecars, j.Variables)
+	j.ImageOverride = j.ImageOverride.withEnvName(envName)
 	return &j, nil
 }
 
@@ -126,9 +131,15 @@ func (j *ScheduledJob) Publish() []Topic {
 	return j.ScheduledJobConfig.PublishConfig.Topics
 }
 
-// BuildArgs returns a docker.BuildArguments object for the job given a workspace root.
-func (j *ScheduledJob) BuildArgs(wsRoot string) *DockerBuildArgs {
-	return j.ImageConfig.Image.BuildConfig(wsRoot)
+// NetworkConfig returns the network configuration of the tasks of the job.
+func (j *ScheduledJob) NetworkConfig() NetworkConfig {
+	return j.Network
+}
+
+// BuildArgs returns the docker.BuildArguments objects, one per build platform, for the job given a workspace root and an environment name.
+// hostPlatform is the "os/arch" of the Docker engine that builds the image, if known.
+func (j *ScheduledJob) BuildArgs(wsRoot, envName, hostPlatform string) ([]*DockerBuildArgs, error) {
+	return j.ImageConfig.Image.BuildConfigWithPlatform(wsRoot, envName, hostPlatform)
 }
 
 // BuildRequired returns if the service requires building from the local Dockerfile.
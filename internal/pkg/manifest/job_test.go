@@ -148,9 +148,9 @@ func TestScheduledJob_ApplyEnv(t *testing.T) {
 				Environments: map[string]*ScheduledJobConfig{
 					"prod": {
 						TaskConfig: TaskConfig{
-							Variables: map[string]string{
+							Variables: Variables{Values: map[string]string{
 								"LOG_LEVEL": "prod",
-							},
+							}},
 						},
 					},
 				},
@@ -181,9 +181,9 @@ func TestScheduledJob_ApplyEnv(t *testing.T) {
 						Count: Count{
 							Value: aws.Int(1),
 						},
-						Variables: map[string]string{
+						Variables: Variables{Values: map[string]string{
 							"LOG_LEVEL": "prod",
-						},
+						}},
 					},
 					Network: NetworkConfig{
 						VPC: vpcConfig{
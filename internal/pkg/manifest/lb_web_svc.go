@@ -5,6 +5,7 @@ package manifest
 
 import (
 	"errors"
+	"reflect"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -56,10 +57,18 @@ type LoadBalancedWebServiceConfig struct {
 	TaskConfig       `yaml:",inline"`
 	Logging          `yaml:"logging,flow"`
 	Sidecars         map[string]*SidecarConfig        `yaml:"sidecars"` // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
+	Flags            Flags                            `yaml:"flags"`
 	Network          NetworkConfig                    `yaml:"network"`
 	PublishConfig    PublishConfig                    `yaml:"publish"`
 	TaskDefOverrides []OverrideRule                   `yaml:"taskdef_overrides"`
 	NLBConfig        NetworkLoadBalancerConfiguration `yaml:"nlb"`
+	AZRebalancing    *bool                            `yaml:"availability_zone_rebalancing"`
+	PropagateTags    *string                          `yaml:"propagate_tags"`
+	GitSHATag        GitSHATag                        `yaml:"git_sha_tag"`
+	DeployConfig     DeploymentConfig                 `yaml:"deployment"`
+	InitContainer    InitContainer                    `yaml:"init_container"`
+	Observability    Observability                    `yaml:"observability"`
+	Readiness        Readiness                        `yaml:"readiness"`
 }
 
 // LoadBalancedWebServiceProps contains properties for creating a new load balanced fargate service manifest.
@@ -152,25 +161,65 @@ func (s *LoadBalancedWebService) Publish() []Topic {
 	return s.LoadBalancedWebServiceConfig.PublishConfig.Topics
 }
 
+// PreDeployTask returns the task to run to completion before the service is updated.
+func (s *LoadBalancedWebService) PreDeployTask() PreDeployTask {
+	return s.LoadBalancedWebServiceConfig.DeployConfig.PreDeploy
+}
+
+// PreDeployTaskConfig returns the task configuration of the service, such as its size and variables,
+// that the pre-deploy task inherits.
+func (s *LoadBalancedWebService) PreDeployTaskConfig() TaskConfig {
+	return s.LoadBalancedWebServiceConfig.TaskConfig
+}
+
+// GitSHATagConfig returns the option to tag the service with the git commit SHA it's deployed from.
+func (s *LoadBalancedWebService) GitSHATagConfig() GitSHATag {
+	return s.GitSHATag
+}
+
+// NetworkConfig returns the network configuration of the tasks of the service.
+func (s *LoadBalancedWebService) NetworkConfig() NetworkConfig {
+	return s.Network
+}
+
+// InitContainerConfig returns the container to run to completion before the main container starts.
+func (s *LoadBalancedWebService) InitContainerConfig() InitContainer {
+	return s.LoadBalancedWebServiceConfig.InitContainer
+}
+
+// ReadinessCheck returns the endpoint to poll once the service is deployed.
+func (s *LoadBalancedWebService) ReadinessCheck() Readiness {
+	return s.LoadBalancedWebServiceConfig.Readiness
+}
+
+// BlueGreenDeployment returns the configuration of the CodeDeploy blue/green deployments of the service.
+func (s *LoadBalancedWebService) BlueGreenDeployment() BlueGreenConfigOrBool {
+	return s.DeployConfig.BlueGreen
+}
+
 // BuildRequired returns if the service requires building from the local Dockerfile.
 func (s *LoadBalancedWebService) BuildRequired() (bool, error) {
 	return requiresBuild(s.ImageConfig.Image)
 }
 
-// BuildArgs returns a docker.BuildArguments object given a ws root directory.
-func (s *LoadBalancedWebService) BuildArgs(wsRoot string) *DockerBuildArgs {
-	return s.ImageConfig.Image.BuildConfig(wsRoot)
+// LoadCollectorConfig reads the configuration file of the OpenTelemetry collector of the service, if any.
+func (s *LoadBalancedWebService) LoadCollectorConfig(read func(path string) ([]byte, error)) error {
+	return s.Observability.loadCollectorConfig(read)
+}
+
+// BuildArgs returns the docker.BuildArguments objects, one per build platform, given a ws root directory and an environment name.
+// hostPlatform is the "os/arch" of the Docker engine that builds the image, if known.
+func (s *LoadBalancedWebService) BuildArgs(wsRoot, envName, hostPlatform string) ([]*DockerBuildArgs, error) {
+	return s.ImageConfig.Image.BuildConfigWithPlatform(wsRoot, envName, hostPlatform)
 }
 
 // ApplyEnv returns the service manifest with environment overrides.
 // If the environment passed in does not have any overrides then it returns itself.
 func (s LoadBalancedWebService) ApplyEnv(envName string) (WorkloadManifest, error) {
 	overrideConfig, ok := s.Environments[envName]
-	if !ok {
-		return &s, nil
-	}
-
-	if overrideConfig == nil {
+	if !ok || overrideConfig == nil {
+		s.Sidecars, s.TaskConfig.Variables = applyFlags(s.Flags, s.Sidecars, s.TaskConfig.Variables)
+		s.ImageOverride = s.ImageOverride.withEnvName(envName)
 		return &s, nil
 	}
 
@@ -186,6 +235,8 @@ func (s LoadBalancedWebService) ApplyEnv(envName string) (WorkloadManifest, erro
 	}
 
 	s.Environments = nil
+	s.Sidecars, s.TaskConfig.Variables = applyFlags(s.Flags, s.Sidecars, s.TaskConfig.Variables)
+	s.ImageOverride = s.ImageOverride.withEnvName(envName)
 	return &s, nil
 }
 
@@ -200,7 +251,22 @@ type RoutingRule struct {
 	// TargetContainer is the container load balancer routes traffic to.
 	TargetContainer          *string `yaml:"target_container"`
 	TargetContainerCamelCase *string `yaml:"targetContainer"` // "targetContainerCamelCase" for backwards compatibility
-	AllowedSourceIps         []IPNet `yaml:"allowed_source_ips"`
+	// TargetPort is the port of the target container that receives the traffic. Defaults to the port the container exposes.
+	TargetPort       *uint16 `yaml:"target_port"`
+	AllowedSourceIps []IPNet `yaml:"allowed_source_ips"`
+	// RedirectToHTTPS redirects the HTTP traffic to HTTPS. Defaults to true if the load balancer has a certificate.
+	RedirectToHTTPS *bool `yaml:"redirect_to_https"`
+	// HostnameVariable is the name of the environment variable that holds the public hostname of the service.
+	HostnameVariable *string      `yaml:"hostname_variable"`
+	AliasRouting     AliasRouting `yaml:"alias_routing"`
+	// AdditionalRules routes the requests of other paths to the service, evaluated in order before the rule of "path".
+	// Only their "path", "alias" and "allowed_source_ips" fields can be specified.
+	AdditionalRules []RoutingRule `yaml:"additional_rules"`
+}
+
+// IsEmpty returns true if no field of the routing rule is set.
+func (r RoutingRule) IsEmpty() bool {
+	return reflect.DeepEqual(r, RoutingRule{})
 }
 
 func (r *RoutingRule) targetContainer() *string {
@@ -220,39 +286,156 @@ type NetworkLoadBalancerConfiguration struct {
 	TargetContainer *string                 `yaml:"target_container"`
 	TargetPort      *int                    `yaml:"target_port"`
 	SSLPolicy       *string                 `yaml:"ssl_policy"`
+
+	// Attributes of the network load balancer's target group.
+	Stickiness       *bool `yaml:"stickiness"`
+	PreserveClientIP *bool `yaml:"preserve_client_ip"`
+	ProxyProtocolV2  *bool `yaml:"proxy_protocol_v2"`
+
+	// Listeners on other ports than "port", which remains the first listener of the network load balancer.
+	AdditionalListeners []NetworkLoadBalancerListener `yaml:"additional_listeners"`
+}
+
+// NetworkLoadBalancerListener holds options for an additional listener of a network load balancer.
+type NetworkLoadBalancerListener struct {
+	Port            *string                 `yaml:"port"`
+	TargetPort      *int                    `yaml:"target_port"`
+	TargetContainer *string                 `yaml:"target_container"`
+	HealthCheck     HealthCheckArgsOrString `yaml:"healthcheck"`
+	Stickiness      *bool                   `yaml:"stickiness"`
 }
 
 func (c *NetworkLoadBalancerConfiguration) IsEmpty() bool {
-	return c.Port == nil && c.HealthCheck.IsEmpty() && c.TargetContainer == nil && c.TargetPort == nil && c.SSLPolicy == nil
+	return c.Port == nil && c.HealthCheck.IsEmpty() && c.TargetContainer == nil && c.TargetPort == nil && c.SSLPolicy == nil &&
+		c.Stickiness == nil && c.PreserveClientIP == nil && c.ProxyProtocolV2 == nil && len(c.AdditionalListeners) == 0
+}
+
+// Route 53 routing policies for the records of "http.alias".
+const (
+	AliasRoutingPolicyWeighted = "weighted"
+	AliasRoutingPolicyFailover = "failover"
+
+	AliasFailoverPrimary   = "primary"
+	AliasFailoverSecondary = "secondary"
+)
+
+var (
+	aliasRoutingPolicies = []string{AliasRoutingPolicyWeighted, AliasRoutingPolicyFailover}
+	aliasFailoverRoles   = []string{AliasFailoverPrimary, AliasFailoverSecondary}
+)
+
+// AliasRouting holds the Route 53 routing policy for records of "http.alias" created in a hosted zone
+// that isn't managed by Copilot, such as weighted or failover records spanning multiple regions.
+type AliasRouting struct {
+	HostedZone    *string `yaml:"hosted_zone"`
+	Policy        *string `yaml:"policy"`
+	SetIdentifier *string `yaml:"set_identifier"`
+	Weight        *int    `yaml:"weight"`
+	Failover      *string `yaml:"failover"`
+	HealthCheck   *string `yaml:"health_check"`
+}
+
+// IsEmpty returns true if AliasRouting is not configured.
+func (a AliasRouting) IsEmpty() bool {
+	return a.HostedZone == nil && a.Policy == nil && a.SetIdentifier == nil && a.Weight == nil &&
+		a.Failover == nil && a.HealthCheck == nil
 }
 
 // IPNet represents an IP network string. For example: 10.1.0.0/16
 type IPNet string
 
 // Alias is a custom type which supports unmarshaling "http.alias" yaml which
-// can either be of type string or type slice of string.
-type Alias stringSliceOrString
+// can either be of type string, type slice of string, or type slice of AdvancedAlias.
+type Alias struct {
+	String          *string
+	StringSlice     []string
+	AdvancedAliases []AdvancedAlias
+}
+
+// AdvancedAlias is an alias of the service along with the hosted zone in which its record is created.
+type AdvancedAlias struct {
+	Alias      *string `yaml:"name"`
+	HostedZone *string `yaml:"hosted_zone"`
+}
 
 // IsEmpty returns empty if Alias is empty.
 func (e *Alias) IsEmpty() bool {
-	return e.String == nil && e.StringSlice == nil
+	return e.String == nil && e.StringSlice == nil && e.AdvancedAliases == nil
 }
 
 // UnmarshalYAML overrides the default YAML unmarshaling logic for the Alias
 // struct, allowing it to perform more complex unmarshaling behavior.
 // This method implements the yaml.Unmarshaler (v3) interface.
 func (e *Alias) UnmarshalYAML(value *yaml.Node) error {
-	if err := unmarshalYAMLToStringSliceOrString((*stringSliceOrString)(e), value); err != nil {
+	if err := value.Decode(&e.AdvancedAliases); err != nil {
+		switch err.(type) {
+		case *yaml.TypeError:
+			// The decoder fills the slice with zero values for the entries that aren't maps.
+			e.AdvancedAliases = nil
+		default:
+			return err
+		}
+	}
+	if e.AdvancedAliases != nil {
+		// Unmarshaled successfully to e.AdvancedAliases, unset the other forms, and return.
+		e.String = nil
+		e.StringSlice = nil
+		return nil
+	}
+
+	var ss stringSliceOrString
+	if err := unmarshalYAMLToStringSliceOrString(&ss, value); err != nil {
 		return errUnmarshalAlias
 	}
+	e.String, e.StringSlice = ss.String, ss.StringSlice
 	return nil
 }
 
+// MarshalYAML writes the Alias back in the form it was written in, a string, a slice of strings,
+// or a slice of aliases with their hosted zone.
+// This method implements the yaml.Marshaler (v3) interface.
+func (e Alias) MarshalYAML() (interface{}, error) {
+	if e.AdvancedAliases != nil {
+		return e.AdvancedAliases, nil
+	}
+	return marshalYAMLFromStringSliceOrString(stringSliceOrString{
+		String:      e.String,
+		StringSlice: e.StringSlice,
+	}), nil
+}
+
 // ToStringSlice converts an Alias to a slice of string using shell-style rules.
 func (e *Alias) ToStringSlice() ([]string, error) {
-	out, err := toStringSlice((*stringSliceOrString)(e))
+	if e.AdvancedAliases != nil {
+		out := make([]string, len(e.AdvancedAliases))
+		for i, alias := range e.AdvancedAliases {
+			out[i] = aws.StringValue(alias.Alias)
+		}
+		return out, nil
+	}
+	out, err := toStringSlice(&stringSliceOrString{
+		String:      e.String,
+		StringSlice: e.StringSlice,
+	})
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
+
+// HostedZoneAliases returns the aliases grouped by the ID of the hosted zone in which their records are created.
+// Aliases without a hosted zone are omitted as their records are managed by Copilot.
+func (e *Alias) HostedZoneAliases() map[string][]string {
+	out := make(map[string][]string)
+	for _, alias := range e.AdvancedAliases {
+		if alias.HostedZone == nil {
+			continue
+		}
+		hostedZone := aws.StringValue(alias.HostedZone)
+		out[hostedZone] = append(out[hostedZone], aws.StringValue(alias.Alias))
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
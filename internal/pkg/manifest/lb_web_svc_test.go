@@ -404,13 +404,13 @@ func TestLoadBalancedWebService_ApplyEnv(t *testing.T) {
 						Count: Count{
 							Value: aws.Int(1),
 						},
-						Variables: map[string]string{
+						Variables: Variables{Values: map[string]string{
 							"LOG_LEVEL":      "DEBUG",
 							"DDB_TABLE_NAME": "awards",
-						},
-						Secrets: map[string]string{
-							"GITHUB_TOKEN": "1111",
-							"TWILIO_TOKEN": "1111",
+						}},
+						Secrets: map[string]Secret{
+							"GITHUB_TOKEN": {From: aws.String("1111")},
+							"TWILIO_TOKEN": {From: aws.String("1111")},
 						},
 						Storage: Storage{
 							Volumes: map[string]*Volume{
@@ -445,7 +445,7 @@ func TestLoadBalancedWebService_ApplyEnv(t *testing.T) {
 					Network: NetworkConfig{
 						VPC: vpcConfig{
 							Placement:      &PublicSubnetPlacement,
-							SecurityGroups: []string{"sg-123"},
+							SecurityGroups: []SecurityGroup{{ID: aws.String("sg-123")}},
 						},
 					},
 				},
@@ -471,9 +471,9 @@ func TestLoadBalancedWebService_ApplyEnv(t *testing.T) {
 							Count: Count{
 								Value: aws.Int(0),
 							},
-							Variables: map[string]string{
+							Variables: Variables{Values: map[string]string{
 								"DDB_TABLE_NAME": "awards-prod",
-							},
+							}},
 							Storage: Storage{
 								Volumes: map[string]*Volume{
 									"myEFSVolume": {
@@ -510,7 +510,7 @@ func TestLoadBalancedWebService_ApplyEnv(t *testing.T) {
 						},
 						Network: NetworkConfig{
 							VPC: vpcConfig{
-								SecurityGroups: []string{"sg-456", "sg-789"},
+								SecurityGroups: []SecurityGroup{{ID: aws.String("sg-456")}, {ID: aws.String("sg-789")}},
 							},
 						},
 					},
@@ -549,13 +549,13 @@ func TestLoadBalancedWebService_ApplyEnv(t *testing.T) {
 						Count: Count{
 							Value: aws.Int(0),
 						},
-						Variables: map[string]string{
+						Variables: Variables{Values: map[string]string{
 							"LOG_LEVEL":      "DEBUG",
 							"DDB_TABLE_NAME": "awards-prod",
-						},
-						Secrets: map[string]string{
-							"GITHUB_TOKEN": "1111",
-							"TWILIO_TOKEN": "1111",
+						}},
+						Secrets: map[string]Secret{
+							"GITHUB_TOKEN": {From: aws.String("1111")},
+							"TWILIO_TOKEN": {From: aws.String("1111")},
 						},
 						Storage: Storage{
 							Volumes: map[string]*Volume{
@@ -602,7 +602,7 @@ func TestLoadBalancedWebService_ApplyEnv(t *testing.T) {
 					Network: NetworkConfig{
 						VPC: vpcConfig{
 							Placement:      &PublicSubnetPlacement,
-							SecurityGroups: []string{"sg-456", "sg-789"},
+							SecurityGroups: []SecurityGroup{{ID: aws.String("sg-456")}, {ID: aws.String("sg-789")}},
 						},
 					},
 				},
@@ -673,7 +673,7 @@ func TestLoadBalancedWebService_ApplyEnv(t *testing.T) {
 					Network: NetworkConfig{
 						VPC: vpcConfig{
 							Placement:      &PublicSubnetPlacement,
-							SecurityGroups: []string{"sg-456", "sg-789"},
+							SecurityGroups: []SecurityGroup{{ID: aws.String("sg-456")}, {ID: aws.String("sg-789")}},
 						},
 					},
 				},
@@ -697,7 +697,7 @@ func TestLoadBalancedWebService_ApplyEnv(t *testing.T) {
 					Network: NetworkConfig{
 						VPC: vpcConfig{
 							Placement:      &PublicSubnetPlacement,
-							SecurityGroups: []string{"sg-456", "sg-789"},
+							SecurityGroups: []SecurityGroup{{ID: aws.String("sg-456")}, {ID: aws.String("sg-789")}},
 						},
 					},
 				},
@@ -1395,6 +1395,93 @@ func TestAlias_IsEmpty(t *testing.T) {
 	}
 }
 
+func TestAlias_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct Alias
+		wantedError  error
+	}{
+		"alias specified in string": {
+			inContent: []byte(`alias: example.com`),
+			wantedStruct: Alias{
+				String: aws.String("example.com"),
+			},
+		},
+		"alias specified in slice of strings": {
+			inContent: []byte(`alias: [example.com, www.example.com]`),
+			wantedStruct: Alias{
+				StringSlice: []string{"example.com", "www.example.com"},
+			},
+		},
+		"alias specified in slice of aliases with a hosted zone": {
+			inContent: []byte(`alias:
+  - name: example.com
+    hosted_zone: Z0873220N255IR3MTNR4
+  - name: tenant.example.org
+    hosted_zone: Z23ABC4XYZL05B`),
+			wantedStruct: Alias{
+				AdvancedAliases: []AdvancedAlias{
+					{
+						Alias:      aws.String("example.com"),
+						HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+					},
+					{
+						Alias:      aws.String("tenant.example.org"),
+						HostedZone: aws.String("Z23ABC4XYZL05B"),
+					},
+				},
+			},
+		},
+		"error if unmarshalable": {
+			inContent: []byte(`alias:
+  name: example.com`),
+			wantedError: errUnmarshalAlias,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := RoutingRule{}
+
+			err := yaml.Unmarshal(tc.inContent, &r)
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, r.Alias)
+		})
+	}
+}
+
+func TestAlias_HostedZoneAliases(t *testing.T) {
+	in := Alias{
+		AdvancedAliases: []AdvancedAlias{
+			{
+				Alias:      aws.String("example.com"),
+				HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+			},
+			{
+				Alias: aws.String("api.example.com"),
+			},
+			{
+				Alias:      aws.String("www.example.com"),
+				HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+			},
+			{
+				Alias:      aws.String("tenant.example.org"),
+				HostedZone: aws.String("Z23ABC4XYZL05B"),
+			},
+		},
+	}
+
+	require.Equal(t, map[string][]string{
+		"Z0873220N255IR3MTNR4": {"example.com", "www.example.com"},
+		"Z23ABC4XYZL05B":       {"tenant.example.org"},
+	}, in.HostedZoneAliases())
+}
+
 func TestNetworkLoadBalancerConfiguration_IsEmpty(t *testing.T) {
 	testCases := map[string]struct {
 		in     NetworkLoadBalancerConfiguration
@@ -0,0 +1,172 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"gopkg.in/yaml.v3"
+)
+
+// Names of the rules of an organization policy.
+const (
+	PolicyRuleAllowedRegions  = "allowed_regions"
+	PolicyRuleRequiredTags    = "required_tags"
+	PolicyRuleForbidLatestTag = "forbid_latest_tag"
+	PolicyRuleMaxCPU          = "max_cpu"
+)
+
+// Policy represents the rules of an organization policy file that a workload must comply with to be deployed.
+type Policy struct {
+	AllowedRegions  []string `yaml:"allowed_regions"`
+	RequiredTags    []string `yaml:"required_tags"`
+	ForbidLatestTag bool     `yaml:"forbid_latest_tag"`
+	MaxCPU          *int     `yaml:"max_cpu"`
+}
+
+// Violation is a rule of a Policy that a workload doesn't comply with.
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+// String implements the fmt.Stringer interface.
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+}
+
+// UnmarshalPolicy deserializes the YAML input of an organization policy file into a Policy.
+func UnmarshalPolicy(in []byte) (*Policy, error) {
+	var p Policy
+	if err := yaml.Unmarshal(in, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal policy: %w", err)
+	}
+	return &p, nil
+}
+
+// policyRule returns the violations of a rule of the policy by the effective manifest of a workload.
+type policyRule func(wl policyWorkload, p Policy) []Violation
+
+// policyRules are the rules evaluated by EvaluatePolicy, in order.
+var policyRules = []policyRule{
+	evaluateForbidLatestTag,
+	evaluateMaxCPU,
+}
+
+// EvaluatePolicy returns the violations of the policy by the effective manifest of a workload,
+// that is the manifest with its environment overrides applied.
+// Rules that depend on the deployment target, such as the region, are evaluated by Policy.EvaluateTarget.
+func EvaluatePolicy(wl WorkloadManifest, policy Policy) []Violation {
+	in, ok := newPolicyWorkload(wl)
+	if !ok {
+		return nil
+	}
+	var violations []Violation
+	for _, rule := range policyRules {
+		violations = append(violations, rule(in, policy)...)
+	}
+	return violations
+}
+
+// EvaluateTarget returns the violations of the policy by the region a workload is deployed to,
+// and by the tags applied to its resources.
+func (p Policy) EvaluateTarget(region string, tags map[string]string) []Violation {
+	var violations []Violation
+	if len(p.AllowedRegions) != 0 && !contains(region, p.AllowedRegions) {
+		violations = append(violations, Violation{
+			Rule:    PolicyRuleAllowedRegions,
+			Message: fmt.Sprintf("region %s is not allowed", region),
+		})
+	}
+	for _, key := range p.RequiredTags {
+		if _, ok := tags[key]; !ok {
+			violations = append(violations, Violation{
+				Rule:    PolicyRuleRequiredTags,
+				Message: fmt.Sprintf("tag %q is required", key),
+			})
+		}
+	}
+	return violations
+}
+
+// policyWorkload holds the fields of a workload manifest that policy rules are evaluated against.
+type policyWorkload struct {
+	images map[string]string // Image locations keyed by container name.
+	cpu    *int
+}
+
+func newPolicyWorkload(wl WorkloadManifest) (policyWorkload, bool) {
+	var (
+		name     string
+		image    Image
+		cpu      *int
+		sidecars map[string]*SidecarConfig
+		logging  Logging
+	)
+	switch t := wl.(type) {
+	case *LoadBalancedWebService:
+		name, image, cpu, sidecars, logging = t.MainContainerName(aws.StringValue(t.Name)), t.ImageConfig.Image, t.CPU, t.Sidecars, t.Logging
+	case *BackendService:
+		name, image, cpu, sidecars, logging = t.MainContainerName(aws.StringValue(t.Name)), t.ImageConfig.Image, t.CPU, t.Sidecars, t.Logging
+	case *WorkerService:
+		name, image, cpu, sidecars, logging = t.MainContainerName(aws.StringValue(t.Name)), t.ImageConfig.Image, t.CPU, t.Sidecars, t.Logging
+	case *ScheduledJob:
+		name, image, cpu, sidecars, logging = t.MainContainerName(aws.StringValue(t.Name)), t.ImageConfig.Image, t.CPU, t.Sidecars, t.Logging
+	case *RequestDrivenWebService:
+		name, image, cpu = aws.StringValue(t.Name), t.ImageConfig.Image, t.InstanceConfig.CPU
+	default:
+		return policyWorkload{}, false
+	}
+	images := make(map[string]string)
+	if image.Location != nil {
+		images[name] = aws.StringValue(image.Location)
+	}
+	for sidecarName, sidecar := range sidecars {
+		if sidecar.Image != nil {
+			images[sidecarName] = aws.StringValue(sidecar.Image)
+		}
+	}
+	if logging.Image != nil {
+		images[firelensContainerName] = aws.StringValue(logging.Image)
+	}
+	return policyWorkload{
+		images: images,
+		cpu:    cpu,
+	}, true
+}
+
+func evaluateForbidLatestTag(wl policyWorkload, p Policy) []Violation {
+	if !p.ForbidLatestTag {
+		return nil
+	}
+	names := make([]string, 0, len(wl.images))
+	for name := range wl.images {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var violations []Violation
+	for _, name := range names {
+		if err := validatePinnedImageTag(wl.images[name]); err != nil {
+			violations = append(violations, Violation{
+				Rule:    PolicyRuleForbidLatestTag,
+				Message: fmt.Sprintf("container %s: %s", name, err),
+			})
+		}
+	}
+	return violations
+}
+
+func evaluateMaxCPU(wl policyWorkload, p Policy) []Violation {
+	if p.MaxCPU == nil || wl.cpu == nil || aws.IntValue(wl.cpu) <= aws.IntValue(p.MaxCPU) {
+		return nil
+	}
+	return []Violation{
+		{
+			Rule:    PolicyRuleMaxCPU,
+			Message: fmt.Sprintf("cpu %d exceeds the maximum of %d", aws.IntValue(wl.cpu), aws.IntValue(p.MaxCPU)),
+		},
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalPolicy(t *testing.T) {
+	in := `allowed_regions: [us-west-2, eu-west-1]
+required_tags:
+  - cost-center
+forbid_latest_tag: true
+max_cpu: 1024
+`
+	got, err := UnmarshalPolicy([]byte(in))
+
+	require.NoError(t, err)
+	require.Equal(t, &Policy{
+		AllowedRegions:  []string{"us-west-2", "eu-west-1"},
+		RequiredTags:    []string{"cost-center"},
+		ForbidLatestTag: true,
+		MaxCPU:          aws.Int(1024),
+	}, got)
+}
+
+func TestEvaluatePolicy(t *testing.T) {
+	policy := Policy{
+		ForbidLatestTag: true,
+		MaxCPU:          aws.Int(1024),
+	}
+	testCases := map[string]struct {
+		in     WorkloadManifest
+		wanted []Violation
+	}{
+		"compliant manifest": {
+			in: &BackendService{
+				Workload: Workload{Name: aws.String("api")},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: ImageWithHealthcheckAndOptionalPort{
+						ImageWithOptionalPort: ImageWithOptionalPort{
+							Image: Image{
+								Location: aws.String("public.ecr.aws/my-org/api:v1.2.0"),
+							},
+						},
+					},
+					TaskConfig: TaskConfig{
+						CPU: aws.Int(512),
+					},
+					Sidecars: map[string]*SidecarConfig{
+						"envoy": {
+							Image: aws.String("envoyproxy/envoy@sha256:9b7f2fc5f6e1ac7b3c8d7e4d9b1b3fc5c8a2e0e1f4d9a7f6c3b2a1e0d9c8b7a6"),
+						},
+					},
+				},
+			},
+		},
+		"manifest with multiple violations": {
+			in: &LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("frontend")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: ImageWithPortAndHealthcheck{
+						ImageWithPort: ImageWithPort{
+							Image: Image{
+								Location: aws.String("nginx:latest"),
+							},
+						},
+					},
+					TaskConfig: TaskConfig{
+						CPU:           aws.Int(2048),
+						ContainerName: aws.String("web"),
+					},
+					Sidecars: map[string]*SidecarConfig{
+						"xray": {
+							Image: aws.String("amazon/aws-xray-daemon"),
+						},
+					},
+				},
+			},
+			wanted: []Violation{
+				{
+					Rule:    PolicyRuleForbidLatestTag,
+					Message: `container web: image "nginx:latest" must be pinned to a specific tag or digest instead of "latest"`,
+				},
+				{
+					Rule:    PolicyRuleForbidLatestTag,
+					Message: `container xray: image "amazon/aws-xray-daemon" must be pinned to a specific tag or digest`,
+				},
+				{
+					Rule:    PolicyRuleMaxCPU,
+					Message: "cpu 2048 exceeds the maximum of 1024",
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, EvaluatePolicy(tc.in, policy))
+		})
+	}
+}
+
+func TestPolicy_EvaluateTarget(t *testing.T) {
+	policy := Policy{
+		AllowedRegions: []string{"us-west-2"},
+		RequiredTags:   []string{"cost-center", "owner"},
+	}
+	testCases := map[string]struct {
+		inRegion string
+		inTags   map[string]string
+
+		wanted []Violation
+	}{
+		"compliant target": {
+			inRegion: "us-west-2",
+			inTags: map[string]string{
+				"cost-center": "1234",
+				"owner":       "payments",
+			},
+		},
+		"target with multiple violations": {
+			inRegion: "us-east-1",
+			inTags: map[string]string{
+				"owner": "payments",
+			},
+			wanted: []Violation{
+				{
+					Rule:    PolicyRuleAllowedRegions,
+					Message: "region us-east-1 is not allowed",
+				},
+				{
+					Rule:    PolicyRuleRequiredTags,
+					Message: `tag "cost-center" is required`,
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, policy.EvaluateTarget(tc.inRegion, tc.inTags))
+		})
+	}
+}
@@ -27,11 +27,13 @@ type RequestDrivenWebServiceConfig struct {
 	RequestDrivenWebServiceHttpConfig `yaml:"http,flow"`
 	InstanceConfig                    AppRunnerInstanceConfig              `yaml:",inline"`
 	ImageConfig                       ImageWithPort                        `yaml:"image"`
-	Variables                         map[string]string                    `yaml:"variables"`
+	Variables                         Variables                            `yaml:"variables"`
+	Flags                             Flags                                `yaml:"flags"`
 	StartCommand                      *string                              `yaml:"command"`
 	Tags                              map[string]string                    `yaml:"tags"`
 	PublishConfig                     PublishConfig                        `yaml:"publish"`
 	Network                           RequestDrivenWebServiceNetworkConfig `yaml:"network"`
+	AutoScaling                       AppRunnerAutoScalingConfig           `yaml:"auto_scaling"`
 }
 
 // RequestDrivenWebServiceNetworkConfig represents options for network connection to AWS resources for a Request-Driven Web Service.
@@ -68,6 +70,18 @@ type AppRunnerInstanceConfig struct {
 	Platform PlatformArgsOrString `yaml:"platform,omitempty"`
 }
 
+// AppRunnerAutoScalingConfig contains the auto scaling configuration properties for an App Runner service.
+type AppRunnerAutoScalingConfig struct {
+	MinSize        *int `yaml:"min_size"`
+	MaxSize        *int `yaml:"max_size"`
+	MaxConcurrency *int `yaml:"max_concurrency"`
+}
+
+// IsEmpty returns true if none of the auto scaling fields are set.
+func (c AppRunnerAutoScalingConfig) IsEmpty() bool {
+	return c.MinSize == nil && c.MaxSize == nil && c.MaxConcurrency == nil
+}
+
 // RequestDrivenWebServiceProps contains properties for creating a new request-driven web service manifest.
 type RequestDrivenWebServiceProps struct {
 	*WorkloadProps
@@ -121,16 +135,23 @@ func (s *RequestDrivenWebService) ContainerPlatform() string {
 	return platformString(s.InstanceConfig.Platform.OS(), s.InstanceConfig.Platform.Arch())
 }
 
-// BuildArgs returns a docker.BuildArguments object given a ws root directory.
-func (s *RequestDrivenWebService) BuildArgs(wsRoot string) *DockerBuildArgs {
-	return s.ImageConfig.Image.BuildConfig(wsRoot)
+// LoadVariables reads the variables file of the service, if any, and merges its values under the inline variables.
+func (s *RequestDrivenWebService) LoadVariables(read func(path string) ([]byte, error)) error {
+	return s.Variables.load(read)
+}
+
+// BuildArgs returns the docker.BuildArguments objects, one per build platform, given a ws root directory and an environment name.
+// hostPlatform is the "os/arch" of the Docker engine that builds the image, if known.
+func (s *RequestDrivenWebService) BuildArgs(wsRoot, envName, hostPlatform string) ([]*DockerBuildArgs, error) {
+	return s.ImageConfig.Image.BuildConfigWithPlatform(wsRoot, envName, hostPlatform)
 }
 
 // ApplyEnv returns the service manifest with environment overrides.
 // If the environment passed in does not have any overrides then it returns itself.
 func (s RequestDrivenWebService) ApplyEnv(envName string) (WorkloadManifest, error) {
 	overrideConfig, ok := s.Environments[envName]
-	if !ok {
+	if !ok || overrideConfig == nil {
+		_, s.Variables = applyFlags(s.Flags, nil, s.Variables)
 		return &s, nil
 	}
 	// Apply overrides to the original service configuration.
@@ -144,6 +165,7 @@ func (s RequestDrivenWebService) ApplyEnv(envName string) (WorkloadManifest, err
 	}
 
 	s.Environments = nil
+	_, s.Variables = applyFlags(s.Flags, nil, s.Variables)
 	return &s, nil
 }
 
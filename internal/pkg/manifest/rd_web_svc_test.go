@@ -169,10 +169,10 @@ func TestRequestDrivenWebService_UnmarshalYaml(t *testing.T) {
 
 			wantedStruct: RequestDrivenWebService{
 				RequestDrivenWebServiceConfig: RequestDrivenWebServiceConfig{
-					Variables: map[string]string{
+					Variables: Variables{Values: map[string]string{
 						"LOG_LEVEL": "info",
 						"NODE_ENV":  "development",
-					},
+					}},
 				},
 			},
 		},
@@ -575,6 +575,48 @@ func TestRequestDrivenWebService_ApplyEnv(t *testing.T) {
 				},
 			},
 		},
+		"with variables gated by a flag that the environment turns off": {
+			in: &RequestDrivenWebService{
+				Workload: Workload{
+					Name: aws.String("phonetool"),
+					Type: aws.String(RequestDrivenWebServiceType),
+				},
+				RequestDrivenWebServiceConfig: RequestDrivenWebServiceConfig{
+					Variables: Variables{
+						Values: map[string]string{
+							"LOG_LEVEL":   "info",
+							"CHECKOUT_V2": "on",
+						},
+						When: map[string]string{
+							"CHECKOUT_V2": "checkout",
+						},
+					},
+					Flags: Flags{"checkout": true},
+				},
+				Environments: map[string]*RequestDrivenWebServiceConfig{
+					"prod-iad": {
+						Flags: Flags{"checkout": false},
+					},
+				},
+			},
+			envToApply: "prod-iad",
+
+			wanted: &RequestDrivenWebService{
+				Workload: Workload{
+					Name: aws.String("phonetool"),
+					Type: aws.String(RequestDrivenWebServiceType),
+				},
+				RequestDrivenWebServiceConfig: RequestDrivenWebServiceConfig{
+					Variables: Variables{
+						Values: map[string]string{
+							"LOG_LEVEL": "info",
+						},
+						When: map[string]string{},
+					},
+					Flags: Flags{"checkout": false},
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
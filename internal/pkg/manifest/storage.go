@@ -61,13 +61,15 @@ type EFSVolumeConfiguration struct {
 	FileSystemID  *string             `yaml:"id"`       // Required. Can be specified as "copilot" or "managed" magic keys.
 	RootDirectory *string             `yaml:"root_dir"` // Default "/". For BYO EFS.
 	AuthConfig    AuthorizationConfig `yaml:"auth"`     // Auth config for BYO EFS.
+	Managed       *bool               `yaml:"managed"`  // Let Copilot create the file system and access point.
 	UID           *uint32             `yaml:"uid"`      // UID for managed EFS.
 	GID           *uint32             `yaml:"gid"`      // GID for managed EFS.
 }
 
 // IsEmpty returns empty if the struct has all zero members.
 func (e *EFSVolumeConfiguration) IsEmpty() bool {
-	return e.FileSystemID == nil && e.RootDirectory == nil && e.AuthConfig.IsEmpty() && e.UID == nil && e.GID == nil
+	return e.FileSystemID == nil && e.RootDirectory == nil && e.AuthConfig.IsEmpty() && e.Managed == nil &&
+		e.UID == nil && e.GID == nil
 }
 
 // EFSConfigOrBool contains custom unmarshaling logic for the `efs` field in the manifest.
@@ -112,12 +114,27 @@ func (e *EFSConfigOrBool) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
-// UseManagedFS returns true if the user has specified EFS as a bool, or has only specified UID and GID.
+// MarshalYAML writes the EFSConfigOrBool back as a map if it has advanced fields, or as a boolean otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (e EFSConfigOrBool) MarshalYAML() (interface{}, error) {
+	if !e.Advanced.IsEmpty() {
+		return e.Advanced, nil
+	}
+	if e.Enabled != nil {
+		return aws.BoolValue(e.Enabled), nil
+	}
+	return nil, nil
+}
+
+// UseManagedFS returns true if the user has specified EFS as a bool, set "managed", or has only specified UID and GID.
 func (e *EFSConfigOrBool) UseManagedFS() bool {
 	// Respect explicitly enabled or disabled value first.
 	if e.Enabled != nil {
 		return aws.BoolValue(e.Enabled)
 	}
+	if e.Advanced.Managed != nil {
+		return aws.BoolValue(e.Advanced.Managed)
+	}
 	// Check whether we're implicitly enabling managed EFS via UID/GID.
 	return !e.Advanced.EmptyUIDConfig()
 }
@@ -155,7 +172,17 @@ func (e *EFSVolumeConfiguration) unsetUIDConfig() {
 	e.GID = nil
 }
 
+func (e *EFSVolumeConfiguration) unsetManaged() {
+	e.Managed = nil
+}
+
 func (e *EFSVolumeConfiguration) isValid() error {
+	if aws.BoolValue(e.Managed) && !e.EmptyBYOConfig() {
+		return &errFieldMutualExclusive{
+			firstField:  "managed",
+			secondField: "id/root_dir/auth",
+		}
+	}
 	if !e.EmptyBYOConfig() && !e.EmptyUIDConfig() {
 		return &errFieldMutualExclusive{
 			firstField:  "uid/gid",
@@ -85,6 +85,33 @@ efs:
   id: 1`),
 			wantErr: `must specify one, not both, of "uid/gid" and "id/root_dir/auth"`,
 		},
+		"with managed shorthand": {
+			manifest: []byte(`
+efs:
+  managed: true`),
+			want: testVolume{
+				EFS: EFSConfigOrBool{
+					Advanced: EFSVolumeConfiguration{
+						Managed: aws.Bool(true),
+					},
+				},
+			},
+		},
+		"managed with an explicit id": {
+			manifest: []byte(`
+efs:
+  managed: true
+  id: fs-12345`),
+			wantErr: `must specify one, not both, of "managed" and "id/root_dir/auth"`,
+		},
+		"managed with an explicit access point": {
+			manifest: []byte(`
+efs:
+  managed: true
+  auth:
+    access_point_id: fsap-1234`),
+			wantErr: `must specify one, not both, of "managed" and "id/root_dir/auth"`,
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -103,6 +130,7 @@ efs:
 				require.Equal(t, tc.want.EFS.Advanced.AuthConfig, v.EFS.Advanced.AuthConfig)
 				require.Equal(t, tc.want.EFS.Advanced.UID, v.EFS.Advanced.UID)
 				require.Equal(t, tc.want.EFS.Advanced.GID, v.EFS.Advanced.GID)
+				require.Equal(t, tc.want.EFS.Advanced.Managed, v.EFS.Advanced.Managed)
 			} else {
 				require.EqualError(t, err, tc.wantErr)
 			}
@@ -110,6 +138,50 @@ efs:
 	}
 }
 
+func TestStorage_UnmarshalEphemeral(t *testing.T) {
+	testCases := map[string]struct {
+		manifest      []byte
+		wantEphemeral *int
+		wantErr       string
+	}{
+		"unset ephemeral keeps the Fargate default": {
+			manifest: []byte(`
+volumes: {}`),
+		},
+		"ephemeral within range": {
+			manifest: []byte(`
+ephemeral: 100`),
+			wantEphemeral: aws.Int(100),
+		},
+		"ephemeral below the minimum": {
+			manifest: []byte(`
+ephemeral: 20`),
+			wantEphemeral: aws.Int(20),
+			wantErr:       `validate "ephemeral": ephemeral storage must be between 21 GiB and 200 GiB`,
+		},
+		"ephemeral above the maximum": {
+			manifest: []byte(`
+ephemeral: 201`),
+			wantEphemeral: aws.Int(201),
+			wantErr:       `validate "ephemeral": ephemeral storage must be between 21 GiB and 200 GiB`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var s Storage
+			require.NoError(t, yaml.Unmarshal(tc.manifest, &s))
+			require.Equal(t, tc.wantEphemeral, s.Ephemeral)
+
+			err := s.Validate()
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_EmptyVolume(t *testing.T) {
 	testCases := map[string]struct {
 		in   EFSConfigOrBool
@@ -198,6 +270,23 @@ func Test_UseManagedFS(t *testing.T) {
 			},
 			want: false,
 		},
+		"with managed set": {
+			in: EFSConfigOrBool{
+				Advanced: EFSVolumeConfiguration{
+					Managed: aws.Bool(true),
+				},
+			},
+			want: true,
+		},
+		"with managed set to false": {
+			in: EFSConfigOrBool{
+				Advanced: EFSVolumeConfiguration{
+					Managed:      aws.Bool(false),
+					FileSystemID: aws.String("fs-12345"),
+				},
+			},
+			want: false,
+		},
 		"with uid/gid set": {
 			in: EFSConfigOrBool{
 				Advanced: EFSVolumeConfiguration{
@@ -27,6 +27,10 @@ const (
 	WorkerServiceType = "Worker Service"
 )
 
+// PropagateTagsStack propagates the tags of the CloudFormation stack to the tasks of a service.
+// The tasks of a service always carry the tags of the ECS service, so it's the only valid source of "propagate_tags".
+const PropagateTagsStack = "stack"
+
 // ServiceTypes are the supported service manifest types.
 var ServiceTypes = []string{
 	RequestDrivenWebServiceType,
@@ -35,6 +39,70 @@ var ServiceTypes = []string{
 	WorkerServiceType,
 }
 
+// DefaultGitSHATagKey is the key of the tag that holds the git commit SHA of a deployment if "git_sha_tag" is true.
+const DefaultGitSHATagKey = "copilot-git-sha"
+
+// GitSHATag represents the option to tag a service and its tasks with the git commit SHA it's deployed from.
+// It is either a boolean to use the DefaultGitSHATagKey, or the key of the tag.
+type GitSHATag struct {
+	Enabled *bool
+	Key     *string // Mutually exclusive with Enabled.
+}
+
+// IsEmpty returns whether GitSHATag is empty.
+func (t *GitSHATag) IsEmpty() bool {
+	return t.Enabled == nil && t.Key == nil
+}
+
+// TagKey returns the key of the tag holding the git commit SHA, and whether the tag is enabled.
+func (t *GitSHATag) TagKey() (string, bool) {
+	if t.Key != nil {
+		return aws.StringValue(t.Key), true
+	}
+	return DefaultGitSHATagKey, aws.BoolValue(t.Enabled)
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the GitSHATag
+// struct, allowing it to be specified as a boolean or a string.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (t *GitSHATag) UnmarshalYAML(value *yaml.Node) error {
+	var enabled bool
+	if err := value.Decode(&enabled); err != nil {
+		switch err.(type) {
+		case *yaml.TypeError:
+			break
+		default:
+			return err
+		}
+	} else {
+		// Unmarshaled successfully to a boolean, unset t.Key, and return.
+		t.Enabled = aws.Bool(enabled)
+		t.Key = nil
+		return nil
+	}
+	if err := value.Decode(&t.Key); err != nil {
+		return errUnmarshalGitSHATag
+	}
+	return nil
+}
+
+// MarshalYAML writes the GitSHATag back as a string if it has a custom key, or as a boolean otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (t GitSHATag) MarshalYAML() (interface{}, error) {
+	if t.Key != nil {
+		return aws.StringValue(t.Key), nil
+	}
+	if t.Enabled != nil {
+		return aws.BoolValue(t.Enabled), nil
+	}
+	return nil, nil
+}
+
+// Statistics of a CloudWatch metric that a custom autoscaling metric can track.
+const defaultCustomMetricStatistic = "Average"
+
+var customMetricStatistics = []string{defaultCustomMetricStatistic, "Minimum", "Maximum", "SampleCount", "Sum"}
+
 // Range contains either a Range or a range configuration for Autoscaling ranges.
 type Range struct {
 	Value       *IntRangeBand // Mutually exclusive with RangeConfig
@@ -79,6 +147,18 @@ func (r *Range) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// MarshalYAML writes the Range back as a map if it has advanced fields, or as a range band string otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (r Range) MarshalYAML() (interface{}, error) {
+	if !r.RangeConfig.IsEmpty() {
+		return r.RangeConfig, nil
+	}
+	if r.Value != nil {
+		return string(*r.Value), nil
+	}
+	return nil, nil
+}
+
 // IntRangeBand is a number range with maximum and minimum values.
 type IntRangeBand string
 
@@ -139,7 +219,7 @@ func (c *Count) UnmarshalYAML(value *yaml.Node) error {
 	if c.AdvancedCount.Spot != nil && (c.AdvancedCount.hasAutoscaling()) {
 		return &errFieldMutualExclusive{
 			firstField:  "spot",
-			secondField: "range/cpu_percentage/memory_percentage/requests/response_time/queue_delay",
+			secondField: "range/cpu_percentage/memory_percentage/requests/response_time/queue_delay/custom_metric",
 		}
 	}
 
@@ -154,6 +234,18 @@ func (c *Count) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// MarshalYAML writes the Count back as a map if it has advanced fields, or as a number otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (c Count) MarshalYAML() (interface{}, error) {
+	if !c.AdvancedCount.IsEmpty() {
+		return c.AdvancedCount, nil
+	}
+	if c.Value != nil {
+		return aws.IntValue(c.Value), nil
+	}
+	return nil, nil
+}
+
 // IsEmpty returns whether Count is empty.
 func (c *Count) IsEmpty() bool {
 	return c.Value == nil && c.AdvancedCount.IsEmpty()
@@ -188,6 +280,11 @@ type AdvancedCount struct {
 	Requests     *int           `yaml:"requests"`
 	ResponseTime *time.Duration `yaml:"response_time"`
 	QueueScaling QueueScaling   `yaml:"queue_delay"`
+	Cooldown     Cooldown       `yaml:"cooldown"`
+	CustomMetric CustomMetric   `yaml:"custom_metric"`
+
+	Scheduled         []ScheduledScaling `yaml:"scheduled"`          // Requires range.
+	CapacityProviders CapacityProviders  `yaml:"capacity_providers"` // mutually exclusive with spot and range.spot_from
 
 	workloadType string
 }
@@ -195,7 +292,8 @@ type AdvancedCount struct {
 // IsEmpty returns whether AdvancedCount is empty.
 func (a *AdvancedCount) IsEmpty() bool {
 	return a.Range.IsEmpty() && a.CPU == nil && a.Memory == nil &&
-		a.Requests == nil && a.ResponseTime == nil && a.Spot == nil && a.QueueScaling.IsEmpty()
+		a.Requests == nil && a.ResponseTime == nil && a.Spot == nil && a.QueueScaling.IsEmpty() && a.Cooldown.IsEmpty() &&
+		a.CustomMetric.IsEmpty() && a.CapacityProviders.IsEmpty() && len(a.Scheduled) == 0
 }
 
 // IgnoreRange returns whether desiredCount is specified on spot capacity
@@ -207,12 +305,26 @@ func (a *AdvancedCount) hasAutoscaling() bool {
 	return !a.Range.IsEmpty() || a.hasScalingFieldsSet()
 }
 
+// isLoadBalanced returns true if the workload can sit behind an application load balancer.
+// Backend services are only behind the internal load balancer if they set "http", which BackendServiceConfig validates.
+func (a *AdvancedCount) isLoadBalanced() bool {
+	return a.workloadType == LoadBalancedWebServiceType || a.workloadType == BackendServiceType
+}
+
 func (a *AdvancedCount) validScalingFields() []string {
+	fields := a.builtInScalingFields()
+	if len(fields) == 0 {
+		return nil
+	}
+	return append(fields, "custom_metric")
+}
+
+func (a *AdvancedCount) builtInScalingFields() []string {
 	switch a.workloadType {
 	case LoadBalancedWebServiceType:
 		return []string{"cpu_percentage", "memory_percentage", "requests", "response_time"}
 	case BackendServiceType:
-		return []string{"cpu_percentage", "memory_percentage"}
+		return []string{"cpu_percentage", "memory_percentage", "requests", "response_time"}
 	case WorkerServiceType:
 		return []string{"cpu_percentage", "memory_percentage", "queue_delay"}
 	default:
@@ -221,11 +333,15 @@ func (a *AdvancedCount) validScalingFields() []string {
 }
 
 func (a *AdvancedCount) hasScalingFieldsSet() bool {
+	return a.hasBuiltInScalingFieldsSet() || !a.CustomMetric.IsEmpty()
+}
+
+func (a *AdvancedCount) hasBuiltInScalingFieldsSet() bool {
 	switch a.workloadType {
 	case LoadBalancedWebServiceType:
 		return a.CPU != nil || a.Memory != nil || a.Requests != nil || a.ResponseTime != nil
 	case BackendServiceType:
-		return a.CPU != nil || a.Memory != nil
+		return a.CPU != nil || a.Memory != nil || a.Requests != nil || a.ResponseTime != nil
 	case WorkerServiceType:
 		return a.CPU != nil || a.Memory != nil || !a.QueueScaling.IsEmpty()
 	default:
@@ -235,6 +351,13 @@ func (a *AdvancedCount) hasScalingFieldsSet() bool {
 
 func (a *AdvancedCount) unsetAutoscaling() {
 	a.Range = Range{}
+	a.unsetBuiltInScalingFields()
+	a.Cooldown = Cooldown{}
+	a.CustomMetric = CustomMetric{}
+	a.Scheduled = nil
+}
+
+func (a *AdvancedCount) unsetBuiltInScalingFields() {
 	a.CPU = nil
 	a.Memory = nil
 	a.Requests = nil
@@ -242,6 +365,92 @@ func (a *AdvancedCount) unsetAutoscaling() {
 	a.QueueScaling = QueueScaling{}
 }
 
+// CapacityProviders represents the split of the tasks of a service between on-demand and spot Fargate capacity.
+type CapacityProviders struct {
+	OnDemand CapacityProviderStrategy `yaml:"on_demand"`
+	Spot     CapacityProviderStrategy `yaml:"spot"`
+}
+
+// IsEmpty returns true if CapacityProviders is not set.
+func (c *CapacityProviders) IsEmpty() bool {
+	return c.OnDemand.IsEmpty() && c.Spot.IsEmpty()
+}
+
+// CapacityProviderStrategy represents the share of the tasks placed on a Fargate capacity provider.
+// Base is the minimum number of tasks on the capacity provider, and the tasks beyond the bases
+// are split between the capacity providers in proportion to their weights.
+type CapacityProviderStrategy struct {
+	Base   *int `yaml:"base"`
+	Weight *int `yaml:"weight"`
+}
+
+// IsEmpty returns true if CapacityProviderStrategy is not set.
+func (c *CapacityProviderStrategy) IsEmpty() bool {
+	return c.Base == nil && c.Weight == nil
+}
+
+// CustomMetric represents a CloudWatch metric that a target tracking policy keeps at the target value.
+type CustomMetric struct {
+	Namespace  *string           `yaml:"namespace"`
+	MetricName *string           `yaml:"metric_name"`
+	Dimensions map[string]string `yaml:"dimensions"`
+	Statistic  *string           `yaml:"statistic"`
+	Target     *float64          `yaml:"target"`
+}
+
+// IsEmpty returns true if the CustomMetric is not set.
+func (m *CustomMetric) IsEmpty() bool {
+	return m.Namespace == nil && m.MetricName == nil && m.Dimensions == nil && m.Statistic == nil && m.Target == nil
+}
+
+// MetricStatistic returns the statistic of the metric to track, "Average" if not otherwise configured.
+func (m *CustomMetric) MetricStatistic() string {
+	if m.Statistic == nil {
+		return defaultCustomMetricStatistic
+	}
+	return aws.StringValue(m.Statistic)
+}
+
+// Cooldown represents the amount of time to wait between scaling activities of the autoscaling policies.
+// It can be a single duration applied to both scale-in and scale-out, or a map with a duration for each.
+type Cooldown struct {
+	ScaleInCooldown  *time.Duration `yaml:"scale_in_cooldown"`
+	ScaleOutCooldown *time.Duration `yaml:"scale_out_cooldown"`
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the Cooldown
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (c *Cooldown) UnmarshalYAML(value *yaml.Node) error {
+	type cooldown Cooldown // Alias to decode the map without recursing into UnmarshalYAML.
+	var config cooldown
+	if err := value.Decode(&config); err != nil {
+		switch err.(type) {
+		case *yaml.TypeError:
+			break
+		default:
+			return err
+		}
+	}
+	if cd := Cooldown(config); !cd.IsEmpty() {
+		*c = cd
+		return nil
+	}
+
+	var duration time.Duration
+	if err := value.Decode(&duration); err != nil {
+		return errUnmarshalCooldown
+	}
+	c.ScaleInCooldown = durationp(duration)
+	c.ScaleOutCooldown = durationp(duration)
+	return nil
+}
+
+// IsEmpty returns true if neither cooldown is set.
+func (c *Cooldown) IsEmpty() bool {
+	return c.ScaleInCooldown == nil && c.ScaleOutCooldown == nil
+}
+
 // QueueScaling represents the configuration to scale a service based on a SQS queue.
 type QueueScaling struct {
 	AcceptableLatency *time.Duration `yaml:"acceptable_latency"`
@@ -283,6 +492,7 @@ func IsTypeAService(t string) bool {
 // See https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-resource-elasticloadbalancingv2-targetgroup.html.
 type HTTPHealthCheckArgs struct {
 	Path               *string        `yaml:"path"`
+	Port               *uint16        `yaml:"port"` // Defaults to the port that receives the traffic.
 	SuccessCodes       *string        `yaml:"success_codes"`
 	HealthyThreshold   *int64         `yaml:"healthy_threshold"`
 	UnhealthyThreshold *int64         `yaml:"unhealthy_threshold"`
@@ -292,7 +502,7 @@ type HTTPHealthCheckArgs struct {
 }
 
 func (h *HTTPHealthCheckArgs) isEmpty() bool {
-	return h.Path == nil && h.HealthyThreshold == nil && h.UnhealthyThreshold == nil &&
+	return h.Path == nil && h.Port == nil && h.SuccessCodes == nil && h.HealthyThreshold == nil && h.UnhealthyThreshold == nil &&
 		h.Interval == nil && h.Timeout == nil && h.GracePeriod == nil
 }
 
@@ -328,6 +538,18 @@ func (hc *HealthCheckArgsOrString) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// MarshalYAML writes the HealthCheckArgsOrString back as a map if it has advanced fields, or as a path otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (hc HealthCheckArgsOrString) MarshalYAML() (interface{}, error) {
+	if !hc.HealthCheckArgs.isEmpty() {
+		return hc.HealthCheckArgs, nil
+	}
+	if hc.HealthCheckPath != nil {
+		return aws.StringValue(hc.HealthCheckPath), nil
+	}
+	return nil, nil
+}
+
 // IsEmpty returns true if there are no health check configuration set.
 func (hc *HealthCheckArgsOrString) IsEmpty() bool {
 	if hc.HealthCheckPath != nil {
@@ -121,11 +121,11 @@ environments:
 							ExecuteCommand: ExecuteCommand{
 								Enable: aws.Bool(true),
 							},
-							Variables: map[string]string{
+							Variables: Variables{Values: map[string]string{
 								"LOG_LEVEL": "WARN",
-							},
-							Secrets: map[string]string{
-								"DB_PASSWORD": "MYSQL_DB_PASSWORD",
+							}},
+							Secrets: map[string]Secret{
+								"DB_PASSWORD": {From: aws.String("MYSQL_DB_PASSWORD")},
 							},
 						},
 						Sidecars: map[string]*SidecarConfig{
@@ -262,8 +262,8 @@ secrets:
 							ExecuteCommand: ExecuteCommand{
 								Enable: aws.Bool(false),
 							},
-							Secrets: map[string]string{
-								"API_TOKEN": "SUBS_API_TOKEN",
+							Secrets: map[string]Secret{
+								"API_TOKEN": {From: aws.String("SUBS_API_TOKEN")},
 							},
 						},
 						Network: NetworkConfig{
@@ -423,6 +423,16 @@ func TestCount_UnmarshalYAML(t *testing.T) {
 				},
 			},
 		},
+		"With range specified as a bare number": {
+			inContent: []byte(`count:
+  range: 5
+`),
+			wantedStruct: Count{
+				AdvancedCount: AdvancedCount{
+					Range: Range{Value: (*IntRangeBand)(aws.String("5"))},
+				},
+			},
+		},
 		"With spot specified as count": {
 			inContent: []byte(`count:
   spot: 42
@@ -433,6 +443,33 @@ func TestCount_UnmarshalYAML(t *testing.T) {
 				},
 			},
 		},
+		"With an on-demand and spot split": {
+			inContent: []byte(`count:
+  range: 1-10
+  cpu_percentage: 70
+  capacity_providers:
+    on_demand:
+      base: 2
+      weight: 1
+    spot:
+      weight: 3
+`),
+			wantedStruct: Count{
+				AdvancedCount: AdvancedCount{
+					Range: Range{Value: &mockRange},
+					CPU:   &mockCPU,
+					CapacityProviders: CapacityProviders{
+						OnDemand: CapacityProviderStrategy{
+							Base:   aws.Int(2),
+							Weight: aws.Int(1),
+						},
+						Spot: CapacityProviderStrategy{
+							Weight: aws.Int(3),
+						},
+					},
+				},
+			},
+		},
 		"With range specified as min-max": {
 			inContent: []byte(`count:
   range:
@@ -471,6 +508,76 @@ func TestCount_UnmarshalYAML(t *testing.T) {
 				},
 			},
 		},
+		"With a single cooldown applied to both scale-in and scale-out": {
+			inContent: []byte(`count:
+  range: 1-10
+  cpu_percentage: 70
+  cooldown: 30s
+`),
+			wantedStruct: Count{
+				AdvancedCount: AdvancedCount{
+					Range: Range{Value: &mockRange},
+					CPU:   &mockCPU,
+					Cooldown: Cooldown{
+						ScaleInCooldown:  durationp(30 * time.Second),
+						ScaleOutCooldown: durationp(30 * time.Second),
+					},
+				},
+			},
+		},
+		"With separate scale-in and scale-out cooldowns": {
+			inContent: []byte(`count:
+  range: 1-10
+  cpu_percentage: 70
+  cooldown:
+    scale_in_cooldown: 5m
+    scale_out_cooldown: 30s
+`),
+			wantedStruct: Count{
+				AdvancedCount: AdvancedCount{
+					Range: Range{Value: &mockRange},
+					CPU:   &mockCPU,
+					Cooldown: Cooldown{
+						ScaleInCooldown:  durationp(5 * time.Minute),
+						ScaleOutCooldown: durationp(30 * time.Second),
+					},
+				},
+			},
+		},
+		"With a custom metric": {
+			inContent: []byte(`count:
+  range: 1-10
+  custom_metric:
+    namespace: AWS/SQS
+    metric_name: ApproximateNumberOfMessagesVisible
+    dimensions:
+      QueueName: orders
+    statistic: Sum
+    target: 100
+`),
+			wantedStruct: Count{
+				AdvancedCount: AdvancedCount{
+					Range: Range{Value: &mockRange},
+					CustomMetric: CustomMetric{
+						Namespace:  aws.String("AWS/SQS"),
+						MetricName: aws.String("ApproximateNumberOfMessagesVisible"),
+						Dimensions: map[string]string{
+							"QueueName": "orders",
+						},
+						Statistic: aws.String("Sum"),
+						Target:    aws.Float64(100),
+					},
+				},
+			},
+		},
+		"Error if cooldown is not a duration": {
+			inContent: []byte(`count:
+  range: 1-10
+  cpu_percentage: 70
+  cooldown: often
+`),
+			wantedError: errUnmarshalCooldown,
+		},
 
 		"Error if mutually exclusive fields are specified": {
 			inContent: []byte(`count:
@@ -479,7 +586,7 @@ func TestCount_UnmarshalYAML(t *testing.T) {
 `),
 			wantedError: &errFieldMutualExclusive{
 				firstField:  "spot",
-				secondField: "range/cpu_percentage/memory_percentage/requests/response_time/queue_delay",
+				secondField: "range/cpu_percentage/memory_percentage/requests/response_time/queue_delay/custom_metric",
 			},
 		},
 		"Error if unmarshalable": {
@@ -719,6 +826,71 @@ func TestCount_Desired(t *testing.T) {
 	}
 }
 
+func TestHealthCheckArgsOrString_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct HealthCheckArgsOrString
+		wantedError  error
+	}{
+		"string form maps to the path only": {
+			inContent: []byte(`healthcheck: /_health`),
+			wantedStruct: HealthCheckArgsOrString{
+				HealthCheckPath: aws.String("/_health"),
+			},
+		},
+		"object form with all fields": {
+			inContent: []byte(`healthcheck:
+  path: /_health
+  port: 8081
+  success_codes: '200,301'
+  healthy_threshold: 3
+  unhealthy_threshold: 4
+  interval: 15s
+  timeout: 10s
+  grace_period: 45s`),
+			wantedStruct: HealthCheckArgsOrString{
+				HealthCheckArgs: HTTPHealthCheckArgs{
+					Path:               aws.String("/_health"),
+					Port:               aws.Uint16(8081),
+					SuccessCodes:       aws.String("200,301"),
+					HealthyThreshold:   aws.Int64(3),
+					UnhealthyThreshold: aws.Int64(4),
+					Interval:           durationp(15 * time.Second),
+					Timeout:            durationp(10 * time.Second),
+					GracePeriod:        durationp(45 * time.Second),
+				},
+			},
+		},
+		"object form with only success codes": {
+			inContent: []byte(`healthcheck:
+  success_codes: '200-299'`),
+			wantedStruct: HealthCheckArgsOrString{
+				HealthCheckArgs: HTTPHealthCheckArgs{
+					SuccessCodes: aws.String("200-299"),
+				},
+			},
+		},
+		"error if unmarshalable": {
+			inContent: []byte(`healthcheck:
+  - /_health`),
+			wantedError: errUnmarshalHealthCheckArgs,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var rule RoutingRule
+			err := yaml.Unmarshal(tc.inContent, &rule)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, rule.HealthCheck)
+		})
+	}
+}
+
 func TestHealthCheckArgsOrString_IsEmpty(t *testing.T) {
 	testCases := map[string]struct {
 		hc     HealthCheckArgsOrString
@@ -807,3 +979,59 @@ func TestQueueScaling_AcceptableBacklogPerTask(t *testing.T) {
 		})
 	}
 }
+
+func TestGitSHATag_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct GitSHATag
+		wantedKey    string
+		wantedOn     bool
+		wantedError  error
+	}{
+		"enabled with the default key": {
+			inContent: []byte(`git_sha_tag: true`),
+
+			wantedStruct: GitSHATag{Enabled: aws.Bool(true)},
+			wantedKey:    "copilot-git-sha",
+			wantedOn:     true,
+		},
+		"disabled": {
+			inContent: []byte(`git_sha_tag: false`),
+
+			wantedStruct: GitSHATag{Enabled: aws.Bool(false)},
+			wantedKey:    "copilot-git-sha",
+		},
+		"user-named key": {
+			inContent: []byte(`git_sha_tag: build-sha`),
+
+			wantedStruct: GitSHATag{Key: aws.String("build-sha")},
+			wantedKey:    "build-sha",
+			wantedOn:     true,
+		},
+		"error if unmarshalable": {
+			inContent: []byte(`git_sha_tag:
+  key: build-sha`),
+
+			wantedError: errUnmarshalGitSHATag,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var s struct {
+				GitSHATag GitSHATag `yaml:"git_sha_tag"`
+			}
+			err := yaml.Unmarshal(tc.inContent, &s)
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, s.GitSHATag)
+			key, on := s.GitSHATag.TagKey()
+			require.Equal(t, tc.wantedKey, key)
+			require.Equal(t, tc.wantedOn, on)
+		})
+	}
+}
@@ -6,7 +6,9 @@ package manifest
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/imdario/mergo"
 )
 
@@ -19,6 +21,7 @@ var defaultTransformers = []mergo.Transformers{
 	imageTransformer{},
 	buildArgsOrStringTransformer{},
 	stringSliceOrStringTransformer{},
+	aliasTransformer{},
 	platformArgsOrStringTransformer{},
 	healthCheckArgsOrStringTransformer{},
 	countTransformer{},
@@ -27,6 +30,14 @@ var defaultTransformers = []mergo.Transformers{
 	efsConfigOrBoolTransformer{},
 	efsVolumeConfigurationTransformer{},
 	sqsQueueOrBoolTransformer{},
+	executeCommandTransformer{},
+	blueGreenConfigOrBoolTransformer{},
+	alarmArgsOrNamesTransformer{},
+	flagsTransformer{},
+	gitSHATagTransformer{},
+	subnetListOrArgsTransformer{},
+	vpcConfigTransformer{},
+	variablesTransformer{},
 }
 
 // See a complete list of `reflect.Kind` here: https://pkg.go.dev/reflect#Kind.
@@ -122,6 +133,39 @@ func (t stringSliceOrStringTransformer) Transformer(typ reflect.Type) func(dst,
 	}
 }
 
+type aliasTransformer struct{}
+
+// Transformer returns custom merge logic for Alias's fields.
+func (t aliasTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(Alias{}) {
+		return nil
+	}
+
+	return func(dst, src reflect.Value) error {
+		dstStruct, srcStruct := dst.Interface().(Alias), src.Interface().(Alias)
+
+		if srcStruct.String != nil {
+			dstStruct.StringSlice = nil
+			dstStruct.AdvancedAliases = nil
+		}
+
+		if srcStruct.StringSlice != nil {
+			dstStruct.String = nil
+			dstStruct.AdvancedAliases = nil
+		}
+
+		if srcStruct.AdvancedAliases != nil {
+			dstStruct.String = nil
+			dstStruct.StringSlice = nil
+		}
+
+		if dst.CanSet() { // For extra safety to prevent panicking.
+			dst.Set(reflect.ValueOf(dstStruct))
+		}
+		return nil
+	}
+}
+
 type platformArgsOrStringTransformer struct{}
 
 // Transformer returns custom merge logic for PlatformArgsOrString's fields.
@@ -138,6 +182,17 @@ func (t platformArgsOrStringTransformer) Transformer(typ reflect.Type) func(dst,
 		}
 
 		if !srcStruct.PlatformArgs.isEmpty() {
+			if dstStruct.PlatformString != nil && !srcStruct.PlatformArgs.bothSpecified() {
+				// Keep the half of the platform that isn't overridden, e.g. "linux" when only "architecture" is set.
+				if parts := strings.Split(string(*dstStruct.PlatformString), "/"); len(parts) == 2 {
+					if srcStruct.PlatformArgs.OSFamily == nil {
+						dstStruct.PlatformArgs.OSFamily = aws.String(normalizeOS(parts[0]))
+					}
+					if srcStruct.PlatformArgs.Arch == nil {
+						dstStruct.PlatformArgs.Arch = aws.String(normalizeArch(parts[1]))
+					}
+				}
+			}
 			dstStruct.PlatformString = nil
 		}
 
@@ -219,6 +274,14 @@ func (t advancedCountTransformer) Transformer(typ reflect.Type) func(dst, src re
 			dstStruct.Spot = nil
 		}
 
+		if !srcStruct.CustomMetric.IsEmpty() {
+			dstStruct.unsetBuiltInScalingFields()
+		}
+
+		if srcStruct.hasBuiltInScalingFieldsSet() {
+			dstStruct.CustomMetric = CustomMetric{}
+		}
+
 		if dst.CanSet() { // For extra safety to prevent panicking.
 			dst.Set(reflect.ValueOf(dstStruct))
 		}
@@ -286,12 +349,13 @@ func (t efsVolumeConfigurationTransformer) Transformer(typ reflect.Type) func(ds
 	}
 	return func(dst, src reflect.Value) error {
 		dstStruct, srcStruct := dst.Interface().(EFSVolumeConfiguration), src.Interface().(EFSVolumeConfiguration)
-		if !srcStruct.EmptyUIDConfig() {
+		if !srcStruct.EmptyUIDConfig() || aws.BoolValue(srcStruct.Managed) {
 			dstStruct.unsetBYOConfig()
 		}
 
 		if !srcStruct.EmptyBYOConfig() {
 			dstStruct.unsetUIDConfig()
+			dstStruct.unsetManaged()
 		}
 
 		if dst.CanSet() { // For extra safety to prevent panicking.
@@ -326,6 +390,58 @@ func (q sqsQueueOrBoolTransformer) Transformer(typ reflect.Type) func(dst, src r
 	}
 }
 
+type executeCommandTransformer struct{}
+
+// Transformer returns custom merge logic for ExecuteCommand so that an environment can turn exec off.
+func (t executeCommandTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(ExecuteCommand{}) {
+		return nil
+	}
+	return func(dst, src reflect.Value) error {
+		dstStruct, srcStruct := dst.Interface().(ExecuteCommand), src.Interface().(ExecuteCommand)
+
+		if !srcStruct.Config.IsEmpty() {
+			dstStruct.Enable = nil
+		}
+
+		if srcStruct.Enable != nil {
+			dstStruct.Config = ExecuteCommandConfig{}
+		}
+
+		if srcStruct.Disabled() {
+			// Drop the configuration of the base so that the override wins even if the base enables exec.
+			dstStruct = srcStruct
+		}
+
+		if dst.CanSet() { // For extra safety to prevent panicking.
+			dst.Set(reflect.ValueOf(dstStruct))
+		}
+		return nil
+	}
+}
+
+type blueGreenConfigOrBoolTransformer struct{}
+
+// Transformer returns custom merge logic for BlueGreenConfigOrBool so that an environment can turn blue/green off.
+func (t blueGreenConfigOrBoolTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(BlueGreenConfigOrBool{}) {
+		return nil
+	}
+	return func(dst, src reflect.Value) error {
+		dstStruct, srcStruct := dst.Interface().(BlueGreenConfigOrBool), src.Interface().(BlueGreenConfigOrBool)
+
+		if srcStruct.Enable != nil && !aws.BoolValue(srcStruct.Enable) {
+			// Drop the configuration of the base so that the override wins even if the base enables blue/green.
+			dstStruct = srcStruct
+		}
+
+		if dst.CanSet() { // For extra safety to prevent panicking.
+			dst.Set(reflect.ValueOf(dstStruct))
+		}
+		return nil
+	}
+}
+
 type basicTransformer struct{}
 
 // Transformer returns custom merge logic for volume's fields.
@@ -359,3 +475,176 @@ func transformPBasicOrSlice(dst, src reflect.Value) error {
 	}
 	return nil
 }
+
+type alarmArgsOrNamesTransformer struct{}
+
+// Transformer returns custom merge logic for AlarmArgsOrNames's fields.
+func (t alarmArgsOrNamesTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(AlarmArgsOrNames{}) {
+		return nil
+	}
+
+	return func(dst, src reflect.Value) error {
+		dstStruct, srcStruct := dst.Interface().(AlarmArgsOrNames), src.Interface().(AlarmArgsOrNames)
+
+		if srcStruct.AlarmNames != nil {
+			dstStruct.AlarmArgs = AlarmArgs{}
+		}
+
+		if !srcStruct.AlarmArgs.IsEmpty() {
+			dstStruct.AlarmNames = nil
+		}
+
+		if dst.CanSet() { // For extra safety to prevent panicking.
+			dst.Set(reflect.ValueOf(dstStruct))
+		}
+		return nil
+	}
+}
+
+type flagsTransformer struct{}
+
+// Transformer returns custom merge logic for Flags so that a flag can be turned off in an environment.
+func (t flagsTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(Flags{}) {
+		return nil
+	}
+
+	return func(dst, src reflect.Value) error {
+		dstFlags, srcFlags := dst.Interface().(Flags), src.Interface().(Flags)
+		if len(srcFlags) == 0 {
+			return nil
+		}
+		merged := make(Flags)
+		for name, on := range dstFlags {
+			merged[name] = on
+		}
+		for name, on := range srcFlags {
+			merged[name] = on
+		}
+
+		if dst.CanSet() { // For extra safety to prevent panicking.
+			dst.Set(reflect.ValueOf(merged))
+		}
+		return nil
+	}
+}
+
+type gitSHATagTransformer struct{}
+
+// Transformer returns custom merge logic for GitSHATag's fields.
+func (t gitSHATagTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(GitSHATag{}) {
+		return nil
+	}
+
+	return func(dst, src reflect.Value) error {
+		dstStruct, srcStruct := dst.Interface().(GitSHATag), src.Interface().(GitSHATag)
+
+		if srcStruct.Key != nil {
+			dstStruct.Enabled = nil
+		}
+
+		if srcStruct.Enabled != nil {
+			dstStruct.Key = nil
+		}
+
+		if dst.CanSet() { // For extra safety to prevent panicking.
+			dst.Set(reflect.ValueOf(dstStruct))
+		}
+		return nil
+	}
+}
+
+type subnetListOrArgsTransformer struct{}
+
+// Transformer returns custom merge logic for SubnetListOrArgs's fields.
+func (t subnetListOrArgsTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(SubnetListOrArgs{}) {
+		return nil
+	}
+
+	return func(dst, src reflect.Value) error {
+		dstStruct, srcStruct := dst.Interface().(SubnetListOrArgs), src.Interface().(SubnetListOrArgs)
+
+		if srcStruct.IDs != nil {
+			dstStruct.SubnetArgs = SubnetArgs{}
+		}
+
+		if !srcStruct.SubnetArgs.IsEmpty() {
+			dstStruct.IDs = nil
+		}
+
+		if dst.CanSet() { // For extra safety to prevent panicking.
+			dst.Set(reflect.ValueOf(dstStruct))
+		}
+		return nil
+	}
+}
+
+type vpcConfigTransformer struct{}
+
+// Transformer returns custom merge logic for vpcConfig's fields, so that an override can switch
+// between a subnet placement and explicit subnets.
+func (t vpcConfigTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(vpcConfig{}) {
+		return nil
+	}
+
+	return func(dst, src reflect.Value) error {
+		dstStruct, srcStruct := dst.Interface().(vpcConfig), src.Interface().(vpcConfig)
+
+		if srcStruct.Placement != nil {
+			dstStruct.Subnets = SubnetListOrArgs{}
+		}
+
+		if !srcStruct.Subnets.IsEmpty() {
+			dstStruct.Placement = nil
+		}
+
+		if dst.CanSet() { // For extra safety to prevent panicking.
+			dst.Set(reflect.ValueOf(dstStruct))
+		}
+		return nil
+	}
+}
+
+type variablesTransformer struct{}
+
+// Transformer returns custom merge logic for Variables so that a variable that an override defines
+// from another source, such as an SSM parameter instead of a value, is removed from its original source.
+func (t variablesTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(Variables{}) {
+		return nil
+	}
+
+	return func(dst, src reflect.Value) error {
+		dstStruct, srcStruct := dst.Interface().(Variables), src.Interface().(Variables)
+
+		dstStruct.Values = withoutVariables(dstStruct.Values, srcStruct.FromSSM, srcStruct.Imports)
+		dstStruct.FromSSM = withoutVariables(dstStruct.FromSSM, srcStruct.Values, srcStruct.Imports)
+		dstStruct.Imports = withoutVariables(dstStruct.Imports, srcStruct.Values, srcStruct.FromSSM)
+
+		if dst.CanSet() { // For extra safety to prevent panicking.
+			dst.Set(reflect.ValueOf(dstStruct))
+		}
+		return nil
+	}
+}
+
+// withoutVariables returns a copy of vars without the names defined in any of the overrides.
+func withoutVariables(vars map[string]string, overrides ...map[string]string) map[string]string {
+	if vars == nil {
+		return nil
+	}
+	out := make(map[string]string, len(vars))
+	for name, val := range vars {
+		out[name] = val
+	}
+	for _, override := range overrides {
+		for name := range override {
+			delete(out, name)
+		}
+	}
+	return out
+}
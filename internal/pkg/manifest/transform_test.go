@@ -333,6 +333,72 @@ func TestStringSliceOrStringTransformer_Transformer(t *testing.T) {
 	}
 }
 
+func TestAliasTransformer_Transformer(t *testing.T) {
+	testCases := map[string]struct {
+		original func(a *Alias)
+		override func(a *Alias)
+		wanted   func(a *Alias)
+	}{
+		"advanced aliases set to empty if string is not nil": {
+			original: func(a *Alias) {
+				a.AdvancedAliases = []AdvancedAlias{
+					{
+						Alias:      aws.String("example.com"),
+						HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+					},
+				}
+			},
+			override: func(a *Alias) {
+				a.String = aws.String("example.com")
+			},
+			wanted: func(a *Alias) {
+				a.String = aws.String("example.com")
+			},
+		},
+		"string and string slice set to empty if advanced aliases is not nil": {
+			original: func(a *Alias) {
+				a.StringSlice = []string{"example.com", "www.example.com"}
+			},
+			override: func(a *Alias) {
+				a.AdvancedAliases = []AdvancedAlias{
+					{
+						Alias:      aws.String("example.com"),
+						HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+					},
+				}
+			},
+			wanted: func(a *Alias) {
+				a.AdvancedAliases = []AdvancedAlias{
+					{
+						Alias:      aws.String("example.com"),
+						HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+					},
+				}
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var dst, override, wanted Alias
+
+			tc.original(&dst)
+			tc.override(&override)
+			tc.wanted(&wanted)
+
+			// Perform default merge.
+			err := mergo.Merge(&dst, override, mergo.WithOverride)
+			require.NoError(t, err)
+
+			// Use aliasTransformer.
+			err = mergo.Merge(&dst, override, mergo.WithOverride, mergo.WithTransformers(aliasTransformer{}))
+			require.NoError(t, err)
+
+			require.Equal(t, wanted, dst)
+		})
+	}
+}
+
 func TestPlatformArgsOrStringTransformer_Transformer(t *testing.T) {
 	mockPlatformStr := PlatformString("mockString")
 	testCases := map[string]struct {
@@ -357,6 +423,38 @@ func TestPlatformArgsOrStringTransformer_Transformer(t *testing.T) {
 				}
 			},
 		},
+		"args keep the half of the string that is not overridden": {
+			original: func(p *PlatformArgsOrString) {
+				p.PlatformString = (*PlatformString)(aws.String("linux/amd64"))
+			},
+			override: func(p *PlatformArgsOrString) {
+				p.PlatformArgs = PlatformArgs{
+					Arch: aws.String("arm64"),
+				}
+			},
+			wanted: func(p *PlatformArgsOrString) {
+				p.PlatformArgs = PlatformArgs{
+					OSFamily: aws.String("linux"),
+					Arch:     aws.String("arm64"),
+				}
+			},
+		},
+		"args keep the normalized half of the string that is not overridden": {
+			original: func(p *PlatformArgsOrString) {
+				p.PlatformString = (*PlatformString)(aws.String("Linux/X86-64"))
+			},
+			override: func(p *PlatformArgsOrString) {
+				p.PlatformArgs = PlatformArgs{
+					OSFamily: aws.String("windows"),
+				}
+			},
+			wanted: func(p *PlatformArgsOrString) {
+				p.PlatformArgs = PlatformArgs{
+					OSFamily: aws.String("windows"),
+					Arch:     aws.String("x86_64"),
+				}
+			},
+		},
 		"args set to empty if string is not nil": {
 			original: func(p *PlatformArgsOrString) {
 				p.PlatformArgs = PlatformArgs{
@@ -555,6 +653,52 @@ func TestAdvancedCountTransformer_Transformer(t *testing.T) {
 				a.Spot = aws.Int(24)
 			},
 		},
+		"built-in metrics set to empty if custom metric is not empty": {
+			original: func(a *AdvancedCount) {
+				a.Range = Range{
+					Value: (*IntRangeBand)(aws.String("1-10")),
+				}
+				a.CPU = &mockPerc
+			},
+			override: func(a *AdvancedCount) {
+				a.CustomMetric = CustomMetric{
+					Namespace:  aws.String("AWS/SQS"),
+					MetricName: aws.String("ApproximateNumberOfMessagesVisible"),
+					Target:     aws.Float64(100),
+				}
+			},
+			wanted: func(a *AdvancedCount) {
+				a.Range = Range{
+					Value: (*IntRangeBand)(aws.String("1-10")),
+				}
+				a.CustomMetric = CustomMetric{
+					Namespace:  aws.String("AWS/SQS"),
+					MetricName: aws.String("ApproximateNumberOfMessagesVisible"),
+					Target:     aws.Float64(100),
+				}
+			},
+		},
+		"custom metric set to empty if a built-in metric is not empty": {
+			original: func(a *AdvancedCount) {
+				a.Range = Range{
+					Value: (*IntRangeBand)(aws.String("1-10")),
+				}
+				a.CustomMetric = CustomMetric{
+					Namespace:  aws.String("AWS/SQS"),
+					MetricName: aws.String("ApproximateNumberOfMessagesVisible"),
+					Target:     aws.Float64(100),
+				}
+			},
+			override: func(a *AdvancedCount) {
+				a.Memory = &mockPerc
+			},
+			wanted: func(a *AdvancedCount) {
+				a.Range = Range{
+					Value: (*IntRangeBand)(aws.String("1-10")),
+				}
+				a.Memory = &mockPerc
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -738,6 +882,31 @@ func TestEfsVolumeConfigurationTransformer_Transformer(t *testing.T) {
 				e.GID = aws.Uint32(53589793)
 			},
 		},
+		"managed set to empty if BYO config is not empty": {
+			original: func(e *EFSVolumeConfiguration) {
+				e.Managed = aws.Bool(true)
+			},
+			override: func(e *EFSVolumeConfiguration) {
+				e.FileSystemID = aws.String("mockFileSystem")
+			},
+			wanted: func(e *EFSVolumeConfiguration) {
+				e.FileSystemID = aws.String("mockFileSystem")
+			},
+		},
+		"BYO config set to empty if managed is true": {
+			original: func(e *EFSVolumeConfiguration) {
+				e.FileSystemID = aws.String("mockFileSystem")
+				e.AuthConfig = AuthorizationConfig{
+					AccessPointID: aws.String("mockAccessPoint"),
+				}
+			},
+			override: func(e *EFSVolumeConfiguration) {
+				e.Managed = aws.Bool(true)
+			},
+			wanted: func(e *EFSVolumeConfiguration) {
+				e.Managed = aws.Bool(true)
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -819,3 +988,491 @@ func TestSQSQueueOrBoolTransformer_Transformer(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteCommandTransformer_Transformer(t *testing.T) {
+	testCases := map[string]struct {
+		original func(e *ExecuteCommand)
+		override func(e *ExecuteCommand)
+		wanted   func(e *ExecuteCommand)
+	}{
+		"bool set to empty if config is not nil": {
+			original: func(e *ExecuteCommand) {
+				e.Enable = aws.Bool(true)
+			},
+			override: func(e *ExecuteCommand) {
+				e.Config = ExecuteCommandConfig{
+					Logging: &ExecLogging{
+						CloudWatchLogGroup: aws.String("exec-audit"),
+					},
+				}
+			},
+			wanted: func(e *ExecuteCommand) {
+				e.Config = ExecuteCommandConfig{
+					Logging: &ExecLogging{
+						CloudWatchLogGroup: aws.String("exec-audit"),
+					},
+				}
+			},
+		},
+		"config set to empty if bool is not nil": {
+			original: func(e *ExecuteCommand) {
+				e.Config = ExecuteCommandConfig{
+					Enable: aws.Bool(true),
+				}
+			},
+			override: func(e *ExecuteCommand) {
+				e.Enable = aws.Bool(true)
+			},
+			wanted: func(e *ExecuteCommand) {
+				e.Enable = aws.Bool(true)
+			},
+		},
+		"disabled override wins over an enabled config": {
+			original: func(e *ExecuteCommand) {
+				e.Config = ExecuteCommandConfig{
+					Enable: aws.Bool(true),
+					Logging: &ExecLogging{
+						S3Bucket: aws.String("audit-bucket"),
+					},
+				}
+			},
+			override: func(e *ExecuteCommand) {
+				e.Config = ExecuteCommandConfig{
+					Enable: aws.Bool(false),
+				}
+			},
+			wanted: func(e *ExecuteCommand) {
+				e.Config = ExecuteCommandConfig{
+					Enable: aws.Bool(false),
+				}
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var dst, override, wanted ExecuteCommand
+
+			tc.original(&dst)
+			tc.override(&override)
+			tc.wanted(&wanted)
+
+			// Perform default merge.
+			err := mergo.Merge(&dst, override, mergo.WithOverride)
+			require.NoError(t, err)
+
+			// Use executeCommandTransformer.
+			err = mergo.Merge(&dst, override, mergo.WithOverride, mergo.WithTransformers(executeCommandTransformer{}))
+			require.NoError(t, err)
+
+			require.Equal(t, wanted, dst)
+		})
+	}
+}
+
+func TestBlueGreenConfigOrBoolTransformer_Transformer(t *testing.T) {
+	testCases := map[string]struct {
+		original func(b *BlueGreenConfigOrBool)
+		override func(b *BlueGreenConfigOrBool)
+		wanted   func(b *BlueGreenConfigOrBool)
+	}{
+		"config is merged with the original": {
+			original: func(b *BlueGreenConfigOrBool) {
+				b.Enable = aws.Bool(true)
+				b.Config.TerminationWait = durationp(10 * time.Minute)
+			},
+			override: func(b *BlueGreenConfigOrBool) {
+				b.Enable = aws.Bool(true)
+				b.Config.TestTrafficRoute = aws.Uint16(8080)
+			},
+			wanted: func(b *BlueGreenConfigOrBool) {
+				b.Enable = aws.Bool(true)
+				b.Config.TerminationWait = durationp(10 * time.Minute)
+				b.Config.TestTrafficRoute = aws.Uint16(8080)
+			},
+		},
+		"disabled override wins over an enabled config": {
+			original: func(b *BlueGreenConfigOrBool) {
+				b.Enable = aws.Bool(true)
+				b.Config.TestTrafficRoute = aws.Uint16(8080)
+			},
+			override: func(b *BlueGreenConfigOrBool) {
+				b.Enable = aws.Bool(false)
+			},
+			wanted: func(b *BlueGreenConfigOrBool) {
+				b.Enable = aws.Bool(false)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var dst, override, wanted BlueGreenConfigOrBool
+
+			tc.original(&dst)
+			tc.override(&override)
+			tc.wanted(&wanted)
+
+			// Perform default merge.
+			err := mergo.Merge(&dst, override, mergo.WithOverride)
+			require.NoError(t, err)
+
+			// Use blueGreenConfigOrBoolTransformer.
+			err = mergo.Merge(&dst, override, mergo.WithOverride, mergo.WithTransformers(blueGreenConfigOrBoolTransformer{}))
+			require.NoError(t, err)
+
+			require.Equal(t, wanted, dst)
+		})
+	}
+}
+
+func TestAlarmArgsOrNamesTransformer_Transformer(t *testing.T) {
+	testCases := map[string]struct {
+		original func(a *AlarmArgsOrNames)
+		override func(a *AlarmArgsOrNames)
+		wanted   func(a *AlarmArgsOrNames)
+	}{
+		"names set to empty if thresholds are not nil": {
+			original: func(a *AlarmArgsOrNames) {
+				a.AlarmNames = []string{"latency"}
+			},
+			override: func(a *AlarmArgsOrNames) {
+				a.AlarmArgs = AlarmArgs{
+					CPUUtilization: aws.Float64(70),
+				}
+			},
+			wanted: func(a *AlarmArgsOrNames) {
+				a.AlarmArgs = AlarmArgs{
+					CPUUtilization: aws.Float64(70),
+				}
+			},
+		},
+		"thresholds set to empty if names are not nil": {
+			original: func(a *AlarmArgsOrNames) {
+				a.AlarmArgs = AlarmArgs{
+					CPUUtilization:    aws.Float64(70),
+					MemoryUtilization: aws.Float64(80),
+				}
+			},
+			override: func(a *AlarmArgsOrNames) {
+				a.AlarmNames = []string{"latency"}
+			},
+			wanted: func(a *AlarmArgsOrNames) {
+				a.AlarmNames = []string{"latency"}
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var dst, override, wanted AlarmArgsOrNames
+
+			tc.original(&dst)
+			tc.override(&override)
+			tc.wanted(&wanted)
+
+			// Perform default merge.
+			err := mergo.Merge(&dst, override, mergo.WithOverride)
+			require.NoError(t, err)
+
+			// Use alarmArgsOrNamesTransformer.
+			err = mergo.Merge(&dst, override, mergo.WithOverride, mergo.WithTransformers(alarmArgsOrNamesTransformer{}))
+			require.NoError(t, err)
+
+			require.Equal(t, wanted, dst)
+		})
+	}
+}
+
+func TestFlagsTransformer_Transformer(t *testing.T) {
+	testCases := map[string]struct {
+		original Flags
+		override Flags
+		wanted   Flags
+	}{
+		"flag turned on": {
+			original: Flags{"tracing": false, "checkout": true},
+			override: Flags{"tracing": true},
+			wanted:   Flags{"tracing": true, "checkout": true},
+		},
+		"flag turned off": {
+			original: Flags{"tracing": true, "checkout": true},
+			override: Flags{"tracing": false},
+			wanted:   Flags{"tracing": false, "checkout": true},
+		},
+		"flag defined in the override only": {
+			original: Flags{"checkout": true},
+			override: Flags{"tracing": false},
+			wanted:   Flags{"tracing": false, "checkout": true},
+		},
+		"flags defined in the override only": {
+			override: Flags{"tracing": false},
+			wanted:   Flags{"tracing": false},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			type config struct {
+				Flags Flags
+			}
+			dst, override := config{Flags: tc.original}, config{Flags: tc.override}
+
+			// Perform default merge.
+			err := mergo.Merge(&dst, override, mergo.WithOverride)
+			require.NoError(t, err)
+
+			// Use flagsTransformer.
+			err = mergo.Merge(&dst, override, mergo.WithOverride, mergo.WithTransformers(flagsTransformer{}))
+			require.NoError(t, err)
+
+			require.Equal(t, tc.wanted, dst.Flags)
+		})
+	}
+}
+
+func TestGitSHATagTransformer_Transformer(t *testing.T) {
+	testCases := map[string]struct {
+		original func(t *GitSHATag)
+		override func(t *GitSHATag)
+		wanted   func(t *GitSHATag)
+	}{
+		"bool set to empty if key is not nil": {
+			original: func(t *GitSHATag) {
+				t.Enabled = aws.Bool(true)
+			},
+			override: func(t *GitSHATag) {
+				t.Key = aws.String("build-sha")
+			},
+			wanted: func(t *GitSHATag) {
+				t.Key = aws.String("build-sha")
+			},
+		},
+		"key set to empty if bool is not nil": {
+			original: func(t *GitSHATag) {
+				t.Key = aws.String("build-sha")
+			},
+			override: func(t *GitSHATag) {
+				t.Enabled = aws.Bool(false)
+			},
+			wanted: func(t *GitSHATag) {
+				t.Enabled = aws.Bool(false)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var dst, override, wanted GitSHATag
+
+			tc.original(&dst)
+			tc.override(&override)
+			tc.wanted(&wanted)
+
+			// Perform default merge.
+			err := mergo.Merge(&dst, override, mergo.WithOverride)
+			require.NoError(t, err)
+
+			// Use gitSHATagTransformer.
+			err = mergo.Merge(&dst, override, mergo.WithOverride, mergo.WithTransformers(gitSHATagTransformer{}))
+			require.NoError(t, err)
+
+			require.Equal(t, wanted, dst)
+		})
+	}
+}
+
+func TestSubnetListOrArgsTransformer_Transformer(t *testing.T) {
+	testCases := map[string]struct {
+		original func(s *SubnetListOrArgs)
+		override func(s *SubnetListOrArgs)
+		wanted   func(s *SubnetListOrArgs)
+	}{
+		"subnet IDs set to empty if tags are not nil": {
+			original: func(s *SubnetListOrArgs) {
+				s.IDs = []string{"subnet-0123abcd"}
+			},
+			override: func(s *SubnetListOrArgs) {
+				s.FromTags = map[string]string{
+					"tier": "private",
+				}
+			},
+			wanted: func(s *SubnetListOrArgs) {
+				s.FromTags = map[string]string{
+					"tier": "private",
+				}
+			},
+		},
+		"tags set to empty if subnet IDs are not nil": {
+			original: func(s *SubnetListOrArgs) {
+				s.FromTags = map[string]string{
+					"tier": "private",
+				}
+			},
+			override: func(s *SubnetListOrArgs) {
+				s.IDs = []string{"subnet-0123abcd"}
+			},
+			wanted: func(s *SubnetListOrArgs) {
+				s.IDs = []string{"subnet-0123abcd"}
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var dst, override, wanted SubnetListOrArgs
+
+			tc.original(&dst)
+			tc.override(&override)
+			tc.wanted(&wanted)
+
+			// Perform default merge.
+			err := mergo.Merge(&dst, override, mergo.WithOverride)
+			require.NoError(t, err)
+
+			// Use subnetListOrArgsTransformer.
+			err = mergo.Merge(&dst, override, mergo.WithOverride, mergo.WithTransformers(subnetListOrArgsTransformer{}))
+			require.NoError(t, err)
+
+			require.Equal(t, wanted, dst)
+		})
+	}
+}
+
+func TestVPCConfigTransformer_Transformer(t *testing.T) {
+	testCases := map[string]struct {
+		original func(v *vpcConfig)
+		override func(v *vpcConfig)
+		wanted   func(v *vpcConfig)
+	}{
+		"placement set to empty if subnets are not empty": {
+			original: func(v *vpcConfig) {
+				v.Placement = &PrivateSubnetPlacement
+			},
+			override: func(v *vpcConfig) {
+				v.Subnets.IDs = []string{"subnet-0123abcd"}
+			},
+			wanted: func(v *vpcConfig) {
+				v.Subnets.IDs = []string{"subnet-0123abcd"}
+			},
+		},
+		"subnets set to empty if placement is not nil": {
+			original: func(v *vpcConfig) {
+				v.Subnets.IDs = []string{"subnet-0123abcd"}
+			},
+			override: func(v *vpcConfig) {
+				v.Placement = &PrivateSubnetPlacement
+			},
+			wanted: func(v *vpcConfig) {
+				v.Placement = &PrivateSubnetPlacement
+			},
+		},
+		"subnets kept if the override sets neither": {
+			original: func(v *vpcConfig) {
+				v.Subnets.IDs = []string{"subnet-0123abcd"}
+			},
+			override: func(v *vpcConfig) {
+				v.SecurityGroups = []SecurityGroup{{ID: aws.String("sg-0123abcd")}}
+			},
+			wanted: func(v *vpcConfig) {
+				v.Subnets.IDs = []string{"subnet-0123abcd"}
+				v.SecurityGroups = []SecurityGroup{{ID: aws.String("sg-0123abcd")}}
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var dst, override, wanted vpcConfig
+
+			tc.original(&dst)
+			tc.override(&override)
+			tc.wanted(&wanted)
+
+			// Perform default merge.
+			err := mergo.Merge(&dst, override, mergo.WithOverride)
+			require.NoError(t, err)
+
+			// Use vpcConfigTransformer.
+			err = mergo.Merge(&dst, override, mergo.WithOverride, mergo.WithTransformers(vpcConfigTransformer{}))
+			require.NoError(t, err)
+
+			require.Equal(t, wanted, dst)
+		})
+	}
+}
+
+func TestVariablesTransformer_Transformer(t *testing.T) {
+	testCases := map[string]struct {
+		original Variables
+		override Variables
+		wanted   Variables
+	}{
+		"value overridden by an SSM parameter": {
+			original: Variables{
+				Values: map[string]string{"LOG_LEVEL": "info", "DB_PASSWORD": "local"},
+			},
+			override: Variables{
+				FromSSM: map[string]string{"DB_PASSWORD": "/db/password"},
+			},
+			wanted: Variables{
+				Values:  map[string]string{"LOG_LEVEL": "info"},
+				FromSSM: map[string]string{"DB_PASSWORD": "/db/password"},
+			},
+		},
+		"import overridden by a value": {
+			original: Variables{
+				Imports: map[string]string{"QUEUE_URL": "shared-QueueURL"},
+			},
+			override: Variables{
+				Values: map[string]string{"QUEUE_URL": "http://localhost:9324"},
+			},
+			wanted: Variables{
+				Values:  map[string]string{"QUEUE_URL": "http://localhost:9324"},
+				Imports: map[string]string{},
+			},
+		},
+		"SSM parameter overridden by an import": {
+			original: Variables{
+				FromSSM: map[string]string{"TOKEN": "/token", "KEY": "/key"},
+			},
+			override: Variables{
+				Imports: map[string]string{"TOKEN": "shared-Token"},
+			},
+			wanted: Variables{
+				FromSSM: map[string]string{"KEY": "/key"},
+				Imports: map[string]string{"TOKEN": "shared-Token"},
+			},
+		},
+		"value overridden by a value": {
+			original: Variables{
+				Values: map[string]string{"LOG_LEVEL": "info"},
+			},
+			override: Variables{
+				Values: map[string]string{"LOG_LEVEL": "debug"},
+			},
+			wanted: Variables{
+				Values: map[string]string{"LOG_LEVEL": "debug"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			type config struct {
+				Variables Variables
+			}
+			dst, override := config{Variables: tc.original}, config{Variables: tc.override}
+
+			// Perform default merge.
+			err := mergo.Merge(&dst, override, mergo.WithOverride)
+			require.NoError(t, err)
+
+			// Use variablesTransformer.
+			err = mergo.Merge(&dst, override, mergo.WithOverride, mergo.WithTransformers(variablesTransformer{}))
+			require.NoError(t, err)
+
+			require.Equal(t, tc.wanted, dst.Variables)
+		})
+	}
+}
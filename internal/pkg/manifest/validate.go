@@ -7,14 +7,21 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"path"
+	"reflect"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/copilot-cli/internal/pkg/graph"
 	"github.com/dustin/go-humanize/english"
+	"github.com/robfig/cron/v3"
 )
 
 const (
@@ -24,24 +31,100 @@ const (
 	dependsOnSuccess  = "SUCCESS"
 	dependsOnHealthy  = "HEALTHY"
 
+	// Limits of the key of a resource tag.
+	maxTagKeyLength      = 128
+	reservedTagKeyPrefix = "aws:"
+
 	// Min and Max values for task ephemeral storage in GiB.
-	ephemeralMinValueGiB = 20
+	ephemeralMinValueGiB = 21
 	ephemeralMaxValueGiB = 200
+
+	// Min and Max values for the number of receives before a message is moved to a dead-letter queue.
+	deadLetterMinTries = 1
+	deadLetterMaxTries = 1000
+
+	// Copilot environments span at least two availability zones.
+	minEnvAZCount = 2
+
+	// Environment variables injected by Copilot are prefixed with COPILOT_ unless "env_var_prefix" is set.
+	defaultEnvVarPrefix = "COPILOT_"
+
+	// Log groups created by Copilot are prefixed with /copilot/.
+	reservedLogGroupPrefix = "/copilot/"
+	maxLogGroupNameLength  = 512
+
+	maxContainerNameLength = 255
+
+	// Min and Max values for the healthy and unhealthy thresholds of a target group health check.
+	minHealthCheckThreshold = 2
+	maxHealthCheckThreshold = 10
+
+	// Limits of the name of an SSM parameter that backs a variable.
+	maxSSMParameterNameLength = 1011
+	maxSSMParameterLevels     = 15
+
+	// ECS accepts a health check grace period of up to 300 seconds.
+	maxHealthCheckStartPeriod = 300 * time.Second
+
+	// Bounds of the retries and the duration of a scheduled job run. The state machine times out in whole seconds.
+	maxJobRetries = 10
+	minJobTimeout = time.Second
+	maxJobTimeout = 24 * time.Hour
+
+	// CodeDeploy keeps the original tasks of a blue/green deployment for up to two days.
+	maxBlueGreenTerminationWait = 48 * time.Hour
+
+	// App Runner handles at most 200 concurrent requests per instance.
+	maxAppRunnerConcurrency = 200
+
+	// An ECS service can register at most 5 target groups. A load balanced web service always registers
+	// the target group of the HTTP load balancer and the one of "nlb.port", which leaves 3 for the additional listeners.
+	maxServiceTargetGroups    = 5
+	maxNLBAdditionalListeners = maxServiceTargetGroups - 2
 )
 
 var (
 	intRangeBandRegexp  = regexp.MustCompile(`^(\d+)-(\d+)$`)
 	volumesPathRegexp   = regexp.MustCompile(`^[a-zA-Z0-9\-\.\_/]+$`)
-	awsSNSTopicRegexp   = regexp.MustCompile(`^[a-zA-Z0-9_-]*$`)   // Validates that an expression contains only letters, numbers, underscores, and hyphens.
-	awsNameRegexp       = regexp.MustCompile(`^[a-z][a-z0-9\-]+$`) // Validates that an expression starts with a letter and only contains letters, numbers, and hyphens.
-	punctuationRegExp   = regexp.MustCompile(`[\.\-]{2,}`)         // Check for consecutive periods or dashes.
-	trailingPunctRegExp = regexp.MustCompile(`[\-\.]$`)            // Check for trailing dash or dot.
+	awsSNSTopicRegexp   = regexp.MustCompile(`^[a-zA-Z0-9_-]*$`)         // Validates that an expression contains only letters, numbers, underscores, and hyphens.
+	awsNameRegexp       = regexp.MustCompile(`^[a-z][a-z0-9\-]+$`)       // Validates that an expression starts with a letter and only contains letters, numbers, and hyphens.
+	punctuationRegExp   = regexp.MustCompile(`[\.\-]{2,}`)               // Check for consecutive periods or dashes.
+	trailingPunctRegExp = regexp.MustCompile(`[\-\.]$`)                  // Check for trailing dash or dot.
+	envVarNameRegexp    = regexp.MustCompile(`^[_a-zA-Z][_a-zA-Z0-9]*$`) // Validates that an expression is a valid environment variable name.
+	logGroupNameRegexp  = regexp.MustCompile(`^[\.\-_/#A-Za-z0-9]+$`)    // Validates that an expression is a valid CloudWatch log group name.
+	containerNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)         // Validates that an expression is a valid ECS container name.
+	hostedZoneIDRegexp  = regexp.MustCompile(`^Z[A-Z0-9]+$`)             // Validates that an expression looks like a Route 53 hosted zone ID.
+
+	// subnetIDRegexp validates that an expression is a valid EC2 subnet ID.
+	subnetIDRegexp = regexp.MustCompile(`^subnet-([0-9a-f]{8}|[0-9a-f]{17})$`)
+	// ssmParameterNameRegexp validates that an expression is an SSM parameter name, either "name" or a "/path/to/name" hierarchy.
+	ssmParameterNameRegexp = regexp.MustCompile(`^((/[a-zA-Z0-9_.\-]+)+|[a-zA-Z0-9_.\-]+)$`)
+	// securityGroupIDRegexp validates that an expression is a valid EC2 security group ID.
+	securityGroupIDRegexp = regexp.MustCompile(`^sg-([0-9a-f]{8}|[0-9a-f]{17})$`)
+	// awsRateScheduleRegexp validates that an expression is a CloudWatch Events rate expression such as "rate(5 minutes)".
+	awsRateScheduleRegexp = regexp.MustCompile(`^rate\((\d+) (minutes?|hours?|days?)\)$`)
+
+	// awsAtScheduleRegexp validates that an expression is an Application Auto Scaling one-time schedule such as "at(2026-12-24T18:00:00)".
+	awsAtScheduleRegexp = regexp.MustCompile(`^at\((.+)\)$`)
+	// scalingCalendarNameRegexp validates that an expression is a valid name of a scaling calendar.
+	scalingCalendarNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+	// imageReferenceRegexp validates that an expression is a valid image reference of the form "[domain/]name[:tag][@digest]".
+	imageReferenceRegexp = regexp.MustCompile(`^(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*(?::[0-9]+)?/)?` +
+		`[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+		`(?::[\w][\w.-]{0,127})?(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?$`)
 
 	essentialContainerDependsOnValidStatuses = []string{dependsOnStart, dependsOnHealthy}
+	initContainerDependsOnValidStatuses      = []string{dependsOnComplete, dependsOnSuccess}
 	dependsOnValidStatuses                   = []string{dependsOnStart, dependsOnComplete, dependsOnSuccess, dependsOnHealthy}
 
 	httpProtocolVersions = []string{"GRPC", "HTTP1", "HTTP2"}
 
+	buildNetworkModes = []string{"default", "host", "none"}
+
+	// Predefined schedules of cron that scheduled jobs accept in "on.schedule".
+	predefinedSchedules = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
 	invalidTaskDefOverridePathRegexp = []string{`Family`, `ContainerDefinitions\[\d+\].Name`}
 )
 
@@ -55,27 +138,65 @@ func (l LoadBalancedWebService) Validate() error {
 		return err
 	}
 	if err = validateTargetContainer(validateTargetContainerOpts{
-		mainContainerName: aws.StringValue(l.Name),
+		mainContainerName: l.MainContainerName(aws.StringValue(l.Name)),
+		mainContainerPort: l.ImageConfig.Port,
 		targetContainer:   l.RoutingRule.targetContainer(),
+		targetPort:        l.RoutingRule.TargetPort,
 		sidecarConfig:     l.Sidecars,
 	}); err != nil {
 		return fmt.Errorf("validate HTTP load balancer target: %w", err)
 	}
 	if err = validateTargetContainer(validateTargetContainerOpts{
-		mainContainerName: aws.StringValue(l.Name),
+		mainContainerName: l.MainContainerName(aws.StringValue(l.Name)),
 		targetContainer:   l.NLBConfig.TargetContainer,
 		sidecarConfig:     l.Sidecars,
 	}); err != nil {
 		return fmt.Errorf("validate network load balancer target: %w", err)
 	}
+	for idx, listener := range l.NLBConfig.AdditionalListeners {
+		if err = validateTargetContainer(validateTargetContainerOpts{
+			mainContainerName: l.MainContainerName(aws.StringValue(l.Name)),
+			targetContainer:   listener.TargetContainer,
+			sidecarConfig:     l.Sidecars,
+		}); err != nil {
+			return fmt.Errorf(`validate network load balancer target of "additional_listeners[%d]": %w`, idx, err)
+		}
+	}
 	if err = validateContainerDeps(validateDependenciesOpts{
 		sidecarConfig:     l.Sidecars,
 		imageConfig:       l.ImageConfig.Image,
-		mainContainerName: aws.StringValue(l.Name),
+		healthCheck:       l.ImageConfig.HealthCheck,
+		mainContainerName: l.MainContainerName(aws.StringValue(l.Name)),
 		logging:           l.Logging,
+		initContainer:     l.InitContainer,
+		observability:     l.Observability,
 	}); err != nil {
 		return fmt.Errorf("validate container dependencies: %w", err)
 	}
+	if err = validateFlags(l.Flags, l.Sidecars, l.TaskConfig.Variables); err != nil {
+		return err
+	}
+	if err = validateSidecarMountPoints(l.Storage.Volumes, l.Sidecars); err != nil {
+		return err
+	}
+	if err = validateDenyDefaultSecurityGroup(l.Network.VPC, l.Storage.Volumes, true); err != nil {
+		return fmt.Errorf(`validate "network.vpc": %w`, err)
+	}
+	if err = validateServiceConnectPorts(l.Network.Connect, l.ImageConfig.Port, l.Sidecars); err != nil {
+		return err
+	}
+	if err = validatePinnedSidecarImages(l.ImageConfig.Image, l.Sidecars, l.Logging); err != nil {
+		return err
+	}
+	if err = validateContainerCount(validateContainerCountOpts{
+		sidecarConfig: l.Sidecars,
+		logging:       l.Logging,
+		initContainer: l.InitContainer,
+		secrets:       l.TaskConfig.Secrets,
+		observability: l.Observability,
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -94,6 +215,17 @@ func (l LoadBalancedWebServiceConfig) Validate() error {
 	if err = l.TaskConfig.Validate(); err != nil {
 		return err
 	}
+	if l.RoutingRule.HostnameVariable != nil {
+		if err = validateReservedEnvVarName(aws.StringValue(l.RoutingRule.HostnameVariable), l.TaskConfig.InjectedEnvVarPrefix()); err != nil {
+			return fmt.Errorf(`validate "http": validate "hostname_variable": %w`, err)
+		}
+		if err = validateInjectedEnvVarName(aws.StringValue(l.RoutingRule.HostnameVariable), l.TaskConfig.Variables); err != nil {
+			return fmt.Errorf(`validate "http": validate "hostname_variable": %w`, err)
+		}
+	}
+	if err = validateBuildPlatforms(l.ImageConfig.Image.Build, l.Platform); err != nil {
+		return err
+	}
 	if err = l.Logging.Validate(); err != nil {
 		return fmt.Errorf(`validate "logging": %w`, err)
 	}
@@ -105,6 +237,9 @@ func (l LoadBalancedWebServiceConfig) Validate() error {
 	if err = l.Network.Validate(); err != nil {
 		return fmt.Errorf(`validate "network": %w`, err)
 	}
+	if err = validateLoadBalancedIPFamily(l.Network); err != nil {
+		return fmt.Errorf(`validate "network": %w`, err)
+	}
 	if err = l.PublishConfig.Validate(); err != nil {
 		return fmt.Errorf(`validate "publish": %w`, err)
 	}
@@ -113,25 +248,76 @@ func (l LoadBalancedWebServiceConfig) Validate() error {
 			return fmt.Errorf(`validate "taskdef_overrides[%d]": %w`, ind, err)
 		}
 	}
+	if err = l.DeployConfig.Validate(); err != nil {
+		return fmt.Errorf(`validate "deployment": %w`, err)
+	}
+	if err = validateDeploymentProgress(l.Count, l.DeployConfig); err != nil {
+		return fmt.Errorf(`validate "deployment": %w`, err)
+	}
+	if l.DeployConfig.BlueGreen.Enabled() && !l.NLBConfig.IsEmpty() {
+		// CodeDeploy shifts the traffic of a single target group.
+		return &errFieldMutualExclusive{
+			firstField:  "deployment.bluegreen",
+			secondField: "nlb",
+		}
+	}
+	if l.DeployConfig.BlueGreen.Enabled() && l.Network.Connect.IsEnabled() {
+		// Service Connect only supports the ECS deployment controller.
+		return &errFieldMutualExclusive{
+			firstField:  "deployment.bluegreen",
+			secondField: "network.connect",
+		}
+	}
+	if err = l.InitContainer.Validate(); err != nil {
+		return fmt.Errorf(`validate "init_container": %w`, err)
+	}
+	if err = l.Readiness.Validate(); err != nil {
+		return fmt.Errorf(`validate "readiness": %w`, err)
+	}
 	if l.TaskConfig.IsWindows() {
 		if err = validateWindows(validateWindowsOpts{
-			execEnabled: aws.BoolValue(l.ExecuteCommand.Enable),
-			efsVolumes:  l.Storage.Volumes,
+			execEnabled:     l.ExecuteCommand.Enabled(),
+			efsVolumes:      l.Storage.Volumes,
+			fireLensEnabled: !l.Logging.IsEmpty(),
+			ulimits:         l.Ulimits,
+			sidecars:        l.Sidecars,
 		}); err != nil {
 			return fmt.Errorf("validate Windows: %w", err)
 		}
 	}
 	if l.TaskConfig.IsARM() {
 		if err = validateARM(validateARMOpts{
-			Spot:     l.Count.AdvancedCount.Spot,
-			SpotFrom: l.Count.AdvancedCount.Range.RangeConfig.SpotFrom,
+			Spot:              l.Count.AdvancedCount.Spot,
+			SpotFrom:          l.Count.AdvancedCount.Range.RangeConfig.SpotFrom,
+			CapacityProviders: l.Count.AdvancedCount.CapacityProviders,
 		}); err != nil {
 			return fmt.Errorf("validate ARM: %w", err)
 		}
 	}
+	if aws.BoolValue(l.AZRebalancing) {
+		if err = validateAZRebalancing(l.Count, minEnvAZCount); err != nil {
+			return fmt.Errorf(`validate "availability_zone_rebalancing": %w`, err)
+		}
+	}
+	if l.PropagateTags != nil {
+		if err = validatePropagateTags(aws.StringValue(l.PropagateTags)); err != nil {
+			return fmt.Errorf(`validate "propagate_tags": %w`, err)
+		}
+	}
+	if err = l.GitSHATag.Validate(); err != nil {
+		return fmt.Errorf(`validate "git_sha_tag": %w`, err)
+	}
 	if err = l.NLBConfig.Validate(); err != nil {
 		return fmt.Errorf(`validate "nlb": %w`, err)
 	}
+	if err = l.Observability.Validate(); err != nil {
+		return fmt.Errorf(`validate "observability": %w`, err)
+	}
+	if l.Observability.TracePropagation != nil {
+		if err = validateInjectedEnvVarName(tracePropagatorsEnvVar, l.TaskConfig.Variables); err != nil {
+			return fmt.Errorf(`validate "observability": validate "trace_propagation": %w`, err)
+		}
+	}
 	return nil
 }
 
@@ -144,14 +330,56 @@ func (b BackendService) Validate() error {
 	if err = b.Workload.Validate(); err != nil {
 		return err
 	}
+	if !b.RoutingRule.IsEmpty() {
+		if b.ImageConfig.Port == nil && b.RoutingRule.targetContainer() == nil {
+			return &errFieldMustBeSpecified{
+				missingField:      "image.port",
+				conditionalFields: []string{"http"},
+			}
+		}
+		if err = validateTargetContainer(validateTargetContainerOpts{
+			mainContainerName: b.MainContainerName(aws.StringValue(b.Name)),
+			mainContainerPort: b.ImageConfig.Port,
+			targetContainer:   b.RoutingRule.targetContainer(),
+			targetPort:        b.RoutingRule.TargetPort,
+			sidecarConfig:     b.Sidecars,
+		}); err != nil {
+			return fmt.Errorf("validate HTTP load balancer target: %w", err)
+		}
+	}
 	if err = validateContainerDeps(validateDependenciesOpts{
 		sidecarConfig:     b.Sidecars,
 		imageConfig:       b.ImageConfig.Image,
-		mainContainerName: aws.StringValue(b.Name),
+		healthCheck:       b.ImageConfig.HealthCheck,
+		mainContainerName: b.MainContainerName(aws.StringValue(b.Name)),
 		logging:           b.Logging,
+		initContainer:     b.InitContainer,
 	}); err != nil {
 		return fmt.Errorf("validate container dependencies: %w", err)
 	}
+	if err = validateFlags(b.Flags, b.Sidecars, b.Variables); err != nil {
+		return err
+	}
+	if err = validateSidecarMountPoints(b.Storage.Volumes, b.Sidecars); err != nil {
+		return err
+	}
+	if err = validateDenyDefaultSecurityGroup(b.Network.VPC, b.Storage.Volumes, !b.RoutingRule.IsEmpty()); err != nil {
+		return fmt.Errorf(`validate "network.vpc": %w`, err)
+	}
+	if err = validateServiceConnectPorts(b.Network.Connect, b.ImageConfig.Port, b.Sidecars); err != nil {
+		return err
+	}
+	if err = validatePinnedSidecarImages(b.ImageConfig.Image, b.Sidecars, b.Logging); err != nil {
+		return err
+	}
+	if err = validateContainerCount(validateContainerCountOpts{
+		sidecarConfig: b.Sidecars,
+		logging:       b.Logging,
+		initContainer: b.InitContainer,
+		secrets:       b.TaskConfig.Secrets,
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -164,9 +392,34 @@ func (b BackendServiceConfig) Validate() error {
 	if err = b.ImageOverride.Validate(); err != nil {
 		return err
 	}
+	if err = b.RoutingRule.validateInternal(); err != nil {
+		return fmt.Errorf(`validate "http": %w`, err)
+	}
+	if !b.RoutingRule.IsEmpty() {
+		if err = validateLoadBalancedIPFamily(b.Network); err != nil {
+			return fmt.Errorf(`validate "network": %w`, err)
+		}
+	}
 	if err = b.TaskConfig.Validate(); err != nil {
 		return err
 	}
+	if b.RoutingRule.IsEmpty() {
+		if b.Count.AdvancedCount.Requests != nil {
+			return fmt.Errorf(`validate "count": %w`, &errFieldMustBeSpecified{
+				missingField:      "http",
+				conditionalFields: []string{"requests"},
+			})
+		}
+		if b.Count.AdvancedCount.ResponseTime != nil {
+			return fmt.Errorf(`validate "count": %w`, &errFieldMustBeSpecified{
+				missingField:      "http",
+				conditionalFields: []string{"response_time"},
+			})
+		}
+	}
+	if err = validateBuildPlatforms(b.ImageConfig.Image.Build, b.Platform); err != nil {
+		return err
+	}
 	if err = b.Logging.Validate(); err != nil {
 		return fmt.Errorf(`validate "logging": %w`, err)
 	}
@@ -186,22 +439,51 @@ func (b BackendServiceConfig) Validate() error {
 			return fmt.Errorf(`validate "taskdef_overrides[%d]": %w`, ind, err)
 		}
 	}
+	if err = b.DeployConfig.Validate(); err != nil {
+		return fmt.Errorf(`validate "deployment": %w`, err)
+	}
+	if err = validateDeploymentProgress(b.Count, b.DeployConfig); err != nil {
+		return fmt.Errorf(`validate "deployment": %w`, err)
+	}
+	if b.DeployConfig.BlueGreen.Enabled() {
+		return errBlueGreenWithoutLoadBalancer
+	}
+	if err = b.InitContainer.Validate(); err != nil {
+		return fmt.Errorf(`validate "init_container": %w`, err)
+	}
 	if b.TaskConfig.IsWindows() {
 		if err = validateWindows(validateWindowsOpts{
-			execEnabled: aws.BoolValue(b.ExecuteCommand.Enable),
-			efsVolumes:  b.Storage.Volumes,
+			execEnabled:     b.ExecuteCommand.Enabled(),
+			efsVolumes:      b.Storage.Volumes,
+			fireLensEnabled: !b.Logging.IsEmpty(),
+			ulimits:         b.Ulimits,
+			sidecars:        b.Sidecars,
 		}); err != nil {
 			return fmt.Errorf("validate Windows: %w", err)
 		}
 	}
 	if b.TaskConfig.IsARM() {
 		if err = validateARM(validateARMOpts{
-			Spot:     b.Count.AdvancedCount.Spot,
-			SpotFrom: b.Count.AdvancedCount.Range.RangeConfig.SpotFrom,
+			Spot:              b.Count.AdvancedCount.Spot,
+			SpotFrom:          b.Count.AdvancedCount.Range.RangeConfig.SpotFrom,
+			CapacityProviders: b.Count.AdvancedCount.CapacityProviders,
 		}); err != nil {
 			return fmt.Errorf("validate ARM: %w", err)
 		}
 	}
+	if aws.BoolValue(b.AZRebalancing) {
+		if err = validateAZRebalancing(b.Count, minEnvAZCount); err != nil {
+			return fmt.Errorf(`validate "availability_zone_rebalancing": %w`, err)
+		}
+	}
+	if b.PropagateTags != nil {
+		if err = validatePropagateTags(aws.StringValue(b.PropagateTags)); err != nil {
+			return fmt.Errorf(`validate "propagate_tags": %w`, err)
+		}
+	}
+	if err = b.GitSHATag.Validate(); err != nil {
+		return fmt.Errorf(`validate "git_sha_tag": %w`, err)
+	}
 	return nil
 }
 
@@ -222,6 +504,9 @@ func (r RequestDrivenWebServiceConfig) Validate() error {
 	if err = r.InstanceConfig.Validate(); err != nil {
 		return err
 	}
+	if err = validateBuildPlatforms(r.ImageConfig.Image.Build, r.InstanceConfig.Platform); err != nil {
+		return err
+	}
 	if err = r.RequestDrivenWebServiceHttpConfig.Validate(); err != nil {
 		return fmt.Errorf(`validate "http": %w`, err)
 	}
@@ -231,6 +516,15 @@ func (r RequestDrivenWebServiceConfig) Validate() error {
 	if err = r.Network.Validate(); err != nil {
 		return fmt.Errorf(`validate "network": %w`, err)
 	}
+	if err = r.Variables.Validate(); err != nil {
+		return fmt.Errorf(`validate "variables": %w`, err)
+	}
+	if err = validateFlags(r.Flags, nil, r.Variables); err != nil {
+		return err
+	}
+	if err = r.AutoScaling.Validate(); err != nil {
+		return fmt.Errorf(`validate "auto_scaling": %w`, err)
+	}
 	return nil
 }
 
@@ -246,11 +540,36 @@ func (w WorkerService) Validate() error {
 	if err = validateContainerDeps(validateDependenciesOpts{
 		sidecarConfig:     w.Sidecars,
 		imageConfig:       w.ImageConfig.Image,
-		mainContainerName: aws.StringValue(w.Name),
+		healthCheck:       w.ImageConfig.HealthCheck,
+		mainContainerName: w.MainContainerName(aws.StringValue(w.Name)),
 		logging:           w.Logging,
+		initContainer:     w.InitContainer,
 	}); err != nil {
 		return fmt.Errorf("validate container dependencies: %w", err)
 	}
+	if err = validateFlags(w.Flags, w.Sidecars, w.Variables); err != nil {
+		return err
+	}
+	if err = validateSidecarMountPoints(w.Storage.Volumes, w.Sidecars); err != nil {
+		return err
+	}
+	if err = validateDenyDefaultSecurityGroup(w.Network.VPC, w.Storage.Volumes, false); err != nil {
+		return fmt.Errorf(`validate "network.vpc": %w`, err)
+	}
+	if err = validateServiceConnectPorts(w.Network.Connect, nil, w.Sidecars); err != nil {
+		return err
+	}
+	if err = validatePinnedSidecarImages(w.ImageConfig.Image, w.Sidecars, w.Logging); err != nil {
+		return err
+	}
+	if err = validateContainerCount(validateContainerCountOpts{
+		sidecarConfig: w.Sidecars,
+		logging:       w.Logging,
+		initContainer: w.InitContainer,
+		secrets:       w.TaskConfig.Secrets,
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -266,6 +585,9 @@ func (w WorkerServiceConfig) Validate() error {
 	if err = w.TaskConfig.Validate(); err != nil {
 		return err
 	}
+	if err = validateBuildPlatforms(w.ImageConfig.Image.Build, w.Platform); err != nil {
+		return err
+	}
 	if err = w.Logging.Validate(); err != nil {
 		return fmt.Errorf(`validate "logging": %w`, err)
 	}
@@ -288,22 +610,51 @@ func (w WorkerServiceConfig) Validate() error {
 			return fmt.Errorf(`validate "taskdef_overrides[%d]": %w`, ind, err)
 		}
 	}
+	if err = w.DeployConfig.Validate(); err != nil {
+		return fmt.Errorf(`validate "deployment": %w`, err)
+	}
+	if err = validateDeploymentProgress(w.Count, w.DeployConfig); err != nil {
+		return fmt.Errorf(`validate "deployment": %w`, err)
+	}
+	if w.DeployConfig.BlueGreen.Enabled() {
+		return errBlueGreenWithoutLoadBalancer
+	}
+	if err = w.InitContainer.Validate(); err != nil {
+		return fmt.Errorf(`validate "init_container": %w`, err)
+	}
 	if w.TaskConfig.IsWindows() {
 		if err = validateWindows(validateWindowsOpts{
-			execEnabled: aws.BoolValue(w.ExecuteCommand.Enable),
-			efsVolumes:  w.Storage.Volumes,
+			execEnabled:     w.ExecuteCommand.Enabled(),
+			efsVolumes:      w.Storage.Volumes,
+			fireLensEnabled: !w.Logging.IsEmpty(),
+			ulimits:         w.Ulimits,
+			sidecars:        w.Sidecars,
 		}); err != nil {
 			return fmt.Errorf(`validate Windows: %w`, err)
 		}
 	}
 	if w.TaskConfig.IsARM() {
 		if err = validateARM(validateARMOpts{
-			Spot:     w.Count.AdvancedCount.Spot,
-			SpotFrom: w.Count.AdvancedCount.Range.RangeConfig.SpotFrom,
+			Spot:              w.Count.AdvancedCount.Spot,
+			SpotFrom:          w.Count.AdvancedCount.Range.RangeConfig.SpotFrom,
+			CapacityProviders: w.Count.AdvancedCount.CapacityProviders,
 		}); err != nil {
 			return fmt.Errorf("validate ARM: %w", err)
 		}
 	}
+	if aws.BoolValue(w.AZRebalancing) {
+		if err = validateAZRebalancing(w.Count, minEnvAZCount); err != nil {
+			return fmt.Errorf(`validate "availability_zone_rebalancing": %w`, err)
+		}
+	}
+	if w.PropagateTags != nil {
+		if err = validatePropagateTags(aws.StringValue(w.PropagateTags)); err != nil {
+			return fmt.Errorf(`validate "propagate_tags": %w`, err)
+		}
+	}
+	if err = w.GitSHATag.Validate(); err != nil {
+		return fmt.Errorf(`validate "git_sha_tag": %w`, err)
+	}
 	return nil
 }
 
@@ -319,11 +670,34 @@ func (s ScheduledJob) Validate() error {
 	if err = validateContainerDeps(validateDependenciesOpts{
 		sidecarConfig:     s.Sidecars,
 		imageConfig:       s.ImageConfig.Image,
-		mainContainerName: aws.StringValue(s.Name),
+		healthCheck:       s.ImageConfig.HealthCheck,
+		mainContainerName: s.MainContainerName(aws.StringValue(s.Name)),
 		logging:           s.Logging,
 	}); err != nil {
 		return fmt.Errorf("validate container dependencies: %w", err)
 	}
+	if err = validateFlags(s.Flags, s.Sidecars, s.Variables); err != nil {
+		return err
+	}
+	if err = validateSidecarMountPoints(s.Storage.Volumes, s.Sidecars); err != nil {
+		return err
+	}
+	if err = validateDenyDefaultSecurityGroup(s.Network.VPC, s.Storage.Volumes, false); err != nil {
+		return fmt.Errorf(`validate "network.vpc": %w`, err)
+	}
+	if !s.Network.Connect.IsEmpty() {
+		return fmt.Errorf(`"network.connect" is not supported for %s`, ScheduledJobType)
+	}
+	if err = validatePinnedSidecarImages(s.ImageConfig.Image, s.Sidecars, s.Logging); err != nil {
+		return err
+	}
+	if err = validateContainerCount(validateContainerCountOpts{
+		sidecarConfig: s.Sidecars,
+		logging:       s.Logging,
+		secrets:       s.TaskConfig.Secrets,
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -339,6 +713,9 @@ func (s ScheduledJobConfig) Validate() error {
 	if err = s.TaskConfig.Validate(); err != nil {
 		return err
 	}
+	if err = validateBuildPlatforms(s.ImageConfig.Image.Build, s.Platform); err != nil {
+		return err
+	}
 	if err = s.Logging.Validate(); err != nil {
 		return fmt.Errorf(`validate "logging": %w`, err)
 	}
@@ -366,16 +743,20 @@ func (s ScheduledJobConfig) Validate() error {
 	}
 	if s.TaskConfig.IsWindows() {
 		if err = validateWindows(validateWindowsOpts{
-			execEnabled: aws.BoolValue(s.ExecuteCommand.Enable),
-			efsVolumes:  s.Storage.Volumes,
+			execEnabled:     s.ExecuteCommand.Enabled(),
+			efsVolumes:      s.Storage.Volumes,
+			fireLensEnabled: !s.Logging.IsEmpty(),
+			ulimits:         s.Ulimits,
+			sidecars:        s.Sidecars,
 		}); err != nil {
 			return fmt.Errorf(`validate Windows: %w`, err)
 		}
 	}
 	if s.TaskConfig.IsARM() {
 		if err = validateARM(validateARMOpts{
-			Spot:     s.Count.AdvancedCount.Spot,
-			SpotFrom: s.Count.AdvancedCount.Range.RangeConfig.SpotFrom,
+			Spot:              s.Count.AdvancedCount.Spot,
+			SpotFrom:          s.Count.AdvancedCount.Range.RangeConfig.SpotFrom,
+			CapacityProviders: s.Count.AdvancedCount.CapacityProviders,
 		}); err != nil {
 			return fmt.Errorf("validate ARM: %w", err)
 		}
@@ -419,9 +800,13 @@ func (i ImageWithHealthcheckAndOptionalPort) Validate() error {
 
 // Validate returns nil if ImageWithHealthcheck is configured correctly.
 func (i ImageWithHealthcheck) Validate() error {
-	if err := i.Image.Validate(); err != nil {
+	var err error
+	if err = i.Image.Validate(); err != nil {
 		return err
 	}
+	if err = i.HealthCheck.Validate(); err != nil {
+		return fmt.Errorf(`validate "healthcheck": %w`, err)
+	}
 	return nil
 }
 
@@ -459,9 +844,47 @@ func (i Image) Validate() error {
 			mustExist:   true,
 		}
 	}
+	if i.Credentials != nil {
+		if i.Location == nil {
+			return &errFieldMustBeSpecified{
+				missingField:      "location",
+				conditionalFields: []string{"credentials"},
+			}
+		}
+		if err = validateSecretsManagerARN(aws.StringValue(i.Credentials)); err != nil {
+			return fmt.Errorf(`validate "credentials": %w`, err)
+		}
+	}
 	if err = i.DependsOn.Validate(); err != nil {
 		return fmt.Errorf(`validate "depends_on": %w`, err)
 	}
+	if err = validateDockerLabels(i.DockerLabels); err != nil {
+		return fmt.Errorf(`validate "labels": %w`, err)
+	}
+	if aws.BoolValue(i.RequirePinnedTag) && i.Location != nil {
+		if err = validatePinnedImageTag(aws.StringValue(i.Location)); err != nil {
+			return fmt.Errorf(`validate "location": %w`, err)
+		}
+	}
+	return nil
+}
+
+// validateDockerLabels returns an error if a label key is empty or contains whitespace,
+// which "docker build --label" and ECS would both reject.
+func validateDockerLabels(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("label key cannot be empty")
+		}
+		if strings.IndexFunc(key, unicode.IsSpace) != -1 {
+			return fmt.Errorf("label key %q cannot contain spaces", key)
+		}
+	}
 	return nil
 }
 
@@ -470,17 +893,14 @@ func (d DependsOn) Validate() error {
 	if d == nil {
 		return nil
 	}
-	for _, v := range d {
-		status := strings.ToUpper(v)
-		var isValid bool
-		for _, allowed := range dependsOnValidStatuses {
-			if status == allowed {
-				isValid = true
-				break
-			}
-		}
-		if !isValid {
-			return fmt.Errorf("container dependency status must be one of %s", english.WordSeries([]string{dependsOnStart, dependsOnComplete, dependsOnSuccess, dependsOnHealthy}, "or"))
+	containers := make([]string, 0, len(d))
+	for container := range d {
+		containers = append(containers, container)
+	}
+	sort.Strings(containers)
+	for _, container := range containers {
+		if !contains(strings.ToUpper(d[container]), dependsOnValidStatuses) {
+			return fmt.Errorf("container dependency status %q for %s must be one of %s", d[container], container, english.WordSeries(dependsOnValidStatuses, "or"))
 		}
 	}
 	return nil
@@ -498,142 +918,882 @@ func (b BuildArgsOrString) Validate() error {
 }
 
 // Validate returns nil if DockerBuildArgs is configured correctly.
-func (DockerBuildArgs) Validate() error {
-	return nil
-}
-
-// Validate returns nil if ContainerHealthCheck is configured correctly.
-func (ContainerHealthCheck) Validate() error {
-	return nil
-}
-
-// Validate returns nil if ImageOverride is configured correctly.
-func (i ImageOverride) Validate() error {
-	var err error
-	if err = i.EntryPoint.Validate(); err != nil {
-		return fmt.Errorf(`validate "entrypoint": %w`, err)
+func (b DockerBuildArgs) Validate() error {
+	seen := make(map[string]bool)
+	for ind, platform := range b.Platforms {
+		if err := platform.Validate(); err != nil {
+			return fmt.Errorf(`validate "platforms[%d]": %w`, ind, err)
+		}
+		if seen[platform.platformString()] {
+			return fmt.Errorf(`platform %s is specified more than once in "platforms"`, platform.platformString())
+		}
+		seen[platform.platformString()] = true
 	}
-	if err = i.Command.Validate(); err != nil {
-		return fmt.Errorf(`validate "command": %w`, err)
+	if b.Network != nil && !contains(aws.StringValue(b.Network), buildNetworkModes) {
+		return fmt.Errorf(`"network" field value '%s' must be one of %s`, aws.StringValue(b.Network), english.WordSeries(buildNetworkModes, "or"))
 	}
-	return nil
+	for ind, ssh := range b.SSH {
+		if err := validateBuildSSH(ssh); err != nil {
+			return fmt.Errorf(`validate "ssh[%d]": %w`, ind, err)
+		}
+	}
+	ids := make([]string, 0, len(b.Secrets))
+	for id := range b.Secrets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := validateBuildSecret(b.Secrets[id]); err != nil {
+			return fmt.Errorf(`validate "secrets[%s]": %w`, id, err)
+		}
+	}
+	if err := validateCacheFromImages(b.CacheFrom); err != nil {
+		return err
+	}
+	return b.validateCacheFrom()
 }
 
-// Validate returns nil if EntryPointOverride is configured correctly.
-func (EntryPointOverride) Validate() error {
+func validateCacheFromImages(images []string) error {
+	for ind, image := range images {
+		if err := validateCacheFromImage(image); err != nil {
+			return fmt.Errorf(`validate "cache_from[%d]": %w`, ind, err)
+		}
+	}
 	return nil
 }
 
-// Validate returns nil if CommandOverride is configured correctly.
-func (CommandOverride) Validate() error {
+// validateCacheFromImage returns an error if the image is not a valid image reference.
+// The "${ENV}" and "${TAG}" tokens are resolved when the image is built, so they are checked as a valid environment name and tag.
+func validateCacheFromImage(image string) error {
+	if image == "" {
+		return errors.New("image cannot be an empty string")
+	}
+	resolved := strings.NewReplacer(buildEnvToken, "env", cacheFromTagToken, "tag").Replace(image)
+	if !imageReferenceRegexp.MatchString(resolved) {
+		return fmt.Errorf("%q is not a valid image reference", image)
+	}
 	return nil
 }
 
-// Validate returns nil if RoutingRule is configured correctly.
-func (r RoutingRule) Validate() error {
-	var err error
-	if err = r.HealthCheck.Validate(); err != nil {
-		return fmt.Errorf(`validate "healthcheck": %w`, err)
-	}
-	if err = r.Alias.Validate(); err != nil {
-		return fmt.Errorf(`validate "alias": %w`, err)
-	}
-	if r.TargetContainer != nil && r.TargetContainerCamelCase != nil {
-		return &errFieldMutualExclusive{
-			firstField:  "target_container",
-			secondField: "targetContainer",
-		}
+// validateBuildSSH returns nil if ssh is "default" or of the form "id=path[,path]".
+func validateBuildSSH(ssh string) error {
+	if ssh == defaultBuildSSH {
+		return nil
 	}
-	for ind, ip := range r.AllowedSourceIps {
-		if err = ip.Validate(); err != nil {
-			return fmt.Errorf(`validate "allowed_source_ips[%d]": %w`, ind, err)
-		}
+	parts := strings.SplitN(ssh, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf(`%q must be "%s" or of the form "id=path"`, ssh, defaultBuildSSH)
 	}
-	if r.ProtocolVersion != nil {
-		if !contains(strings.ToUpper(*r.ProtocolVersion), httpProtocolVersions) {
-			return fmt.Errorf(`"version" field value '%s' must be one of %s`, *r.ProtocolVersion, english.WordSeries(httpProtocolVersions, "or"))
+	for _, path := range strings.Split(parts[1], ",") {
+		if path == "" {
+			return fmt.Errorf(`%q must be "%s" or of the form "id=path"`, ssh, defaultBuildSSH)
 		}
 	}
 	return nil
 }
 
-// Validate returns nil if HealthCheckArgsOrString is configured correctly.
-func (h HealthCheckArgsOrString) Validate() error {
-	if h.IsEmpty() {
-		return nil
+// validateBuildSecret returns nil if src is a file path or a reference to an environment variable.
+func validateBuildSecret(src string) error {
+	if src == "" {
+		return fmt.Errorf(`must be a file path or an environment variable of the form "%sNAME"`, BuildSecretEnvPrefix)
 	}
-	return h.HealthCheckArgs.Validate()
-}
-
-// Validate returns nil if HTTPHealthCheckArgs is configured correctly.
-func (h HTTPHealthCheckArgs) Validate() error {
-	if h.isEmpty() {
+	if !strings.HasPrefix(src, BuildSecretEnvPrefix) {
 		return nil
 	}
+	if name := strings.TrimPrefix(src, BuildSecretEnvPrefix); !envVarNameRegexp.MatchString(name) {
+		return fmt.Errorf(`%q is not a valid environment variable name`, name)
+	}
 	return nil
 }
 
-// Validate returns nil if Alias is configured correctly.
-func (Alias) Validate() error {
-	return nil
+// Validate returns nil if PlatformBuildArgs is configured correctly.
+func (p PlatformBuildArgs) Validate() error {
+	if err := p.PlatformArgs.Validate(); err != nil {
+		return err
+	}
+	return validateCacheFromImages(p.CacheFrom)
 }
 
-// Validate returns nil if IPNet is configured correctly.
-func (ip IPNet) Validate() error {
-	if _, _, err := net.ParseCIDR(string(ip)); err != nil {
-		return fmt.Errorf("parse IPNet %s: %w", string(ip), err)
+// Validate returns nil if ContainerHealthCheck is configured correctly.
+func (hc ContainerHealthCheck) Validate() error {
+	if hc.IsEmpty() {
+		return nil
+	}
+	if hc.GRPC != nil {
+		if hc.Command != nil {
+			return &errFieldMutualExclusive{
+				firstField:  "command",
+				secondField: "grpc",
+			}
+		}
+		if err := hc.GRPC.Validate(); err != nil {
+			return fmt.Errorf(`validate "grpc": %w`, err)
+		}
+	}
+	defaulted := hc.Defaulted()
+	if *defaulted.Timeout >= *defaulted.Interval {
+		return fmt.Errorf(`"timeout" %s must be less than "interval" %s`, defaulted.Timeout, defaulted.Interval)
+	}
+	if startPeriod := *defaulted.StartPeriod; startPeriod < 0 || startPeriod > maxHealthCheckStartPeriod {
+		return fmt.Errorf(`"start_period" %s must be between 0s and %s`, startPeriod, maxHealthCheckStartPeriod)
 	}
 	return nil
 }
 
-// Validate returns nil if NetworkLoadBalancerConfiguration is configured correctly.
-func (c NetworkLoadBalancerConfiguration) Validate() error {
-	if c.IsEmpty() {
-		return nil
-	}
-	if aws.StringValue(c.Port) == "" {
+// Validate returns nil if GRPCHealthCheck is configured correctly.
+func (g GRPCHealthCheck) Validate() error {
+	if g.Port == nil {
 		return &errFieldMustBeSpecified{
 			missingField: "port",
 		}
 	}
-	if err := c.HealthCheck.Validate(); err != nil {
-		return fmt.Errorf(`validate "healthcheck": %w`, err)
+	if aws.Uint16Value(g.Port) == 0 {
+		return fmt.Errorf(`"port" must be between 1 and 65535`)
 	}
 	return nil
 }
 
-// Validate returns nil if TaskConfig is configured correctly.
-func (t TaskConfig) Validate() error {
-	var err error
-	if err = t.Platform.Validate(); err != nil {
-		return fmt.Errorf(`validate "platform": %w`, err)
+// Validate returns nil if DeploymentConfig is configured correctly.
+func (d DeploymentConfig) Validate() error {
+	if err := d.PreDeploy.Validate(); err != nil {
+		return fmt.Errorf(`validate "pre_deploy": %w`, err)
 	}
-	if err = t.Count.Validate(); err != nil {
-		return fmt.Errorf(`validate "count": %w`, err)
-	}
-	if err = t.ExecuteCommand.Validate(); err != nil {
-		return fmt.Errorf(`validate "exec": %w`, err)
-	}
-	if err = t.Storage.Validate(); err != nil {
-		return fmt.Errorf(`validate "storage": %w`, err)
+	if err := d.RollbackAlarms.Validate(); err != nil {
+		return fmt.Errorf(`validate "rollback_alarms": %w`, err)
+	}
+	if d.BlueGreen.Enabled() {
+		if err := d.BlueGreen.Config.Validate(); err != nil {
+			return fmt.Errorf(`validate "bluegreen": %w`, err)
+		}
+		if d.Strategy != nil {
+			return &errFieldMutualExclusive{
+				firstField:  "bluegreen",
+				secondField: "strategy",
+			}
+		}
+		if !d.RollbackAlarms.IsEmpty() {
+			return &errFieldMutualExclusive{
+				firstField:  "bluegreen",
+				secondField: "rollback_alarms",
+			}
+		}
+	}
+	if d.Strategy != nil && !contains(aws.StringValue(d.Strategy), deploymentStrategies) {
+		return fmt.Errorf(`"strategy" value "%s" must be one of %s`, aws.StringValue(d.Strategy), english.WordSeries(deploymentStrategies, "or"))
+	}
+	if d.IsRecreate() && d.MinHealthyPercent != nil {
+		return &errFieldMutualExclusive{
+			firstField:  "strategy: recreate",
+			secondField: "min_healthy_percent",
+		}
+	}
+	if d.IsRecreate() && d.MaxPercent != nil {
+		return &errFieldMutualExclusive{
+			firstField:  "strategy: recreate",
+			secondField: "max_percent",
+		}
+	}
+	if d.MinHealthyPercent != nil {
+		if v := aws.IntValue(d.MinHealthyPercent); v < 0 || v > 100 {
+			return fmt.Errorf(`"min_healthy_percent" value %d must be between 0 and 100`, v)
+		}
+	}
+	if d.MaxPercent != nil {
+		if v := aws.IntValue(d.MaxPercent); v < 100 {
+			return fmt.Errorf(`"max_percent" value %d must be at least 100`, v)
+		}
+	}
+	if d.minHealthyPercent() == 100 && d.maxPercent() == 100 {
+		return errors.New(`"min_healthy_percent" and "max_percent" cannot both be 100, otherwise ECS can neither start nor stop a task during a deployment`)
+	}
+	return nil
+}
+
+// Validate returns nil if BlueGreenConfig is configured correctly.
+func (b BlueGreenConfig) Validate() error {
+	if b.TerminationWait != nil {
+		if wait := *b.TerminationWait; wait < 0 || wait > maxBlueGreenTerminationWait || wait%time.Minute != 0 {
+			return fmt.Errorf(`"termination_wait" %s must be a whole number of minutes between 0m and %s`, wait, maxBlueGreenTerminationWait)
+		}
+	}
+	if b.TestTrafficRoute != nil {
+		switch port := aws.Uint16Value(b.TestTrafficRoute); port {
+		case 0:
+			return errors.New(`"test_traffic_route" must be greater than 0`)
+		case 80, 443:
+			return fmt.Errorf(`"test_traffic_route" %d cannot be a port of the production listeners`, port)
+		}
+	}
+	arns := b.Hooks.FunctionARNs()
+	events := make([]string, 0, len(arns))
+	for event := range arns {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+	for _, event := range events {
+		if err := validateLambdaFunctionARN(arns[event]); err != nil {
+			return fmt.Errorf(`validate "hooks": %w`, err)
+		}
+	}
+	return nil
+}
+
+// validateDeploymentProgress returns an error if a deployment of a fixed number of tasks can neither start a task
+// above the desired count nor stop a task below it. For example, a single task requires a "max_percent" of 200 to be
+// replaced without going below a "min_healthy_percent" of 100.
+func validateDeploymentProgress(count Count, d DeploymentConfig) error {
+	if d.MinHealthyPercent == nil && d.MaxPercent == nil {
+		return nil
+	}
+	desired := aws.IntValue(count.Value)
+	if desired == 0 {
+		return nil
+	}
+	maxTasks := desired * d.maxPercent() / 100             // ECS rounds the upper limit down.
+	minTasks := (desired*d.minHealthyPercent() + 99) / 100 // ECS rounds the lower limit up.
+	if maxTasks > desired || minTasks < desired {
+		return nil
+	}
+	return fmt.Errorf(`"min_healthy_percent" %d and "max_percent" %d don't allow ECS to start or stop any of the %d tasks of "count" during a deployment`,
+		d.minHealthyPercent(), d.maxPercent(), desired)
+}
+
+// Validate returns nil if AlarmArgsOrNames is configured correctly.
+func (a AlarmArgsOrNames) Validate() error {
+	if a.IsEmpty() {
+		return nil
+	}
+	if !a.AlarmArgs.IsEmpty() {
+		return a.AlarmArgs.Validate()
+	}
+	if len(a.AlarmNames) == 0 {
+		return errors.New("at least one alarm name must be specified")
+	}
+	names := make(map[string]bool, len(a.AlarmNames))
+	for _, name := range a.AlarmNames {
+		if name == "" {
+			return errors.New("alarm names must not be empty")
+		}
+		if names[name] {
+			return fmt.Errorf("alarm %q is specified more than once", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// Validate returns nil if AlarmArgs is configured correctly.
+func (a AlarmArgs) Validate() error {
+	thresholds := []struct {
+		field string
+		value *float64
+	}{
+		{field: "cpu_utilization", value: a.CPUUtilization},
+		{field: "memory_utilization", value: a.MemoryUtilization},
+	}
+	for _, threshold := range thresholds {
+		if threshold.value == nil {
+			continue
+		}
+		if v := aws.Float64Value(threshold.value); v < 0 || v > 100 {
+			return fmt.Errorf(`"%s" value %v must be a percentage between 0 and 100`, threshold.field, v)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if InitContainer is configured correctly.
+func (c InitContainer) Validate() error {
+	if c.IsEmpty() {
+		return nil
+	}
+	if err := c.Image.Validate(); err != nil {
+		return fmt.Errorf(`validate "image": %w`, err)
+	}
+	if err := c.Command.Validate(); err != nil {
+		return fmt.Errorf(`validate "command": %w`, err)
+	}
+	return nil
+}
+
+// Validate returns nil if Readiness is configured correctly.
+func (r Readiness) Validate() error {
+	if r.IsEmpty() {
+		return nil
+	}
+	if r.URL == nil {
+		return &errFieldMustBeSpecified{
+			missingField:      "url",
+			conditionalFields: []string{"status"},
+		}
+	}
+	u, err := url.Parse(aws.StringValue(r.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf(`"url" value %q must be an absolute http or https URL`, aws.StringValue(r.URL))
+	}
+	if r.Status != nil {
+		if status := aws.IntValue(r.Status); status < 100 || status > 599 {
+			return fmt.Errorf(`"status" value %d must be an HTTP status code from 100 to 599`, status)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if PreDeployTask is configured correctly.
+func (t PreDeployTask) Validate() error {
+	if t.IsEmpty() {
+		return nil
+	}
+	if aws.StringValue(t.Image) == "" {
+		return &errFieldMustBeSpecified{
+			missingField: "image",
+		}
+	}
+	command, err := t.Command.ToStringSlice()
+	if err != nil {
+		return fmt.Errorf(`validate "command": %w`, err)
+	}
+	if len(command) == 0 {
+		return &errFieldMustBeSpecified{
+			missingField: "command",
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if ImageOverride is configured correctly.
+func (i ImageOverride) Validate() error {
+	var err error
+	if err = i.EntryPoint.Validate(); err != nil {
+		return fmt.Errorf(`validate "entrypoint": %w`, err)
+	}
+	if err = i.Command.Validate(); err != nil {
+		return fmt.Errorf(`validate "command": %w`, err)
+	}
+	names := make([]string, 0, len(i.Ulimits))
+	for name := range i.Ulimits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !contains(name, ulimitNames) {
+			return fmt.Errorf(`validate "ulimits": ulimit "%s" must be one of %s`, name, english.WordSeries(ulimitNames, "or"))
+		}
+		if err = i.Ulimits[name].Validate(); err != nil {
+			return fmt.Errorf(`validate ulimit "%s": %w`, name, err)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if Ulimit is configured correctly.
+func (u Ulimit) Validate() error {
+	if u.Soft == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "soft",
+		}
+	}
+	if u.Hard == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "hard",
+		}
+	}
+	if aws.IntValue(u.Hard) < aws.IntValue(u.Soft) {
+		return fmt.Errorf(`"hard" limit %d must be greater than or equal to "soft" limit %d`, aws.IntValue(u.Hard), aws.IntValue(u.Soft))
+	}
+	return nil
+}
+
+// Validate returns nil if EntryPointOverride is configured correctly.
+func (e EntryPointOverride) Validate() error {
+	_, err := e.ToStringSlice()
+	return err
+}
+
+// Validate returns nil if CommandOverride is configured correctly.
+func (c CommandOverride) Validate() error {
+	_, err := c.ToStringSlice()
+	return err
+}
+
+// Validate returns nil if RoutingRule is configured correctly.
+func (r RoutingRule) Validate() error {
+	var err error
+	if err = r.HealthCheck.Validate(); err != nil {
+		return fmt.Errorf(`validate "healthcheck": %w`, err)
+	}
+	if err = r.HealthCheck.HealthCheckArgs.validateTargetGroup(); err != nil {
+		return fmt.Errorf(`validate "healthcheck": %w`, err)
+	}
+	if err = r.Alias.Validate(); err != nil {
+		return fmt.Errorf(`validate "alias": %w`, err)
+	}
+	if err = r.AliasRouting.Validate(); err != nil {
+		return fmt.Errorf(`validate "alias_routing": %w`, err)
+	}
+	if !r.AliasRouting.IsEmpty() && r.Alias.IsEmpty() {
+		return &errFieldMustBeSpecified{
+			missingField:      "alias",
+			conditionalFields: []string{"alias_routing"},
+		}
+	}
+	if !r.AliasRouting.IsEmpty() && r.Alias.HostedZoneAliases() != nil {
+		return &errFieldMutualExclusive{
+			firstField:  "alias_routing",
+			secondField: "alias.hosted_zone",
+		}
+	}
+	if r.TargetContainer != nil && r.TargetContainerCamelCase != nil {
+		return &errFieldMutualExclusive{
+			firstField:  "target_container",
+			secondField: "targetContainer",
+		}
+	}
+	if target := r.targetContainer(); target != nil && aws.StringValue(target) == "" {
+		return errors.New(`"target_container" cannot be an empty string`)
+	}
+	if r.TargetPort != nil && aws.Uint16Value(r.TargetPort) == 0 {
+		return errors.New(`"target_port" must be greater than 0`)
+	}
+	for ind, ip := range r.AllowedSourceIps {
+		if err = ip.Validate(); err != nil {
+			return fmt.Errorf(`validate "allowed_source_ips[%d]": %w`, ind, err)
+		}
+	}
+	if r.ProtocolVersion != nil {
+		if !contains(strings.ToUpper(*r.ProtocolVersion), httpProtocolVersions) {
+			return fmt.Errorf(`"version" field value '%s' must be one of %s`, *r.ProtocolVersion, english.WordSeries(httpProtocolVersions, "or"))
+		}
+	}
+	if r.HostnameVariable != nil {
+		if err = validateHostnameVariable(aws.StringValue(r.HostnameVariable)); err != nil {
+			return fmt.Errorf(`validate "hostname_variable": %w`, err)
+		}
+	}
+	if err = r.validateAdditionalRules(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r RoutingRule) validateAdditionalRules() error {
+	if len(r.AdditionalRules) == 0 {
+		return nil
+	}
+	aliases, err := r.Alias.ToStringSlice()
+	if err != nil {
+		return fmt.Errorf(`convert "alias" to string slice: %w`, err)
+	}
+	paths := map[string]bool{
+		normalizedRulePath(aws.StringValue(r.Path)): true,
+	}
+	for ind, rule := range r.AdditionalRules {
+		if err := rule.validateAdditionalRule(aliases); err != nil {
+			return fmt.Errorf(`validate "additional_rules[%d]": %w`, ind, err)
+		}
+		rulePath := normalizedRulePath(aws.StringValue(rule.Path))
+		if paths[rulePath] {
+			return fmt.Errorf(`validate "additional_rules[%d]": "path" %s is already routed by another rule`, ind, aws.StringValue(rule.Path))
+		}
+		paths[rulePath] = true
+	}
+	return nil
+}
+
+// validateInternal returns nil if the routing rule of a service behind the internal load balancer is configured correctly.
+// The internal load balancer only has an HTTP listener, so the fields of aliases and HTTPS are not supported.
+func (r RoutingRule) validateInternal() error {
+	if r.IsEmpty() {
+		return nil
+	}
+	unsupported := RoutingRule{
+		Alias:            r.Alias,
+		AliasRouting:     r.AliasRouting,
+		RedirectToHTTPS:  r.RedirectToHTTPS,
+		HostnameVariable: r.HostnameVariable,
+		AdditionalRules:  r.AdditionalRules,
+	}
+	if !unsupported.IsEmpty() {
+		return errors.New(`"alias", "alias_routing", "redirect_to_https", "hostname_variable" and "additional_rules" are not supported behind the internal load balancer`)
+	}
+	if r.Path == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "path",
+		}
+	}
+	return r.Validate()
+}
+
+// validateAdditionalRule returns nil if a rule of "additional_rules" is configured correctly.
+// The aliases of the rule must be aliases of the service so that they're covered by its certificate and records.
+func (r RoutingRule) validateAdditionalRule(svcAliases []string) error {
+	unsupported := r
+	unsupported.Path, unsupported.Alias, unsupported.AllowedSourceIps = nil, Alias{}, nil
+	if !reflect.DeepEqual(unsupported, RoutingRule{}) {
+		return errors.New(`only "path", "alias" and "allowed_source_ips" can be specified`)
+	}
+	if r.Path == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "path",
+		}
+	}
+	if normalizedRulePath(aws.StringValue(r.Path)) == "" {
+		return errors.New(`"path" cannot be the root path, the rule of "http.path" routes the other requests`)
+	}
+	if r.Alias.HostedZoneAliases() != nil {
+		return errors.New(`"alias.hosted_zone" can only be specified in "http.alias"`)
+	}
+	aliases, err := r.Alias.ToStringSlice()
+	if err != nil {
+		return fmt.Errorf(`convert "alias" to string slice: %w`, err)
+	}
+	for _, alias := range aliases {
+		if !contains(alias, svcAliases) {
+			return fmt.Errorf(`"alias" %s must be one of the aliases of "http.alias"`, alias)
+		}
+	}
+	for ind, ip := range r.AllowedSourceIps {
+		if err := ip.Validate(); err != nil {
+			return fmt.Errorf(`validate "allowed_source_ips[%d]": %w`, ind, err)
+		}
+	}
+	return nil
+}
+
+// normalizedRulePath returns the path of a listener rule without its leading and trailing slashes.
+func normalizedRulePath(rulePath string) string {
+	return strings.Trim(rulePath, "/")
+}
+
+// validateReservedEnvVarName returns an error if the environment variable name starts with the prefix
+// of the variables injected by Copilot.
+func validateReservedEnvVarName(name, prefix string) error {
+	if strings.HasPrefix(name, prefix) {
+		return fmt.Errorf("environment variable names cannot start with %q", prefix)
+	}
+	return nil
+}
+
+// validateLoadBalancedIPFamily returns an error if a service behind a load balancer sets "ip_family" to "ipv6".
+// The load balancers of an environment only have IPv4 addresses, so they can't forward requests to IPv6 targets.
+func validateLoadBalancedIPFamily(network NetworkConfig) error {
+	if strings.ToLower(aws.StringValue(network.VPC.IPFamily)) == IPFamilyIPv6 {
+		return fmt.Errorf(`"ip_family" %s is not supported for services behind a load balancer, use %s instead`, IPFamilyIPv6, IPFamilyDualStack)
+	}
+	return nil
+}
+
+// validateInjectedEnvVarName returns an error if the variable that Copilot injects under name is also defined under "variables".
+func validateInjectedEnvVarName(name string, vars Variables) error {
+	if vars.has(name) {
+		return fmt.Errorf(`environment variable "%s" is also defined under "variables"`, name)
+	}
+	return nil
+}
+
+func validateBuildPlatforms(build BuildArgsOrString, platform PlatformArgsOrString) error {
+	if len(build.BuildArgs.Platforms) != 0 && !platform.IsEmpty() {
+		return &errFieldMutualExclusive{
+			firstField:  "build.platforms",
+			secondField: "platform",
+		}
+	}
+	if len(build.BuildArgs.Platforms) == 0 {
+		return nil
+	}
+	// The tasks of a workload with "build.platforms" run on the default platform, so its image must be built for it.
+	for _, p := range build.BuildArgs.Platforms {
+		if platform := p.platformString(); platform == defaultPlatform || platform == platformString(OSLinux, ArchX86) {
+			return nil
+		}
+	}
+	return fmt.Errorf(`"build.platforms" must include the platform %s that the tasks run on`, defaultPlatform)
+}
+
+func validateHostnameVariable(name string) error {
+	if !envVarNameRegexp.MatchString(name) {
+		return fmt.Errorf("%q is not a valid environment variable name", name)
+	}
+	return nil
+}
+
+// Validate returns nil if HealthCheckArgsOrString is configured correctly.
+func (h HealthCheckArgsOrString) Validate() error {
+	if h.IsEmpty() {
+		return nil
+	}
+	return h.HealthCheckArgs.Validate()
+}
+
+// Validate returns nil if HTTPHealthCheckArgs is configured correctly.
+func (h HTTPHealthCheckArgs) Validate() error {
+	if h.isEmpty() {
+		return nil
+	}
+	return nil
+}
+
+// validateTargetGroup returns nil if HTTPHealthCheckArgs can configure the health check of a load balancer target group.
+func (h HTTPHealthCheckArgs) validateTargetGroup() error {
+	if h.Port != nil && aws.Uint16Value(h.Port) == 0 {
+		return errors.New(`"port" must be greater than 0`)
+	}
+	if h.HealthyThreshold != nil {
+		if threshold := aws.Int64Value(h.HealthyThreshold); threshold < minHealthCheckThreshold || threshold > maxHealthCheckThreshold {
+			return fmt.Errorf(`"healthy_threshold" %d must be between %d and %d`, threshold, minHealthCheckThreshold, maxHealthCheckThreshold)
+		}
+	}
+	if h.UnhealthyThreshold != nil {
+		if threshold := aws.Int64Value(h.UnhealthyThreshold); threshold < minHealthCheckThreshold || threshold > maxHealthCheckThreshold {
+			return fmt.Errorf(`"unhealthy_threshold" %d must be between %d and %d`, threshold, minHealthCheckThreshold, maxHealthCheckThreshold)
+		}
+	}
+	if h.Timeout != nil && h.Interval != nil && *h.Timeout >= *h.Interval {
+		return fmt.Errorf(`"timeout" %s must be less than "interval" %s`, *h.Timeout, *h.Interval)
+	}
+	return nil
+}
+
+// Validate returns nil if AliasRouting is configured correctly.
+func (a AliasRouting) Validate() error {
+	if a.IsEmpty() {
+		return nil
+	}
+	if aws.StringValue(a.HostedZone) == "" {
+		return &errFieldMustBeSpecified{
+			missingField: "hosted_zone",
+		}
+	}
+	switch policy := aws.StringValue(a.Policy); policy {
+	case AliasRoutingPolicyWeighted:
+		if a.Weight == nil {
+			return &errFieldMustBeSpecified{
+				missingField: "weight",
+			}
+		}
+		if weight := aws.IntValue(a.Weight); weight < 0 || weight > 255 {
+			return fmt.Errorf(`"weight" must be between 0 and 255`)
+		}
+		if a.Failover != nil {
+			return fmt.Errorf(`"failover" cannot be specified with a "%s" routing policy`, policy)
+		}
+	case AliasRoutingPolicyFailover:
+		if a.Failover == nil {
+			return &errFieldMustBeSpecified{
+				missingField: "failover",
+			}
+		}
+		if !contains(aws.StringValue(a.Failover), aliasFailoverRoles) {
+			return fmt.Errorf(`"failover" value "%s" must be one of %s`, aws.StringValue(a.Failover), english.WordSeries(aliasFailoverRoles, "or"))
+		}
+		if a.Weight != nil {
+			return fmt.Errorf(`"weight" cannot be specified with a "%s" routing policy`, policy)
+		}
+	case "":
+		return &errFieldMustBeSpecified{
+			missingField: "policy",
+		}
+	default:
+		return fmt.Errorf(`"policy" value "%s" must be one of %s`, policy, english.WordSeries(aliasRoutingPolicies, "or"))
+	}
+	return nil
+}
+
+// Validate returns nil if Alias is configured correctly.
+func (a Alias) Validate() error {
+	for ind, alias := range a.AdvancedAliases {
+		if err := alias.Validate(); err != nil {
+			return fmt.Errorf(`validate "alias[%d]": %w`, ind, err)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if AdvancedAlias is configured correctly.
+func (a AdvancedAlias) Validate() error {
+	if aws.StringValue(a.Alias) == "" {
+		return &errFieldMustBeSpecified{
+			missingField: "name",
+		}
+	}
+	if a.HostedZone != nil && !hostedZoneIDRegexp.MatchString(aws.StringValue(a.HostedZone)) {
+		return fmt.Errorf(`"hosted_zone" value "%s" must be a hosted zone ID such as "Z0873220N255IR3MTNR4"`, aws.StringValue(a.HostedZone))
+	}
+	return nil
+}
+
+// Validate returns nil if IPNet is configured correctly.
+func (ip IPNet) Validate() error {
+	if _, _, err := net.ParseCIDR(string(ip)); err != nil {
+		return fmt.Errorf("parse IPNet %s: %w", string(ip), err)
+	}
+	return nil
+}
+
+// Validate returns nil if NetworkLoadBalancerConfiguration is configured correctly.
+func (c NetworkLoadBalancerConfiguration) Validate() error {
+	if c.IsEmpty() {
+		return nil
+	}
+	if aws.StringValue(c.Port) == "" {
+		return &errFieldMustBeSpecified{
+			missingField: "port",
+		}
+	}
+	if err := c.HealthCheck.Validate(); err != nil {
+		return fmt.Errorf(`validate "healthcheck": %w`, err)
+	}
+	if err := validateNLBStickiness(aws.StringValue(c.Port), c.Stickiness); err != nil {
+		return err
+	}
+	isUDP := strings.HasSuffix(strings.ToLower(aws.StringValue(c.Port)), "/udp")
+	// Client IP preservation is always on for UDP target groups and can't be turned off.
+	if c.PreserveClientIP != nil && !aws.BoolValue(c.PreserveClientIP) && isUDP {
+		return fmt.Errorf(`"preserve_client_ip" cannot be disabled for the UDP target group of port %s`, aws.StringValue(c.Port))
+	}
+	if len(c.AdditionalListeners) > maxNLBAdditionalListeners {
+		return fmt.Errorf(`validate "additional_listeners": at most %d additional listeners can be specified because an ECS service can register at most %d target groups`, maxNLBAdditionalListeners, maxServiceTargetGroups)
+	}
+	listenerPorts := map[string]bool{
+		nlbListenerPortNumber(aws.StringValue(c.Port)): true,
+	}
+	for idx, listener := range c.AdditionalListeners {
+		if err := listener.Validate(); err != nil {
+			return fmt.Errorf(`validate "additional_listeners[%d]": %w`, idx, err)
+		}
+		port := nlbListenerPortNumber(aws.StringValue(listener.Port))
+		if listenerPorts[port] {
+			return fmt.Errorf(`validate "additional_listeners[%d]": port %s is already used by another listener`, idx, port)
+		}
+		listenerPorts[port] = true
+	}
+	return nil
+}
+
+// Validate returns nil if NetworkLoadBalancerListener is configured correctly.
+func (l NetworkLoadBalancerListener) Validate() error {
+	if aws.StringValue(l.Port) == "" {
+		return &errFieldMustBeSpecified{
+			missingField: "port",
+		}
+	}
+	if err := l.HealthCheck.Validate(); err != nil {
+		return fmt.Errorf(`validate "healthcheck": %w`, err)
+	}
+	return validateNLBStickiness(aws.StringValue(l.Port), l.Stickiness)
+}
+
+// validateNLBStickiness returns an error if stickiness is enabled for the listener of a port of the form "port[/protocol]"
+// that doesn't use TCP. Sticky sessions are not supported with TLS listeners, and the UDP target groups don't support them either.
+func validateNLBStickiness(port string, stickiness *bool) error {
+	if !aws.BoolValue(stickiness) {
+		return nil
+	}
+	parts := strings.Split(port, "/")
+	if len(parts) == 1 || strings.ToLower(parts[1]) == "tcp" {
+		return nil
+	}
+	return fmt.Errorf(`"stickiness" can only be enabled for TCP listeners, not for the %s listener of port %s`, strings.ToUpper(parts[1]), port)
+}
+
+// nlbListenerPortNumber returns the port number of a network load balancer listener of the form "port[/protocol]".
+// A network load balancer can't have two listeners on the same port, even with different protocols.
+func nlbListenerPortNumber(port string) string {
+	return strings.Split(port, "/")[0]
+}
+
+// Validate returns nil if TaskConfig is configured correctly.
+func (t TaskConfig) Validate() error {
+	var err error
+	if err = t.Platform.Validate(); err != nil {
+		return fmt.Errorf(`validate "platform": %w`, err)
+	}
+	if err = t.Count.Validate(); err != nil {
+		return fmt.Errorf(`validate "count": %w`, err)
+	}
+	if err = t.ExecuteCommand.Validate(); err != nil {
+		return fmt.Errorf(`validate "exec": %w`, err)
+	}
+	if err = t.Storage.Validate(); err != nil {
+		return fmt.Errorf(`validate "storage": %w`, err)
+	}
+	if t.ContainerName != nil {
+		if err = validateContainerName(aws.StringValue(t.ContainerName)); err != nil {
+			return fmt.Errorf(`validate "container_name": %w`, err)
+		}
+	}
+	if t.EnvVarPrefix != nil {
+		if err = validateEnvVarPrefix(aws.StringValue(t.EnvVarPrefix)); err != nil {
+			return fmt.Errorf(`validate "env_var_prefix": %w`, err)
+		}
+	}
+	for name, secret := range t.Secrets {
+		if err = secret.Validate(); err != nil {
+			return fmt.Errorf(`validate secret "%s": %w`, name, err)
+		}
+	}
+	if err = t.Variables.Validate(); err != nil {
+		return fmt.Errorf(`validate "variables": %w`, err)
+	}
+	for _, name := range t.Variables.names() {
+		if err = validateReservedEnvVarName(name, t.InjectedEnvVarPrefix()); err != nil {
+			return fmt.Errorf(`validate "variables.%s": %w`, name, err)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if Variables is configured correctly.
+func (v Variables) Validate() error {
+	keys := make([]string, 0, len(v.FromSSM))
+	for key := range v.FromSSM {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := validateSSMParameterName(v.FromSSM[key]); err != nil {
+			return fmt.Errorf(`validate "%s.from_ssm": %w`, key, err)
+		}
 	}
 	return nil
 }
 
-// Validate returns nil if PlatformArgsOrString is configured correctly.
-func (p PlatformArgsOrString) Validate() error {
-	if p.IsEmpty() {
+// Validate returns nil if Secret is configured correctly.
+func (s Secret) Validate() error {
+	if s.AppConfig == nil {
 		return nil
 	}
-	if !p.PlatformArgs.isEmpty() {
-		return p.PlatformArgs.Validate()
+	if err := s.AppConfig.Validate(); err != nil {
+		return fmt.Errorf(`validate "appconfig": %w`, err)
+	}
+	return nil
+}
+
+// Validate returns nil if AppConfigSecret is configured correctly.
+func (a AppConfigSecret) Validate() error {
+	if a.Application == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "application",
+		}
+	}
+	if a.Environment == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "environment",
+		}
 	}
-	if p.PlatformString != nil {
-		return p.PlatformString.Validate()
+	if a.Profile == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "profile",
+		}
 	}
 	return nil
 }
 
+// Validate returns nil if PlatformArgsOrString is configured correctly.
+func (p PlatformArgsOrString) Validate() error {
+	return p.IsValid()
+}
+
 // Validate returns nil if PlatformArgs is configured correctly.
 func (p PlatformArgs) Validate() error {
 	if !p.bothSpecified() {
@@ -645,8 +1805,11 @@ func (p PlatformArgs) Validate() error {
 	}
 	prettyValidPlatforms := strings.Join(ss, ", ")
 
-	os := strings.ToLower(aws.StringValue(p.OSFamily))
-	arch := strings.ToLower(aws.StringValue(p.Arch))
+	os := normalizeOS(aws.StringValue(p.OSFamily))
+	arch := normalizeArch(aws.StringValue(p.Arch))
+	if contains(os, WindowsOSFamilies) && IsArmArch(arch) {
+		return fmt.Errorf("platform pair %s is invalid: %s", p.String(), errWindowsOnARM)
+	}
 	for _, vap := range validAdvancedPlatforms {
 		if os == aws.StringValue(vap.OSFamily) && arch == aws.StringValue(vap.Arch) {
 			return nil
@@ -661,8 +1824,12 @@ func (p PlatformString) Validate() error {
 	if len(args) != 2 {
 		return fmt.Errorf("platform '%s' must be in the format [OS]/[Arch]", string(p))
 	}
+	os, arch := normalizeOS(args[0]), normalizeArch(args[1])
+	if contains(os, WindowsOSFamilies) && IsArmArch(arch) {
+		return fmt.Errorf("platform '%s' is invalid: %s", p, errWindowsOnARM)
+	}
 	for _, validPlatform := range ValidShortPlatforms {
-		if strings.ToLower(string(p)) == validPlatform {
+		if platformString(os, arch) == validPlatform {
 			return nil
 		}
 	}
@@ -682,6 +1849,12 @@ func (a AdvancedCount) Validate() error {
 	if len(a.validScalingFields()) == 0 {
 		return fmt.Errorf("cannot have autoscaling options for workloads of type '%s'", a.workloadType)
 	}
+	if a.Requests != nil && !a.isLoadBalanced() {
+		return errors.New(`"requests" can only be specified for load balanced workloads`)
+	}
+	if a.ResponseTime != nil && !a.isLoadBalanced() {
+		return errors.New(`"response_time" can only be specified for load balanced workloads`)
+	}
 	// Validate spot and remaining autoscaling fields.
 	if a.Spot != nil && a.hasAutoscaling() {
 		return &errFieldMutualExclusive{
@@ -693,6 +1866,31 @@ func (a AdvancedCount) Validate() error {
 		return fmt.Errorf(`validate "range": %w`, err)
 	}
 
+	// Validate combinations with "capacity_providers".
+	if !a.CapacityProviders.IsEmpty() {
+		if a.Spot != nil {
+			return &errFieldMutualExclusive{
+				firstField:  "spot",
+				secondField: "capacity_providers",
+			}
+		}
+		if a.Range.RangeConfig.SpotFrom != nil {
+			return &errFieldMutualExclusive{
+				firstField:  "range.spot_from",
+				secondField: "capacity_providers",
+			}
+		}
+		if a.Range.IsEmpty() {
+			return &errFieldMustBeSpecified{
+				missingField:      "range",
+				conditionalFields: []string{"capacity_providers"},
+			}
+		}
+		if err := a.CapacityProviders.Validate(); err != nil {
+			return fmt.Errorf(`validate "capacity_providers": %w`, err)
+		}
+	}
+
 	// Validate combinations with "range".
 	if a.Range.IsEmpty() && a.hasScalingFieldsSet() {
 		return &errFieldMustBeSpecified{
@@ -700,12 +1898,31 @@ func (a AdvancedCount) Validate() error {
 			conditionalFields: a.validScalingFields(),
 		}
 	}
-	if !a.Range.IsEmpty() && !a.hasScalingFieldsSet() {
+	if !a.Range.IsEmpty() && !a.hasScalingFieldsSet() && len(a.Scheduled) == 0 {
 		return &errAtLeastOneFieldMustBeSpecified{
-			missingFields:    a.validScalingFields(),
+			missingFields:    append(a.validScalingFields(), "scheduled"),
 			conditionalField: "range",
 		}
 	}
+	if a.Range.IsEmpty() && len(a.Scheduled) > 0 {
+		return &errFieldMustBeSpecified{
+			missingField:      "range",
+			conditionalFields: []string{"scheduled"},
+		}
+	}
+
+	if !a.CustomMetric.IsEmpty() && a.hasBuiltInScalingFieldsSet() {
+		return &errFieldMutualExclusive{
+			firstField:  "custom_metric",
+			secondField: strings.Join(a.builtInScalingFields(), "/"),
+		}
+	}
+	if a.Range.IsEmpty() && !a.Cooldown.IsEmpty() {
+		return &errFieldMustBeSpecified{
+			missingField:      "range",
+			conditionalFields: []string{"cooldown"},
+		}
+	}
 
 	// Validate individual custom autoscaling options.
 	if err := a.QueueScaling.Validate(); err != nil {
@@ -721,6 +1938,156 @@ func (a AdvancedCount) Validate() error {
 			return fmt.Errorf(`validate "memory_percentage": %w`, err)
 		}
 	}
+	if a.Requests != nil && aws.IntValue(a.Requests) <= 0 {
+		return fmt.Errorf(`"requests" value %d must be a positive integer`, aws.IntValue(a.Requests))
+	}
+	if a.ResponseTime != nil && *a.ResponseTime <= 0 {
+		return fmt.Errorf(`"response_time" value %s must be a positive duration`, a.ResponseTime.String())
+	}
+	if err := a.Cooldown.Validate(); err != nil {
+		return fmt.Errorf(`validate "cooldown": %w`, err)
+	}
+	if err := a.CustomMetric.Validate(); err != nil {
+		return fmt.Errorf(`validate "custom_metric": %w`, err)
+	}
+	for i, scheduled := range a.Scheduled {
+		if err := scheduled.Validate(); err != nil {
+			return fmt.Errorf(`validate "scheduled[%d]": %w`, i, err)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if ScheduledScaling is configured correctly.
+func (s ScheduledScaling) Validate() error {
+	if s.Calendar == nil {
+		return s.ScheduledAction.Validate()
+	}
+	if !s.ScheduledAction.IsEmpty() {
+		return &errFieldMutualExclusive{
+			firstField:  "calendar",
+			secondField: "schedule/range/timezone",
+		}
+	}
+	if aws.StringValue(s.Calendar) == "" {
+		return errors.New(`"calendar" cannot be empty`)
+	}
+	return nil
+}
+
+// Validate returns nil if ScheduledAction is configured correctly.
+func (a ScheduledAction) Validate() error {
+	if a.Schedule == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "schedule",
+		}
+	}
+	if a.Range == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "range",
+		}
+	}
+	if err := validateScalingSchedule(aws.StringValue(a.Schedule)); err != nil {
+		return fmt.Errorf(`validate "schedule": %w`, err)
+	}
+	if err := a.Range.Validate(); err != nil {
+		return fmt.Errorf(`validate "range": %w`, err)
+	}
+	return nil
+}
+
+// validateScalingSchedule returns nil if the schedule is an "at", rate, or cron expression of Application Auto Scaling.
+func validateScalingSchedule(schedule string) error {
+	switch {
+	case strings.HasPrefix(schedule, "rate("):
+		return validateAWSRateSchedule(schedule)
+	case strings.HasPrefix(schedule, "cron("):
+		return validateAWSCronSchedule(schedule)
+	}
+	match := awsAtScheduleRegexp.FindStringSubmatch(schedule)
+	if match == nil {
+		return fmt.Errorf(`schedule %q must be an "at", "rate", or "cron" expression`, schedule)
+	}
+	if _, err := time.Parse("2006-01-02T15:04:05", match[1]); err != nil {
+		return fmt.Errorf(`at expression %q must be of the form "at(yyyy-mm-ddThh:mm:ss)"`, schedule)
+	}
+	return nil
+}
+
+// Validate returns nil if CapacityProviders is configured correctly.
+func (c CapacityProviders) Validate() error {
+	if err := c.OnDemand.Validate(); err != nil {
+		return fmt.Errorf(`validate "on_demand": %w`, err)
+	}
+	if err := c.Spot.Validate(); err != nil {
+		return fmt.Errorf(`validate "spot": %w`, err)
+	}
+	if c.OnDemand.Base != nil && c.Spot.Base != nil {
+		return &errFieldMutualExclusive{
+			firstField:  "on_demand.base",
+			secondField: "spot.base",
+		}
+	}
+	if aws.IntValue(c.OnDemand.Weight) == 0 && aws.IntValue(c.Spot.Weight) == 0 {
+		return errors.New(`"weight" must be positive for at least one of "on_demand" or "spot"`)
+	}
+	return nil
+}
+
+// Validate returns nil if CapacityProviderStrategy is configured correctly.
+func (c CapacityProviderStrategy) Validate() error {
+	if c.IsEmpty() {
+		return nil
+	}
+	if aws.IntValue(c.Base) < 0 {
+		return fmt.Errorf(`"base" value %d cannot be negative`, aws.IntValue(c.Base))
+	}
+	if aws.IntValue(c.Weight) < 0 {
+		return fmt.Errorf(`"weight" value %d cannot be negative`, aws.IntValue(c.Weight))
+	}
+	if aws.IntValue(c.Base) == 0 && aws.IntValue(c.Weight) == 0 {
+		return errors.New(`at least one of "base" or "weight" must be positive`)
+	}
+	return nil
+}
+
+// Validate returns nil if CustomMetric is configured correctly.
+func (m CustomMetric) Validate() error {
+	if m.IsEmpty() {
+		return nil
+	}
+	if m.Namespace == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "namespace",
+		}
+	}
+	if m.MetricName == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "metric_name",
+		}
+	}
+	if m.Target == nil {
+		return &errFieldMustBeSpecified{
+			missingField: "target",
+		}
+	}
+	if aws.Float64Value(m.Target) <= 0 {
+		return fmt.Errorf(`"target" value %v must be a positive number`, aws.Float64Value(m.Target))
+	}
+	if m.Statistic != nil && !contains(aws.StringValue(m.Statistic), customMetricStatistics) {
+		return fmt.Errorf(`"statistic" value "%s" must be one of %s`, aws.StringValue(m.Statistic), english.WordSeries(customMetricStatistics, "or"))
+	}
+	return nil
+}
+
+// Validate returns nil if Cooldown is configured correctly.
+func (c Cooldown) Validate() error {
+	if c.ScaleInCooldown != nil && *c.ScaleInCooldown < 0 {
+		return fmt.Errorf(`"scale_in_cooldown" value %s cannot be negative`, c.ScaleInCooldown.String())
+	}
+	if c.ScaleOutCooldown != nil && *c.ScaleOutCooldown < 0 {
+		return fmt.Errorf(`"scale_out_cooldown" value %s cannot be negative`, c.ScaleOutCooldown.String())
+	}
 	return nil
 }
 
@@ -807,13 +2174,18 @@ func (r RangeConfig) Validate() error {
 		}
 	}
 	min, max := aws.IntValue(r.Min), aws.IntValue(r.Max)
-	if min <= max {
-		return nil
+	if min > max {
+		return &errMinGreaterThanMax{
+			min: min,
+			max: max,
+		}
 	}
-	return &errMinGreaterThanMax{
-		min: min,
-		max: max,
+	if r.SpotFrom != nil {
+		if spotFrom := aws.IntValue(r.SpotFrom); spotFrom < min || spotFrom > max {
+			return fmt.Errorf(`"spot_from" value %d must be within the range %d-%d`, spotFrom, min, max)
+		}
 	}
+	return nil
 }
 
 // Validate returns nil if ExecuteCommand is configured correctly.
@@ -825,7 +2197,23 @@ func (e ExecuteCommand) Validate() error {
 }
 
 // Validate returns nil if ExecuteCommandConfig is configured correctly.
-func (ExecuteCommandConfig) Validate() error {
+func (e ExecuteCommandConfig) Validate() error {
+	if e.Logging != nil {
+		if err := e.Logging.Validate(); err != nil {
+			return fmt.Errorf(`validate "logging": %w`, err)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if ExecLogging is configured correctly.
+func (l ExecLogging) Validate() error {
+	if l.S3KeyPrefix != nil && l.S3Bucket == nil {
+		return &errFieldMustBeSpecified{
+			missingField:      "s3_bucket",
+			conditionalFields: []string{"s3_key_prefix"},
+		}
+	}
 	return nil
 }
 
@@ -837,11 +2225,18 @@ func (s Storage) Validate() error {
 	if s.Ephemeral != nil {
 		ephemeral := aws.IntValue(s.Ephemeral)
 		if ephemeral < ephemeralMinValueGiB || ephemeral > ephemeralMaxValueGiB {
-			return fmt.Errorf(`validate "ephemeral": ephemeral storage must be between 20 GiB and 200 GiB`)
+			return fmt.Errorf(`validate "ephemeral": ephemeral storage must be between %d GiB and %d GiB`, ephemeralMinValueGiB, ephemeralMaxValueGiB)
 		}
 	}
+	names := make([]string, 0, len(s.Volumes))
+	for name := range s.Volumes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 	var hasManagedVolume bool
-	for k, v := range s.Volumes {
+	mountedBy := make(map[string]string)
+	for _, k := range names {
+		v := s.Volumes[k]
 		if err := v.Validate(); err != nil {
 			return fmt.Errorf(`validate "volumes[%s]": %w`, k, err)
 		}
@@ -851,6 +2246,11 @@ func (s Storage) Validate() error {
 			}
 			hasManagedVolume = true
 		}
+		mountPath := path.Clean(aws.StringValue(v.ContainerPath))
+		if other, ok := mountedBy[mountPath]; ok {
+			return fmt.Errorf(`volumes %s and %s cannot both be mounted at path %s`, other, k, mountPath)
+		}
+		mountedBy[mountPath] = k
 	}
 	return nil
 }
@@ -896,6 +2296,22 @@ func (e EFSVolumeConfiguration) Validate() error {
 			secondField: "id/root_dir/auth",
 		}
 	}
+	if aws.BoolValue(e.Managed) && !e.EmptyBYOConfig() {
+		return &errFieldMutualExclusive{
+			firstField:  "managed",
+			secondField: "id/root_dir/auth",
+		}
+	}
+	if e.Managed != nil && !aws.BoolValue(e.Managed) {
+		if !e.EmptyUIDConfig() {
+			return fmt.Errorf(`"managed" must be true if "uid/gid" are specified`)
+		}
+		if e.FileSystemID == nil {
+			return &errFieldMustBeSpecified{
+				missingField: "id",
+			}
+		}
+	}
 	if e.UID != nil && e.GID == nil {
 		return &errFieldMustBeSpecified{
 			missingField:      "gid",
@@ -915,11 +2331,14 @@ func (e EFSVolumeConfiguration) Validate() error {
 		return fmt.Errorf(`validate "auth": %w`, err)
 	}
 	if e.AuthConfig.AccessPointID != nil {
-		if (aws.StringValue(e.RootDirectory) == "" || aws.StringValue(e.RootDirectory) == "/") &&
-			(e.AuthConfig.IAM == nil || aws.BoolValue(e.AuthConfig.IAM)) {
-			return nil
+		// The access point sets the root directory of the mount, so ECS rejects any other root directory.
+		if rootDir := aws.StringValue(e.RootDirectory); rootDir != "" && rootDir != "/" {
+			return fmt.Errorf(`"root_dir" must be either empty or "/" when "auth.access_point_id" is specified, got %q`, rootDir)
 		}
-		return fmt.Errorf(`"root_dir" must be either empty or "/" and "auth.iam" must be true when "access_point_id" is used`)
+		if e.AuthConfig.IAM != nil && !aws.BoolValue(e.AuthConfig.IAM) {
+			return fmt.Errorf(`"auth.iam" must be true when "auth.access_point_id" is specified`)
+		}
+		return nil
 	}
 	if e.RootDirectory != nil {
 		if err := validateVolumePath(aws.StringValue(e.RootDirectory)); err != nil {
@@ -939,9 +2358,106 @@ func (a AuthorizationConfig) Validate() error {
 
 // Validate returns nil if Logging is configured correctly.
 func (l Logging) Validate() error {
+	if l.LogGroup != nil {
+		if err := validateLogGroupName(aws.StringValue(l.LogGroup)); err != nil {
+			return fmt.Errorf(`validate "log_group": %w`, err)
+		}
+	}
+	if aws.BoolValue(l.ImportLogGroup) {
+		if l.LogGroup == nil {
+			return &errFieldMustBeSpecified{
+				missingField:      "log_group",
+				conditionalFields: []string{"import_log_group"},
+			}
+		}
+		if l.Retention != nil {
+			return &errFieldMutualExclusive{
+				firstField:  "retention",
+				secondField: "import_log_group",
+			}
+		}
+	}
 	if l.IsEmpty() {
 		return nil
 	}
+	if l.ConfigType != nil && !contains(aws.StringValue(l.ConfigType), firelensConfigTypes) {
+		return fmt.Errorf(`"configType" value "%s" must be one of %s`, aws.StringValue(l.ConfigType), english.WordSeries(firelensConfigTypes, "or"))
+	}
+	if key, ok := reservedFirelensOption(l.Destination, firelensReservedOptions); ok {
+		return fmt.Errorf(`validate "destination": option %q is reserved by Copilot`, key)
+	}
+	if key, ok := reservedFirelensOption(l.SecretOptions, append([]string{firelensNameOption}, firelensReservedOptions...)); ok {
+		return fmt.Errorf(`validate "secretOptions": option %q is reserved and cannot be a secret`, key)
+	}
+	for name, secret := range l.Secrets {
+		if err := secret.Validate(); err != nil {
+			return fmt.Errorf(`validate secret "%s": %w`, name, err)
+		}
+		if secret.AppConfig != nil {
+			return fmt.Errorf(`validate secret "%s": "appconfig" secrets are only served to the main container`, name)
+		}
+	}
+	return nil
+}
+
+// reservedFirelensOption returns the first key of the options, in alphabetical order, that is reserved.
+func reservedFirelensOption(options map[string]string, reserved []string) (string, bool) {
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if contains(key, reserved) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func validateContainerName(name string) error {
+	if len(name) == 0 || len(name) > maxContainerNameLength {
+		return fmt.Errorf("container name must be between 1 and %d characters long", maxContainerNameLength)
+	}
+	if !containerNameRegexp.MatchString(name) {
+		return fmt.Errorf("container name %q can only contain letters, numbers, underscores, and hyphens", name)
+	}
+	return nil
+}
+
+func validateEnvVarPrefix(prefix string) error {
+	if prefix == "" {
+		return errors.New("prefix cannot be an empty string")
+	}
+	if !envVarNameRegexp.MatchString(prefix) {
+		return fmt.Errorf("prefix %q can only contain letters, numbers, and underscores, and cannot start with a number", prefix)
+	}
+	return nil
+}
+
+func validateSSMParameterName(name string) error {
+	if len(name) == 0 || len(name) > maxSSMParameterNameLength {
+		return fmt.Errorf("parameter name must be between 1 and %d characters long", maxSSMParameterNameLength)
+	}
+	if !ssmParameterNameRegexp.MatchString(name) {
+		return fmt.Errorf(`parameter name %q can only contain letters, numbers, and the characters "_-./", and must start with "/" if it is a path`, name)
+	}
+	if levels := strings.Count(name, "/"); levels > maxSSMParameterLevels {
+		return fmt.Errorf("parameter name %q has %d levels of hierarchy, which exceeds the maximum of %d", name, levels, maxSSMParameterLevels)
+	}
+	return nil
+}
+
+func validateLogGroupName(name string) error {
+	if len(name) == 0 || len(name) > maxLogGroupNameLength {
+		return fmt.Errorf("log group name must be between 1 and %d characters long", maxLogGroupNameLength)
+	}
+	if !logGroupNameRegexp.MatchString(name) {
+		return fmt.Errorf(`log group name %q can only contain letters, numbers, and the characters "_-/.#"`, name)
+	}
+	if strings.HasPrefix(name, reservedLogGroupPrefix) {
+		return fmt.Errorf(`log group name %q cannot start with %q, which is reserved for log groups managed by Copilot`, name, reservedLogGroupPrefix)
+	}
 	return nil
 }
 
@@ -961,6 +2477,102 @@ func (s SidecarConfig) Validate() error {
 	return s.ImageOverride.Validate()
 }
 
+// validateServiceConnectPorts returns an error if Service Connect refers to a port that none of the containers expose.
+func validateServiceConnectPorts(connect ServiceConnect, mainPort *uint16, sidecars map[string]*SidecarConfig) error {
+	if !connect.IsEnabled() {
+		return nil
+	}
+	if connect.Alias != nil && mainPort == nil {
+		return fmt.Errorf(`validate "network.connect": %w`, &errFieldMustBeSpecified{
+			missingField:      "image.port",
+			conditionalFields: []string{"network.connect.alias"},
+		})
+	}
+	exposed := make(map[uint16]bool)
+	if mainPort != nil {
+		exposed[aws.Uint16Value(mainPort)] = true
+	}
+	for _, sidecar := range sidecars {
+		if sidecar == nil || sidecar.Port == nil {
+			continue
+		}
+		port, err := strconv.ParseUint(strings.SplitN(aws.StringValue(sidecar.Port), "/", 2)[0], 10, 16)
+		if err != nil {
+			continue
+		}
+		exposed[uint16(port)] = true
+	}
+	for idx, port := range connect.Ports {
+		if !exposed[aws.Uint16Value(port.Port)] {
+			return fmt.Errorf(`validate "network.connect.ports[%d]": port %d is not exposed by the main container or any of the sidecars`, idx, aws.Uint16Value(port.Port))
+		}
+	}
+	return nil
+}
+
+// validateDenyDefaultSecurityGroup returns an error if the tasks leave out the security group of the environment
+// while they rely on it to receive requests from the load balancers of the environment or to mount EFS volumes.
+func validateDenyDefaultSecurityGroup(vpc vpcConfig, volumes map[string]*Volume, behindEnvLoadBalancer bool) error {
+	if !aws.BoolValue(vpc.DenyDefaultSecurityGroup) {
+		return nil
+	}
+	if behindEnvLoadBalancer {
+		return errors.New(`"deny_default_security_group" cannot be true for a service behind a load balancer of the environment, which only reaches tasks in the environment security group`)
+	}
+	names := make([]string, 0, len(volumes))
+	for name := range volumes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if volumes[name] != nil && !volumes[name].EmptyVolume() {
+			return fmt.Errorf(`"deny_default_security_group" cannot be true if volume %s uses EFS, which is mounted through the environment security group`, name)
+		}
+	}
+	return nil
+}
+
+// validateSidecarMountPoints returns an error if a sidecar mounts a volume that isn't declared in "storage.volumes",
+// or mounts a task-scoped volume as read-only while no container can write to it, in which case the volume is always empty.
+func validateSidecarMountPoints(volumes map[string]*Volume, sidecars map[string]*SidecarConfig) error {
+	writers := make(map[string]bool)
+	for name, volume := range volumes {
+		if volume != nil && volume.ReadOnly != nil && !aws.BoolValue(volume.ReadOnly) {
+			writers[name] = true
+		}
+	}
+	names := make([]string, 0, len(sidecars))
+	for name, sidecar := range sidecars {
+		if sidecar == nil {
+			continue
+		}
+		names = append(names, name)
+		for _, mp := range sidecar.MountPoints {
+			if mp.ReadOnly != nil && !aws.BoolValue(mp.ReadOnly) {
+				writers[aws.StringValue(mp.SourceVolume)] = true
+			}
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for idx, mp := range sidecars[name].MountPoints {
+			source := aws.StringValue(mp.SourceVolume)
+			volume, ok := volumes[source]
+			if !ok {
+				return fmt.Errorf(`validate "sidecars[%s].mount_points[%d]": "source_volume" %s must be one of the volumes in "storage.volumes"`, name, idx, source)
+			}
+			if volume != nil && !volume.EmptyVolume() {
+				// EFS volumes hold data written outside of the task.
+				continue
+			}
+			if !writers[source] {
+				return fmt.Errorf(`validate "sidecars[%s].mount_points[%d]": volume %s is mounted read-only by every container of the task, set "read_only: false" on the container that writes to it`, name, idx, source)
+			}
+		}
+	}
+	return nil
+}
+
 // Validate returns nil if SidecarMountPoint is configured correctly.
 func (s SidecarMountPoint) Validate() error {
 	if aws.StringValue(s.SourceVolume) == "" {
@@ -979,6 +2591,32 @@ func (n NetworkConfig) Validate() error {
 	if err := n.VPC.Validate(); err != nil {
 		return fmt.Errorf(`validate "vpc": %w`, err)
 	}
+	if err := n.Connect.Validate(); err != nil {
+		return fmt.Errorf(`validate "connect": %w`, err)
+	}
+	return nil
+}
+
+// Validate returns nil if ServiceConnect is configured correctly.
+func (s ServiceConnect) Validate() error {
+	names := make(map[string]bool)
+	for idx, port := range s.Ports {
+		if port.Name == nil {
+			return fmt.Errorf(`validate "ports[%d]": %w`, idx, &errFieldMustBeSpecified{
+				missingField: "name",
+			})
+		}
+		if port.Port == nil {
+			return fmt.Errorf(`validate "ports[%d]": %w`, idx, &errFieldMustBeSpecified{
+				missingField: "port",
+			})
+		}
+		name := aws.StringValue(port.Name)
+		if names[name] {
+			return fmt.Errorf(`validate "ports[%d]": name %q is used by more than one port`, idx, name)
+		}
+		names[name] = true
+	}
 	return nil
 }
 
@@ -1011,9 +2649,56 @@ func (v vpcConfig) Validate() error {
 	if v.isEmpty() {
 		return nil
 	}
-	if v.Placement != nil {
-		if err := v.Placement.Validate(); err != nil {
-			return fmt.Errorf(`validate "placement": %w`, err)
+	if v.Placement != nil && !v.Subnets.IsEmpty() {
+		return &errFieldMutualExclusive{
+			firstField:  "placement",
+			secondField: "subnets",
+		}
+	}
+	if v.Placement != nil {
+		if err := v.Placement.Validate(); err != nil {
+			return fmt.Errorf(`validate "placement": %w`, err)
+		}
+	}
+	if err := v.Subnets.Validate(); err != nil {
+		return fmt.Errorf(`validate "subnets": %w`, err)
+	}
+	if aws.BoolValue(v.DenyDefaultSecurityGroup) && len(v.SecurityGroups) == 0 {
+		// Without the security group of the environment, tasks would be left without any egress.
+		return errors.New(`"security_groups" must be specified if "deny_default_security_group" is true`)
+	}
+	for idx, sg := range v.SecurityGroups {
+		if err := sg.Validate(); err != nil {
+			return fmt.Errorf(`validate "security_groups[%d]": %w`, idx, err)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if SecurityGroup is configured correctly.
+func (sg SecurityGroup) Validate() error {
+	if sg.FromCFN != nil {
+		if aws.StringValue(sg.FromCFN) == "" {
+			return errors.New(`"from_cfn" cannot be an empty string`)
+		}
+		return nil
+	}
+	if id := aws.StringValue(sg.ID); !securityGroupIDRegexp.MatchString(id) {
+		return fmt.Errorf(`security group ID %q must be of the form sg-<8 or 17 hexadecimal characters>`, id)
+	}
+	return nil
+}
+
+// Validate returns nil if SubnetListOrArgs is configured correctly.
+func (s SubnetListOrArgs) Validate() error {
+	for _, id := range s.IDs {
+		if !subnetIDRegexp.MatchString(id) {
+			return fmt.Errorf(`subnet ID %q must be of the form subnet-<8 or 17 hexadecimal characters>`, id)
+		}
+	}
+	for key := range s.FromTags {
+		if key == "" {
+			return errors.New(`tag key in "from_tags" cannot be empty`)
 		}
 	}
 	return nil
@@ -1061,6 +2746,25 @@ func (r AppRunnerInstanceConfig) Validate() error {
 	return nil
 }
 
+// Validate returns nil if AppRunnerAutoScalingConfig is configured correctly.
+func (c AppRunnerAutoScalingConfig) Validate() error {
+	if c.MinSize != nil && aws.IntValue(c.MinSize) < 1 {
+		return fmt.Errorf(`"min_size" must be at least 1`)
+	}
+	if c.MaxSize != nil && aws.IntValue(c.MaxSize) < 1 {
+		return fmt.Errorf(`"max_size" must be at least 1`)
+	}
+	if c.MinSize != nil && c.MaxSize != nil && aws.IntValue(c.MinSize) > aws.IntValue(c.MaxSize) {
+		return fmt.Errorf(`"min_size" %d cannot be greater than "max_size" %d`, aws.IntValue(c.MinSize), aws.IntValue(c.MaxSize))
+	}
+	if c.MaxConcurrency != nil {
+		if concurrency := aws.IntValue(c.MaxConcurrency); concurrency < 1 || concurrency > maxAppRunnerConcurrency {
+			return fmt.Errorf(`"max_concurrency" %d must be between 1 and %d`, concurrency, maxAppRunnerConcurrency)
+		}
+	}
+	return nil
+}
+
 // Validate returns nil if RequestDrivenWebServiceHttpConfig is configured correctly.
 func (r RequestDrivenWebServiceHttpConfig) Validate() error {
 	return r.HealthCheckConfiguration.Validate()
@@ -1073,11 +2777,31 @@ func (c JobTriggerConfig) Validate() error {
 			missingField: "schedule",
 		}
 	}
+	if err := validateSchedule(aws.StringValue(c.Schedule)); err != nil {
+		return fmt.Errorf(`validate "schedule": %w`, err)
+	}
 	return nil
 }
 
 // Validate returns nil if JobFailureHandlerConfig is configured correctly.
-func (JobFailureHandlerConfig) Validate() error {
+func (j JobFailureHandlerConfig) Validate() error {
+	if j.Timeout != nil {
+		timeout, err := time.ParseDuration(aws.StringValue(j.Timeout))
+		if err != nil {
+			return fmt.Errorf(`parse "timeout": %w`, err)
+		}
+		if timeout < minJobTimeout || timeout > maxJobTimeout {
+			return fmt.Errorf(`"timeout" %s must be between %s and %s`, timeout, minJobTimeout, maxJobTimeout)
+		}
+		if timeout != timeout.Truncate(time.Second) {
+			return fmt.Errorf(`"timeout" %s must be a whole number of seconds`, timeout)
+		}
+	}
+	if j.Retries != nil {
+		if retries := aws.IntValue(j.Retries); retries < 0 || retries > maxJobRetries {
+			return fmt.Errorf(`"retries" %d must be between 0 and %d`, retries, maxJobRetries)
+		}
+	}
 	return nil
 }
 
@@ -1093,7 +2817,42 @@ func (p PublishConfig) Validate() error {
 
 // Validate returns nil if Topic is configured correctly.
 func (t Topic) Validate() error {
-	return validatePubSubName(aws.StringValue(t.Name))
+	if err := validatePubSubName(aws.StringValue(t.Name)); err != nil {
+		return err
+	}
+	if err := t.FIFO.Validate(); err != nil {
+		return fmt.Errorf(`validate "fifo": %w`, err)
+	}
+	return nil
+}
+
+// Validate returns nil if FIFOTopicAdvanceConfigOrBool is configured correctly.
+func (f FIFOTopicAdvanceConfigOrBool) Validate() error {
+	if f.IsEmpty() {
+		return nil
+	}
+	return f.Advanced.Validate()
+}
+
+// Validate returns nil if FIFOTopicAdvanceConfig is configured correctly.
+func (f FIFOTopicAdvanceConfig) Validate() error {
+	if f.IsEmpty() {
+		return nil
+	}
+	if f.DeduplicationScope != nil && !contains(aws.StringValue(f.DeduplicationScope), fifoDeduplicationScopes) {
+		return fmt.Errorf(`"deduplication_scope" value "%s" must be one of %s`, aws.StringValue(f.DeduplicationScope), english.WordSeries(fifoDeduplicationScopes, "or"))
+	}
+	if f.ThroughputLimit != nil && !contains(aws.StringValue(f.ThroughputLimit), fifoThroughputLimits) {
+		return fmt.Errorf(`"throughput_limit" value "%s" must be one of %s`, aws.StringValue(f.ThroughputLimit), english.WordSeries(fifoThroughputLimits, "or"))
+	}
+	if aws.StringValue(f.DeduplicationScope) == FIFODeduplicationScopeMessageGroup && aws.StringValue(f.ThroughputLimit) == FIFOThroughputLimitPerTopic {
+		return fmt.Errorf(`"throughput_limit" must be %s when "deduplication_scope" is %s`, FIFOThroughputLimitPerMessageGroupID, FIFODeduplicationScopeMessageGroup)
+	}
+	// SNS scopes the deduplication and the throughput of a FIFO topic together.
+	if aws.StringValue(f.DeduplicationScope) == FIFODeduplicationScopeTopic && aws.StringValue(f.ThroughputLimit) == FIFOThroughputLimitPerMessageGroupID {
+		return fmt.Errorf(`"throughput_limit" must be %s when "deduplication_scope" is %s`, FIFOThroughputLimitPerTopic, FIFODeduplicationScopeTopic)
+	}
+	return nil
 }
 
 // Validate returns nil if SubscribeConfig is configured correctly.
@@ -1105,6 +2864,9 @@ func (s SubscribeConfig) Validate() error {
 		if err := topic.Validate(); err != nil {
 			return fmt.Errorf(`validate "topics[%d]": %w`, ind, err)
 		}
+		if err := validateImportedTopicFIFO(topic, s.DeliversToFIFOQueue(topic)); err != nil {
+			return fmt.Errorf(`validate "topics[%d]": %w`, ind, err)
+		}
 	}
 	if err := s.Queue.Validate(); err != nil {
 		return fmt.Errorf(`validate "queue": %w`, err)
@@ -1114,17 +2876,23 @@ func (s SubscribeConfig) Validate() error {
 
 // Validate returns nil if TopicSubscription is configured correctly.
 func (t TopicSubscription) Validate() error {
-	if err := validatePubSubName(aws.StringValue(t.Name)); err != nil {
-		return err
-	}
-	svcName := aws.StringValue(t.Service)
-	if svcName == "" {
-		return &errFieldMustBeSpecified{
-			missingField: "service",
+	if t.ImportedARN != nil {
+		if err := t.validateImportedTopic(); err != nil {
+			return err
+		}
+	} else {
+		if err := validatePubSubName(aws.StringValue(t.Name)); err != nil {
+			return err
+		}
+		svcName := aws.StringValue(t.Service)
+		if svcName == "" {
+			return &errFieldMustBeSpecified{
+				missingField: "service",
+			}
+		}
+		if !isValidSubSvcName(svcName) {
+			return fmt.Errorf("service name must start with a letter, contain only lower-case letters, numbers, and hyphens, and have no consecutive or trailing hyphen")
 		}
-	}
-	if !isValidSubSvcName(svcName) {
-		return fmt.Errorf("service name must start with a letter, contain only lower-case letters, numbers, and hyphens, and have no consecutive or trailing hyphen")
 	}
 	if err := t.Queue.Validate(); err != nil {
 		return fmt.Errorf(`validate "queue": %w`, err)
@@ -1132,6 +2900,41 @@ func (t TopicSubscription) Validate() error {
 	return nil
 }
 
+func (t TopicSubscription) validateImportedTopic() error {
+	if t.Name != nil {
+		return &errFieldMutualExclusive{
+			firstField:  "name",
+			secondField: "arn",
+		}
+	}
+	if t.Service != nil {
+		return &errFieldMutualExclusive{
+			firstField:  "service",
+			secondField: "arn",
+		}
+	}
+	if err := validateSNSTopicARN(aws.StringValue(t.ImportedARN)); err != nil {
+		return fmt.Errorf(`validate "arn": %w`, err)
+	}
+	return nil
+}
+
+// validateImportedTopicFIFO validates that an imported topic is a FIFO topic if and only if it delivers to a FIFO queue.
+// SNS can't deliver the messages of a standard topic to a FIFO queue, nor the messages of a FIFO topic to a standard queue.
+func validateImportedTopicFIFO(t TopicSubscription, fifoQueue bool) error {
+	if t.ImportedARN == nil {
+		return nil
+	}
+	fifoTopic := strings.HasSuffix(aws.StringValue(t.ImportedARN), ".fifo")
+	if fifoQueue && !fifoTopic {
+		return fmt.Errorf(`"arn" must be the ARN of a FIFO topic ending with ".fifo" to deliver to a FIFO queue`)
+	}
+	if !fifoQueue && fifoTopic {
+		return fmt.Errorf(`"arn" is a FIFO topic and can only deliver to a FIFO queue, set "queue.fifo" to true`)
+	}
+	return nil
+}
+
 // Validate returns nil if SQSQueue is configured correctly.
 func (q SQSQueueOrBool) Validate() error {
 	if q.IsEmpty() {
@@ -1156,6 +2959,9 @@ func (d DeadLetterQueue) Validate() error {
 	if d.IsEmpty() {
 		return nil
 	}
+	if tries := aws.Uint16Value(d.Tries); tries < deadLetterMinTries || tries > deadLetterMaxTries {
+		return fmt.Errorf(`"tries" must be between %d and %d`, deadLetterMinTries, deadLetterMaxTries)
+	}
 	return nil
 }
 
@@ -1174,67 +2980,259 @@ type validateDependenciesOpts struct {
 	mainContainerName string
 	sidecarConfig     map[string]*SidecarConfig
 	imageConfig       Image
+	healthCheck       ContainerHealthCheck
 	logging           Logging
+	initContainer     InitContainer
+	observability     Observability
 }
 
 type containerDependency struct {
-	dependsOn   DependsOn
-	isEssential bool
+	dependsOn      DependsOn
+	isEssential    bool
+	hasHealthCheck bool
 }
 
 type validateTargetContainerOpts struct {
 	mainContainerName string
+	mainContainerPort *uint16
 	targetContainer   *string
+	targetPort        *uint16 // Port of the target container that receives the traffic, if set explicitly.
 	sidecarConfig     map[string]*SidecarConfig
 }
 
 type validateWindowsOpts struct {
-	execEnabled bool
-	efsVolumes  map[string]*Volume
+	execEnabled     bool
+	efsVolumes      map[string]*Volume
+	fireLensEnabled bool
+	ulimits         map[string]Ulimit
+	sidecars        map[string]*SidecarConfig
+}
+
+type validateContainerCountOpts struct {
+	sidecarConfig map[string]*SidecarConfig
+	logging       Logging
+	initContainer InitContainer
+	observability Observability
+	secrets       map[string]Secret
 }
 
 type validateARMOpts struct {
-	Spot     *int
-	SpotFrom *int
+	Spot              *int
+	SpotFrom          *int
+	CapacityProviders CapacityProviders
+}
+
+// validatePinnedImageTag returns an error if the image has no tag or uses the "latest" tag.
+// Images referenced by digest are pinned.
+func validatePinnedImageTag(location string) error {
+	if idx := strings.LastIndex(location, "@"); idx != -1 && location[idx+1:] != "" {
+		return nil
+	}
+	name := strings.TrimSuffix(location[strings.LastIndex(location, "/")+1:], "@")
+	idx := strings.LastIndex(name, ":")
+	if idx == -1 || name[idx+1:] == "" {
+		return fmt.Errorf(`image %q must be pinned to a specific tag or digest`, location)
+	}
+	if name[idx+1:] == "latest" {
+		return fmt.Errorf(`image %q must be pinned to a specific tag or digest instead of "latest"`, location)
+	}
+	return nil
+}
+
+// validatePinnedSidecarImages applies the main container's "require_pinned_tag" policy to the sidecar and log router images.
+func validatePinnedSidecarImages(image Image, sidecars map[string]*SidecarConfig, logging Logging) error {
+	if !aws.BoolValue(image.RequirePinnedTag) {
+		return nil
+	}
+	names := make([]string, 0, len(sidecars))
+	for name := range sidecars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if sidecars[name].Image == nil {
+			continue
+		}
+		if err := validatePinnedImageTag(aws.StringValue(sidecars[name].Image)); err != nil {
+			return fmt.Errorf(`validate "sidecars[%s]": %w`, name, err)
+		}
+	}
+	if !logging.IsEmpty() {
+		if err := validatePinnedImageTag(aws.StringValue(logging.LogImage())); err != nil {
+			return fmt.Errorf(`validate "logging": %w`, err)
+		}
+	}
+	return nil
+}
+
+// validateFlags returns an error if a "when" condition of a sidecar or variable names a flag that isn't defined in "flags".
+func validateFlags(flags Flags, sidecars map[string]*SidecarConfig, vars Variables) error {
+	names := make([]string, 0, len(sidecars))
+	for name := range sidecars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if sidecars[name] == nil || sidecars[name].When == nil {
+			continue
+		}
+		if flag := aws.StringValue(sidecars[name].When); !flags.defines(flag) {
+			return fmt.Errorf(`validate "sidecars[%s].when": flag %q is not defined in "flags"`, name, flag)
+		}
+	}
+	keys := make([]string, 0, len(vars.When))
+	for key := range vars.When {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if flag := vars.When[key]; !flags.defines(flag) {
+			return fmt.Errorf(`validate "variables[%s].when": flag %q is not defined in "flags"`, key, flag)
+		}
+	}
+	return nil
 }
 
 func validateTargetContainer(opts validateTargetContainerOpts) error {
-	if opts.targetContainer == nil {
+	if opts.targetContainer == nil && opts.targetPort == nil {
 		return nil
 	}
-	targetContainer := aws.StringValue(opts.targetContainer)
-	if targetContainer == opts.mainContainerName {
+	targetContainer := opts.mainContainerName
+	if opts.targetContainer != nil {
+		targetContainer = aws.StringValue(opts.targetContainer)
+	}
+	exposedPort := strconv.FormatUint(uint64(aws.Uint16Value(opts.mainContainerPort)), 10)
+	if targetContainer != opts.mainContainerName {
+		sidecar, ok := opts.sidecarConfig[targetContainer]
+		if !ok {
+			return fmt.Errorf("target container %s doesn't exist", targetContainer)
+		}
+		if sidecar.Port == nil {
+			return fmt.Errorf("target container %s doesn't expose any port", targetContainer)
+		}
+		exposedPort = strings.Split(aws.StringValue(sidecar.Port), "/")[0]
+	}
+	if opts.targetPort == nil {
 		return nil
 	}
-	sidecar, ok := opts.sidecarConfig[targetContainer]
-	if !ok {
-		return fmt.Errorf("target container %s doesn't exist", targetContainer)
+	if targetPort := strconv.FormatUint(uint64(aws.Uint16Value(opts.targetPort)), 10); targetPort != exposedPort {
+		return fmt.Errorf("target container %s doesn't expose port %s", targetContainer, targetPort)
+	}
+	return nil
+}
+
+// validateContainerCount returns an error if the main container, the sidecars, and the containers
+// injected by Copilot such as the FireLens log router exceed the number of containers ECS allows in a task.
+func validateContainerCount(opts validateContainerCountOpts) error {
+	count := 1 + len(opts.sidecarConfig)
+	if !opts.logging.IsEmpty() {
+		count++
+	}
+	if !opts.initContainer.IsEmpty() {
+		count++
 	}
-	if sidecar.Port == nil {
-		return fmt.Errorf("target container %s doesn't expose any port", targetContainer)
+	if name, _ := opts.observability.tracingContainer(); name != "" {
+		count++
+	}
+	for _, secret := range opts.secrets {
+		if secret.AppConfig != nil {
+			// All the AppConfig secrets are served by a single agent sidecar.
+			count++
+			break
+		}
+	}
+	if count > maxContainersPerTask {
+		return fmt.Errorf("task has %d containers, including sidecars and the log router, but ECS allows at most %d containers per task", count, maxContainersPerTask)
 	}
 	return nil
 }
 
 func validateContainerDeps(opts validateDependenciesOpts) error {
+	if err := validateMainContainerNameUnique(opts); err != nil {
+		return err
+	}
 	containerDependencies := make(map[string]containerDependency)
 	containerDependencies[opts.mainContainerName] = containerDependency{
-		dependsOn:   opts.imageConfig.DependsOn,
-		isEssential: true,
+		dependsOn:      opts.imageConfig.DependsOn,
+		isEssential:    true,
+		hasHealthCheck: !opts.healthCheck.IsEmpty(),
 	}
 	if !opts.logging.IsEmpty() {
-		containerDependencies[firelensContainerName] = containerDependency{}
+		containerDependencies[firelensContainerName] = containerDependency{
+			isEssential: true,
+		}
+	}
+	if !opts.initContainer.IsEmpty() {
+		if _, ok := opts.sidecarConfig[InitContainerName]; ok {
+			return fmt.Errorf("sidecar %s has the same name as the init container", InitContainerName)
+		}
+		mainDeps, err := initContainerDeps(opts.mainContainerName, opts.imageConfig.DependsOn)
+		if err != nil {
+			return err
+		}
+		containerDependencies[opts.mainContainerName] = containerDependency{
+			dependsOn:      mainDeps,
+			isEssential:    true,
+			hasHealthCheck: !opts.healthCheck.IsEmpty(),
+		}
+		containerDependencies[InitContainerName] = containerDependency{
+			dependsOn: opts.initContainer.Image.DependsOn,
+		}
+	}
+	if name, description := opts.observability.tracingContainer(); name != "" {
+		if _, ok := opts.sidecarConfig[name]; ok {
+			return fmt.Errorf(`sidecar %s has the same name as the %s injected by "observability.tracing"`, name, description)
+		}
+		containerDependencies[name] = containerDependency{}
 	}
 	for name, config := range opts.sidecarConfig {
 		containerDependencies[name] = containerDependency{
-			dependsOn:   config.DependsOn,
-			isEssential: config.Essential == nil || aws.BoolValue(config.Essential),
+			dependsOn:      config.DependsOn,
+			isEssential:    config.Essential == nil || aws.BoolValue(config.Essential),
+			hasHealthCheck: !config.HealthCheck.IsEmpty(),
 		}
 	}
 	if err := validateDepsForEssentialContainers(containerDependencies); err != nil {
 		return err
 	}
-	return validateNoCircularDependencies(containerDependencies)
+	if err := validateNoCircularDependencies(containerDependencies); err != nil {
+		return err
+	}
+	return validateDepsOnHealthyContainers(containerDependencies)
+}
+
+// validateMainContainerNameUnique returns an error if the main container has the same name as
+// a sidecar or a container injected by Copilot.
+func validateMainContainerNameUnique(opts validateDependenciesOpts) error {
+	if _, ok := opts.sidecarConfig[opts.mainContainerName]; ok {
+		return fmt.Errorf("sidecar %s has the same name as the main container", opts.mainContainerName)
+	}
+	if !opts.logging.IsEmpty() && opts.mainContainerName == firelensContainerName {
+		return fmt.Errorf("main container cannot be named %s, which is reserved for the log router", firelensContainerName)
+	}
+	if !opts.initContainer.IsEmpty() && opts.mainContainerName == InitContainerName {
+		return fmt.Errorf("main container cannot be named %s, which is reserved for the init container", InitContainerName)
+	}
+	if name, description := opts.observability.tracingContainer(); name != "" && opts.mainContainerName == name {
+		return fmt.Errorf("main container cannot be named %s, which is reserved for the %s", name, description)
+	}
+	return nil
+}
+
+// initContainerDeps returns the dependencies of the main container including the dependency on the init container,
+// which must run to completion before the main container starts.
+func initContainerDeps(mainContainerName string, deps DependsOn) (DependsOn, error) {
+	withInit := DependsOn{
+		InitContainerName: dependsOnComplete,
+	}
+	for name, status := range deps {
+		if name == InitContainerName && !contains(strings.ToUpper(status), initContainerDependsOnValidStatuses) {
+			return nil, fmt.Errorf("validate %s container dependencies status: init container %s can only have status %s",
+				mainContainerName, InitContainerName, english.WordSeries(initContainerDependsOnValidStatuses, "or"))
+		}
+		withInit[name] = status
+	}
+	return withInit, nil
 }
 
 func validateDepsForEssentialContainers(deps map[string]containerDependency) error {
@@ -1251,6 +3249,37 @@ func validateDepsForEssentialContainers(deps map[string]containerDependency) err
 	return nil
 }
 
+// validateDepsOnHealthyContainers returns an error if a container waits for another container to be HEALTHY
+// but that container doesn't define a health check, in which case ECS never reports it as healthy,
+// or isn't essential, in which case it can stop without stopping the task.
+func validateDepsOnHealthyContainers(deps map[string]containerDependency) error {
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dependsOn := deps[name].dependsOn
+		depNames := make([]string, 0, len(dependsOn))
+		for dep := range dependsOn {
+			depNames = append(depNames, dep)
+		}
+		sort.Strings(depNames)
+		for _, dep := range depNames {
+			if strings.ToUpper(dependsOn[dep]) != dependsOnHealthy {
+				continue
+			}
+			if !deps[dep].isEssential {
+				return fmt.Errorf(`validate %s container dependencies status: non-essential container %s cannot have status %s`, name, dep, dependsOnHealthy)
+			}
+			if !deps[dep].hasHealthCheck {
+				return fmt.Errorf(`validate %s container dependencies status: container %s must have a "healthcheck" to have status %s`, name, dep, dependsOnHealthy)
+			}
+		}
+	}
+	return nil
+}
+
 func validateEssentialContainerDependency(name, status string) error {
 	for _, allowed := range essentialContainerDependsOnValidStatuses {
 		if status == allowed {
@@ -1293,6 +3322,81 @@ func buildDependencyGraph(deps map[string]containerDependency) (*graph.Graph, er
 	return dependencyGraph, nil
 }
 
+// validateSchedule returns nil if the schedule is a rate or cron expression of CloudWatch Events,
+// a fixed interval such as "@every 1h30m", a predefined schedule, or a standard 5-field cron expression.
+func validateSchedule(schedule string) error {
+	const everyPrefix = "@every "
+	switch {
+	case strings.HasPrefix(schedule, "rate("):
+		return validateAWSRateSchedule(schedule)
+	case strings.HasPrefix(schedule, "cron("):
+		return validateAWSCronSchedule(schedule)
+	case strings.HasPrefix(schedule, everyPrefix):
+		interval, err := time.ParseDuration(strings.TrimPrefix(schedule, everyPrefix))
+		if err != nil {
+			return fmt.Errorf(`parse interval of %q: %w`, schedule, err)
+		}
+		// Intervals are converted to rate expressions, which are defined in whole minutes.
+		if interval < time.Minute || interval != interval.Truncate(time.Minute) {
+			return fmt.Errorf(`interval of %q must be a whole number of minutes or hours`, schedule)
+		}
+		return nil
+	case strings.HasPrefix(schedule, "@"):
+		if !contains(schedule, predefinedSchedules) {
+			return fmt.Errorf(`predefined schedule %q must be one of %s`, schedule, english.WordSeries(predefinedSchedules, "or"))
+		}
+		return nil
+	}
+	return validateStandardCronSchedule(schedule)
+}
+
+func validateAWSRateSchedule(schedule string) error {
+	match := awsRateScheduleRegexp.FindStringSubmatch(schedule)
+	if match == nil {
+		return fmt.Errorf(`rate expression %q must be of the form "rate(<value> <minutes, hours, or days>)"`, schedule)
+	}
+	value, err := strconv.Atoi(match[1])
+	if err != nil || value == 0 {
+		return fmt.Errorf(`value of rate expression %q must be a positive integer`, schedule)
+	}
+	// CloudWatch Events rejects "rate(1 minutes)" and "rate(5 minute)".
+	if singular := !strings.HasSuffix(match[2], "s"); singular != (value == 1) {
+		return fmt.Errorf(`unit of rate expression %q must be singular for a value of 1 and plural otherwise`, schedule)
+	}
+	return nil
+}
+
+func validateAWSCronSchedule(schedule string) error {
+	if !strings.HasSuffix(schedule, ")") {
+		return fmt.Errorf(`cron expression %q must end with ")"`, schedule)
+	}
+	fields := strings.Fields(strings.TrimSuffix(strings.TrimPrefix(schedule, "cron("), ")"))
+	if len(fields) != 6 {
+		return fmt.Errorf(`cron expression %q must have 6 fields "<minutes> <hours> <day-of-month> <month> <day-of-week> <year>", found %d`, schedule, len(fields))
+	}
+	if fields[2] != "?" && fields[4] != "?" {
+		return fmt.Errorf(`cron expression %q must use "?" in either the day-of-month or the day-of-week field`, schedule)
+	}
+	return nil
+}
+
+func validateStandardCronSchedule(schedule string) error {
+	fields := strings.Fields(schedule)
+	switch {
+	case len(fields) == 6:
+		return fmt.Errorf(`cron expression %q must have 5 fields "<minutes> <hours> <day-of-month> <month> <day-of-week>", found 6: wrap it as "cron(%s)" to use the 6-field syntax of CloudWatch Events`, schedule, schedule)
+	case len(fields) != 5:
+		return fmt.Errorf(`cron expression %q must have 5 fields "<minutes> <hours> <day-of-month> <month> <day-of-week>", found %d`, schedule, len(fields))
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return fmt.Errorf(`parse cron expression %q: %w`, schedule, err)
+	}
+	if dom, dow := fields[2], fields[4]; !strings.ContainsAny(dom, "*?") && !strings.ContainsAny(dow, "*?") {
+		return fmt.Errorf(`cron expression %q cannot specify both the day-of-month and the day-of-week`, schedule)
+	}
+	return nil
+}
+
 // Validate that paths contain only an approved set of characters to guard against command injection.
 // We can accept 0-9A-Za-z-_.
 func validateVolumePath(input string) error {
@@ -1319,6 +3423,33 @@ func validatePubSubName(name string) error {
 	return nil
 }
 
+func validateSNSTopicARN(topicARN string) error {
+	parsed, err := arn.Parse(topicARN)
+	if err != nil || parsed.Service != "sns" || parsed.Region == "" || parsed.AccountID == "" ||
+		parsed.Resource == "" || strings.Contains(parsed.Resource, ":") {
+		return fmt.Errorf("%q must be a SNS topic ARN of the form arn:<partition>:sns:<region>:<account>:<topic name>", topicARN)
+	}
+	return nil
+}
+
+func validateLambdaFunctionARN(functionARN string) error {
+	parsed, err := arn.Parse(functionARN)
+	if err != nil || parsed.Service != "lambda" || parsed.Region == "" || parsed.AccountID == "" ||
+		!strings.HasPrefix(parsed.Resource, "function:") || parsed.Resource == "function:" {
+		return fmt.Errorf("%q must be a Lambda function ARN of the form arn:<partition>:lambda:<region>:<account>:function:<function name>", functionARN)
+	}
+	return nil
+}
+
+func validateSecretsManagerARN(secretARN string) error {
+	parsed, err := arn.Parse(secretARN)
+	if err != nil || parsed.Service != "secretsmanager" || parsed.Region == "" || parsed.AccountID == "" ||
+		!strings.HasPrefix(parsed.Resource, "secret:") || parsed.Resource == "secret:" {
+		return fmt.Errorf("%q must be a Secrets Manager secret ARN of the form arn:<partition>:secretsmanager:<region>:<account>:secret:<secret name>", secretARN)
+	}
+	return nil
+}
+
 func isValidSubSvcName(name string) bool {
 	if !awsNameRegexp.MatchString(name) {
 		return false
@@ -1343,16 +3474,129 @@ func validateWindows(opts validateWindowsOpts) error {
 			return errors.New(`'EFS' is not supported when deploying a Windows container`)
 		}
 	}
+	// The FireLens log router and resource limits only exist for Linux containers,
+	// so they can't share a task with a Windows container.
+	if opts.fireLensEnabled {
+		return errors.New(`'logging' is not supported when deploying a Windows container`)
+	}
+	if len(opts.ulimits) != 0 {
+		return errors.New(`'ulimits' is not supported when deploying a Windows container`)
+	}
+	sidecarNames := make([]string, 0, len(opts.sidecars))
+	for name := range opts.sidecars {
+		sidecarNames = append(sidecarNames, name)
+	}
+	sort.Strings(sidecarNames)
+	for _, name := range sidecarNames {
+		if sidecar := opts.sidecars[name]; sidecar != nil && len(sidecar.Ulimits) != 0 {
+			return fmt.Errorf(`'ulimits' of sidecar %q is not supported when deploying a Windows container`, name)
+		}
+	}
+	return nil
+}
+
+func validateAZRebalancing(count Count, azCount int) error {
+	desired, err := count.Desired()
+	if err != nil {
+		return err
+	}
+	if aws.IntValue(desired) < azCount {
+		return fmt.Errorf(`"count" must be at least %d to place a task in each availability zone`, azCount)
+	}
+	return nil
+}
+
+// Validate returns nil if Observability is configured correctly.
+func (o Observability) Validate() error {
+	if o.TracePropagation != nil && !contains(aws.StringValue(o.TracePropagation), tracePropagationFormats) {
+		return fmt.Errorf(`"trace_propagation" value "%s" must be one of %s`, aws.StringValue(o.TracePropagation),
+			english.WordSeries(tracePropagationFormats, "or"))
+	}
+	if o.Tracing != nil && !contains(aws.StringValue(o.Tracing), tracingVendors) {
+		return fmt.Errorf(`"tracing" value "%s" must be one of %s`, aws.StringValue(o.Tracing),
+			english.WordSeries(tracingVendors, "or"))
+	}
+	if o.CollectorConfig != nil {
+		if !o.OTelTracingEnabled() {
+			return fmt.Errorf(`"collector_config" can only be specified when "tracing" is "%s"`, TracingAWSOTel)
+		}
+		if aws.StringValue(o.CollectorConfig) == "" {
+			return errors.New(`"collector_config" cannot be empty`)
+		}
+	}
+	return nil
+}
+
+// Validate returns nil if GitSHATag is configured correctly.
+func (t GitSHATag) Validate() error {
+	if t.Key == nil {
+		return nil
+	}
+	key := aws.StringValue(t.Key)
+	if key == "" {
+		return errors.New("tag key must not be empty")
+	}
+	if len(key) > maxTagKeyLength {
+		return fmt.Errorf("tag key %q must not be longer than %d characters", key, maxTagKeyLength)
+	}
+	if strings.HasPrefix(strings.ToLower(key), reservedTagKeyPrefix) {
+		return fmt.Errorf("tag key %q must not start with the reserved prefix %q", key, reservedTagKeyPrefix)
+	}
+	return nil
+}
+
+func validatePropagateTags(source string) error {
+	if source != PropagateTagsStack {
+		return fmt.Errorf(`value "%s" must be %s`, source, PropagateTagsStack)
+	}
 	return nil
 }
 
 func validateARM(opts validateARMOpts) error {
-	if opts.Spot != nil || opts.SpotFrom != nil {
+	if opts.Spot != nil || opts.SpotFrom != nil || !opts.CapacityProviders.Spot.IsEmpty() {
 		return errors.New(`'Fargate Spot' is not supported when deploying on ARM architecture`)
 	}
 	return nil
 }
 
+// Platform capabilities of Fargate that are only offered in some regions.
+const (
+	fargateCapabilityARM     = "ARM64 architecture"
+	fargateCapabilityWindows = "Windows containers"
+)
+
+// fargateUnavailableRegions maps each platform capability to the regions where Fargate doesn't support it.
+var fargateUnavailableRegions = map[string][]string{
+	fargateCapabilityARM:     {"ap-northeast-3", "us-gov-east-1", "us-gov-west-1"},
+	fargateCapabilityWindows: {"ap-northeast-3", "ap-southeast-3", "me-central-1"},
+}
+
+// ValidatePlatformInRegion returns an error if Fargate can't run the platform of the workload manifest in the region.
+func ValidatePlatformInRegion(mft interface{}, region string) error {
+	type manifest interface {
+		ContainerPlatform() string
+		IsARM() bool
+		IsWindows() bool
+	}
+	mf, ok := mft.(manifest)
+	if !ok {
+		return nil
+	}
+	var capability string
+	switch {
+	case mf.IsARM():
+		capability = fargateCapabilityARM
+	case mf.IsWindows():
+		capability = fargateCapabilityWindows
+	default:
+		return nil
+	}
+	if contains(region, fargateUnavailableRegions[capability]) {
+		return fmt.Errorf(`platform %q is not supported in region %s: Fargate doesn't offer %s in this region`, mf.ContainerPlatform(), region, capability)
+	}
+	return nil
+}
+
 func contains(name string, names []string) bool {
 	for _, n := range names {
 		if name == n {
@@ -1361,3 +3605,70 @@ func contains(name string, names []string) bool {
 	}
 	return false
 }
+
+// Validate returns nil if the environment manifest is configured correctly.
+func (e Environment) Validate() error {
+	names := make([]string, 0, len(e.ScalingCalendars))
+	for name := range e.ScalingCalendars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !scalingCalendarNameRegexp.MatchString(name) {
+			return fmt.Errorf(`scaling calendar name %q can only contain letters, numbers, underscores, and hyphens`, name)
+		}
+		calendar := e.ScalingCalendars[name]
+		if len(calendar) == 0 {
+			return fmt.Errorf(`validate "scaling_calendars.%s": must have at least one scheduled action`, name)
+		}
+		for i, action := range calendar {
+			if err := action.Validate(); err != nil {
+				return fmt.Errorf(`validate "scaling_calendars.%s[%d]": %w`, name, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateAZRebalancing returns an error if the service turns on "availability_zone_rebalancing"
+// with fewer tasks than the azCount availability zones that its subnets span.
+func ValidateAZRebalancing(mft interface{}, azCount int) error {
+	count, enabled := azRebalancing(mft)
+	if !enabled {
+		return nil
+	}
+	if err := validateAZRebalancing(count, azCount); err != nil {
+		return fmt.Errorf(`validate "availability_zone_rebalancing": %w`, err)
+	}
+	return nil
+}
+
+// IsAZRebalancingEnabled returns true if the service turns on "availability_zone_rebalancing".
+func IsAZRebalancingEnabled(mft interface{}) bool {
+	_, enabled := azRebalancing(mft)
+	return enabled
+}
+
+func azRebalancing(mft interface{}) (count Count, enabled bool) {
+	switch t := mft.(type) {
+	case *LoadBalancedWebService:
+		return t.Count, aws.BoolValue(t.AZRebalancing)
+	case *BackendService:
+		return t.Count, aws.BoolValue(t.AZRebalancing)
+	case *WorkerService:
+		return t.Count, aws.BoolValue(t.AZRebalancing)
+	default:
+		return Count{}, false
+	}
+}
+
+// ValidateScalingCalendars returns an error if the workload references a scaling calendar from "count.scheduled"
+// that isn't defined in calendars.
+func ValidateScalingCalendars(mft interface{}, calendars map[string]ScalingCalendar) error {
+	for _, name := range ScalingCalendarReferences(mft) {
+		if _, ok := calendars[name]; !ok {
+			return fmt.Errorf(`scaling calendar %q referenced by "count.scheduled" is not defined in the environment manifest`, name)
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package manifest
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -55,6 +56,154 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate "http": `,
 		},
+		"error if hostname_variable uses the prefix of the injected variables": {
+			lbConfig: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRule{
+						HostnameVariable: aws.String("COPILOT_HOST"),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "http": validate "hostname_variable": environment variable names cannot start with "COPILOT_"`),
+		},
+		"error if hostname_variable uses a custom prefix of the injected variables": {
+			lbConfig: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRule{
+						HostnameVariable: aws.String("ACME_HOST"),
+					},
+					TaskConfig: TaskConfig{
+						EnvVarPrefix: aws.String("ACME_"),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "http": validate "hostname_variable": environment variable names cannot start with "ACME_"`),
+		},
+		"error if the tasks only have IPv6 addresses": {
+			lbConfig: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRule{
+						Path: aws.String("/"),
+					},
+					Network: NetworkConfig{
+						VPC: vpcConfig{
+							IPFamily: aws.String("IPv6"),
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "network": "ip_family" ipv6 is not supported for services behind a load balancer, use dualstack instead`),
+		},
+		"error if hostname_variable is also defined under variables": {
+			lbConfig: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRule{
+						HostnameVariable: aws.String("PUBLIC_HOST"),
+					},
+					TaskConfig: TaskConfig{
+						Variables: Variables{
+							FromSSM: map[string]string{
+								"PUBLIC_HOST": "/my-app/host",
+							},
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "http": validate "hostname_variable": environment variable "PUBLIC_HOST" is also defined under "variables"`),
+		},
+		"error if the trace propagation variable is also defined under variables": {
+			lbConfig: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRule{
+						Path: aws.String("/"),
+					},
+					TaskConfig: TaskConfig{
+						Variables: Variables{
+							Values: map[string]string{
+								"OTEL_PROPAGATORS": "b3",
+							},
+						},
+					},
+					Observability: Observability{
+						TracePropagation: aws.String("w3c"),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "observability": validate "trace_propagation": environment variable "OTEL_PROPAGATORS" is also defined under "variables"`),
+		},
+		"hostname_variable can use the default prefix if the injected variables use a custom one": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("mockName")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRule{
+						HostnameVariable: aws.String("COPILOT_HOST"),
+					},
+					TaskConfig: TaskConfig{
+						EnvVarPrefix: aws.String("ACME_"),
+					},
+				},
+			},
+		},
+		"error if env_var_prefix is empty": {
+			lbConfig: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskConfig: TaskConfig{
+						EnvVarPrefix: aws.String(""),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "env_var_prefix": prefix cannot be an empty string`),
+		},
+		"error if env_var_prefix is not a valid environment variable name": {
+			lbConfig: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskConfig: TaskConfig{
+						EnvVarPrefix: aws.String("ACME-"),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "env_var_prefix": prefix "ACME-" can only contain letters, numbers, and underscores, and cannot start with a number`),
+		},
+		"error if a variable starts with the prefix of the injected variables": {
+			lbConfig: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskConfig: TaskConfig{
+						Variables: Variables{
+							Values: map[string]string{
+								"LOG_LEVEL":       "debug",
+								"COPILOT_VERSION": "v1",
+							},
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "variables.COPILOT_VERSION": environment variable names cannot start with "COPILOT_"`),
+		},
+		"error if an imported variable starts with a custom prefix of the injected variables": {
+			lbConfig: LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskConfig: TaskConfig{
+						EnvVarPrefix: aws.String("ACME_"),
+						Variables: Variables{
+							Imports: map[string]string{
+								"ACME_DB": "db-endpoint",
+							},
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "variables.ACME_DB": environment variable names cannot start with "ACME_"`),
+		},
 		"error if fail to validate sidecars": {
 			lbConfig: LoadBalancedWebService{
 				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
@@ -75,7 +224,7 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
 					ImageConfig: testImageConfig,
 					Network: NetworkConfig{
-						vpcConfig{
+						VPC: vpcConfig{
 							Placement: (*Placement)(aws.String("")),
 						},
 					},
@@ -129,6 +278,66 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate HTTP load balancer target: `,
 		},
+		"error if the HTTP target container doesn't exist": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("mockName")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRule{
+						TargetContainer: aws.String("nginx"),
+						TargetPort:      aws.Uint16(8080),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate HTTP load balancer target: target container nginx doesn't exist`),
+		},
+		"error if the HTTP target container doesn't expose the target port": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("mockName")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRule{
+						TargetContainer: aws.String("nginx"),
+						TargetPort:      aws.Uint16(8080),
+					},
+					Sidecars: map[string]*SidecarConfig{
+						"nginx": {
+							Port: aws.String("80/tcp"),
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate HTTP load balancer target: target container nginx doesn't expose port 8080`),
+		},
+		"error if the main container doesn't expose the HTTP target port": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("mockName")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRule{
+						TargetPort: aws.Uint16(8080),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate HTTP load balancer target: target container mockName doesn't expose port 8080`),
+		},
+		"valid HTTP target port of a sidecar container": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("mockName")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRule{
+						TargetContainer: aws.String("nginx"),
+						TargetPort:      aws.Uint16(8080),
+					},
+					Sidecars: map[string]*SidecarConfig{
+						"nginx": {
+							Port: aws.String("8080/tcp"),
+						},
+					},
+				},
+			},
+		},
 		"error if fail to validate network load balancer target": {
 			lbConfig: LoadBalancedWebService{
 				Workload: Workload{Name: aws.String("mockName")},
@@ -145,6 +354,27 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate network load balancer target: `,
 		},
+		"error if the target container of an additional network load balancer listener doesn't exist": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("mockName")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					RoutingRule: RoutingRule{
+						TargetContainer: aws.String("mockName"),
+					},
+					NLBConfig: NetworkLoadBalancerConfiguration{
+						Port: aws.String("443"),
+						AdditionalListeners: []NetworkLoadBalancerListener{
+							{
+								Port:            aws.String("53/udp"),
+								TargetContainer: aws.String("foo"),
+							},
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate network load balancer target of "additional_listeners[0]": target container foo doesn't exist`),
+		},
 		"error if fail to validate dependencies": {
 			lbConfig: LoadBalancedWebService{
 				Workload: Workload{Name: aws.String("mockName")},
@@ -177,6 +407,49 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate Windows: `,
 		},
+		"error if a Windows service shares its task with the Linux log router sidecar": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("mockName")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskConfig: TaskConfig{
+						Platform: PlatformArgsOrString{
+							PlatformArgs: PlatformArgs{
+								OSFamily: aws.String(OSWindowsServer2019Core),
+								Arch:     aws.String(ArchX86),
+							},
+						},
+					},
+					Logging: Logging{
+						Destination: map[string]string{
+							"Name": "cloudwatch",
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate Windows: 'logging' is not supported when deploying a Windows container`),
+		},
+		"valid all-Windows service with a sidecar": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("mockName")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskConfig: TaskConfig{
+						Platform: PlatformArgsOrString{
+							PlatformArgs: PlatformArgs{
+								OSFamily: aws.String(OSWindowsServer2019Core),
+								Arch:     aws.String(ArchX86),
+							},
+						},
+					},
+					Sidecars: map[string]*SidecarConfig{
+						"iis-exporter": {
+							Image: aws.String("mcr.microsoft.com/windows/servercore:ltsc2019"),
+						},
+					},
+				},
+			},
+		},
 		"error if fail to validate ARM": {
 			lbConfig: LoadBalancedWebService{
 				Workload: Workload{
@@ -197,6 +470,53 @@ func TestLoadBalancedWebService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate ARM: `,
 		},
+		"error if bluegreen is used with a network load balancer": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("mockName")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					NLBConfig: NetworkLoadBalancerConfiguration{
+						Port: aws.String("443/tcp"),
+					},
+					DeployConfig: DeploymentConfig{
+						BlueGreen: BlueGreenConfigOrBool{Enable: aws.Bool(true)},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "deployment.bluegreen" and "nlb"`),
+		},
+		"error if bluegreen is used with service connect": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("mockName")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					Network: NetworkConfig{
+						Connect: ServiceConnect{
+							Enabled: aws.Bool(true),
+						},
+					},
+					DeployConfig: DeploymentConfig{
+						BlueGreen: BlueGreenConfigOrBool{Enable: aws.Bool(true)},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "deployment.bluegreen" and "network.connect"`),
+		},
+		"valid with bluegreen": {
+			lbConfig: LoadBalancedWebService{
+				Workload: Workload{Name: aws.String("mockName")},
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: testImageConfig,
+					DeployConfig: DeploymentConfig{
+						BlueGreen: BlueGreenConfigOrBool{
+							Config: BlueGreenConfig{
+								TestTrafficRoute: aws.Uint16(8080),
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -265,7 +585,7 @@ func TestBackendService_Validate(t *testing.T) {
 				BackendServiceConfig: BackendServiceConfig{
 					ImageConfig: testImageConfig,
 					Network: NetworkConfig{
-						vpcConfig{
+						VPC: vpcConfig{
 							Placement: (*Placement)(aws.String("")),
 						},
 					},
@@ -286,70 +606,264 @@ func TestBackendService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate "publish": `,
 		},
-		"error if fail to validate taskdef override": {
+		"error if availability zone rebalancing is enabled with a single task": {
 			config: BackendService{
 				BackendServiceConfig: BackendServiceConfig{
 					ImageConfig: testImageConfig,
-					TaskDefOverrides: []OverrideRule{
-						{
-							Path: "Family",
+					TaskConfig: TaskConfig{
+						Count: Count{
+							Value: aws.Int(1),
 						},
 					},
+					AZRebalancing: aws.Bool(true),
 				},
 			},
-			wantedErrorMsgPrefix: `validate "taskdef_overrides[0]": `,
+			wantedErrorMsgPrefix: `validate "availability_zone_rebalancing": `,
 		},
-		"error if name is not set": {
+		"error if propagate_tags is invalid": {
 			config: BackendService{
 				BackendServiceConfig: BackendServiceConfig{
-					ImageConfig: testImageConfig,
+					ImageConfig:   testImageConfig,
+					PropagateTags: aws.String("task"),
 				},
 			},
-			wantedError: fmt.Errorf(`"name" must be specified`),
+			wantedError: fmt.Errorf(`validate "propagate_tags": value "task" must be stack`),
 		},
-		"error if fail to validate dependencies": {
+		"error if http is specified without a port": {
 			config: BackendService{
 				Workload: Workload{Name: aws.String("mockName")},
 				BackendServiceConfig: BackendServiceConfig{
 					ImageConfig: testImageConfig,
-					Sidecars: map[string]*SidecarConfig{
-						"foo": {
-							DependsOn: map[string]string{"bar": "start"},
-						},
-						"bar": {
-							DependsOn: map[string]string{"foo": "start"},
-						},
+					RoutingRule: RoutingRule{
+						Path: aws.String("/api"),
 					},
 				},
 			},
-			wantedErrorMsgPrefix: `validate container dependencies: `,
+			wantedError: fmt.Errorf(`"image.port" must be specified if "http" is specified`),
 		},
-		"error if fail to validate Windows": {
+		"error if http sets an alias": {
 			config: BackendService{
-				Workload: Workload{Name: aws.String("mockName")},
 				BackendServiceConfig: BackendServiceConfig{
 					ImageConfig: testImageConfig,
-					TaskConfig: TaskConfig{
-						Platform:       PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("windows/amd64"))},
-						ExecuteCommand: ExecuteCommand{Enable: aws.Bool(true)},
+					RoutingRule: RoutingRule{
+						Path:  aws.String("/api"),
+						Alias: Alias{String: aws.String("api.example.com")},
 					},
 				},
 			},
-			wantedErrorMsgPrefix: `validate Windows: `,
+			wantedError: fmt.Errorf(`validate "http": "alias", "alias_routing", "redirect_to_https", "hostname_variable" and "additional_rules" are not supported behind the internal load balancer`),
 		},
-		"error if fail to validate ARM": {
+		"error if http doesn't set a path": {
 			config: BackendService{
-				Workload: Workload{
-					Name: aws.String("mockName"),
-				},
 				BackendServiceConfig: BackendServiceConfig{
 					ImageConfig: testImageConfig,
-					TaskConfig: TaskConfig{
-						Platform: PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/arm64"))},
-						Count: Count{
-							AdvancedCount: AdvancedCount{
-								Spot:         aws.Int(123),
-								workloadType: BackendServiceType,
+					RoutingRule: RoutingRule{
+						HealthCheck: HealthCheckArgsOrString{
+							HealthCheckPath: aws.String("/healthz"),
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "http": "path" must be specified`),
+		},
+		"error if requests is specified without http": {
+			config: BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskConfig: TaskConfig{
+						Count: Count{
+							AdvancedCount: AdvancedCount{
+								Range: Range{
+									Value: (*IntRangeBand)(aws.String("1-2")),
+								},
+								Requests:     aws.Int(100),
+								workloadType: BackendServiceType,
+							},
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "count": "http" must be specified if "requests" is specified`),
+		},
+		"error if both build.platforms and platform are specified": {
+			config: BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: ImageWithHealthcheckAndOptionalPort{
+						ImageWithOptionalPort: ImageWithOptionalPort{
+							Image: Image{
+								Build: BuildArgsOrString{
+									BuildArgs: DockerBuildArgs{
+										Platforms: []PlatformBuildArgs{
+											{
+												PlatformArgs: PlatformArgs{
+													OSFamily: aws.String("linux"),
+													Arch:     aws.String("arm64"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					TaskConfig: TaskConfig{
+						Platform: PlatformArgsOrString{
+							PlatformString: (*PlatformString)(aws.String("linux/arm64")),
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "build.platforms" and "platform"`),
+		},
+		"error if build.platforms doesn't include the platform of the tasks": {
+			config: BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: ImageWithHealthcheckAndOptionalPort{
+						ImageWithOptionalPort: ImageWithOptionalPort{
+							Image: Image{
+								Build: BuildArgsOrString{
+									BuildArgs: DockerBuildArgs{
+										Platforms: []PlatformBuildArgs{
+											{
+												PlatformArgs: PlatformArgs{
+													OSFamily: aws.String("linux"),
+													Arch:     aws.String("arm64"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`"build.platforms" must include the platform linux/amd64 that the tasks run on`),
+		},
+		"error if a sidecar image is not pinned when required": {
+			config: BackendService{
+				Workload: Workload{Name: aws.String("mockName")},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: ImageWithHealthcheckAndOptionalPort{
+						ImageWithOptionalPort: ImageWithOptionalPort{
+							Image: Image{
+								Location:         aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/api:v1.2.0"),
+								RequirePinnedTag: aws.Bool(true),
+							},
+						},
+					},
+					Sidecars: map[string]*SidecarConfig{
+						"nginx": {
+							Image: aws.String("public.ecr.aws/nginx/nginx:1.21"),
+						},
+						"xray": {
+							Image: aws.String("amazon/aws-xray-daemon:latest"),
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "sidecars[xray]": image "amazon/aws-xray-daemon:latest" must be pinned to a specific tag or digest instead of "latest"`),
+		},
+		"error if the default log router image is used when pinned tags are required": {
+			config: BackendService{
+				Workload: Workload{Name: aws.String("mockName")},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: ImageWithHealthcheckAndOptionalPort{
+						ImageWithOptionalPort: ImageWithOptionalPort{
+							Image: Image{
+								Location:         aws.String("api:v1.2.0"),
+								RequirePinnedTag: aws.Bool(true),
+							},
+						},
+					},
+					Logging: Logging{
+						Destination: map[string]string{
+							"Name": "cloudwatch",
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "logging": image "amazon/aws-for-fluent-bit:latest" must be pinned to a specific tag or digest instead of "latest"`),
+		},
+		"error if fail to validate taskdef override": {
+			config: BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskDefOverrides: []OverrideRule{
+						{
+							Path: "Family",
+						},
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "taskdef_overrides[0]": `,
+		},
+		"error if name is not set": {
+			config: BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+				},
+			},
+			wantedError: fmt.Errorf(`"name" must be specified`),
+		},
+		"error if fail to validate dependencies": {
+			config: BackendService{
+				Workload: Workload{Name: aws.String("mockName")},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					Sidecars: map[string]*SidecarConfig{
+						"foo": {
+							DependsOn: map[string]string{"bar": "start"},
+						},
+						"bar": {
+							DependsOn: map[string]string{"foo": "start"},
+						},
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate container dependencies: `,
+		},
+		"error if the container name is used by a sidecar": {
+			config: BackendService{
+				Workload: Workload{Name: aws.String("mockName")},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskConfig: TaskConfig{
+						ContainerName: aws.String("app"),
+					},
+					Sidecars: map[string]*SidecarConfig{
+						"app": {},
+					},
+				},
+			},
+			wantedError: fmt.Errorf("validate container dependencies: sidecar app has the same name as the main container"),
+		},
+		"error if fail to validate Windows": {
+			config: BackendService{
+				Workload: Workload{Name: aws.String("mockName")},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskConfig: TaskConfig{
+						Platform:       PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("windows/amd64"))},
+						ExecuteCommand: ExecuteCommand{Enable: aws.Bool(true)},
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate Windows: `,
+		},
+		"error if fail to validate ARM": {
+			config: BackendService{
+				Workload: Workload{
+					Name: aws.String("mockName"),
+				},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskConfig: TaskConfig{
+						Platform: PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/arm64"))},
+						Count: Count{
+							AdvancedCount: AdvancedCount{
+								Spot:         aws.Int(123),
+								workloadType: BackendServiceType,
 							},
 						},
 					},
@@ -357,6 +871,80 @@ func TestBackendService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate ARM: `,
 		},
+		"error if fail to validate git_sha_tag": {
+			config: BackendService{
+				Workload: Workload{Name: aws.String("mockName")},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					GitSHATag:   GitSHATag{Key: aws.String("aws:git-sha")},
+				},
+			},
+			wantedError: errors.New(`validate "git_sha_tag": tag key "aws:git-sha" must not start with the reserved prefix "aws:"`),
+		},
+		"error if a sidecar is gated by an undefined flag": {
+			config: BackendService{
+				Workload: Workload{Name: aws.String("mockName")},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					Flags:       Flags{"tracing": true},
+					Sidecars: map[string]*SidecarConfig{
+						"xray": {
+							Image: aws.String("amazon/aws-xray-daemon"),
+							When:  aws.String("xray"),
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`validate "sidecars[xray].when": flag "xray" is not defined in "flags"`),
+		},
+		"error if a variable is gated by an undefined flag": {
+			config: BackendService{
+				Workload: Workload{Name: aws.String("mockName")},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					TaskConfig: TaskConfig{
+						Variables: Variables{
+							Values: map[string]string{"CHECKOUT_V2": "on"},
+							When:   map[string]string{"CHECKOUT_V2": "checkout"},
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`validate "variables[CHECKOUT_V2].when": flag "checkout" is not defined in "flags"`),
+		},
+		"success with flag-gated sidecars and variables": {
+			config: BackendService{
+				Workload: Workload{Name: aws.String("mockName")},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					Flags:       Flags{"tracing": false},
+					Sidecars: map[string]*SidecarConfig{
+						"xray": {
+							Image: aws.String("amazon/aws-xray-daemon"),
+							When:  aws.String("tracing"),
+						},
+					},
+					TaskConfig: TaskConfig{
+						Variables: Variables{
+							Values: map[string]string{"TRACING": "on"},
+							When:   map[string]string{"TRACING": "tracing"},
+						},
+					},
+				},
+			},
+		},
+		"error if bluegreen is used without a load balancer": {
+			config: BackendService{
+				Workload: Workload{Name: aws.String("mockName")},
+				BackendServiceConfig: BackendServiceConfig{
+					ImageConfig: testImageConfig,
+					DeployConfig: DeploymentConfig{
+						BlueGreen: BlueGreenConfigOrBool{Enable: aws.Bool(true)},
+					},
+				},
+			},
+			wantedError: errors.New(`validate "deployment": "bluegreen" requires a load balancer, which only Load Balanced Web Services have`),
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -422,6 +1010,26 @@ func TestRequestDrivenWebService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate "platform": `,
 		},
+		"error if a variable is gated by an undefined flag": {
+			config: RequestDrivenWebService{
+				Workload: Workload{
+					Name: aws.String("mockName"),
+				},
+				RequestDrivenWebServiceConfig: RequestDrivenWebServiceConfig{
+					ImageConfig: ImageWithPort{
+						Image: Image{
+							Build: BuildArgsOrString{BuildString: aws.String("mockBuild")},
+						},
+						Port: uint16P(80),
+					},
+					Variables: Variables{
+						Values: map[string]string{"CHECKOUT_V2": "on"},
+						When:   map[string]string{"CHECKOUT_V2": "checkout"},
+					},
+				},
+			},
+			wantedError: errors.New(`validate "variables[CHECKOUT_V2].when": flag "checkout" is not defined in "flags"`),
+		},
 		"error if fail to validate network": {
 			config: RequestDrivenWebService{
 				Workload: Workload{
@@ -443,6 +1051,47 @@ func TestRequestDrivenWebService_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate "network": `,
 		},
+		"error if fail to validate variables": {
+			config: RequestDrivenWebService{
+				Workload: Workload{
+					Name: aws.String("mockName"),
+				},
+				RequestDrivenWebServiceConfig: RequestDrivenWebServiceConfig{
+					ImageConfig: ImageWithPort{
+						Image: Image{
+							Build: BuildArgsOrString{BuildString: aws.String("mockBuild")},
+						},
+						Port: uint16P(80),
+					},
+					Variables: Variables{
+						FromSSM: map[string]string{
+							"DB_HOST": "phonetool/db_host",
+						},
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "variables": `,
+		},
+		"error if fail to validate auto scaling": {
+			config: RequestDrivenWebService{
+				Workload: Workload{
+					Name: aws.String("mockName"),
+				},
+				RequestDrivenWebServiceConfig: RequestDrivenWebServiceConfig{
+					ImageConfig: ImageWithPort{
+						Image: Image{
+							Build: BuildArgsOrString{BuildString: aws.String("mockBuild")},
+						},
+						Port: uint16P(80),
+					},
+					AutoScaling: AppRunnerAutoScalingConfig{
+						MinSize: aws.Int(5),
+						MaxSize: aws.Int(2),
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "auto_scaling": `,
+		},
 		"error if name is not set": {
 			config: RequestDrivenWebService{
 				RequestDrivenWebServiceConfig: RequestDrivenWebServiceConfig{
@@ -519,7 +1168,7 @@ func TestWorkerService_Validate(t *testing.T) {
 				WorkerServiceConfig: WorkerServiceConfig{
 					ImageConfig: testImageConfig,
 					Network: NetworkConfig{
-						vpcConfig{
+						VPC: vpcConfig{
 							Placement: (*Placement)(aws.String("")),
 						},
 					},
@@ -690,7 +1339,7 @@ func TestScheduledJob_Validate(t *testing.T) {
 				ScheduledJobConfig: ScheduledJobConfig{
 					ImageConfig: testImageConfig,
 					Network: NetworkConfig{
-						vpcConfig{
+						VPC: vpcConfig{
 							Placement: (*Placement)(aws.String("")),
 						},
 					},
@@ -712,7 +1361,7 @@ func TestScheduledJob_Validate(t *testing.T) {
 				ScheduledJobConfig: ScheduledJobConfig{
 					ImageConfig: testImageConfig,
 					On: JobTriggerConfig{
-						Schedule: aws.String("mockSchedule"),
+						Schedule: aws.String("@daily"),
 					},
 					PublishConfig: PublishConfig{
 						Topics: []Topic{
@@ -728,7 +1377,7 @@ func TestScheduledJob_Validate(t *testing.T) {
 				ScheduledJobConfig: ScheduledJobConfig{
 					ImageConfig: testImageConfig,
 					On: JobTriggerConfig{
-						Schedule: aws.String("mockSchedule"),
+						Schedule: aws.String("@daily"),
 					},
 					TaskDefOverrides: []OverrideRule{
 						{
@@ -744,7 +1393,7 @@ func TestScheduledJob_Validate(t *testing.T) {
 				ScheduledJobConfig: ScheduledJobConfig{
 					ImageConfig: testImageConfig,
 					On: JobTriggerConfig{
-						Schedule: aws.String("mockSchedule"),
+						Schedule: aws.String("@daily"),
 					},
 				},
 			},
@@ -756,7 +1405,7 @@ func TestScheduledJob_Validate(t *testing.T) {
 				ScheduledJobConfig: ScheduledJobConfig{
 					ImageConfig: testImageConfig,
 					On: JobTriggerConfig{
-						Schedule: aws.String("mockSchedule"),
+						Schedule: aws.String("@daily"),
 					},
 					Sidecars: map[string]*SidecarConfig{
 						"foo": {
@@ -776,7 +1425,7 @@ func TestScheduledJob_Validate(t *testing.T) {
 				ScheduledJobConfig: ScheduledJobConfig{
 					ImageConfig: testImageConfig,
 					On: JobTriggerConfig{
-						Schedule: aws.String("mockSchedule"),
+						Schedule: aws.String("@daily"),
 					},
 					TaskConfig: TaskConfig{
 						Platform:       PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("windows/amd64"))},
@@ -833,17 +1482,104 @@ func TestImageWithPort_Validate(t *testing.T) {
 	}
 }
 
-func TestImage_Validate(t *testing.T) {
+func TestContainerHealthCheck_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		Image Image
+		in ContainerHealthCheck
 
-		wantedError          error
-		wantedErrorMsgPrefix string
+		wantedError error
 	}{
-		"error if build and location both specified": {
-			Image: Image{
-				Build: BuildArgsOrString{
-					BuildString: aws.String("mockBuild"),
+		"valid command health check": {
+			in: ContainerHealthCheck{
+				Command: []string{"CMD", "pwd"},
+			},
+		},
+		"error if both command and grpc are specified": {
+			in: ContainerHealthCheck{
+				Command: []string{"CMD", "pwd"},
+				GRPC: &GRPCHealthCheck{
+					Port: aws.Uint16(50051),
+				},
+			},
+			wantedError: errors.New(`must specify one, not both, of "command" and "grpc"`),
+		},
+		"error if grpc port is not specified": {
+			in: ContainerHealthCheck{
+				GRPC: &GRPCHealthCheck{
+					Service: aws.String("grpc.health.v1.Health"),
+				},
+			},
+			wantedError: errors.New(`validate "grpc": "port" must be specified`),
+		},
+		"error if grpc port is 0": {
+			in: ContainerHealthCheck{
+				GRPC: &GRPCHealthCheck{
+					Port: aws.Uint16(0),
+				},
+			},
+			wantedError: errors.New(`validate "grpc": "port" must be between 1 and 65535`),
+		},
+		"error if timeout is not less than interval": {
+			in: ContainerHealthCheck{
+				Command:  []string{"CMD", "pwd"},
+				Interval: durationp(10 * time.Second),
+				Timeout:  durationp(10 * time.Second),
+			},
+			wantedError: errors.New(`"timeout" 10s must be less than "interval" 10s`),
+		},
+		"error if timeout is not less than the default interval": {
+			in: ContainerHealthCheck{
+				Command: []string{"CMD", "pwd"},
+				Timeout: durationp(15 * time.Second),
+			},
+			wantedError: errors.New(`"timeout" 15s must be less than "interval" 10s`),
+		},
+		"error if start_period is greater than 300s": {
+			in: ContainerHealthCheck{
+				Command:     []string{"CMD", "pwd"},
+				StartPeriod: durationp(301 * time.Second),
+			},
+			wantedError: errors.New(`"start_period" 5m1s must be between 0s and 5m0s`),
+		},
+		"error if start_period is negative": {
+			in: ContainerHealthCheck{
+				Command:     []string{"CMD", "pwd"},
+				StartPeriod: durationp(-1 * time.Second),
+			},
+			wantedError: errors.New(`"start_period" -1s must be between 0s and 5m0s`),
+		},
+		"valid timings": {
+			in: ContainerHealthCheck{
+				Command:     []string{"CMD", "pwd"},
+				Interval:    durationp(30 * time.Second),
+				Timeout:     durationp(10 * time.Second),
+				StartPeriod: durationp(300 * time.Second),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.in.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestImage_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		Image Image
+
+		wantedError          error
+		wantedErrorMsgPrefix string
+	}{
+		"error if build and location both specified": {
+			Image: Image{
+				Build: BuildArgsOrString{
+					BuildString: aws.String("mockBuild"),
 				},
 				Location: aws.String("mockLocation"),
 			},
@@ -862,6 +1598,90 @@ func TestImage_Validate(t *testing.T) {
 			},
 			wantedErrorMsgPrefix: `validate "depends_on":`,
 		},
+		"error if a depends_on condition is misspelled": {
+			Image: Image{
+				Location: aws.String("mockLocation"),
+				DependsOn: DependsOn{
+					"db": "healty",
+				},
+			},
+			wantedError: fmt.Errorf(`validate "depends_on": container dependency status "healty" for db must be one of START, COMPLETE, SUCCESS or HEALTHY`),
+		},
+		"valid depends_on condition": {
+			Image: Image{
+				Location: aws.String("mockLocation"),
+				DependsOn: DependsOn{
+					"db": "healthy",
+				},
+			},
+		},
+		"error if location is not pinned when required": {
+			Image: Image{
+				Location:         aws.String("nginx"),
+				RequirePinnedTag: aws.Bool(true),
+			},
+			wantedError: fmt.Errorf(`validate "location": image "nginx" must be pinned to a specific tag or digest`),
+		},
+		"unpinned location is allowed by default": {
+			Image: Image{
+				Location: aws.String("nginx:latest"),
+			},
+		},
+		"pinned location is allowed when required": {
+			Image: Image{
+				Location:         aws.String("nginx:1.21"),
+				RequirePinnedTag: aws.Bool(true),
+			},
+		},
+		"error if credentials are specified with build": {
+			Image: Image{
+				Build: BuildArgsOrString{
+					BuildString: aws.String("mockBuild"),
+				},
+				Credentials: aws.String("arn:aws:secretsmanager:us-west-2:123456789012:secret:dockerhub-XyZ123"),
+			},
+			wantedError: fmt.Errorf(`"location" must be specified if "credentials" is specified`),
+		},
+		"error if credentials is not a Secrets Manager ARN": {
+			Image: Image{
+				Location:    aws.String("private.registry.example.com/app:v1"),
+				Credentials: aws.String("arn:aws:ssm:us-west-2:123456789012:parameter/dockerhub"),
+			},
+			wantedError: fmt.Errorf(`validate "credentials": "arn:aws:ssm:us-west-2:123456789012:parameter/dockerhub" must be a Secrets Manager secret ARN of the form arn:<partition>:secretsmanager:<region>:<account>:secret:<secret name>`),
+		},
+		"valid credentials with location": {
+			Image: Image{
+				Location:    aws.String("private.registry.example.com/app:v1"),
+				Credentials: aws.String("arn:aws:secretsmanager:us-west-2:123456789012:secret:dockerhub-XyZ123"),
+			},
+		},
+		"error if a label key is empty": {
+			Image: Image{
+				Location: aws.String("mockLocation"),
+				DockerLabels: map[string]string{
+					"": "value",
+				},
+			},
+			wantedError: fmt.Errorf(`validate "labels": label key cannot be empty`),
+		},
+		"error if a label key contains spaces": {
+			Image: Image{
+				Location: aws.String("mockLocation"),
+				DockerLabels: map[string]string{
+					"com.example.team":  "payments",
+					"com.example owner": "me",
+				},
+			},
+			wantedError: fmt.Errorf(`validate "labels": label key "com.example owner" cannot contain spaces`),
+		},
+		"valid labels": {
+			Image: Image{
+				Location: aws.String("mockLocation"),
+				DockerLabels: map[string]string{
+					"org.opencontainers.image.revision": "${GIT_SHA}",
+				},
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -881,16 +1701,163 @@ func TestImage_Validate(t *testing.T) {
 	}
 }
 
-func TestDependsOn_Validate(t *testing.T) {
+func TestDockerBuildArgs_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		in     DependsOn
+		in     DockerBuildArgs
 		wanted error
 	}{
-		"should return an error if dependency status is invalid": {
-			in: DependsOn{
-				"foo": "bar",
+		"should return an error if a platform is invalid": {
+			in: DockerBuildArgs{
+				Platforms: []PlatformBuildArgs{
+					{
+						PlatformArgs: PlatformArgs{
+							OSFamily: aws.String("linux"),
+						},
+					},
+				},
+			},
+			wanted: errors.New(`validate "platforms[0]": fields "osfamily" and "architecture" must either both be specified or both be empty`),
+		},
+		"should return an error if a platform is specified twice": {
+			in: DockerBuildArgs{
+				Platforms: []PlatformBuildArgs{
+					{
+						PlatformArgs: PlatformArgs{
+							OSFamily: aws.String("linux"),
+							Arch:     aws.String("arm64"),
+						},
+					},
+					{
+						PlatformArgs: PlatformArgs{
+							OSFamily: aws.String("Linux"),
+							Arch:     aws.String("arm64"),
+						},
+						Dockerfile: aws.String("arm/Dockerfile"),
+					},
+				},
+			},
+			wanted: errors.New(`platform linux/arm64 is specified more than once in "platforms"`),
+		},
+		"should return an error if cache_from images conflict across platforms": {
+			in: DockerBuildArgs{
+				Platforms: []PlatformBuildArgs{
+					{
+						PlatformArgs: PlatformArgs{
+							OSFamily: aws.String("linux"),
+							Arch:     aws.String("amd64"),
+						},
+						CacheFrom: []string{"foo/bar:latest"},
+					},
+					{
+						PlatformArgs: PlatformArgs{
+							OSFamily: aws.String("linux"),
+							Arch:     aws.String("arm64"),
+						},
+						CacheFrom: []string{"foo/bar:arm64", "foo/bar:latest"},
+					},
+				},
+			},
+			wanted: errors.New(`cache_from image "foo/bar:latest" is referenced by both platforms linux/amd64 and linux/arm64`),
+		},
+		"should return an error if a cache_from image is empty": {
+			in: DockerBuildArgs{
+				CacheFrom: []string{"foo/bar:latest", ""},
+			},
+			wanted: errors.New(`validate "cache_from[1]": image cannot be an empty string`),
+		},
+		"should return an error if a cache_from image is not a valid reference": {
+			in: DockerBuildArgs{
+				CacheFrom: []string{"Foo/Bar:latest"},
+			},
+			wanted: errors.New(`validate "cache_from[0]": "Foo/Bar:latest" is not a valid image reference`),
+		},
+		"should return an error if a platform cache_from image is not a valid reference": {
+			in: DockerBuildArgs{
+				Platforms: []PlatformBuildArgs{
+					{
+						PlatformArgs: PlatformArgs{
+							OSFamily: aws.String("linux"),
+							Arch:     aws.String("arm64"),
+						},
+						CacheFrom: []string{"foo/bar@sha256:abc"},
+					},
+				},
+			},
+			wanted: errors.New(`validate "platforms[0]": validate "cache_from[0]": "foo/bar@sha256:abc" is not a valid image reference`),
+		},
+		"should not return an error for cache_from images with tags, digests and the env and tag tokens": {
+			in: DockerBuildArgs{
+				CacheFrom: []string{
+					"foo/bar",
+					"123456789012.dkr.ecr.us-west-2.amazonaws.com/foo/bar:${ENV}-cache",
+					"foo/bar:${TAG}",
+					"localhost:5000/foo/bar@sha256:9b7f2fc5f6e1ac7b3c8d7e4d9b1b3fc5c8a2e0e1f4d9a7f6c3b2a1e0d9c8b7a6",
+				},
+			},
+		},
+		"should return an error if the network mode is invalid": {
+			in: DockerBuildArgs{
+				Network: aws.String("bridge"),
+			},
+			wanted: errors.New(`"network" field value 'bridge' must be one of default, host or none`),
+		},
+		"should return an error if an ssh entry has no path": {
+			in: DockerBuildArgs{
+				SSH: []string{"default", "github="},
+			},
+			wanted: errors.New(`validate "ssh[1]": "github=" must be "default" or of the form "id=path"`),
+		},
+		"should return an error if an ssh entry is not default": {
+			in: DockerBuildArgs{
+				SSH: []string{"github"},
+			},
+			wanted: errors.New(`validate "ssh[0]": "github" must be "default" or of the form "id=path"`),
+		},
+		"should return an error if a secret is empty": {
+			in: DockerBuildArgs{
+				Secrets: map[string]string{
+					"npmrc": "",
+				},
+			},
+			wanted: errors.New(`validate "secrets[npmrc]": must be a file path or an environment variable of the form "env:NAME"`),
+		},
+		"should return an error if a secret references an invalid environment variable": {
+			in: DockerBuildArgs{
+				Secrets: map[string]string{
+					"npmrc": ".npmrc",
+					"token": "env:GITHUB-TOKEN",
+				},
+			},
+			wanted: errors.New(`validate "secrets[token]": "GITHUB-TOKEN" is not a valid environment variable name`),
+		},
+		"should not return an error for valid ssh and secrets": {
+			in: DockerBuildArgs{
+				SSH: []string{"default", "github=/home/user/.ssh/id_ed25519,keys/id_rsa"},
+				Secrets: map[string]string{
+					"npmrc": ".npmrc",
+					"token": "env:GITHUB_TOKEN",
+				},
+			},
+		},
+		"should not return an error for distinct platforms": {
+			in: DockerBuildArgs{
+				CacheFrom: []string{"foo/bar:latest"},
+				Platforms: []PlatformBuildArgs{
+					{
+						PlatformArgs: PlatformArgs{
+							OSFamily: aws.String("linux"),
+							Arch:     aws.String("amd64"),
+						},
+					},
+					{
+						PlatformArgs: PlatformArgs{
+							OSFamily: aws.String("linux"),
+							Arch:     aws.String("arm64"),
+						},
+						CacheFrom: []string{"foo/bar:arm64"},
+					},
+				},
 			},
-			wanted: errors.New("container dependency status must be one of START, COMPLETE, SUCCESS or HEALTHY"),
 		},
 	}
 	for name, tc := range testCases {
@@ -906,105 +1873,174 @@ func TestDependsOn_Validate(t *testing.T) {
 	}
 }
 
-func TestRoutingRule_Validate(t *testing.T) {
+func TestImageOverride_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		RoutingRule RoutingRule
-
-		wantedErrorMsgPrefix string
-		wantedError          error
+		in     ImageOverride
+		wanted error
 	}{
-		"error if both target_container and targetContainer are specified": {
-			RoutingRule: RoutingRule{
-				TargetContainer:          aws.String("mockContainer"),
-				TargetContainerCamelCase: aws.String("mockContainer"),
+		"should return an error if the entrypoint has an unbalanced quote": {
+			in: ImageOverride{
+				EntryPoint: EntryPointOverride{
+					String: aws.String(`/bin/sh -c "echo hello`),
+				},
 			},
-			wantedError: fmt.Errorf(`must specify one, not both, of "target_container" and "targetContainer"`),
+			wanted: errors.New(`validate "entrypoint": convert string into tokens using shell-style rules: unterminated double quote at position 11 in "/bin/sh -c \"echo hello"`),
 		},
-		"error if one of allowed_source_ips is not valid": {
-			RoutingRule: RoutingRule{
-				AllowedSourceIps: []IPNet{
-					IPNet("10.1.0.0/24"),
-					IPNet("badIP"),
-					IPNet("10.1.1.0/24"),
+		"should return an error if the command has an unbalanced quote": {
+			in: ImageOverride{
+				Command: CommandOverride{
+					String: aws.String(`echo 'hello`),
 				},
 			},
-			wantedErrorMsgPrefix: `validate "allowed_source_ips[1]": `,
+			wanted: errors.New(`validate "command": convert string into tokens using shell-style rules: unterminated single quote at position 5 in "echo 'hello"`),
 		},
-		"error if protocol version is not valid": {
-			RoutingRule: RoutingRule{
-				ProtocolVersion: aws.String("quic"),
+		"should return an error if a ulimit name is not recognized": {
+			in: ImageOverride{
+				Ulimits: map[string]Ulimit{
+					"files": {Soft: aws.Int(1024), Hard: aws.Int(1024)},
+				},
 			},
-			wantedErrorMsgPrefix: `"version" field value 'quic' must be one of GRPC, HTTP1 or HTTP2`,
+			wanted: errors.New(`validate "ulimits": ulimit "files" must be one of core, cpu, data, fsize, locks, memlock, msgqueue, nice, nofile, nproc, rss, rtprio, rttime, sigpending or stack`),
 		},
-		"should not error if protocol version is not uppercase": {
-			RoutingRule: RoutingRule{
-				ProtocolVersion: aws.String("gRPC"),
+		"should return an error if a ulimit is missing its hard limit": {
+			in: ImageOverride{
+				Ulimits: map[string]Ulimit{
+					"nofile": {Soft: aws.Int(1024)},
+				},
+			},
+			wanted: errors.New(`validate ulimit "nofile": "hard" must be specified`),
+		},
+		"should return an error if the hard limit is lower than the soft limit": {
+			in: ImageOverride{
+				Ulimits: map[string]Ulimit{
+					"nofile": {Soft: aws.Int(65536), Hard: aws.Int(1024)},
+				},
+			},
+			wanted: errors.New(`validate ulimit "nofile": "hard" limit 1024 must be greater than or equal to "soft" limit 65536`),
+		},
+		"should return the error of the first invalid ulimit in alphabetical order": {
+			in: ImageOverride{
+				Ulimits: map[string]Ulimit{
+					"nofile": {Soft: aws.Int(65536), Hard: aws.Int(1024)},
+					"core":   {Soft: aws.Int(0)},
+					"stack":  {Hard: aws.Int(8192)},
+				},
+			},
+			wanted: errors.New(`validate ulimit "core": "hard" must be specified`),
+		},
+		"should not return an error for valid ulimits": {
+			in: ImageOverride{
+				Ulimits: map[string]Ulimit{
+					"nofile": {Soft: aws.Int(1024), Hard: aws.Int(65536)},
+					"core":   {Soft: aws.Int(0), Hard: aws.Int(0)},
+				},
+			},
+		},
+		"should not return an error for balanced quotes": {
+			in: ImageOverride{
+				EntryPoint: EntryPointOverride{
+					String: aws.String(`/bin/sh -c "echo 'hello'"`),
+				},
+				Command: CommandOverride{
+					StringSlice: []string{"echo", `"hello`},
+				},
 			},
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.RoutingRule.Validate()
+			err := tc.in.Validate()
 
-			if tc.wantedError != nil {
-				require.EqualError(t, gotErr, tc.wantedError.Error())
-				return
-			}
-			if tc.wantedErrorMsgPrefix != "" {
-				require.Error(t, gotErr)
-				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
-				return
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
 			}
-			require.NoError(t, gotErr)
 		})
 	}
 }
 
-func TestNetworkLoadBalancerConfiguration_Validate(t *testing.T) {
+func Test_validatePinnedImageTag(t *testing.T) {
 	testCases := map[string]struct {
-		nlb NetworkLoadBalancerConfiguration
-
-		wantedErrorMsgPrefix string
-		wantedError          error
+		in     string
+		wanted error
 	}{
-		"success if empty": {
-			nlb: NetworkLoadBalancerConfiguration{},
+		"no tag": {
+			in:     "nginx",
+			wanted: errors.New(`image "nginx" must be pinned to a specific tag or digest`),
 		},
-		"error if port unspecified": {
-			nlb: NetworkLoadBalancerConfiguration{
-				TargetContainer: aws.String("main"),
-			},
-			wantedErrorMsgPrefix: `validate "nlb": `,
-			wantedError:          fmt.Errorf(`"port" must be specified`),
+		"latest tag": {
+			in:     "nginx:latest",
+			wanted: errors.New(`image "nginx:latest" must be pinned to a specific tag or digest instead of "latest"`),
+		},
+		"registry port without a tag": {
+			in:     "localhost:5000/team/api",
+			wanted: errors.New(`image "localhost:5000/team/api" must be pinned to a specific tag or digest`),
+		},
+		"registry port with the latest tag": {
+			in:     "localhost:5000/team/api:latest",
+			wanted: errors.New(`image "localhost:5000/team/api:latest" must be pinned to a specific tag or digest instead of "latest"`),
+		},
+		"empty tag": {
+			in:     "nginx:",
+			wanted: errors.New(`image "nginx:" must be pinned to a specific tag or digest`),
+		},
+		"empty tag with a registry port": {
+			in:     "localhost:5000/team/api:",
+			wanted: errors.New(`image "localhost:5000/team/api:" must be pinned to a specific tag or digest`),
+		},
+		"empty digest": {
+			in:     "nginx@",
+			wanted: errors.New(`image "nginx@" must be pinned to a specific tag or digest`),
+		},
+		"pinned tag": {
+			in: "public.ecr.aws/nginx/nginx:1.21.6",
+		},
+		"pinned tag with a registry port": {
+			in: "localhost:5000/team/api:v2",
+		},
+		"pinned digest": {
+			in: "nginx@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
 		},
 	}
-
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.nlb.Validate()
+			err := validatePinnedImageTag(tc.in)
 
-			if tc.wantedError != nil {
-				require.EqualError(t, gotErr, tc.wantedError.Error())
-				return
-			}
-			if tc.wantedErrorMsgPrefix != "" {
-				require.Error(t, gotErr)
-				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
-				return
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
 			}
-			require.NoError(t, gotErr)
 		})
 	}
 }
 
-func TestIPNet_Validate(t *testing.T) {
+func TestDependsOn_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		in     IPNet
+		in     DependsOn
 		wanted error
 	}{
-		"should return an error if IPNet is not valid": {
-			in:     IPNet("badIPNet"),
-			wanted: errors.New("parse IPNet badIPNet: invalid CIDR address: badIPNet"),
+		"should return an error if dependency status is invalid": {
+			in: DependsOn{
+				"foo": "bar",
+			},
+			wanted: errors.New(`container dependency status "bar" for foo must be one of START, COMPLETE, SUCCESS or HEALTHY`),
+		},
+		"should return an error on a misspelled status": {
+			in: DependsOn{
+				"cache": "start",
+				"db":    "HEALTY",
+			},
+			wanted: errors.New(`container dependency status "HEALTY" for db must be one of START, COMPLETE, SUCCESS or HEALTHY`),
+		},
+		"should accept every valid status regardless of case": {
+			in: DependsOn{
+				"a": "start",
+				"b": "COMPLETE",
+				"c": "Success",
+				"d": "healthy",
+			},
 		},
 	}
 	for name, tc := range testCases {
@@ -1020,121 +2056,90 @@ func TestIPNet_Validate(t *testing.T) {
 	}
 }
 
-func TestTaskConfig_Validate(t *testing.T) {
-	mockPerc := Percentage(70)
+func TestAliasRouting_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		TaskConfig TaskConfig
-
-		wantedErrorPrefix string
+		in     AliasRouting
+		wanted error
 	}{
-		"error if fail to validate platform": {
-			TaskConfig: TaskConfig{
-				Platform: PlatformArgsOrString{
-					PlatformString: (*PlatformString)(aws.String("")),
-				},
-			},
-			wantedErrorPrefix: `validate "platform": `,
+		"should not return an error if empty": {
+			in: AliasRouting{},
 		},
-		"error if fail to validate count": {
-			TaskConfig: TaskConfig{
-				Count: Count{
-					AdvancedCount: AdvancedCount{
-						Spot: aws.Int(123),
-						CPU:  &mockPerc,
-					},
-				},
+		"should return an error if hosted zone is missing": {
+			in: AliasRouting{
+				Policy: aws.String("weighted"),
+				Weight: aws.Int(10),
 			},
-			wantedErrorPrefix: `validate "count": `,
+			wanted: errors.New(`"hosted_zone" must be specified`),
 		},
-		"error if fail to validate storage": {
-			TaskConfig: TaskConfig{
-				Storage: Storage{
-					Volumes: map[string]*Volume{
-						"foo": {
-							EFS: EFSConfigOrBool{
-								Advanced: EFSVolumeConfiguration{
-									UID:          aws.Uint32(123),
-									FileSystemID: aws.String("mockID"),
-								},
-							},
-						},
-					},
-				},
+		"should return an error if policy is missing": {
+			in: AliasRouting{
+				HostedZone: aws.String("Z0123456789"),
 			},
-			wantedErrorPrefix: `validate "storage": `,
+			wanted: errors.New(`"policy" must be specified`),
 		},
-	}
-	for name, tc := range testCases {
-		t.Run(name, func(t *testing.T) {
-			gotErr := tc.TaskConfig.Validate()
-
-			if tc.wantedErrorPrefix != "" {
-				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
-			} else {
-				require.NoError(t, gotErr)
-			}
-		})
-	}
-}
-
-func TestPlatformArgsOrString_Validate(t *testing.T) {
-	testCases := map[string]struct {
-		in     PlatformArgsOrString
-		wanted error
-	}{
-		"error if platform string is invalid": {
-			in:     PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("foobar/amd64"))},
-			wanted: fmt.Errorf("platform 'foobar/amd64' is invalid; valid platforms are: linux/amd64, linux/x86_64, linux/arm, linux/arm64, windows/amd64 and windows/x86_64"),
+		"should return an error if policy is invalid": {
+			in: AliasRouting{
+				HostedZone: aws.String("Z0123456789"),
+				Policy:     aws.String("latency"),
+			},
+			wanted: errors.New(`"policy" value "latency" must be one of weighted or failover`),
 		},
-		"error if only half of platform string is specified": {
-			in:     PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux"))},
-			wanted: fmt.Errorf("platform 'linux' must be in the format [OS]/[Arch]"),
+		"should return an error if weight is missing for a weighted policy": {
+			in: AliasRouting{
+				HostedZone: aws.String("Z0123456789"),
+				Policy:     aws.String("weighted"),
+			},
+			wanted: errors.New(`"weight" must be specified`),
 		},
-		"error if only osfamily is specified": {
-			in: PlatformArgsOrString{
-				PlatformArgs: PlatformArgs{
-					OSFamily: aws.String("linux"),
-				},
+		"should return an error if weight is out of range": {
+			in: AliasRouting{
+				HostedZone: aws.String("Z0123456789"),
+				Policy:     aws.String("weighted"),
+				Weight:     aws.Int(256),
 			},
-			wanted: fmt.Errorf(`fields "osfamily" and "architecture" must either both be specified or both be empty`),
+			wanted: errors.New(`"weight" must be between 0 and 255`),
 		},
-		"error if only architecture is specified": {
-			in: PlatformArgsOrString{
-				PlatformArgs: PlatformArgs{
-					Arch: aws.String("X86_64"),
-				},
+		"should return an error if failover is specified for a weighted policy": {
+			in: AliasRouting{
+				HostedZone: aws.String("Z0123456789"),
+				Policy:     aws.String("weighted"),
+				Weight:     aws.Int(0),
+				Failover:   aws.String("primary"),
 			},
-			wanted: fmt.Errorf(`fields "osfamily" and "architecture" must either both be specified or both be empty`),
+			wanted: errors.New(`"failover" cannot be specified with a "weighted" routing policy`),
 		},
-		"error if osfamily is invalid": {
-			in: PlatformArgsOrString{
-				PlatformArgs: PlatformArgs{
-					OSFamily: aws.String("foo"),
-					Arch:     aws.String("amd64"),
-				},
+		"should return an error if failover is missing for a failover policy": {
+			in: AliasRouting{
+				HostedZone: aws.String("Z0123456789"),
+				Policy:     aws.String("failover"),
 			},
-			wanted: fmt.Errorf("platform pair ('foo', 'amd64') is invalid: fields ('osfamily', 'architecture') must be one of ('linux', 'x86_64'), ('linux', 'amd64'), ('linux', 'arm'), ('linux', 'arm64'), ('windows', 'x86_64'), ('windows', 'amd64'), ('windows_server_2019_core', 'x86_64'), ('windows_server_2019_core', 'amd64'), ('windows_server_2019_full', 'x86_64'), ('windows_server_2019_full', 'amd64')"),
+			wanted: errors.New(`"failover" must be specified`),
 		},
-		"error if arch is invalid": {
-			in: PlatformArgsOrString{
-				PlatformArgs: PlatformArgs{
-					OSFamily: aws.String("linux"),
-					Arch:     aws.String("bar"),
-				},
+		"should return an error if failover is invalid": {
+			in: AliasRouting{
+				HostedZone: aws.String("Z0123456789"),
+				Policy:     aws.String("failover"),
+				Failover:   aws.String("tertiary"),
 			},
-			wanted: fmt.Errorf("platform pair ('linux', 'bar') is invalid: fields ('osfamily', 'architecture') must be one of ('linux', 'x86_64'), ('linux', 'amd64'), ('linux', 'arm'), ('linux', 'arm64'), ('windows', 'x86_64'), ('windows', 'amd64'), ('windows_server_2019_core', 'x86_64'), ('windows_server_2019_core', 'amd64'), ('windows_server_2019_full', 'x86_64'), ('windows_server_2019_full', 'amd64')"),
+			wanted: errors.New(`"failover" value "tertiary" must be one of primary or secondary`),
 		},
-		"return nil if platform string valid": {
-			in: PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/amd64"))},
+		"should return an error if weight is specified for a failover policy": {
+			in: AliasRouting{
+				HostedZone: aws.String("Z0123456789"),
+				Policy:     aws.String("failover"),
+				Failover:   aws.String("secondary"),
+				Weight:     aws.Int(10),
+			},
+			wanted: errors.New(`"weight" cannot be specified with a "failover" routing policy`),
 		},
-		"return nil if platform args valid": {
-			in: PlatformArgsOrString{
-				PlatformArgs: PlatformArgs{
-					OSFamily: aws.String("linux"),
-					Arch:     aws.String("amd64"),
-				},
+		"should not return an error with a failover policy and a health check": {
+			in: AliasRouting{
+				HostedZone:    aws.String("Z0123456789"),
+				Policy:        aws.String("failover"),
+				Failover:      aws.String("primary"),
+				SetIdentifier: aws.String("us-west-2"),
+				HealthCheck:   aws.String("abcdef11-2222-3333-4444-555555fedcba"),
 			},
-			wanted: nil,
 		},
 	}
 	for name, tc := range testCases {
@@ -1150,150 +2155,295 @@ func TestPlatformArgsOrString_Validate(t *testing.T) {
 	}
 }
 
-func TestAdvancedCount_Validate(t *testing.T) {
-	var (
-		mockPerc    = Percentage(70)
-		invalidPerc = Percentage(-1)
-	)
+func TestRoutingRule_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		AdvancedCount AdvancedCount
+		RoutingRule RoutingRule
 
-		wantedError          error
 		wantedErrorMsgPrefix string
+		wantedError          error
 	}{
-		"cannot have autoscaling for scheduled jobs": {
-			AdvancedCount: AdvancedCount{
-				Spot:         aws.Int(42),
-				workloadType: ScheduledJobType,
+		"error if both target_container and targetContainer are specified": {
+			RoutingRule: RoutingRule{
+				TargetContainer:          aws.String("mockContainer"),
+				TargetContainerCamelCase: aws.String("mockContainer"),
 			},
-			wantedError: errors.New("cannot have autoscaling options for workloads of type 'Scheduled Job'"),
+			wantedError: fmt.Errorf(`must specify one, not both, of "target_container" and "targetContainer"`),
 		},
-		"valid if only spot is specified": {
-			AdvancedCount: AdvancedCount{
-				Spot:         aws.Int(42),
-				workloadType: BackendServiceType,
+		"error if target_container is an empty string": {
+			RoutingRule: RoutingRule{
+				TargetContainer: aws.String(""),
 			},
+			wantedError: fmt.Errorf(`"target_container" cannot be an empty string`),
 		},
-		"valid when range and and at least one autoscaling fields are specified": {
-			AdvancedCount: AdvancedCount{
-				Range: Range{
-					Value: (*IntRangeBand)(aws.String("1-10")),
-				},
-				CPU: &mockPerc,
-				QueueScaling: QueueScaling{
-					AcceptableLatency: durationp(10 * time.Second),
-					AvgProcessingTime: durationp(1 * time.Second),
-				},
-				workloadType: WorkerServiceType,
+		"error if target_port is 0": {
+			RoutingRule: RoutingRule{
+				TargetPort: aws.Uint16(0),
 			},
+			wantedError: fmt.Errorf(`"target_port" must be greater than 0`),
 		},
-		"error if both spot and autoscaling fields are specified": {
-			AdvancedCount: AdvancedCount{
-				Spot:         aws.Int(123),
-				CPU:          &mockPerc,
-				workloadType: LoadBalancedWebServiceType,
+		"error if healthcheck port is 0": {
+			RoutingRule: RoutingRule{
+				HealthCheck: HealthCheckArgsOrString{
+					HealthCheckArgs: HTTPHealthCheckArgs{
+						Port: aws.Uint16(0),
+					},
+				},
 			},
-			wantedError: fmt.Errorf(`must specify one, not both, of "spot" and "range/cpu_percentage/memory_percentage/requests/response_time"`),
+			wantedError: fmt.Errorf(`validate "healthcheck": "port" must be greater than 0`),
 		},
-		"error if fail to validate range": {
-			AdvancedCount: AdvancedCount{
-				Range: Range{
-					Value: (*IntRangeBand)(aws.String("")),
+		"error if healthy_threshold is out of range": {
+			RoutingRule: RoutingRule{
+				HealthCheck: HealthCheckArgsOrString{
+					HealthCheckArgs: HTTPHealthCheckArgs{
+						HealthyThreshold: aws.Int64(11),
+					},
 				},
-				workloadType: LoadBalancedWebServiceType,
 			},
-			wantedErrorMsgPrefix: `validate "range": `,
+			wantedError: fmt.Errorf(`validate "healthcheck": "healthy_threshold" 11 must be between 2 and 10`),
 		},
-		"error if range is missing when autoscaling fields are set for Load Balanced Web Service": {
-			AdvancedCount: AdvancedCount{
-				Requests:     aws.Int(123),
-				workloadType: LoadBalancedWebServiceType,
+		"error if unhealthy_threshold is out of range": {
+			RoutingRule: RoutingRule{
+				HealthCheck: HealthCheckArgsOrString{
+					HealthCheckArgs: HTTPHealthCheckArgs{
+						UnhealthyThreshold: aws.Int64(1),
+					},
+				},
 			},
-			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage, memory_percentage, requests or response_time" are specified`),
+			wantedError: fmt.Errorf(`validate "healthcheck": "unhealthy_threshold" 1 must be between 2 and 10`),
 		},
-		"error if range is specified but no autoscaling fields are specified for a Load Balanced Web Service": {
-			AdvancedCount: AdvancedCount{
-				Range: Range{
-					Value: (*IntRangeBand)(aws.String("1-10")),
+		"error if healthcheck timeout is not less than interval": {
+			RoutingRule: RoutingRule{
+				HealthCheck: HealthCheckArgsOrString{
+					HealthCheckArgs: HTTPHealthCheckArgs{
+						Interval: durationp(10 * time.Second),
+						Timeout:  durationp(10 * time.Second),
+					},
 				},
-				workloadType: LoadBalancedWebServiceType,
 			},
-			wantedError: fmt.Errorf(`must specify at least one of "cpu_percentage", "memory_percentage", "requests" or "response_time" if "range" is specified`),
+			wantedError: fmt.Errorf(`validate "healthcheck": "timeout" 10s must be less than "interval" 10s`),
 		},
-		"error if range is specified but no autoscaling fields are specified for a Backend Service": {
-			AdvancedCount: AdvancedCount{
-				Range: Range{
-					Value: (*IntRangeBand)(aws.String("1-10")),
+		"should not error with a full healthcheck object": {
+			RoutingRule: RoutingRule{
+				HealthCheck: HealthCheckArgsOrString{
+					HealthCheckArgs: HTTPHealthCheckArgs{
+						Path:               aws.String("/_health"),
+						Port:               aws.Uint16(8081),
+						SuccessCodes:       aws.String("200-299"),
+						HealthyThreshold:   aws.Int64(2),
+						UnhealthyThreshold: aws.Int64(10),
+						Interval:           durationp(15 * time.Second),
+						Timeout:            durationp(10 * time.Second),
+					},
 				},
-				workloadType: BackendServiceType,
 			},
-			wantedError: fmt.Errorf(`must specify at least one of "cpu_percentage" or "memory_percentage" if "range" is specified`),
 		},
-		"error if range is specified but no autoscaling fields are specified for a Worker Service": {
-			AdvancedCount: AdvancedCount{
-				Range: Range{
-					Value: (*IntRangeBand)(aws.String("1-10")),
+		"error if one of allowed_source_ips is not valid": {
+			RoutingRule: RoutingRule{
+				AllowedSourceIps: []IPNet{
+					IPNet("10.1.0.0/24"),
+					IPNet("badIP"),
+					IPNet("10.1.1.0/24"),
 				},
-				workloadType: WorkerServiceType,
 			},
-			wantedError: fmt.Errorf(`must specify at least one of "cpu_percentage", "memory_percentage" or "queue_delay" if "range" is specified`),
+			wantedErrorMsgPrefix: `validate "allowed_source_ips[1]": `,
 		},
-		"error if range is missing when autoscaling fields are set for Backend Service": {
-			AdvancedCount: AdvancedCount{
-				CPU:          &mockPerc,
-				workloadType: BackendServiceType,
+		"error if protocol version is not valid": {
+			RoutingRule: RoutingRule{
+				ProtocolVersion: aws.String("quic"),
 			},
-			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage or memory_percentage" are specified`),
+			wantedErrorMsgPrefix: `"version" field value 'quic' must be one of GRPC, HTTP1 or HTTP2`,
 		},
-		"error if range is missing when autoscaling fields are set for Worker Service": {
-			AdvancedCount: AdvancedCount{
-				CPU:          &mockPerc,
-				workloadType: WorkerServiceType,
+		"should not error if protocol version is not uppercase": {
+			RoutingRule: RoutingRule{
+				ProtocolVersion: aws.String("gRPC"),
 			},
-			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage, memory_percentage or queue_delay" are specified`),
 		},
-		"wrap error from queue_delay on failure": {
-			AdvancedCount: AdvancedCount{
-				Range: Range{
-					RangeConfig: RangeConfig{
-						Min:      aws.Int(1),
-						Max:      aws.Int(10),
-						SpotFrom: aws.Int(6),
-					},
+		"error if alias_routing is invalid": {
+			RoutingRule: RoutingRule{
+				Alias: Alias{String: aws.String("api.example.com")},
+				AliasRouting: AliasRouting{
+					Policy: aws.String(AliasRoutingPolicyWeighted),
 				},
-				QueueScaling: QueueScaling{
-					AcceptableLatency: nil,
-					AvgProcessingTime: durationp(1 * time.Second),
+			},
+			wantedError: fmt.Errorf(`validate "alias_routing": "hosted_zone" must be specified`),
+		},
+		"error if alias_routing is specified without alias": {
+			RoutingRule: RoutingRule{
+				AliasRouting: AliasRouting{
+					HostedZone: aws.String("Z0123456789"),
+					Policy:     aws.String(AliasRoutingPolicyFailover),
+					Failover:   aws.String(AliasFailoverPrimary),
 				},
-				workloadType: WorkerServiceType,
 			},
-			wantedErrorMsgPrefix: `validate "queue_delay": `,
+			wantedError: fmt.Errorf(`"alias" must be specified if "alias_routing" is specified`),
 		},
-		"error if CPU perc is not valid": {
-			AdvancedCount: AdvancedCount{
-				Range: Range{
-					Value: (*IntRangeBand)(stringP("1-2")),
+		"error if alias name is not specified": {
+			RoutingRule: RoutingRule{
+				Alias: Alias{
+					AdvancedAliases: []AdvancedAlias{
+						{
+							HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+						},
+					},
 				},
-				CPU:          &invalidPerc,
-				workloadType: LoadBalancedWebServiceType,
 			},
-			wantedErrorMsgPrefix: `validate "cpu_percentage": `,
+			wantedError: fmt.Errorf(`validate "alias": validate "alias[0]": "name" must be specified`),
 		},
-		"error if memory perc is not valid": {
-			AdvancedCount: AdvancedCount{
-				Range: Range{
-					Value: (*IntRangeBand)(stringP("1-2")),
+		"error if alias hosted_zone is not a hosted zone ID": {
+			RoutingRule: RoutingRule{
+				Alias: Alias{
+					AdvancedAliases: []AdvancedAlias{
+						{
+							Alias:      aws.String("example.com"),
+							HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+						},
+						{
+							Alias:      aws.String("tenant.example.org"),
+							HostedZone: aws.String("example.org"),
+						},
+					},
 				},
-				Memory:       &invalidPerc,
-				workloadType: LoadBalancedWebServiceType,
 			},
-			wantedErrorMsgPrefix: `validate "memory_percentage": `,
+			wantedError: fmt.Errorf(`validate "alias": validate "alias[1]": "hosted_zone" value "example.org" must be a hosted zone ID such as "Z0873220N255IR3MTNR4"`),
 		},
-	}
-	for name, tc := range testCases {
-		t.Run(name, func(t *testing.T) {
-			gotErr := tc.AdvancedCount.Validate()
-
+		"error if alias_routing is specified with aliases in a hosted zone": {
+			RoutingRule: RoutingRule{
+				Alias: Alias{
+					AdvancedAliases: []AdvancedAlias{
+						{
+							Alias:      aws.String("example.com"),
+							HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+						},
+					},
+				},
+				AliasRouting: AliasRouting{
+					HostedZone: aws.String("Z0123456789"),
+					Policy:     aws.String(AliasRoutingPolicyFailover),
+					Failover:   aws.String(AliasFailoverPrimary),
+				},
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "alias_routing" and "alias.hosted_zone"`),
+		},
+		"should not error if aliases are in hosted zones": {
+			RoutingRule: RoutingRule{
+				Alias: Alias{
+					AdvancedAliases: []AdvancedAlias{
+						{
+							Alias:      aws.String("example.com"),
+							HostedZone: aws.String("Z0873220N255IR3MTNR4"),
+						},
+						{
+							Alias: aws.String("api.example.com"),
+						},
+					},
+				},
+			},
+		},
+		"error if an additional rule specifies an unsupported field": {
+			RoutingRule: RoutingRule{
+				Path: aws.String("/"),
+				AdditionalRules: []RoutingRule{
+					{
+						Path:       aws.String("api"),
+						Stickiness: aws.Bool(true),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "additional_rules[0]": only "path", "alias" and "allowed_source_ips" can be specified`),
+		},
+		"error if an additional rule does not specify a path": {
+			RoutingRule: RoutingRule{
+				Path: aws.String("/"),
+				AdditionalRules: []RoutingRule{
+					{
+						AllowedSourceIps: []IPNet{"10.1.0.0/24"},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "additional_rules[0]": "path" must be specified`),
+		},
+		"error if the path of an additional rule is the root path": {
+			RoutingRule: RoutingRule{
+				Path: aws.String("api"),
+				AdditionalRules: []RoutingRule{
+					{
+						Path: aws.String("/"),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "additional_rules[0]": "path" cannot be the root path, the rule of "http.path" routes the other requests`),
+		},
+		"error if the path of an additional rule is not unique": {
+			RoutingRule: RoutingRule{
+				Path: aws.String("/"),
+				AdditionalRules: []RoutingRule{
+					{
+						Path: aws.String("api"),
+					},
+					{
+						Path: aws.String("/api/"),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "additional_rules[1]": "path" /api/ is already routed by another rule`),
+		},
+		"error if the alias of an additional rule is not an alias of the service": {
+			RoutingRule: RoutingRule{
+				Path:  aws.String("/"),
+				Alias: Alias{String: aws.String("example.com")},
+				AdditionalRules: []RoutingRule{
+					{
+						Path:  aws.String("admin"),
+						Alias: Alias{String: aws.String("admin.example.com")},
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "additional_rules[0]": "alias" admin.example.com must be one of the aliases of "http.alias"`),
+		},
+		"error if the allowed source ips of an additional rule are invalid": {
+			RoutingRule: RoutingRule{
+				Path: aws.String("/"),
+				AdditionalRules: []RoutingRule{
+					{
+						Path:             aws.String("admin"),
+						AllowedSourceIps: []IPNet{"10.1.0.0/24", "badIP"},
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "additional_rules[0]": validate "allowed_source_ips[1]": `,
+		},
+		"should not error if additional rules are valid": {
+			RoutingRule: RoutingRule{
+				Path:  aws.String("/"),
+				Alias: Alias{StringSlice: []string{"example.com", "admin.example.com"}},
+				AdditionalRules: []RoutingRule{
+					{
+						Path: aws.String("api"),
+					},
+					{
+						Path:             aws.String("admin"),
+						Alias:            Alias{String: aws.String("admin.example.com")},
+						AllowedSourceIps: []IPNet{"10.1.0.0/24"},
+					},
+				},
+			},
+		},
+		"error if hostname_variable is not a valid environment variable name": {
+			RoutingRule: RoutingRule{
+				HostnameVariable: aws.String("PUBLIC-HOST"),
+			},
+			wantedError: fmt.Errorf(`validate "hostname_variable": "PUBLIC-HOST" is not a valid environment variable name`),
+		},
+		"should not error if hostname_variable is valid": {
+			RoutingRule: RoutingRule{
+				HostnameVariable: aws.String("PUBLIC_HOST"),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.RoutingRule.Validate()
+
 			if tc.wantedError != nil {
 				require.EqualError(t, gotErr, tc.wantedError.Error())
 				return
@@ -1308,95 +2458,168 @@ func TestAdvancedCount_Validate(t *testing.T) {
 	}
 }
 
-func TestPercentage_Validate(t *testing.T) {
+func TestObservability_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		in     Percentage
+		in     Observability
 		wanted error
 	}{
-		"should return an error if percentage is not valid": {
-			in:     Percentage(120),
-			wanted: errors.New("percentage value 120 must be an integer from 0 to 100"),
+		"error if trace_propagation is unknown": {
+			in: Observability{
+				TracePropagation: aws.String("b3"),
+			},
+			wanted: errors.New(`"trace_propagation" value "b3" must be one of awsxray or w3c`),
 		},
+		"valid with awsxray": {
+			in: Observability{
+				TracePropagation: aws.String("awsxray"),
+			},
+		},
+		"valid with w3c": {
+			in: Observability{
+				TracePropagation: aws.String("w3c"),
+			},
+		},
+		"error if tracing is unknown": {
+			in: Observability{
+				Tracing: aws.String("datadog"),
+			},
+			wanted: errors.New(`"tracing" value "datadog" must be one of awsxray or awsotel`),
+		},
+		"valid with awsxray tracing": {
+			in: Observability{
+				Tracing:          aws.String("awsxray"),
+				TracePropagation: aws.String("awsxray"),
+			},
+		},
+		"error if collector_config is specified without the OpenTelemetry collector": {
+			in: Observability{
+				Tracing:         aws.String("awsxray"),
+				CollectorConfig: aws.String("otel/config.yaml"),
+			},
+			wanted: errors.New(`"collector_config" can only be specified when "tracing" is "awsotel"`),
+		},
+		"error if collector_config is empty": {
+			in: Observability{
+				Tracing:         aws.String("awsotel"),
+				CollectorConfig: aws.String(""),
+			},
+			wanted: errors.New(`"collector_config" cannot be empty`),
+		},
+		"valid with awsotel tracing and a collector config": {
+			in: Observability{
+				Tracing:         aws.String("awsotel"),
+				CollectorConfig: aws.String("otel/config.yaml"),
+			},
+		},
+		"valid if empty": {},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := tc.in.Validate()
+			gotErr := tc.in.Validate()
 
 			if tc.wanted != nil {
-				require.EqualError(t, err, tc.wanted.Error())
+				require.EqualError(t, gotErr, tc.wanted.Error())
 			} else {
-				require.NoError(t, err)
+				require.NoError(t, gotErr)
 			}
 		})
 	}
 }
 
-func TestQueueScaling_Validate(t *testing.T) {
+func TestInitContainer_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		in     QueueScaling
+		in     InitContainer
 		wanted error
 	}{
-		"should return an error if only msg_processing_time is specified": {
-			in: QueueScaling{
-				AvgProcessingTime: durationp(1 * time.Second),
-			},
-			wanted: errors.New(`"acceptable_latency" must be specified if "msg_processing_time" is specified`),
-		},
-		"should return an error if only acceptable_latency is specified": {
-			in: QueueScaling{
-				AcceptableLatency: durationp(1 * time.Second),
+		"error if neither build nor location is specified": {
+			in: InitContainer{
+				Command: CommandOverride{String: aws.String("./migrate up")},
 			},
-			wanted: errors.New(`"msg_processing_time" must be specified if "acceptable_latency" is specified`),
+			wanted: errors.New(`validate "image": must specify one of "build" and "location"`),
 		},
-		"should return an error if the msg_processing_time is 0": {
-			in: QueueScaling{
-				AcceptableLatency: durationp(1 * time.Second),
-				AvgProcessingTime: durationp(0 * time.Second),
+		"error if a dependency status is invalid": {
+			in: InitContainer{
+				Image: Image{
+					Location: aws.String("migrate/migrate:v4"),
+					DependsOn: DependsOn{
+						"proxy": "ready",
+					},
+				},
 			},
-			wanted: errors.New(`"msg_processing_time" cannot be 0`),
+			wanted: errors.New(`validate "image": validate "depends_on": container dependency status "ready" for proxy must be one of START, COMPLETE, SUCCESS or HEALTHY`),
 		},
-		"should return an error if the msg_processing_time is longer than acceptable_latency": {
-			in: QueueScaling{
-				AcceptableLatency: durationp(500 * time.Millisecond),
-				AvgProcessingTime: durationp(1 * time.Second),
+		"valid with a build target": {
+			in: InitContainer{
+				Image: Image{
+					Build: BuildArgsOrString{
+						BuildArgs: DockerBuildArgs{
+							Dockerfile: aws.String("Dockerfile"),
+							Target:     aws.String("migrate"),
+						},
+					},
+				},
+				Command: CommandOverride{StringSlice: []string{"./migrate", "up"}},
 			},
-			wanted: errors.New(`"msg_processing_time" cannot be longer than "acceptable_latency"`),
 		},
+		"valid if empty": {},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := tc.in.Validate()
+			gotErr := tc.in.Validate()
 
 			if tc.wanted != nil {
-				require.EqualError(t, err, tc.wanted.Error())
+				require.EqualError(t, gotErr, tc.wanted.Error())
 			} else {
-				require.NoError(t, err)
+				require.NoError(t, gotErr)
 			}
 		})
 	}
 }
 
-func TestIntRangeBand_Validate(t *testing.T) {
+func TestReadiness_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		IntRangeBand IntRangeBand
-
-		wantedError error
+		in     Readiness
+		wanted error
 	}{
-		"error if range value is in invalid format": {
-			IntRangeBand: IntRangeBand(*aws.String("")),
-			wantedError:  fmt.Errorf("invalid range value . Should be in format of ${min}-${max}"),
+		"error if url is missing": {
+			in: Readiness{
+				Status: aws.Int(200),
+			},
+			wanted: errors.New(`"url" must be specified if "status" is specified`),
 		},
-		"error if range min is greater than max": {
-			IntRangeBand: IntRangeBand(*aws.String("6-4")),
-			wantedError:  fmt.Errorf("min value 6 cannot be greater than max value 4"),
+		"error if url is not absolute": {
+			in: Readiness{
+				URL: aws.String("/ready"),
+			},
+			wanted: errors.New(`"url" value "/ready" must be an absolute http or https URL`),
+		},
+		"error if url has an unsupported scheme": {
+			in: Readiness{
+				URL: aws.String("tcp://api.example.com:5432"),
+			},
+			wanted: errors.New(`"url" value "tcp://api.example.com:5432" must be an absolute http or https URL`),
+		},
+		"error if status is not an HTTP status code": {
+			in: Readiness{
+				URL:    aws.String("https://api.example.com/ready"),
+				Status: aws.Int(42),
+			},
+			wanted: errors.New(`"status" value 42 must be an HTTP status code from 100 to 599`),
+		},
+		"valid with url and status": {
+			in: Readiness{
+				URL:    aws.String("https://api.example.com/ready"),
+				Status: aws.Int(204),
+			},
 		},
+		"valid if empty": {},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.IntRangeBand.Validate()
+			gotErr := tc.in.Validate()
 
-			if tc.wantedError != nil {
-				require.EqualError(t, gotErr, tc.wantedError.Error())
+			if tc.wanted != nil {
+				require.EqualError(t, gotErr, tc.wanted.Error())
 			} else {
 				require.NoError(t, gotErr)
 			}
@@ -1404,32 +2627,181 @@ func TestIntRangeBand_Validate(t *testing.T) {
 	}
 }
 
-func TestRangeConfig_Validate(t *testing.T) {
+func TestDeploymentConfig_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		RangeConfig RangeConfig
-
-		wantedError error
+		in     DeploymentConfig
+		wanted error
 	}{
-		"error if max is not set": {
-			RangeConfig: RangeConfig{
-				Min: aws.Int(2),
+		"error if pre_deploy image is missing": {
+			in: DeploymentConfig{
+				PreDeploy: PreDeployTask{
+					Command: CommandOverride{String: aws.String("up")},
+				},
 			},
-			wantedError: fmt.Errorf(`"min/max" must be specified`),
+			wanted: errors.New(`validate "pre_deploy": "image" must be specified`),
 		},
-		"error if range min is greater than max": {
-			RangeConfig: RangeConfig{
-				Min: aws.Int(2),
-				Max: aws.Int(1),
+		"error if pre_deploy command is missing": {
+			in: DeploymentConfig{
+				PreDeploy: PreDeployTask{
+					Image: aws.String("migrate/migrate:v4"),
+				},
 			},
-			wantedError: fmt.Errorf("min value 2 cannot be greater than max value 1"),
+			wanted: errors.New(`validate "pre_deploy": "command" must be specified`),
+		},
+		"valid with image and command": {
+			in: DeploymentConfig{
+				PreDeploy: PreDeployTask{
+					Image:   aws.String("migrate/migrate:v4"),
+					Command: CommandOverride{StringSlice: []string{"up"}},
+				},
+			},
+		},
+		"error if an alarm name is specified twice": {
+			in: DeploymentConfig{
+				RollbackAlarms: AlarmArgsOrNames{
+					AlarmNames: []string{"latency", "errors", "latency"},
+				},
+			},
+			wanted: errors.New(`validate "rollback_alarms": alarm "latency" is specified more than once`),
+		},
+		"error if the list of alarm names is empty": {
+			in: DeploymentConfig{
+				RollbackAlarms: AlarmArgsOrNames{
+					AlarmNames: []string{},
+				},
+			},
+			wanted: errors.New(`validate "rollback_alarms": at least one alarm name must be specified`),
+		},
+		"error if an alarm name is empty": {
+			in: DeploymentConfig{
+				RollbackAlarms: AlarmArgsOrNames{
+					AlarmNames: []string{""},
+				},
+			},
+			wanted: errors.New(`validate "rollback_alarms": alarm names must not be empty`),
+		},
+		"error if a threshold is above 100": {
+			in: DeploymentConfig{
+				RollbackAlarms: AlarmArgsOrNames{
+					AlarmArgs: AlarmArgs{
+						CPUUtilization:    aws.Float64(70),
+						MemoryUtilization: aws.Float64(120),
+					},
+				},
+			},
+			wanted: errors.New(`validate "rollback_alarms": "memory_utilization" value 120 must be a percentage between 0 and 100`),
+		},
+		"error if a threshold is negative": {
+			in: DeploymentConfig{
+				RollbackAlarms: AlarmArgsOrNames{
+					AlarmArgs: AlarmArgs{
+						CPUUtilization: aws.Float64(-1),
+					},
+				},
+			},
+			wanted: errors.New(`validate "rollback_alarms": "cpu_utilization" value -1 must be a percentage between 0 and 100`),
 		},
+		"error if min_healthy_percent is above 100": {
+			in: DeploymentConfig{
+				MinHealthyPercent: aws.Int(150),
+			},
+			wanted: errors.New(`"min_healthy_percent" value 150 must be between 0 and 100`),
+		},
+		"error if max_percent is below 100": {
+			in: DeploymentConfig{
+				MaxPercent: aws.Int(50),
+			},
+			wanted: errors.New(`"max_percent" value 50 must be at least 100`),
+		},
+		"error if no task can be started or stopped": {
+			in: DeploymentConfig{
+				MaxPercent: aws.Int(100),
+			},
+			wanted: errors.New(`"min_healthy_percent" and "max_percent" cannot both be 100, otherwise ECS can neither start nor stop a task during a deployment`),
+		},
+		"valid with min_healthy_percent and max_percent": {
+			in: DeploymentConfig{
+				MinHealthyPercent: aws.Int(50),
+				MaxPercent:        aws.Int(100),
+			},
+		},
+		"valid with rollback alarms": {
+			in: DeploymentConfig{
+				RollbackAlarms: AlarmArgsOrNames{
+					AlarmNames: []string{"latency", "errors"},
+				},
+			},
+		},
+		"error if strategy is invalid": {
+			in: DeploymentConfig{
+				Strategy: aws.String("blue-green"),
+			},
+			wanted: errors.New(`"strategy" value "blue-green" must be one of rolling or recreate`),
+		},
+		"error if recreate strategy is specified with min_healthy_percent": {
+			in: DeploymentConfig{
+				Strategy:          aws.String("recreate"),
+				MinHealthyPercent: aws.Int(50),
+			},
+			wanted: errors.New(`must specify one, not both, of "strategy: recreate" and "min_healthy_percent"`),
+		},
+		"error if recreate strategy is specified with max_percent": {
+			in: DeploymentConfig{
+				Strategy:   aws.String("recreate"),
+				MaxPercent: aws.Int(150),
+			},
+			wanted: errors.New(`must specify one, not both, of "strategy: recreate" and "max_percent"`),
+		},
+		"valid with recreate strategy": {
+			in: DeploymentConfig{
+				Strategy: aws.String("recreate"),
+			},
+		},
+		"error if bluegreen is invalid": {
+			in: DeploymentConfig{
+				BlueGreen: BlueGreenConfigOrBool{
+					Config: BlueGreenConfig{
+						TestTrafficRoute: aws.Uint16(443),
+					},
+				},
+			},
+			wanted: errors.New(`validate "bluegreen": "test_traffic_route" 443 cannot be a port of the production listeners`),
+		},
+		"error if bluegreen is specified with a strategy": {
+			in: DeploymentConfig{
+				Strategy:  aws.String("rolling"),
+				BlueGreen: BlueGreenConfigOrBool{Enable: aws.Bool(true)},
+			},
+			wanted: errors.New(`must specify one, not both, of "bluegreen" and "strategy"`),
+		},
+		"error if bluegreen is specified with rollback alarms": {
+			in: DeploymentConfig{
+				RollbackAlarms: AlarmArgsOrNames{
+					AlarmNames: []string{"latency"},
+				},
+				BlueGreen: BlueGreenConfigOrBool{Enable: aws.Bool(true)},
+			},
+			wanted: errors.New(`must specify one, not both, of "bluegreen" and "rollback_alarms"`),
+		},
+		"valid with bluegreen": {
+			in: DeploymentConfig{
+				BlueGreen: BlueGreenConfigOrBool{Enable: aws.Bool(true)},
+			},
+		},
+		"valid with rolling strategy and bounds": {
+			in: DeploymentConfig{
+				Strategy:          aws.String("rolling"),
+				MinHealthyPercent: aws.Int(50),
+			},
+		},
+		"valid if empty": {},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.RangeConfig.Validate()
+			gotErr := tc.in.Validate()
 
-			if tc.wantedError != nil {
-				require.EqualError(t, gotErr, tc.wantedError.Error())
+			if tc.wanted != nil {
+				require.EqualError(t, gotErr, tc.wanted.Error())
 			} else {
 				require.NoError(t, gotErr)
 			}
@@ -1437,129 +2809,117 @@ func TestRangeConfig_Validate(t *testing.T) {
 	}
 }
 
-func TestStorage_Validate(t *testing.T) {
+func TestBlueGreenConfig_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		Storage Storage
-
-		wantedErrorMsgPrefix string
-		wantedError          error
+		in     BlueGreenConfig
+		wanted error
 	}{
-		"error if ephemeral is invalid": {
-			Storage: Storage{
-				Ephemeral: aws.Int(19),
+		"valid if empty": {},
+		"valid with all fields": {
+			in: BlueGreenConfig{
+				TerminationWait:  durationp(30 * time.Minute),
+				TestTrafficRoute: aws.Uint16(8080),
+				Hooks: BlueGreenHooks{
+					AfterAllowTestTraffic: aws.String("arn:aws:lambda:us-west-2:123456789012:function:validate"),
+					BeforeAllowTraffic:    aws.String("arn:aws:lambda:us-west-2:123456789012:function:smoke-test"),
+				},
 			},
-			wantedError: fmt.Errorf(`validate "ephemeral": ephemeral storage must be between 20 GiB and 200 GiB`),
 		},
-		"error if fail to validate volumes": {
-			Storage: Storage{
-				Volumes: map[string]*Volume{
-					"foo": {
-						EFS: EFSConfigOrBool{
-							Enabled: aws.Bool(true),
-						},
-					},
-				},
-			},
-			wantedErrorMsgPrefix: `validate "volumes[foo]": `,
-		},
-		"error if storage has more than one managed volume": {
-			Storage: Storage{
-				Volumes: map[string]*Volume{
-					"foo": {
-						EFS: EFSConfigOrBool{
-							Enabled: aws.Bool(true),
-						},
-						MountPointOpts: MountPointOpts{
-							ContainerPath: aws.String("mockPath"),
-						},
-					},
-					"bar": {
-						EFS: EFSConfigOrBool{
-							Enabled: aws.Bool(true),
-						},
-						MountPointOpts: MountPointOpts{
-							ContainerPath: aws.String("mockPath"),
-						},
-					},
-				},
-			},
-			wantedError: fmt.Errorf("cannot specify more than one managed volume per service"),
-		},
-		"valid": {
-			Storage: Storage{
-				Volumes: map[string]*Volume{
-					"foo": {
-						EFS: EFSConfigOrBool{
-							Enabled: aws.Bool(true),
-						},
-						MountPointOpts: MountPointOpts{
-							ContainerPath: aws.String("mockPath"),
-						},
-					},
-					"bar": {
-						EFS: EFSConfigOrBool{
-							Enabled: aws.Bool(false),
-						},
-						MountPointOpts: MountPointOpts{
-							ContainerPath: aws.String("mockPath"),
-						},
-					},
-					"foobar": {
-						EFS: EFSConfigOrBool{
-							Advanced: EFSVolumeConfiguration{
-								FileSystemID: aws.String("fs-1234567"),
-							},
-						},
-						MountPointOpts: MountPointOpts{
-							ContainerPath: aws.String("mockPath"),
-						},
-					},
+		"error if termination_wait is not a whole number of minutes": {
+			in: BlueGreenConfig{
+				TerminationWait: durationp(90 * time.Second),
+			},
+			wanted: errors.New(`"termination_wait" 1m30s must be a whole number of minutes between 0m and 48h0m0s`),
+		},
+		"error if termination_wait is too long": {
+			in: BlueGreenConfig{
+				TerminationWait: durationp(72 * time.Hour),
+			},
+			wanted: errors.New(`"termination_wait" 72h0m0s must be a whole number of minutes between 0m and 48h0m0s`),
+		},
+		"error if test_traffic_route is 0": {
+			in: BlueGreenConfig{
+				TestTrafficRoute: aws.Uint16(0),
+			},
+			wanted: errors.New(`"test_traffic_route" must be greater than 0`),
+		},
+		"error if test_traffic_route is a production port": {
+			in: BlueGreenConfig{
+				TestTrafficRoute: aws.Uint16(80),
+			},
+			wanted: errors.New(`"test_traffic_route" 80 cannot be a port of the production listeners`),
+		},
+		"error if a hook isn't a Lambda function ARN": {
+			in: BlueGreenConfig{
+				Hooks: BlueGreenHooks{
+					AfterInstall: aws.String("arn:aws:sns:us-west-2:123456789012:topic"),
 				},
 			},
+			wanted: errors.New(`validate "hooks": "arn:aws:sns:us-west-2:123456789012:topic" must be a Lambda function ARN of the form arn:<partition>:lambda:<region>:<account>:function:<function name>`),
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.Storage.Validate()
+			gotErr := tc.in.Validate()
 
-			if tc.wantedError != nil {
-				require.EqualError(t, gotErr, tc.wantedError.Error())
-				return
-			}
-			if tc.wantedErrorMsgPrefix != "" {
-				require.Error(t, gotErr)
-				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
-				return
+			if tc.wanted != nil {
+				require.EqualError(t, gotErr, tc.wanted.Error())
+			} else {
+				require.NoError(t, gotErr)
 			}
-			require.NoError(t, gotErr)
 		})
 	}
 }
 
-func TestVolume_Validate(t *testing.T) {
+func TestValidateDeploymentProgress(t *testing.T) {
 	testCases := map[string]struct {
-		Volume Volume
+		inCount      Count
+		inDeployment DeploymentConfig
 
-		wantedErrorPrefix string
+		wanted error
 	}{
-		"error if fail to validate efs": {
-			Volume: Volume{
-				EFS: EFSConfigOrBool{
-					Advanced: EFSVolumeConfiguration{
-						UID:           aws.Uint32(123),
-						RootDirectory: aws.String("mockDir"),
-					},
+		"valid with the default percentages": {
+			inCount: Count{Value: aws.Int(1)},
+		},
+		"error if a single task can't be replaced": {
+			inCount: Count{Value: aws.Int(1)},
+			inDeployment: DeploymentConfig{
+				MinHealthyPercent: aws.Int(50),
+				MaxPercent:        aws.Int(150),
+			},
+			wanted: errors.New(`"min_healthy_percent" 50 and "max_percent" 150 don't allow ECS to start or stop any of the 1 tasks of "count" during a deployment`),
+		},
+		"valid if a single task can be stopped first": {
+			inCount: Count{Value: aws.Int(1)},
+			inDeployment: DeploymentConfig{
+				MinHealthyPercent: aws.Int(0),
+				MaxPercent:        aws.Int(100),
+			},
+		},
+		"valid if one of many tasks can be started": {
+			inCount: Count{Value: aws.Int(10)},
+			inDeployment: DeploymentConfig{
+				MaxPercent: aws.Int(110),
+			},
+		},
+		"valid with autoscaling": {
+			inCount: Count{
+				AdvancedCount: AdvancedCount{
+					Range: Range{Value: (*IntRangeBand)(aws.String("1-10"))},
 				},
 			},
-			wantedErrorPrefix: `validate "efs": `,
+			inDeployment: DeploymentConfig{
+				MinHealthyPercent: aws.Int(50),
+				MaxPercent:        aws.Int(150),
+			},
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.Volume.Validate()
+			gotErr := validateDeploymentProgress(tc.inCount, tc.inDeployment)
 
-			if tc.wantedErrorPrefix != "" {
-				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			if tc.wanted != nil {
+				require.EqualError(t, gotErr, tc.wanted.Error())
 			} else {
 				require.NoError(t, gotErr)
 			}
@@ -1567,111 +2927,180 @@ func TestVolume_Validate(t *testing.T) {
 	}
 }
 
-func TestEFSVolumeConfiguration_Validate(t *testing.T) {
+func TestNetworkLoadBalancerConfiguration_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		EFSVolumeConfiguration EFSVolumeConfiguration
+		nlb NetworkLoadBalancerConfiguration
 
-		wantedError error
+		wantedErrorMsgPrefix string
+		wantedError          error
 	}{
-		"error if uid/gid are specified with id/root_dir/auth": {
-			EFSVolumeConfiguration: EFSVolumeConfiguration{
-				UID:        aws.Uint32(123),
-				AuthConfig: AuthorizationConfig{IAM: aws.Bool(true)},
+		"success if empty": {
+			nlb: NetworkLoadBalancerConfiguration{},
+		},
+		"error if port unspecified": {
+			nlb: NetworkLoadBalancerConfiguration{
+				TargetContainer: aws.String("main"),
 			},
-			wantedError: fmt.Errorf(`must specify one, not both, of "uid/gid" and "id/root_dir/auth"`),
+			wantedErrorMsgPrefix: `validate "nlb": `,
+			wantedError:          fmt.Errorf(`"port" must be specified`),
 		},
-		"error if uid is set but gid is not": {
-			EFSVolumeConfiguration: EFSVolumeConfiguration{
-				UID: aws.Uint32(123),
+		"error if only target group attributes are specified": {
+			nlb: NetworkLoadBalancerConfiguration{
+				ProxyProtocolV2: aws.Bool(true),
 			},
-			wantedError: fmt.Errorf(`"gid" must be specified if "uid" is specified`),
+			wantedError: fmt.Errorf(`"port" must be specified`),
 		},
-		"error if gid is set but uid is not": {
-			EFSVolumeConfiguration: EFSVolumeConfiguration{
-				GID: aws.Uint32(123),
+		"error if client IP preservation is disabled for UDP": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Port:             aws.String("53/udp"),
+				PreserveClientIP: aws.Bool(false),
 			},
-			wantedError: fmt.Errorf(`"uid" must be specified if "gid" is specified`),
+			wantedError: fmt.Errorf(`"preserve_client_ip" cannot be disabled for the UDP target group of port 53/udp`),
 		},
-		"error if uid is 0": {
-			EFSVolumeConfiguration: EFSVolumeConfiguration{
-				UID: aws.Uint32(0),
-				GID: aws.Uint32(0),
+		"error if stickiness is enabled for a UDP listener": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Port:       aws.String("53/udp"),
+				Stickiness: aws.Bool(true),
 			},
-			wantedError: fmt.Errorf(`"uid" must not be 0`),
+			wantedError: fmt.Errorf(`"stickiness" can only be enabled for TCP listeners, not for the UDP listener of port 53/udp`),
 		},
-		"error if AuthorizationConfig is not configured correctly": {
-			EFSVolumeConfiguration: EFSVolumeConfiguration{
-				AuthConfig: AuthorizationConfig{
-					AccessPointID: aws.String("mockID"),
+		"error if stickiness is enabled for a TLS listener": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Port:       aws.String("443/tls"),
+				Stickiness: aws.Bool(true),
+			},
+			wantedError: fmt.Errorf(`"stickiness" can only be enabled for TCP listeners, not for the TLS listener of port 443/tls`),
+		},
+		"error if stickiness is enabled for an additional UDP listener": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Port:       aws.String("443"),
+				Stickiness: aws.Bool(true),
+				AdditionalListeners: []NetworkLoadBalancerListener{
+					{
+						Port:       aws.String("53/udp"),
+						Stickiness: aws.Bool(true),
+					},
 				},
-				RootDirectory: aws.String("mockDir"),
 			},
-			wantedError: fmt.Errorf(`"root_dir" must be either empty or "/" and "auth.iam" must be true when "access_point_id" is used`),
+			wantedError: fmt.Errorf(`validate "additional_listeners[0]": "stickiness" can only be enabled for TCP listeners, not for the UDP listener of port 53/udp`),
 		},
-		"error if root_dir is invalid": {
-			EFSVolumeConfiguration: EFSVolumeConfiguration{
-				RootDirectory: aws.String("!!!!"),
+		"error if the port of an additional listener is unspecified": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Port: aws.String("443"),
+				AdditionalListeners: []NetworkLoadBalancerListener{
+					{
+						TargetPort: aws.Int(8053),
+					},
+				},
 			},
-			wantedError: fmt.Errorf(`validate "root_dir": path can only contain the characters a-zA-Z0-9.-_/`),
+			wantedError: fmt.Errorf(`validate "additional_listeners[0]": "port" must be specified`),
 		},
-	}
-	for name, tc := range testCases {
-		t.Run(name, func(t *testing.T) {
-			gotErr := tc.EFSVolumeConfiguration.Validate()
-
-			if tc.wantedError != nil {
-				require.EqualError(t, gotErr, tc.wantedError.Error())
-			} else {
-				require.NoError(t, gotErr)
-			}
-		})
-	}
-}
-
-func TestSidecarConfig_Validate(t *testing.T) {
-	testCases := map[string]struct {
-		config SidecarConfig
-
-		wantedErrorPrefix string
-	}{
-		"error if fail to validate mount_points": {
-			config: SidecarConfig{
-				MountPoints: []SidecarMountPoint{
-					{},
+		"error if an additional listener uses the port of the primary listener": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Port: aws.String("443/tls"),
+				AdditionalListeners: []NetworkLoadBalancerListener{
+					{
+						Port: aws.String("443/tcp"),
+					},
 				},
 			},
-			wantedErrorPrefix: `validate "mount_points[0]": `,
+			wantedError: fmt.Errorf(`validate "additional_listeners[0]": port 443 is already used by another listener`),
 		},
-		"error if fail to validate depends_on": {
-			config: SidecarConfig{
-				DependsOn: DependsOn{
-					"foo": "bar",
+		"error if two additional listeners use the same port": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Port: aws.String("443"),
+				AdditionalListeners: []NetworkLoadBalancerListener{
+					{
+						Port: aws.String("53/udp"),
+					},
+					{
+						Port: aws.String("53"),
+					},
 				},
 			},
-			wantedErrorPrefix: `validate "depends_on": `,
+			wantedError: fmt.Errorf(`validate "additional_listeners[1]": port 53 is already used by another listener`),
+		},
+		"error if there are more additional listeners than the service can register target groups for": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Port: aws.String("443"),
+				AdditionalListeners: []NetworkLoadBalancerListener{
+					{
+						Port: aws.String("53/udp"),
+					},
+					{
+						Port: aws.String("8080"),
+					},
+					{
+						Port: aws.String("8081"),
+					},
+					{
+						Port: aws.String("8082"),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`validate "additional_listeners": at most 3 additional listeners can be specified because an ECS service can register at most 5 target groups`),
+		},
+		"error if additional listeners are specified without a port": {
+			nlb: NetworkLoadBalancerConfiguration{
+				AdditionalListeners: []NetworkLoadBalancerListener{
+					{
+						Port: aws.String("53/udp"),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`"port" must be specified`),
+		},
+		"success with additional listeners": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Port: aws.String("8080"),
+				AdditionalListeners: []NetworkLoadBalancerListener{
+					{
+						Port:            aws.String("53/udp"),
+						TargetPort:      aws.Int(8053),
+						TargetContainer: aws.String("dns"),
+					},
+					{
+						Port: aws.String("443/tls"),
+					},
+				},
+			},
+		},
+		"success with target group attributes": {
+			nlb: NetworkLoadBalancerConfiguration{
+				Port:             aws.String("443/tcp"),
+				Stickiness:       aws.Bool(true),
+				PreserveClientIP: aws.Bool(false),
+				ProxyProtocolV2:  aws.Bool(true),
+			},
 		},
 	}
+
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.config.Validate()
+			gotErr := tc.nlb.Validate()
 
-			if tc.wantedErrorPrefix != "" {
-				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
-			} else {
-				require.NoError(t, gotErr)
+			if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+				return
+			}
+			if tc.wantedErrorMsgPrefix != "" {
+				require.Error(t, gotErr)
+				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
+				return
 			}
+			require.NoError(t, gotErr)
 		})
 	}
 }
 
-func TestSidecarMountPoint_Validate(t *testing.T) {
+func TestIPNet_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		in     SidecarMountPoint
+		in     IPNet
 		wanted error
 	}{
-		"should return an error if source_volume is not set": {
-			in:     SidecarMountPoint{},
-			wanted: errors.New(`"source_volume" must be specified`),
+		"should return an error if IPNet is not valid": {
+			in:     IPNet("badIPNet"),
+			wanted: errors.New("parse IPNet badIPNet: invalid CIDR address: badIPNet"),
 		},
 	}
 	for name, tc := range testCases {
@@ -1687,81 +3116,118 @@ func TestSidecarMountPoint_Validate(t *testing.T) {
 	}
 }
 
-func TestMountPointOpts_Validate(t *testing.T) {
+func TestTaskConfig_Validate(t *testing.T) {
+	mockPerc := Percentage(70)
 	testCases := map[string]struct {
-		in     MountPointOpts
-		wanted error
+		TaskConfig TaskConfig
+
+		wantedErrorPrefix string
 	}{
-		"should return an error if path is not set": {
-			in:     MountPointOpts{},
-			wanted: errors.New(`"path" must be specified`),
-		},
-		"should return an error if path is invalid": {
-			in: MountPointOpts{
-				ContainerPath: aws.String("!!!!!!"),
-			},
-			wanted: errors.New(`validate "path": path can only contain the characters a-zA-Z0-9.-_/`),
+		"error if fail to validate platform": {
+			TaskConfig: TaskConfig{
+				Platform: PlatformArgsOrString{
+					PlatformString: (*PlatformString)(aws.String("")),
+				},
+			},
+			wantedErrorPrefix: `validate "platform": `,
 		},
-	}
-	for name, tc := range testCases {
-		t.Run(name, func(t *testing.T) {
-			err := tc.in.Validate()
-
-			if tc.wanted != nil {
-				require.EqualError(t, err, tc.wanted.Error())
-			} else {
-				require.NoError(t, err)
-			}
-		})
-	}
-}
-
-func TestNetworkConfig_Validate(t *testing.T) {
-	testCases := map[string]struct {
-		config NetworkConfig
-
-		wantedErrorPrefix string
-	}{
-		"error if fail to validate vpc": {
-			config: NetworkConfig{
-				VPC: vpcConfig{
-					Placement: (*Placement)(aws.String("")),
+		"error if fail to validate count": {
+			TaskConfig: TaskConfig{
+				Count: Count{
+					AdvancedCount: AdvancedCount{
+						Spot: aws.Int(123),
+						CPU:  &mockPerc,
+					},
 				},
 			},
-			wantedErrorPrefix: `validate "vpc": `,
+			wantedErrorPrefix: `validate "count": `,
 		},
-	}
-	for name, tc := range testCases {
-		t.Run(name, func(t *testing.T) {
-			gotErr := tc.config.Validate()
-
-			if tc.wantedErrorPrefix != "" {
-				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
-			} else {
-				require.NoError(t, gotErr)
-			}
-		})
-	}
-}
-
-func TestRequestDrivenWebServiceNetworkConfig_Validate(t *testing.T) {
-	testCases := map[string]struct {
-		config RequestDrivenWebServiceNetworkConfig
-
-		wantedErrorPrefix string
-	}{
-		"error if fail to validate vpc": {
-			config: RequestDrivenWebServiceNetworkConfig{
-				VPC: rdwsVpcConfig{
-					Placement: (*RequestDrivenWebServicePlacement)(aws.String("")),
+		"error if fail to validate storage": {
+			TaskConfig: TaskConfig{
+				Storage: Storage{
+					Volumes: map[string]*Volume{
+						"foo": {
+							EFS: EFSConfigOrBool{
+								Advanced: EFSVolumeConfiguration{
+									UID:          aws.Uint32(123),
+									FileSystemID: aws.String("mockID"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantedErrorPrefix: `validate "storage": `,
+		},
+		"error if fail to validate exec": {
+			TaskConfig: TaskConfig{
+				ExecuteCommand: ExecuteCommand{
+					Config: ExecuteCommandConfig{
+						Logging: &ExecLogging{
+							S3KeyPrefix: aws.String("exec/"),
+						},
+					},
+				},
+			},
+			wantedErrorPrefix: `validate "exec": validate "logging": `,
+		},
+		"error if fail to validate secrets": {
+			TaskConfig: TaskConfig{
+				Secrets: map[string]Secret{
+					"FLAGS": {
+						AppConfig: &AppConfigSecret{
+							Application: aws.String("phonetool"),
+						},
+					},
+				},
+			},
+			wantedErrorPrefix: `validate secret "FLAGS": validate "appconfig": `,
+		},
+		"error if container name has invalid characters": {
+			TaskConfig: TaskConfig{
+				ContainerName: aws.String("api.main"),
+			},
+			wantedErrorPrefix: `validate "container_name": container name "api.main" can only contain letters, numbers, underscores, and hyphens`,
+		},
+		"error if container name is empty": {
+			TaskConfig: TaskConfig{
+				ContainerName: aws.String(""),
+			},
+			wantedErrorPrefix: `validate "container_name": container name must be between 1 and 255 characters long`,
+		},
+		"valid container name": {
+			TaskConfig: TaskConfig{
+				ContainerName: aws.String("web_app-1"),
+			},
+		},
+		"error if a variable is read from an invalid SSM parameter name": {
+			TaskConfig: TaskConfig{
+				Variables: Variables{
+					FromSSM: map[string]string{
+						"DB_HOST": "/phonetool/test/db host",
+					},
+				},
+			},
+			wantedErrorPrefix: `validate "variables": validate "DB_HOST.from_ssm": parameter name "/phonetool/test/db host" can only contain letters, numbers, and the characters "_-./", and must start with "/" if it is a path`,
+		},
+		"valid SSM parameter names of variables": {
+			TaskConfig: TaskConfig{
+				Variables: Variables{
+					Values: map[string]string{
+						"DEBUG": "true",
+					},
+					FromSSM: map[string]string{
+						"DB_HOST":  "/phonetool/test/db_host",
+						"LOG_MODE": "log-mode.v2",
+						"AMI_ID":   "/aws/service/ecs/optimized-ami/amazon-linux-2/recommended/image_id",
+					},
 				},
 			},
-			wantedErrorPrefix: `validate "vpc": `,
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.config.Validate()
+			gotErr := tc.TaskConfig.Validate()
 
 			if tc.wantedErrorPrefix != "" {
 				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
@@ -1772,25 +3238,73 @@ func TestRequestDrivenWebServiceNetworkConfig_Validate(t *testing.T) {
 	}
 }
 
-func TestRdwsVpcConfig_Validate(t *testing.T) {
+func TestVariables_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		config rdwsVpcConfig
-
-		wantedErrorPrefix string
+		in     Variables
+		wanted error
 	}{
-		"error if fail to validate placement": {
-			config: rdwsVpcConfig{
-				Placement: (*RequestDrivenWebServicePlacement)(aws.String("")),
+		"valid if there are no parameter-backed variables": {
+			in: Variables{
+				Values: map[string]string{
+					"PORT": "8080",
+				},
 			},
-			wantedErrorPrefix: `validate "placement": `,
+		},
+		"valid parameter name without a hierarchy": {
+			in: Variables{
+				FromSSM: map[string]string{
+					"DB_HOST": "db_host",
+				},
+			},
+		},
+		"error if the parameter name is empty": {
+			in: Variables{
+				FromSSM: map[string]string{
+					"DB_HOST": "",
+				},
+			},
+			wanted: errors.New(`validate "DB_HOST.from_ssm": parameter name must be between 1 and 1011 characters long`),
+		},
+		"error if a hierarchy does not start with a slash": {
+			in: Variables{
+				FromSSM: map[string]string{
+					"DB_HOST": "phonetool/test/db_host",
+				},
+			},
+			wanted: errors.New(`validate "DB_HOST.from_ssm": parameter name "phonetool/test/db_host" can only contain letters, numbers, and the characters "_-./", and must start with "/" if it is a path`),
+		},
+		"error if the path has an empty level": {
+			in: Variables{
+				FromSSM: map[string]string{
+					"DB_HOST": "/phonetool//db_host",
+				},
+			},
+			wanted: errors.New(`validate "DB_HOST.from_ssm": parameter name "/phonetool//db_host" can only contain letters, numbers, and the characters "_-./", and must start with "/" if it is a path`),
+		},
+		"error if the hierarchy is too deep": {
+			in: Variables{
+				FromSSM: map[string]string{
+					"DB_HOST": strings.Repeat("/a", 16),
+				},
+			},
+			wanted: fmt.Errorf(`validate "DB_HOST.from_ssm": parameter name %q has 16 levels of hierarchy, which exceeds the maximum of 15`, strings.Repeat("/a", 16)),
+		},
+		"report the invalid variables in alphabetical order": {
+			in: Variables{
+				FromSSM: map[string]string{
+					"B_VAR": "b var",
+					"A_VAR": "a var",
+				},
+			},
+			wanted: errors.New(`validate "A_VAR.from_ssm": parameter name "a var" can only contain letters, numbers, and the characters "_-./", and must start with "/" if it is a path`),
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.config.Validate()
+			gotErr := tc.in.Validate()
 
-			if tc.wantedErrorPrefix != "" {
-				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			if tc.wanted != nil {
+				require.EqualError(t, gotErr, tc.wanted.Error())
 			} else {
 				require.NoError(t, gotErr)
 			}
@@ -1798,25 +3312,142 @@ func TestRdwsVpcConfig_Validate(t *testing.T) {
 	}
 }
 
-func TestVpcConfig_Validate(t *testing.T) {
+func TestLogging_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		config vpcConfig
-
-		wantedErrorPrefix string
+		in     Logging
+		wanted error
 	}{
-		"error if fail to validate placement": {
-			config: vpcConfig{
-				Placement: (*Placement)(aws.String("")),
+		"error if configType is invalid": {
+			in: Logging{
+				ConfigType: aws.String("logstash"),
+			},
+			wanted: errors.New(`"configType" value "logstash" must be one of fluentbit or fluentd`),
+		},
+		"valid with fluentd": {
+			in: Logging{
+				ConfigType: aws.String("fluentd"),
+				SecretOptions: map[string]string{
+					"apikey": "/copilot/secret",
+				},
+			},
+		},
+		"valid without configType": {
+			in: Logging{
+				Destination: map[string]string{
+					"Name": "cloudwatch",
+				},
+			},
+		},
+		"error if destination sets a reserved option": {
+			in: Logging{
+				Destination: map[string]string{
+					"Name":              "cloudwatch",
+					"config-file-value": "/fluent-bit/etc/extra.conf",
+				},
+			},
+			wanted: errors.New(`validate "destination": option "config-file-value" is reserved by Copilot`),
+		},
+		"error if secretOptions sets the plugin name": {
+			in: Logging{
+				Destination: map[string]string{
+					"region": "us-west-2",
+				},
+				SecretOptions: map[string]string{
+					"Name":   "/copilot/plugin",
+					"apikey": "/copilot/secret",
+				},
+			},
+			wanted: errors.New(`validate "secretOptions": option "Name" is reserved and cannot be a secret`),
+		},
+		"valid with log router variables and secrets": {
+			in: Logging{
+				Variables: map[string]string{
+					"DD_SITE": "datadoghq.com",
+				},
+				Secrets: map[string]Secret{
+					"DD_API_KEY": {From: aws.String("/copilot/datadog/api-key")},
+				},
+			},
+		},
+		"error if a log router secret is invalid": {
+			in: Logging{
+				Secrets: map[string]Secret{
+					"DD_API_KEY": {AppConfig: &AppConfigSecret{Application: aws.String("my-app")}},
+				},
+			},
+			wanted: errors.New(`validate secret "DD_API_KEY": validate "appconfig": "environment" must be specified`),
+		},
+		"error if a log router secret is served by AppConfig": {
+			in: Logging{
+				Secrets: map[string]Secret{
+					"DD_API_KEY": {AppConfig: &AppConfigSecret{
+						Application: aws.String("my-app"),
+						Environment: aws.String("prod"),
+						Profile:     aws.String("datadog"),
+					}},
+				},
+			},
+			wanted: errors.New(`validate secret "DD_API_KEY": "appconfig" secrets are only served to the main container`),
+		},
+		"error if secretOptions sets a reserved option": {
+			in: Logging{
+				SecretOptions: map[string]string{
+					"config-file-type": "/copilot/type",
+				},
+			},
+			wanted: errors.New(`validate "secretOptions": option "config-file-type" is reserved and cannot be a secret`),
+		},
+		"error if log_group has invalid characters": {
+			in: Logging{
+				LogGroup: aws.String("central logs"),
+			},
+			wanted: errors.New(`validate "log_group": log group name "central logs" can only contain letters, numbers, and the characters "_-/.#"`),
+		},
+		"error if log_group is too long": {
+			in: Logging{
+				LogGroup: aws.String(strings.Repeat("a", 513)),
+			},
+			wanted: errors.New(`validate "log_group": log group name must be between 1 and 512 characters long`),
+		},
+		"error if log_group collides with Copilot-managed log groups": {
+			in: Logging{
+				LogGroup: aws.String("/copilot/my-app-test-api"),
+			},
+			wanted: errors.New(`validate "log_group": log group name "/copilot/my-app-test-api" cannot start with "/copilot/", which is reserved for log groups managed by Copilot`),
+		},
+		"valid with a custom log_group": {
+			in: Logging{
+				Retention: aws.Int(14),
+				LogGroup:  aws.String("/central/payments/api"),
+			},
+		},
+		"error if import_log_group is set without log_group": {
+			in: Logging{
+				ImportLogGroup: aws.Bool(true),
+			},
+			wanted: errors.New(`"log_group" must be specified if "import_log_group" is specified`),
+		},
+		"error if retention is set for an imported log group": {
+			in: Logging{
+				Retention:      aws.Int(14),
+				LogGroup:       aws.String("/central/payments/api"),
+				ImportLogGroup: aws.Bool(true),
+			},
+			wanted: errors.New(`must specify one, not both, of "retention" and "import_log_group"`),
+		},
+		"valid with an imported log_group": {
+			in: Logging{
+				LogGroup:       aws.String("/central/payments/api"),
+				ImportLogGroup: aws.Bool(true),
 			},
-			wantedErrorPrefix: `validate "placement": `,
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.config.Validate()
+			gotErr := tc.in.Validate()
 
-			if tc.wantedErrorPrefix != "" {
-				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			if tc.wanted != nil {
+				require.EqualError(t, gotErr, tc.wanted.Error())
 			} else {
 				require.NoError(t, gotErr)
 			}
@@ -1824,141 +3455,2659 @@ func TestVpcConfig_Validate(t *testing.T) {
 	}
 }
 
-func TestPlacement_Validate(t *testing.T) {
-	mockEmptyPlacement := Placement("")
-	mockInvalidPlacement := Placement("external")
+func TestAppConfigSecret_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		in     *Placement
+		in     AppConfigSecret
 		wanted error
 	}{
-		"should return an error if placement is empty": {
-			in:     &mockEmptyPlacement,
-			wanted: errors.New(`"placement" cannot be empty`),
+		"error if application is missing": {
+			in: AppConfigSecret{
+				Environment: aws.String("test"),
+				Profile:     aws.String("flags"),
+			},
+			wanted: errors.New(`"application" must be specified`),
 		},
-		"should return an error if placement is invalid": {
-			in:     &mockInvalidPlacement,
-			wanted: errors.New(`"placement" external must be one of public, private`),
+		"error if environment is missing": {
+			in: AppConfigSecret{
+				Application: aws.String("phonetool"),
+				Profile:     aws.String("flags"),
+			},
+			wanted: errors.New(`"environment" must be specified`),
+		},
+		"error if profile is missing": {
+			in: AppConfigSecret{
+				Application: aws.String("phonetool"),
+				Environment: aws.String("test"),
+			},
+			wanted: errors.New(`"profile" must be specified`),
+		},
+		"valid": {
+			in: AppConfigSecret{
+				Application: aws.String("phonetool"),
+				Environment: aws.String("test"),
+				Profile:     aws.String("flags"),
+			},
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := tc.in.Validate()
+			gotErr := tc.in.Validate()
 
 			if tc.wanted != nil {
-				require.EqualError(t, err, tc.wanted.Error())
+				require.EqualError(t, gotErr, tc.wanted.Error())
 			} else {
-				require.NoError(t, err)
+				require.NoError(t, gotErr)
 			}
 		})
 	}
 }
 
-func TestRequestDrivenWebServicePlacement_Validate(t *testing.T) {
+func TestExecLogging_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		in     *RequestDrivenWebServicePlacement
+		in     ExecLogging
 		wanted error
 	}{
-		"should return an error if placement is public": {
-			in:     (*RequestDrivenWebServicePlacement)(aws.String("public")),
-			wanted: errors.New(`placement "public" is not supported for Request-Driven Web Service`),
+		"error if s3_key_prefix is set without s3_bucket": {
+			in: ExecLogging{
+				CloudWatchLogGroup: aws.String("exec-audit"),
+				S3KeyPrefix:        aws.String("exec/"),
+			},
+			wanted: errors.New(`"s3_bucket" must be specified if "s3_key_prefix" is specified`),
+		},
+		"valid with both s3_bucket and s3_key_prefix": {
+			in: ExecLogging{
+				S3Bucket:    aws.String("audit-bucket"),
+				S3KeyPrefix: aws.String("exec/"),
+			},
+		},
+		"valid with only a log group": {
+			in: ExecLogging{
+				CloudWatchLogGroup:          aws.String("exec-audit"),
+				CloudWatchEncryptionEnabled: aws.Bool(true),
+			},
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := tc.in.Validate()
+			gotErr := tc.in.Validate()
 
 			if tc.wanted != nil {
-				require.EqualError(t, err, tc.wanted.Error())
+				require.EqualError(t, gotErr, tc.wanted.Error())
 			} else {
-				require.NoError(t, err)
+				require.NoError(t, gotErr)
 			}
 		})
 	}
 }
 
-func TestAppRunnerInstanceConfig_Validate(t *testing.T) {
+func TestPlatformArgsOrString_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		config            AppRunnerInstanceConfig
-		wantedErrorPrefix string
-		wantedError       error
+		in     PlatformArgsOrString
+		wanted error
 	}{
-		"error if fail to validate platforms": {
-			config: AppRunnerInstanceConfig{
-				Platform: PlatformArgsOrString{
-					PlatformString: (*PlatformString)(aws.String("")),
+		"error if platform string is invalid": {
+			in:     PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("foobar/amd64"))},
+			wanted: fmt.Errorf("platform 'foobar/amd64' is invalid; valid platforms are: linux/amd64, linux/x86_64, linux/arm, linux/arm64, windows/amd64 and windows/x86_64"),
+		},
+		"error if only half of platform string is specified": {
+			in:     PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux"))},
+			wanted: fmt.Errorf("platform 'linux' must be in the format [OS]/[Arch]"),
+		},
+		"error if only osfamily is specified": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("linux"),
+				},
+			},
+			wanted: fmt.Errorf(`fields "osfamily" and "architecture" must either both be specified or both be empty`),
+		},
+		"error if only architecture is specified": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					Arch: aws.String("X86_64"),
+				},
+			},
+			wanted: fmt.Errorf(`fields "osfamily" and "architecture" must either both be specified or both be empty`),
+		},
+		"error if osfamily is invalid": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("foo"),
+					Arch:     aws.String("amd64"),
+				},
+			},
+			wanted: fmt.Errorf("platform pair ('foo', 'amd64') is invalid: fields ('osfamily', 'architecture') must be one of ('linux', 'x86_64'), ('linux', 'amd64'), ('linux', 'arm'), ('linux', 'arm64'), ('windows', 'x86_64'), ('windows', 'amd64'), ('windows_server_2019_core', 'x86_64'), ('windows_server_2019_core', 'amd64'), ('windows_server_2019_full', 'x86_64'), ('windows_server_2019_full', 'amd64'), ('windows_server_2022_core', 'x86_64'), ('windows_server_2022_core', 'amd64'), ('windows_server_2022_full', 'x86_64'), ('windows_server_2022_full', 'amd64')"),
+		},
+		"error if arch is invalid": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("linux"),
+					Arch:     aws.String("bar"),
+				},
+			},
+			wanted: fmt.Errorf("platform pair ('linux', 'bar') is invalid: fields ('osfamily', 'architecture') must be one of ('linux', 'x86_64'), ('linux', 'amd64'), ('linux', 'arm'), ('linux', 'arm64'), ('windows', 'x86_64'), ('windows', 'amd64'), ('windows_server_2019_core', 'x86_64'), ('windows_server_2019_core', 'amd64'), ('windows_server_2019_full', 'x86_64'), ('windows_server_2019_full', 'amd64'), ('windows_server_2022_core', 'x86_64'), ('windows_server_2022_core', 'amd64'), ('windows_server_2022_full', 'x86_64'), ('windows_server_2022_full', 'amd64')"),
+		},
+		"return nil if platform string valid": {
+			in: PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/amd64"))},
+		},
+		"return nil if platform string uses an architecture alias": {
+			in: PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("Linux/AArch64"))},
+		},
+		"return nil if platform args use aliases": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("windows-server-2022-core"),
+					Arch:     aws.String("x86-64"),
+				},
+			},
+		},
+		"return nil if platform args valid": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("linux"),
+					Arch:     aws.String("amd64"),
+				},
+			},
+			wanted: nil,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAdvancedCount_Validate(t *testing.T) {
+	var (
+		mockPerc    = Percentage(70)
+		invalidPerc = Percentage(-1)
+	)
+	testCases := map[string]struct {
+		AdvancedCount AdvancedCount
+
+		wantedError          error
+		wantedErrorMsgPrefix string
+	}{
+		"cannot have autoscaling for scheduled jobs": {
+			AdvancedCount: AdvancedCount{
+				Spot:         aws.Int(42),
+				workloadType: ScheduledJobType,
+			},
+			wantedError: errors.New("cannot have autoscaling options for workloads of type 'Scheduled Job'"),
+		},
+		"valid if only spot is specified": {
+			AdvancedCount: AdvancedCount{
+				Spot:         aws.Int(42),
+				workloadType: BackendServiceType,
+			},
+		},
+		"valid when range and and at least one autoscaling fields are specified": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(aws.String("1-10")),
+				},
+				CPU: &mockPerc,
+				QueueScaling: QueueScaling{
+					AcceptableLatency: durationp(10 * time.Second),
+					AvgProcessingTime: durationp(1 * time.Second),
+				},
+				workloadType: WorkerServiceType,
+			},
+		},
+		"valid with an on-demand and spot split": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(aws.String("1-10")),
+				},
+				CPU: &mockPerc,
+				CapacityProviders: CapacityProviders{
+					OnDemand: CapacityProviderStrategy{
+						Base: aws.Int(1),
+					},
+					Spot: CapacityProviderStrategy{
+						Weight: aws.Int(1),
+					},
+				},
+				workloadType: BackendServiceType,
+			},
+		},
+		"error if both spot and capacity_providers are specified": {
+			AdvancedCount: AdvancedCount{
+				Spot: aws.Int(2),
+				CapacityProviders: CapacityProviders{
+					Spot: CapacityProviderStrategy{
+						Weight: aws.Int(1),
+					},
+				},
+				workloadType: BackendServiceType,
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "spot" and "capacity_providers"`),
+		},
+		"error if both spot_from and capacity_providers are specified": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					RangeConfig: RangeConfig{
+						Min:      aws.Int(1),
+						Max:      aws.Int(10),
+						SpotFrom: aws.Int(3),
+					},
+				},
+				CPU: &mockPerc,
+				CapacityProviders: CapacityProviders{
+					Spot: CapacityProviderStrategy{
+						Weight: aws.Int(1),
+					},
+				},
+				workloadType: BackendServiceType,
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "range.spot_from" and "capacity_providers"`),
+		},
+		"error if capacity_providers is specified without range": {
+			AdvancedCount: AdvancedCount{
+				CapacityProviders: CapacityProviders{
+					Spot: CapacityProviderStrategy{
+						Weight: aws.Int(1),
+					},
+				},
+				workloadType: BackendServiceType,
+			},
+			wantedError: fmt.Errorf(`"range" must be specified if "capacity_providers" is specified`),
+		},
+		"error if fail to validate capacity_providers": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(aws.String("1-10")),
+				},
+				CPU: &mockPerc,
+				CapacityProviders: CapacityProviders{
+					OnDemand: CapacityProviderStrategy{
+						Base:   aws.Int(0),
+						Weight: aws.Int(0),
+					},
+				},
+				workloadType: BackendServiceType,
+			},
+			wantedError: fmt.Errorf(`validate "capacity_providers": validate "on_demand": at least one of "base" or "weight" must be positive`),
+		},
+		"error if both spot and autoscaling fields are specified": {
+			AdvancedCount: AdvancedCount{
+				Spot:         aws.Int(123),
+				CPU:          &mockPerc,
+				workloadType: LoadBalancedWebServiceType,
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "spot" and "range/cpu_percentage/memory_percentage/requests/response_time/custom_metric"`),
+		},
+		"error if fail to validate range": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(aws.String("")),
+				},
+				workloadType: LoadBalancedWebServiceType,
+			},
+			wantedErrorMsgPrefix: `validate "range": `,
+		},
+		"error if range is missing when autoscaling fields are set for Load Balanced Web Service": {
+			AdvancedCount: AdvancedCount{
+				Requests:     aws.Int(123),
+				workloadType: LoadBalancedWebServiceType,
+			},
+			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage, memory_percentage, requests, response_time or custom_metric" are specified`),
+		},
+		"error if range is specified but no autoscaling fields are specified for a Load Balanced Web Service": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(aws.String("1-10")),
+				},
+				workloadType: LoadBalancedWebServiceType,
+			},
+			wantedError: fmt.Errorf(`must specify at least one of "cpu_percentage", "memory_percentage", "requests", "response_time", "custom_metric" or "scheduled" if "range" is specified`),
+		},
+		"error if range is specified but no autoscaling fields are specified for a Backend Service": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(aws.String("1-10")),
+				},
+				workloadType: BackendServiceType,
+			},
+			wantedError: fmt.Errorf(`must specify at least one of "cpu_percentage", "memory_percentage", "requests", "response_time", "custom_metric" or "scheduled" if "range" is specified`),
+		},
+		"error if range is specified but no autoscaling fields are specified for a Worker Service": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(aws.String("1-10")),
+				},
+				workloadType: WorkerServiceType,
+			},
+			wantedError: fmt.Errorf(`must specify at least one of "cpu_percentage", "memory_percentage", "queue_delay", "custom_metric" or "scheduled" if "range" is specified`),
+		},
+		"valid when range and scheduled actions are specified": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(aws.String("1-10")),
+				},
+				Scheduled: []ScheduledScaling{
+					{Calendar: aws.String("business-hours")},
+					{
+						ScheduledAction: ScheduledAction{
+							Schedule: aws.String("cron(0 22 ? * * *)"),
+							Range:    (*IntRangeBand)(aws.String("1-2")),
+						},
+					},
+				},
+				workloadType: BackendServiceType,
+			},
+		},
+		"error if range is missing when scheduled actions are set": {
+			AdvancedCount: AdvancedCount{
+				Scheduled: []ScheduledScaling{
+					{Calendar: aws.String("business-hours")},
+				},
+				workloadType: BackendServiceType,
+			},
+			wantedError: fmt.Errorf(`"range" must be specified if "scheduled" is specified`),
+		},
+		"error if a scheduled action is invalid": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(aws.String("1-10")),
+				},
+				Scheduled: []ScheduledScaling{
+					{Calendar: aws.String("business-hours")},
+					{
+						ScheduledAction: ScheduledAction{
+							Schedule: aws.String("cron(0 22 * * *)"),
+							Range:    (*IntRangeBand)(aws.String("1-2")),
+						},
+					},
+				},
+				workloadType: WorkerServiceType,
+			},
+			wantedErrorMsgPrefix: `validate "scheduled[1]": validate "schedule": `,
+		},
+		"error if range is missing when autoscaling fields are set for Backend Service": {
+			AdvancedCount: AdvancedCount{
+				CPU:          &mockPerc,
+				workloadType: BackendServiceType,
+			},
+			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage, memory_percentage, requests, response_time or custom_metric" are specified`),
+		},
+		"error if range is missing when autoscaling fields are set for Worker Service": {
+			AdvancedCount: AdvancedCount{
+				CPU:          &mockPerc,
+				workloadType: WorkerServiceType,
+			},
+			wantedError: fmt.Errorf(`"range" must be specified if "cpu_percentage, memory_percentage, queue_delay or custom_metric" are specified`),
+		},
+		"wrap error from queue_delay on failure": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					RangeConfig: RangeConfig{
+						Min:      aws.Int(1),
+						Max:      aws.Int(10),
+						SpotFrom: aws.Int(6),
+					},
+				},
+				QueueScaling: QueueScaling{
+					AcceptableLatency: nil,
+					AvgProcessingTime: durationp(1 * time.Second),
+				},
+				workloadType: WorkerServiceType,
+			},
+			wantedErrorMsgPrefix: `validate "queue_delay": `,
+		},
+		"error if CPU perc is not valid": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				CPU:          &invalidPerc,
+				workloadType: LoadBalancedWebServiceType,
+			},
+			wantedErrorMsgPrefix: `validate "cpu_percentage": `,
+		},
+		"error if memory perc is not valid": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				Memory:       &invalidPerc,
+				workloadType: LoadBalancedWebServiceType,
+			},
+			wantedErrorMsgPrefix: `validate "memory_percentage": `,
+		},
+		"error if requests is specified for a workload without a load balancer": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				CPU:          &mockPerc,
+				Requests:     aws.Int(1000),
+				workloadType: WorkerServiceType,
+			},
+			wantedError: errors.New(`"requests" can only be specified for load balanced workloads`),
+		},
+		"valid if requests and response_time are specified for a Backend Service": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				Requests:     aws.Int(1000),
+				ResponseTime: durationp(2 * time.Second),
+				workloadType: BackendServiceType,
+			},
+		},
+		"error if requests is not positive": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				Requests:     aws.Int(0),
+				workloadType: LoadBalancedWebServiceType,
+			},
+			wantedError: errors.New(`"requests" value 0 must be a positive integer`),
+		},
+		"error if response_time is specified for a workload without a load balancer": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				CPU:          &mockPerc,
+				ResponseTime: durationp(2 * time.Second),
+				workloadType: WorkerServiceType,
+			},
+			wantedError: errors.New(`"response_time" can only be specified for load balanced workloads`),
+		},
+		"error if response_time is not positive": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				ResponseTime: durationp(-1 * time.Second),
+				workloadType: LoadBalancedWebServiceType,
+			},
+			wantedError: errors.New(`"response_time" value -1s must be a positive duration`),
+		},
+		"valid if response_time is specified for a Load Balanced Web Service": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				ResponseTime: durationp(500 * time.Millisecond),
+				workloadType: LoadBalancedWebServiceType,
+			},
+		},
+		"valid if requests is specified for a Load Balanced Web Service": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				Requests:     aws.Int(1000),
+				workloadType: LoadBalancedWebServiceType,
+			},
+		},
+		"error if custom_metric is specified with a built-in metric": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				CPU: &mockPerc,
+				CustomMetric: CustomMetric{
+					Namespace:  aws.String("AWS/SQS"),
+					MetricName: aws.String("ApproximateNumberOfMessagesVisible"),
+					Target:     aws.Float64(100),
+				},
+				workloadType: WorkerServiceType,
+			},
+			wantedError: errors.New(`must specify one, not both, of "custom_metric" and "cpu_percentage/memory_percentage/queue_delay"`),
+		},
+		"error if custom_metric is missing a target": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				CustomMetric: CustomMetric{
+					Namespace:  aws.String("AWS/SQS"),
+					MetricName: aws.String("ApproximateNumberOfMessagesVisible"),
+				},
+				workloadType: WorkerServiceType,
+			},
+			wantedError: errors.New(`validate "custom_metric": "target" must be specified`),
+		},
+		"error if custom_metric has an invalid statistic": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				CustomMetric: CustomMetric{
+					Namespace:  aws.String("AWS/SQS"),
+					MetricName: aws.String("ApproximateNumberOfMessagesVisible"),
+					Statistic:  aws.String("p99"),
+					Target:     aws.Float64(100),
+				},
+				workloadType: WorkerServiceType,
+			},
+			wantedError: errors.New(`validate "custom_metric": "statistic" value "p99" must be one of Average, Minimum, Maximum, SampleCount or Sum`),
+		},
+		"valid with only a custom_metric": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				CustomMetric: CustomMetric{
+					Namespace:  aws.String("AWS/SQS"),
+					MetricName: aws.String("ApproximateNumberOfMessagesVisible"),
+					Dimensions: map[string]string{
+						"QueueName": "orders",
+					},
+					Target: aws.Float64(100),
+				},
+				workloadType: BackendServiceType,
+			},
+		},
+		"error if cooldown is specified without range": {
+			AdvancedCount: AdvancedCount{
+				Cooldown: Cooldown{
+					ScaleInCooldown: durationp(time.Minute),
+				},
+				workloadType: BackendServiceType,
+			},
+			wantedError: errors.New(`"range" must be specified if "cooldown" is specified`),
+		},
+		"error if scale_out_cooldown is negative": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				CPU: &mockPerc,
+				Cooldown: Cooldown{
+					ScaleOutCooldown: durationp(-1 * time.Second),
+				},
+				workloadType: BackendServiceType,
+			},
+			wantedError: errors.New(`validate "cooldown": "scale_out_cooldown" value -1s cannot be negative`),
+		},
+		"valid with scale-in and scale-out cooldowns": {
+			AdvancedCount: AdvancedCount{
+				Range: Range{
+					Value: (*IntRangeBand)(stringP("1-2")),
+				},
+				CPU: &mockPerc,
+				Cooldown: Cooldown{
+					ScaleInCooldown:  durationp(5 * time.Minute),
+					ScaleOutCooldown: durationp(30 * time.Second),
+				},
+				workloadType: BackendServiceType,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.AdvancedCount.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+				return
+			}
+			if tc.wantedErrorMsgPrefix != "" {
+				require.Error(t, gotErr)
+				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestPercentage_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     Percentage
+		wanted error
+	}{
+		"should return an error if percentage is not valid": {
+			in:     Percentage(120),
+			wanted: errors.New("percentage value 120 must be an integer from 0 to 100"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQueueScaling_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     QueueScaling
+		wanted error
+	}{
+		"should return an error if only msg_processing_time is specified": {
+			in: QueueScaling{
+				AvgProcessingTime: durationp(1 * time.Second),
+			},
+			wanted: errors.New(`"acceptable_latency" must be specified if "msg_processing_time" is specified`),
+		},
+		"should return an error if only acceptable_latency is specified": {
+			in: QueueScaling{
+				AcceptableLatency: durationp(1 * time.Second),
+			},
+			wanted: errors.New(`"msg_processing_time" must be specified if "acceptable_latency" is specified`),
+		},
+		"should return an error if the msg_processing_time is 0": {
+			in: QueueScaling{
+				AcceptableLatency: durationp(1 * time.Second),
+				AvgProcessingTime: durationp(0 * time.Second),
+			},
+			wanted: errors.New(`"msg_processing_time" cannot be 0`),
+		},
+		"should return an error if the msg_processing_time is longer than acceptable_latency": {
+			in: QueueScaling{
+				AcceptableLatency: durationp(500 * time.Millisecond),
+				AvgProcessingTime: durationp(1 * time.Second),
+			},
+			wanted: errors.New(`"msg_processing_time" cannot be longer than "acceptable_latency"`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIntRangeBand_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		IntRangeBand IntRangeBand
+
+		wantedError error
+	}{
+		"error if range value is in invalid format": {
+			IntRangeBand: IntRangeBand(*aws.String("")),
+			wantedError:  fmt.Errorf("invalid range value . Should be in format of ${min}-${max}"),
+		},
+		"error if range min is greater than max": {
+			IntRangeBand: IntRangeBand(*aws.String("6-4")),
+			wantedError:  fmt.Errorf("min value 6 cannot be greater than max value 4"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.IntRangeBand.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestRangeConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		RangeConfig RangeConfig
+
+		wantedError error
+	}{
+		"error if max is not set": {
+			RangeConfig: RangeConfig{
+				Min: aws.Int(2),
+			},
+			wantedError: fmt.Errorf(`"min/max" must be specified`),
+		},
+		"error if range min is greater than max": {
+			RangeConfig: RangeConfig{
+				Min: aws.Int(2),
+				Max: aws.Int(1),
+			},
+			wantedError: fmt.Errorf("min value 2 cannot be greater than max value 1"),
+		},
+		"error if spot_from is below the range": {
+			RangeConfig: RangeConfig{
+				Min:      aws.Int(2),
+				Max:      aws.Int(10),
+				SpotFrom: aws.Int(1),
+			},
+			wantedError: fmt.Errorf(`"spot_from" value 1 must be within the range 2-10`),
+		},
+		"error if spot_from is above the range": {
+			RangeConfig: RangeConfig{
+				Min:      aws.Int(1),
+				Max:      aws.Int(10),
+				SpotFrom: aws.Int(11),
+			},
+			wantedError: fmt.Errorf(`"spot_from" value 11 must be within the range 1-10`),
+		},
+		"valid with spot_from at the bounds of the range": {
+			RangeConfig: RangeConfig{
+				Min:      aws.Int(1),
+				Max:      aws.Int(10),
+				SpotFrom: aws.Int(10),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.RangeConfig.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestStorage_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		Storage Storage
+
+		wantedErrorMsgPrefix string
+		wantedError          error
+	}{
+		"error if ephemeral is invalid": {
+			Storage: Storage{
+				Ephemeral: aws.Int(20),
+			},
+			wantedError: fmt.Errorf(`validate "ephemeral": ephemeral storage must be between 21 GiB and 200 GiB`),
+		},
+		"error if fail to validate volumes": {
+			Storage: Storage{
+				Volumes: map[string]*Volume{
+					"foo": {
+						EFS: EFSConfigOrBool{
+							Enabled: aws.Bool(true),
+						},
+					},
+				},
+			},
+			wantedErrorMsgPrefix: `validate "volumes[foo]": `,
+		},
+		"error if storage has more than one managed volume": {
+			Storage: Storage{
+				Volumes: map[string]*Volume{
+					"foo": {
+						EFS: EFSConfigOrBool{
+							Enabled: aws.Bool(true),
+						},
+						MountPointOpts: MountPointOpts{
+							ContainerPath: aws.String("mockPath"),
+						},
+					},
+					"bar": {
+						EFS: EFSConfigOrBool{
+							Enabled: aws.Bool(true),
+						},
+						MountPointOpts: MountPointOpts{
+							ContainerPath: aws.String("mockPath"),
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf("cannot specify more than one managed volume per service"),
+		},
+		"error if two volumes are mounted at the same path": {
+			Storage: Storage{
+				Ephemeral: aws.Int(100),
+				Volumes: map[string]*Volume{
+					"cache": {
+						MountPointOpts: MountPointOpts{
+							ContainerPath: aws.String("/var/data/"),
+						},
+					},
+					"data": {
+						EFS: EFSConfigOrBool{
+							Enabled: aws.Bool(true),
+						},
+						MountPointOpts: MountPointOpts{
+							ContainerPath: aws.String("/var/data"),
+						},
+					},
+				},
+			},
+			wantedError: fmt.Errorf("volumes cache and data cannot both be mounted at path /var/data"),
+		},
+		"valid": {
+			Storage: Storage{
+				Volumes: map[string]*Volume{
+					"foo": {
+						EFS: EFSConfigOrBool{
+							Enabled: aws.Bool(true),
+						},
+						MountPointOpts: MountPointOpts{
+							ContainerPath: aws.String("mockPath"),
+						},
+					},
+					"bar": {
+						EFS: EFSConfigOrBool{
+							Enabled: aws.Bool(false),
+						},
+						MountPointOpts: MountPointOpts{
+							ContainerPath: aws.String("mockPath/bar"),
+						},
+					},
+					"foobar": {
+						EFS: EFSConfigOrBool{
+							Advanced: EFSVolumeConfiguration{
+								FileSystemID: aws.String("fs-1234567"),
+							},
+						},
+						MountPointOpts: MountPointOpts{
+							ContainerPath: aws.String("mockPath/foobar"),
+						},
+					},
+				},
+			},
+		},
+		"valid with a managed volume alongside the maximum ephemeral storage": {
+			Storage: Storage{
+				Ephemeral: aws.Int(200),
+				Volumes: map[string]*Volume{
+					"data": {
+						EFS: EFSConfigOrBool{
+							Enabled: aws.Bool(true),
+						},
+						MountPointOpts: MountPointOpts{
+							ContainerPath: aws.String("/var/data"),
+						},
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.Storage.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+				return
+			}
+			if tc.wantedErrorMsgPrefix != "" {
+				require.Error(t, gotErr)
+				require.Contains(t, gotErr.Error(), tc.wantedErrorMsgPrefix)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestVolume_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		Volume Volume
+
+		wantedErrorPrefix string
+	}{
+		"error if fail to validate efs": {
+			Volume: Volume{
+				EFS: EFSConfigOrBool{
+					Advanced: EFSVolumeConfiguration{
+						UID:           aws.Uint32(123),
+						RootDirectory: aws.String("mockDir"),
+					},
+				},
+			},
+			wantedErrorPrefix: `validate "efs": `,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.Volume.Validate()
+
+			if tc.wantedErrorPrefix != "" {
+				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestEFSVolumeConfiguration_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		EFSVolumeConfiguration EFSVolumeConfiguration
+
+		wantedError error
+	}{
+		"error if uid/gid are specified with id/root_dir/auth": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				UID:        aws.Uint32(123),
+				AuthConfig: AuthorizationConfig{IAM: aws.Bool(true)},
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "uid/gid" and "id/root_dir/auth"`),
+		},
+		"error if managed is specified with id/root_dir/auth": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				Managed:      aws.Bool(true),
+				FileSystemID: aws.String("fs-12345"),
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "managed" and "id/root_dir/auth"`),
+		},
+		"error if managed is false with uid/gid": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				Managed: aws.Bool(false),
+				UID:     aws.Uint32(123),
+				GID:     aws.Uint32(123),
+			},
+			wantedError: fmt.Errorf(`"managed" must be true if "uid/gid" are specified`),
+		},
+		"error if managed is false without an id": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				Managed: aws.Bool(false),
+			},
+			wantedError: fmt.Errorf(`"id" must be specified`),
+		},
+		"valid with managed and uid/gid": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				Managed: aws.Bool(true),
+				UID:     aws.Uint32(123),
+				GID:     aws.Uint32(123),
+			},
+		},
+		"error if uid is set but gid is not": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				UID: aws.Uint32(123),
+			},
+			wantedError: fmt.Errorf(`"gid" must be specified if "uid" is specified`),
+		},
+		"error if gid is set but uid is not": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				GID: aws.Uint32(123),
+			},
+			wantedError: fmt.Errorf(`"uid" must be specified if "gid" is specified`),
+		},
+		"error if uid is 0": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				UID: aws.Uint32(0),
+				GID: aws.Uint32(0),
+			},
+			wantedError: fmt.Errorf(`"uid" must not be 0`),
+		},
+		"error if root_dir isn't the root with an access point": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				AuthConfig: AuthorizationConfig{
+					AccessPointID: aws.String("mockID"),
+				},
+				RootDirectory: aws.String("mockDir"),
+			},
+			wantedError: fmt.Errorf(`"root_dir" must be either empty or "/" when "auth.access_point_id" is specified, got "mockDir"`),
+		},
+		"error if iam is disabled with an access point": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				AuthConfig: AuthorizationConfig{
+					AccessPointID: aws.String("mockID"),
+					IAM:           aws.Bool(false),
+				},
+			},
+			wantedError: fmt.Errorf(`"auth.iam" must be true when "auth.access_point_id" is specified`),
+		},
+		"valid with an access point and the root directory": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				FileSystemID: aws.String("fs-12345"),
+				AuthConfig: AuthorizationConfig{
+					AccessPointID: aws.String("fsap-12345"),
+					IAM:           aws.Bool(true),
+				},
+				RootDirectory: aws.String("/"),
+			},
+		},
+		"error if uid/gid are specified with an access point": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				UID: aws.Uint32(123),
+				GID: aws.Uint32(123),
+				AuthConfig: AuthorizationConfig{
+					AccessPointID: aws.String("fsap-12345"),
+				},
+			},
+			wantedError: fmt.Errorf(`must specify one, not both, of "uid/gid" and "id/root_dir/auth"`),
+		},
+		"error if root_dir is invalid": {
+			EFSVolumeConfiguration: EFSVolumeConfiguration{
+				RootDirectory: aws.String("!!!!"),
+			},
+			wantedError: fmt.Errorf(`validate "root_dir": path can only contain the characters a-zA-Z0-9.-_/`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.EFSVolumeConfiguration.Validate()
+
+			if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestSidecarConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		config SidecarConfig
+
+		wantedErrorPrefix string
+	}{
+		"error if fail to validate mount_points": {
+			config: SidecarConfig{
+				MountPoints: []SidecarMountPoint{
+					{},
+				},
+			},
+			wantedErrorPrefix: `validate "mount_points[0]": `,
+		},
+		"error if fail to validate depends_on": {
+			config: SidecarConfig{
+				DependsOn: DependsOn{
+					"foo": "bar",
+				},
+			},
+			wantedErrorPrefix: `validate "depends_on": `,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.config.Validate()
+
+			if tc.wantedErrorPrefix != "" {
+				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestSidecarMountPoint_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     SidecarMountPoint
+		wanted error
+	}{
+		"should return an error if source_volume is not set": {
+			in:     SidecarMountPoint{},
+			wanted: errors.New(`"source_volume" must be specified`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMountPointOpts_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     MountPointOpts
+		wanted error
+	}{
+		"should return an error if path is not set": {
+			in:     MountPointOpts{},
+			wanted: errors.New(`"path" must be specified`),
+		},
+		"should return an error if path is invalid": {
+			in: MountPointOpts{
+				ContainerPath: aws.String("!!!!!!"),
+			},
+			wanted: errors.New(`validate "path": path can only contain the characters a-zA-Z0-9.-_/`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNetworkConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		config NetworkConfig
+
+		wantedErrorPrefix string
+	}{
+		"error if fail to validate vpc": {
+			config: NetworkConfig{
+				VPC: vpcConfig{
+					Placement: (*Placement)(aws.String("")),
+				},
+			},
+			wantedErrorPrefix: `validate "vpc": `,
+		},
+		"error if fail to validate service connect": {
+			config: NetworkConfig{
+				Connect: ServiceConnect{
+					Ports: []ServiceConnectPort{
+						{
+							Port: aws.Uint16(9090),
+						},
+					},
+				},
+			},
+			wantedErrorPrefix: `validate "connect": `,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.config.Validate()
+
+			if tc.wantedErrorPrefix != "" {
+				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestServiceConnect_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     ServiceConnect
+		wanted error
+	}{
+		"error if a port has no name": {
+			in: ServiceConnect{
+				Ports: []ServiceConnectPort{
+					{
+						Port: aws.Uint16(9090),
+					},
+				},
+			},
+			wanted: errors.New(`validate "ports[0]": "name" must be specified`),
+		},
+		"error if a port has no port": {
+			in: ServiceConnect{
+				Ports: []ServiceConnectPort{
+					{
+						Name: aws.String("api-admin"),
+					},
+				},
+			},
+			wanted: errors.New(`validate "ports[0]": "port" must be specified`),
+		},
+		"error if two ports have the same name": {
+			in: ServiceConnect{
+				Ports: []ServiceConnectPort{
+					{
+						Name: aws.String("api-admin"),
+						Port: aws.Uint16(9090),
+					},
+					{
+						Name: aws.String("api-admin"),
+						Port: aws.Uint16(9091),
+					},
+				},
+			},
+			wanted: errors.New(`validate "ports[1]": name "api-admin" is used by more than one port`),
+		},
+		"success": {
+			in: ServiceConnect{
+				Alias: aws.String("api"),
+				Ports: []ServiceConnectPort{
+					{
+						Name: aws.String("api-admin"),
+						Port: aws.Uint16(9090),
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRequestDrivenWebServiceNetworkConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		config RequestDrivenWebServiceNetworkConfig
+
+		wantedErrorPrefix string
+	}{
+		"error if fail to validate vpc": {
+			config: RequestDrivenWebServiceNetworkConfig{
+				VPC: rdwsVpcConfig{
+					Placement: (*RequestDrivenWebServicePlacement)(aws.String("")),
+				},
+			},
+			wantedErrorPrefix: `validate "vpc": `,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.config.Validate()
+
+			if tc.wantedErrorPrefix != "" {
+				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestRdwsVpcConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		config rdwsVpcConfig
+
+		wantedErrorPrefix string
+	}{
+		"error if fail to validate placement": {
+			config: rdwsVpcConfig{
+				Placement: (*RequestDrivenWebServicePlacement)(aws.String("")),
+			},
+			wantedErrorPrefix: `validate "placement": `,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.config.Validate()
+
+			if tc.wantedErrorPrefix != "" {
+				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestVpcConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		config vpcConfig
+
+		wantedErrorPrefix string
+	}{
+		"error if fail to validate placement": {
+			config: vpcConfig{
+				Placement: (*Placement)(aws.String("")),
+			},
+			wantedErrorPrefix: `validate "placement": `,
+		},
+		"error if both placement and subnets are specified": {
+			config: vpcConfig{
+				Placement: &PrivateSubnetPlacement,
+				Subnets: SubnetListOrArgs{
+					IDs: []string{"subnet-0123abcd"},
+				},
+			},
+			wantedErrorPrefix: `must specify one, not both, of "placement" and "subnets"`,
+		},
+		"error if fail to validate subnets": {
+			config: vpcConfig{
+				Subnets: SubnetListOrArgs{
+					IDs: []string{"subnet-xyz"},
+				},
+			},
+			wantedErrorPrefix: `validate "subnets": `,
+		},
+		"success with subnets": {
+			config: vpcConfig{
+				Subnets: SubnetListOrArgs{
+					IDs: []string{"subnet-0123abcd"},
+				},
+			},
+		},
+		"error if the default security group is denied without explicit security groups": {
+			config: vpcConfig{
+				DenyDefaultSecurityGroup: aws.Bool(true),
+			},
+			wantedErrorPrefix: `"security_groups" must be specified if "deny_default_security_group" is true`,
+		},
+		"error if fail to validate security groups": {
+			config: vpcConfig{
+				SecurityGroups: []SecurityGroup{{ID: aws.String("group")}},
+			},
+			wantedErrorPrefix: `validate "security_groups[0]": `,
+		},
+		"success with the default security group denied": {
+			config: vpcConfig{
+				SecurityGroups:           []SecurityGroup{{ID: aws.String("sg-0123abcd")}},
+				DenyDefaultSecurityGroup: aws.Bool(true),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.config.Validate()
+
+			if tc.wantedErrorPrefix != "" {
+				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestSubnetListOrArgs_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     SubnetListOrArgs
+		wanted error
+	}{
+		"error if a subnet ID is malformed": {
+			in: SubnetListOrArgs{
+				IDs: []string{"subnet-0123abcd", "sbn-0123abcd"},
+			},
+			wanted: errors.New(`subnet ID "sbn-0123abcd" must be of the form subnet-<8 or 17 hexadecimal characters>`),
+		},
+		"error if a tag key is empty": {
+			in: SubnetListOrArgs{
+				SubnetArgs: SubnetArgs{
+					FromTags: map[string]string{
+						"": "private",
+					},
+				},
+			},
+			wanted: errors.New(`tag key in "from_tags" cannot be empty`),
+		},
+		"success with short and long subnet IDs": {
+			in: SubnetListOrArgs{
+				IDs: []string{"subnet-0123abcd", "subnet-0123456789abcdef0"},
+			},
+		},
+		"success with tags": {
+			in: SubnetListOrArgs{
+				SubnetArgs: SubnetArgs{
+					FromTags: map[string]string{
+						"tier": "private",
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSecurityGroup_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     SecurityGroup
+		wanted error
+	}{
+		"error if a security group ID is malformed": {
+			in: SecurityGroup{
+				ID: aws.String("group-0123abcd"),
+			},
+			wanted: errors.New(`security group ID "group-0123abcd" must be of the form sg-<8 or 17 hexadecimal characters>`),
+		},
+		"error if the export name is empty": {
+			in: SecurityGroup{
+				FromCFN: aws.String(""),
+			},
+			wanted: errors.New(`"from_cfn" cannot be an empty string`),
+		},
+		"success with short and long security group IDs": {
+			in: SecurityGroup{
+				ID: aws.String("sg-0123456789abcdef0"),
+			},
+		},
+		"success with an export name": {
+			in: SecurityGroup{
+				FromCFN: aws.String("shared-db-SecurityGroupID"),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPlacement_Validate(t *testing.T) {
+	mockEmptyPlacement := Placement("")
+	mockInvalidPlacement := Placement("external")
+	testCases := map[string]struct {
+		in     *Placement
+		wanted error
+	}{
+		"should return an error if placement is empty": {
+			in:     &mockEmptyPlacement,
+			wanted: errors.New(`"placement" cannot be empty`),
+		},
+		"should return an error if placement is invalid": {
+			in:     &mockInvalidPlacement,
+			wanted: errors.New(`"placement" external must be one of public, private`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRequestDrivenWebServicePlacement_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     *RequestDrivenWebServicePlacement
+		wanted error
+	}{
+		"should return an error if placement is public": {
+			in:     (*RequestDrivenWebServicePlacement)(aws.String("public")),
+			wanted: errors.New(`placement "public" is not supported for Request-Driven Web Service`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAppRunnerInstanceConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		config            AppRunnerInstanceConfig
+		wantedErrorPrefix string
+		wantedError       error
+	}{
+		"error if fail to validate platforms": {
+			config: AppRunnerInstanceConfig{
+				Platform: PlatformArgsOrString{
+					PlatformString: (*PlatformString)(aws.String("")),
 				},
 			},
 			wantedErrorPrefix: `validate "platform": `,
 		},
-		"error if windows os in PlatformString": {
-			config: AppRunnerInstanceConfig{
-				Platform: PlatformArgsOrString{
-					PlatformString: (*PlatformString)(aws.String("windows/amd64")),
+		"error if windows os in PlatformString": {
+			config: AppRunnerInstanceConfig{
+				Platform: PlatformArgsOrString{
+					PlatformString: (*PlatformString)(aws.String("windows/amd64")),
+				},
+			},
+			wantedError: fmt.Errorf("Windows is not supported for App Runner services"),
+		},
+		"error if windows os in PlatformArgs": {
+			config: AppRunnerInstanceConfig{
+				CPU:    nil,
+				Memory: nil,
+				Platform: PlatformArgsOrString{
+					PlatformArgs: PlatformArgs{
+						OSFamily: aws.String("windows"),
+						Arch:     aws.String("amd64"),
+					},
+				},
+			},
+			wantedError: fmt.Errorf("Windows is not supported for App Runner services"),
+		},
+		"error if invalid arch in PlatformString": {
+			config: AppRunnerInstanceConfig{
+				Platform: PlatformArgsOrString{
+					PlatformArgs: PlatformArgs{
+						OSFamily: aws.String("linux"),
+						Arch:     aws.String("leg64"),
+					},
+				},
+			},
+			wantedError: fmt.Errorf("validate \"platform\": platform pair ('linux', 'leg64') is invalid: fields ('osfamily', 'architecture') must be one of ('linux', 'x86_64'), ('linux', 'amd64'), ('linux', 'arm'), ('linux', 'arm64'), ('windows', 'x86_64'), ('windows', 'amd64'), ('windows_server_2019_core', 'x86_64'), ('windows_server_2019_core', 'amd64'), ('windows_server_2019_full', 'x86_64'), ('windows_server_2019_full', 'amd64'), ('windows_server_2022_core', 'x86_64'), ('windows_server_2022_core', 'amd64'), ('windows_server_2022_full', 'x86_64'), ('windows_server_2022_full', 'amd64')"),
+		},
+		"error if App Runner + ARM": {
+			config: AppRunnerInstanceConfig{
+				Platform: PlatformArgsOrString{
+					PlatformString: (*PlatformString)(aws.String("linux/arm64")),
+				},
+			},
+			wantedError: fmt.Errorf("App Runner services can only build on amd64 and x86_64 architectures"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.config.Validate()
+
+			if tc.wantedErrorPrefix != "" {
+				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			} else if tc.wantedError != nil {
+				require.EqualError(t, gotErr, tc.wantedError.Error())
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestJobTriggerConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     *JobTriggerConfig
+		wanted error
+	}{
+		"should return an error if schedule is empty": {
+			in:     &JobTriggerConfig{},
+			wanted: errors.New(`"schedule" must be specified`),
+		},
+		"should accept a rate expression": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("rate(5 minutes)"),
+			},
+		},
+		"should accept a rate expression of a single unit": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("rate(1 day)"),
+			},
+		},
+		"should return an error if the unit of a rate expression doesn't match its value": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("rate(1 hours)"),
+			},
+			wanted: errors.New(`validate "schedule": unit of rate expression "rate(1 hours)" must be singular for a value of 1 and plural otherwise`),
+		},
+		"should return an error if a rate expression is malformed": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("rate(5 weeks)"),
+			},
+			wanted: errors.New(`validate "schedule": rate expression "rate(5 weeks)" must be of the form "rate(<value> <minutes, hours, or days>)"`),
+		},
+		"should accept a cron expression of CloudWatch Events": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("cron(0 9 ? * MON-FRI *)"),
+			},
+		},
+		"should return an error if a cron expression of CloudWatch Events has 5 fields": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("cron(0 9 * * 1)"),
+			},
+			wanted: errors.New(`validate "schedule": cron expression "cron(0 9 * * 1)" must have 6 fields "<minutes> <hours> <day-of-month> <month> <day-of-week> <year>", found 5`),
+		},
+		"should return an error if a cron expression of CloudWatch Events has no ? wildcard": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("cron(0 9 * * 1 *)"),
+			},
+			wanted: errors.New(`validate "schedule": cron expression "cron(0 9 * * 1 *)" must use "?" in either the day-of-month or the day-of-week field`),
+		},
+		"should accept a fixed interval": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("@every 1h30m"),
+			},
+		},
+		"should return an error if a fixed interval isn't in whole minutes": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("@every 90s"),
+			},
+			wanted: errors.New(`validate "schedule": interval of "@every 90s" must be a whole number of minutes or hours`),
+		},
+		"should accept @yearly": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("@yearly"),
+			},
+		},
+		"should accept @annually": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("@annually"),
+			},
+		},
+		"should accept @monthly": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("@monthly"),
+			},
+		},
+		"should accept @weekly": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("@weekly"),
+			},
+		},
+		"should accept @daily": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("@daily"),
+			},
+		},
+		"should accept @midnight": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("@midnight"),
+			},
+		},
+		"should accept @hourly": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("@hourly"),
+			},
+		},
+		"should return an error for an unknown predefined schedule": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("@fortnightly"),
+			},
+			wanted: errors.New(`validate "schedule": predefined schedule "@fortnightly" must be one of @yearly, @annually, @monthly, @weekly, @daily, @midnight or @hourly`),
+		},
+		"should accept a standard cron expression": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("0 9 * * 1-5"),
+			},
+		},
+		"should return an error if a standard cron expression has 6 fields": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("0 9 * * 1-5 *"),
+			},
+			wanted: errors.New(`validate "schedule": cron expression "0 9 * * 1-5 *" must have 5 fields "<minutes> <hours> <day-of-month> <month> <day-of-week>", found 6: wrap it as "cron(0 9 * * 1-5 *)" to use the 6-field syntax of CloudWatch Events`),
+		},
+		"should return an error if a standard cron expression is malformed": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("0 25 * * *"),
+			},
+			wanted: errors.New(`validate "schedule": parse cron expression "0 25 * * *": end of range (25) above maximum (23): 25`),
+		},
+		"should return an error if both the day-of-month and the day-of-week are specified": {
+			in: &JobTriggerConfig{
+				Schedule: aws.String("0 9 1 * 1"),
+			},
+			wanted: errors.New(`validate "schedule": cron expression "0 9 1 * 1" cannot specify both the day-of-month and the day-of-week`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAppRunnerAutoScalingConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     AppRunnerAutoScalingConfig
+		wanted error
+	}{
+		"should accept an empty configuration": {},
+		"should accept a full configuration": {
+			in: AppRunnerAutoScalingConfig{
+				MinSize:        aws.Int(1),
+				MaxSize:        aws.Int(10),
+				MaxConcurrency: aws.Int(100),
+			},
+		},
+		"should accept equal min_size and max_size": {
+			in: AppRunnerAutoScalingConfig{
+				MinSize: aws.Int(3),
+				MaxSize: aws.Int(3),
+			},
+		},
+		"should return an error if min_size is less than 1": {
+			in: AppRunnerAutoScalingConfig{
+				MinSize: aws.Int(0),
+			},
+			wanted: errors.New(`"min_size" must be at least 1`),
+		},
+		"should return an error if max_size is less than 1": {
+			in: AppRunnerAutoScalingConfig{
+				MaxSize: aws.Int(0),
+			},
+			wanted: errors.New(`"max_size" must be at least 1`),
+		},
+		"should return an error if min_size is greater than max_size": {
+			in: AppRunnerAutoScalingConfig{
+				MinSize: aws.Int(5),
+				MaxSize: aws.Int(2),
+			},
+			wanted: errors.New(`"min_size" 5 cannot be greater than "max_size" 2`),
+		},
+		"should return an error if max_concurrency is less than 1": {
+			in: AppRunnerAutoScalingConfig{
+				MaxConcurrency: aws.Int(0),
+			},
+			wanted: errors.New(`"max_concurrency" 0 must be between 1 and 200`),
+		},
+		"should return an error if max_concurrency is greater than 200": {
+			in: AppRunnerAutoScalingConfig{
+				MaxConcurrency: aws.Int(201),
+			},
+			wanted: errors.New(`"max_concurrency" 201 must be between 1 and 200`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestJobFailureHandlerConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     JobFailureHandlerConfig
+		wanted error
+	}{
+		"should return nil if timeout and retries are not set": {
+			in: JobFailureHandlerConfig{},
+		},
+		"should return an error if timeout isn't a duration": {
+			in: JobFailureHandlerConfig{
+				Timeout: aws.String("5 hours"),
+			},
+			wanted: errors.New(`parse "timeout": time: unknown unit " hours" in duration "5 hours"`),
+		},
+		"should return an error if timeout is longer than a day": {
+			in: JobFailureHandlerConfig{
+				Timeout: aws.String("25h"),
+			},
+			wanted: errors.New(`"timeout" 25h0m0s must be between 1s and 24h0m0s`),
+		},
+		"should return an error if timeout is shorter than a second": {
+			in: JobFailureHandlerConfig{
+				Timeout: aws.String("500ms"),
+			},
+			wanted: errors.New(`"timeout" 500ms must be between 1s and 24h0m0s`),
+		},
+		"should return an error if timeout has fractional seconds": {
+			in: JobFailureHandlerConfig{
+				Timeout: aws.String("1.5s"),
+			},
+			wanted: errors.New(`"timeout" 1.5s must be a whole number of seconds`),
+		},
+		"should return an error if retries is negative": {
+			in: JobFailureHandlerConfig{
+				Retries: aws.Int(-1),
+			},
+			wanted: errors.New(`"retries" -1 must be between 0 and 10`),
+		},
+		"should return an error if retries is more than 10": {
+			in: JobFailureHandlerConfig{
+				Retries: aws.Int(11),
+			},
+			wanted: errors.New(`"retries" 11 must be between 0 and 10`),
+		},
+		"should return nil with the largest timeout and retries": {
+			in: JobFailureHandlerConfig{
+				Timeout: aws.String("24h"),
+				Retries: aws.Int(10),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPublishConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		config PublishConfig
+
+		wantedErrorPrefix string
+	}{
+		"error if fail to validate topics": {
+			config: PublishConfig{
+				Topics: []Topic{
+					{},
+				},
+			},
+			wantedErrorPrefix: `validate "topics[0]": `,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.config.Validate()
+
+			if tc.wantedErrorPrefix != "" {
+				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestTopic_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     Topic
+		wanted error
+	}{
+		"should return an error if name is empty": {
+			in:     Topic{},
+			wanted: errors.New(`"name" must be specified`),
+		},
+		"should return an error if name is not valid": {
+			in: Topic{
+				Name: aws.String("!@#"),
+			},
+			wanted: errors.New(`"name" can only contain letters, numbers, underscores, and hypthens`),
+		},
+		"should return an error if deduplication_scope is not valid": {
+			in: Topic{
+				Name: aws.String("orders"),
+				FIFO: FIFOTopicAdvanceConfigOrBool{
+					Advanced: FIFOTopicAdvanceConfig{
+						DeduplicationScope: aws.String("queue"),
+					},
+				},
+			},
+			wanted: errors.New(`validate "fifo": "deduplication_scope" value "queue" must be one of messageGroup or topic`),
+		},
+		"should return an error if throughput_limit is not valid": {
+			in: Topic{
+				Name: aws.String("orders"),
+				FIFO: FIFOTopicAdvanceConfigOrBool{
+					Advanced: FIFOTopicAdvanceConfig{
+						ThroughputLimit: aws.String("perQueue"),
+					},
+				},
+			},
+			wanted: errors.New(`validate "fifo": "throughput_limit" value "perQueue" must be one of perMessageGroupId or perTopic`),
+		},
+		"should return an error if messageGroup scope is combined with a perTopic limit": {
+			in: Topic{
+				Name: aws.String("orders"),
+				FIFO: FIFOTopicAdvanceConfigOrBool{
+					Advanced: FIFOTopicAdvanceConfig{
+						DeduplicationScope: aws.String("messageGroup"),
+						ThroughputLimit:    aws.String("perTopic"),
+					},
+				},
+			},
+			wanted: errors.New(`validate "fifo": "throughput_limit" must be perMessageGroupId when "deduplication_scope" is messageGroup`),
+		},
+		"should return an error if topic scope is combined with a perMessageGroupId limit": {
+			in: Topic{
+				Name: aws.String("orders"),
+				FIFO: FIFOTopicAdvanceConfigOrBool{
+					Advanced: FIFOTopicAdvanceConfig{
+						DeduplicationScope: aws.String("topic"),
+						ThroughputLimit:    aws.String("perMessageGroupId"),
+					},
+				},
+			},
+			wanted: errors.New(`validate "fifo": "throughput_limit" must be perTopic when "deduplication_scope" is topic`),
+		},
+		"valid FIFO topic": {
+			in: Topic{
+				Name: aws.String("orders"),
+				FIFO: FIFOTopicAdvanceConfigOrBool{
+					Advanced: FIFOTopicAdvanceConfig{
+						ContentBasedDeduplication: aws.Bool(true),
+						DeduplicationScope:        aws.String("messageGroup"),
+						ThroughputLimit:           aws.String("perMessageGroupId"),
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSubscribeConfig_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		config SubscribeConfig
+
+		wantedErrorPrefix string
+	}{
+		"error if fail to validate topics": {
+			config: SubscribeConfig{
+				Topics: []TopicSubscription{
+					{
+						Name: aws.String("mockTopic"),
+					},
+				},
+			},
+			wantedErrorPrefix: `validate "topics[0]": `,
+		},
+		"error if a FIFO queue subscribes to an imported standard topic": {
+			config: SubscribeConfig{
+				Topics: []TopicSubscription{
+					{
+						ImportedARN: aws.String("arn:aws:sns:us-west-2:123456789012:orders"),
+					},
+				},
+				Queue: SQSQueue{
+					FIFO: aws.Bool(true),
+				},
+			},
+			wantedErrorPrefix: `validate "topics[0]": "arn" must be the ARN of a FIFO topic ending with ".fifo" to deliver to a FIFO queue`,
+		},
+		"error if a standard queue subscribes to an imported FIFO topic": {
+			config: SubscribeConfig{
+				Topics: []TopicSubscription{
+					{
+						ImportedARN: aws.String("arn:aws:sns:us-west-2:123456789012:orders.fifo"),
+						Queue: SQSQueueOrBool{
+							Enabled: aws.Bool(true),
+						},
+					},
+				},
+				Queue: SQSQueue{
+					FIFO: aws.Bool(true),
+				},
+			},
+			wantedErrorPrefix: `validate "topics[0]": "arn" is a FIFO topic and can only deliver to a FIFO queue, set "queue.fifo" to true`,
+		},
+		"imported FIFO topic delivers to a dedicated FIFO queue": {
+			config: SubscribeConfig{
+				Topics: []TopicSubscription{
+					{
+						ImportedARN: aws.String("arn:aws:sns:us-west-2:123456789012:orders.fifo"),
+						Queue: SQSQueueOrBool{
+							Advanced: SQSQueue{
+								FIFO: aws.Bool(true),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.config.Validate()
+
+			if tc.wantedErrorPrefix != "" {
+				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			} else {
+				require.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestTopicSubscription_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     TopicSubscription
+		wanted error
+	}{
+		"should return an error if topic name is empty": {
+			in:     TopicSubscription{},
+			wanted: errors.New(`"name" must be specified`),
+		},
+		"should return an error if service is empty": {
+			in: TopicSubscription{
+				Name: aws.String("mockTopic"),
+			},
+			wanted: errors.New(`"service" must be specified`),
+		},
+		"should return an error if service is in invalid format": {
+			in: TopicSubscription{
+				Name:    aws.String("mockTopic"),
+				Service: aws.String("!!!!!"),
+			},
+			wanted: errors.New("service name must start with a letter, contain only lower-case letters, numbers, and hyphens, and have no consecutive or trailing hyphen"),
+		},
+		"should return an error if both name and arn are specified": {
+			in: TopicSubscription{
+				Name:        aws.String("mockTopic"),
+				ImportedARN: aws.String("arn:aws:sns:us-west-2:123456789012:mockTopic"),
+			},
+			wanted: errors.New(`must specify one, not both, of "name" and "arn"`),
+		},
+		"should return an error if both service and arn are specified": {
+			in: TopicSubscription{
+				Service:     aws.String("mockSvc"),
+				ImportedARN: aws.String("arn:aws:sns:us-west-2:123456789012:mockTopic"),
+			},
+			wanted: errors.New(`must specify one, not both, of "service" and "arn"`),
+		},
+		"should return an error if arn is not a SNS topic ARN": {
+			in: TopicSubscription{
+				ImportedARN: aws.String("arn:aws:sqs:us-west-2:123456789012:mockQueue"),
+			},
+			wanted: errors.New(`validate "arn": "arn:aws:sqs:us-west-2:123456789012:mockQueue" must be a SNS topic ARN of the form arn:<partition>:sns:<region>:<account>:<topic name>`),
+		},
+		"should return an error if arn is missing the account": {
+			in: TopicSubscription{
+				ImportedARN: aws.String("arn:aws:sns:us-west-2::mockTopic"),
+			},
+			wanted: errors.New(`validate "arn": "arn:aws:sns:us-west-2::mockTopic" must be a SNS topic ARN of the form arn:<partition>:sns:<region>:<account>:<topic name>`),
+		},
+		"should not return an error with an imported topic": {
+			in: TopicSubscription{
+				ImportedARN: aws.String("arn:aws:sns:us-west-2:123456789012:mockTopic"),
+				Queue: SQSQueueOrBool{
+					Enabled: aws.Bool(true),
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSQSQueue_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     SQSQueue
+		wanted error
+	}{
+		"should not return an error if empty": {
+			in: SQSQueue{},
+		},
+		"should return an error if tries is zero": {
+			in: SQSQueue{
+				DeadLetter: DeadLetterQueue{
+					Tries: aws.Uint16(0),
+				},
+			},
+			wanted: errors.New(`validate "dead_letter": "tries" must be between 1 and 1000`),
+		},
+		"should return an error if tries is too large": {
+			in: SQSQueue{
+				DeadLetter: DeadLetterQueue{
+					Tries: aws.Uint16(1001),
+				},
+			},
+			wanted: errors.New(`validate "dead_letter": "tries" must be between 1 and 1000`),
+		},
+		"should not return an error with valid tries": {
+			in: SQSQueue{
+				DeadLetter: DeadLetterQueue{
+					Tries: aws.Uint16(1000),
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOverrideRule_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		in     OverrideRule
+		wanted error
+	}{
+		"should return an error if override rule is invalid": {
+			in: OverrideRule{
+				Path: "ContainerDefinitions[1].Name",
+			},
+			wanted: errors.New(`"ContainerDefinitions\[\d+\].Name" cannot be overridden with a custom value`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLoadBalancerTarget(t *testing.T) {
+	testCases := map[string]struct {
+		in     validateTargetContainerOpts
+		wanted error
+	}{
+		"should return an error if target container doesn't exist": {
+			in: validateTargetContainerOpts{
+				mainContainerName: "mockMainContainer",
+				targetContainer:   aws.String("foo"),
+			},
+			wanted: fmt.Errorf("target container foo doesn't exist"),
+		},
+		"should return an error if target container doesn't expose any port": {
+			in: validateTargetContainerOpts{
+				mainContainerName: "mockMainContainer",
+				targetContainer:   aws.String("foo"),
+				sidecarConfig: map[string]*SidecarConfig{
+					"foo": {},
+				},
+			},
+			wanted: fmt.Errorf("target container foo doesn't expose any port"),
+		},
+		"success with no target container set": {
+			in: validateTargetContainerOpts{
+				mainContainerName: "mockMainContainer",
+				targetContainer:   nil,
+				sidecarConfig: map[string]*SidecarConfig{
+					"foo": {},
+				},
+			},
+		},
+		"success": {
+			in: validateTargetContainerOpts{
+				mainContainerName: "mockMainContainer",
+				targetContainer:   aws.String("foo"),
+				sidecarConfig: map[string]*SidecarConfig{
+					"foo": {
+						Port: aws.String("80"),
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateTargetContainer(tc.in)
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateDenyDefaultSecurityGroup(t *testing.T) {
+	testCases := map[string]struct {
+		inVPC                   vpcConfig
+		inVolumes               map[string]*Volume
+		inBehindEnvLoadBalancer bool
+
+		wanted error
+	}{
+		"should return an error if the service is behind a load balancer of the environment": {
+			inVPC: vpcConfig{
+				DenyDefaultSecurityGroup: aws.Bool(true),
+			},
+			inBehindEnvLoadBalancer: true,
+			wanted:                  errors.New(`"deny_default_security_group" cannot be true for a service behind a load balancer of the environment, which only reaches tasks in the environment security group`),
+		},
+		"should return an error if a volume uses EFS": {
+			inVPC: vpcConfig{
+				DenyDefaultSecurityGroup: aws.Bool(true),
+			},
+			inVolumes: map[string]*Volume{
+				"scratch": {},
+				"data": {
+					EFS: EFSConfigOrBool{
+						Enabled: aws.Bool(true),
+					},
+				},
+			},
+			wanted: errors.New(`"deny_default_security_group" cannot be true if volume data uses EFS, which is mounted through the environment security group`),
+		},
+		"success if the environment security group is kept": {
+			inVolumes: map[string]*Volume{
+				"data": {
+					EFS: EFSConfigOrBool{
+						Enabled: aws.Bool(true),
+					},
+				},
+			},
+			inBehindEnvLoadBalancer: true,
+		},
+		"success without EFS volumes or a load balancer": {
+			inVPC: vpcConfig{
+				DenyDefaultSecurityGroup: aws.Bool(true),
+			},
+			inVolumes: map[string]*Volume{
+				"scratch": {},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateDenyDefaultSecurityGroup(tc.inVPC, tc.inVolumes, tc.inBehindEnvLoadBalancer)
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateContainerDeps(t *testing.T) {
+	testCases := map[string]struct {
+		in     validateDependenciesOpts
+		wanted error
+	}{
+		"should return an error if main container dependencies status is invalid": {
+			in: validateDependenciesOpts{
+				mainContainerName: "mockMainContainer",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"mockMainContainer": "complete",
+					},
+				},
+			},
+			wanted: fmt.Errorf("validate mockMainContainer container dependencies status: essential container mockMainContainer can only have status START or HEALTHY"),
+		},
+		"should return an error if sidecar container dependencies status is invalid": {
+			in: validateDependenciesOpts{
+				mainContainerName: "mockMainContainer",
+				sidecarConfig: map[string]*SidecarConfig{
+					"foo": {
+						DependsOn: DependsOn{
+							"mockMainContainer": "success",
+						},
+					},
 				},
 			},
-			wantedError: fmt.Errorf("Windows is not supported for App Runner services"),
+			wanted: fmt.Errorf("validate foo container dependencies status: essential container mockMainContainer can only have status START or HEALTHY"),
 		},
-		"error if windows os in PlatformArgs": {
-			config: AppRunnerInstanceConfig{
-				CPU:    nil,
-				Memory: nil,
-				Platform: PlatformArgsOrString{
-					PlatformArgs: PlatformArgs{
-						OSFamily: aws.String("windows"),
-						Arch:     aws.String("amd64"),
+		"should return an error if a main container dependency does not exist": {
+			in: validateDependenciesOpts{
+				mainContainerName: "mockMainContainer",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"foo": "healthy",
 					},
 				},
 			},
-			wantedError: fmt.Errorf("Windows is not supported for App Runner services"),
+			wanted: fmt.Errorf("container foo does not exist"),
 		},
-		"error if invalid arch in PlatformString": {
-			config: AppRunnerInstanceConfig{
-				Platform: PlatformArgsOrString{
-					PlatformArgs: PlatformArgs{
-						OSFamily: aws.String("linux"),
-						Arch:     aws.String("leg64"),
+		"should return an error if a firelens container does not exist": {
+			in: validateDependenciesOpts{
+				mainContainerName: "mockMainContainer",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"firelens_log_router": "start",
+					},
+				},
+			},
+			wanted: fmt.Errorf("container firelens_log_router does not exist"),
+		},
+		"should return an error if a sidecar container dependency does not exist": {
+			in: validateDependenciesOpts{
+				mainContainerName: "mockMainContainer",
+				sidecarConfig: map[string]*SidecarConfig{
+					"foo": {
+						DependsOn: DependsOn{
+							"bar": "healthy",
+						},
+					},
+				},
+			},
+			wanted: fmt.Errorf("container bar does not exist"),
+		},
+		"should return an error if container depends on itself": {
+			in: validateDependenciesOpts{
+				mainContainerName: "mockMainContainer",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"mockMainContainer": "healthy",
+					},
+				},
+			},
+			wanted: fmt.Errorf("container mockMainContainer cannot depend on itself"),
+		},
+		"should return an error if a sidecar depends on itself": {
+			in: validateDependenciesOpts{
+				mainContainerName: "mockMainContainer",
+				sidecarConfig: map[string]*SidecarConfig{
+					"foo": {
+						DependsOn: DependsOn{
+							"foo": "start",
+						},
+					},
+				},
+			},
+			wanted: fmt.Errorf("container foo cannot depend on itself"),
+		},
+		"should return an error if container dependencies graph is cyclic": {
+			in: validateDependenciesOpts{
+				mainContainerName: "alpha",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"beta": "healthy",
+					},
+				},
+				sidecarConfig: map[string]*SidecarConfig{
+					"beta": {
+						DependsOn: DependsOn{
+							"gamma": "healthy",
+						},
+					},
+					"gamma": {
+						DependsOn: DependsOn{
+							"alpha": "healthy",
+						},
+					},
+					"zeta": {
+						DependsOn: DependsOn{
+							"alpha": "healthy",
+						},
+					},
+				},
+			},
+			wanted: fmt.Errorf("circular container dependency chain includes the following containers: [alpha beta gamma]"),
+		},
+		"should return an error if the main container does not wait for the init container to complete": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"init": "start",
+					},
+				},
+				initContainer: InitContainer{
+					Image: Image{
+						Build: BuildArgsOrString{
+							BuildArgs: DockerBuildArgs{
+								Target: aws.String("migrate"),
+							},
+						},
+					},
+				},
+			},
+			wanted: fmt.Errorf("validate api container dependencies status: init container init can only have status COMPLETE or SUCCESS"),
+		},
+		"should return an error if the init container depends on the main container": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				initContainer: InitContainer{
+					Image: Image{
+						Location: aws.String("migrate/migrate:v4"),
+						DependsOn: DependsOn{
+							"api": "start",
+						},
+					},
+				},
+			},
+			wanted: fmt.Errorf("circular container dependency chain includes the following containers: [api init]"),
+		},
+		"should return an error if a sidecar has the name of the init container": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				sidecarConfig: map[string]*SidecarConfig{
+					"init": {},
+				},
+				initContainer: InitContainer{
+					Image: Image{
+						Location: aws.String("migrate/migrate:v4"),
+					},
+				},
+			},
+			wanted: fmt.Errorf("sidecar init has the same name as the init container"),
+		},
+		"should return an error if a sidecar has the name of the X-Ray daemon": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				sidecarConfig: map[string]*SidecarConfig{
+					"xray": {},
+				},
+				observability: Observability{
+					Tracing: aws.String("awsxray"),
+				},
+			},
+			wanted: fmt.Errorf(`sidecar xray has the same name as the X-Ray daemon injected by "observability.tracing"`),
+		},
+		"should return an error if the main container has the name of the X-Ray daemon": {
+			in: validateDependenciesOpts{
+				mainContainerName: "xray",
+				observability: Observability{
+					Tracing: aws.String("awsxray"),
+				},
+			},
+			wanted: fmt.Errorf("main container cannot be named xray, which is reserved for the X-Ray daemon"),
+		},
+		"should return an error if a sidecar has the name of the OpenTelemetry collector": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				sidecarConfig: map[string]*SidecarConfig{
+					"aws-otel-collector": {},
+				},
+				observability: Observability{
+					Tracing: aws.String("awsotel"),
+				},
+			},
+			wanted: fmt.Errorf(`sidecar aws-otel-collector has the same name as the OpenTelemetry collector injected by "observability.tracing"`),
+		},
+		"should allow a sidecar named xray if tracing is disabled": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				sidecarConfig: map[string]*SidecarConfig{
+					"xray": {},
+				},
+			},
+		},
+		"should return an error if a sidecar has the name of the main container": {
+			in: validateDependenciesOpts{
+				mainContainerName: "web",
+				sidecarConfig: map[string]*SidecarConfig{
+					"web": {},
+				},
+			},
+			wanted: fmt.Errorf("sidecar web has the same name as the main container"),
+		},
+		"should return an error if the main container has the name of the log router": {
+			in: validateDependenciesOpts{
+				mainContainerName: "firelens_log_router",
+				logging: Logging{
+					Image: aws.String("foobar"),
+				},
+			},
+			wanted: fmt.Errorf("main container cannot be named firelens_log_router, which is reserved for the log router"),
+		},
+		"should return an error if the main container waits for a sidecar without a health check to be healthy": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"envoy": "healthy",
+					},
+				},
+				sidecarConfig: map[string]*SidecarConfig{
+					"envoy": {},
+				},
+			},
+			wanted: fmt.Errorf(`validate api container dependencies status: container envoy must have a "healthcheck" to have status HEALTHY`),
+		},
+		"should return an error if a sidecar waits for the main container without a health check to be healthy": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				sidecarConfig: map[string]*SidecarConfig{
+					"envoy": {
+						DependsOn: DependsOn{
+							"api": "healthy",
+						},
+					},
+				},
+			},
+			wanted: fmt.Errorf(`validate envoy container dependencies status: container api must have a "healthcheck" to have status HEALTHY`),
+		},
+		"should return the first HEALTHY dependency without a health check in alphabetical order": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"envoy": "healthy",
+						"auth":  "healthy",
+					},
+				},
+				sidecarConfig: map[string]*SidecarConfig{
+					"envoy": {},
+					"auth":  {},
+					"logger": {
+						DependsOn: DependsOn{
+							"envoy": "healthy",
+						},
+					},
+				},
+			},
+			wanted: fmt.Errorf(`validate api container dependencies status: container auth must have a "healthcheck" to have status HEALTHY`),
+		},
+		"success with the main container waiting for a healthy proxy sidecar": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"envoy": "healthy",
+					},
+				},
+				sidecarConfig: map[string]*SidecarConfig{
+					"envoy": {
+						HealthCheck: ContainerHealthCheck{
+							Command: []string{"CMD-SHELL", "curl -f http://localhost:9901/ready || exit 1"},
+						},
+					},
+				},
+			},
+		},
+		"should return an error if the main container waits for a non-essential sidecar to be healthy": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"logshipper": "healthy",
+					},
+				},
+				sidecarConfig: map[string]*SidecarConfig{
+					"logshipper": {
+						Essential: aws.Bool(false),
+						HealthCheck: ContainerHealthCheck{
+							Command: []string{"CMD-SHELL", "pgrep vector || exit 1"},
+						},
+					},
+				},
+			},
+			wanted: fmt.Errorf(`validate api container dependencies status: non-essential container logshipper cannot have status HEALTHY`),
+		},
+		"success with the main container waiting for a non-essential sidecar to start": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"logshipper": "start",
+						"envoy":      "healthy",
+					},
+				},
+				sidecarConfig: map[string]*SidecarConfig{
+					"logshipper": {
+						Essential: aws.Bool(false),
+					},
+					"envoy": {
+						Essential: aws.Bool(true),
+						HealthCheck: ContainerHealthCheck{
+							Command: []string{"CMD-SHELL", "curl -f http://localhost:9901/ready || exit 1"},
+						},
+					},
+				},
+			},
+		},
+		"success with an init container": {
+			in: validateDependenciesOpts{
+				mainContainerName: "api",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"init": "success",
+					},
+				},
+				initContainer: InitContainer{
+					Image: Image{
+						Location: aws.String("migrate/migrate:v4"),
 					},
 				},
 			},
-			wantedError: fmt.Errorf("validate \"platform\": platform pair ('linux', 'leg64') is invalid: fields ('osfamily', 'architecture') must be one of ('linux', 'x86_64'), ('linux', 'amd64'), ('linux', 'arm'), ('linux', 'arm64'), ('windows', 'x86_64'), ('windows', 'amd64'), ('windows_server_2019_core', 'x86_64'), ('windows_server_2019_core', 'amd64'), ('windows_server_2019_full', 'x86_64'), ('windows_server_2019_full', 'amd64')"),
 		},
-		"error if App Runner + ARM": {
-			config: AppRunnerInstanceConfig{
-				Platform: PlatformArgsOrString{
-					PlatformString: (*PlatformString)(aws.String("linux/arm64")),
+		"success": {
+			in: validateDependenciesOpts{
+				mainContainerName: "alpha",
+				imageConfig: Image{
+					DependsOn: DependsOn{
+						"firelens_log_router": "start",
+						"beta":                "complete",
+					},
+				},
+				logging: Logging{
+					Image: aws.String("foobar"),
+				},
+				sidecarConfig: map[string]*SidecarConfig{
+					"beta": {
+						Essential: aws.Bool(false),
+						DependsOn: DependsOn{
+							"firelens_log_router": "start",
+						},
+					},
 				},
 			},
-			wantedError: fmt.Errorf("App Runner services can only build on amd64 and x86_64 architectures"),
-		},
-	}
-	for name, tc := range testCases {
-		t.Run(name, func(t *testing.T) {
-			gotErr := tc.config.Validate()
-
-			if tc.wantedErrorPrefix != "" {
-				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
-			} else if tc.wantedError != nil {
-				require.EqualError(t, gotErr, tc.wantedError.Error())
-			} else {
-				require.NoError(t, gotErr)
-			}
-		})
-	}
-}
-
-func TestJobTriggerConfig_Validate(t *testing.T) {
-	testCases := map[string]struct {
-		in     *JobTriggerConfig
-		wanted error
-	}{
-		"should return an error if schedule is empty": {
-			in:     &JobTriggerConfig{},
-			wanted: errors.New(`"schedule" must be specified`),
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := tc.in.Validate()
+			err := validateContainerDeps(tc.in)
 
 			if tc.wanted != nil {
 				require.EqualError(t, err, tc.wanted.Error())
@@ -1969,56 +6118,168 @@ func TestJobTriggerConfig_Validate(t *testing.T) {
 	}
 }
 
-func TestPublishConfig_Validate(t *testing.T) {
+func TestValidateContainerCount(t *testing.T) {
+	sidecars := func(n int) map[string]*SidecarConfig {
+		m := make(map[string]*SidecarConfig, n)
+		for i := 0; i < n; i++ {
+			m[fmt.Sprintf("sidecar%d", i)] = &SidecarConfig{
+				Image: aws.String("public.ecr.aws/nginx/nginx:1.21"),
+			}
+		}
+		return m
+	}
 	testCases := map[string]struct {
-		config PublishConfig
-
-		wantedErrorPrefix string
+		in          validateContainerCountOpts
+		wantedError error
 	}{
-		"error if fail to validate topics": {
-			config: PublishConfig{
-				Topics: []Topic{
-					{},
+		"error if sidecars and the log router exceed the limit": {
+			in: validateContainerCountOpts{
+				sidecarConfig: sidecars(9),
+				logging: Logging{
+					Destination: map[string]string{
+						"Name": "cloudwatch",
+					},
+				},
+			},
+			wantedError: errors.New("task has 11 containers, including sidecars and the log router, but ECS allows at most 10 containers per task"),
+		},
+		"valid at the limit with the log router": {
+			in: validateContainerCountOpts{
+				sidecarConfig: sidecars(8),
+				logging: Logging{
+					Destination: map[string]string{
+						"Name": "cloudwatch",
+					},
+				},
+			},
+		},
+		"valid at the limit without the log router": {
+			in: validateContainerCountOpts{
+				sidecarConfig: sidecars(9),
+			},
+		},
+		"error if sidecars and the X-Ray daemon exceed the limit": {
+			in: validateContainerCountOpts{
+				sidecarConfig: sidecars(9),
+				observability: Observability{
+					Tracing: aws.String("awsxray"),
+				},
+			},
+			wantedError: errors.New("task has 11 containers, including sidecars and the log router, but ECS allows at most 10 containers per task"),
+		},
+		"error if sidecars and the AppConfig agent exceed the limit": {
+			in: validateContainerCountOpts{
+				sidecarConfig: sidecars(9),
+				secrets: map[string]Secret{
+					"FEATURES": {
+						AppConfig: &AppConfigSecret{
+							Application: aws.String("my-app"),
+							Environment: aws.String("prod"),
+							Profile:     aws.String("features"),
+						},
+					},
+					"LIMITS": {
+						AppConfig: &AppConfigSecret{
+							Application: aws.String("my-app"),
+							Environment: aws.String("prod"),
+							Profile:     aws.String("limits"),
+						},
+					},
+				},
+			},
+			wantedError: errors.New("task has 11 containers, including sidecars and the log router, but ECS allows at most 10 containers per task"),
+		},
+		"valid at the limit with SSM secrets": {
+			in: validateContainerCountOpts{
+				sidecarConfig: sidecars(9),
+				secrets: map[string]Secret{
+					"DB_PASSWORD": {
+						From: aws.String("DB_PASSWORD"),
+					},
 				},
 			},
-			wantedErrorPrefix: `validate "topics[0]": `,
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.config.Validate()
+			err := validateContainerCount(tc.in)
 
-			if tc.wantedErrorPrefix != "" {
-				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
 			} else {
-				require.NoError(t, gotErr)
+				require.NoError(t, err)
 			}
 		})
 	}
 }
 
-func TestTopic_Validate(t *testing.T) {
+func TestValidateWindows(t *testing.T) {
 	testCases := map[string]struct {
-		in     Topic
-		wanted error
+		in          validateWindowsOpts
+		wantedError error
 	}{
-		"should return an error if name is empty": {
-			in:     Topic{},
-			wanted: errors.New(`"name" must be specified`),
+		"should return an error if efs specified": {
+			in: validateWindowsOpts{
+				execEnabled: true,
+			},
+			wantedError: fmt.Errorf(`'exec' is not supported when deploying a Windows container`),
 		},
-		"should return an error if name is not valid": {
-			in: Topic{
-				Name: aws.String("!@#"),
+		"error if efs specified": {
+			in: validateWindowsOpts{
+				efsVolumes: map[string]*Volume{
+					"someVolume": {
+						EFS: EFSConfigOrBool{
+							Enabled: aws.Bool(true),
+						},
+						MountPointOpts: MountPointOpts{
+							ContainerPath: aws.String("mockPath"),
+						},
+					},
+				},
 			},
-			wanted: errors.New(`"name" can only contain letters, numbers, underscores, and hypthens`),
+			wantedError: errors.New(`'EFS' is not supported when deploying a Windows container`),
+		},
+		"error if FireLens logging is enabled": {
+			in: validateWindowsOpts{
+				fireLensEnabled: true,
+			},
+			wantedError: errors.New(`'logging' is not supported when deploying a Windows container`),
+		},
+		"error if ulimits are specified": {
+			in: validateWindowsOpts{
+				ulimits: map[string]Ulimit{
+					"nofile": {Soft: aws.Int(1024), Hard: aws.Int(1024)},
+				},
+			},
+			wantedError: errors.New(`'ulimits' is not supported when deploying a Windows container`),
+		},
+		"error if a sidecar specifies ulimits": {
+			in: validateWindowsOpts{
+				sidecars: map[string]*SidecarConfig{
+					"nginx": {
+						ImageOverride: ImageOverride{
+							Ulimits: map[string]Ulimit{
+								"nofile": {Soft: aws.Int(1024), Hard: aws.Int(1024)},
+							},
+						},
+					},
+				},
+			},
+			wantedError: errors.New(`'ulimits' of sidecar "nginx" is not supported when deploying a Windows container`),
+		},
+		"should return nil if neither efs nor exec specified": {
+			in: validateWindowsOpts{
+				execEnabled: false,
+			},
+			wantedError: nil,
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := tc.in.Validate()
+			err := validateWindows(tc.in)
 
-			if tc.wanted != nil {
-				require.EqualError(t, err, tc.wanted.Error())
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
 			} else {
 				require.NoError(t, err)
 			}
@@ -2026,65 +6287,120 @@ func TestTopic_Validate(t *testing.T) {
 	}
 }
 
-func TestSubscribeConfig_Validate(t *testing.T) {
+func TestCapacityProviders_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		config SubscribeConfig
-
-		wantedErrorPrefix string
+		in          CapacityProviders
+		wantedError error
 	}{
-		"error if fail to validate topics": {
-			config: SubscribeConfig{
-				Topics: []TopicSubscription{
-					{
-						Name: aws.String("mockTopic"),
-					},
+		"error if a base is negative": {
+			in: CapacityProviders{
+				OnDemand: CapacityProviderStrategy{
+					Base: aws.Int(-1),
+				},
+			},
+			wantedError: errors.New(`validate "on_demand": "base" value -1 cannot be negative`),
+		},
+		"error if a weight is negative": {
+			in: CapacityProviders{
+				Spot: CapacityProviderStrategy{
+					Weight: aws.Int(-2),
+				},
+			},
+			wantedError: errors.New(`validate "spot": "weight" value -2 cannot be negative`),
+		},
+		"error if both capacity providers have a base": {
+			in: CapacityProviders{
+				OnDemand: CapacityProviderStrategy{
+					Base: aws.Int(1),
+				},
+				Spot: CapacityProviderStrategy{
+					Base:   aws.Int(1),
+					Weight: aws.Int(1),
+				},
+			},
+			wantedError: errors.New(`must specify one, not both, of "on_demand.base" and "spot.base"`),
+		},
+		"error if no capacity provider has a weight": {
+			in: CapacityProviders{
+				OnDemand: CapacityProviderStrategy{
+					Base: aws.Int(2),
+				},
+			},
+			wantedError: errors.New(`"weight" must be positive for at least one of "on_demand" or "spot"`),
+		},
+		"valid with weights only": {
+			in: CapacityProviders{
+				OnDemand: CapacityProviderStrategy{
+					Weight: aws.Int(1),
+				},
+				Spot: CapacityProviderStrategy{
+					Weight: aws.Int(3),
 				},
 			},
-			wantedErrorPrefix: `validate "topics[0]": `,
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotErr := tc.config.Validate()
+			err := tc.in.Validate()
 
-			if tc.wantedErrorPrefix != "" {
-				require.Contains(t, gotErr.Error(), tc.wantedErrorPrefix)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
 			} else {
-				require.NoError(t, gotErr)
+				require.NoError(t, err)
 			}
 		})
 	}
 }
 
-func TestTopicSubscription_Validate(t *testing.T) {
+func TestValidateARM(t *testing.T) {
 	testCases := map[string]struct {
-		in     TopicSubscription
-		wanted error
+		in          validateARMOpts
+		wantedError error
 	}{
-		"should return an error if topic name is empty": {
-			in:     TopicSubscription{},
-			wanted: errors.New(`"name" must be specified`),
+		"should return an error if Spot specified inline": {
+			in: validateARMOpts{
+				Spot: aws.Int(2),
+			},
+			wantedError: fmt.Errorf(`'Fargate Spot' is not supported when deploying on ARM architecture`),
 		},
-		"should return an error if service is empty": {
-			in: TopicSubscription{
-				Name: aws.String("mockTopic"),
+		"should return an error if Spot specified with spot_from": {
+			in: validateARMOpts{
+				SpotFrom: aws.Int(2),
 			},
-			wanted: errors.New(`"service" must be specified`),
+			wantedError: fmt.Errorf(`'Fargate Spot' is not supported when deploying on ARM architecture`),
 		},
-		"should return an error if service is in invalid format": {
-			in: TopicSubscription{
-				Name:    aws.String("mockTopic"),
-				Service: aws.String("!!!!!"),
+		"should return an error if Spot capacity provider specified": {
+			in: validateARMOpts{
+				CapacityProviders: CapacityProviders{
+					Spot: CapacityProviderStrategy{
+						Weight: aws.Int(1),
+					},
+				},
 			},
-			wanted: errors.New("service name must start with a letter, contain only lower-case letters, numbers, and hyphens, and have no consecutive or trailing hyphen"),
+			wantedError: fmt.Errorf(`'Fargate Spot' is not supported when deploying on ARM architecture`),
+		},
+		"should return nil if only the on-demand capacity provider is specified": {
+			in: validateARMOpts{
+				CapacityProviders: CapacityProviders{
+					OnDemand: CapacityProviderStrategy{
+						Weight: aws.Int(1),
+					},
+				},
+			},
+		},
+		"should return nil if Spot not specified": {
+			in: validateARMOpts{
+				Spot: nil,
+			},
+			wantedError: nil,
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := tc.in.Validate()
+			err := validateARM(tc.in)
 
-			if tc.wanted != nil {
-				require.EqualError(t, err, tc.wanted.Error())
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
 			} else {
 				require.NoError(t, err)
 			}
@@ -2092,24 +6408,53 @@ func TestTopicSubscription_Validate(t *testing.T) {
 	}
 }
 
-func TestOverrideRule_Validate(t *testing.T) {
+func TestValidateAZRebalancing(t *testing.T) {
 	testCases := map[string]struct {
-		in     OverrideRule
-		wanted error
+		in          Count
+		wantedError error
 	}{
-		"should return an error if override rule is invalid": {
-			in: OverrideRule{
-				Path: "ContainerDefinitions[1].Name",
+		"should return an error if a single task is desired": {
+			in: Count{
+				Value: aws.Int(1),
+			},
+			wantedError: fmt.Errorf(`"count" must be at least 2 to place a task in each availability zone`),
+		},
+		"should return an error if the minimum of the range is too small": {
+			in: Count{
+				AdvancedCount: AdvancedCount{
+					Range: Range{
+						Value: (*IntRangeBand)(aws.String("1-10")),
+					},
+				},
+			},
+			wantedError: fmt.Errorf(`"count" must be at least 2 to place a task in each availability zone`),
+		},
+		"should return nil if the range covers every availability zone": {
+			in: Count{
+				AdvancedCount: AdvancedCount{
+					Range: Range{
+						RangeConfig: RangeConfig{
+							Min: aws.Int(3),
+							Max: aws.Int(10),
+						},
+					},
+				},
+			},
+		},
+		"should return nil if enough tasks are placed on spot": {
+			in: Count{
+				AdvancedCount: AdvancedCount{
+					Spot: aws.Int(2),
+				},
 			},
-			wanted: errors.New(`"ContainerDefinitions\[\d+\].Name" cannot be overridden with a custom value`),
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := tc.in.Validate()
+			err := validateAZRebalancing(tc.in, minEnvAZCount)
 
-			if tc.wanted != nil {
-				require.EqualError(t, err, tc.wanted.Error())
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
 			} else {
 				require.NoError(t, err)
 			}
@@ -2117,52 +6462,91 @@ func TestOverrideRule_Validate(t *testing.T) {
 	}
 }
 
-func TestValidateLoadBalancerTarget(t *testing.T) {
+func TestScheduledScaling_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		in     validateTargetContainerOpts
+		in     ScheduledScaling
 		wanted error
 	}{
-		"should return an error if target container doesn't exist": {
-			in: validateTargetContainerOpts{
-				mainContainerName: "mockMainContainer",
-				targetContainer:   aws.String("foo"),
+		"should accept a calendar reference": {
+			in: ScheduledScaling{Calendar: aws.String("business-hours")},
+		},
+		"should accept a cron action with a time zone": {
+			in: ScheduledScaling{
+				ScheduledAction: ScheduledAction{
+					Schedule: aws.String("cron(0 8 ? * MON-FRI *)"),
+					Timezone: aws.String("Europe/Paris"),
+					Range:    (*IntRangeBand)(aws.String("2-10")),
+				},
 			},
-			wanted: fmt.Errorf("target container foo doesn't exist"),
 		},
-		"should return an error if target container doesn't expose any port": {
-			in: validateTargetContainerOpts{
-				mainContainerName: "mockMainContainer",
-				targetContainer:   aws.String("foo"),
-				sidecarConfig: map[string]*SidecarConfig{
-					"foo": {},
+		"should accept a one-time action": {
+			in: ScheduledScaling{
+				ScheduledAction: ScheduledAction{
+					Schedule: aws.String("at(2026-12-25T00:00:00)"),
+					Range:    (*IntRangeBand)(aws.String("0-0")),
 				},
 			},
-			wanted: fmt.Errorf("target container foo doesn't expose any port"),
 		},
-		"success with no target container set": {
-			in: validateTargetContainerOpts{
-				mainContainerName: "mockMainContainer",
-				targetContainer:   nil,
-				sidecarConfig: map[string]*SidecarConfig{
-					"foo": {},
+		"should return an error if a calendar reference also sets an action": {
+			in: ScheduledScaling{
+				Calendar: aws.String("business-hours"),
+				ScheduledAction: ScheduledAction{
+					Schedule: aws.String("rate(1 day)"),
 				},
 			},
+			wanted: errors.New(`must specify one, not both, of "calendar" and "schedule/range/timezone"`),
 		},
-		"success": {
-			in: validateTargetContainerOpts{
-				mainContainerName: "mockMainContainer",
-				targetContainer:   aws.String("foo"),
-				sidecarConfig: map[string]*SidecarConfig{
-					"foo": {
-						Port: aws.String("80"),
-					},
+		"should return an error if the calendar name is empty": {
+			in:     ScheduledScaling{Calendar: aws.String("")},
+			wanted: errors.New(`"calendar" cannot be empty`),
+		},
+		"should return an error if the schedule is missing": {
+			in: ScheduledScaling{
+				ScheduledAction: ScheduledAction{
+					Range: (*IntRangeBand)(aws.String("1-2")),
+				},
+			},
+			wanted: errors.New(`"schedule" must be specified`),
+		},
+		"should return an error if the range is missing": {
+			in: ScheduledScaling{
+				ScheduledAction: ScheduledAction{
+					Schedule: aws.String("rate(1 day)"),
+				},
+			},
+			wanted: errors.New(`"range" must be specified`),
+		},
+		"should return an error if the schedule is not an Application Auto Scaling expression": {
+			in: ScheduledScaling{
+				ScheduledAction: ScheduledAction{
+					Schedule: aws.String("@daily"),
+					Range:    (*IntRangeBand)(aws.String("1-2")),
+				},
+			},
+			wanted: errors.New(`validate "schedule": schedule "@daily" must be an "at", "rate", or "cron" expression`),
+		},
+		"should return an error if an at expression is malformed": {
+			in: ScheduledScaling{
+				ScheduledAction: ScheduledAction{
+					Schedule: aws.String("at(2026-12-25)"),
+					Range:    (*IntRangeBand)(aws.String("1-2")),
 				},
 			},
+			wanted: errors.New(`validate "schedule": at expression "at(2026-12-25)" must be of the form "at(yyyy-mm-ddThh:mm:ss)"`),
+		},
+		"should return an error if the range is invalid": {
+			in: ScheduledScaling{
+				ScheduledAction: ScheduledAction{
+					Schedule: aws.String("rate(1 day)"),
+					Range:    (*IntRangeBand)(aws.String("5-2")),
+				},
+			},
+			wanted: errors.New(`validate "range": min value 5 cannot be greater than max value 2`),
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := validateTargetContainer(tc.in)
+			err := tc.in.Validate()
 
 			if tc.wanted != nil {
 				require.EqualError(t, err, tc.wanted.Error())
@@ -2173,135 +6557,116 @@ func TestValidateLoadBalancerTarget(t *testing.T) {
 	}
 }
 
-func TestValidateContainerDeps(t *testing.T) {
+func TestEnvironment_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		in     validateDependenciesOpts
+		in     Environment
 		wanted error
 	}{
-		"should return an error if main container dependencies status is invalid": {
-			in: validateDependenciesOpts{
-				mainContainerName: "mockMainContainer",
-				imageConfig: Image{
-					DependsOn: DependsOn{
-						"mockMainContainer": "complete",
+		"should accept an environment without scaling calendars": {},
+		"should accept valid scaling calendars": {
+			in: Environment{
+				ScalingCalendars: map[string]ScalingCalendar{
+					"business-hours": {
+						{Schedule: aws.String("cron(0 8 ? * MON-FRI *)"), Range: (*IntRangeBand)(aws.String("2-10"))},
+						{Schedule: aws.String("cron(0 18 ? * MON-FRI *)"), Range: (*IntRangeBand)(aws.String("1-2"))},
 					},
 				},
 			},
-			wanted: fmt.Errorf("validate mockMainContainer container dependencies status: essential container mockMainContainer can only have status START or HEALTHY"),
 		},
-		"should return an error if sidecar container dependencies status is invalid": {
-			in: validateDependenciesOpts{
-				mainContainerName: "mockMainContainer",
-				sidecarConfig: map[string]*SidecarConfig{
-					"foo": {
-						DependsOn: DependsOn{
-							"mockMainContainer": "success",
-						},
+		"should return an error if a calendar name has invalid characters": {
+			in: Environment{
+				ScalingCalendars: map[string]ScalingCalendar{
+					"business hours": {
+						{Schedule: aws.String("rate(1 day)"), Range: (*IntRangeBand)(aws.String("1-2"))},
 					},
 				},
 			},
-			wanted: fmt.Errorf("validate foo container dependencies status: essential container mockMainContainer can only have status START or HEALTHY"),
+			wanted: errors.New(`scaling calendar name "business hours" can only contain letters, numbers, underscores, and hyphens`),
 		},
-		"should return an error if a main container dependency does not exist": {
-			in: validateDependenciesOpts{
-				mainContainerName: "mockMainContainer",
-				imageConfig: Image{
-					DependsOn: DependsOn{
-						"foo": "healthy",
-					},
+		"should return an error if a calendar is empty": {
+			in: Environment{
+				ScalingCalendars: map[string]ScalingCalendar{
+					"holidays": {},
 				},
 			},
-			wanted: fmt.Errorf("container foo does not exist"),
+			wanted: errors.New(`validate "scaling_calendars.holidays": must have at least one scheduled action`),
 		},
-		"should return an error if a firelens container does not exist": {
-			in: validateDependenciesOpts{
-				mainContainerName: "mockMainContainer",
-				imageConfig: Image{
-					DependsOn: DependsOn{
-						"firelens_log_router": "start",
+		"should return an error if an action of a calendar is invalid": {
+			in: Environment{
+				ScalingCalendars: map[string]ScalingCalendar{
+					"holidays": {
+						{Schedule: aws.String("at(2026-12-25T00:00:00)"), Range: (*IntRangeBand)(aws.String("0-0"))},
+						{Schedule: aws.String("at(2026-12-26T00:00:00)")},
 					},
 				},
 			},
-			wanted: fmt.Errorf("container firelens_log_router does not exist"),
+			wanted: errors.New(`validate "scaling_calendars.holidays[1]": "range" must be specified`),
 		},
-		"should return an error if a sidecar container dependency does not exist": {
-			in: validateDependenciesOpts{
-				mainContainerName: "mockMainContainer",
-				sidecarConfig: map[string]*SidecarConfig{
-					"foo": {
-						DependsOn: DependsOn{
-							"bar": "healthy",
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateScalingCalendars(t *testing.T) {
+	calendars := map[string]ScalingCalendar{
+		"business-hours": {
+			{Schedule: aws.String("cron(0 8 ? * MON-FRI *)"), Range: (*IntRangeBand)(aws.String("2-10"))},
+		},
+	}
+	newBackend := func(names ...string) *BackendService {
+		var scheduled []ScheduledScaling
+		for _, name := range names {
+			scheduled = append(scheduled, ScheduledScaling{Calendar: aws.String(name)})
+		}
+		return &BackendService{
+			BackendServiceConfig: BackendServiceConfig{
+				TaskConfig: TaskConfig{
+					Count: Count{
+						AdvancedCount: AdvancedCount{
+							Scheduled: scheduled,
 						},
 					},
 				},
 			},
-			wanted: fmt.Errorf("container bar does not exist"),
+		}
+	}
+	testCases := map[string]struct {
+		mft       interface{}
+		calendars map[string]ScalingCalendar
+		wanted    error
+	}{
+		"should accept a workload without references": {
+			mft: newBackend(),
 		},
-		"should return an error if container depends on itself": {
-			in: validateDependenciesOpts{
-				mainContainerName: "mockMainContainer",
-				imageConfig: Image{
-					DependsOn: DependsOn{
-						"mockMainContainer": "healthy",
-					},
-				},
-			},
-			wanted: fmt.Errorf("container mockMainContainer cannot depend on itself"),
+		"should accept a workload type without count": {
+			mft: &ScheduledJob{},
 		},
-		"should return an error if container dependencies graph is cyclic": {
-			in: validateDependenciesOpts{
-				mainContainerName: "alpha",
-				imageConfig: Image{
-					DependsOn: DependsOn{
-						"beta": "healthy",
-					},
-				},
-				sidecarConfig: map[string]*SidecarConfig{
-					"beta": {
-						DependsOn: DependsOn{
-							"gamma": "healthy",
-						},
-					},
-					"gamma": {
-						DependsOn: DependsOn{
-							"alpha": "healthy",
-						},
-					},
-					"zeta": {
-						DependsOn: DependsOn{
-							"alpha": "healthy",
-						},
-					},
-				},
-			},
-			wanted: fmt.Errorf("circular container dependency chain includes the following containers: [alpha beta gamma]"),
+		"should accept references to defined calendars": {
+			mft:       newBackend("business-hours"),
+			calendars: calendars,
 		},
-		"success": {
-			in: validateDependenciesOpts{
-				mainContainerName: "alpha",
-				imageConfig: Image{
-					DependsOn: DependsOn{
-						"firelens_log_router": "start",
-						"beta":                "complete",
-					},
-				},
-				logging: Logging{
-					Image: aws.String("foobar"),
-				},
-				sidecarConfig: map[string]*SidecarConfig{
-					"beta": {
-						Essential: aws.Bool(false),
-						DependsOn: DependsOn{
-							"firelens_log_router": "start",
-						},
-					},
-				},
-			},
+		"should return an error if a referenced calendar is not defined": {
+			mft:       newBackend("business-hours", "holidays"),
+			calendars: calendars,
+			wanted:    errors.New(`scaling calendar "holidays" referenced by "count.scheduled" is not defined in the environment manifest`),
+		},
+		"should return an error if the environment has no calendars": {
+			mft:    newBackend("business-hours"),
+			wanted: errors.New(`scaling calendar "business-hours" referenced by "count.scheduled" is not defined in the environment manifest`),
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := validateContainerDeps(tc.in)
+			err := ValidateScalingCalendars(tc.mft, tc.calendars)
 
 			if tc.wanted != nil {
 				require.EqualError(t, err, tc.wanted.Error())
@@ -2312,82 +6677,137 @@ func TestValidateContainerDeps(t *testing.T) {
 	}
 }
 
-func TestValidateWindows(t *testing.T) {
+func TestValidatePlatformInRegion(t *testing.T) {
 	testCases := map[string]struct {
-		in          validateWindowsOpts
-		wantedError error
+		platform PlatformArgsOrString
+		region   string
+
+		wantedErr error
 	}{
-		"should return an error if efs specified": {
-			in: validateWindowsOpts{
-				execEnabled: true,
-			},
-			wantedError: fmt.Errorf(`'exec' is not supported when deploying a Windows container`),
+		"ARM platform in an unsupported region": {
+			platform:  PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/arm64"))},
+			region:    "us-gov-west-1",
+			wantedErr: errors.New(`platform "linux/arm64" is not supported in region us-gov-west-1: Fargate doesn't offer ARM64 architecture in this region`),
 		},
-		"error if efs specified": {
-			in: validateWindowsOpts{
-				efsVolumes: map[string]*Volume{
-					"someVolume": {
-						EFS: EFSConfigOrBool{
-							Enabled: aws.Bool(true),
-						},
-						MountPointOpts: MountPointOpts{
-							ContainerPath: aws.String("mockPath"),
-						},
-					},
+		"ARM platform in a supported region": {
+			platform: PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/arm64"))},
+			region:   "us-west-2",
+		},
+		"Windows platform in an unsupported region": {
+			platform: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String(OSWindowsServer2019Core),
+					Arch:     aws.String(ArchX86),
 				},
 			},
-			wantedError: errors.New(`'EFS' is not supported when deploying a Windows container`),
+			region:    "me-central-1",
+			wantedErr: errors.New(`platform "windows/x86_64" is not supported in region me-central-1: Fargate doesn't offer Windows containers in this region`),
 		},
-		"should return nil if neither efs nor exec specified": {
-			in: validateWindowsOpts{
-				execEnabled: false,
-			},
-			wantedError: nil,
+		"default platform": {
+			region: "us-gov-west-1",
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := validateWindows(tc.in)
+			mft := &BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					TaskConfig: TaskConfig{
+						Platform: tc.platform,
+					},
+				},
+			}
 
-			if tc.wantedError != nil {
-				require.EqualError(t, err, tc.wantedError.Error())
-			} else {
-				require.NoError(t, err)
+			err := ValidatePlatformInRegion(mft, tc.region)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
 			}
+			require.NoError(t, err)
 		})
 	}
 }
 
-func TestValidateARM(t *testing.T) {
+func TestGitSHATag_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		in          validateARMOpts
-		wantedError error
+		in     GitSHATag
+		wanted error
 	}{
-		"should return an error if Spot specified inline": {
-			in: validateARMOpts{
-				Spot: aws.Int(2),
-			},
-			wantedError: fmt.Errorf(`'Fargate Spot' is not supported when deploying on ARM architecture`),
+		"valid if empty": {},
+		"valid with the default key": {
+			in: GitSHATag{Enabled: aws.Bool(true)},
 		},
-		"should return an error if Spot specified with spot_from": {
-			in: validateARMOpts{
-				SpotFrom: aws.Int(2),
-			},
-			wantedError: fmt.Errorf(`'Fargate Spot' is not supported when deploying on ARM architecture`),
+		"valid with a user-named key": {
+			in: GitSHATag{Key: aws.String("build-sha")},
 		},
-		"should return nil if Spot not specified": {
-			in: validateARMOpts{
-				Spot: nil,
+		"error if the key is empty": {
+			in:     GitSHATag{Key: aws.String("")},
+			wanted: errors.New("tag key must not be empty"),
+		},
+		"error if the key is too long": {
+			in:     GitSHATag{Key: aws.String(strings.Repeat("a", 129))},
+			wanted: fmt.Errorf("tag key %q must not be longer than 128 characters", strings.Repeat("a", 129)),
+		},
+		"error if the key has the reserved prefix": {
+			in:     GitSHATag{Key: aws.String("AWS:sha")},
+			wanted: errors.New(`tag key "AWS:sha" must not start with the reserved prefix "aws:"`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.Validate()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateAZRebalancing_Environment(t *testing.T) {
+	newWorker := func(count int, enabled bool) *WorkerService {
+		return &WorkerService{
+			WorkerServiceConfig: WorkerServiceConfig{
+				TaskConfig: TaskConfig{
+					Count: Count{
+						Value: aws.Int(count),
+					},
+				},
+				AZRebalancing: aws.Bool(enabled),
 			},
-			wantedError: nil,
+		}
+	}
+	testCases := map[string]struct {
+		mft     interface{}
+		azCount int
+		wanted  error
+	}{
+		"should accept a workload type without rebalancing": {
+			mft:     &ScheduledJob{},
+			azCount: 3,
+		},
+		"should accept a service that doesn't turn on rebalancing": {
+			mft:     newWorker(1, false),
+			azCount: 3,
+		},
+		"should accept a service with a task in each availability zone": {
+			mft:     newWorker(3, true),
+			azCount: 3,
+		},
+		"should return an error if the environment spans more availability zones than tasks": {
+			mft:     newWorker(2, true),
+			azCount: 3,
+			wanted:  errors.New(`validate "availability_zone_rebalancing": "count" must be at least 3 to place a task in each availability zone`),
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := validateARM(tc.in)
+			err := ValidateAZRebalancing(tc.mft, tc.azCount)
 
-			if tc.wantedError != nil {
-				require.EqualError(t, err, tc.wantedError.Error())
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
 			} else {
 				require.NoError(t, err)
 			}
@@ -5,9 +5,11 @@ package manifest
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/copilot-cli/internal/pkg/template"
 	"github.com/imdario/mergo"
 	"gopkg.in/yaml.v3"
@@ -36,6 +38,32 @@ func (s *WorkerService) Publish() []Topic {
 	return s.WorkerServiceConfig.PublishConfig.Topics
 }
 
+// PreDeployTask returns the task to run to completion before the service is updated.
+func (s *WorkerService) PreDeployTask() PreDeployTask {
+	return s.WorkerServiceConfig.DeployConfig.PreDeploy
+}
+
+// PreDeployTaskConfig returns the task configuration of the service, such as its size and variables,
+// that the pre-deploy task inherits.
+func (s *WorkerService) PreDeployTaskConfig() TaskConfig {
+	return s.WorkerServiceConfig.TaskConfig
+}
+
+// GitSHATagConfig returns the option to tag the service with the git commit SHA it's deployed from.
+func (s *WorkerService) GitSHATagConfig() GitSHATag {
+	return s.GitSHATag
+}
+
+// NetworkConfig returns the network configuration of the tasks of the service.
+func (s *WorkerService) NetworkConfig() NetworkConfig {
+	return s.Network
+}
+
+// InitContainerConfig returns the container to run to completion before the main container starts.
+func (s *WorkerService) InitContainerConfig() InitContainer {
+	return s.WorkerServiceConfig.InitContainer
+}
+
 // WorkerServiceConfig holds the configuration that can be overridden per environments.
 type WorkerServiceConfig struct {
 	ImageConfig      ImageWithHealthcheck `yaml:"image,flow"`
@@ -43,10 +71,16 @@ type WorkerServiceConfig struct {
 	TaskConfig       `yaml:",inline"`
 	Logging          Logging                   `yaml:"logging,flow"`
 	Sidecars         map[string]*SidecarConfig `yaml:"sidecars"` // NOTE: keep the pointers because `mergo` doesn't automatically deep merge map's value unless it's a pointer type.
+	Flags            Flags                     `yaml:"flags"`
 	Subscribe        SubscribeConfig           `yaml:"subscribe"`
 	PublishConfig    PublishConfig             `yaml:"publish"`
 	Network          NetworkConfig             `yaml:"network"`
 	TaskDefOverrides []OverrideRule            `yaml:"taskdef_overrides"`
+	AZRebalancing    *bool                     `yaml:"availability_zone_rebalancing"`
+	PropagateTags    *string                   `yaml:"propagate_tags"`
+	GitSHATag        GitSHATag                 `yaml:"git_sha_tag"`
+	DeployConfig     DeploymentConfig          `yaml:"deployment"`
+	InitContainer    InitContainer             `yaml:"init_container"`
 }
 
 // SubscribeConfig represents the configurable options for setting up subscriptions.
@@ -62,9 +96,37 @@ func (s *SubscribeConfig) IsEmpty() bool {
 
 // TopicSubscription represents the configurable options for setting up a SNS Topic Subscription.
 type TopicSubscription struct {
-	Name    *string        `yaml:"name"`
-	Service *string        `yaml:"service"`
-	Queue   SQSQueueOrBool `yaml:"queue"`
+	Name        *string        `yaml:"name"`
+	Service     *string        `yaml:"service"`
+	ImportedARN *string        `yaml:"arn"` // ARN of a SNS topic that isn't managed by Copilot.
+	Queue       SQSQueueOrBool `yaml:"queue"`
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the TopicSubscription
+// struct, allowing the "name" field to reference the ARN of an imported SNS topic.
+func (t *TopicSubscription) UnmarshalYAML(value *yaml.Node) error {
+	type topicSubscription TopicSubscription
+	var sub topicSubscription
+	if err := value.Decode(&sub); err != nil {
+		return err
+	}
+	if sub.ImportedARN == nil && strings.HasPrefix(aws.StringValue(sub.Name), "arn:") {
+		sub.ImportedARN, sub.Name = sub.Name, nil
+	}
+	*t = TopicSubscription(sub)
+	return nil
+}
+
+// TopicName returns the name of the SNS topic the subscription receives messages from.
+func (t TopicSubscription) TopicName() string {
+	if t.ImportedARN == nil {
+		return aws.StringValue(t.Name)
+	}
+	parsed, err := arn.Parse(aws.StringValue(t.ImportedARN))
+	if err != nil {
+		return ""
+	}
+	return parsed.Resource
 }
 
 // SQSQueueOrBool contains custom unmarshaling logic for the `queue` field in the manifest.
@@ -100,18 +162,43 @@ func (q *SQSQueueOrBool) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// MarshalYAML writes the SQSQueueOrBool back as a map if it has advanced fields, or as a boolean otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (q SQSQueueOrBool) MarshalYAML() (interface{}, error) {
+	if !q.Advanced.IsEmpty() {
+		return q.Advanced, nil
+	}
+	if q.Enabled != nil {
+		return aws.BoolValue(q.Enabled), nil
+	}
+	return nil, nil
+}
+
 // SQSQueue represents the configurable options for setting up a SQS Queue.
 type SQSQueue struct {
 	Retention  *time.Duration  `yaml:"retention"`
 	Delay      *time.Duration  `yaml:"delay"`
 	Timeout    *time.Duration  `yaml:"timeout"`
 	DeadLetter DeadLetterQueue `yaml:"dead_letter"`
+	FIFO       *bool           `yaml:"fifo"` // FIFO queues receive the messages of FIFO topics.
 }
 
 // IsEmpty returns empty if the struct has all zero members.
 func (q *SQSQueue) IsEmpty() bool {
 	return q.Retention == nil && q.Delay == nil && q.Timeout == nil &&
-		q.DeadLetter.IsEmpty()
+		q.DeadLetter.IsEmpty() && q.FIFO == nil
+}
+
+// DeliversToFIFOQueue returns true if the messages of the topic subscription are delivered to a FIFO queue.
+// Subscriptions without a dedicated queue deliver to the service's events queue.
+func (s SubscribeConfig) DeliversToFIFOQueue(t TopicSubscription) bool {
+	if aws.BoolValue(t.Queue.Enabled) {
+		return false
+	}
+	if !t.Queue.Advanced.IsEmpty() {
+		return aws.BoolValue(t.Queue.Advanced.FIFO)
+	}
+	return aws.BoolValue(s.Queue.FIFO)
 }
 
 // DeadLetterQueue represents the configurable options for setting up a Dead-Letter Queue.
@@ -171,9 +258,10 @@ func (s *WorkerService) BuildRequired() (bool, error) {
 	return requiresBuild(s.ImageConfig.Image)
 }
 
-// BuildArgs returns a docker.BuildArguments object for the service given a workspace root directory
-func (s *WorkerService) BuildArgs(wsRoot string) *DockerBuildArgs {
-	return s.ImageConfig.Image.BuildConfig(wsRoot)
+// BuildArgs returns the docker.BuildArguments objects, one per build platform, for the service given a workspace root directory and an environment name.
+// hostPlatform is the "os/arch" of the Docker engine that builds the image, if known.
+func (s *WorkerService) BuildArgs(wsRoot, envName, hostPlatform string) ([]*DockerBuildArgs, error) {
+	return s.ImageConfig.Image.BuildConfigWithPlatform(wsRoot, envName, hostPlatform)
 }
 
 // Subscriptions returns a list of TopicSubscriotion objects which represent the SNS topics the service
@@ -186,11 +274,9 @@ func (s *WorkerService) Subscriptions() []TopicSubscription {
 // If the environment passed in does not have any overrides then it returns itself.
 func (s WorkerService) ApplyEnv(envName string) (WorkloadManifest, error) {
 	overrideConfig, ok := s.Environments[envName]
-	if !ok {
-		return &s, nil
-	}
-
-	if overrideConfig == nil {
+	if !ok || overrideConfig == nil {
+		s.Sidecars, s.Variables = applyFlags(s.Flags, s.Sidecars, s.Variables)
+		s.ImageOverride = s.ImageOverride.withEnvName(envName)
 		return &s, nil
 	}
 
@@ -205,6 +291,8 @@ func (s WorkerService) ApplyEnv(envName string) (WorkloadManifest, error) {
 		}
 	}
 	s.Environments = nil
+	s.Sidecars, s.Variables = applyFlags(s.Flags, s.Sidecars, s.Variables)
+	s.ImageOverride = s.ImageOverride.withEnvName(envName)
 	return &s, nil
 }
 
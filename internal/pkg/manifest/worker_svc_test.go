@@ -314,9 +314,9 @@ func TestWorkerSvc_ApplyEnv(t *testing.T) {
 						},
 					},
 					CPU: aws.Int(512),
-					Variables: map[string]string{
+					Variables: Variables{Values: map[string]string{
 						"LOG_LEVEL": "",
-					},
+					}},
 				},
 				Sidecars: map[string]*SidecarConfig{
 					"xray": {
@@ -688,9 +688,9 @@ func TestWorkerSvc_ApplyEnv(t *testing.T) {
 								CPU: &mockPerc,
 							},
 						},
-						Variables: map[string]string{
+						Variables: Variables{Values: map[string]string{
 							"LOG_LEVEL": "",
-						},
+						}},
 					},
 					Sidecars: map[string]*SidecarConfig{
 						"xray": {
@@ -1196,6 +1196,77 @@ func TestDeadLetterQueue_IsEmpty(t *testing.T) {
 	}
 }
 
+func TestTopicSubscription_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct TopicSubscription
+	}{
+		"with a topic managed by Copilot": {
+			inContent: []byte(`name: events
+service: api`),
+
+			wantedStruct: TopicSubscription{
+				Name:    aws.String("events"),
+				Service: aws.String("api"),
+			},
+		},
+		"with an imported topic ARN": {
+			inContent: []byte(`arn: arn:aws:sns:us-west-2:123456789012:events`),
+
+			wantedStruct: TopicSubscription{
+				ImportedARN: aws.String("arn:aws:sns:us-west-2:123456789012:events"),
+			},
+		},
+		"with an imported topic ARN as the name": {
+			inContent: []byte(`name: arn:aws:sns:us-west-2:123456789012:events
+queue: true`),
+
+			wantedStruct: TopicSubscription{
+				ImportedARN: aws.String("arn:aws:sns:us-west-2:123456789012:events"),
+				Queue: SQSQueueOrBool{
+					Enabled: aws.Bool(true),
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var sub TopicSubscription
+			err := yaml.Unmarshal(tc.inContent, &sub)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, sub)
+		})
+	}
+}
+
+func TestTopicSubscription_TopicName(t *testing.T) {
+	testCases := map[string]struct {
+		in     TopicSubscription
+		wanted string
+	}{
+		"topic managed by Copilot": {
+			in: TopicSubscription{
+				Name:    aws.String("events"),
+				Service: aws.String("api"),
+			},
+			wanted: "events",
+		},
+		"imported topic": {
+			in: TopicSubscription{
+				ImportedARN: aws.String("arn:aws:sns:us-west-2:123456789012:events"),
+			},
+			wanted: "events",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, tc.in.TopicName())
+		})
+	}
+}
+
 func TestSQSQueueOrBool_UnmarshalYAML(t *testing.T) {
 	testCases := map[string]struct {
 		inContent []byte
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -17,6 +19,7 @@ import (
 	"github.com/google/shlex"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/dustin/go-humanize/english"
 	"gopkg.in/yaml.v3"
 )
 
@@ -24,15 +27,35 @@ import (
 const (
 	firelensContainerName = "firelens_log_router"
 	defaultFluentbitImage = "amazon/aws-for-fluent-bit:latest"
+	defaultFluentdImage   = "fluent/fluentd:latest"
 	defaultDockerfileName = "Dockerfile"
+
+	// buildEnvToken is substituted with the environment name in "build" fields.
+	buildEnvToken = "${ENV}"
+	// cacheFromTagToken is substituted with the tag of the image being built in "cache_from" images.
+	cacheFromTagToken = "${TAG}"
+
+	// variablesFromFileKey is the key under "variables" that points to a file of KEY=VALUE lines.
+	variablesFromFileKey = "from_file"
+
+	// defaultBuildSSH forwards the default SSH agent socket to the build.
+	defaultBuildSSH = "default"
 )
 
+// maxContainersPerTask is the maximum number of containers that ECS allows in a task definition.
+const maxContainersPerTask = 10
+
+// BuildSecretEnvPrefix is the prefix of a build secret that is read from an environment variable instead of a file.
+const BuildSecretEnvPrefix = "env:"
+
 // Platform options.
 const (
 	OSLinux                 = dockerengine.OSLinux
 	OSWindows               = dockerengine.OSWindows
 	OSWindowsServer2019Core = "windows_server_2019_core"
 	OSWindowsServer2019Full = "windows_server_2019_full"
+	OSWindowsServer2022Core = "windows_server_2022_core"
+	OSWindowsServer2022Full = "windows_server_2022_full"
 
 	ArchAMD64 = dockerengine.ArchAMD64
 	ArchX86   = dockerengine.ArchX86
@@ -44,6 +67,13 @@ const (
 	MinWindowsTaskMemory = 2048
 )
 
+// AWS VPC IP address families.
+const (
+	IPFamilyIPv4      = "ipv4"
+	IPFamilyIPv6      = "ipv6"
+	IPFamilyDualStack = "dualstack"
+)
+
 var (
 	// AWS VPC subnet placement options.
 	PublicSubnetPlacement  = Placement("public")
@@ -53,7 +83,21 @@ var (
 	WorkloadTypes = append(ServiceTypes, JobTypes...)
 
 	// Acceptable strings for Windows operating systems.
-	WindowsOSFamilies = []string{OSWindows, OSWindowsServer2019Core, OSWindowsServer2019Full}
+	WindowsOSFamilies = []string{OSWindows, OSWindowsServer2019Core, OSWindowsServer2019Full, OSWindowsServer2022Core, OSWindowsServer2022Full}
+
+	// osAliases maps the spellings of OS families used by other tools to the names Copilot uses.
+	osAliases = map[string]string{
+		"windows-server-2019-core": OSWindowsServer2019Core,
+		"windows-server-2019-full": OSWindowsServer2019Full,
+		"windows-server-2022-core": OSWindowsServer2022Core,
+		"windows-server-2022-full": OSWindowsServer2022Full,
+	}
+
+	// archAliases maps the spellings of architectures used by other tools to the names Copilot uses.
+	archAliases = map[string]string{
+		"x86-64":  ArchX86,
+		"aarch64": ArchARM64,
+	}
 
 	// ValidShortPlatforms are all of the os/arch combinations that the PlatformString field may accept.
 	ValidShortPlatforms = []string{
@@ -79,23 +123,50 @@ var (
 		{OSFamily: aws.String(OSWindowsServer2019Core), Arch: aws.String(ArchAMD64)},
 		{OSFamily: aws.String(OSWindowsServer2019Full), Arch: aws.String(ArchX86)},
 		{OSFamily: aws.String(OSWindowsServer2019Full), Arch: aws.String(ArchAMD64)},
+		{OSFamily: aws.String(OSWindowsServer2022Core), Arch: aws.String(ArchX86)},
+		{OSFamily: aws.String(OSWindowsServer2022Core), Arch: aws.String(ArchAMD64)},
+		{OSFamily: aws.String(OSWindowsServer2022Full), Arch: aws.String(ArchX86)},
+		{OSFamily: aws.String(OSWindowsServer2022Full), Arch: aws.String(ArchAMD64)},
 	}
 
 	// All placement options.
 	subnetPlacements = []string{string(PublicSubnetPlacement), string(PrivateSubnetPlacement)}
 
+	// All IP address family options.
+	ipFamilies = []string{IPFamilyIPv4, IPFamilyIPv6, IPFamilyDualStack}
+
+	// shellEnvVarRegExp matches "$VAR" and "${VAR}", optionally escaped with a leading backslash.
+	shellEnvVarRegExp = regexp.MustCompile(`\\?\$(?:\{([_a-zA-Z][_a-zA-Z0-9]*)\}|([_a-zA-Z][_a-zA-Z0-9]*))`)
+
 	// Error definitions.
 	ErrAppRunnerInvalidPlatformWindows = errors.New("Windows is not supported for App Runner services")
+	errWindowsOnARM                    = errors.New("Windows containers can't run on ARM architecture on Fargate")
+	errBlueGreenWithoutLoadBalancer    = errors.New(`validate "deployment": "bluegreen" requires a load balancer, which only Load Balanced Web Services have`)
 
 	errUnmarshalBuildOpts    = errors.New("unable to unmarshal build field into string or compose-style map")
 	errUnmarshalPlatformOpts = errors.New("unable to unmarshal platform field into string or compose-style map")
 	errUnmarshalCountOpts    = errors.New(`unable to unmarshal "count" field to an integer or autoscaling configuration`)
 	errUnmarshalRangeOpts    = errors.New(`unable to unmarshal "range" field`)
+	errUnmarshalCooldown     = errors.New(`unable to unmarshal "cooldown" field into duration or scale-in and scale-out cooldowns`)
+	errUnmarshalAlarms       = errors.New(`unable to unmarshal "rollback_alarms" field into slice of strings or alarm thresholds`)
+	errUnmarshalGitSHATag    = errors.New(`unable to unmarshal "git_sha_tag" field into boolean or tag key`)
+	errUnmarshalSubnets      = errors.New(`unable to unmarshal "subnets" field into slice of subnet IDs or subnet tags`)
+	errUnmarshalSecGroup     = errors.New(`unable to unmarshal "security_groups" entry into string or "from_cfn" import`)
 
 	errUnmarshalExec       = errors.New(`unable to unmarshal "exec" field into boolean or exec configuration`)
+	errUnmarshalBlueGreen  = errors.New(`unable to unmarshal "bluegreen" field into boolean or blue/green configuration`)
 	errUnmarshalEntryPoint = errors.New(`unable to unmarshal "entrypoint" into string or slice of strings`)
-	errUnmarshalAlias      = errors.New(`unable to unmarshal "alias" into string or slice of strings`)
+	errUnmarshalAlias      = errors.New(`unable to unmarshal "alias" into string, slice of strings, or slice of aliases with a hosted zone`)
 	errUnmarshalCommand    = errors.New(`unable to unmarshal "command" into string or slice of strings`)
+	errUnmarshalFIFO       = errors.New(`unable to unmarshal "fifo" field into boolean or FIFO topic configuration`)
+	errUnmarshalSecret     = errors.New(`unable to unmarshal "secrets" entry into string or AppConfig configuration`)
+	errUnmarshalVariable   = errors.New(`unable to unmarshal "variables" entry into scalar or variable with exactly one of "value", "from_ssm" or "import"`)
+	errUnmarshalUlimit     = errors.New(`unable to unmarshal "ulimits" entry into integer or soft and hard limits`)
+	errUnmarshalFromFile   = errors.New(`unable to unmarshal "variables.from_file" into the path of a variables file: "from_file" is reserved and can't be a variable`)
+
+	errPlacementNotSpecified = &errFieldMustBeSpecified{
+		missingField: "network.vpc.placement",
+	}
 )
 
 // WorkloadManifest represents a workload manifest.
@@ -128,11 +199,12 @@ type DependsOn map[string]string
 
 // Image represents the workload's container image.
 type Image struct {
-	Build        BuildArgsOrString `yaml:"build"`           // Build an image from a Dockerfile.
-	Location     *string           `yaml:"location"`        // Use an existing image instead.
-	Credentials  *string           `yaml:"credentials"`     // ARN of the secret containing the private repository credentials.
-	DockerLabels map[string]string `yaml:"labels,flow"`     // Apply Docker labels to the container at runtime.
-	DependsOn    DependsOn         `yaml:"depends_on,flow"` // Add any sidecar dependencies.
+	Build            BuildArgsOrString `yaml:"build"`              // Build an image from a Dockerfile.
+	Location         *string           `yaml:"location"`           // Use an existing image instead.
+	Credentials      *string           `yaml:"credentials"`        // ARN of the secret containing the private repository credentials.
+	DockerLabels     map[string]string `yaml:"labels,flow"`        // Apply Docker labels to the built image and to the container at runtime.
+	DependsOn        DependsOn         `yaml:"depends_on,flow"`    // Add any sidecar dependencies.
+	RequirePinnedTag *bool             `yaml:"require_pinned_tag"` // Reject image locations without a tag or with the "latest" tag.
 }
 
 // UnmarshalYAML overrides the default YAML unmarshaling logic for the Image
@@ -183,6 +255,57 @@ type ImageWithOptionalPort struct {
 	Port  *uint16 `yaml:"port"`
 }
 
+// imageFields is an Image without its yaml.Unmarshaler, so that its fields are written when it's inlined.
+// The YAML encoder skips the inlined fields that implement yaml.Unmarshaler.
+type imageFields Image
+
+// MarshalYAML writes the image fields inline with the health check.
+// This method implements the yaml.Marshaler (v3) interface.
+func (i ImageWithHealthcheck) MarshalYAML() (interface{}, error) {
+	return struct {
+		imageFields `yaml:",inline"`
+		HealthCheck ContainerHealthCheck `yaml:"healthcheck"`
+	}{imageFields(i.Image), i.HealthCheck}, nil
+}
+
+// MarshalYAML writes the image fields inline with the port and the health check.
+// This method implements the yaml.Marshaler (v3) interface.
+func (i ImageWithPortAndHealthcheck) MarshalYAML() (interface{}, error) {
+	return struct {
+		imageFields `yaml:",inline"`
+		Port        *uint16              `yaml:"port"`
+		HealthCheck ContainerHealthCheck `yaml:"healthcheck"`
+	}{imageFields(i.Image), i.Port, i.HealthCheck}, nil
+}
+
+// MarshalYAML writes the image fields inline with the port.
+// This method implements the yaml.Marshaler (v3) interface.
+func (i ImageWithPort) MarshalYAML() (interface{}, error) {
+	return struct {
+		imageFields `yaml:",inline"`
+		Port        *uint16 `yaml:"port"`
+	}{imageFields(i.Image), i.Port}, nil
+}
+
+// MarshalYAML writes the image fields inline with the optional port and the health check.
+// This method implements the yaml.Marshaler (v3) interface.
+func (i ImageWithHealthcheckAndOptionalPort) MarshalYAML() (interface{}, error) {
+	return struct {
+		imageFields `yaml:",inline"`
+		Port        *uint16              `yaml:"port"`
+		HealthCheck ContainerHealthCheck `yaml:"healthcheck"`
+	}{imageFields(i.Image), i.Port, i.HealthCheck}, nil
+}
+
+// MarshalYAML writes the image fields inline with the optional port.
+// This method implements the yaml.Marshaler (v3) interface.
+func (i ImageWithOptionalPort) MarshalYAML() (interface{}, error) {
+	return struct {
+		imageFields `yaml:",inline"`
+		Port        *uint16 `yaml:"port"`
+	}{imageFields(i.Image), i.Port}, nil
+}
+
 // GetLocation returns the location of the image.
 func (i Image) GetLocation() string {
 	return aws.StringValue(i.Location)
@@ -194,7 +317,46 @@ func (i Image) GetLocation() string {
 // 2. Specific dockerfile, context = dockerfile dir
 // 3. "Dockerfile" located in context dir
 // 4. "Dockerfile" located in ws root.
-func (i *Image) BuildConfig(rootDirectory string) *DockerBuildArgs {
+// Any "${ENV}" token in the dockerfile, context, target, or args is substituted with envName.
+// If "build.platforms" is specified, one build configuration is returned per platform.
+func (i *Image) BuildConfig(rootDirectory, envName string) ([]*DockerBuildArgs, error) {
+	build, err := i.Build.Interpolate(envName)
+	if err != nil {
+		return nil, err
+	}
+	resolved := Image{Build: build, DockerLabels: i.DockerLabels}
+	if len(build.BuildArgs.Platforms) == 0 {
+		return []*DockerBuildArgs{resolved.buildConfig(rootDirectory)}, nil
+	}
+	if err := build.BuildArgs.validateCacheFrom(); err != nil {
+		return nil, err
+	}
+	var configs []*DockerBuildArgs
+	for _, platform := range build.BuildArgs.Platforms {
+		resolved.Build = build.forPlatform(platform)
+		config := resolved.buildConfig(rootDirectory)
+		config.Platform = aws.String(platform.platformString())
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// BuildConfigWithPlatform is like BuildConfig, but if the image isn't built for "build.platforms",
+// its build configuration records hostPlatform, the "os/arch" of the Docker engine, as the HostPlatform.
+// Callers can then detect images that are built for the host instead of the platform that the tasks run on.
+func (i *Image) BuildConfigWithPlatform(rootDirectory, envName, hostPlatform string) ([]*DockerBuildArgs, error) {
+	configs, err := i.BuildConfig(rootDirectory, envName)
+	if err != nil {
+		return nil, err
+	}
+	if hostPlatform == "" || len(configs) != 1 || configs[0].Platform != nil {
+		return configs, nil
+	}
+	configs[0].HostPlatform = aws.String(hostPlatform)
+	return configs, nil
+}
+
+func (i *Image) buildConfig(rootDirectory string) *DockerBuildArgs {
 	df := i.dockerfile()
 	ctx := i.context()
 	dockerfile := aws.String(filepath.Join(rootDirectory, defaultDockerfileName))
@@ -218,6 +380,10 @@ func (i *Image) BuildConfig(rootDirectory string) *DockerBuildArgs {
 		Args:       i.args(),
 		Target:     i.target(),
 		CacheFrom:  i.cacheFrom(),
+		SSH:        i.ssh(rootDirectory),
+		Secrets:    i.secrets(rootDirectory),
+		Network:    i.Build.BuildArgs.Network,
+		Labels:     i.DockerLabels,
 	}
 }
 
@@ -254,6 +420,44 @@ func (i *Image) target() *string {
 	return i.Build.BuildArgs.Target
 }
 
+// ssh returns the SSH agent sockets or keys to forward to the build, if they exist.
+// Relative paths are resolved against the root directory.
+func (i *Image) ssh(rootDirectory string) []string {
+	var out []string
+	for _, ssh := range i.Build.BuildArgs.SSH {
+		parts := strings.SplitN(ssh, "=", 2)
+		if len(parts) != 2 {
+			out = append(out, ssh)
+			continue
+		}
+		paths := strings.Split(parts[1], ",")
+		for ind, path := range paths {
+			if !filepath.IsAbs(path) {
+				paths[ind] = filepath.Join(rootDirectory, path)
+			}
+		}
+		out = append(out, fmt.Sprintf("%s=%s", parts[0], strings.Join(paths, ",")))
+	}
+	return out
+}
+
+// secrets returns the build secrets keyed by id, if they exist.
+// Relative file paths are resolved against the root directory.
+func (i *Image) secrets(rootDirectory string) map[string]string {
+	if i.Build.BuildArgs.Secrets == nil {
+		return nil
+	}
+	out := make(map[string]string, len(i.Build.BuildArgs.Secrets))
+	for id, src := range i.Build.BuildArgs.Secrets {
+		if strings.HasPrefix(src, BuildSecretEnvPrefix) || filepath.IsAbs(src) {
+			out[id] = src
+			continue
+		}
+		out[id] = filepath.Join(rootDirectory, src)
+	}
+	return out
+}
+
 // cacheFrom returns the cache from build section, if it exists.
 // Otherwise it returns nil.
 func (i *Image) cacheFrom() []string {
@@ -262,8 +466,63 @@ func (i *Image) cacheFrom() []string {
 
 // ImageOverride holds fields that override Dockerfile image defaults.
 type ImageOverride struct {
-	EntryPoint EntryPointOverride `yaml:"entrypoint"`
-	Command    CommandOverride    `yaml:"command"`
+	EntryPoint     EntryPointOverride `yaml:"entrypoint"`
+	Command        CommandOverride    `yaml:"command"`
+	PseudoTerminal *bool              `yaml:"tty"`
+	Interactive    *bool              `yaml:"stdin_open"`
+	Ulimits        map[string]Ulimit  `yaml:"ulimits"`
+}
+
+// Ulimit is a custom type which supports unmarshaling yaml which
+// can either be of type int, applied as both the soft and hard limit, or type ulimitConfig.
+type Ulimit struct {
+	Soft *int
+	Hard *int
+}
+
+// ulimitNames are the resource limit names accepted by ECS.
+var ulimitNames = []string{"core", "cpu", "data", "fsize", "locks", "memlock", "msgqueue", "nice",
+	"nofile", "nproc", "rss", "rtprio", "rttime", "sigpending", "stack"}
+
+type ulimitConfig struct {
+	Soft *int `yaml:"soft"`
+	Hard *int `yaml:"hard"`
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the Ulimit
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (u *Ulimit) UnmarshalYAML(value *yaml.Node) error {
+	var config ulimitConfig
+	if err := value.Decode(&config); err != nil {
+		switch err.(type) {
+		case *yaml.TypeError:
+			break
+		default:
+			return err
+		}
+	}
+
+	if config.Soft != nil || config.Hard != nil {
+		u.Soft, u.Hard = config.Soft, config.Hard
+		return nil
+	}
+
+	var limit int
+	if err := value.Decode(&limit); err != nil {
+		return errUnmarshalUlimit
+	}
+	u.Soft, u.Hard = aws.Int(limit), aws.Int(limit)
+	return nil
+}
+
+// MarshalYAML writes the Ulimit back as a map of its soft and hard limits.
+// This method implements the yaml.Marshaler (v3) interface.
+func (u Ulimit) MarshalYAML() (interface{}, error) {
+	return ulimitConfig{
+		Soft: u.Soft,
+		Hard: u.Hard,
+	}, nil
 }
 
 // EntryPointOverride is a custom type which supports unmarshalling "entrypoint" yaml which
@@ -284,6 +543,12 @@ func (e *EntryPointOverride) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// MarshalYAML writes the EntryPointOverride back in the form it was written in, a string or a slice of strings.
+// This method implements the yaml.Marshaler (v3) interface.
+func (e EntryPointOverride) MarshalYAML() (interface{}, error) {
+	return marshalYAMLFromStringSliceOrString(stringSliceOrString(e)), nil
+}
+
 // ToStringSlice converts an EntryPointOverride to a slice of string using shell-style rules.
 func (e *EntryPointOverride) ToStringSlice() ([]string, error) {
 	out, err := toStringSlice((*stringSliceOrString)(e))
@@ -303,6 +568,12 @@ func (c *CommandOverride) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// MarshalYAML writes the CommandOverride back in the form it was written in, a string or a slice of strings.
+// This method implements the yaml.Marshaler (v3) interface.
+func (c CommandOverride) MarshalYAML() (interface{}, error) {
+	return marshalYAMLFromStringSliceOrString(stringSliceOrString(c)), nil
+}
+
 // ToStringSlice converts an CommandOverride to a slice of string using shell-style rules.
 func (c *CommandOverride) ToStringSlice() ([]string, error) {
 	out, err := toStringSlice((*stringSliceOrString)(c))
@@ -312,11 +583,107 @@ func (c *CommandOverride) ToStringSlice() ([]string, error) {
 	return out, nil
 }
 
+// ToStringSliceWithEnv converts a CommandOverride to a slice of string using shell-style rules.
+// Before the string form is split, "$VAR" and "${VAR}" tokens are expanded with lookup.
+// Unknown variables and escaped "\$" tokens are left intact. The slice form is returned as is.
+func (c *CommandOverride) ToStringSliceWithEnv(lookup func(string) (string, bool)) ([]string, error) {
+	if c.String == nil {
+		return c.ToStringSlice()
+	}
+	expanded := expandEnvVars(aws.StringValue(c.String), lookup)
+	return toStringSlice(&stringSliceOrString{
+		String: &expanded,
+	})
+}
+
+// withEnvName returns a copy of the ImageOverride where the "$COPILOT_ENVIRONMENT_NAME" tokens of the entrypoint and command
+// are replaced by envName, the same value as the one that the Interpolator substitutes for "${COPILOT_ENVIRONMENT_NAME}".
+// Other tokens are left intact.
+func (o ImageOverride) withEnvName(envName string) ImageOverride {
+	// Only the environment name is known when the overrides of the environment are applied.
+	interpolator := &Interpolator{
+		predefinedEnvVars: map[string]string{
+			reservedEnvVarKeyForEnvName: envName,
+		},
+	}
+	o.EntryPoint = EntryPointOverride(stringSliceOrString(o.EntryPoint).expandEnvVars(interpolator.predefinedEnvVar))
+	o.Command = CommandOverride(stringSliceOrString(o.Command).expandEnvVars(interpolator.predefinedEnvVar))
+	return o
+}
+
+// expandEnvVars replaces "$VAR" and "${VAR}" in the shell command s with their values from lookup.
+// Like a shell, tokens between single quotes are left intact. Single quotes within double quotes are literal characters.
+func expandEnvVars(s string, lookup func(string) (string, bool)) string {
+	var out strings.Builder
+	var inDoubleQuotes bool
+	start := 0 // Start of the segment outside of single quotes that isn't written yet.
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++ // The escaped character can't open or close quotes.
+		case '"':
+			inDoubleQuotes = !inDoubleQuotes
+		case '\'':
+			if inDoubleQuotes {
+				continue
+			}
+			out.WriteString(expandEnvVarTokens(s[start:i], lookup))
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end == -1 {
+				// Unbalanced quotes are rejected by validation, keep the rest as is.
+				out.WriteString(s[i:])
+				return out.String()
+			}
+			closing := i + 1 + end
+			out.WriteString(s[i : closing+1])
+			i = closing
+			start = closing + 1
+		}
+	}
+	out.WriteString(expandEnvVarTokens(s[start:], lookup))
+	return out.String()
+}
+
+// expandEnvVarTokens replaces every "$VAR" and "${VAR}" in s with their values from lookup, regardless of quotes.
+func expandEnvVarTokens(s string, lookup func(string) (string, bool)) string {
+	return shellEnvVarRegExp.ReplaceAllStringFunc(s, func(match string) string {
+		if strings.HasPrefix(match, `\`) {
+			return match
+		}
+		submatches := shellEnvVarRegExp.FindStringSubmatch(match)
+		key := submatches[1]
+		if key == "" {
+			key = submatches[2]
+		}
+		val, ok := lookup(key)
+		if !ok {
+			return match
+		}
+		return val
+	})
+}
+
 type stringSliceOrString struct {
 	String      *string
 	StringSlice []string
 }
 
+// expandEnvVars returns a copy of s with the tokens of both the string and the slice form expanded with lookup.
+func (s stringSliceOrString) expandEnvVars(lookup func(string) (string, bool)) stringSliceOrString {
+	if s.String != nil {
+		s.String = aws.String(expandEnvVars(aws.StringValue(s.String), lookup))
+	}
+	if s.StringSlice != nil {
+		slice := make([]string, len(s.StringSlice))
+		for i, arg := range s.StringSlice {
+			// Each element is a single argument that isn't interpreted by a shell, so quotes are literal characters.
+			slice[i] = expandEnvVarTokens(arg, lookup)
+		}
+		s.StringSlice = slice
+	}
+	return s
+}
+
 func unmarshalYAMLToStringSliceOrString(s *stringSliceOrString, value *yaml.Node) error {
 	if err := value.Decode(&s.StringSlice); err != nil {
 		switch err.(type) {
@@ -336,6 +703,16 @@ func unmarshalYAMLToStringSliceOrString(s *stringSliceOrString, value *yaml.Node
 	return value.Decode(&s.String)
 }
 
+func marshalYAMLFromStringSliceOrString(s stringSliceOrString) interface{} {
+	if s.StringSlice != nil {
+		return s.StringSlice
+	}
+	if s.String != nil {
+		return aws.StringValue(s.String)
+	}
+	return nil
+}
+
 func toStringSlice(s *stringSliceOrString) ([]string, error) {
 	if s.StringSlice != nil {
 		return s.StringSlice, nil
@@ -345,6 +722,9 @@ func toStringSlice(s *stringSliceOrString) ([]string, error) {
 		return nil, nil
 	}
 
+	if err := checkBalancedQuotes(*s.String); err != nil {
+		return nil, fmt.Errorf("convert string into tokens using shell-style rules: %w", err)
+	}
 	out, err := shlex.Split(*s.String)
 	if err != nil {
 		return nil, fmt.Errorf("convert string into tokens using shell-style rules: %w", err)
@@ -353,6 +733,35 @@ func toStringSlice(s *stringSliceOrString) ([]string, error) {
 	return out, nil
 }
 
+// checkBalancedQuotes returns an error if s contains a quote that is never closed or ends with a dangling escape.
+// Backslashes escape the next character outside of quotes and within double quotes, but not within single quotes.
+func checkBalancedQuotes(s string) error {
+	var quote rune
+	var quoteStart int
+	escaped := false
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote == 0 && (r == '"' || r == '\''):
+			quote, quoteStart = r, i
+		case quote != 0 && r == quote:
+			quote = 0
+		}
+	}
+	switch {
+	case quote == '"':
+		return fmt.Errorf(`unterminated double quote at position %d in %q`, quoteStart, s)
+	case quote == '\'':
+		return fmt.Errorf(`unterminated single quote at position %d in %q`, quoteStart, s)
+	case escaped:
+		return fmt.Errorf(`dangling escape character at the end of %q`, s)
+	}
+	return nil
+}
+
 // BuildArgsOrString is a custom type which supports unmarshaling yaml which
 // can either be of type string or type DockerBuildArgs.
 type BuildArgsOrString struct {
@@ -360,6 +769,24 @@ type BuildArgsOrString struct {
 	BuildArgs   DockerBuildArgs
 }
 
+// forPlatform returns a copy of the BuildArgsOrString where the dockerfile, context, and cache_from
+// fields of the platform take precedence over the top-level ones.
+func (b BuildArgsOrString) forPlatform(platform PlatformBuildArgs) BuildArgsOrString {
+	out := b
+	out.BuildArgs.Platforms = nil
+	if platform.Dockerfile != nil {
+		out.BuildString = nil
+		out.BuildArgs.Dockerfile = platform.Dockerfile
+	}
+	if platform.Context != nil {
+		out.BuildArgs.Context = platform.Context
+	}
+	if platform.CacheFrom != nil {
+		out.BuildArgs.CacheFrom = platform.CacheFrom
+	}
+	return out
+}
+
 func (b *BuildArgsOrString) isEmpty() bool {
 	if aws.StringValue(b.BuildString) == "" && b.BuildArgs.isEmpty() {
 		return true
@@ -392,24 +819,170 @@ func (b *BuildArgsOrString) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// MarshalYAML writes the BuildArgsOrString back as a map if it has build arguments, or as a Dockerfile path otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (b BuildArgsOrString) MarshalYAML() (interface{}, error) {
+	if !b.BuildArgs.isEmpty() {
+		return b.BuildArgs, nil
+	}
+	if b.BuildString != nil {
+		return aws.StringValue(b.BuildString), nil
+	}
+	return nil, nil
+}
+
+// Interpolate returns a copy of the BuildArgsOrString where every "${ENV}" token in the
+// dockerfile, context, target, args values, and cache_from images is replaced with envName.
+// Fields without the token, such as images pinned by digest, are left untouched.
+func (b BuildArgsOrString) Interpolate(envName string) (BuildArgsOrString, error) {
+	out := b
+	for _, field := range []struct {
+		name string
+		dst  **string
+	}{
+		{name: "build", dst: &out.BuildString},
+		{name: "dockerfile", dst: &out.BuildArgs.Dockerfile},
+		{name: "context", dst: &out.BuildArgs.Context},
+		{name: "target", dst: &out.BuildArgs.Target},
+	} {
+		if err := interpolateBuildField(field.name, field.dst, envName); err != nil {
+			return BuildArgsOrString{}, err
+		}
+	}
+	if b.BuildArgs.Args != nil {
+		out.BuildArgs.Args = make(map[string]string, len(b.BuildArgs.Args))
+		for key, value := range b.BuildArgs.Args {
+			if strings.Contains(value, buildEnvToken) && envName == "" {
+				return BuildArgsOrString{}, fmt.Errorf(`"args.%s" references %s but no environment is defined`, key, buildEnvToken)
+			}
+			out.BuildArgs.Args[key] = strings.ReplaceAll(value, buildEnvToken, envName)
+		}
+	}
+	cacheFrom, err := interpolateCacheFrom("cache_from", b.BuildArgs.CacheFrom, envName)
+	if err != nil {
+		return BuildArgsOrString{}, err
+	}
+	out.BuildArgs.CacheFrom = cacheFrom
+	if b.BuildArgs.Platforms != nil {
+		out.BuildArgs.Platforms = make([]PlatformBuildArgs, len(b.BuildArgs.Platforms))
+		for ind, platform := range b.BuildArgs.Platforms {
+			if err := interpolateBuildField(fmt.Sprintf("platforms[%d].dockerfile", ind), &platform.Dockerfile, envName); err != nil {
+				return BuildArgsOrString{}, err
+			}
+			if err := interpolateBuildField(fmt.Sprintf("platforms[%d].context", ind), &platform.Context, envName); err != nil {
+				return BuildArgsOrString{}, err
+			}
+			cacheFrom, err := interpolateCacheFrom(fmt.Sprintf("platforms[%d].cache_from", ind), platform.CacheFrom, envName)
+			if err != nil {
+				return BuildArgsOrString{}, err
+			}
+			platform.CacheFrom = cacheFrom
+			out.BuildArgs.Platforms[ind] = platform
+		}
+	}
+	return out, nil
+}
+
+// interpolateBuildField replaces every "${ENV}" token in the value that dst points to with envName.
+func interpolateBuildField(field string, dst **string, envName string) error {
+	if *dst == nil || !strings.Contains(**dst, buildEnvToken) {
+		return nil
+	}
+	if envName == "" {
+		return fmt.Errorf(`"%s" references %s but no environment is defined`, field, buildEnvToken)
+	}
+	*dst = aws.String(strings.ReplaceAll(**dst, buildEnvToken, envName))
+	return nil
+}
+
+// interpolateCacheFrom returns a copy of the cache_from images with every "${ENV}" token replaced with envName.
+func interpolateCacheFrom(field string, images []string, envName string) ([]string, error) {
+	if images == nil {
+		return nil, nil
+	}
+	out := make([]string, len(images))
+	for ind, image := range images {
+		if strings.Contains(image, buildEnvToken) && envName == "" {
+			return nil, fmt.Errorf(`"%s[%d]" references %s but no environment is defined`, field, ind, buildEnvToken)
+		}
+		out[ind] = strings.ReplaceAll(image, buildEnvToken, envName)
+	}
+	return out, nil
+}
+
 // DockerBuildArgs represents the options specifiable under the "build" field
 // of Docker Compose services. For more information, see:
 // https://docs.docker.com/compose/compose-file/#build
 type DockerBuildArgs struct {
-	Context    *string           `yaml:"context,omitempty"`
-	Dockerfile *string           `yaml:"dockerfile,omitempty"`
-	Args       map[string]string `yaml:"args,omitempty"`
-	Target     *string           `yaml:"target,omitempty"`
-	CacheFrom  []string          `yaml:"cache_from,omitempty"`
+	Context    *string             `yaml:"context,omitempty"`
+	Dockerfile *string             `yaml:"dockerfile,omitempty"`
+	Args       map[string]string   `yaml:"args,omitempty"`
+	Target     *string             `yaml:"target,omitempty"`
+	CacheFrom  []string            `yaml:"cache_from,omitempty"`
+	Platforms  []PlatformBuildArgs `yaml:"platforms,omitempty"`
+	SSH        []string            `yaml:"ssh,omitempty"`
+	Secrets    map[string]string   `yaml:"secrets,omitempty"`
+	Network    *string             `yaml:"network,omitempty"`
+
+	// Platform is the "os/arch" target of a resolved build configuration. Only set by Image.BuildConfig.
+	Platform *string `yaml:"-"`
+	// Labels are the Docker labels of the image, copied from "image.labels". Only set by Image.BuildConfig.
+	Labels map[string]string `yaml:"-"`
+	// HostPlatform is the "os/arch" of the Docker engine that builds an image without a target platform.
+	// Only set by Image.BuildConfigWithPlatform.
+	HostPlatform *string `yaml:"-"`
+}
+
+// CacheFromImages returns the "cache_from" images with every "${TAG}" token replaced with tag, the tag of the image being built.
+func (b *DockerBuildArgs) CacheFromImages(tag string) ([]string, error) {
+	if b.CacheFrom == nil {
+		return nil, nil
+	}
+	out := make([]string, len(b.CacheFrom))
+	for ind, image := range b.CacheFrom {
+		if strings.Contains(image, cacheFromTagToken) && tag == "" {
+			return nil, fmt.Errorf(`"cache_from[%d]" references %s but the image has no tag`, ind, cacheFromTagToken)
+		}
+		out[ind] = strings.ReplaceAll(image, cacheFromTagToken, tag)
+	}
+	return out, nil
 }
 
 func (b *DockerBuildArgs) isEmpty() bool {
-	if b.Context == nil && b.Dockerfile == nil && b.Args == nil && b.Target == nil && b.CacheFrom == nil {
+	if b.Context == nil && b.Dockerfile == nil && b.Args == nil && b.Target == nil && b.CacheFrom == nil && b.Platforms == nil &&
+		b.SSH == nil && b.Secrets == nil && b.Network == nil {
 		return true
 	}
 	return false
 }
 
+// validateCacheFrom returns an error if the same cache_from image is referenced by more than one platform.
+func (b *DockerBuildArgs) validateCacheFrom() error {
+	platformFor := make(map[string]string)
+	for _, platform := range b.Platforms {
+		for _, image := range platform.CacheFrom {
+			if other, ok := platformFor[image]; ok {
+				return fmt.Errorf(`cache_from image "%s" is referenced by both platforms %s and %s`, image, other, platform.platformString())
+			}
+			platformFor[image] = platform.platformString()
+		}
+	}
+	return nil
+}
+
+// PlatformBuildArgs represents the build overrides for a single target platform under "build.platforms".
+type PlatformBuildArgs struct {
+	PlatformArgs `yaml:",inline"`
+	Dockerfile   *string  `yaml:"dockerfile,omitempty"`
+	Context      *string  `yaml:"context,omitempty"`
+	CacheFrom    []string `yaml:"cache_from,omitempty"`
+}
+
+// platformString returns the platform in the format "os/arch".
+func (p PlatformBuildArgs) platformString() string {
+	return platformString(normalizeOS(aws.StringValue(p.OSFamily)), normalizeArch(aws.StringValue(p.Arch)))
+}
+
 // ExecuteCommand is a custom type which supports unmarshaling yaml which
 // can either be of type bool or type ExecuteCommandConfig.
 type ExecuteCommand struct {
@@ -440,41 +1013,112 @@ func (e *ExecuteCommand) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// MarshalYAML writes the ExecuteCommand back as a map if it has advanced fields, or as a boolean otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (e ExecuteCommand) MarshalYAML() (interface{}, error) {
+	if !e.Config.IsEmpty() {
+		return e.Config, nil
+	}
+	if e.Enable != nil {
+		return aws.BoolValue(e.Enable), nil
+	}
+	return nil, nil
+}
+
+// Enabled returns true if the tasks of the service are deployed with ECS Execute Command turned on.
+func (e ExecuteCommand) Enabled() bool {
+	if e.Disabled() {
+		return false
+	}
+	return !e.Config.IsEmpty() || aws.BoolValue(e.Enable)
+}
+
+// Disabled returns true if ECS Execute Command is explicitly turned off with either "exec: false" or "exec.enable: false".
+func (e ExecuteCommand) Disabled() bool {
+	if !e.Config.IsEmpty() {
+		return e.Config.Enable != nil && !aws.BoolValue(e.Config.Enable)
+	}
+	return e.Enable != nil && !aws.BoolValue(e.Enable)
+}
+
 // ExecuteCommandConfig represents the configuration for ECS Execute Command.
 type ExecuteCommandConfig struct {
-	Enable *bool `yaml:"enable"`
-	// Reserved for future use.
+	Enable  *bool        `yaml:"enable"`
+	Logging *ExecLogging `yaml:"logging"`
 }
 
 // IsEmpty returns whether ExecuteCommandConfig is empty.
 func (e ExecuteCommandConfig) IsEmpty() bool {
-	return e.Enable == nil
+	return e.Enable == nil && e.Logging.IsEmpty()
 }
 
+// ExecLogging represents where the audit logs of ECS Execute Command sessions are shipped to.
+type ExecLogging struct {
+	CloudWatchLogGroup          *string `yaml:"cloud_watch_log_group"`
+	CloudWatchEncryptionEnabled *bool   `yaml:"cloud_watch_encryption_enabled"`
+	S3Bucket                    *string `yaml:"s3_bucket"`
+	S3KeyPrefix                 *string `yaml:"s3_key_prefix"`
+}
+
+// IsEmpty returns whether ExecLogging is empty.
+func (l *ExecLogging) IsEmpty() bool {
+	return l == nil || (l.CloudWatchLogGroup == nil && l.CloudWatchEncryptionEnabled == nil &&
+		l.S3Bucket == nil && l.S3KeyPrefix == nil)
+}
+
+// Firelens log router types.
+const (
+	FirelensConfigTypeFluentBit = "fluentbit"
+	FirelensConfigTypeFluentd   = "fluentd"
+)
+
+var firelensConfigTypes = []string{FirelensConfigTypeFluentBit, FirelensConfigTypeFluentd}
+
+// Option keys of the Firelens log router that Copilot generates from "enableMetadata" and "configFilePath".
+var firelensReservedOptions = []string{"enable-ecs-log-metadata", "config-file-type", "config-file-value"}
+
+// The "Name" option selects the output plugin of the log router and can't be read from a secret.
+const firelensNameOption = "Name"
+
 // Logging holds configuration for Firelens to route your logs.
 type Logging struct {
 	Retention      *int              `yaml:"retention"`
+	LogGroup       *string           `yaml:"log_group"`
+	ImportLogGroup *bool             `yaml:"import_log_group"`
 	Image          *string           `yaml:"image"`
 	Destination    map[string]string `yaml:"destination,flow"`
 	EnableMetadata *bool             `yaml:"enableMetadata"`
 	SecretOptions  map[string]string `yaml:"secretOptions"`
 	ConfigFile     *string           `yaml:"configFilePath"`
 	Variables      map[string]string `yaml:"variables"`
-	Secrets        map[string]string `yaml:"secrets"`
+	Secrets        map[string]Secret `yaml:"secrets"`
+	ConfigType     *string           `yaml:"configType"`
 }
 
 // IsEmpty returns empty if the struct has all zero members.
 func (lc *Logging) IsEmpty() bool {
 	return lc.Image == nil && lc.Destination == nil && lc.EnableMetadata == nil &&
-		lc.SecretOptions == nil && lc.ConfigFile == nil && lc.Variables == nil && lc.Secrets == nil
+		lc.SecretOptions == nil && lc.ConfigFile == nil && lc.Variables == nil && lc.Secrets == nil &&
+		lc.ConfigType == nil
+}
+
+// FirelensConfigType returns the type of the Firelens log router, Fluent Bit if not otherwise configured.
+func (lc *Logging) FirelensConfigType() string {
+	if lc.ConfigType == nil {
+		return FirelensConfigTypeFluentBit
+	}
+	return aws.StringValue(lc.ConfigType)
 }
 
-// LogImage returns the default Fluent Bit image if not otherwise configured.
+// LogImage returns the default image of the log router type if not otherwise configured.
 func (lc *Logging) LogImage() *string {
-	if lc.Image == nil {
-		return aws.String(defaultFluentbitImage)
+	if lc.Image != nil {
+		return lc.Image
+	}
+	if lc.FirelensConfigType() == FirelensConfigTypeFluentd {
+		return aws.String(defaultFluentdImage)
 	}
-	return lc.Image
+	return aws.String(defaultFluentbitImage)
 }
 
 // GetEnableMetadata returns the configuration values and sane default for the EnableMEtadata field
@@ -486,6 +1130,99 @@ func (lc *Logging) GetEnableMetadata() *string {
 	return aws.String(strconv.FormatBool(*lc.EnableMetadata))
 }
 
+// Trace header formats that a service can propagate.
+const (
+	TracePropagationAWSXRay = "awsxray"
+	TracePropagationW3C     = "w3c"
+)
+
+var tracePropagationFormats = []string{TracePropagationAWSXRay, TracePropagationW3C}
+
+// tracePropagatorsEnvVar is the name of the variable that holds the trace header format.
+const tracePropagatorsEnvVar = "OTEL_PROPAGATORS"
+
+// Tracing vendors whose daemon Copilot injects as a sidecar.
+const (
+	TracingAWSXRay = "awsxray"
+	TracingAWSOTel = "awsotel"
+)
+
+var tracingVendors = []string{TracingAWSXRay, TracingAWSOTel}
+
+// Names of the sidecars injected by "observability.tracing".
+const (
+	XRayContainerName          = "xray"
+	OTelCollectorContainerName = "aws-otel-collector"
+)
+
+// Observability holds the configuration for tracing requests across services.
+type Observability struct {
+	// TracePropagation is the trace header format that the application propagates to downstream calls.
+	// The Application Load Balancer always adds an "X-Amzn-Trace-Id" header to incoming requests, so applications
+	// using "w3c" are expected to start their "traceparent" context from it.
+	TracePropagation *string `yaml:"trace_propagation"`
+	// Tracing is the vendor of the daemon that Copilot runs next to the application to send its traces.
+	Tracing *string `yaml:"tracing"`
+	// CollectorConfig is the path, relative to the workspace root, of the configuration file of the OpenTelemetry collector.
+	CollectorConfig *string `yaml:"collector_config"`
+
+	// CollectorConfigContent is the content of the CollectorConfig file, read with LoadWorkloadCollectorConfig.
+	CollectorConfigContent *string `yaml:"-"`
+}
+
+// IsEmpty returns true if Observability is not configured.
+func (o Observability) IsEmpty() bool {
+	return o.TracePropagation == nil && o.Tracing == nil && o.CollectorConfig == nil
+}
+
+// XRayTracingEnabled returns true if the X-Ray daemon sidecar is injected into the task.
+func (o Observability) XRayTracingEnabled() bool {
+	return aws.StringValue(o.Tracing) == TracingAWSXRay
+}
+
+// OTelTracingEnabled returns true if the AWS Distro for OpenTelemetry collector sidecar is injected into the task.
+func (o Observability) OTelTracingEnabled() bool {
+	return aws.StringValue(o.Tracing) == TracingAWSOTel
+}
+
+// tracingContainer returns the name and a description of the sidecar injected by "observability.tracing",
+// or empty strings if tracing is disabled.
+func (o Observability) tracingContainer() (name, description string) {
+	switch {
+	case o.XRayTracingEnabled():
+		return XRayContainerName, "X-Ray daemon"
+	case o.OTelTracingEnabled():
+		return OTelCollectorContainerName, "OpenTelemetry collector"
+	}
+	return "", ""
+}
+
+func (o *Observability) loadCollectorConfig(read func(path string) ([]byte, error)) error {
+	if o.CollectorConfig == nil {
+		return nil
+	}
+	path := aws.StringValue(o.CollectorConfig)
+	content, err := read(path)
+	if err != nil {
+		return fmt.Errorf("read collector config file %s: %w", path, err)
+	}
+	o.CollectorConfigContent = aws.String(string(content))
+	return nil
+}
+
+// LoadWorkloadCollectorConfig reads the OpenTelemetry collector configuration file of a workload manifest with read,
+// if the manifest specifies one, so that a missing or unreadable file is reported before the manifest is deployed.
+func LoadWorkloadCollectorConfig(mft interface{}, read func(path string) ([]byte, error)) error {
+	type collectorConfigLoader interface {
+		LoadCollectorConfig(read func(path string) ([]byte, error)) error
+	}
+	loader, ok := mft.(collectorConfigLoader)
+	if !ok {
+		return nil
+	}
+	return loader.LoadCollectorConfig(read)
+}
+
 // SidecarConfig represents the configurable options for setting up a sidecar container.
 type SidecarConfig struct {
 	Port          *string              `yaml:"port"`
@@ -498,78 +1235,873 @@ type SidecarConfig struct {
 	DockerLabels  map[string]string    `yaml:"labels"`
 	DependsOn     DependsOn            `yaml:"depends_on"`
 	HealthCheck   ContainerHealthCheck `yaml:"healthcheck"`
+	When          *string              `yaml:"when"`
 	ImageOverride `yaml:",inline"`
 }
 
-// TaskConfig represents the resource boundaries and environment variables for the containers in the task.
-type TaskConfig struct {
-	CPU            *int                 `yaml:"cpu"`
-	Memory         *int                 `yaml:"memory"`
-	Platform       PlatformArgsOrString `yaml:"platform,omitempty"`
-	Count          Count                `yaml:"count"`
-	ExecuteCommand ExecuteCommand       `yaml:"exec"`
-	Variables      map[string]string    `yaml:"variables"`
-	Secrets        map[string]string    `yaml:"secrets"`
-	Storage        Storage              `yaml:"storage"`
+// DeploymentConfig represents the deployment config for an ECS service.
+type DeploymentConfig struct {
+	PreDeploy         PreDeployTask         `yaml:"pre_deploy"`
+	RollbackAlarms    AlarmArgsOrNames      `yaml:"rollback_alarms"`
+	Strategy          *string               `yaml:"strategy"`
+	MinHealthyPercent *int                  `yaml:"min_healthy_percent"`
+	MaxPercent        *int                  `yaml:"max_percent"`
+	BlueGreen         BlueGreenConfigOrBool `yaml:"bluegreen"`
 }
 
-// ContainerPlatform returns the platform for the service.
-func (t *TaskConfig) ContainerPlatform() string {
-	if t.Platform.IsEmpty() {
-		return ""
+// BlueGreenConfigOrBool represents a blue/green deployment of the service managed by CodeDeploy.
+// It is either turned on with a boolean, or configured with a map.
+type BlueGreenConfigOrBool struct {
+	Enable *bool
+	Config BlueGreenConfig
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the BlueGreenConfigOrBool
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (b *BlueGreenConfigOrBool) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.MappingNode {
+		if err := value.Decode(&b.Config); err != nil {
+			return err
+		}
+		// An empty map turns blue/green deployments on with the default configuration.
+		b.Enable = aws.Bool(true)
+		return nil
 	}
-	if t.IsWindows() {
-		return platformString(OSWindows, t.Platform.Arch())
+	if err := value.Decode(&b.Enable); err != nil {
+		return errUnmarshalBlueGreen
 	}
-	return platformString(t.Platform.OS(), t.Platform.Arch())
+	return nil
 }
 
-// IsWindows returns whether or not the service is building with a Windows OS.
-func (t TaskConfig) IsWindows() bool {
-	return isWindowsPlatform(t.Platform)
+// MarshalYAML writes the BlueGreenConfigOrBool back as a map if it has advanced fields, or as a boolean otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (b BlueGreenConfigOrBool) MarshalYAML() (interface{}, error) {
+	if !b.Config.IsEmpty() {
+		return b.Config, nil
+	}
+	if b.Enable != nil {
+		return aws.BoolValue(b.Enable), nil
+	}
+	return nil, nil
 }
 
-// IsARM returns whether or not the service is building with an ARM Arch.
-func (t TaskConfig) IsARM() bool {
-	return IsArmArch(t.Platform.Arch())
+// Enabled returns true if the service is deployed with blue/green instead of an ECS rolling update.
+func (b BlueGreenConfigOrBool) Enabled() bool {
+	if b.Enable != nil && !aws.BoolValue(b.Enable) {
+		return false
+	}
+	return aws.BoolValue(b.Enable) || !b.Config.IsEmpty()
 }
 
-// PublishConfig represents the configurable options for setting up publishers.
-type PublishConfig struct {
-	Topics []Topic `yaml:"topics"`
+// BlueGreenConfig holds the configuration of a blue/green deployment.
+type BlueGreenConfig struct {
+	TerminationWait  *time.Duration `yaml:"termination_wait,omitempty"`   // How long to keep the original tasks after the traffic is rerouted.
+	TestTrafficRoute *uint16        `yaml:"test_traffic_route,omitempty"` // Port of the load balancer listener that routes test traffic to the replacement tasks.
+	Hooks            BlueGreenHooks `yaml:"hooks,omitempty"`
 }
 
-// Topic represents the configurable options for setting up a SNS Topic.
-type Topic struct {
-	Name *string `yaml:"name"`
+// IsEmpty returns true if there is no configuration.
+func (b BlueGreenConfig) IsEmpty() bool {
+	return b.TerminationWait == nil && b.TestTrafficRoute == nil && b.Hooks.IsEmpty()
+}
+
+// DefaultBlueGreenTerminationWait is how long the original tasks of a blue/green deployment keep running
+// after the traffic is rerouted, so that the deployment can be rolled back quickly.
+const DefaultBlueGreenTerminationWait = 5 * time.Minute
+
+// BlueGreenHooks holds the ARNs of the Lambda functions that validate a blue/green deployment
+// at each of its lifecycle events.
+type BlueGreenHooks struct {
+	BeforeInstall         *string `yaml:"before_install,omitempty"`
+	AfterInstall          *string `yaml:"after_install,omitempty"`
+	AfterAllowTestTraffic *string `yaml:"after_allow_test_traffic,omitempty"`
+	BeforeAllowTraffic    *string `yaml:"before_allow_traffic,omitempty"`
+	AfterAllowTraffic     *string `yaml:"after_allow_traffic,omitempty"`
+}
+
+// IsEmpty returns true if there are no hooks.
+func (h BlueGreenHooks) IsEmpty() bool {
+	return h.BeforeInstall == nil && h.AfterInstall == nil && h.AfterAllowTestTraffic == nil &&
+		h.BeforeAllowTraffic == nil && h.AfterAllowTraffic == nil
+}
+
+// FunctionARNs returns the ARNs of the hooks keyed by the name of their lifecycle event.
+func (h BlueGreenHooks) FunctionARNs() map[string]string {
+	events := map[string]*string{
+		"BeforeInstall":         h.BeforeInstall,
+		"AfterInstall":          h.AfterInstall,
+		"AfterAllowTestTraffic": h.AfterAllowTestTraffic,
+		"BeforeAllowTraffic":    h.BeforeAllowTraffic,
+		"AfterAllowTraffic":     h.AfterAllowTraffic,
+	}
+	arns := make(map[string]string)
+	for event, arn := range events {
+		if arn != nil {
+			arns[event] = aws.StringValue(arn)
+		}
+	}
+	return arns
+}
+
+// Strategies to replace the tasks of a service during a deployment.
+const (
+	DeploymentStrategyRolling  = "rolling"  // Start new tasks before stopping the old ones.
+	DeploymentStrategyRecreate = "recreate" // Stop the old tasks before starting new ones.
+)
+
+var deploymentStrategies = []string{DeploymentStrategyRolling, DeploymentStrategyRecreate}
+
+// Default bounds of the number of running tasks during a deployment, in percent of the desired count.
+const (
+	defaultMinHealthyPercent = 100
+	defaultMaxPercent        = 200
+)
+
+// IsRecreate returns true if the old tasks of the service are stopped before new ones are started.
+// The strategy defaults to "rolling" when it's not set.
+func (d DeploymentConfig) IsRecreate() bool {
+	return aws.StringValue(d.Strategy) == DeploymentStrategyRecreate
+}
+
+// RecreatedTaskCount returns the desired number of tasks of a service that uses the "recreate" deployment strategy.
+// It returns false if the workload isn't an ECS service, or if it uses the "rolling" strategy.
+func RecreatedTaskCount(wl WorkloadManifest) (int, bool) {
+	var (
+		count  Count
+		deploy DeploymentConfig
+	)
+	switch t := wl.(type) {
+	case *LoadBalancedWebService:
+		count, deploy = t.Count, t.DeployConfig
+	case *BackendService:
+		count, deploy = t.Count, t.DeployConfig
+	case *WorkerService:
+		count, deploy = t.Count, t.DeployConfig
+	default:
+		return 0, false
+	}
+	if !deploy.IsRecreate() {
+		return 0, false
+	}
+	desired, err := count.Desired()
+	if err != nil {
+		return 0, false
+	}
+	return aws.IntValue(desired), true
+}
+
+func (d DeploymentConfig) minHealthyPercent() int {
+	if d.MinHealthyPercent == nil {
+		return defaultMinHealthyPercent
+	}
+	return aws.IntValue(d.MinHealthyPercent)
+}
+
+func (d DeploymentConfig) maxPercent() int {
+	if d.MaxPercent == nil {
+		return defaultMaxPercent
+	}
+	return aws.IntValue(d.MaxPercent)
+}
+
+// AlarmArgsOrNames represents the CloudWatch alarms that roll back a deployment when they go into the ALARM state.
+// It is either the names of existing alarms, or the thresholds of alarms for Copilot to create.
+type AlarmArgsOrNames struct {
+	AlarmNames []string
+	AlarmArgs  AlarmArgs
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the AlarmArgsOrNames
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (a *AlarmArgsOrNames) UnmarshalYAML(value *yaml.Node) error {
+	if err := value.Decode(&a.AlarmArgs); err != nil {
+		switch err.(type) {
+		case *yaml.TypeError:
+			break
+		default:
+			return err
+		}
+	}
+	if !a.AlarmArgs.IsEmpty() {
+		// Unmarshaled successfully to a.AlarmArgs, unset a.AlarmNames, and return.
+		a.AlarmNames = nil
+		return nil
+	}
+	if err := value.Decode(&a.AlarmNames); err != nil {
+		return errUnmarshalAlarms
+	}
+	return nil
+}
+
+// MarshalYAML writes the AlarmArgsOrNames back as a map if it configures alarms, or as a list of existing alarm names otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (a AlarmArgsOrNames) MarshalYAML() (interface{}, error) {
+	if !a.AlarmArgs.IsEmpty() {
+		return a.AlarmArgs, nil
+	}
+	if a.AlarmNames != nil {
+		return a.AlarmNames, nil
+	}
+	return nil, nil
+}
+
+// IsEmpty returns empty if the struct has all zero members.
+func (a AlarmArgsOrNames) IsEmpty() bool {
+	return a.AlarmNames == nil && a.AlarmArgs.IsEmpty()
+}
+
+// AlarmArgs represents the utilization thresholds, in percent, of the alarms that Copilot creates
+// to roll back a deployment.
+type AlarmArgs struct {
+	CPUUtilization    *float64 `yaml:"cpu_utilization"`
+	MemoryUtilization *float64 `yaml:"memory_utilization"`
+}
+
+// IsEmpty returns empty if the struct has all zero members.
+func (a AlarmArgs) IsEmpty() bool {
+	return a.CPUUtilization == nil && a.MemoryUtilization == nil
+}
+
+// PreDeployTask represents a one-off task, such as a database migration, that must run to completion
+// before the service is updated.
+type PreDeployTask struct {
+	Image   *string         `yaml:"image"`
+	Command CommandOverride `yaml:"command"`
+}
+
+// IsEmpty returns empty if the struct has all zero members.
+func (t PreDeployTask) IsEmpty() bool {
+	return t.Image == nil && t.Command.String == nil && t.Command.StringSlice == nil
+}
+
+// InitContainerName is the name of the container that runs to completion before the main container starts.
+const InitContainerName = "init"
+
+// InitContainer represents a container that runs to completion before the main container of the task starts,
+// such as a database schema setup built from a different Dockerfile target than the main container.
+type InitContainer struct {
+	Image     Image             `yaml:"image"`
+	Command   CommandOverride   `yaml:"command"`
+	Variables map[string]string `yaml:"variables"`
+}
+
+// IsEmpty returns empty if the struct has all zero members.
+func (c InitContainer) IsEmpty() bool {
+	return c.Image.Build.isEmpty() && c.Image.Location == nil && c.Image.Credentials == nil &&
+		c.Image.DockerLabels == nil && c.Image.DependsOn == nil &&
+		c.Command.String == nil && c.Command.StringSlice == nil && c.Variables == nil
+}
+
+// BuildRequired returns if the init container image should be built from a local Dockerfile.
+func (c InitContainer) BuildRequired() (bool, error) {
+	if c.IsEmpty() {
+		return false, nil
+	}
+	return requiresBuild(c.Image)
+}
+
+// Readiness represents an endpoint that must return the expected status code before a deployment is considered ready.
+type Readiness struct {
+	URL    *string `yaml:"url"`
+	Status *int    `yaml:"status"`
+}
+
+// IsEmpty returns empty if the struct has all zero members.
+func (r Readiness) IsEmpty() bool {
+	return r.URL == nil && r.Status == nil
+}
+
+// TaskConfig represents the resource boundaries and environment variables for the containers in the task.
+type TaskConfig struct {
+	CPU            *int                 `yaml:"cpu"`
+	Memory         *int                 `yaml:"memory"`
+	Platform       PlatformArgsOrString `yaml:"platform,omitempty"`
+	Count          Count                `yaml:"count"`
+	ExecuteCommand ExecuteCommand       `yaml:"exec"`
+	Variables      Variables            `yaml:"variables"`
+	Secrets        map[string]Secret    `yaml:"secrets"`
+	Storage        Storage              `yaml:"storage"`
+	ContainerName  *string              `yaml:"container_name"`
+	EnvVarPrefix   *string              `yaml:"env_var_prefix"`
+}
+
+// MainContainerName returns the name of the main container of the workload.
+// The container is named after the workload unless "container_name" is set.
+func (t TaskConfig) MainContainerName(wkldName string) string {
+	if t.ContainerName != nil {
+		return aws.StringValue(t.ContainerName)
+	}
+	return wkldName
+}
+
+// InjectedEnvVarPrefix returns the prefix of the environment variables that Copilot injects in the containers of the workload.
+// The variables are prefixed with "COPILOT_" unless "env_var_prefix" is set.
+func (t TaskConfig) InjectedEnvVarPrefix() string {
+	if t.EnvVarPrefix != nil {
+		return aws.StringValue(t.EnvVarPrefix)
+	}
+	return defaultEnvVarPrefix
+}
+
+// Secret represents an identifier for sensitive data. It is either the name or ARN of an SSM parameter
+// or the ARN of a Secrets Manager secret, or the configuration profile of an AWS AppConfig application.
+type Secret struct {
+	From      *string
+	AppConfig *AppConfigSecret
+}
+
+// AppConfigSecret represents an AWS AppConfig configuration profile that's fetched through the AppConfig agent.
+type AppConfigSecret struct {
+	Application *string `yaml:"application"`
+	Environment *string `yaml:"environment"`
+	Profile     *string `yaml:"profile"`
+}
+
+type secretConfig struct {
+	AppConfig *AppConfigSecret `yaml:"appconfig"`
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the Secret
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (s *Secret) UnmarshalYAML(value *yaml.Node) error {
+	var cfg secretConfig
+	if err := value.Decode(&cfg); err != nil {
+		switch err.(type) {
+		case *yaml.TypeError:
+			break
+		default:
+			return err
+		}
+	}
+
+	if cfg.AppConfig != nil {
+		s.AppConfig = cfg.AppConfig
+		return nil
+	}
+
+	if err := value.Decode(&s.From); err != nil {
+		return errUnmarshalSecret
+	}
+	return nil
+}
+
+// MarshalYAML writes the Secret back as a string if it's an SSM parameter or Secrets Manager secret, or as a map otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (s Secret) MarshalYAML() (interface{}, error) {
+	if s.AppConfig != nil {
+		return secretConfig{
+			AppConfig: s.AppConfig,
+		}, nil
+	}
+	if s.From != nil {
+		return aws.StringValue(s.From), nil
+	}
+	return nil, nil
+}
+
+// LoadVariables reads the variables file of the task, if any, and merges its values under the inline variables.
+func (t *TaskConfig) LoadVariables(read func(path string) ([]byte, error)) error {
+	return t.Variables.load(read)
+}
+
+// Variables represents the environment variables of a container. Besides inline KEY: value pairs,
+// variables can be loaded in bulk from a file of KEY=VALUE lines with the "from_file" key.
+// A variable can also be gated by a flag with the `KEY: {value: v, when: flag}` form.
+type Variables struct {
+	FromFile *string
+	Values   map[string]string
+	FromSSM  map[string]string // Name of the SSM parameter that holds each parameter-backed variable.
+	Imports  map[string]string // Name of the CloudFormation export that holds each imported variable.
+	When     map[string]string // Name of the flag that gates each conditional variable.
+}
+
+// Variable represents a single entry under "variables". It is either a scalar, whose value is kept as written
+// so that `true` and `8080` become "true" and "8080", or a map with exactly one of "value", "from_ssm" or "import".
+type Variable struct {
+	Value   *string `yaml:"value,omitempty"`
+	FromSSM *string `yaml:"from_ssm,omitempty"`
+	Import  *string `yaml:"import,omitempty"`
+	When    *string `yaml:"when,omitempty"`
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the Variable
+// struct, allowing it to accept scalars of any type as well as the map form.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (v *Variable) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.AliasNode {
+		value = value.Alias
+	}
+	switch value.Kind {
+	case yaml.ScalarNode:
+		if value.ShortTag() == "!!null" {
+			v.Value = aws.String("")
+			return nil
+		}
+		v.Value = aws.String(value.Value)
+		return nil
+	case yaml.MappingNode:
+		type variable Variable
+		var cfg variable
+		if err := value.Decode(&cfg); err != nil {
+			return err
+		}
+		set := 0
+		for _, field := range []*string{cfg.Value, cfg.FromSSM, cfg.Import} {
+			if field != nil {
+				set++
+			}
+		}
+		if set != 1 {
+			return errUnmarshalVariable
+		}
+		*v = Variable(cfg)
+		return nil
+	}
+	return errUnmarshalVariable
+}
+
+// MarshalYAML writes the Variable back as a plain string, or as a map if it is parameter-backed, imported or gated by a flag.
+// This method implements the yaml.Marshaler (v3) interface.
+func (v Variable) MarshalYAML() (interface{}, error) {
+	if v.FromSSM == nil && v.Import == nil && v.When == nil {
+		return aws.StringValue(v.Value), nil
+	}
+	type variable Variable
+	return variable(v), nil
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the Variables
+// struct, allowing the "from_file" key to be specified alongside the inline variables.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (v *Variables) UnmarshalYAML(value *yaml.Node) error {
+	var vars map[string]Variable
+	if err := value.Decode(&vars); err != nil {
+		return err
+	}
+	if file, ok := vars[variablesFromFileKey]; ok && (file.FromSSM != nil || file.Import != nil || file.When != nil) {
+		return errUnmarshalFromFile
+	}
+	values := make(map[string]string)
+	for key, val := range vars {
+		switch {
+		case val.FromSSM != nil:
+			if v.FromSSM == nil {
+				v.FromSSM = make(map[string]string)
+			}
+			v.FromSSM[key] = aws.StringValue(val.FromSSM)
+		case val.Import != nil:
+			if v.Imports == nil {
+				v.Imports = make(map[string]string)
+			}
+			v.Imports[key] = aws.StringValue(val.Import)
+		default:
+			values[key] = aws.StringValue(val.Value)
+		}
+		if val.When != nil {
+			if v.When == nil {
+				v.When = make(map[string]string)
+			}
+			v.When[key] = aws.StringValue(val.When)
+		}
+	}
+	if path, ok := values[variablesFromFileKey]; ok {
+		v.FromFile = aws.String(path)
+		delete(values, variablesFromFileKey)
+	}
+	if len(values) != 0 {
+		v.Values = values
+	}
+	return nil
+}
+
+// MarshalYAML writes the Variables back as a map of KEY: value pairs, where the variables gated by a flag
+// are written in the `KEY: {value: v, when: flag}` form and the parameter-backed or imported variables
+// in the `KEY: {from_ssm: name}` or `KEY: {import: name}` form.
+// This method implements the yaml.Marshaler (v3) interface.
+func (v Variables) MarshalYAML() (interface{}, error) {
+	if v.FromFile == nil && len(v.Values) == 0 && len(v.FromSSM) == 0 && len(v.Imports) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]interface{}, len(v.Values)+len(v.FromSSM)+len(v.Imports)+1)
+	if v.FromFile != nil {
+		out[variablesFromFileKey] = aws.StringValue(v.FromFile)
+	}
+	when := func(key string) *string {
+		if flag, ok := v.When[key]; ok {
+			return aws.String(flag)
+		}
+		return nil
+	}
+	for key, val := range v.Values {
+		out[key] = Variable{Value: aws.String(val), When: when(key)}
+	}
+	for key, name := range v.FromSSM {
+		out[key] = Variable{FromSSM: aws.String(name), When: when(key)}
+	}
+	for key, name := range v.Imports {
+		out[key] = Variable{Import: aws.String(name), When: when(key)}
+	}
+	return out, nil
+}
+
+// has returns true if a variable named name is defined with a value, a parameter, or an import.
+func (v Variables) has(name string) bool {
+	_, inValues := v.Values[name]
+	_, inSSM := v.FromSSM[name]
+	_, inImports := v.Imports[name]
+	return inValues || inSSM || inImports
+}
+
+// names returns the sorted names of the variables defined with a value, a parameter, or an import.
+func (v Variables) names() []string {
+	var names []string
+	for _, vars := range []map[string]string{v.Values, v.FromSSM, v.Imports} {
+		for name := range vars {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// load reads the variables file with read, if one is specified, and merges its values with the inline variables.
+// Inline variables take precedence over the ones in the file.
+func (v *Variables) load(read func(path string) ([]byte, error)) error {
+	if v.FromFile == nil {
+		return nil
+	}
+	path := aws.StringValue(v.FromFile)
+	content, err := read(path)
+	if err != nil {
+		return fmt.Errorf("read variables file %s: %w", path, err)
+	}
+	values, err := parseVariablesFile(string(content))
+	if err != nil {
+		return fmt.Errorf("parse variables file %s: %w", path, err)
+	}
+	for key, val := range v.Values {
+		values[key] = val
+	}
+	for key := range v.FromSSM {
+		delete(values, key)
+	}
+	for key := range v.Imports {
+		delete(values, key)
+	}
+	v.Values = values
+	v.FromFile = nil
+	return nil
+}
+
+// LoadWorkloadVariables reads the variables file of a workload manifest with read, if the manifest specifies one,
+// and merges its values under the inline variables.
+func LoadWorkloadVariables(mft interface{}, read func(path string) ([]byte, error)) error {
+	type variablesLoader interface {
+		LoadVariables(read func(path string) ([]byte, error)) error
+	}
+	loader, ok := mft.(variablesLoader)
+	if !ok {
+		return nil
+	}
+	return loader.LoadVariables(read)
+}
+
+// Flags are named switches, set per environment, that turn on the manifest sections with a matching "when" condition.
+type Flags map[string]bool
+
+func (f Flags) defines(name string) bool {
+	_, ok := f[name]
+	return ok
+}
+
+// applyFlags returns the sidecars and variables without the ones whose "when" condition names a flag that is turned off.
+// Conditions on undefined flags are kept so that they are reported on validation.
+func applyFlags(flags Flags, sidecars map[string]*SidecarConfig, vars Variables) (map[string]*SidecarConfig, Variables) {
+	isOff := func(flag *string) bool {
+		if flag == nil {
+			return false
+		}
+		on, ok := flags[aws.StringValue(flag)]
+		return ok && !on
+	}
+	var filteredSidecars map[string]*SidecarConfig
+	if sidecars != nil {
+		filteredSidecars = make(map[string]*SidecarConfig)
+		for name, sidecar := range sidecars {
+			if sidecar != nil && isOff(sidecar.When) {
+				continue
+			}
+			filteredSidecars[name] = sidecar
+		}
+	}
+	if len(vars.When) == 0 {
+		return filteredSidecars, vars
+	}
+	filteredVars := Variables{
+		FromFile: vars.FromFile,
+		When:     make(map[string]string),
+	}
+	filter := func(in map[string]string) map[string]string {
+		if in == nil {
+			return nil
+		}
+		out := make(map[string]string)
+		for key, val := range in {
+			flag, ok := vars.When[key]
+			if ok && isOff(aws.String(flag)) {
+				continue
+			}
+			out[key] = val
+			if ok {
+				filteredVars.When[key] = flag
+			}
+		}
+		return out
+	}
+	filteredVars.Values = filter(vars.Values)
+	filteredVars.FromSSM = filter(vars.FromSSM)
+	filteredVars.Imports = filter(vars.Imports)
+	return filteredSidecars, filteredVars
+}
+
+// parseVariablesFile parses KEY=VALUE lines into a map, skipping blank lines and lines that start with "#".
+// Like in a .env file, lines can start with "export", values can be wrapped in single or double quotes,
+// and unquoted values end at a " #" comment.
+func parseVariablesFile(content string) (map[string]string, error) {
+	values := make(map[string]string)
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		key := strings.TrimSpace(strings.TrimPrefix(parts[0], "export "))
+		if len(parts) != 2 || key == "" {
+			return nil, fmt.Errorf(`line %d: "%s" must be of the form KEY=VALUE`, i+1, line)
+		}
+		val, err := parseVariablesFileValue(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf(`line %d: %w`, i+1, err)
+		}
+		values[key] = val
+	}
+	return values, nil
+}
+
+// parseVariablesFileValue returns the value of a variable without its quotes or trailing comment.
+// Double-quoted values can contain escape sequences such as \n or \", while single-quoted values are taken literally.
+func parseVariablesFileValue(val string) (string, error) {
+	switch {
+	case strings.HasPrefix(val, `"`):
+		end := closingQuote(val)
+		if end == -1 {
+			return "", fmt.Errorf(`value %s is missing a closing double quote`, val)
+		}
+		if rest := strings.TrimSpace(val[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf(`value %s has characters after its closing double quote`, val)
+		}
+		unquoted, err := strconv.Unquote(val[:end+1])
+		if err != nil {
+			return "", fmt.Errorf(`value %s is not a valid double-quoted string`, val)
+		}
+		return unquoted, nil
+	case strings.HasPrefix(val, "'"):
+		end := strings.Index(val[1:], "'")
+		if end == -1 {
+			return "", fmt.Errorf(`value %s is missing a closing single quote`, val)
+		}
+		if rest := strings.TrimSpace(val[end+2:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf(`value %s has characters after its closing single quote`, val)
+		}
+		return val[1 : end+1], nil
+	}
+	if idx := strings.Index(val, " #"); idx != -1 {
+		val = strings.TrimSpace(val[:idx])
+	}
+	return val, nil
+}
+
+// closingQuote returns the index of the double quote that closes the string opened at the start of val, or -1.
+func closingQuote(val string) int {
+	for i := 1; i < len(val); i++ {
+		switch val[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// ContainerPlatform returns the platform for the service.
+func (t *TaskConfig) ContainerPlatform() string {
+	os, arch := t.ContainerOSArch()
+	if os == "" {
+		return ""
+	}
+	if t.IsWindows() {
+		return platformString(OSWindows, arch)
+	}
+	return platformString(os, arch)
+}
+
+// ContainerOSArch returns the normalized OS family and architecture of the task, or empty strings if no platform is set.
+func (t TaskConfig) ContainerOSArch() (os, arch string) {
+	if t.Platform.IsEmpty() {
+		return "", ""
+	}
+	return t.Platform.OS(), t.Platform.Arch()
+}
+
+// IsWindows returns whether or not the service is building with a Windows OS.
+func (t TaskConfig) IsWindows() bool {
+	return isWindowsPlatform(t.Platform)
+}
+
+// IsARM returns whether or not the service is building with an ARM Arch.
+func (t TaskConfig) IsARM() bool {
+	return IsArmArch(t.Platform.Arch())
+}
+
+// PublishConfig represents the configurable options for setting up publishers.
+type PublishConfig struct {
+	Topics []Topic `yaml:"topics"`
+}
+
+// Topic represents the configurable options for setting up a SNS Topic.
+type Topic struct {
+	Name *string                      `yaml:"name"`
+	FIFO FIFOTopicAdvanceConfigOrBool `yaml:"fifo"`
+}
+
+// FIFO topic deduplication scopes and throughput limits.
+const (
+	FIFODeduplicationScopeMessageGroup = "messageGroup"
+	FIFODeduplicationScopeTopic        = "topic"
+
+	FIFOThroughputLimitPerMessageGroupID = "perMessageGroupId"
+	FIFOThroughputLimitPerTopic          = "perTopic"
+)
+
+var (
+	fifoDeduplicationScopes = []string{FIFODeduplicationScopeMessageGroup, FIFODeduplicationScopeTopic}
+	fifoThroughputLimits    = []string{FIFOThroughputLimitPerMessageGroupID, FIFOThroughputLimitPerTopic}
+)
+
+// FIFOTopicAdvanceConfigOrBool contains custom unmarshaling logic for the `fifo` field in the manifest.
+type FIFOTopicAdvanceConfigOrBool struct {
+	Enable   *bool
+	Advanced FIFOTopicAdvanceConfig
+}
+
+// IsEmpty returns empty if the struct has all zero members.
+func (f *FIFOTopicAdvanceConfigOrBool) IsEmpty() bool {
+	return f.Enable == nil && f.Advanced.IsEmpty()
+}
+
+// IsEnabled returns whether the topic is a FIFO topic.
+func (f *FIFOTopicAdvanceConfigOrBool) IsEnabled() bool {
+	return aws.BoolValue(f.Enable) || !f.Advanced.IsEmpty()
+}
+
+// UnmarshalYAML implements the yaml(v3) interface. It allows FIFO to be specified as a
+// bool or a struct alternately.
+func (f *FIFOTopicAdvanceConfigOrBool) UnmarshalYAML(value *yaml.Node) error {
+	if err := value.Decode(&f.Advanced); err != nil {
+		switch err.(type) {
+		case *yaml.TypeError:
+			break
+		default:
+			return err
+		}
+	}
+	if !f.Advanced.IsEmpty() {
+		// Unmarshaled successfully to f.Advanced, unset f.Enable, and return.
+		f.Enable = nil
+		return nil
+	}
+	if err := value.Decode(&f.Enable); err != nil {
+		return errUnmarshalFIFO
+	}
+	return nil
+}
+
+// MarshalYAML writes the FIFOTopicAdvanceConfigOrBool back as a map if it has advanced fields, or as a boolean otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (f FIFOTopicAdvanceConfigOrBool) MarshalYAML() (interface{}, error) {
+	if !f.Advanced.IsEmpty() {
+		return f.Advanced, nil
+	}
+	if f.Enable != nil {
+		return aws.BoolValue(f.Enable), nil
+	}
+	return nil, nil
+}
+
+// FIFOTopicAdvanceConfig represents the configurable options for a FIFO SNS Topic.
+type FIFOTopicAdvanceConfig struct {
+	ContentBasedDeduplication *bool   `yaml:"content_based_deduplication"`
+	DeduplicationScope        *string `yaml:"deduplication_scope"`
+	ThroughputLimit           *string `yaml:"throughput_limit"`
+}
+
+// IsEmpty returns empty if the struct has all zero members.
+func (f *FIFOTopicAdvanceConfig) IsEmpty() bool {
+	return f.ContentBasedDeduplication == nil && f.DeduplicationScope == nil && f.ThroughputLimit == nil
 }
 
 // NetworkConfig represents options for network connection to AWS resources within a VPC.
 type NetworkConfig struct {
-	VPC vpcConfig `yaml:"vpc"`
+	VPC     vpcConfig      `yaml:"vpc"`
+	Connect ServiceConnect `yaml:"connect"`
 }
 
 // IsEmpty returns empty if the struct has all zero members.
 func (c *NetworkConfig) IsEmpty() bool {
-	return c.VPC.isEmpty()
+	return c.VPC.isEmpty() && c.Connect.IsEmpty()
+}
+
+// ServiceConnect represents the ECS Service Connect configuration of a service.
+type ServiceConnect struct {
+	Enabled *bool                `yaml:"enabled"`
+	Alias   *string              `yaml:"alias"`
+	Ports   []ServiceConnectPort `yaml:"ports"`
+}
+
+// IsEmpty returns empty if the struct has all zero members.
+func (s *ServiceConnect) IsEmpty() bool {
+	return s.Enabled == nil && s.Alias == nil && len(s.Ports) == 0
 }
 
-// UnmarshalYAML ensures that a NetworkConfig always defaults to public subnets.
-// If the user specified a placement that's not valid then throw an error.
+// IsEnabled returns true if Service Connect is configured, unless it is explicitly disabled.
+func (s *ServiceConnect) IsEnabled() bool {
+	if s.Enabled != nil {
+		return aws.BoolValue(s.Enabled)
+	}
+	return s.Alias != nil || len(s.Ports) != 0
+}
+
+// ServiceConnectPort represents a named port of the containers that other services can reach through Service Connect.
+type ServiceConnectPort struct {
+	Name *string `yaml:"name"`
+	Port *uint16 `yaml:"port"`
+}
+
+// UnmarshalYAML throws an error if the user specified an IP family that's not valid.
+// The placement and the IP family are left empty if they're not specified, so that environment overrides
+// don't reset them. Tasks default to the public subnets and IPv4 when the stack is rendered.
 func (c *NetworkConfig) UnmarshalYAML(value *yaml.Node) error {
 	type networkWithDefaults NetworkConfig
-	publicPlacement := Placement(PublicSubnetPlacement)
-	defaultVPCConf := vpcConfig{
-		Placement: &publicPlacement,
-	}
-	conf := networkWithDefaults{
-		VPC: defaultVPCConf,
-	}
+	var conf networkWithDefaults
 	if err := value.Decode(&conf); err != nil {
 		return err
 	}
-	if conf.VPC.isEmpty() { // If after unmarshaling the user did not specify VPC configuration then reset it to public.
-		conf.VPC = defaultVPCConf
+	if conf.VPC.IPFamily != nil && !contains(strings.ToLower(aws.StringValue(conf.VPC.IPFamily)), ipFamilies) {
+		return fmt.Errorf(`"ip_family" value "%s" must be one of %s`, aws.StringValue(conf.VPC.IPFamily), english.WordSeries(ipFamilies, "or"))
 	}
 	*c = NetworkConfig(conf)
 	return nil
@@ -581,11 +2113,123 @@ type Placement string
 // vpcConfig represents the security groups and subnets attached to a task.
 type vpcConfig struct {
 	*Placement     `yaml:"placement"`
-	SecurityGroups []string `yaml:"security_groups"`
+	Subnets        SubnetListOrArgs `yaml:"subnets"`
+	SecurityGroups []SecurityGroup  `yaml:"security_groups"`
+	// DenyDefaultSecurityGroup removes the security group of the environment from the task, leaving only SecurityGroups.
+	DenyDefaultSecurityGroup *bool   `yaml:"deny_default_security_group"`
+	IPFamily                 *string `yaml:"ip_family"`
 }
 
 func (c *vpcConfig) isEmpty() bool {
-	return c.Placement == nil && c.SecurityGroups == nil
+	return c.Placement == nil && c.Subnets.IsEmpty() && c.SecurityGroups == nil && c.DenyDefaultSecurityGroup == nil && c.IPFamily == nil
+}
+
+// SubnetListOrArgs represents the subnets to place tasks in instead of the public or private subnets of the environment.
+// It is either a list of subnet IDs, or the tags to select the subnets of the environment VPC by.
+type SubnetListOrArgs struct {
+	IDs []string
+	SubnetArgs
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the SubnetListOrArgs
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (s *SubnetListOrArgs) UnmarshalYAML(value *yaml.Node) error {
+	if err := value.Decode(&s.SubnetArgs); err != nil {
+		switch err.(type) {
+		case *yaml.TypeError:
+			break
+		default:
+			return err
+		}
+	}
+	if !s.SubnetArgs.IsEmpty() {
+		// Unmarshaled successfully to s.SubnetArgs, unset s.IDs, and return.
+		s.IDs = nil
+		return nil
+	}
+	if err := value.Decode(&s.IDs); err != nil {
+		return errUnmarshalSubnets
+	}
+	return nil
+}
+
+// MarshalYAML writes the SubnetListOrArgs back as a map if it selects subnets by tags, or as a list of subnet IDs otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (s SubnetListOrArgs) MarshalYAML() (interface{}, error) {
+	if !s.SubnetArgs.IsEmpty() {
+		return s.SubnetArgs, nil
+	}
+	if s.IDs != nil {
+		return s.IDs, nil
+	}
+	return nil, nil
+}
+
+// IsEmpty returns empty if the struct has all zero members.
+func (s SubnetListOrArgs) IsEmpty() bool {
+	return s.IDs == nil && s.SubnetArgs.IsEmpty()
+}
+
+// SubnetArgs represents the tags that the subnets of the environment VPC are selected by.
+// A subnet is selected if it has every tag with the given value.
+type SubnetArgs struct {
+	FromTags map[string]string `yaml:"from_tags"`
+}
+
+// IsEmpty returns empty if the struct has all zero members.
+func (s SubnetArgs) IsEmpty() bool {
+	return len(s.FromTags) == 0
+}
+
+// SecurityGroup represents an additional security group attached to a task. It is either the ID
+// of a security group, or the name of a CloudFormation export that holds the ID.
+type SecurityGroup struct {
+	ID      *string
+	FromCFN *string
+}
+
+type securityGroupConfig struct {
+	FromCFN *string `yaml:"from_cfn"`
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the SecurityGroup
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (sg *SecurityGroup) UnmarshalYAML(value *yaml.Node) error {
+	var cfg securityGroupConfig
+	if err := value.Decode(&cfg); err != nil {
+		switch err.(type) {
+		case *yaml.TypeError:
+			break
+		default:
+			return err
+		}
+	}
+
+	if cfg.FromCFN != nil {
+		sg.FromCFN = cfg.FromCFN
+		return nil
+	}
+
+	if err := value.Decode(&sg.ID); err != nil {
+		return errUnmarshalSecGroup
+	}
+	return nil
+}
+
+// MarshalYAML writes the SecurityGroup back as a string if it's an ID, or as a "from_cfn" map otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (sg SecurityGroup) MarshalYAML() (interface{}, error) {
+	if sg.FromCFN != nil {
+		return securityGroupConfig{
+			FromCFN: sg.FromCFN,
+		}, nil
+	}
+	if sg.ID != nil {
+		return aws.StringValue(sg.ID), nil
+	}
+	return nil, nil
 }
 
 // UnmarshalWorkload deserializes the YAML input stream into a workload manifest object.
@@ -622,14 +2266,48 @@ func UnmarshalWorkload(in []byte) (WorkloadManifest, error) {
 	return m, nil
 }
 
+// ValidatePlacementSpecified returns an error if the workload manifest doesn't specify the placement of its tasks
+// with "network.vpc.placement" or "network.vpc.subnets", instead of defaulting to public subnets.
+// The placement of environment overrides is optional as it's merged with the placement of the manifest.
+// Request-Driven Web Services have no placement, so they are not checked.
+func ValidatePlacementSpecified(in []byte) error {
+	var mft struct {
+		Workload `yaml:",inline"`
+		Network  struct {
+			VPC struct {
+				Placement *Placement       `yaml:"placement"`
+				Subnets   SubnetListOrArgs `yaml:"subnets"`
+			} `yaml:"vpc"`
+		} `yaml:"network"`
+	}
+	if err := yaml.Unmarshal(in, &mft); err != nil {
+		return fmt.Errorf("unmarshal to workload manifest: %w", err)
+	}
+	if aws.StringValue(mft.Type) == RequestDrivenWebServiceType {
+		return nil
+	}
+	if mft.Network.VPC.Placement == nil && mft.Network.VPC.Subnets.IsEmpty() {
+		return errPlacementNotSpecified
+	}
+	return nil
+}
+
 // ContainerHealthCheck holds the configuration to determine if the service container is healthy.
 // See https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-properties-ecs-taskdefinition-healthcheck.html
 type ContainerHealthCheck struct {
-	Command     []string       `yaml:"command"`
-	Interval    *time.Duration `yaml:"interval"`
-	Retries     *int           `yaml:"retries"`
-	Timeout     *time.Duration `yaml:"timeout"`
-	StartPeriod *time.Duration `yaml:"start_period"`
+	Command     []string         `yaml:"command"`
+	GRPC        *GRPCHealthCheck `yaml:"grpc"` // Mutually exclusive with Command.
+	Interval    *time.Duration   `yaml:"interval"`
+	Retries     *int             `yaml:"retries"`
+	Timeout     *time.Duration   `yaml:"timeout"`
+	StartPeriod *time.Duration   `yaml:"start_period"`
+}
+
+// GRPCHealthCheck holds the configuration to probe a container that implements the gRPC health checking protocol.
+// See https://github.com/grpc/grpc/blob/master/doc/health-checking.md
+type GRPCHealthCheck struct {
+	Port    *uint16 `yaml:"port"`
+	Service *string `yaml:"service"`
 }
 
 // NewDefaultContainerHealthCheck returns container health check configuration
@@ -646,12 +2324,19 @@ func NewDefaultContainerHealthCheck() *ContainerHealthCheck {
 
 // IsEmpty checks if the health check is empty.
 func (hc ContainerHealthCheck) IsEmpty() bool {
-	return hc.Command == nil && hc.Interval == nil && hc.Retries == nil && hc.Timeout == nil && hc.StartPeriod == nil
+	return hc.Command == nil && hc.GRPC == nil && hc.Interval == nil && hc.Retries == nil && hc.Timeout == nil && hc.StartPeriod == nil
+}
+
+// Defaulted returns a copy of the healthcheck with the fields that are not set populated from
+// NewDefaultContainerHealthCheck, so that every field other than "grpc" is non-nil.
+func (hc ContainerHealthCheck) Defaulted() ContainerHealthCheck {
+	hc.ApplyIfNotSet(NewDefaultContainerHealthCheck())
+	return hc
 }
 
 // ApplyIfNotSet changes the healthcheck's fields only if they were not set and the other healthcheck has them set.
 func (hc *ContainerHealthCheck) ApplyIfNotSet(other *ContainerHealthCheck) {
-	if hc.Command == nil && other.Command != nil {
+	if hc.Command == nil && hc.GRPC == nil && other.Command != nil {
 		hc.Command = other.Command
 	}
 	if hc.Interval == nil && other.Interval != nil {
@@ -698,22 +2383,64 @@ func (p *PlatformArgsOrString) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// MarshalYAML writes the PlatformArgsOrString back as a map of its OS family and architecture, or as an "[os]/[arch]" string otherwise.
+// This method implements the yaml.Marshaler (v3) interface.
+func (p PlatformArgsOrString) MarshalYAML() (interface{}, error) {
+	if !p.PlatformArgs.isEmpty() {
+		return p.PlatformArgs, nil
+	}
+	if p.PlatformString != nil {
+		return string(*p.PlatformString), nil
+	}
+	return nil, nil
+}
+
+// IsValid returns nil if the platform is empty or one of the supported OS/arch pairs.
+// Otherwise, it returns an error that names the offending value.
+func (p PlatformArgsOrString) IsValid() error {
+	if p.IsEmpty() {
+		return nil
+	}
+	if !p.PlatformArgs.isEmpty() {
+		return p.PlatformArgs.Validate()
+	}
+	return p.PlatformString.Validate()
+}
+
 // OS returns the operating system family.
 func (p *PlatformArgsOrString) OS() string {
 	if p := aws.StringValue((*string)(p.PlatformString)); p != "" {
 		args := strings.Split(p, "/")
-		return strings.ToLower(args[0])
+		return normalizeOS(args[0])
 	}
-	return strings.ToLower(aws.StringValue(p.PlatformArgs.OSFamily))
+	return normalizeOS(aws.StringValue(p.PlatformArgs.OSFamily))
 }
 
 // Arch returns the architecture of PlatformArgsOrString.
 func (p *PlatformArgsOrString) Arch() string {
 	if p := aws.StringValue((*string)(p.PlatformString)); p != "" {
 		args := strings.Split(p, "/")
-		return strings.ToLower(args[1])
+		return normalizeArch(args[1])
+	}
+	return normalizeArch(aws.StringValue(p.PlatformArgs.Arch))
+}
+
+// normalizeOS returns the lowercase OS family, replacing aliases with the name Copilot uses.
+func normalizeOS(os string) string {
+	os = strings.ToLower(os)
+	if canonical, ok := osAliases[os]; ok {
+		return canonical
 	}
-	return strings.ToLower(aws.StringValue(p.PlatformArgs.Arch))
+	return os
+}
+
+// normalizeArch returns the lowercase architecture, replacing aliases such as "x86-64" with the name Copilot uses.
+func normalizeArch(arch string) string {
+	arch = strings.ToLower(arch)
+	if canonical, ok := archAliases[arch]; ok {
+		return canonical
+	}
+	return arch
 }
 
 // PlatformArgs represents the specifics of a target OS.
@@ -754,6 +2481,11 @@ func RedirectPlatform(os, arch, wlType string) (platform string, err error) {
 	if platformString(os, arch) == defaultPlatform {
 		return "", nil
 	}
+	// Windows server families, like "windows_server_2022_core", build with the "windows" OS.
+	os = strings.ToLower(os)
+	if contains(os, WindowsOSFamilies) {
+		os = OSWindows
+	}
 	// Return an error if a platform cannot be redirected.
 	if wlType == RequestDrivenWebServiceType && os == OSWindows {
 		return "", ErrAppRunnerInvalidPlatformWindows
@@ -774,7 +2506,8 @@ func isWindowsPlatform(platform PlatformArgsOrString) bool {
 
 // IsArmArch returns whether or not the arch is ARM.
 func IsArmArch(arch string) bool {
-	return strings.ToLower(arch) == ArchARM || strings.ToLower(arch) == ArchARM64 
+	arch = normalizeArch(arch)
+	return arch == ArchARM || arch == ArchARM64
 }
 
 func requiresBuild(image Image) (bool, error) {
@@ -789,6 +2522,25 @@ func requiresBuild(image Image) (bool, error) {
 	return false, nil
 }
 
+// Sources of a workload's main container image.
+const (
+	ImageSourceBuild    = "build"    // The image is built from a local Dockerfile.
+	ImageSourceLocation = "location" // An existing image is pulled from a registry.
+)
+
+// WorkloadImageSource returns ImageSourceBuild if the workload's container image is built from a local Dockerfile,
+// or ImageSourceLocation if the image is pulled from "image.location".
+func WorkloadImageSource(wl interface{}) (string, error) {
+	required, err := dockerfileBuildRequired("workload", wl)
+	if err != nil {
+		return "", err
+	}
+	if required {
+		return ImageSourceBuild, nil
+	}
+	return ImageSourceLocation, nil
+}
+
 func dockerfileBuildRequired(workloadType string, svc interface{}) (bool, error) {
 	type manifest interface {
 		BuildRequired() (bool, error)
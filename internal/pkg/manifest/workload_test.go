@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/require"
@@ -187,11 +188,69 @@ func TestCommandOverride_UnmarshalYAML(t *testing.T) {
 	}
 }
 
+func TestSidecarConfig_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedEntryPoint EntryPointOverride
+		wantedCommand    CommandOverride
+		wantedError      error
+	}{
+		"entrypoint and command specified in strings": {
+			inContent: []byte(`image: nginx
+entrypoint: /bin/sh -c
+command: "echo 'hello world'"`),
+			wantedEntryPoint: EntryPointOverride{
+				String: aws.String("/bin/sh -c"),
+			},
+			wantedCommand: CommandOverride{
+				String: aws.String("echo 'hello world'"),
+			},
+		},
+		"entrypoint and command specified in slices of strings": {
+			inContent: []byte(`image: nginx
+entrypoint: ["/bin/sh", "-c"]
+command: ["echo 'hello world'"]`),
+			wantedEntryPoint: EntryPointOverride{
+				StringSlice: []string{"/bin/sh", "-c"},
+			},
+			wantedCommand: CommandOverride{
+				StringSlice: []string{"echo 'hello world'"},
+			},
+		},
+		"error if entrypoint is unmarshalable": {
+			inContent:   []byte(`entrypoint: {"/bin/sh", "-c"}`),
+			wantedError: errUnmarshalEntryPoint,
+		},
+		"error if command is unmarshalable": {
+			inContent:   []byte(`command: {-c}`),
+			wantedError: errUnmarshalCommand,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var sidecar SidecarConfig
+
+			err := yaml.Unmarshal(tc.inContent, &sidecar)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedEntryPoint, sidecar.EntryPoint)
+				require.Equal(t, tc.wantedCommand, sidecar.Command)
+			}
+		})
+	}
+}
+
 func TestCommandOverride_ToStringSlice(t *testing.T) {
 	testCases := map[string]struct {
 		inCommandOverrides CommandOverride
+		inEnvName          string
 
 		wantedSlice []string
+		wantedErr   error
 	}{
 		"Both fields are empty": {
 			inCommandOverrides: CommandOverride{
@@ -214,11 +273,156 @@ func TestCommandOverride_ToStringSlice(t *testing.T) {
 			},
 			wantedSlice: []string{"-c", "read", "some", "command"},
 		},
+		"Given a string with quotes nested in other quotes": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`echo "it's" 'say "hi"' "escaped \" quote"`),
+			},
+			wantedSlice: []string{"echo", "it's", `say "hi"`, `escaped " quote`},
+		},
+		"Error on an unbalanced double quote": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`echo "hello`),
+			},
+			wantedErr: errors.New(`convert string into tokens using shell-style rules: unterminated double quote at position 5 in "echo \"hello"`),
+		},
+		"Error on an unbalanced single quote": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`echo 'hello world`),
+			},
+			wantedErr: errors.New(`convert string into tokens using shell-style rules: unterminated single quote at position 5 in "echo 'hello world"`),
+		},
+		"Error on a dangling escape character": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`echo hello\`),
+			},
+			wantedErr: errors.New(`convert string into tokens using shell-style rules: dangling escape character at the end of "echo hello\\"`),
+		},
+		"Given a string with an environment name token": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`serve --env ${COPILOT_ENVIRONMENT_NAME} --name "$COPILOT_ENVIRONMENT_NAME api"`),
+			},
+			inEnvName:   "prod",
+			wantedSlice: []string{"serve", "--env", "prod", "--name", "prod api"},
+		},
+		"Given a string slice with an environment name token": {
+			inCommandOverrides: CommandOverride{
+				StringSlice: []string{"serve", "--env=${COPILOT_ENVIRONMENT_NAME}"},
+			},
+			inEnvName:   "prod",
+			wantedSlice: []string{"serve", "--env=prod"},
+		},
+		"Does not expand an environment name token in single quotes": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`sh -c 'echo $COPILOT_ENVIRONMENT_NAME' "it's ${COPILOT_ENVIRONMENT_NAME}"`),
+			},
+			inEnvName:   "prod",
+			wantedSlice: []string{"sh", "-c", "echo $COPILOT_ENVIRONMENT_NAME", "it's prod"},
+		},
+		"Expands the environment name token next to a quote in a string slice": {
+			inCommandOverrides: CommandOverride{
+				StringSlice: []string{"echo", "'$COPILOT_ENVIRONMENT_NAME'"},
+			},
+			inEnvName:   "prod",
+			wantedSlice: []string{"echo", "'prod'"},
+		},
+		"Leaves unknown tokens intact": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`serve --env ${COPILOT_ENVIRONMENT_NAME} --home "$HOME" '${UNKNOWN}'`),
+			},
+			inEnvName:   "prod",
+			wantedSlice: []string{"serve", "--env", "prod", "--home", "$HOME", "${UNKNOWN}"},
+		},
 	}
 
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
+			if tc.inEnvName != "" {
+				tc.inCommandOverrides = ImageOverride{Command: tc.inCommandOverrides}.withEnvName(tc.inEnvName).Command
+			}
 			out, err := tc.inCommandOverrides.ToStringSlice()
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedSlice, out)
+		})
+	}
+}
+
+func TestCommandOverride_ToStringSliceWithEnv(t *testing.T) {
+	lookup := func(key string) (string, bool) {
+		env := map[string]string{
+			"HOME":   "/root",
+			"GREET":  "hello world",
+			"EMPTY":  "",
+			"SUFFIX": "app",
+		}
+		val, ok := env[key]
+		return val, ok
+	}
+	testCases := map[string]struct {
+		inCommandOverrides CommandOverride
+
+		wantedSlice []string
+	}{
+		"Both fields are empty": {
+			inCommandOverrides: CommandOverride{},
+			wantedSlice:        nil,
+		},
+		"Expands unbraced and braced variables": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`echo $HOME/${SUFFIX}`),
+			},
+			wantedSlice: []string{"echo", "/root/app"},
+		},
+		"Expanded values are split unless quoted": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`echo $GREET "$GREET"`),
+			},
+			wantedSlice: []string{"echo", "hello", "world", "hello world"},
+		},
+		"Leaves unknown variables intact": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`echo "$UNKNOWN" ${UNKNOWN}`),
+			},
+			wantedSlice: []string{"echo", "$UNKNOWN", "${UNKNOWN}"},
+		},
+		"Does not expand escaped variables": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`echo \$HOME "\${HOME}"`),
+			},
+			wantedSlice: []string{"echo", "$HOME", "${HOME}"},
+		},
+		"Does not expand variables in single quotes": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`echo '$HOME ${SUFFIX}' $HOME`),
+			},
+			wantedSlice: []string{"echo", "$HOME ${SUFFIX}", "/root"},
+		},
+		"Expands variables next to single quotes within double quotes": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`echo "'$SUFFIX'" \'$SUFFIX`),
+			},
+			wantedSlice: []string{"echo", "'app'", "'app"},
+		},
+		"Expands to an empty value": {
+			inCommandOverrides: CommandOverride{
+				String: aws.String(`echo "${EMPTY}"`),
+			},
+			wantedSlice: []string{"echo", ""},
+		},
+		"Given a string slice": {
+			inCommandOverrides: CommandOverride{
+				StringSlice: []string{"echo", "$HOME"},
+			},
+			wantedSlice: []string{"echo", "$HOME"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			out, err := tc.inCommandOverrides.ToStringSliceWithEnv(lookup)
 			require.NoError(t, err)
 			require.Equal(t, tc.wantedSlice, out)
 		})
@@ -286,6 +490,70 @@ func TestBuildArgs_UnmarshalYAML(t *testing.T) {
 				BuildString: nil,
 			},
 		},
+		"Dockerfile with network build opts": {
+			inContent: []byte(`build:
+  dockerfile: path/to/Dockerfile
+  network: host`),
+			wantedStruct: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Dockerfile: aws.String("path/to/Dockerfile"),
+					Network:    aws.String("host"),
+				},
+				BuildString: nil,
+			},
+		},
+		"Dockerfile with ssh and secrets build opts": {
+			inContent: []byte(`build:
+  ssh:
+    - default
+    - github=~/.ssh/id_ed25519
+  secrets:
+    npmrc: .npmrc
+    token: env:GITHUB_TOKEN`),
+			wantedStruct: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					SSH: []string{"default", "github=~/.ssh/id_ed25519"},
+					Secrets: map[string]string{
+						"npmrc": ".npmrc",
+						"token": "env:GITHUB_TOKEN",
+					},
+				},
+				BuildString: nil,
+			},
+		},
+		"Dockerfile with platforms build opts": {
+			inContent: []byte(`build:
+  dockerfile: Dockerfile
+  platforms:
+    - osfamily: linux
+      architecture: amd64
+    - osfamily: linux
+      architecture: arm64
+      dockerfile: arm/Dockerfile
+      cache_from:
+        - foo/bar:arm64`),
+			wantedStruct: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Dockerfile: aws.String("Dockerfile"),
+					Platforms: []PlatformBuildArgs{
+						{
+							PlatformArgs: PlatformArgs{
+								OSFamily: aws.String("linux"),
+								Arch:     aws.String("amd64"),
+							},
+						},
+						{
+							PlatformArgs: PlatformArgs{
+								OSFamily: aws.String("linux"),
+								Arch:     aws.String("arm64"),
+							},
+							Dockerfile: aws.String("arm/Dockerfile"),
+							CacheFrom:  []string{"foo/bar:arm64"},
+						},
+					},
+				},
+			},
+		},
 		"Error if unmarshalable": {
 			inContent: []byte(`build:
   badfield: OH NOES
@@ -312,11 +580,123 @@ func TestBuildArgs_UnmarshalYAML(t *testing.T) {
 				require.Equal(t, tc.wantedStruct.BuildArgs.Args, b.Build.BuildArgs.Args)
 				require.Equal(t, tc.wantedStruct.BuildArgs.Target, b.Build.BuildArgs.Target)
 				require.Equal(t, tc.wantedStruct.BuildArgs.CacheFrom, b.Build.BuildArgs.CacheFrom)
+				require.Equal(t, tc.wantedStruct.BuildArgs.Platforms, b.Build.BuildArgs.Platforms)
 			}
 		})
 	}
 }
 
+func TestContainerHealthCheck_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct ContainerHealthCheck
+	}{
+		"unmarshal a gRPC health check": {
+			inContent: []byte(`grpc:
+  port: 50051
+  service: grpc.health.v1.Health
+retries: 3`),
+			wantedStruct: ContainerHealthCheck{
+				GRPC: &GRPCHealthCheck{
+					Port:    aws.Uint16(50051),
+					Service: aws.String("grpc.health.v1.Health"),
+				},
+				Retries: aws.Int(3),
+			},
+		},
+		"unmarshal a command health check": {
+			inContent: []byte(`command: ["CMD", "pwd"]`),
+			wantedStruct: ContainerHealthCheck{
+				Command: []string{"CMD", "pwd"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var hc ContainerHealthCheck
+			err := yaml.Unmarshal(tc.inContent, &hc)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, hc)
+		})
+	}
+}
+
+func TestContainerHealthCheck_Defaulted(t *testing.T) {
+	testCases := map[string]struct {
+		in ContainerHealthCheck
+
+		wanted ContainerHealthCheck
+	}{
+		"defaults every field of an empty health check": {
+			wanted: ContainerHealthCheck{
+				Command:     []string{"CMD-SHELL", "curl -f http://localhost/ || exit 1"},
+				Interval:    durationp(10 * time.Second),
+				Retries:     aws.Int(2),
+				Timeout:     durationp(5 * time.Second),
+				StartPeriod: durationp(0),
+			},
+		},
+		"keeps the fields that are set": {
+			in: ContainerHealthCheck{
+				Command:     []string{"CMD", "pwd"},
+				Interval:    durationp(30 * time.Second),
+				Retries:     aws.Int(5),
+				Timeout:     durationp(10 * time.Second),
+				StartPeriod: durationp(60 * time.Second),
+			},
+			wanted: ContainerHealthCheck{
+				Command:     []string{"CMD", "pwd"},
+				Interval:    durationp(30 * time.Second),
+				Retries:     aws.Int(5),
+				Timeout:     durationp(10 * time.Second),
+				StartPeriod: durationp(60 * time.Second),
+			},
+		},
+		"defaults only the fields that are not set": {
+			in: ContainerHealthCheck{
+				Interval:    durationp(30 * time.Second),
+				StartPeriod: durationp(15 * time.Second),
+			},
+			wanted: ContainerHealthCheck{
+				Command:     []string{"CMD-SHELL", "curl -f http://localhost/ || exit 1"},
+				Interval:    durationp(30 * time.Second),
+				Retries:     aws.Int(2),
+				Timeout:     durationp(5 * time.Second),
+				StartPeriod: durationp(15 * time.Second),
+			},
+		},
+		"does not default the command of a gRPC health check": {
+			in: ContainerHealthCheck{
+				GRPC: &GRPCHealthCheck{
+					Port: aws.Uint16(50051),
+				},
+				Retries: aws.Int(3),
+			},
+			wanted: ContainerHealthCheck{
+				GRPC: &GRPCHealthCheck{
+					Port: aws.Uint16(50051),
+				},
+				Interval:    durationp(10 * time.Second),
+				Retries:     aws.Int(3),
+				Timeout:     durationp(5 * time.Second),
+				StartPeriod: durationp(0),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			in := tc.in
+
+			got := tc.in.Defaulted()
+
+			require.Equal(t, tc.wanted, got)
+			require.Equal(t, in, tc.in, "the health check should not be modified")
+		})
+	}
+}
+
 func TestPlatformArgsOrString_UnmarshalYAML(t *testing.T) {
 	testCases := map[string]struct {
 		inContent []byte
@@ -384,6 +764,30 @@ func TestPlatformArgsOrString_OS(t *testing.T) {
 			},
 			wanted: "windows_server_2019_core",
 		},
+		"should return lowercase Windows Server 2022 OS": {
+			in: &PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("Windows_Server_2022_Full"),
+					Arch:     aws.String("x86_64"),
+				},
+			},
+			wanted: "windows_server_2022_full",
+		},
+		"should normalize hyphenated Windows Server OS": {
+			in: &PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("Windows-Server-2019-Core"),
+					Arch:     aws.String("x86_64"),
+				},
+			},
+			wanted: "windows_server_2019_core",
+		},
+		"should normalize OS in a platform string": {
+			in: &PlatformArgsOrString{
+				PlatformString: (*PlatformString)(aws.String("windows-server-2022-full/x86_64")),
+			},
+			wanted: "windows_server_2022_full",
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -418,6 +822,39 @@ func TestPlatformArgsOrString_Arch(t *testing.T) {
 			},
 			wanted: "amd64",
 		},
+		"should keep amd64 as is": {
+			in: &PlatformArgsOrString{
+				PlatformString: (*PlatformString)(aws.String("linux/AMD64")),
+			},
+			wanted: "amd64",
+		},
+		"should keep bare arm as is": {
+			in: &PlatformArgsOrString{
+				PlatformString: (*PlatformString)(aws.String("linux/ARM")),
+			},
+			wanted: "arm",
+		},
+		"should normalize x86-64 to x86_64": {
+			in: &PlatformArgsOrString{
+				PlatformString: (*PlatformString)(aws.String("linux/x86-64")),
+			},
+			wanted: "x86_64",
+		},
+		"should normalize X86-64 in a map to x86_64": {
+			in: &PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("linux"),
+					Arch:     aws.String("X86-64"),
+				},
+			},
+			wanted: "x86_64",
+		},
+		"should normalize aarch64 to arm64": {
+			in: &PlatformArgsOrString{
+				PlatformString: (*PlatformString)(aws.String("linux/aarch64")),
+			},
+			wanted: "arm64",
+		},
 	}
 
 	for name, tc := range testCases {
@@ -427,6 +864,41 @@ func TestPlatformArgsOrString_Arch(t *testing.T) {
 	}
 }
 
+func TestTaskConfig_ContainerOSArch(t *testing.T) {
+	testCases := map[string]struct {
+		in         PlatformArgsOrString
+		wantedOS   string
+		wantedArch string
+	}{
+		"should return empty strings when platform is not set": {},
+		"should return normalized values when platform is of string format 'os/arch'": {
+			in: PlatformArgsOrString{
+				PlatformString: (*PlatformString)(aws.String("Linux/AArch64")),
+			},
+			wantedOS:   "linux",
+			wantedArch: "arm64",
+		},
+		"should return normalized values when platform is a map": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("Windows-Server-2022-Full"),
+					Arch:     aws.String("X86-64"),
+				},
+			},
+			wantedOS:   "windows_server_2022_full",
+			wantedArch: "x86_64",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			os, arch := TaskConfig{Platform: tc.in}.ContainerOSArch()
+			require.Equal(t, tc.wantedOS, os)
+			require.Equal(t, tc.wantedArch, arch)
+		})
+	}
+}
+
 func TestRedirectPlatform(t *testing.T) {
 	testCases := map[string]struct {
 		inOS           string
@@ -466,6 +938,25 @@ func TestRedirectPlatform(t *testing.T) {
 			wantedPlatform: "windows/x86_64",
 			wantedError:    nil,
 		},
+		"targets windows/x86_64 for Windows Server 2022 Core": {
+			inOS:   OSWindowsServer2022Core,
+			inArch: "amd64",
+
+			wantedPlatform: "windows/x86_64",
+		},
+		"targets windows/x86_64 for Windows Server 2022 Full regardless of case": {
+			inOS:   "WINDOWS_SERVER_2022_FULL",
+			inArch: "x86_64",
+
+			wantedPlatform: "windows/x86_64",
+		},
+		"returns error if App Runner + Windows Server 2022": {
+			inOS:           OSWindowsServer2022Core,
+			inArch:         "amd64",
+			inWorkloadType: RequestDrivenWebServiceType,
+
+			wantedError: errors.New("Windows is not supported for App Runner services"),
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -480,34 +971,221 @@ func TestRedirectPlatform(t *testing.T) {
 	}
 }
 
-func TestExec_UnmarshalYAML(t *testing.T) {
+func TestUlimit_UnmarshalYAML(t *testing.T) {
 	testCases := map[string]struct {
 		inContent []byte
 
-		wantedStruct ExecuteCommand
+		wantedStruct ImageOverride
 		wantedError  error
 	}{
-		"use default with empty value": {
-			inContent: []byte(`exec:
-count: 1`),
-
-			wantedStruct: ExecuteCommand{
-				Enable: aws.Bool(false),
+		"integer shorthand sets both limits": {
+			inContent: []byte(`ulimits:
+  nofile: 65536`),
+			wantedStruct: ImageOverride{
+				Ulimits: map[string]Ulimit{
+					"nofile": {Soft: aws.Int(65536), Hard: aws.Int(65536)},
+				},
 			},
 		},
-		"use default without any input": {
-			inContent: []byte(`count: 1`),
-
-			wantedStruct: ExecuteCommand{
-				Enable: aws.Bool(false),
+		"soft and hard limits": {
+			inContent: []byte(`ulimits:
+  nproc:
+    soft: 1024
+    hard: 4096`),
+			wantedStruct: ImageOverride{
+				Ulimits: map[string]Ulimit{
+					"nproc": {Soft: aws.Int(1024), Hard: aws.Int(4096)},
+				},
 			},
 		},
-		"simple enable": {
-			inContent: []byte(`exec: true`),
-
-			wantedStruct: ExecuteCommand{
-				Enable: aws.Bool(true),
-			},
+		"error if unmarshalable": {
+			inContent: []byte(`ulimits:
+  nofile: unlimited`),
+			wantedError: errUnmarshalUlimit,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got ImageOverride
+			err := yaml.Unmarshal(tc.inContent, &got)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedStruct, got)
+			}
+		})
+	}
+}
+
+func TestReadiness_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct LoadBalancedWebServiceConfig
+	}{
+		"url and status": {
+			inContent: []byte(`readiness:
+  url: https://api.example.com/ready
+  status: 204`),
+			wantedStruct: LoadBalancedWebServiceConfig{
+				Readiness: Readiness{
+					URL:    aws.String("https://api.example.com/ready"),
+					Status: aws.Int(204),
+				},
+			},
+		},
+		"url only": {
+			inContent: []byte(`readiness:
+  url: https://api.example.com/ready`),
+			wantedStruct: LoadBalancedWebServiceConfig{
+				Readiness: Readiness{
+					URL: aws.String("https://api.example.com/ready"),
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got LoadBalancedWebServiceConfig
+			err := yaml.Unmarshal(tc.inContent, &got)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct.Readiness, got.Readiness)
+		})
+	}
+}
+
+func TestAlarmArgsOrNames_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct AlarmArgsOrNames
+		wantedError  error
+	}{
+		"alarm names": {
+			inContent: []byte(`rollback_alarms: ["latency", "errors"]`),
+			wantedStruct: AlarmArgsOrNames{
+				AlarmNames: []string{"latency", "errors"},
+			},
+		},
+		"alarm thresholds": {
+			inContent: []byte(`rollback_alarms:
+  cpu_utilization: 70
+  memory_utilization: 85.5`),
+			wantedStruct: AlarmArgsOrNames{
+				AlarmArgs: AlarmArgs{
+					CPUUtilization:    aws.Float64(70),
+					MemoryUtilization: aws.Float64(85.5),
+				},
+			},
+		},
+		"error if neither names nor thresholds": {
+			inContent:   []byte(`rollback_alarms: latency`),
+			wantedError: errUnmarshalAlarms,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got DeploymentConfig
+			err := yaml.Unmarshal(tc.inContent, &got)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, got.RollbackAlarms)
+		})
+	}
+}
+
+func TestBlueGreenConfigOrBool_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct BlueGreenConfigOrBool
+		wantedError  error
+	}{
+		"rolling update if not specified": {
+			inContent: []byte(`min_healthy_percent: 50`),
+		},
+		"turned on with a boolean": {
+			inContent: []byte(`bluegreen: true`),
+			wantedStruct: BlueGreenConfigOrBool{
+				Enable: aws.Bool(true),
+			},
+		},
+		"turned on with an empty map": {
+			inContent: []byte(`bluegreen: {}`),
+			wantedStruct: BlueGreenConfigOrBool{
+				Enable: aws.Bool(true),
+			},
+		},
+		"blue/green with all fields": {
+			inContent: []byte(`bluegreen:
+  termination_wait: 30m
+  test_traffic_route: 8080
+  hooks:
+    after_allow_test_traffic: arn:aws:lambda:us-west-2:123456789012:function:integ-test
+    before_allow_traffic: arn:aws:lambda:us-west-2:123456789012:function:smoke-test`),
+			wantedStruct: BlueGreenConfigOrBool{
+				Enable: aws.Bool(true),
+				Config: BlueGreenConfig{
+					TerminationWait:  durationp(30 * time.Minute),
+					TestTrafficRoute: aws.Uint16(8080),
+					Hooks: BlueGreenHooks{
+						AfterAllowTestTraffic: aws.String("arn:aws:lambda:us-west-2:123456789012:function:integ-test"),
+						BeforeAllowTraffic:    aws.String("arn:aws:lambda:us-west-2:123456789012:function:smoke-test"),
+					},
+				},
+			},
+		},
+		"error if neither a boolean nor a map": {
+			inContent:   []byte(`bluegreen: [8080]`),
+			wantedError: errUnmarshalBlueGreen,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got DeploymentConfig
+			err := yaml.Unmarshal(tc.inContent, &got)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, got.BlueGreen)
+		})
+	}
+}
+
+func TestExec_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct ExecuteCommand
+		wantedError  error
+	}{
+		"use default with empty value": {
+			inContent: []byte(`exec:
+count: 1`),
+
+			wantedStruct: ExecuteCommand{
+				Enable: aws.Bool(false),
+			},
+		},
+		"use default without any input": {
+			inContent: []byte(`count: 1`),
+
+			wantedStruct: ExecuteCommand{
+				Enable: aws.Bool(false),
+			},
+		},
+		"simple enable": {
+			inContent: []byte(`exec: true`),
+
+			wantedStruct: ExecuteCommand{
+				Enable: aws.Bool(true),
+			},
 		},
 		"with config": {
 			inContent: []byte(`exec:
@@ -519,6 +1197,25 @@ count: 1`),
 				},
 			},
 		},
+		"with logging": {
+			inContent: []byte(`exec:
+  logging:
+    cloud_watch_log_group: exec-audit
+    cloud_watch_encryption_enabled: true
+    s3_bucket: audit-bucket
+    s3_key_prefix: exec/`),
+			wantedStruct: ExecuteCommand{
+				Enable: aws.Bool(false),
+				Config: ExecuteCommandConfig{
+					Logging: &ExecLogging{
+						CloudWatchLogGroup:          aws.String("exec-audit"),
+						CloudWatchEncryptionEnabled: aws.Bool(true),
+						S3Bucket:                    aws.String("audit-bucket"),
+						S3KeyPrefix:                 aws.String("exec/"),
+					},
+				},
+			},
+		},
 		"Error if unmarshalable": {
 			inContent: []byte(`exec:
   badfield: OH NOES
@@ -546,12 +1243,589 @@ count: 1`),
 	}
 }
 
+func TestExec_ApplyEnv(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct  ExecuteCommand
+		wantedEnabled bool
+	}{
+		"inherits the base value": {
+			inContent: []byte(`name: api
+type: Backend Service
+image:
+  location: nginx
+exec: true
+environments:
+  prod:
+    count: 2`),
+
+			wantedStruct: ExecuteCommand{
+				Enable: aws.Bool(true),
+			},
+			wantedEnabled: true,
+		},
+		"false override wins over a true base": {
+			inContent: []byte(`name: api
+type: Backend Service
+image:
+  location: nginx
+exec: true
+environments:
+  prod:
+    exec: false`),
+
+			wantedStruct: ExecuteCommand{
+				Enable: aws.Bool(false),
+			},
+		},
+		"false override wins over a base with config": {
+			inContent: []byte(`name: api
+type: Backend Service
+image:
+  location: nginx
+exec:
+  enable: true
+  logging:
+    s3_bucket: audit-bucket
+environments:
+  prod:
+    exec: false`),
+
+			wantedStruct: ExecuteCommand{
+				Enable: aws.Bool(false),
+			},
+		},
+		"disabled config override wins over a true base": {
+			inContent: []byte(`name: api
+type: Backend Service
+image:
+  location: nginx
+exec: true
+environments:
+  prod:
+    exec:
+      enable: false`),
+
+			wantedStruct: ExecuteCommand{
+				Config: ExecuteCommandConfig{
+					Enable: aws.Bool(false),
+				},
+			},
+		},
+		"config override enables exec": {
+			inContent: []byte(`name: api
+type: Backend Service
+image:
+  location: nginx
+environments:
+  prod:
+    exec:
+      logging:
+        cloud_watch_log_group: exec-audit`),
+
+			wantedStruct: ExecuteCommand{
+				Config: ExecuteCommandConfig{
+					Logging: &ExecLogging{
+						CloudWatchLogGroup: aws.String("exec-audit"),
+					},
+				},
+			},
+			wantedEnabled: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mft, err := UnmarshalWorkload(tc.inContent)
+			require.NoError(t, err)
+
+			got, err := mft.ApplyEnv("prod")
+
+			require.NoError(t, err)
+			exec := got.(*BackendService).ExecuteCommand
+			require.Equal(t, tc.wantedStruct, exec)
+			require.Equal(t, tc.wantedEnabled, exec.Enabled())
+			require.Equal(t, !tc.wantedEnabled, exec.Disabled())
+		})
+	}
+}
+
+func TestSecret_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct map[string]Secret
+		wantedError  error
+	}{
+		"plain string secrets": {
+			inContent: []byte(`secrets:
+  GITHUB_TOKEN: GH_TOKEN_SECRET
+  DB_PASSWORD: arn:aws:secretsmanager:us-west-2:111122223333:secret:db-password`),
+			wantedStruct: map[string]Secret{
+				"GITHUB_TOKEN": {From: aws.String("GH_TOKEN_SECRET")},
+				"DB_PASSWORD":  {From: aws.String("arn:aws:secretsmanager:us-west-2:111122223333:secret:db-password")},
+			},
+		},
+		"appconfig secret": {
+			inContent: []byte(`secrets:
+  FLAGS:
+    appconfig:
+      application: phonetool
+      environment: test
+      profile: flags`),
+			wantedStruct: map[string]Secret{
+				"FLAGS": {
+					AppConfig: &AppConfigSecret{
+						Application: aws.String("phonetool"),
+						Environment: aws.String("test"),
+						Profile:     aws.String("flags"),
+					},
+				},
+			},
+		},
+		"error if unmarshalable": {
+			inContent: []byte(`secrets:
+  FLAGS:
+    - foo`),
+			wantedError: errUnmarshalSecret,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var task TaskConfig
+			err := yaml.Unmarshal(tc.inContent, &task)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedStruct, task.Secrets)
+			}
+		})
+	}
+}
+
+func TestVariables_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct Variables
+		wantedError  error
+	}{
+		"inline variables only": {
+			inContent: []byte(`variables:
+  LOG_LEVEL: info`),
+			wantedStruct: Variables{
+				Values: map[string]string{
+					"LOG_LEVEL": "info",
+				},
+			},
+		},
+		"file only": {
+			inContent: []byte(`variables:
+  from_file: ./config/app.env`),
+			wantedStruct: Variables{
+				FromFile: aws.String("./config/app.env"),
+			},
+		},
+		"file and inline variables": {
+			inContent: []byte(`variables:
+  from_file: ./config/app.env
+  LOG_LEVEL: info`),
+			wantedStruct: Variables{
+				FromFile: aws.String("./config/app.env"),
+				Values: map[string]string{
+					"LOG_LEVEL": "info",
+				},
+			},
+		},
+		"variables gated by a flag": {
+			inContent: []byte(`variables:
+  LOG_LEVEL: info
+  CHECKOUT_V2:
+    value: "on"
+    when: checkout`),
+			wantedStruct: Variables{
+				Values: map[string]string{
+					"LOG_LEVEL":   "info",
+					"CHECKOUT_V2": "on",
+				},
+				When: map[string]string{
+					"CHECKOUT_V2": "checkout",
+				},
+			},
+		},
+		"scalars of any type are kept as written": {
+			inContent: []byte(`variables:
+  DEBUG: true
+  PORT: 8080
+  RATIO: 1.50
+  HEX: 0x1F
+  EMPTY:
+  NULL_VALUE: ~`),
+			wantedStruct: Variables{
+				Values: map[string]string{
+					"DEBUG":      "true",
+					"PORT":       "8080",
+					"RATIO":      "1.50",
+					"HEX":        "0x1F",
+					"EMPTY":      "",
+					"NULL_VALUE": "",
+				},
+			},
+		},
+		"quoted scalars stay strings": {
+			inContent: []byte(`variables:
+  DEBUG: "true"
+  PORT: '8080'
+  NULL_VALUE: "~"`),
+			wantedStruct: Variables{
+				Values: map[string]string{
+					"DEBUG":      "true",
+					"PORT":       "8080",
+					"NULL_VALUE": "~",
+				},
+			},
+		},
+		"typed value of a conditional variable": {
+			inContent: []byte(`variables:
+  CHECKOUT_V2:
+    value: true
+    when: checkout`),
+			wantedStruct: Variables{
+				Values: map[string]string{
+					"CHECKOUT_V2": "true",
+				},
+				When: map[string]string{
+					"CHECKOUT_V2": "checkout",
+				},
+			},
+		},
+		"parameter-backed and imported variables": {
+			inContent: []byte(`variables:
+  LOG_LEVEL: info
+  DB_HOST:
+    from_ssm: /phonetool/test/db_host
+  QUEUE_URL:
+    import: phonetool-test-QueueURL
+    when: queue`),
+			wantedStruct: Variables{
+				Values: map[string]string{
+					"LOG_LEVEL": "info",
+				},
+				FromSSM: map[string]string{
+					"DB_HOST": "/phonetool/test/db_host",
+				},
+				Imports: map[string]string{
+					"QUEUE_URL": "phonetool-test-QueueURL",
+				},
+				When: map[string]string{
+					"QUEUE_URL": "queue",
+				},
+			},
+		},
+		"error if a variable has more than one source": {
+			inContent: []byte(`variables:
+  DB_HOST:
+    value: localhost
+    from_ssm: /phonetool/test/db_host`),
+			wantedError: errUnmarshalVariable,
+		},
+		"error if a variable has no source": {
+			inContent: []byte(`variables:
+  DB_HOST:
+    when: db`),
+			wantedError: errUnmarshalVariable,
+		},
+		"error if a variable is a sequence": {
+			inContent: []byte(`variables:
+  PORTS: [80, 443]`),
+			wantedError: errUnmarshalVariable,
+		},
+		"error if from_file is used as a variable": {
+			inContent: []byte(`variables:
+  from_file:
+    from_ssm: /phonetool/test/from_file`),
+			wantedError: errUnmarshalFromFile,
+		},
+		"error if from_file is gated by a flag": {
+			inContent: []byte(`variables:
+  from_file:
+    value: ./config/app.env
+    when: config`),
+			wantedError: errUnmarshalFromFile,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var task TaskConfig
+			err := yaml.Unmarshal(tc.inContent, &task)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, task.Variables)
+		})
+	}
+}
+
+func TestVariables_MarshalYAML(t *testing.T) {
+	in := Variables{
+		Values: map[string]string{
+			"DEBUG":       "true",
+			"CHECKOUT_V2": "on",
+		},
+		FromSSM: map[string]string{
+			"DB_HOST": "/phonetool/test/db_host",
+		},
+		Imports: map[string]string{
+			"QUEUE_URL": "phonetool-test-QueueURL",
+		},
+		When: map[string]string{
+			"CHECKOUT_V2": "checkout",
+		},
+	}
+
+	out, err := yaml.Marshal(in)
+	require.NoError(t, err)
+	var got Variables
+	require.NoError(t, yaml.Unmarshal(out, &got))
+	require.Equal(t, in, got)
+}
+
+func TestTaskConfig_LoadVariables(t *testing.T) {
+	testCases := map[string]struct {
+		inVariables Variables
+		inFile      string
+		inReadErr   error
+
+		wantedVariables Variables
+		wantedError     error
+	}{
+		"no file to read": {
+			inVariables: Variables{
+				Values: map[string]string{"LOG_LEVEL": "info"},
+			},
+			wantedVariables: Variables{
+				Values: map[string]string{"LOG_LEVEL": "info"},
+			},
+		},
+		"error if the file cannot be read": {
+			inVariables: Variables{FromFile: aws.String("app.env")},
+			inReadErr:   errors.New("some error"),
+			wantedError: errors.New("read variables file app.env: some error"),
+		},
+		"error with the line number of a malformed entry": {
+			inVariables: Variables{FromFile: aws.String("app.env")},
+			inFile:      "# comment\nLOG_LEVEL=info\n\nNODE_ENV\n",
+			wantedError: errors.New(`parse variables file app.env: line 4: "NODE_ENV" must be of the form KEY=VALUE`),
+		},
+		"error if a quoted value is not closed": {
+			inVariables: Variables{FromFile: aws.String("app.env")},
+			inFile:      "GREETING=\"hello\n",
+			wantedError: errors.New(`parse variables file app.env: line 1: value "hello is missing a closing double quote`),
+		},
+		"error if a quoted value is followed by other characters": {
+			inVariables: Variables{FromFile: aws.String("app.env")},
+			inFile:      "GREETING='hello' world\n",
+			wantedError: errors.New(`parse variables file app.env: line 1: value 'hello' world has characters after its closing single quote`),
+		},
+		"parses exported, quoted and commented entries": {
+			inVariables: Variables{FromFile: aws.String("app.env")},
+			inFile: `export LOG_LEVEL=info
+GREETING="hello \"world\"\nbye" # double quotes
+PATTERN='a\nb # c'
+PASSWORD=abc#123
+TIMEOUT=30 # seconds
+EMPTY=""
+`,
+			wantedVariables: Variables{
+				Values: map[string]string{
+					"LOG_LEVEL": "info",
+					"GREETING":  "hello \"world\"\nbye",
+					"PATTERN":   `a\nb # c`,
+					"PASSWORD":  "abc#123",
+					"TIMEOUT":   "30",
+					"EMPTY":     "",
+				},
+			},
+		},
+		"inline variables win over the ones in the file": {
+			inVariables: Variables{
+				FromFile: aws.String("app.env"),
+				Values:   map[string]string{"LOG_LEVEL": "debug"},
+			},
+			inFile: `# shared settings
+LOG_LEVEL=info
+DB_URL = postgres://host:5432/db?sslmode=require
+
+NODE_ENV=production
+`,
+			wantedVariables: Variables{
+				Values: map[string]string{
+					"LOG_LEVEL": "debug",
+					"DB_URL":    "postgres://host:5432/db?sslmode=require",
+					"NODE_ENV":  "production",
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			task := TaskConfig{
+				Variables: tc.inVariables,
+			}
+			err := task.LoadVariables(func(path string) ([]byte, error) {
+				require.Equal(t, "app.env", path)
+				return []byte(tc.inFile), tc.inReadErr
+			})
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedVariables, task.Variables)
+			}
+		})
+	}
+}
+
+func TestLoadWorkloadCollectorConfig(t *testing.T) {
+	testCases := map[string]struct {
+		inObservability Observability
+		inFile          string
+		inReadErr       error
+
+		wantedObservability Observability
+		wantedError         error
+	}{
+		"no file to read": {
+			inObservability: Observability{
+				Tracing: aws.String("awsotel"),
+			},
+			wantedObservability: Observability{
+				Tracing: aws.String("awsotel"),
+			},
+		},
+		"error if the file cannot be read": {
+			inObservability: Observability{
+				Tracing:         aws.String("awsotel"),
+				CollectorConfig: aws.String("otel/config.yaml"),
+			},
+			inReadErr:   errors.New("some error"),
+			wantedError: errors.New("read collector config file otel/config.yaml: some error"),
+		},
+		"reads the content of the file": {
+			inObservability: Observability{
+				Tracing:         aws.String("awsotel"),
+				CollectorConfig: aws.String("otel/config.yaml"),
+			},
+			inFile: "receivers:\n  otlp:\n",
+			wantedObservability: Observability{
+				Tracing:                aws.String("awsotel"),
+				CollectorConfig:        aws.String("otel/config.yaml"),
+				CollectorConfigContent: aws.String("receivers:\n  otlp:\n"),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mft := &LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					Observability: tc.inObservability,
+				},
+			}
+			err := LoadWorkloadCollectorConfig(mft, func(path string) ([]byte, error) {
+				require.Equal(t, "otel/config.yaml", path)
+				return []byte(tc.inFile), tc.inReadErr
+			})
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedObservability, mft.Observability)
+			}
+		})
+	}
+}
+
 func TestBuildConfig(t *testing.T) {
 	mockWsRoot := "/root/dir"
 	testCases := map[string]struct {
-		inBuild     BuildArgsOrString
-		wantedBuild DockerBuildArgs
+		inBuild      BuildArgsOrString
+		inEnvName    string
+		inLabels     map[string]string
+		wantedBuild  DockerBuildArgs
+		wantedBuilds []*DockerBuildArgs
+		wantedErr    error
 	}{
+		"one build configuration per platform": {
+			inBuild: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Dockerfile: aws.String("Dockerfile"),
+					Args: map[string]string{
+						"GO_VERSION": "1.17",
+					},
+					Platforms: []PlatformBuildArgs{
+						{
+							PlatformArgs: PlatformArgs{
+								OSFamily: aws.String("linux"),
+								Arch:     aws.String("amd64"),
+							},
+						},
+						{
+							PlatformArgs: PlatformArgs{
+								OSFamily: aws.String("Linux"),
+								Arch:     aws.String("ARM64"),
+							},
+							Dockerfile: aws.String("arm/Dockerfile"),
+							CacheFrom:  []string{"foo/bar:arm64"},
+						},
+					},
+				},
+			},
+			wantedBuilds: []*DockerBuildArgs{
+				{
+					Dockerfile: aws.String(filepath.Join(mockWsRoot, "Dockerfile")),
+					Context:    aws.String(mockWsRoot),
+					Args: map[string]string{
+						"GO_VERSION": "1.17",
+					},
+					Platform: aws.String("linux/amd64"),
+				},
+				{
+					Dockerfile: aws.String(filepath.Join(mockWsRoot, "arm/Dockerfile")),
+					Context:    aws.String(filepath.Join(mockWsRoot, "arm")),
+					Args: map[string]string{
+						"GO_VERSION": "1.17",
+					},
+					CacheFrom: []string{"foo/bar:arm64"},
+					Platform:  aws.String("linux/arm64"),
+				},
+			},
+		},
+		"error if cache_from images conflict across platforms": {
+			inBuild: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Platforms: []PlatformBuildArgs{
+						{
+							PlatformArgs: PlatformArgs{
+								OSFamily: aws.String("linux"),
+								Arch:     aws.String("amd64"),
+							},
+							CacheFrom: []string{"foo/bar:latest"},
+						},
+						{
+							PlatformArgs: PlatformArgs{
+								OSFamily: aws.String("linux"),
+								Arch:     aws.String("arm64"),
+							},
+							CacheFrom: []string{"foo/bar:latest"},
+						},
+					},
+				},
+			},
+			wantedErr: errors.New(`cache_from image "foo/bar:latest" is referenced by both platforms linux/amd64 and linux/arm64`),
+		},
 		"simple case: BuildString path to dockerfile": {
 			inBuild: BuildArgsOrString{
 				BuildString: aws.String("my/Dockerfile"),
@@ -561,6 +1835,21 @@ func TestBuildConfig(t *testing.T) {
 				Context:    aws.String(filepath.Join(mockWsRoot, "my")),
 			},
 		},
+		"labels are passed to every build": {
+			inBuild: BuildArgsOrString{
+				BuildString: aws.String("my/Dockerfile"),
+			},
+			inLabels: map[string]string{
+				"org.opencontainers.image.revision": "abc123",
+			},
+			wantedBuild: DockerBuildArgs{
+				Dockerfile: aws.String(filepath.Join(mockWsRoot, "my/Dockerfile")),
+				Context:    aws.String(filepath.Join(mockWsRoot, "my")),
+				Labels: map[string]string{
+					"org.opencontainers.image.revision": "abc123",
+				},
+			},
+		},
 		"Different context than dockerfile": {
 			inBuild: BuildArgsOrString{
 				BuildArgs: DockerBuildArgs{
@@ -619,6 +1908,26 @@ func TestBuildConfig(t *testing.T) {
 				},
 			},
 		},
+		"resolves the environment token": {
+			inBuild: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Dockerfile: aws.String("envs/${ENV}/Dockerfile"),
+					Target:     aws.String("${ENV}"),
+				},
+			},
+			inEnvName: "test",
+			wantedBuild: DockerBuildArgs{
+				Dockerfile: aws.String(filepath.Join(mockWsRoot, "envs/test/Dockerfile")),
+				Context:    aws.String(filepath.Join(mockWsRoot, "envs/test")),
+				Target:     aws.String("test"),
+			},
+		},
+		"error if the environment token can't be resolved": {
+			inBuild: BuildArgsOrString{
+				BuildString: aws.String("envs/${ENV}/Dockerfile"),
+			},
+			wantedErr: errors.New(`"build" references ${ENV} but no environment is defined`),
+		},
 		"including build options": {
 			inBuild: BuildArgsOrString{
 				BuildArgs: DockerBuildArgs{
@@ -627,6 +1936,7 @@ func TestBuildConfig(t *testing.T) {
 						"foo/bar:latest",
 						"foo/bar/baz:1.2.3",
 					},
+					Network: aws.String("none"),
 				},
 			},
 			wantedBuild: DockerBuildArgs{
@@ -637,17 +1947,292 @@ func TestBuildConfig(t *testing.T) {
 					"foo/bar:latest",
 					"foo/bar/baz:1.2.3",
 				},
+				Network: aws.String("none"),
+			},
+		},
+		"resolving relative ssh and secret paths against the workspace root": {
+			inBuild: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					SSH: []string{"default", "github=keys/id_ed25519,/home/user/.ssh/id_rsa"},
+					Secrets: map[string]string{
+						"npmrc": ".npmrc",
+						"cert":  "/etc/ssl/cert.pem",
+						"token": "env:GITHUB_TOKEN",
+					},
+				},
+			},
+			wantedBuild: DockerBuildArgs{
+				Dockerfile: aws.String(filepath.Join(mockWsRoot, "Dockerfile")),
+				Context:    aws.String(mockWsRoot),
+				SSH: []string{
+					"default",
+					fmt.Sprintf("github=%s,/home/user/.ssh/id_rsa", filepath.Join(mockWsRoot, "keys/id_ed25519")),
+				},
+				Secrets: map[string]string{
+					"npmrc": filepath.Join(mockWsRoot, ".npmrc"),
+					"cert":  "/etc/ssl/cert.pem",
+					"token": "env:GITHUB_TOKEN",
+				},
 			},
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			s := Image{
+				Build:        tc.inBuild,
+				DockerLabels: tc.inLabels,
+			}
+			got, err := s.BuildConfig(mockWsRoot, tc.inEnvName)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			if tc.wantedBuilds != nil {
+				require.Equal(t, tc.wantedBuilds, got)
+				return
+			}
+			require.Equal(t, []*DockerBuildArgs{&tc.wantedBuild}, got)
+		})
+	}
+}
+
+func TestBuildConfigWithPlatform(t *testing.T) {
+	mockWsRoot := "/root/dir"
+	testCases := map[string]struct {
+		inBuild        BuildArgsOrString
+		inHostPlatform string
+
+		wantedHostPlatforms []*string
+	}{
+		"records the platform of the host if the image has no target platform": {
+			inBuild: BuildArgsOrString{
+				BuildString: aws.String("web/Dockerfile"),
+			},
+			inHostPlatform:      "linux/arm64",
+			wantedHostPlatforms: []*string{aws.String("linux/arm64")},
+		},
+		"doesn't record an unknown platform of the host": {
+			inBuild: BuildArgsOrString{
+				BuildString: aws.String("web/Dockerfile"),
+			},
+			wantedHostPlatforms: []*string{nil},
+		},
+		"doesn't record the platform of the host if the image is built for each platform": {
+			inBuild: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Platforms: []PlatformBuildArgs{
+						{
+							PlatformArgs: PlatformArgs{
+								OSFamily: aws.String(OSLinux),
+								Arch:     aws.String(ArchAMD64),
+							},
+						},
+						{
+							PlatformArgs: PlatformArgs{
+								OSFamily: aws.String(OSLinux),
+								Arch:     aws.String(ArchARM64),
+							},
+						},
+					},
+				},
+			},
+			inHostPlatform:      "linux/arm64",
+			wantedHostPlatforms: []*string{nil, nil},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			img := Image{
 				Build: tc.inBuild,
 			}
-			got := s.BuildConfig(mockWsRoot)
 
-			require.Equal(t, tc.wantedBuild, *got)
+			got, err := img.BuildConfigWithPlatform(mockWsRoot, "test", tc.inHostPlatform)
+
+			require.NoError(t, err)
+			var gotHostPlatforms []*string
+			for _, config := range got {
+				gotHostPlatforms = append(gotHostPlatforms, config.HostPlatform)
+			}
+			require.Equal(t, tc.wantedHostPlatforms, gotHostPlatforms)
+		})
+	}
+}
+
+func TestBuildArgsOrString_Interpolate(t *testing.T) {
+	testCases := map[string]struct {
+		in        BuildArgsOrString
+		inEnvName string
+
+		wanted    BuildArgsOrString
+		wantedErr error
+	}{
+		"leaves fields without the token untouched": {
+			in: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Dockerfile: aws.String("my/Dockerfile"),
+					Target:     aws.String("prod"),
+				},
+			},
+			inEnvName: "test",
+			wanted: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Dockerfile: aws.String("my/Dockerfile"),
+					Target:     aws.String("prod"),
+				},
+			},
+		},
+		"substitutes the token in a build string": {
+			in: BuildArgsOrString{
+				BuildString: aws.String("envs/${ENV}/Dockerfile"),
+			},
+			inEnvName: "test",
+			wanted: BuildArgsOrString{
+				BuildString: aws.String("envs/test/Dockerfile"),
+			},
+		},
+		"substitutes the token in dockerfile, context, target and args": {
+			in: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Dockerfile: aws.String("${ENV}/Dockerfile"),
+					Context:    aws.String("${ENV}"),
+					Target:     aws.String("${ENV}-stage"),
+					Args: map[string]string{
+						"KEY": "${ENV}",
+					},
+				},
+			},
+			inEnvName: "prod",
+			wanted: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Dockerfile: aws.String("prod/Dockerfile"),
+					Context:    aws.String("prod"),
+					Target:     aws.String("prod-stage"),
+					Args: map[string]string{
+						"KEY": "prod",
+					},
+				},
+			},
+		},
+		"substitutes the token in cache_from images and keeps digests verbatim": {
+			in: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					CacheFrom: []string{
+						"foo/bar:${ENV}",
+						"foo/bar@sha256:9b7f2fc5f6e1ac7b3c8d7e4d9b1b3fc5c8a2e0e1f4d9a7f6c3b2a1e0d9c8b7a6",
+					},
+					Platforms: []PlatformBuildArgs{
+						{
+							PlatformArgs: PlatformArgs{
+								OSFamily: aws.String("linux"),
+								Arch:     aws.String("arm64"),
+							},
+							Dockerfile: aws.String("${ENV}/Dockerfile.arm64"),
+							CacheFrom:  []string{"foo/bar:${ENV}-arm64"},
+						},
+					},
+				},
+			},
+			inEnvName: "prod",
+			wanted: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					CacheFrom: []string{
+						"foo/bar:prod",
+						"foo/bar@sha256:9b7f2fc5f6e1ac7b3c8d7e4d9b1b3fc5c8a2e0e1f4d9a7f6c3b2a1e0d9c8b7a6",
+					},
+					Platforms: []PlatformBuildArgs{
+						{
+							PlatformArgs: PlatformArgs{
+								OSFamily: aws.String("linux"),
+								Arch:     aws.String("arm64"),
+							},
+							Dockerfile: aws.String("prod/Dockerfile.arm64"),
+							CacheFrom:  []string{"foo/bar:prod-arm64"},
+						},
+					},
+				},
+			},
+		},
+		"error if the token is used without an environment": {
+			in: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Target: aws.String("${ENV}"),
+				},
+			},
+			wantedErr: errors.New(`"target" references ${ENV} but no environment is defined`),
+		},
+		"error if the token is used in a build arg without an environment": {
+			in: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Args: map[string]string{
+						"STAGE": "${ENV}",
+					},
+				},
+			},
+			wantedErr: errors.New(`"args.STAGE" references ${ENV} but no environment is defined`),
+		},
+		"error if the token is used in a platform cache_from image without an environment": {
+			in: BuildArgsOrString{
+				BuildArgs: DockerBuildArgs{
+					Platforms: []PlatformBuildArgs{
+						{
+							CacheFrom: []string{"foo/bar", "foo/bar:${ENV}"},
+						},
+					},
+				},
+			},
+			wantedErr: errors.New(`"platforms[0].cache_from[1]" references ${ENV} but no environment is defined`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.in.Interpolate(tc.inEnvName)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
+func TestDockerBuildArgs_CacheFromImages(t *testing.T) {
+	testCases := map[string]struct {
+		in    DockerBuildArgs
+		inTag string
+
+		wanted    []string
+		wantedErr error
+	}{
+		"returns nil if there are no cache_from images": {
+			inTag: "v1",
+		},
+		"replaces the tag token with the image tag": {
+			in: DockerBuildArgs{
+				CacheFrom: []string{"foo/bar:latest", "foo/bar:${TAG}"},
+			},
+			inTag:  "v1",
+			wanted: []string{"foo/bar:latest", "foo/bar:v1"},
+		},
+		"error if the tag token is referenced and the image has no tag": {
+			in: DockerBuildArgs{
+				CacheFrom: []string{"foo/bar:${TAG}"},
+			},
+			wantedErr: errors.New(`"cache_from[0]" references ${TAG} but the image has no tag`),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.in.CacheFromImages(tc.inTag)
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wanted, got)
 		})
 	}
 }
@@ -683,26 +2268,65 @@ func TestLogging_IsEmpty(t *testing.T) {
 
 func TestLogging_LogImage(t *testing.T) {
 	testCases := map[string]struct {
-		inputImage  *string
-		wantedImage *string
+		inputImage      *string
+		inputConfigType *string
+		wantedImage     *string
 	}{
 		"Image specified": {
 			inputImage:  aws.String("nginx:why-on-earth"),
 			wantedImage: aws.String("nginx:why-on-earth"),
 		},
-		"no image specified": {
-			inputImage:  nil,
-			wantedImage: aws.String(defaultFluentbitImage),
+		"Image specified with fluentd": {
+			inputImage:      aws.String("my-fluentd:v1"),
+			inputConfigType: aws.String(FirelensConfigTypeFluentd),
+			wantedImage:     aws.String("my-fluentd:v1"),
+		},
+		"no image specified": {
+			inputImage:  nil,
+			wantedImage: aws.String(defaultFluentbitImage),
+		},
+		"no image specified with fluentbit": {
+			inputConfigType: aws.String(FirelensConfigTypeFluentBit),
+			wantedImage:     aws.String(defaultFluentbitImage),
+		},
+		"no image specified with fluentd": {
+			inputConfigType: aws.String(FirelensConfigTypeFluentd),
+			wantedImage:     aws.String(defaultFluentdImage),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			l := Logging{
+				Image:      tc.inputImage,
+				ConfigType: tc.inputConfigType,
+			}
+			got := l.LogImage()
+
+			require.Equal(t, tc.wantedImage, got)
+		})
+	}
+}
+
+func TestLogging_FirelensConfigType(t *testing.T) {
+	testCases := map[string]struct {
+		in     *string
+		wanted string
+	}{
+		"defaults to fluentbit": {
+			wanted: FirelensConfigTypeFluentBit,
+		},
+		"fluentd specified": {
+			in:     aws.String(FirelensConfigTypeFluentd),
+			wanted: FirelensConfigTypeFluentd,
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			l := Logging{
-				Image: tc.inputImage,
+				ConfigType: tc.in,
 			}
-			got := l.LogImage()
 
-			require.Equal(t, tc.wantedImage, got)
+			require.Equal(t, tc.wanted, l.FirelensConfigType())
 		})
 	}
 }
@@ -749,7 +2373,14 @@ func TestNetworkConfig_IsEmpty(t *testing.T) {
 		"non empty network config": {
 			in: NetworkConfig{
 				VPC: vpcConfig{
-					SecurityGroups: []string{"group"},
+					SecurityGroups: []SecurityGroup{{ID: aws.String("group")}},
+				},
+			},
+		},
+		"non empty if only service connect is configured": {
+			in: NetworkConfig{
+				Connect: ServiceConnect{
+					Enabled: aws.Bool(true),
 				},
 			},
 		},
@@ -772,16 +2403,12 @@ func TestNetworkConfig_UnmarshalYAML(t *testing.T) {
 		wantedConfig *NetworkConfig
 		wantedErr    error
 	}{
-		"defaults to public placement if vpc is empty": {
+		"leaves the placement empty if vpc is empty": {
 			data: `
 network:
   vpc:
 `,
-			wantedConfig: &NetworkConfig{
-				VPC: vpcConfig{
-					Placement: &PublicSubnetPlacement,
-				},
-			},
+			wantedConfig: &NetworkConfig{},
 		},
 		"unmarshals successfully for public placement with security groups": {
 			data: `
@@ -795,10 +2422,139 @@ network:
 			wantedConfig: &NetworkConfig{
 				VPC: vpcConfig{
 					Placement:      &PublicSubnetPlacement,
-					SecurityGroups: []string{"sg-1234", "sg-4567"},
+					SecurityGroups: []SecurityGroup{{ID: aws.String("sg-1234")}, {ID: aws.String("sg-4567")}},
+				},
+			},
+		},
+		"unmarshals successfully for dual-stack networking": {
+			data: `
+network:
+  vpc:
+    placement: 'private'
+    ip_family: 'dualstack'
+`,
+			wantedConfig: &NetworkConfig{
+				VPC: vpcConfig{
+					Placement: &PrivateSubnetPlacement,
+					IPFamily:  aws.String(IPFamilyDualStack),
+				},
+			},
+		},
+		"unmarshals service connect with the default vpc": {
+			data: `
+network:
+  connect:
+    alias: api
+    ports:
+    - name: api-admin
+      port: 9090
+`,
+			wantedConfig: &NetworkConfig{
+				Connect: ServiceConnect{
+					Alias: aws.String("api"),
+					Ports: []ServiceConnectPort{
+						{
+							Name: aws.String("api-admin"),
+							Port: aws.Uint16(9090),
+						},
+					},
+				},
+			},
+		},
+		"does not default to public placement if subnet IDs are specified": {
+			data: `
+network:
+  vpc:
+    subnets: ['subnet-0123abcd', 'subnet-4567cdef']
+`,
+			wantedConfig: &NetworkConfig{
+				VPC: vpcConfig{
+					Subnets: SubnetListOrArgs{
+						IDs: []string{"subnet-0123abcd", "subnet-4567cdef"},
+					},
+				},
+			},
+		},
+		"unmarshals subnets selected by tags": {
+			data: `
+network:
+  vpc:
+    subnets:
+      from_tags:
+        tier: private
+        team: payments
+`,
+			wantedConfig: &NetworkConfig{
+				VPC: vpcConfig{
+					Subnets: SubnetListOrArgs{
+						SubnetArgs: SubnetArgs{
+							FromTags: map[string]string{
+								"tier": "private",
+								"team": "payments",
+							},
+						},
+					},
+				},
+			},
+		},
+		"unmarshals security groups imported from CloudFormation exports": {
+			data: `
+network:
+  vpc:
+    placement: 'private'
+    security_groups:
+    - 'sg-1234'
+    - from_cfn: 'shared-db-SecurityGroupID'
+`,
+			wantedConfig: &NetworkConfig{
+				VPC: vpcConfig{
+					Placement: &PrivateSubnetPlacement,
+					SecurityGroups: []SecurityGroup{
+						{ID: aws.String("sg-1234")},
+						{FromCFN: aws.String("shared-db-SecurityGroupID")},
+					},
 				},
 			},
 		},
+		"unmarshals the toggle to deny the default security group": {
+			data: `
+network:
+  vpc:
+    security_groups: ['sg-1234']
+    deny_default_security_group: true
+`,
+			wantedConfig: &NetworkConfig{
+				VPC: vpcConfig{
+					SecurityGroups:           []SecurityGroup{{ID: aws.String("sg-1234")}},
+					DenyDefaultSecurityGroup: aws.Bool(true),
+				},
+			},
+		},
+		"returns an error if a security group is neither a string nor an import": {
+			data: `
+network:
+  vpc:
+    security_groups:
+    - ['sg-1234']
+`,
+			wantedErr: errUnmarshalSecGroup,
+		},
+		"returns an error if subnets are neither a list nor tags": {
+			data: `
+network:
+  vpc:
+    subnets: subnet-0123abcd
+`,
+			wantedErr: errUnmarshalSubnets,
+		},
+		"returns an error if the IP family is invalid": {
+			data: `
+network:
+  vpc:
+    ip_family: 'ipv5'
+`,
+			wantedErr: errors.New(`"ip_family" value "ipv5" must be one of ipv4, ipv6 or dualstack`),
+		},
 	}
 
 	for name, tc := range testCases {
@@ -823,6 +2579,116 @@ network:
 	}
 }
 
+func TestServiceConnect_IsEnabled(t *testing.T) {
+	testCases := map[string]struct {
+		in     ServiceConnect
+		wanted bool
+	}{
+		"disabled if empty": {},
+		"enabled if explicitly enabled": {
+			in: ServiceConnect{
+				Enabled: aws.Bool(true),
+			},
+			wanted: true,
+		},
+		"enabled if an alias is specified": {
+			in: ServiceConnect{
+				Alias: aws.String("api"),
+			},
+			wanted: true,
+		},
+		"disabled if explicitly disabled even with ports": {
+			in: ServiceConnect{
+				Enabled: aws.Bool(false),
+				Ports: []ServiceConnectPort{
+					{
+						Name: aws.String("api-admin"),
+						Port: aws.Uint16(9090),
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, tc.in.IsEnabled())
+		})
+	}
+}
+
+func TestValidatePlacementSpecified(t *testing.T) {
+	testCases := map[string]struct {
+		inContent string
+
+		wantedErr error
+	}{
+		"error if network is omitted": {
+			inContent: `name: api
+type: Backend Service
+image:
+  location: nginx
+`,
+			wantedErr: errors.New(`"network.vpc.placement" must be specified`),
+		},
+		"error if placement is omitted": {
+			inContent: `name: api
+type: Backend Service
+image:
+  location: nginx
+network:
+  vpc:
+    security_groups: ['sg-1234']
+`,
+			wantedErr: errors.New(`"network.vpc.placement" must be specified`),
+		},
+		"placement of environment overrides is optional": {
+			inContent: `name: api
+type: Backend Service
+image:
+  location: nginx
+network:
+  vpc:
+    placement: private
+environments:
+  test:
+    network:
+      vpc:
+        security_groups: ['sg-1234']
+`,
+		},
+		"subnets can be specified instead of the placement": {
+			inContent: `name: api
+type: Backend Service
+image:
+  location: nginx
+network:
+  vpc:
+    subnets: ['subnet-1234']
+`,
+		},
+		"request-driven web services are not checked": {
+			inContent: `name: api
+type: Request-Driven Web Service
+image:
+  location: nginx
+`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			err := ValidatePlacementSpecified([]byte(tc.inContent))
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestDependency_UnmarshalYAML(t *testing.T) {
 	testCases := map[string]struct {
 		inContent []byte
@@ -898,6 +2764,55 @@ topics:
 				},
 			},
 		},
+		"FIFO topic with the shorthand": {
+			inContent: `
+topics:
+  - name: orders
+    fifo: true
+`,
+			wantedPublish: PublishConfig{
+				Topics: []Topic{
+					{
+						Name: aws.String("orders"),
+						FIFO: FIFOTopicAdvanceConfigOrBool{
+							Enable: aws.Bool(true),
+						},
+					},
+				},
+			},
+		},
+		"FIFO topic with advanced configuration": {
+			inContent: `
+topics:
+  - name: orders
+    fifo:
+      content_based_deduplication: true
+      deduplication_scope: messageGroup
+      throughput_limit: perMessageGroupId
+`,
+			wantedPublish: PublishConfig{
+				Topics: []Topic{
+					{
+						Name: aws.String("orders"),
+						FIFO: FIFOTopicAdvanceConfigOrBool{
+							Advanced: FIFOTopicAdvanceConfig{
+								ContentBasedDeduplication: aws.Bool(true),
+								DeduplicationScope:        aws.String("messageGroup"),
+								ThroughputLimit:           aws.String("perMessageGroupId"),
+							},
+						},
+					},
+				},
+			},
+		},
+		"Error when fifo is unmarshalable": {
+			inContent: `
+topics:
+  - name: orders
+    fifo: [yes]
+`,
+			wantedErr: errUnmarshalFIFO,
+		},
 		"Error when unmarshalable": {
 			inContent: `
 topics: abc
@@ -921,3 +2836,207 @@ topics: abc
 		})
 	}
 }
+
+func TestPlatformArgsOrString_IsValid(t *testing.T) {
+	testCases := map[string]struct {
+		in     PlatformArgsOrString
+		wanted error
+	}{
+		"valid if empty": {},
+		"valid for a supported platform string": {
+			in: PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("linux/arm64"))},
+		},
+		"valid for Windows Server 2022": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String(OSWindowsServer2022Core),
+					Arch:     aws.String(ArchX86),
+				},
+			},
+		},
+		"error naming the unsupported platform string": {
+			in:     PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("darwin/arm64"))},
+			wanted: errors.New("platform 'darwin/arm64' is invalid; valid platforms are: linux/amd64, linux/x86_64, linux/arm, linux/arm64, windows/amd64 and windows/x86_64"),
+		},
+		"error naming the unsupported platform pair": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String(OSLinux),
+					Arch:     aws.String("s390x"),
+				},
+			},
+			wanted: errors.New("platform pair ('linux', 's390x') is invalid: fields ('osfamily', 'architecture') must be one of ('linux', 'x86_64'), ('linux', 'amd64'), ('linux', 'arm'), ('linux', 'arm64'), ('windows', 'x86_64'), ('windows', 'amd64'), ('windows_server_2019_core', 'x86_64'), ('windows_server_2019_core', 'amd64'), ('windows_server_2019_full', 'x86_64'), ('windows_server_2019_full', 'amd64'), ('windows_server_2022_core', 'x86_64'), ('windows_server_2022_core', 'amd64'), ('windows_server_2022_full', 'x86_64'), ('windows_server_2022_full', 'amd64')"),
+		},
+		"error if a Windows Server 2022 family is paired with ARM": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String(OSWindowsServer2022Full),
+					Arch:     aws.String(ArchARM64),
+				},
+			},
+			wanted: errors.New("platform pair ('windows_server_2022_full', 'arm64') is invalid: Windows containers can't run on ARM architecture on Fargate"),
+		},
+		"error if a Windows family is paired with ARM regardless of case": {
+			in: PlatformArgsOrString{
+				PlatformArgs: PlatformArgs{
+					OSFamily: aws.String("Windows_Server_2019_Core"),
+					Arch:     aws.String("ARM"),
+				},
+			},
+			wanted: errors.New("platform pair ('Windows_Server_2019_Core', 'ARM') is invalid: Windows containers can't run on ARM architecture on Fargate"),
+		},
+		"error if the Windows platform string is paired with ARM": {
+			in:     PlatformArgsOrString{PlatformString: (*PlatformString)(aws.String("windows/arm64"))},
+			wanted: errors.New("platform 'windows/arm64' is invalid: Windows containers can't run on ARM architecture on Fargate"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.in.IsValid()
+
+			if tc.wanted != nil {
+				require.EqualError(t, err, tc.wanted.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWorkloadImageSource(t *testing.T) {
+	testCases := map[string]struct {
+		in interface{}
+
+		wanted    string
+		wantedErr error
+	}{
+		"invalid type": {
+			in: struct{}{},
+
+			wantedErr: errors.New("workload does not have required methods BuildRequired()"),
+		},
+		"neither build nor location": {
+			in: &BackendService{},
+
+			wantedErr: errors.New(`check if workload requires building from local Dockerfile: either "image.build" or "image.location" needs to be specified in the manifest`),
+		},
+		"service built from a Dockerfile": {
+			in: &LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					ImageConfig: ImageWithPortAndHealthcheck{
+						ImageWithPort: ImageWithPort{
+							Image: Image{
+								Build: BuildArgsOrString{
+									BuildString: aws.String("./Dockerfile"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wanted: ImageSourceBuild,
+		},
+		"service pulled from a location": {
+			in: &WorkerService{
+				WorkerServiceConfig: WorkerServiceConfig{
+					ImageConfig: ImageWithHealthcheck{
+						Image: Image{
+							Location: aws.String("nginx:1.21"),
+						},
+					},
+				},
+			},
+			wanted: ImageSourceLocation,
+		},
+		"job built from a Dockerfile": {
+			in: &ScheduledJob{
+				ScheduledJobConfig: ScheduledJobConfig{
+					ImageConfig: ImageWithHealthcheck{
+						Image: Image{
+							Build: BuildArgsOrString{
+								BuildArgs: DockerBuildArgs{
+									Dockerfile: aws.String("mailer/Dockerfile"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wanted: ImageSourceBuild,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := WorkloadImageSource(tc.in)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestRecreatedTaskCount(t *testing.T) {
+	testCases := map[string]struct {
+		in WorkloadManifest
+
+		wantedCount int
+		wantedOK    bool
+	}{
+		"false if the workload isn't an ECS service": {
+			in: &ScheduledJob{},
+		},
+		"false if the service uses the default strategy": {
+			in: &BackendService{
+				BackendServiceConfig: BackendServiceConfig{
+					TaskConfig: TaskConfig{
+						Count: Count{Value: aws.Int(3)},
+					},
+				},
+			},
+		},
+		"returns the desired count of a recreated service": {
+			in: &LoadBalancedWebService{
+				LoadBalancedWebServiceConfig: LoadBalancedWebServiceConfig{
+					TaskConfig: TaskConfig{
+						Count: Count{Value: aws.Int(3)},
+					},
+					DeployConfig: DeploymentConfig{
+						Strategy: aws.String(DeploymentStrategyRecreate),
+					},
+				},
+			},
+			wantedCount: 3,
+			wantedOK:    true,
+		},
+		"returns the minimum of the range of an autoscaled service": {
+			in: &WorkerService{
+				WorkerServiceConfig: WorkerServiceConfig{
+					TaskConfig: TaskConfig{
+						Count: Count{
+							AdvancedCount: AdvancedCount{
+								Range: Range{Value: (*IntRangeBand)(aws.String("2-10"))},
+							},
+						},
+					},
+					DeployConfig: DeploymentConfig{
+						Strategy: aws.String(DeploymentStrategyRecreate),
+					},
+				},
+			},
+			wantedCount: 2,
+			wantedOK:    true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			count, ok := RecreatedTaskCount(tc.in)
+
+			require.Equal(t, tc.wantedOK, ok)
+			require.Equal(t, tc.wantedCount, count)
+		})
+	}
+}
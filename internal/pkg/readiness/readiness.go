@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package readiness provides functionality to wait until a deployed service serves the expected responses.
+package readiness
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultStatus is the status code expected from a readiness URL when none is specified.
+	DefaultStatus = http.StatusOK
+
+	defaultInterval = 5 * time.Second
+	defaultTimeout  = 5 * time.Minute
+)
+
+type httpClient interface {
+	Get(url string) (resp *http.Response, err error)
+}
+
+// Poller polls a URL until it returns an expected status code.
+type Poller struct {
+	client   httpClient
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// New returns a Poller that sends a request every 5 seconds for up to 5 minutes.
+func New() *Poller {
+	return &Poller{
+		client:   &http.Client{Timeout: 10 * time.Second},
+		interval: defaultInterval,
+		timeout:  defaultTimeout,
+	}
+}
+
+// Wait polls url until it responds with the status code, and returns an error if it doesn't do so before the timeout.
+func (p *Poller) Wait(url string, status int) error {
+	deadline := time.Now().Add(p.timeout)
+	var lastErr error
+	for {
+		resp, err := p.client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == status {
+				return nil
+			}
+			lastErr = fmt.Errorf("got status %d", resp.StatusCode)
+		} else {
+			lastErr = err
+		}
+		if time.Now().Add(p.interval).After(deadline) {
+			return fmt.Errorf("%s did not return status %d within %s: %w", url, status, p.timeout, lastErr)
+		}
+		time.Sleep(p.interval)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readiness
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoller_Wait(t *testing.T) {
+	testCases := map[string]struct {
+		statuses     []int
+		wantedStatus int
+
+		wantedRequests int
+		wantedErrorMsg string
+	}{
+		"returns once the expected status is served": {
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			wantedStatus: http.StatusOK,
+
+			wantedRequests: 3,
+		},
+		"matches a non-default status": {
+			statuses:     []int{http.StatusNoContent},
+			wantedStatus: http.StatusNoContent,
+
+			wantedRequests: 1,
+		},
+		"error if the status is never served": {
+			statuses:     []int{http.StatusServiceUnavailable},
+			wantedStatus: http.StatusOK,
+
+			wantedErrorMsg: "did not return status 200 within 20ms: got status 503",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			var requests int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tc.statuses[len(tc.statuses)-1]
+				if requests < len(tc.statuses) {
+					status = tc.statuses[requests]
+				}
+				requests++
+				w.WriteHeader(status)
+			}))
+			defer srv.Close()
+			p := &Poller{
+				client:   srv.Client(),
+				interval: time.Millisecond,
+				timeout:  20 * time.Millisecond,
+			}
+
+			// WHEN
+			err := p.Wait(srv.URL, tc.wantedStatus)
+
+			// THEN
+			if tc.wantedErrorMsg != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.wantedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedRequests, requests)
+		})
+	}
+}
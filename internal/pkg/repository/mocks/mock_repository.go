@@ -96,6 +96,26 @@ func (mr *MockContainerLoginBuildPusherMockRecorder) Push(uri interface{}, tags
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockContainerLoginBuildPusher)(nil).Push), varargs...)
 }
 
+// PushManifestList mocks base method.
+func (m *MockContainerLoginBuildPusher) PushManifestList(uri string, images []string, tags ...string) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{uri, images}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PushManifestList", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PushManifestList indicates an expected call of PushManifestList.
+func (mr *MockContainerLoginBuildPusherMockRecorder) PushManifestList(uri, images interface{}, tags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{uri, images}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushManifestList", reflect.TypeOf((*MockContainerLoginBuildPusher)(nil).PushManifestList), varargs...)
+}
+
 // MockRegistry is a mock of Registry interface.
 type MockRegistry struct {
 	ctrl     *gomock.Controller
@@ -5,7 +5,9 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/aws/copilot-cli/internal/pkg/docker/dockerengine"
 )
@@ -15,6 +17,7 @@ type ContainerLoginBuildPusher interface {
 	Build(args *dockerengine.BuildArguments) error
 	Login(uri, username, password string) error
 	Push(uri string, tags ...string) (digest string, err error)
+	PushManifestList(uri string, images []string, tags ...string) (digest string, err error)
 	IsEcrCredentialHelperEnabled(uri string) bool
 }
 
@@ -47,33 +50,89 @@ func New(name string, registry Registry) (*Repository, error) {
 }
 
 // BuildAndPush builds the image from Dockerfile and pushes it to the repository with tags.
-func (r *Repository) BuildAndPush(docker ContainerLoginBuildPusher, args *dockerengine.BuildArguments) (digest string, err error) {
-	if args.URI == "" {
-		args.URI = r.uri
+// If it is given the build arguments of more than one platform, it builds and pushes an image per platform
+// instead, and pushes a manifest list of these images with tags.
+func (r *Repository) BuildAndPush(docker ContainerLoginBuildPusher, args ...*dockerengine.BuildArguments) (digest string, err error) {
+	if len(args) == 0 {
+		return "", errors.New("no build arguments to build the image from")
 	}
-	if err := docker.Build(args); err != nil {
-		return "", fmt.Errorf("build Dockerfile at %s: %w", args.Dockerfile, err)
+	if len(args) > 1 {
+		return r.buildAndPushPlatforms(docker, args)
 	}
+	arg := args[0]
+	if arg.URI == "" {
+		arg.URI = r.uri
+	}
+	if err := docker.Build(arg); err != nil {
+		return "", fmt.Errorf("build Dockerfile at %s: %w", arg.Dockerfile, err)
+	}
+	if err := r.login(docker, arg.URI); err != nil {
+		return "", err
+	}
+	digest, err = docker.Push(arg.URI, arg.Tags...)
+	if err != nil {
+		return "", fmt.Errorf("push to repo %s: %w", r.name, err)
+	}
+	return digest, nil
+}
 
-	// Perform docker login only if credStore attribute value != ecr-login
-	if !docker.IsEcrCredentialHelperEnabled(args.URI) {
-		username, password, err := r.registry.Auth()
-		if err != nil {
-			return "", fmt.Errorf("get auth: %w", err)
+// buildAndPushPlatforms builds and pushes an image per platform, each tagged with platformImageTag,
+// and then pushes the manifest list of the images with the tags of the build arguments.
+func (r *Repository) buildAndPushPlatforms(docker ContainerLoginBuildPusher, args []*dockerengine.BuildArguments) (digest string, err error) {
+	uri, tags := args[0].URI, args[0].Tags
+	if uri == "" {
+		uri = r.uri
+	}
+	var images []string
+	for _, arg := range args {
+		platformArg := *arg
+		platformArg.URI = uri
+		platformArg.Tags = []string{platformImageTag(tags, arg.Platform)}
+		if err := docker.Build(&platformArg); err != nil {
+			return "", fmt.Errorf("build Dockerfile at %s for platform %s: %w", arg.Dockerfile, arg.Platform, err)
 		}
-
-		if err := docker.Login(args.URI, username, password); err != nil {
-			return "", fmt.Errorf("login to repo %s: %w", r.name, err)
+		images = append(images, fmt.Sprintf("%s:%s", uri, platformArg.Tags[0]))
+	}
+	if err := r.login(docker, uri); err != nil {
+		return "", err
+	}
+	for _, image := range images {
+		if _, err := docker.Push(image); err != nil {
+			return "", fmt.Errorf("push to repo %s: %w", r.name, err)
 		}
 	}
-
-	digest, err = docker.Push(args.URI, args.Tags...)
+	digest, err = docker.PushManifestList(uri, images, tags...)
 	if err != nil {
-		return "", fmt.Errorf("push to repo %s: %w", r.name, err)
+		return "", fmt.Errorf("push manifest list to repo %s: %w", r.name, err)
 	}
 	return digest, nil
 }
 
+// login performs docker login only if credStore attribute value != ecr-login.
+func (r *Repository) login(docker ContainerLoginBuildPusher, uri string) error {
+	if docker.IsEcrCredentialHelperEnabled(uri) {
+		return nil
+	}
+	username, password, err := r.registry.Auth()
+	if err != nil {
+		return fmt.Errorf("get auth: %w", err)
+	}
+	if err := docker.Login(uri, username, password); err != nil {
+		return fmt.Errorf("login to repo %s: %w", r.name, err)
+	}
+	return nil
+}
+
+// platformImageTag returns the tag of the image built for the "os/arch" platform, such as "v1.0.0-linux-arm64",
+// given the tags of the manifest list.
+func platformImageTag(tags []string, platform string) string {
+	tag := "latest"
+	if len(tags) > 0 {
+		tag = tags[0]
+	}
+	return fmt.Sprintf("%s-%s", tag, strings.ReplaceAll(platform, "/", "-"))
+}
+
 // URI returns the uri of the repository.
 func (r *Repository) URI() string {
 	return r.uri
@@ -144,3 +144,107 @@ func TestRepository_BuildAndPush(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_BuildAndPush_Platforms(t *testing.T) {
+	const (
+		inRepoName  = "my-repo"
+		mockRepoURI = "mockRepoURI"
+		mockDigest  = "sha256:f1d4ae3f7261a72e98c6ebefe9985cf10a0ea5bd762585a43e0700ed99863807"
+	)
+	amd64Args := dockerengine.BuildArguments{
+		URI:        mockRepoURI,
+		Dockerfile: "Dockerfile",
+		Context:    ".",
+		Platform:   "linux/amd64",
+		Tags:       []string{"v1"},
+	}
+	arm64Args := dockerengine.BuildArguments{
+		URI:        mockRepoURI,
+		Dockerfile: "arm/Dockerfile",
+		Context:    "arm",
+		Platform:   "linux/arm64",
+		Tags:       []string{"v1"},
+	}
+	wantedImages := []string{"mockRepoURI:v1-linux-amd64", "mockRepoURI:v1-linux-arm64"}
+
+	testCases := map[string]struct {
+		inMockDocker func(m *mocks.MockContainerLoginBuildPusher)
+
+		wantedError  error
+		wantedDigest string
+	}{
+		"failed to build the image of a platform": {
+			inMockDocker: func(m *mocks.MockContainerLoginBuildPusher) {
+				m.EXPECT().Build(gomock.Any()).Return(nil)
+				m.EXPECT().Build(gomock.Any()).Return(errors.New("some error"))
+			},
+			wantedError: errors.New("build Dockerfile at arm/Dockerfile for platform linux/arm64: some error"),
+		},
+		"failed to push the manifest list": {
+			inMockDocker: func(m *mocks.MockContainerLoginBuildPusher) {
+				m.EXPECT().Build(gomock.Any()).Times(2)
+				m.EXPECT().IsEcrCredentialHelperEnabled(mockRepoURI).Return(true)
+				m.EXPECT().Push(gomock.Any()).Times(2)
+				m.EXPECT().PushManifestList(mockRepoURI, wantedImages, "v1").Return("", errors.New("some error"))
+			},
+			wantedError: errors.New("push manifest list to repo my-repo: some error"),
+		},
+		"success": {
+			inMockDocker: func(m *mocks.MockContainerLoginBuildPusher) {
+				amd64 := amd64Args
+				amd64.Tags = []string{"v1-linux-amd64"}
+				arm64 := arm64Args
+				arm64.Tags = []string{"v1-linux-arm64"}
+				gomock.InOrder(
+					m.EXPECT().Build(&amd64).Return(nil),
+					m.EXPECT().Build(&arm64).Return(nil),
+					m.EXPECT().IsEcrCredentialHelperEnabled(mockRepoURI).Return(true),
+					m.EXPECT().Push("mockRepoURI:v1-linux-amd64").Return("sha256:amd64", nil),
+					m.EXPECT().Push("mockRepoURI:v1-linux-arm64").Return("sha256:arm64", nil),
+					m.EXPECT().PushManifestList(mockRepoURI, wantedImages, "v1").Return(mockDigest, nil),
+				)
+			},
+			wantedDigest: mockDigest,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDocker := mocks.NewMockContainerLoginBuildPusher(ctrl)
+			tc.inMockDocker(mockDocker)
+
+			repo := &Repository{
+				name:     inRepoName,
+				registry: mocks.NewMockRegistry(ctrl),
+
+				uri: mockRepoURI,
+			}
+
+			amd64, arm64 := amd64Args, arm64Args
+			digest, err := repo.BuildAndPush(mockDocker, &amd64, &arm64)
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedDigest, digest)
+			}
+		})
+	}
+}
+
+func TestRepository_BuildAndPush_NoArguments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := &Repository{
+		name:     "my-repo",
+		registry: mocks.NewMockRegistry(ctrl),
+
+		uri: "mockRepoURI",
+	}
+
+	_, err := repo.BuildAndPush(mocks.NewMockContainerLoginBuildPusher(ctrl))
+	require.EqualError(t, err, "no build arguments to build the image from")
+}
@@ -10,6 +10,7 @@ import (
 
 var (
 	errNoSubnetFound = errors.New("no subnets found")
+	errNoTaskStarted = errors.New("no task was started")
 
 	errVPCGetterNil     = errors.New("vpc getter is not set")
 	errClusterGetterNil = errors.New("cluster getter is not set")
@@ -32,3 +33,12 @@ type errGetDefaultCluster struct {
 func (e *errGetDefaultCluster) Error() string {
 	return fmt.Sprintf("get default cluster: %v", e.parentErr)
 }
+
+type errHookTaskFailed struct {
+	groupName string
+	reason    string
+}
+
+func (e *errHookTaskFailed) Error() string {
+	return fmt.Sprintf("task %s failed: %s", e.groupName, e.reason)
+}
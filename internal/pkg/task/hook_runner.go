@@ -0,0 +1,137 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awscloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/deploy"
+	termprogress "github.com/aws/copilot-cli/internal/pkg/term/progress"
+)
+
+const (
+	defaultHookTaskCPU    = 256
+	defaultHookTaskMemory = 512
+)
+
+// HookInput holds the configuration of the one-off task run by a workload hook.
+type HookInput struct {
+	// Name of the task group, used to name the task resources.
+	Name    string
+	Image   string
+	Command []string
+
+	// Size of the task. Defaults to 256 CPU units and 512 MiB if zero.
+	CPU    int
+	Memory int
+
+	EnvVars map[string]string
+	Secrets map[string]string
+
+	// ARNs of the roles of the task. The task resources create default roles if empty.
+	TaskRole      string
+	ExecutionRole string
+}
+
+// HookRunner runs the one-off task of a workload hook, such as a database migration before a deployment,
+// in an environment and waits for it to exit.
+type HookRunner struct {
+	// App and Env in which the task is launched.
+	App string
+	Env string
+
+	// Role assumed by CloudFormation to deploy the task resources.
+	RoleARN string
+
+	// Interfaces to interact with dependencies. Must not be nil.
+	Out                  termprogress.FileWriter
+	Deployer             ResourcesDeployer
+	VPCGetter            VPCGetter
+	ClusterGetter        ClusterGetter
+	Starter              Runner
+	EnvironmentDescriber EnvironmentDescriber
+	Waiter               StoppedWaiter
+}
+
+// Run deploys the task definition of the hook, runs a single task in the environment and waits for it to stop.
+// It returns an error if any container of the task does not exit with code 0.
+func (r *HookRunner) Run(in HookInput) error {
+	var opts []awscloudformation.StackOption
+	if r.RoleARN != "" {
+		opts = append(opts, awscloudformation.WithRoleARN(r.RoleARN))
+	}
+	cpu, memory := in.CPU, in.Memory
+	if cpu == 0 {
+		cpu = defaultHookTaskCPU
+	}
+	if memory == 0 {
+		memory = defaultHookTaskMemory
+	}
+	if err := r.Deployer.DeployTask(r.Out, &deploy.CreateTaskResourcesInput{
+		Name:          in.Name,
+		CPU:           cpu,
+		Memory:        memory,
+		Image:         in.Image,
+		TaskRole:      in.TaskRole,
+		ExecutionRole: in.ExecutionRole,
+		Command:       in.Command,
+		EnvVars:       in.EnvVars,
+		Secrets:       in.Secrets,
+		App:           r.App,
+		Env:           r.Env,
+	}, opts...); err != nil {
+		return fmt.Errorf("provision resources for task %s: %w", in.Name, err)
+	}
+
+	envRunner := &EnvRunner{
+		Count:     1,
+		GroupName: in.Name,
+
+		App: r.App,
+		Env: r.Env,
+
+		VPCGetter:            r.VPCGetter,
+		ClusterGetter:        r.ClusterGetter,
+		Starter:              r.Starter,
+		EnvironmentDescriber: r.EnvironmentDescriber,
+	}
+	tasks, err := envRunner.Run()
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		return &errRunTask{
+			groupName: in.Name,
+			parentErr: errNoTaskStarted,
+		}
+	}
+
+	taskARNs := make([]string, len(tasks))
+	for i, task := range tasks {
+		taskARNs[i] = task.TaskARN
+	}
+	stopped, err := r.Waiter.WaitUntilTasksStopped(tasks[0].ClusterARN, taskARNs)
+	if err != nil {
+		return fmt.Errorf("wait for task %s to stop: %w", in.Name, err)
+	}
+	for _, task := range stopped {
+		for _, container := range task.Containers {
+			if container.ExitCode == nil {
+				return &errHookTaskFailed{
+					groupName: in.Name,
+					reason:    fmt.Sprintf("container %s did not exit: %s", aws.StringValue(container.Name), aws.StringValue(task.StoppedReason)),
+				}
+			}
+			if code := aws.Int64Value(container.ExitCode); code != 0 {
+				return &errHookTaskFailed{
+					groupName: in.Name,
+					reason:    fmt.Sprintf("container %s exited with code %d", aws.StringValue(container.Name), code),
+				}
+			}
+		}
+	}
+	return nil
+}
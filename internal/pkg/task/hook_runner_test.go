@@ -0,0 +1,212 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	awscloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
+	"github.com/aws/copilot-cli/internal/pkg/aws/ecs"
+	"github.com/aws/copilot-cli/internal/pkg/deploy"
+	"github.com/aws/copilot-cli/internal/pkg/describe"
+	"github.com/aws/copilot-cli/internal/pkg/task/mocks"
+	termprogress "github.com/aws/copilot-cli/internal/pkg/term/progress"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+type hookRunnerMocks struct {
+	deployer  *mocks.MockResourcesDeployer
+	vpc       *mocks.MockVPCGetter
+	cluster   *mocks.MockClusterGetter
+	starter   *mocks.MockRunner
+	describer *mocks.MockEnvironmentDescriber
+	waiter    *mocks.MockStoppedWaiter
+}
+
+func TestHookRunner_Run(t *testing.T) {
+	const (
+		inApp  = "my-app"
+		inEnv  = "my-env"
+		inRole = "arn:aws:iam::123456789012:role/my-app-my-env-CFNExecutionRole"
+	)
+	inHook := HookInput{
+		Name:          "my-app-my-env-api-pre-deploy",
+		Image:         "migrate/migrate:v4",
+		Command:       []string{"up"},
+		CPU:           1024,
+		Memory:        2048,
+		EnvVars:       map[string]string{"DB_NAME": "orders"},
+		Secrets:       map[string]string{"DB_PASSWORD": "/copilot/my-app/my-env/secrets/db-password"},
+		TaskRole:      "arn:aws:iam::123456789012:role/my-app-my-env-api-TaskRole",
+		ExecutionRole: "arn:aws:iam::123456789012:role/my-app-my-env-api-ExecutionRole",
+	}
+	mockRunTask := func(m *hookRunnerMocks) {
+		m.cluster.EXPECT().ClusterARN(inApp, inEnv).Return("cluster-1", nil)
+		m.describer.EXPECT().Describe().Return(&describe.EnvDescription{
+			EnvironmentVPC: describe.EnvironmentVPC{
+				PublicSubnetIDs: []string{"subnet-1"},
+			},
+		}, nil)
+		m.vpc.EXPECT().SecurityGroups(gomock.Any()).Return([]string{"sg-1"}, nil)
+		m.starter.EXPECT().RunTask(gomock.Any()).Return([]*ecs.Task{
+			{
+				TaskArn:    aws.String("task-1"),
+				ClusterArn: aws.String("cluster-1"),
+			},
+		}, nil)
+	}
+	stoppedTask := func(exitCode *int64) []*ecs.Task {
+		return []*ecs.Task{
+			{
+				TaskArn:       aws.String("task-1"),
+				StoppedReason: aws.String("Essential container in task exited"),
+				Containers: []*awsecs.Container{
+					{
+						Name:     aws.String("my-app-my-env-api-pre-deploy"),
+						ExitCode: exitCode,
+					},
+				},
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		inHook     *HookInput
+		setupMocks func(m *hookRunnerMocks)
+
+		wantedError error
+	}{
+		"error if fail to deploy the task resources": {
+			setupMocks: func(m *hookRunnerMocks) {
+				m.deployer.EXPECT().DeployTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+			},
+			wantedError: errors.New("provision resources for task my-app-my-env-api-pre-deploy: some error"),
+		},
+		"error if fail to run the task": {
+			setupMocks: func(m *hookRunnerMocks) {
+				m.deployer.EXPECT().DeployTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.cluster.EXPECT().ClusterARN(inApp, inEnv).Return("", errors.New("some error"))
+			},
+			wantedError: errors.New("get cluster for environment my-env: some error"),
+		},
+		"error if fail to wait for the task to stop": {
+			setupMocks: func(m *hookRunnerMocks) {
+				m.deployer.EXPECT().DeployTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRunTask(m)
+				m.waiter.EXPECT().WaitUntilTasksStopped("cluster-1", []string{"task-1"}).Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("wait for task my-app-my-env-api-pre-deploy to stop: some error"),
+		},
+		"error if the container exits with a non-zero code": {
+			setupMocks: func(m *hookRunnerMocks) {
+				m.deployer.EXPECT().DeployTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRunTask(m)
+				m.waiter.EXPECT().WaitUntilTasksStopped("cluster-1", []string{"task-1"}).Return(stoppedTask(aws.Int64(1)), nil)
+			},
+			wantedError: errors.New("task my-app-my-env-api-pre-deploy failed: container my-app-my-env-api-pre-deploy exited with code 1"),
+		},
+		"error if the container never exits": {
+			setupMocks: func(m *hookRunnerMocks) {
+				m.deployer.EXPECT().DeployTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRunTask(m)
+				m.waiter.EXPECT().WaitUntilTasksStopped("cluster-1", []string{"task-1"}).Return(stoppedTask(nil), nil)
+			},
+			wantedError: errors.New("task my-app-my-env-api-pre-deploy failed: container my-app-my-env-api-pre-deploy did not exit: Essential container in task exited"),
+		},
+		"success": {
+			setupMocks: func(m *hookRunnerMocks) {
+				m.deployer.EXPECT().DeployTask(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ termprogress.FileWriter, in *deploy.CreateTaskResourcesInput, opts ...awscloudformation.StackOption) error {
+						require.Equal(t, &deploy.CreateTaskResourcesInput{
+							Name:          "my-app-my-env-api-pre-deploy",
+							CPU:           1024,
+							Memory:        2048,
+							Image:         "migrate/migrate:v4",
+							TaskRole:      "arn:aws:iam::123456789012:role/my-app-my-env-api-TaskRole",
+							ExecutionRole: "arn:aws:iam::123456789012:role/my-app-my-env-api-ExecutionRole",
+							Command:       []string{"up"},
+							EnvVars:       map[string]string{"DB_NAME": "orders"},
+							Secrets:       map[string]string{"DB_PASSWORD": "/copilot/my-app/my-env/secrets/db-password"},
+							App:           inApp,
+							Env:           inEnv,
+						}, in)
+						require.Len(t, opts, 1)
+						return nil
+					})
+				mockRunTask(m)
+				m.waiter.EXPECT().WaitUntilTasksStopped("cluster-1", []string{"task-1"}).Return(stoppedTask(aws.Int64(0)), nil)
+			},
+		},
+		"success with the default task size": {
+			inHook: &HookInput{
+				Name:    "my-app-my-env-api-pre-deploy",
+				Image:   "migrate/migrate:v4",
+				Command: []string{"up"},
+			},
+			setupMocks: func(m *hookRunnerMocks) {
+				m.deployer.EXPECT().DeployTask(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ termprogress.FileWriter, in *deploy.CreateTaskResourcesInput, opts ...awscloudformation.StackOption) error {
+						require.Equal(t, &deploy.CreateTaskResourcesInput{
+							Name:    "my-app-my-env-api-pre-deploy",
+							CPU:     256,
+							Memory:  512,
+							Image:   "migrate/migrate:v4",
+							Command: []string{"up"},
+							App:     inApp,
+							Env:     inEnv,
+						}, in)
+						return nil
+					})
+				mockRunTask(m)
+				m.waiter.EXPECT().WaitUntilTasksStopped("cluster-1", []string{"task-1"}).Return(stoppedTask(aws.Int64(0)), nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := &hookRunnerMocks{
+				deployer:  mocks.NewMockResourcesDeployer(ctrl),
+				vpc:       mocks.NewMockVPCGetter(ctrl),
+				cluster:   mocks.NewMockClusterGetter(ctrl),
+				starter:   mocks.NewMockRunner(ctrl),
+				describer: mocks.NewMockEnvironmentDescriber(ctrl),
+				waiter:    mocks.NewMockStoppedWaiter(ctrl),
+			}
+			tc.setupMocks(m)
+
+			runner := &HookRunner{
+				App:     inApp,
+				Env:     inEnv,
+				RoleARN: inRole,
+
+				Deployer:             m.deployer,
+				VPCGetter:            m.vpc,
+				ClusterGetter:        m.cluster,
+				Starter:              m.starter,
+				EnvironmentDescriber: m.describer,
+				Waiter:               m.waiter,
+			}
+
+			in := inHook
+			if tc.inHook != nil {
+				in = *tc.inHook
+			}
+			err := runner.Run(in)
+
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
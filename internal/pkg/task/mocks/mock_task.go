@@ -7,9 +7,12 @@ package mocks
 import (
 	reflect "reflect"
 
+	cloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
 	ec2 "github.com/aws/copilot-cli/internal/pkg/aws/ec2"
 	ecs "github.com/aws/copilot-cli/internal/pkg/aws/ecs"
+	deploy "github.com/aws/copilot-cli/internal/pkg/deploy"
 	describe "github.com/aws/copilot-cli/internal/pkg/describe"
+	progress "github.com/aws/copilot-cli/internal/pkg/term/progress"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -225,3 +228,83 @@ func (mr *MockRunnerMockRecorder) RunTask(input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunTask", reflect.TypeOf((*MockRunner)(nil).RunTask), input)
 }
+
+// MockResourcesDeployer is a mock of ResourcesDeployer interface.
+type MockResourcesDeployer struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourcesDeployerMockRecorder
+}
+
+// MockResourcesDeployerMockRecorder is the mock recorder for MockResourcesDeployer.
+type MockResourcesDeployerMockRecorder struct {
+	mock *MockResourcesDeployer
+}
+
+// NewMockResourcesDeployer creates a new mock instance.
+func NewMockResourcesDeployer(ctrl *gomock.Controller) *MockResourcesDeployer {
+	mock := &MockResourcesDeployer{ctrl: ctrl}
+	mock.recorder = &MockResourcesDeployerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourcesDeployer) EXPECT() *MockResourcesDeployerMockRecorder {
+	return m.recorder
+}
+
+// DeployTask mocks base method.
+func (m *MockResourcesDeployer) DeployTask(out progress.FileWriter, input *deploy.CreateTaskResourcesInput, opts ...cloudformation.StackOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{out, input}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeployTask", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeployTask indicates an expected call of DeployTask.
+func (mr *MockResourcesDeployerMockRecorder) DeployTask(out, input interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{out, input}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployTask", reflect.TypeOf((*MockResourcesDeployer)(nil).DeployTask), varargs...)
+}
+
+// MockStoppedWaiter is a mock of StoppedWaiter interface.
+type MockStoppedWaiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoppedWaiterMockRecorder
+}
+
+// MockStoppedWaiterMockRecorder is the mock recorder for MockStoppedWaiter.
+type MockStoppedWaiterMockRecorder struct {
+	mock *MockStoppedWaiter
+}
+
+// NewMockStoppedWaiter creates a new mock instance.
+func NewMockStoppedWaiter(ctrl *gomock.Controller) *MockStoppedWaiter {
+	mock := &MockStoppedWaiter{ctrl: ctrl}
+	mock.recorder = &MockStoppedWaiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoppedWaiter) EXPECT() *MockStoppedWaiterMockRecorder {
+	return m.recorder
+}
+
+// WaitUntilTasksStopped mocks base method.
+func (m *MockStoppedWaiter) WaitUntilTasksStopped(cluster string, taskARNs []string) ([]*ecs.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitUntilTasksStopped", cluster, taskARNs)
+	ret0, _ := ret[0].([]*ecs.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitUntilTasksStopped indicates an expected call of WaitUntilTasksStopped.
+func (mr *MockStoppedWaiterMockRecorder) WaitUntilTasksStopped(cluster, taskARNs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilTasksStopped", reflect.TypeOf((*MockStoppedWaiter)(nil).WaitUntilTasksStopped), cluster, taskARNs)
+}
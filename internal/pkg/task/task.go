@@ -12,9 +12,12 @@ import (
 	"github.com/aws/copilot-cli/internal/pkg/docker/dockerengine"
 
 	"github.com/aws/aws-sdk-go/aws"
+	awscloudformation "github.com/aws/copilot-cli/internal/pkg/aws/cloudformation"
 	"github.com/aws/copilot-cli/internal/pkg/aws/ec2"
 	"github.com/aws/copilot-cli/internal/pkg/aws/ecs"
+	"github.com/aws/copilot-cli/internal/pkg/deploy"
 	"github.com/aws/copilot-cli/internal/pkg/describe"
+	termprogress "github.com/aws/copilot-cli/internal/pkg/term/progress"
 )
 
 // VPCGetter wraps methods of getting VPC info.
@@ -42,6 +45,16 @@ type Runner interface {
 	RunTask(input ecs.RunTaskInput) ([]*ecs.Task, error)
 }
 
+// ResourcesDeployer wraps the method of deploying the resources of a one-off task.
+type ResourcesDeployer interface {
+	DeployTask(out termprogress.FileWriter, input *deploy.CreateTaskResourcesInput, opts ...awscloudformation.StackOption) error
+}
+
+// StoppedWaiter wraps the method of waiting for tasks to stop.
+type StoppedWaiter interface {
+	WaitUntilTasksStopped(cluster string, taskARNs []string) ([]*ecs.Task, error)
+}
+
 // Task represents a one-off workload that runs until completed or an error occurs.
 type Task struct {
 	TaskARN    string
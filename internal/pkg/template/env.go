@@ -22,6 +22,7 @@ var (
 		"custom-resources",
 		"custom-resources-role",
 		"environment-manager-role",
+		"github-deploy-role",
 		"lambdas",
 		"vpc-resources",
 		"nat-gateways",
@@ -39,8 +40,9 @@ type EnvOpts struct {
 	CustomDomainLambda        string
 	ScriptBucketName          string
 
-	ImportVPC *config.ImportVPC
-	VPCConfig *config.AdjustVPC
+	ImportVPC  *config.ImportVPC
+	VPCConfig  *config.AdjustVPC
+	GitHubOIDC *config.GitHubOIDC
 
 	LatestVersion string
 }
@@ -19,6 +19,7 @@ func TestTemplate_ParseEnv(t *testing.T) {
 				"templates/environment/partials/custom-resources.yml":         []byte("custom-resources"),
 				"templates/environment/partials/custom-resources-role.yml":    []byte("custom-resources-role"),
 				"templates/environment/partials/environment-manager-role.yml": []byte("environment-manager-role"),
+				"templates/environment/partials/github-deploy-role.yml":       []byte("github-deploy-role"),
 				"templates/environment/partials/lambdas.yml":                  []byte("lambdas"),
 				"templates/environment/partials/vpc-resources.yml":            []byte("vpc-resources"),
 				"templates/environment/partials/nat-gateways.yml":             []byte("nat-gateways"),
@@ -250,6 +250,21 @@ func TestGenerateSNSJSON(t *testing.T) {
 			},
 			wanted: `{"tests":"arn:aws:sns:us-west-2:123456789012:appName-envName-svcName-tests"}`,
 		},
+		"FIFO topics have the .fifo suffix in their ARN": {
+			in: []*Topic{
+				{
+					Name:            aws.String("orders"),
+					FIFOTopicConfig: &FIFOTopicConfig{},
+					AccountID:       "123456789012",
+					Region:          "us-west-2",
+					Partition:       "aws",
+					App:             "appName",
+					Env:             "envName",
+					Svc:             "svcName",
+				},
+			},
+			wanted: `{"orders":"arn:aws:sns:us-west-2:123456789012:appName-envName-svcName-orders.fifo"}`,
+		},
 		"Topics with no names show empty": {
 			in: []*Topic{
 				{
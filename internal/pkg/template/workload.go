@@ -46,6 +46,9 @@ const (
 	OSWindowsServerFull = "WINDOWS_SERVER_2019_FULL"
 	OSWindowsServerCore = "WINDOWS_SERVER_2019_CORE"
 
+	OSWindowsServer2022Full = "WINDOWS_SERVER_2022_FULL"
+	OSWindowsServer2022Core = "WINDOWS_SERVER_2022_CORE"
+
 	ArchX86   = "X86_64"
 	ArchARM   = "ARM"
 	ArchARM64 = "ARM64"
@@ -54,6 +57,12 @@ const (
 // Constants for ARN options.
 const (
 	snsARNPattern = "arn:%s:sns:%s:%s:%s-%s-%s-%s"
+
+	// FIFO topic names must end with this suffix.
+	fifoTopicSuffix = ".fifo"
+
+	// Environment variables injected by Copilot are prefixed with COPILOT_ unless a custom prefix is set.
+	defaultEnvVarPrefix = "COPILOT_"
 )
 
 var (
@@ -73,6 +82,7 @@ var (
 		"sidecars",
 		"logconfig",
 		"autoscaling",
+		"rollback-alarms",
 		"eventrule",
 		"state-machine",
 		"state-machine-definition.json",
@@ -87,11 +97,13 @@ var (
 		"subscribe",
 		"nlb",
 		"vpc-connector",
+		"target-group-properties",
+		"bluegreen",
 	}
 
 	// Operating systems to determine Fargate platform versions.
 	osFamiliesForPV100 = []string{
-		OSWindowsServerFull, OSWindowsServerCore,
+		OSWindowsServerFull, OSWindowsServerCore, OSWindowsServer2022Full, OSWindowsServer2022Core,
 	}
 )
 
@@ -107,20 +119,23 @@ type WorkloadNestedStackOpts struct {
 
 // SidecarOpts holds configuration that's needed if the service has sidecar containers.
 type SidecarOpts struct {
-	Name         *string
-	Image        *string
-	Essential    *bool
-	Port         *string
-	Protocol     *string
-	CredsParam   *string
-	Variables    map[string]string
-	Secrets      map[string]string
-	Storage      SidecarStorageOpts
-	DockerLabels map[string]string
-	DependsOn    map[string]string
-	EntryPoint   []string
-	Command      []string
-	HealthCheck  *ContainerHealthCheck
+	Name           *string
+	Image          *string
+	Essential      *bool
+	Port           *string
+	Protocol       *string
+	CredsParam     *string
+	Variables      map[string]string
+	Secrets        map[string]string
+	Storage        SidecarStorageOpts
+	DockerLabels   map[string]string
+	DependsOn      map[string]string
+	EntryPoint     []string
+	Command        []string
+	PseudoTerminal *bool
+	Interactive    *bool
+	Ulimits        []*Ulimit
+	HealthCheck    *ContainerHealthCheck
 }
 
 // SidecarStorageOpts holds data structures for rendering Mount Points inside of a sidecar.
@@ -149,6 +164,13 @@ type EFSPermission struct {
 	AccessPointID *string
 }
 
+// Ulimit holds information needed to render a resource limit in a containerdefinition.
+type Ulimit struct {
+	Name      string
+	SoftLimit int
+	HardLimit int
+}
+
 // MountPoint holds information needed to render a MountPoint in a containerdefinition.
 type MountPoint struct {
 	ContainerPath *string
@@ -186,6 +208,7 @@ type EFSVolumeConfiguration struct {
 // its logs.
 type LogConfigOpts struct {
 	Image          *string
+	ConfigType     string
 	Destination    map[string]string
 	EnableMetadata *string
 	SecretOptions  map[string]string
@@ -194,9 +217,18 @@ type LogConfigOpts struct {
 	Secrets        map[string]string
 }
 
+// AppConfigSecretOpts holds configuration for an AWS AppConfig configuration profile that's served
+// to the main container by the AppConfig agent sidecar.
+type AppConfigSecretOpts struct {
+	Application string
+	Environment string
+	Profile     string
+}
+
 // HTTPHealthCheckOpts holds configuration that's needed for HTTP Health Check.
 type HTTPHealthCheckOpts struct {
 	HealthCheckPath     string
+	Port                string // Empty for the port that receives the traffic.
 	SuccessCodes        string
 	HealthyThreshold    *int64
 	UnhealthyThreshold  *int64
@@ -213,12 +245,79 @@ type NetworkLoadBalancerListener struct {
 	TargetContainer string
 	TargetPort      string
 	SSLPolicy       *string
+
+	// Target group attributes.
+	Stickiness       *bool
+	PreserveClientIP *bool
+	ProxyProtocolV2  bool
+
+	HealthCheck HTTPHealthCheckOpts
+}
+
+// IngressProtocols returns the IP protocols that the network load balancer uses to reach the targets of the listener.
+// TLS listeners forward to TCP targets, and TCP_UDP listeners need both protocols.
+func (l NetworkLoadBalancerListener) IngressProtocols() []string {
+	switch l.Protocol {
+	case "UDP":
+		return []string{"udp"}
+	case "TCP_UDP":
+		return []string{"tcp", "udp"}
+	default:
+		return []string{"tcp"}
+	}
+}
+
+// AliasRoutingOpts holds configuration for the weighted or failover Route 53 records of a service's aliases.
+type AliasRoutingOpts struct {
+	HostedZoneID  string
+	SetIdentifier *string // If nil, the environment name is used instead.
+	Weight        *int
+	Failover      string // PRIMARY or SECONDARY.
+	HealthCheckID *string
+}
+
+// RoutingRuleOpts holds configuration for a listener rule that routes requests to a service in addition to the rule of its path.
+type RoutingRuleOpts struct {
+	Path             string   // Path without leading or trailing slashes.
+	Aliases          []string // If empty, the aliases of the service are used instead.
+	AllowedSourceIps []string
+}
+
+// ObservabilityOpts holds configuration for tracing requests across services.
+type ObservabilityOpts struct {
+	TracePropagators string // Value of OTEL_PROPAGATORS, such as "xray" or "tracecontext".
+	Tracing          string // Vendor of the tracing sidecar, "awsxray" or "awsotel". Grants the task role permissions to send traces.
+}
+
+// HostnameVariableOpts holds configuration for the environment variable that exposes the public hostname of a service.
+type HostnameVariableOpts struct {
+	Name  string
+	Alias string // If empty, the DNS name of the public load balancer is used instead.
 }
 
 // NetworkLoadBalancer holds configuration that's needed for a Network Load Balancer.
 type NetworkLoadBalancer struct {
-	PublicSubnetCIDRs []string
-	Listener          NetworkLoadBalancerListener
+	PublicSubnetCIDRs   []string
+	Listener            NetworkLoadBalancerListener
+	AdditionalListeners []NetworkLoadBalancerListener
+
+	// The ports that the listeners target in addition to the port that each container already exposes.
+	MainContainerPortMappings []PortMapping
+	SidecarPortMappings       map[string][]PortMapping // Keyed by sidecar name.
+}
+
+// PortMappingsOfSidecar returns the ports that the listeners target in addition to the port that the sidecar exposes.
+func (nlb *NetworkLoadBalancer) PortMappingsOfSidecar(name *string) []PortMapping {
+	if nlb == nil || name == nil {
+		return nil
+	}
+	return nlb.SidecarPortMappings[*name]
+}
+
+// PortMapping holds a port and protocol of a container, such as "8080" and "udp".
+type PortMapping struct {
+	ContainerPort string
+	Protocol      string
 }
 
 // AdvancedCount holds configuration for autoscaling and capacity provider
@@ -246,15 +345,50 @@ type CapacityProviderStrategy struct {
 	CapacityProvider string
 }
 
+// DeploymentConfigurationOpts holds the bounds of the number of running tasks during a service deployment,
+// in percent of the desired count. ECS defaults of 100 and 200 are used if they're nil.
+type DeploymentConfigurationOpts struct {
+	MinHealthyPercent *int
+	MaxPercent        *int
+}
+
+// BlueGreenOpts holds configuration for a blue/green deployment of a service managed by CodeDeploy.
+type BlueGreenOpts struct {
+	TerminationWaitMinutes int
+	TestListenerPort       *uint16  // Port of the listener for test traffic, nil if there's no test traffic route.
+	HookFunctionARNs       []string // ARNs of the Lambda functions that validate the deployment.
+	ServiceTaskDefinition  string   // ARN of the task definition that the existing service was created with, empty for a new service.
+}
+
+// RollbackAlarmsOpts holds configuration for the CloudWatch alarms that roll back a service deployment.
+type RollbackAlarmsOpts struct {
+	AlarmNames        []string // Names of existing alarms.
+	CPUUtilization    *float64 // Threshold in percent of the CPU utilization alarm to create.
+	MemoryUtilization *float64 // Threshold in percent of the memory utilization alarm to create.
+}
+
 // AutoscalingOpts holds configuration that's needed for Auto Scaling.
 type AutoscalingOpts struct {
-	MinCapacity  *int
-	MaxCapacity  *int
-	CPU          *float64
-	Memory       *float64
-	Requests     *float64
-	ResponseTime *float64
-	QueueDelay   *AutoscalingQueueDelayOpts
+	MinCapacity      *int
+	MaxCapacity      *int
+	CPU              *float64
+	Memory           *float64
+	Requests         *float64
+	ResponseTime     *float64
+	QueueDelay       *AutoscalingQueueDelayOpts
+	CustomMetric     *AutoscalingCustomMetricOpts
+	ScaleInCooldown  *int64
+	ScaleOutCooldown *int64
+	ScheduledActions []AutoscalingScheduledActionOpts
+}
+
+// AutoscalingScheduledActionOpts holds configuration to change the capacity bounds of a service on a schedule.
+type AutoscalingScheduledActionOpts struct {
+	Name        string
+	Schedule    string
+	Timezone    *string
+	MinCapacity int
+	MaxCapacity int
 }
 
 // AutoscalingQueueDelayOpts holds configuration to scale SQS queues.
@@ -262,8 +396,41 @@ type AutoscalingQueueDelayOpts struct {
 	AcceptableBacklogPerTask int
 }
 
+// AutoscalingCustomMetricOpts holds configuration to scale on a CloudWatch metric.
+type AutoscalingCustomMetricOpts struct {
+	Namespace  string
+	MetricName string
+	Dimensions []AutoscalingMetricDimension
+	Statistic  string
+	Target     float64
+}
+
+// AutoscalingMetricDimension holds a dimension of a CloudWatch metric.
+type AutoscalingMetricDimension struct {
+	Name  string
+	Value string
+}
+
+// AppRunnerAutoScalingOpts holds configuration for the auto scaling of an App Runner service.
+// Fields left nil fall back to the App Runner defaults.
+type AppRunnerAutoScalingOpts struct {
+	MinSize        *int
+	MaxSize        *int
+	MaxConcurrency *int
+}
+
 // ExecuteCommandOpts holds configuration that's needed for ECS Execute Command.
-type ExecuteCommandOpts struct{}
+type ExecuteCommandOpts struct {
+	Logging *ExecuteCommandLoggingOpts
+}
+
+// ExecuteCommandLoggingOpts holds the destinations of ECS Execute Command audit logs.
+type ExecuteCommandLoggingOpts struct {
+	CloudWatchLogGroup          string
+	CloudWatchEncryptionEnabled bool
+	S3Bucket                    string
+	S3KeyPrefix                 string
+}
 
 // StateMachineOpts holds configuration needed for State Machine retries and timeout.
 type StateMachineOpts struct {
@@ -271,6 +438,12 @@ type StateMachineOpts struct {
 	Retries *int
 }
 
+// FIFOTopicConfig holds configuration needed if the topic is a FIFO topic.
+type FIFOTopicConfig struct {
+	ContentBasedDeduplication *bool
+	ThroughputScope           *string // Scope of the deduplication and the throughput, "Topic" or "MessageGroup".
+}
+
 // PublishOpts holds configuration needed if the service has publishers.
 type PublishOpts struct {
 	Topics []*Topic
@@ -278,7 +451,8 @@ type PublishOpts struct {
 
 // Topic holds information needed to render a SNSTopic in a container definition.
 type Topic struct {
-	Name *string
+	Name            *string
+	FIFOTopicConfig *FIFOTopicConfig
 
 	Region    string
 	Partition string
@@ -308,7 +482,9 @@ func (s *SubscribeOpts) HasTopicQueues() bool {
 type TopicSubscription struct {
 	Name    *string
 	Service *string
+	ARN     *string // Set if the topic is imported instead of published by a Copilot service.
 	Queue   *SQSQueue
+	FIFO    bool // Set if the topic is a FIFO topic, whose messages are delivered to a FIFO queue.
 }
 
 // SQSQueue holds information needed to render a SQS Queue in a container definition.
@@ -317,6 +493,7 @@ type SQSQueue struct {
 	Delay      *int64
 	Timeout    *int64
 	DeadLetter *DeadLetterQueue
+	FIFO       bool
 }
 
 // DeadLetterQueue holds information needed to render a dead-letter SQS Queue in a container definition.
@@ -328,7 +505,12 @@ type DeadLetterQueue struct {
 type NetworkOpts struct {
 	AssignPublicIP string
 	SubnetsType    string
+	SubnetIDs      []string // Subnets to place tasks in instead of the subnets of SubnetsType.
 	SecurityGroups []string
+	// Names of the CloudFormation exports that hold the IDs of additional security groups.
+	SecurityGroupImports []string
+	// DenyDefaultSecurityGroup omits the security group of the environment from the tasks.
+	DenyDefaultSecurityGroup bool
 }
 
 // RuntimePlatformOpts holds configuration needed for Platform configuration.
@@ -366,36 +548,57 @@ func (p RuntimePlatformOpts) isEmpty() bool {
 type WorkloadOpts struct {
 	// Additional options that are common between **all** workload templates.
 	Variables                map[string]string
+	VariableImports          map[string]string // Names of the CloudFormation exports imported by environment variables.
 	Secrets                  map[string]string
+	AppConfigSecrets         map[string]*AppConfigSecretOpts
 	Aliases                  []string
-	Tags                     map[string]string        // Used by App Runner workloads to tag App Runner service resources
+	HostedZoneAliases        map[string][]string      // Aliases keyed by the ID of the hosted zone in which their records are created.
+	Tags                     map[string]string        // Used by App Runner workloads and ECS services that propagate stack tags to tag service resources
 	NestedStack              *WorkloadNestedStackOpts // Outputs from nested stacks such as the addons stack.
 	AddonsExtraParams        string                   // Additional user defined Parameters for the addons stack.
 	Sidecars                 []*SidecarOpts
 	LogConfig                *LogConfigOpts
+	LogGroupName             string // Custom name of the awslogs log group, derived from the stack if empty.
+	ImportLogGroup           bool   // Use the existing log group named LogGroupName instead of creating one.
 	Autoscaling              *AutoscalingOpts
+	RollbackAlarms           *RollbackAlarmsOpts
+	DeploymentConfiguration  DeploymentConfigurationOpts
+	BlueGreen                *BlueGreenOpts
 	CapacityProviders        []*CapacityProviderStrategy
 	DesiredCountOnSpot       *int
+	AZRebalancing            bool
 	Storage                  *StorageOpts
 	Network                  NetworkOpts
 	ExecuteCommand           *ExecuteCommandOpts
 	Platform                 RuntimePlatformOpts
 	EntryPoint               []string
 	Command                  []string
+	PseudoTerminal           *bool
+	Interactive              *bool
+	Ulimits                  []*Ulimit
 	DomainAlias              string
 	DockerLabels             map[string]string
 	DependsOn                map[string]string
 	Publish                  *PublishOpts
 	ServiceDiscoveryEndpoint string
+	ServiceConnect           *ServiceConnectOpts
 	HTTPVersion              *string
+	ContainerName            string // Name of the main container, the workload name is used if empty.
+	EnvVarPrefix             string // Prefix of the environment variables injected by Copilot, "COPILOT_" is used if empty.
 
 	// Additional options for service templates.
-	WorkloadType        string
-	HealthCheck         *ContainerHealthCheck
-	HTTPHealthCheck     HTTPHealthCheckOpts
-	DeregistrationDelay *int64
-	AllowedSourceIps    []string
-	NLB                 *NetworkLoadBalancer
+	WorkloadType         string
+	HealthCheck          *ContainerHealthCheck
+	HTTPHealthCheck      HTTPHealthCheckOpts
+	DeregistrationDelay  *int64
+	AllowedSourceIps     []string
+	AdditionalRules      []RoutingRuleOpts // Listener rules evaluated in order before the rule of the service's path.
+	DisableHTTPSRedirect bool              // Forward the HTTP traffic to the service instead of redirecting it to HTTPS.
+	NLB                  *NetworkLoadBalancer
+	InternalALB          bool // Route the HTTP traffic of a backend service through the environment's internal load balancer.
+	HostnameVariable     *HostnameVariableOpts
+	AliasRouting         *AliasRoutingOpts
+	Observability        *ObservabilityOpts
 
 	// Lambda functions.
 	RulePriorityLambda             string
@@ -409,8 +612,9 @@ type WorkloadOpts struct {
 	StateMachine       *StateMachineOpts
 
 	// Additional options for request driven web service templates.
-	StartCommand      *string
-	EnableHealthCheck bool
+	StartCommand         *string
+	EnableHealthCheck    bool
+	AppRunnerAutoScaling *AppRunnerAutoScalingOpts
 	// Input needed for the custom resource that adds a custom domain to the service.
 	Alias                *string
 	ScriptBucketName     *string
@@ -423,31 +627,46 @@ type WorkloadOpts struct {
 	Subscribe *SubscribeOpts
 }
 
+// ServiceConnectOpts holds configuration for ECS Service Connect.
+// The port mappings of the containers are named after their container.
+type ServiceConnectOpts struct {
+	MainPortName string // Name of the port mapping of the main container, empty if it doesn't expose a port.
+	Services     []ServiceConnectServiceOpts
+}
+
+// ServiceConnectServiceOpts holds configuration for a port that other services reach through Service Connect.
+type ServiceConnectServiceOpts struct {
+	PortName      string
+	DiscoveryName string
+	DNSName       string
+	Port          uint16
+}
+
 // ParseLoadBalancedWebService parses a load balanced web service's CloudFormation template
 // with the specified data object and returns its content.
 func (t *Template) ParseLoadBalancedWebService(data WorkloadOpts) (*Content, error) {
-	return t.parseSvc(lbWebSvcTplName, data, withSvcParsingFuncs())
+	return t.parseSvc(lbWebSvcTplName, data, withSvcParsingFuncs(), withEnvVarPrefix(data.EnvVarPrefix))
 }
 
 // ParseRequestDrivenWebService parses a request-driven web service's CloudFormation template
 // with the specified data object and returns its content.
 func (t *Template) ParseRequestDrivenWebService(data WorkloadOpts) (*Content, error) {
-	return t.parseSvc(rdWebSvcTplName, data, withSvcParsingFuncs())
+	return t.parseSvc(rdWebSvcTplName, data, withSvcParsingFuncs(), withEnvVarPrefix(data.EnvVarPrefix))
 }
 
 // ParseBackendService parses a backend service's CloudFormation template with the specified data object and returns its content.
 func (t *Template) ParseBackendService(data WorkloadOpts) (*Content, error) {
-	return t.parseSvc(backendSvcTplName, data, withSvcParsingFuncs())
+	return t.parseSvc(backendSvcTplName, data, withSvcParsingFuncs(), withEnvVarPrefix(data.EnvVarPrefix))
 }
 
 // ParseWorkerService parses a worker service's CloudFormation template with the specified data object and returns its content.
 func (t *Template) ParseWorkerService(data WorkloadOpts) (*Content, error) {
-	return t.parseSvc(workerSvcTplName, data, withSvcParsingFuncs())
+	return t.parseSvc(workerSvcTplName, data, withSvcParsingFuncs(), withEnvVarPrefix(data.EnvVarPrefix))
 }
 
 // ParseScheduledJob parses a scheduled job's Cloudformation Template
 func (t *Template) ParseScheduledJob(data WorkloadOpts) (*Content, error) {
-	return t.parseJob(scheduledJobTplName, data, withSvcParsingFuncs())
+	return t.parseJob(scheduledJobTplName, data, withSvcParsingFuncs(), withEnvVarPrefix(data.EnvVarPrefix))
 }
 
 // parseSvc parses a service's CloudFormation template with the specified data object and returns its content.
@@ -502,6 +721,19 @@ func withSvcParsingFuncs() ParseOption {
 	}
 }
 
+// withEnvVarPrefix returns a ParseOption that renders "envVarPrefix" as the prefix of the environment variables
+// injected by Copilot in the containers of the workload.
+func withEnvVarPrefix(prefix string) ParseOption {
+	if prefix == "" {
+		prefix = defaultEnvVarPrefix
+	}
+	return func(t *template.Template) *template.Template {
+		return t.Funcs(map[string]interface{}{
+			"envVarPrefix": func() string { return prefix },
+		})
+	}
+}
+
 func hasSecrets(opts WorkloadOpts) bool {
 	if len(opts.Secrets) > 0 {
 		return true
@@ -526,6 +758,9 @@ func envControllerParameters(o WorkloadOpts) []string {
 	if o.WorkloadType == "Load Balanced Web Service" {
 		parameters = append(parameters, []string{"ALBWorkloads,", "Aliases,"}...) // YAML needs the comma separator; resolved in EnvContr.
 	}
+	if o.WorkloadType == "Backend Service" && o.InternalALB {
+		parameters = append(parameters, "InternalALBWorkloads,")
+	}
 	if o.Network.SubnetsType == PrivateSubnetsPlacement {
 		parameters = append(parameters, "NATWorkloads,")
 	}
@@ -546,5 +781,9 @@ func contains(list []string, s string) bool {
 
 // ARN determines the arn for a topic using the SNSTopic name and account information
 func (t Topic) ARN() string {
-	return fmt.Sprintf(snsARNPattern, t.Partition, t.Region, t.AccountID, t.App, t.Env, t.Svc, aws.StringValue(t.Name))
+	arn := fmt.Sprintf(snsARNPattern, t.Partition, t.Region, t.AccountID, t.App, t.Env, t.Svc, aws.StringValue(t.Name))
+	if t.FIFOTopicConfig != nil {
+		arn += fifoTopicSuffix
+	}
+	return arn
 }
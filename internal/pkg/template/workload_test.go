@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
@@ -43,6 +44,7 @@ func TestTemplate_ParseSvc(t *testing.T) {
 					"templates/workloads/partials/cf/sidecars.yml":                        []byte("sidecars"),
 					"templates/workloads/partials/cf/logconfig.yml":                       []byte("logconfig"),
 					"templates/workloads/partials/cf/autoscaling.yml":                     []byte("autoscaling"),
+					"templates/workloads/partials/cf/rollback-alarms.yml":                 []byte("rollback-alarms"),
 					"templates/workloads/partials/cf/state-machine-definition.json.yml":   []byte("state-machine-definition"),
 					"templates/workloads/partials/cf/eventrule.yml":                       []byte("eventrule"),
 					"templates/workloads/partials/cf/state-machine.yml":                   []byte("state-machine"),
@@ -57,6 +59,8 @@ func TestTemplate_ParseSvc(t *testing.T) {
 					"templates/workloads/partials/cf/subscribe.yml":                       []byte("subscribe"),
 					"templates/workloads/partials/cf/nlb.yml":                             []byte("nlb"),
 					"templates/workloads/partials/cf/vpc-connector.yml":                   []byte("vpc-connector"),
+					"templates/workloads/partials/cf/target-group-properties.yml":         []byte("target-group-properties"),
+					"templates/workloads/partials/cf/bluegreen.yml":                       []byte("bluegreen"),
 				}
 			},
 			wantedContent: `  loggroup
@@ -73,6 +77,7 @@ func TestTemplate_ParseSvc(t *testing.T) {
   sidecars
   logconfig
   autoscaling
+  rollback-alarms
   eventrule
   state-machine
   state-machine-definition
@@ -87,6 +92,8 @@ func TestTemplate_ParseSvc(t *testing.T) {
   subscribe
   nlb
   vpc-connector
+  target-group-properties
+  bluegreen
 `,
 		},
 	}
@@ -203,6 +210,2011 @@ func TestTemplate_ParseNetwork(t *testing.T) {
      - Fn::ImportValue: !Sub '${AppName}-${EnvName}-EnvironmentSecurityGroup'
      - "sg-1bcf1d5b"
      - "sg-asdasdas"
+`,
+		},
+		"should render AWS VPC configuration with imported security groups": {
+			input: NetworkOpts{
+				AssignPublicIP:       "DISABLED",
+				SubnetsType:          "PrivateSubnets",
+				SecurityGroups:       []string{"sg-1bcf1d5b"},
+				SecurityGroupImports: []string{"shared-db-SecurityGroupID"},
+			},
+			wantedNetworkConfig: `
+ AwsvpcConfiguration:
+   AssignPublicIp: DISABLED
+   Subnets:
+     Fn::Split:
+       - ','
+       - Fn::ImportValue: !Sub '${AppName}-${EnvName}-PrivateSubnets'
+   SecurityGroups:
+     - Fn::ImportValue: !Sub '${AppName}-${EnvName}-EnvironmentSecurityGroup'
+     - "sg-1bcf1d5b"
+     - Fn::ImportValue: shared-db-SecurityGroupID
+`,
+		},
+		"should render AWS VPC configuration without the environment security group": {
+			input: NetworkOpts{
+				AssignPublicIP:           "DISABLED",
+				SubnetsType:              "PrivateSubnets",
+				SecurityGroups:           []string{"sg-1bcf1d5b"},
+				DenyDefaultSecurityGroup: true,
+			},
+			wantedNetworkConfig: `
+ AwsvpcConfiguration:
+   AssignPublicIp: DISABLED
+   Subnets:
+     Fn::Split:
+       - ','
+       - Fn::ImportValue: !Sub '${AppName}-${EnvName}-PrivateSubnets'
+   SecurityGroups:
+     - "sg-1bcf1d5b"
+`,
+		},
+		"should render AWS VPC configuration for explicit subnets": {
+			input: NetworkOpts{
+				AssignPublicIP: "DISABLED",
+				SubnetIDs:      []string{"subnet-0123abcd", "subnet-4567cdef"},
+			},
+			wantedNetworkConfig: `
+ AwsvpcConfiguration:
+   AssignPublicIp: DISABLED
+   Subnets:
+     - subnet-0123abcd
+     - subnet-4567cdef
+   SecurityGroups:
+     - Fn::ImportValue: !Sub '${AppName}-${EnvName}-EnvironmentSecurityGroup'
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+			wanted := make(map[interface{}]interface{})
+			err := yaml.Unmarshal([]byte(tc.wantedNetworkConfig), &wanted)
+			require.NoError(t, err, "unmarshal wanted config")
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				Network: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			require.Equal(t, wanted, actual.Resources.Service.Properties.NetworkConfiguration)
+		})
+	}
+}
+
+func TestTemplate_ParseAZRebalancing(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			Service struct {
+				Properties struct {
+					AvailabilityZoneRebalancing string `yaml:"AvailabilityZoneRebalancing"`
+				} `yaml:"Properties"`
+			} `yaml:"Service"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input bool
+
+		wanted string
+	}{
+		"should not render the property by default": {
+			input:  false,
+			wanted: "",
+		},
+		"should enable availability zone rebalancing": {
+			input:  true,
+			wanted: "ENABLED",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				AZRebalancing: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			require.Equal(t, tc.wanted, actual.Resources.Service.Properties.AvailabilityZoneRebalancing)
+		})
+	}
+}
+
+func TestTemplate_ParseServiceConnect(t *testing.T) {
+	type portMapping struct {
+		ContainerPort interface{} `yaml:"ContainerPort"`
+		Name          string      `yaml:"Name"`
+	}
+	type cfn struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []struct {
+						PortMappings []portMapping `yaml:"PortMappings"`
+					} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+			Service struct {
+				Properties struct {
+					ServiceConnectConfiguration struct {
+						Enabled   bool   `yaml:"Enabled"`
+						Namespace string `yaml:"Namespace"`
+						Services  []struct {
+							PortName      string `yaml:"PortName"`
+							DiscoveryName string `yaml:"DiscoveryName"`
+							ClientAliases []struct {
+								Port    int    `yaml:"Port"`
+								DnsName string `yaml:"DnsName"`
+							} `yaml:"ClientAliases"`
+						} `yaml:"Services"`
+					} `yaml:"ServiceConnectConfiguration"`
+				} `yaml:"Properties"`
+			} `yaml:"Service"`
+		} `yaml:"Resources"`
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+		WorkloadType:             "Load Balanced Web Service",
+		ServiceDiscoveryEndpoint: "test.my-app.local",
+		Sidecars: []*SidecarOpts{
+			{
+				Name: aws.String("envoy"),
+				Port: aws.String("9090"),
+			},
+		},
+		ServiceConnect: &ServiceConnectOpts{
+			MainPortName: "frontend",
+			Services: []ServiceConnectServiceOpts{
+				{
+					PortName:      "frontend",
+					DiscoveryName: "frontend-8080",
+					DNSName:       "web",
+					Port:          8080,
+				},
+				{
+					PortName:      "envoy",
+					DiscoveryName: "frontend-admin",
+					DNSName:       "admin",
+					Port:          9090,
+				},
+			},
+		},
+	})
+
+	// THEN
+	require.NoError(t, err, "parse load balanced web service")
+	var actual cfn
+	err = yaml.Unmarshal(content.Bytes(), &actual)
+	require.NoError(t, err, "unmarshal actual config")
+	containers := actual.Resources.TaskDefinition.Properties.ContainerDefinitions
+	require.Len(t, containers, 2)
+	require.Equal(t, "frontend", containers[0].PortMappings[0].Name)
+	require.Equal(t, "envoy", containers[1].PortMappings[0].Name)
+	connect := actual.Resources.Service.Properties.ServiceConnectConfiguration
+	require.True(t, connect.Enabled)
+	require.Equal(t, "test.my-app.local", connect.Namespace)
+	require.Len(t, connect.Services, 2)
+	require.Equal(t, "frontend", connect.Services[0].PortName)
+	require.Equal(t, "frontend-8080", connect.Services[0].DiscoveryName)
+	require.Equal(t, 8080, connect.Services[0].ClientAliases[0].Port)
+	require.Equal(t, "web", connect.Services[0].ClientAliases[0].DnsName)
+	require.Equal(t, "envoy", connect.Services[1].PortName)
+	require.Equal(t, "admin", connect.Services[1].ClientAliases[0].DnsName)
+}
+
+func TestTemplate_ParseLogGroupName(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			LogGroup struct {
+				Properties struct {
+					LogGroupName interface{} `yaml:"LogGroupName"`
+				} `yaml:"Properties"`
+			} `yaml:"LogGroup"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input string
+
+		wanted interface{}
+	}{
+		"should derive the name from the stack by default": {
+			wanted: []interface{}{"", []interface{}{"/copilot/", "AppName", "-", "EnvName", "-", "WorkloadName"}},
+		},
+		"should use the custom log group name": {
+			input:  "/central/payments#api",
+			wanted: "/central/payments#api",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				LogGroupName: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			require.Equal(t, tc.wanted, actual.Resources.LogGroup.Properties.LogGroupName)
+		})
+	}
+}
+
+func TestTemplate_ParseContainerName(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []struct {
+						Name interface{} `yaml:"Name"`
+					} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input string
+
+		wanted interface{}
+	}{
+		"should name the main container after the workload by default": {
+			wanted: "WorkloadName",
+		},
+		"should use the custom container name": {
+			input:  "web",
+			wanted: "web",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				ContainerName: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			require.Equal(t, tc.wanted, actual.Resources.TaskDefinition.Properties.ContainerDefinitions[0].Name)
+		})
+	}
+}
+
+func TestTemplate_ParseEnvVarPrefix(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []struct {
+						Name        string `yaml:"Name"`
+						Environment []struct {
+							Name string `yaml:"Name"`
+						} `yaml:"Environment"`
+					} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+	}
+	mountPoints := []*MountPoint{
+		{
+			ContainerPath: aws.String("/var/www"),
+			ReadOnly:      aws.Bool(true),
+			SourceVolume:  aws.String("efs"),
+		},
+	}
+
+	testCases := map[string]struct {
+		input string
+
+		wanted []string
+	}{
+		"should prefix the injected variables with COPILOT_ by default": {
+			wanted: []string{
+				"COPILOT_APPLICATION_NAME",
+				"COPILOT_SERVICE_DISCOVERY_ENDPOINT",
+				"COPILOT_ENVIRONMENT_NAME",
+				"COPILOT_SERVICE_NAME",
+				"COPILOT_MOUNT_POINTS",
+			},
+		},
+		"should use the custom prefix for the injected variables": {
+			input: "ACME_",
+			wanted: []string{
+				"ACME_APPLICATION_NAME",
+				"ACME_SERVICE_DISCOVERY_ENDPOINT",
+				"ACME_ENVIRONMENT_NAME",
+				"ACME_SERVICE_NAME",
+				"ACME_MOUNT_POINTS",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				EnvVarPrefix: tc.input,
+				Storage: &StorageOpts{
+					MountPoints: mountPoints,
+				},
+				Sidecars: []*SidecarOpts{
+					{
+						Name:  aws.String("nginx"),
+						Image: aws.String("public.ecr.aws/nginx/nginx"),
+						Storage: SidecarStorageOpts{
+							MountPoints: mountPoints,
+						},
+					},
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			containers := actual.Resources.TaskDefinition.Properties.ContainerDefinitions
+			require.Len(t, containers, 2)
+			for _, container := range containers {
+				var names []string
+				for _, envVar := range container.Environment {
+					names = append(names, envVar.Name)
+				}
+				require.ElementsMatch(t, tc.wanted, names, "environment variables of container %s", container.Name)
+			}
+		})
+	}
+}
+
+func TestTemplate_ParseVariableImports(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []struct {
+						Environment []struct {
+							Name  string      `yaml:"Name"`
+							Value interface{} `yaml:"Value"`
+						} `yaml:"Environment"`
+					} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseBackendService(WorkloadOpts{
+		Variables: map[string]string{
+			"DB_HOST": "{{resolve:ssm:/phonetool/test/db_host}}",
+		},
+		VariableImports: map[string]string{
+			"QUEUE_URL": "phonetool-test-QueueURL",
+		},
+	})
+
+	// THEN
+	require.NoError(t, err, "parse backend service")
+	var actual cfn
+	err = yaml.Unmarshal(content.Bytes(), &actual)
+	require.NoError(t, err, "unmarshal actual config")
+	env := make(map[string]interface{})
+	for _, envVar := range actual.Resources.TaskDefinition.Properties.ContainerDefinitions[0].Environment {
+		env[envVar.Name] = envVar.Value
+	}
+	require.Equal(t, "{{resolve:ssm:/phonetool/test/db_host}}", env["DB_HOST"])
+	require.Equal(t, map[string]interface{}{
+		"Fn::ImportValue": "phonetool-test-QueueURL",
+	}, env["QUEUE_URL"])
+}
+
+func TestTemplate_ParseDeploymentConfiguration(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			Service struct {
+				Properties struct {
+					DeploymentConfiguration struct {
+						MinimumHealthyPercent int `yaml:"MinimumHealthyPercent"`
+						MaximumPercent        int `yaml:"MaximumPercent"`
+					} `yaml:"DeploymentConfiguration"`
+				} `yaml:"Properties"`
+			} `yaml:"Service"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input DeploymentConfigurationOpts
+
+		wantedMin int
+		wantedMax int
+	}{
+		"should render the ECS defaults": {
+			wantedMin: 100,
+			wantedMax: 200,
+		},
+		"should render the overrides": {
+			input: DeploymentConfigurationOpts{
+				MinHealthyPercent: aws.Int(0),
+				MaxPercent:        aws.Int(150),
+			},
+			wantedMin: 0,
+			wantedMax: 150,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				DeploymentConfiguration: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			require.Equal(t, tc.wantedMin, actual.Resources.Service.Properties.DeploymentConfiguration.MinimumHealthyPercent)
+			require.Equal(t, tc.wantedMax, actual.Resources.Service.Properties.DeploymentConfiguration.MaximumPercent)
+		})
+	}
+}
+
+func TestTemplate_ParseRollbackAlarms(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Properties struct {
+				DeploymentConfiguration struct {
+					Alarms map[string]interface{} `yaml:"Alarms"`
+				} `yaml:"DeploymentConfiguration"`
+				MetricName string  `yaml:"MetricName"`
+				Threshold  float64 `yaml:"Threshold"`
+			} `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input *RollbackAlarmsOpts
+
+		wantedAlarms     map[string]interface{}
+		wantedThresholds map[string]float64
+	}{
+		"should not render alarms by default": {},
+		"should roll back on existing alarms": {
+			input: &RollbackAlarmsOpts{
+				AlarmNames: []string{"latency", "errors"},
+			},
+			wantedAlarms: map[string]interface{}{
+				"Enable":     true,
+				"Rollback":   true,
+				"AlarmNames": []interface{}{"latency", "errors"},
+			},
+		},
+		"should create utilization alarms and roll back on them": {
+			input: &RollbackAlarmsOpts{
+				CPUUtilization:    aws.Float64(70),
+				MemoryUtilization: aws.Float64(85.5),
+			},
+			wantedAlarms: map[string]interface{}{
+				"Enable":   true,
+				"Rollback": true,
+				"AlarmNames": []interface{}{
+					"${AppName}-${EnvName}-${WorkloadName}-CopilotRollbackCPUAlarm",
+					"${AppName}-${EnvName}-${WorkloadName}-CopilotRollbackMemAlarm",
+				},
+			},
+			wantedThresholds: map[string]float64{
+				"RollbackCPUUtilizationAlarm":    70,
+				"RollbackMemoryUtilizationAlarm": 85.5,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				RollbackAlarms: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			require.Equal(t, tc.wantedAlarms, actual.Resources["Service"].Properties.DeploymentConfiguration.Alarms)
+			for resource, threshold := range tc.wantedThresholds {
+				alarm, ok := actual.Resources[resource]
+				require.True(t, ok, "alarm %s should be rendered", resource)
+				require.Equal(t, threshold, alarm.Properties.Threshold)
+			}
+		})
+	}
+}
+
+func TestTemplate_ParseBlueGreen(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			Service struct {
+				Properties struct {
+					TaskDefinition yaml.Node `yaml:"TaskDefinition"`
+				} `yaml:"Properties"`
+			} `yaml:"Service"`
+		} `yaml:"Resources"`
+		Outputs map[string]struct {
+			Value yaml.Node `yaml:"Value"`
+		} `yaml:"Outputs"`
+	}
+
+	testCases := map[string]struct {
+		input *BlueGreenOpts
+
+		wantedTag   string
+		wantedValue string
+	}{
+		"should reference the task definition of a new service": {
+			input: &BlueGreenOpts{
+				TerminationWaitMinutes: 5,
+			},
+			wantedTag:   "!Ref",
+			wantedValue: "TaskDefinition",
+		},
+		"should keep the task definition of an existing service": {
+			input: &BlueGreenOpts{
+				TerminationWaitMinutes: 5,
+				ServiceTaskDefinition:  "arn:aws:ecs:us-west-2:123456789012:task-definition/phonetool-test-fe:1",
+			},
+			wantedTag:   "!!str",
+			wantedValue: "arn:aws:ecs:us-west-2:123456789012:task-definition/phonetool-test-fe:1",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				BlueGreen: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			taskDef := actual.Resources.Service.Properties.TaskDefinition
+			require.Equal(t, tc.wantedTag, taskDef.Tag)
+			require.Equal(t, tc.wantedValue, taskDef.Value)
+			output, ok := actual.Outputs["ServiceTaskDefinition"]
+			require.True(t, ok, "the task definition of the service should be an output")
+			require.Equal(t, tc.wantedTag, output.Value.Tag)
+			require.Equal(t, tc.wantedValue, output.Value.Value)
+			for _, name := range []string{"TaskDefinition", "CodeDeployApplication", "CodeDeployDeploymentGroup"} {
+				require.Contains(t, actual.Outputs, name)
+			}
+		})
+	}
+}
+
+func TestTemplate_ParseImportedLogGroup(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			LogGroup       interface{} `yaml:"LogGroup"`
+			TaskDefinition struct {
+				DependsOn  interface{} `yaml:"DependsOn"`
+				Properties struct {
+					ContainerDefinitions []struct {
+						LogConfiguration struct {
+							Options map[string]interface{} `yaml:"Options"`
+						} `yaml:"LogConfiguration"`
+					} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseBackendService(WorkloadOpts{
+		LogGroupName:   "/central/payments/api",
+		ImportLogGroup: true,
+	})
+
+	// THEN
+	require.NoError(t, err, "parse backend service")
+	var actual cfn
+	err = yaml.Unmarshal(content.Bytes(), &actual)
+	require.NoError(t, err, "unmarshal actual config")
+	require.Nil(t, actual.Resources.LogGroup)
+	require.Nil(t, actual.Resources.TaskDefinition.DependsOn)
+	require.Equal(t, "/central/payments/api", actual.Resources.TaskDefinition.Properties.ContainerDefinitions[0].LogConfiguration.Options["awslogs-group"])
+}
+
+func TestTemplate_ParsePropagateTags(t *testing.T) {
+	type tag struct {
+		Key   string    `yaml:"Key"`
+		Value yaml.Node `yaml:"Value"`
+	}
+	type cfn struct {
+		Resources struct {
+			Service struct {
+				Properties struct {
+					PropagateTags string `yaml:"PropagateTags"`
+					Tags          []tag  `yaml:"Tags"`
+				} `yaml:"Properties"`
+			} `yaml:"Service"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input map[string]string
+
+		wantedTags map[string]string
+	}{
+		"should not tag the service by default": {},
+		"should tag the service with the stack-level tags": {
+			input: map[string]string{
+				"owner": "frontend",
+			},
+			wantedTags: map[string]string{
+				"copilot-application": "AppName",
+				"copilot-environment": "EnvName",
+				"copilot-service":     "WorkloadName",
+				"owner":               "frontend",
+			},
+		},
+		"should quote tag values that aren't plain strings in YAML": {
+			input: map[string]string{
+				"cost-center": "0123",
+				"on-call":     "team: frontend",
+			},
+			wantedTags: map[string]string{
+				"copilot-application": "AppName",
+				"copilot-environment": "EnvName",
+				"copilot-service":     "WorkloadName",
+				"cost-center":         "0123",
+				"on-call":             "team: frontend",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				Tags: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			require.Equal(t, "SERVICE", actual.Resources.Service.Properties.PropagateTags)
+			var tags map[string]string
+			for _, t := range actual.Resources.Service.Properties.Tags {
+				if tags == nil {
+					tags = make(map[string]string)
+				}
+				tags[t.Key] = t.Value.Value
+			}
+			require.Equal(t, tc.wantedTags, tags)
+		})
+	}
+}
+
+func TestTemplate_ParseFIFOTopic(t *testing.T) {
+	type topicProperties struct {
+		TopicName                 yaml.Node `yaml:"TopicName"`
+		FifoTopic                 *bool     `yaml:"FifoTopic"`
+		ContentBasedDeduplication *bool     `yaml:"ContentBasedDeduplication"`
+	}
+	type cfn struct {
+		Resources struct {
+			OrdersSNSTopic struct {
+				Properties topicProperties `yaml:"Properties"`
+			} `yaml:"ordersSNSTopic"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input *FIFOTopicConfig
+
+		wanted topicProperties
+	}{
+		"should render a standard topic": {
+			wanted: topicProperties{
+				TopicName: yaml.Node{Value: "${AWS::StackName}-orders"},
+			},
+		},
+		"should render a FIFO topic": {
+			input: &FIFOTopicConfig{},
+			wanted: topicProperties{
+				TopicName: yaml.Node{Value: "${AWS::StackName}-orders.fifo"},
+				FifoTopic: aws.Bool(true),
+			},
+		},
+		"should render a FIFO topic with content based deduplication": {
+			input: &FIFOTopicConfig{
+				ContentBasedDeduplication: aws.Bool(true),
+			},
+			wanted: topicProperties{
+				TopicName:                 yaml.Node{Value: "${AWS::StackName}-orders.fifo"},
+				FifoTopic:                 aws.Bool(true),
+				ContentBasedDeduplication: aws.Bool(true),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				Publish: &PublishOpts{
+					Topics: []*Topic{
+						{
+							Name:            aws.String("orders"),
+							FIFOTopicConfig: tc.input,
+						},
+					},
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			got := actual.Resources.OrdersSNSTopic.Properties
+			require.Equal(t, "!Sub", got.TopicName.Tag)
+			require.Equal(t, tc.wanted.TopicName.Value, got.TopicName.Value)
+			require.Equal(t, tc.wanted.FifoTopic, got.FifoTopic)
+			require.Equal(t, tc.wanted.ContentBasedDeduplication, got.ContentBasedDeduplication)
+		})
+	}
+}
+
+func TestTemplate_ParseTerminalFlags(t *testing.T) {
+	type containerDefinition struct {
+		Name           yaml.Node `yaml:"Name"`
+		PseudoTerminal *bool     `yaml:"PseudoTerminal"`
+		Interactive    *bool     `yaml:"Interactive"`
+	}
+	type cfn struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []containerDefinition `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		pseudoTerminal *bool
+		interactive    *bool
+	}{
+		"should not set the flags by default": {},
+		"should set both flags": {
+			pseudoTerminal: aws.Bool(true),
+			interactive:    aws.Bool(true),
+		},
+		"should set flags explicitly disabled": {
+			pseudoTerminal: aws.Bool(false),
+			interactive:    aws.Bool(true),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				PseudoTerminal: tc.pseudoTerminal,
+				Interactive:    tc.interactive,
+				Sidecars: []*SidecarOpts{
+					{
+						Name:           aws.String("debugger"),
+						Image:          aws.String("busybox:1.34"),
+						PseudoTerminal: tc.pseudoTerminal,
+						Interactive:    tc.interactive,
+					},
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			defs := actual.Resources.TaskDefinition.Properties.ContainerDefinitions
+			require.Len(t, defs, 2)
+			for _, def := range defs {
+				require.Equal(t, tc.pseudoTerminal, def.PseudoTerminal)
+				require.Equal(t, tc.interactive, def.Interactive)
+			}
+		})
+	}
+}
+
+func TestTemplate_ParseUlimits(t *testing.T) {
+	type ulimit struct {
+		Name      string `yaml:"Name"`
+		SoftLimit int    `yaml:"SoftLimit"`
+		HardLimit int    `yaml:"HardLimit"`
+	}
+	type cfn struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []struct {
+						Ulimits []ulimit `yaml:"Ulimits"`
+					} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input []*Ulimit
+
+		wanted []ulimit
+	}{
+		"should not render ulimits by default": {},
+		"should render ulimits on every container": {
+			input: []*Ulimit{
+				{Name: "nofile", SoftLimit: 65536, HardLimit: 65536},
+				{Name: "nproc", SoftLimit: 1024, HardLimit: 4096},
+			},
+			wanted: []ulimit{
+				{Name: "nofile", SoftLimit: 65536, HardLimit: 65536},
+				{Name: "nproc", SoftLimit: 1024, HardLimit: 4096},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				Ulimits: tc.input,
+				Sidecars: []*SidecarOpts{
+					{
+						Name:    aws.String("proxy"),
+						Image:   aws.String("envoyproxy/envoy:v1.24"),
+						Ulimits: tc.input,
+					},
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			defs := actual.Resources.TaskDefinition.Properties.ContainerDefinitions
+			require.Len(t, defs, 2)
+			for _, def := range defs {
+				require.Equal(t, tc.wanted, def.Ulimits)
+			}
+		})
+	}
+}
+
+func TestTemplate_ParseInitContainer(t *testing.T) {
+	type dependency struct {
+		Condition     string `yaml:"Condition"`
+		ContainerName string `yaml:"ContainerName"`
+	}
+	type containerDefinition struct {
+		Name      string       `yaml:"Name"`
+		Image     string       `yaml:"Image"`
+		Essential *bool        `yaml:"Essential"`
+		Command   []string     `yaml:"Command"`
+		DependsOn []dependency `yaml:"DependsOn"`
+	}
+	type cfn struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []containerDefinition `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseBackendService(WorkloadOpts{
+		DependsOn: map[string]string{
+			"init": "COMPLETE",
+		},
+		Sidecars: []*SidecarOpts{
+			{
+				Name:      aws.String("init"),
+				Image:     aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/app/api:v1-init"),
+				Essential: aws.Bool(false),
+				Command:   []string{"./migrate", "up"},
+			},
+		},
+	})
+
+	// THEN
+	require.NoError(t, err, "parse backend service")
+	var actual cfn
+	err = yaml.Unmarshal(content.Bytes(), &actual)
+	require.NoError(t, err, "unmarshal actual config")
+	defs := actual.Resources.TaskDefinition.Properties.ContainerDefinitions
+	require.Len(t, defs, 2)
+	require.Equal(t, []dependency{
+		{Condition: "COMPLETE", ContainerName: "init"},
+	}, defs[0].DependsOn, "main container should wait for the init container to complete")
+	require.Equal(t, containerDefinition{
+		Name:      "init",
+		Image:     "123456789012.dkr.ecr.us-west-2.amazonaws.com/app/api:v1-init",
+		Essential: aws.Bool(false),
+		Command:   []string{"./migrate", "up"},
+	}, defs[1])
+}
+
+func TestTemplate_ParseFirelensConfigType(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []struct {
+						Name                  string `yaml:"Name"`
+						FirelensConfiguration struct {
+							Type    string            `yaml:"Type"`
+							Options map[string]string `yaml:"Options"`
+						} `yaml:"FirelensConfiguration"`
+					} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input string
+	}{
+		"should render a fluentbit log router": {
+			input: "fluentbit",
+		},
+		"should render a fluentd log router": {
+			input: "fluentd",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				LogConfig: &LogConfigOpts{
+					Image:          aws.String("log-router:v1"),
+					ConfigType:     tc.input,
+					EnableMetadata: aws.String("true"),
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			var found bool
+			for _, def := range actual.Resources.TaskDefinition.Properties.ContainerDefinitions {
+				if def.Name != "firelens_log_router" {
+					continue
+				}
+				found = true
+				require.Equal(t, tc.input, def.FirelensConfiguration.Type)
+				require.Equal(t, "true", def.FirelensConfiguration.Options["enable-ecs-log-metadata"])
+			}
+			require.True(t, found, "firelens log router container must be defined")
+		})
+	}
+}
+
+func TestTemplate_ParseExecuteCommandLogging(t *testing.T) {
+	type statement struct {
+		Action   yaml.Node `yaml:"Action"`
+		Resource yaml.Node `yaml:"Resource"`
+	}
+	type cfn struct {
+		Resources struct {
+			TaskRole struct {
+				Properties struct {
+					Policies []struct {
+						PolicyName     string `yaml:"PolicyName"`
+						PolicyDocument struct {
+							Statement []statement `yaml:"Statement"`
+						} `yaml:"PolicyDocument"`
+					} `yaml:"Policies"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskRole"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input *ExecuteCommandLoggingOpts
+
+		wantedS3Resources map[string]string
+	}{
+		"should not grant S3 access without a bucket": {
+			input: &ExecuteCommandLoggingOpts{
+				CloudWatchLogGroup: "exec-audit",
+			},
+		},
+		"should grant access to the bucket and key prefix": {
+			input: &ExecuteCommandLoggingOpts{
+				S3Bucket:    "audit-bucket",
+				S3KeyPrefix: "exec/",
+			},
+			wantedS3Resources: map[string]string{
+				"s3:GetEncryptionConfiguration": "arn:${AWS::Partition}:s3:::audit-bucket",
+				"s3:PutObject":                  "arn:${AWS::Partition}:s3:::audit-bucket/exec/*",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				ExecuteCommand: &ExecuteCommandOpts{
+					Logging: tc.input,
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			var s3Resources map[string]string
+			for _, policy := range actual.Resources.TaskRole.Properties.Policies {
+				if policy.PolicyName != "ExecuteCommand" {
+					continue
+				}
+				for _, stmt := range policy.PolicyDocument.Statement {
+					if stmt.Action.Kind != yaml.ScalarNode {
+						continue
+					}
+					if s3Resources == nil {
+						s3Resources = make(map[string]string)
+					}
+					require.Equal(t, "!Sub", stmt.Resource.Tag)
+					s3Resources[stmt.Action.Value] = stmt.Resource.Value
+				}
+			}
+			require.Equal(t, tc.wantedS3Resources, s3Resources)
+		})
+	}
+}
+
+func TestTemplate_ParseExecuteCommandLoggingEnvController(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			EnvControllerAction struct {
+				Properties struct {
+					ExecLogging map[string]string `yaml:"ExecLogging"`
+				} `yaml:"Properties"`
+			} `yaml:"EnvControllerAction"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input *ExecuteCommandOpts
+
+		wanted map[string]string
+	}{
+		"should not configure the cluster without exec": {},
+		"should not configure the cluster without logging": {
+			input: &ExecuteCommandOpts{},
+		},
+		"should configure the logging of the cluster": {
+			input: &ExecuteCommandOpts{
+				Logging: &ExecuteCommandLoggingOpts{
+					CloudWatchLogGroup:          "exec-audit",
+					CloudWatchEncryptionEnabled: true,
+					S3Bucket:                    "audit-bucket",
+					S3KeyPrefix:                 "exec/",
+				},
+			},
+			wanted: map[string]string{
+				"CloudWatchLogGroup":          "exec-audit",
+				"CloudWatchEncryptionEnabled": "true",
+				"S3Bucket":                    "audit-bucket",
+				"S3KeyPrefix":                 "exec/",
+			},
+		},
+		"should leave out the log group if it's not set": {
+			input: &ExecuteCommandOpts{
+				Logging: &ExecuteCommandLoggingOpts{
+					S3Bucket: "audit-bucket",
+				},
+			},
+			wanted: map[string]string{
+				"S3Bucket": "audit-bucket",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseBackendService(WorkloadOpts{
+				ExecuteCommand: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse backend service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			require.Equal(t, tc.wanted, actual.Resources.EnvControllerAction.Properties.ExecLogging)
+		})
+	}
+}
+func TestTemplate_ParseHostnameVariable(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []struct {
+						Environment []struct {
+							Name  string    `yaml:"Name"`
+							Value yaml.Node `yaml:"Value"`
+						} `yaml:"Environment"`
+					} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input *HostnameVariableOpts
+
+		wantedTag   string
+		wantedValue string
+	}{
+		"should reference the load balancer DNS name without an alias": {
+			input: &HostnameVariableOpts{
+				Name: "PUBLIC_HOST",
+			},
+			wantedTag:   "!GetAtt",
+			wantedValue: "EnvControllerAction.PublicLoadBalancerDNSName",
+		},
+		"should use the alias if configured": {
+			input: &HostnameVariableOpts{
+				Name:  "PUBLIC_HOST",
+				Alias: "example.com",
+			},
+			wantedTag:   "!!str",
+			wantedValue: "example.com",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				WorkloadType:     "Load Balanced Web Service",
+				HostnameVariable: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			require.NotEmpty(t, actual.Resources.TaskDefinition.Properties.ContainerDefinitions)
+			var found bool
+			for _, env := range actual.Resources.TaskDefinition.Properties.ContainerDefinitions[0].Environment {
+				if env.Name != tc.input.Name {
+					continue
+				}
+				found = true
+				require.Equal(t, tc.wantedTag, env.Value.Tag)
+				require.Equal(t, tc.wantedValue, env.Value.Value)
+			}
+			require.True(t, found, "environment variable %s should be rendered", tc.input.Name)
+		})
+	}
+}
+
+func TestTemplate_ParseObservability(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []struct {
+						Environment []struct {
+							Name  string `yaml:"Name"`
+							Value string `yaml:"Value"`
+						} `yaml:"Environment"`
+					} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input *ObservabilityOpts
+
+		wantedPropagators string
+	}{
+		"should not render the propagators variable by default": {},
+		"should render the propagators variable": {
+			input: &ObservabilityOpts{
+				TracePropagators: "tracecontext",
+			},
+			wantedPropagators: "tracecontext",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				WorkloadType:  "Load Balanced Web Service",
+				Observability: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			require.NotEmpty(t, actual.Resources.TaskDefinition.Properties.ContainerDefinitions)
+			var propagators string
+			for _, env := range actual.Resources.TaskDefinition.Properties.ContainerDefinitions[0].Environment {
+				if env.Name == "OTEL_PROPAGATORS" {
+					propagators = env.Value
+				}
+			}
+			require.Equal(t, tc.wantedPropagators, propagators)
+		})
+	}
+}
+
+func TestTemplate_ParseTaskRoleXRayPolicy(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			TaskRole struct {
+				Properties struct {
+					ManagedPolicyArns []string `yaml:"ManagedPolicyArns"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskRole"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input *ObservabilityOpts
+
+		wantedPolicies []string
+	}{
+		"should not attach managed policies by default": {
+			input: &ObservabilityOpts{
+				TracePropagators: "xray",
+			},
+		},
+		"should attach the X-Ray daemon policy if tracing with X-Ray": {
+			input: &ObservabilityOpts{
+				Tracing: "awsxray",
+			},
+			wantedPolicies: []string{"arn:${AWS::Partition}:iam::aws:policy/AWSXRayDaemonWriteAccess"},
+		},
+		"should attach the X-Ray and CloudWatch policies if tracing with the OpenTelemetry collector": {
+			input: &ObservabilityOpts{
+				Tracing: "awsotel",
+			},
+			wantedPolicies: []string{
+				"arn:${AWS::Partition}:iam::aws:policy/AWSXRayDaemonWriteAccess",
+				"arn:${AWS::Partition}:iam::aws:policy/CloudWatchAgentServerPolicy",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				WorkloadType:  "Load Balanced Web Service",
+				Observability: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			require.Equal(t, tc.wantedPolicies, actual.Resources.TaskRole.Properties.ManagedPolicyArns)
+		})
+	}
+}
+
+func TestTemplate_ParseAppConfigSecrets(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []struct {
+						Name        string `yaml:"Name"`
+						Image       string `yaml:"Image"`
+						Environment []struct {
+							Name  string `yaml:"Name"`
+							Value string `yaml:"Value"`
+						} `yaml:"Environment"`
+					} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+			TaskRole struct {
+				Properties struct {
+					Policies []struct {
+						PolicyName string `yaml:"PolicyName"`
+					} `yaml:"Policies"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskRole"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input map[string]*AppConfigSecretOpts
+
+		wantedVariables map[string]string
+		wantedAgent     bool
+	}{
+		"should not render the agent without AppConfig secrets": {},
+		"should render the agent and a variable per AppConfig secret": {
+			input: map[string]*AppConfigSecretOpts{
+				"FLAGS": {
+					Application: "phonetool",
+					Environment: "test",
+					Profile:     "flags",
+				},
+			},
+			wantedVariables: map[string]string{
+				"FLAGS": "http://localhost:2772/applications/phonetool/environments/test/configurations/flags",
+			},
+			wantedAgent: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				WorkloadType:     "Load Balanced Web Service",
+				AppConfigSecrets: tc.input,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			containers := actual.Resources.TaskDefinition.Properties.ContainerDefinitions
+			require.NotEmpty(t, containers)
+			for name, wanted := range tc.wantedVariables {
+				var found bool
+				for _, env := range containers[0].Environment {
+					if env.Name == name {
+						found = true
+						require.Equal(t, wanted, env.Value)
+					}
+				}
+				require.True(t, found, "environment variable %s should be rendered", name)
+			}
+			var hasAgent bool
+			for _, container := range containers {
+				if container.Name == "appconfig_agent" {
+					hasAgent = true
+					require.Equal(t, "public.ecr.aws/aws-appconfig/aws-appconfig-agent:2.x", container.Image)
+				}
+			}
+			require.Equal(t, tc.wantedAgent, hasAgent)
+			var hasPolicy bool
+			for _, policy := range actual.Resources.TaskRole.Properties.Policies {
+				if policy.PolicyName == "GrantAppConfigAgentAccess" {
+					hasPolicy = true
+				}
+			}
+			require.Equal(t, tc.wantedAgent, hasPolicy)
+		})
+	}
+}
+
+func TestTemplate_ParseNLBTargetGroupAttributes(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			NLBTargetGroup struct {
+				Properties struct {
+					TargetGroupAttributes []struct {
+						Key   string `yaml:"Key"`
+						Value string `yaml:"Value"`
+					} `yaml:"TargetGroupAttributes"`
+				} `yaml:"Properties"`
+			} `yaml:"NLBTargetGroup"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input NetworkLoadBalancerListener
+
+		wantedAttributes map[string]string
+	}{
+		"should disable proxy protocol v2, reference the stickiness parameter and leave client IP preservation unset by default": {
+			input: NetworkLoadBalancerListener{
+				Port:     "443",
+				Protocol: "TCP",
+			},
+			wantedAttributes: map[string]string{
+				"deregistration_delay.timeout_seconds":                "60",
+				"stickiness.enabled":                                  "Stickiness",
+				"deregistration_delay.connection_termination.enabled": "false",
+				"proxy_protocol_v2.enabled":                           "false",
+			},
+		},
+		"should render stickiness if configured": {
+			input: NetworkLoadBalancerListener{
+				Port:       "443",
+				Protocol:   "TCP",
+				Stickiness: aws.Bool(true),
+			},
+			wantedAttributes: map[string]string{
+				"deregistration_delay.timeout_seconds":                "60",
+				"stickiness.enabled":                                  "true",
+				"deregistration_delay.connection_termination.enabled": "false",
+				"proxy_protocol_v2.enabled":                           "false",
+			},
+		},
+		"should render the configured target group attributes": {
+			input: NetworkLoadBalancerListener{
+				Port:             "443",
+				Protocol:         "TLS",
+				PreserveClientIP: aws.Bool(false),
+				ProxyProtocolV2:  true,
+			},
+			wantedAttributes: map[string]string{
+				"deregistration_delay.timeout_seconds":                "60",
+				"deregistration_delay.connection_termination.enabled": "false",
+				"proxy_protocol_v2.enabled":                           "true",
+				"preserve_client_ip.enabled":                          "false",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				WorkloadType:        "Load Balanced Web Service",
+				DeregistrationDelay: aws.Int64(60),
+				NLB: &NetworkLoadBalancer{
+					Listener: tc.input,
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			attributes := make(map[string]string)
+			for _, attr := range actual.Resources.NLBTargetGroup.Properties.TargetGroupAttributes {
+				attributes[attr.Key] = attr.Value
+			}
+			require.Equal(t, tc.wantedAttributes, attributes)
+		})
+	}
+}
+
+func TestTemplate_ParseNLBAdditionalListeners(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Type       string                 `yaml:"Type"`
+			Properties map[string]interface{} `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+		WorkloadType:        "Load Balanced Web Service",
+		DeregistrationDelay: aws.Int64(60),
+		NLB: &NetworkLoadBalancer{
+			PublicSubnetCIDRs: []string{"10.0.0.0/24"},
+			Listener: NetworkLoadBalancerListener{
+				Port:            "8080",
+				Protocol:        "TCP",
+				TargetContainer: "api",
+				TargetPort:      "8080",
+			},
+			AdditionalListeners: []NetworkLoadBalancerListener{
+				{
+					Port:            "53",
+					Protocol:        "UDP",
+					TargetContainer: "dns",
+					TargetPort:      "8053",
+					HealthCheck: HTTPHealthCheckOpts{
+						HealthyThreshold: aws.Int64(3),
+					},
+				},
+				{
+					Port:            "9090",
+					Protocol:        "TCP",
+					TargetContainer: "api",
+					TargetPort:      "9090",
+					Stickiness:      aws.Bool(true),
+				},
+			},
+			MainContainerPortMappings: []PortMapping{
+				{
+					ContainerPort: "9090",
+					Protocol:      "tcp",
+				},
+			},
+			SidecarPortMappings: map[string][]PortMapping{
+				"dns": {
+					{
+						ContainerPort: "8053",
+						Protocol:      "udp",
+					},
+				},
+			},
+		},
+		Sidecars: []*SidecarOpts{
+			{
+				Name: aws.String("dns"),
+				Port: aws.String("8053"),
+			},
+		},
+	})
+
+	// THEN
+	require.NoError(t, err, "parse load balanced web service")
+	var actual cfn
+	err = yaml.Unmarshal(content.Bytes(), &actual)
+	require.NoError(t, err, "unmarshal actual config")
+
+	listener, ok := actual.Resources["NLBAdditionalListener0"]
+	require.True(t, ok, "additional listener should be rendered")
+	require.Equal(t, "AWS::ElasticLoadBalancingV2::Listener", listener.Type)
+	require.Equal(t, 53, listener.Properties["Port"])
+	require.Equal(t, "UDP", listener.Properties["Protocol"])
+
+	targetGroup, ok := actual.Resources["NLBAdditionalTargetGroup0"]
+	require.True(t, ok, "additional target group should be rendered")
+	require.Equal(t, 8053, targetGroup.Properties["Port"])
+	require.Equal(t, "UDP", targetGroup.Properties["Protocol"])
+	require.Equal(t, 3, targetGroup.Properties["HealthyThresholdCount"])
+	stickyTargetGroup, ok := actual.Resources["NLBAdditionalTargetGroup1"]
+	require.True(t, ok, "sticky additional target group should be rendered")
+	require.Contains(t, stickyTargetGroup.Properties["TargetGroupAttributes"], map[string]interface{}{
+		"Key":   "stickiness.enabled",
+		"Value": true,
+	})
+
+	securityGroup, ok := actual.Resources["NLBSecurityGroup"]
+	require.True(t, ok, "security group should be rendered")
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"CidrIp":      "10.0.0.0/24",
+			"Description": "Ingress to allow access from Network Load Balancer subnet",
+			"FromPort":    8080,
+			"IpProtocol":  "tcp",
+			"ToPort":      8080,
+		},
+		map[string]interface{}{
+			"CidrIp":      "10.0.0.0/24",
+			"Description": "Ingress to allow access from Network Load Balancer subnet",
+			"FromPort":    8053,
+			"IpProtocol":  "udp",
+			"ToPort":      8053,
+		},
+		map[string]interface{}{
+			"CidrIp":      "10.0.0.0/24",
+			"Description": "Ingress to allow access from Network Load Balancer subnet",
+			"FromPort":    9090,
+			"IpProtocol":  "tcp",
+			"ToPort":      9090,
+		},
+	}, securityGroup.Properties["SecurityGroupIngress"])
+
+	service := actual.Resources["Service"]
+	require.Contains(t, service.Properties["LoadBalancers"], map[string]interface{}{
+		"ContainerName":  "dns",
+		"ContainerPort":  8053,
+		"TargetGroupArn": "NLBAdditionalTargetGroup0",
+	})
+
+	taskDef := actual.Resources["TaskDefinition"]
+	containers := taskDef.Properties["ContainerDefinitions"].([]interface{})
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"ContainerPort": "ContainerPort",
+		},
+		map[string]interface{}{
+			"ContainerPort": 9090,
+			"Protocol":      "tcp",
+		},
+	}, containers[0].(map[string]interface{})["PortMappings"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"ContainerPort": 8053,
+		},
+		map[string]interface{}{
+			"ContainerPort": 8053,
+			"Protocol":      "udp",
+		},
+	}, containers[1].(map[string]interface{})["PortMappings"])
+}
+
+func TestTemplate_ParseTopicSubscriptionARN(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Properties struct {
+				TopicArn yaml.Node `yaml:"TopicArn"`
+			} `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input *TopicSubscription
+
+		wantedLogicalID string
+		wantedTag       string
+		wantedValue     string
+	}{
+		"should build the ARN of a topic published by a Copilot service": {
+			input: &TopicSubscription{
+				Name:    aws.String("events"),
+				Service: aws.String("api"),
+			},
+			wantedLogicalID: "apieventsSNSTopicSubscription",
+			wantedTag:       "!Join",
+		},
+		"should reference an imported topic by its ARN": {
+			input: &TopicSubscription{
+				Name:    aws.String("events"),
+				Service: aws.String(""),
+				ARN:     aws.String("arn:aws:sns:us-west-2:123456789012:events"),
+				Queue:   &SQSQueue{},
+			},
+			wantedLogicalID: "eventsSNSTopicSubscription",
+			wantedTag:       "!!str",
+			wantedValue:     "arn:aws:sns:us-west-2:123456789012:events",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseWorkerService(WorkloadOpts{
+				WorkloadType: "Worker Service",
+				Subscribe: &SubscribeOpts{
+					Topics: []*TopicSubscription{tc.input},
+				},
+			})
+
+			// THEN
+			require.NoError(t, err, "parse worker service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			sub, ok := actual.Resources[tc.wantedLogicalID]
+			require.True(t, ok, "subscription %s should be rendered", tc.wantedLogicalID)
+			require.Equal(t, tc.wantedTag, sub.Properties.TopicArn.Tag)
+			if tc.wantedValue != "" {
+				require.Equal(t, tc.wantedValue, sub.Properties.TopicArn.Value)
+			}
+		})
+	}
+}
+
+func TestTemplate_ParseAliasRouting(t *testing.T) {
+	type recordSet struct {
+		Name          string    `yaml:"Name"`
+		Type          string    `yaml:"Type"`
+		SetIdentifier yaml.Node `yaml:"SetIdentifier"`
+		Weight        *int      `yaml:"Weight"`
+		Failover      string    `yaml:"Failover"`
+		HealthCheckID string    `yaml:"HealthCheckId"`
+		AliasTarget   struct {
+			EvaluateTargetHealth bool `yaml:"EvaluateTargetHealth"`
+		} `yaml:"AliasTarget"`
+	}
+	type cfn struct {
+		Resources struct {
+			LoadBalancerAliasRouting *struct {
+				Properties struct {
+					HostedZoneID string      `yaml:"HostedZoneId"`
+					RecordSets   []recordSet `yaml:"RecordSets"`
+				} `yaml:"Properties"`
+			} `yaml:"LoadBalancerAliasRouting"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		inAliases []string
+		inRouting *AliasRoutingOpts
+
+		wantedHostedZoneID      string
+		wantedRecordSets        []recordSet
+		wantedSetIdentifierTag  string
+		wantedSetIdentifierName string
+	}{
+		"should not render records without routing": {
+			inAliases: []string{"api.example.com"},
+		},
+		"should render a weighted record per alias": {
+			inAliases: []string{"api.example.com", "v1.api.example.com"},
+			inRouting: &AliasRoutingOpts{
+				HostedZoneID:  "Z0123456789",
+				SetIdentifier: aws.String("us-west-2"),
+				Weight:        aws.Int(0),
+			},
+			wantedHostedZoneID:      "Z0123456789",
+			wantedSetIdentifierTag:  "!!str",
+			wantedSetIdentifierName: "us-west-2",
+			wantedRecordSets: []recordSet{
+				{Name: "api.example.com", Type: "A", Weight: aws.Int(0)},
+				{Name: "v1.api.example.com", Type: "A", Weight: aws.Int(0)},
+			},
+		},
+		"should render a failover record with a health check": {
+			inAliases: []string{"api.example.com"},
+			inRouting: &AliasRoutingOpts{
+				HostedZoneID:  "Z0123456789",
+				Failover:      "PRIMARY",
+				HealthCheckID: aws.String("abcdef11-2222-3333-4444-555555fedcba"),
+			},
+			wantedHostedZoneID:      "Z0123456789",
+			wantedSetIdentifierTag:  "!Ref",
+			wantedSetIdentifierName: "EnvName",
+			wantedRecordSets: []recordSet{
+				{Name: "api.example.com", Type: "A", Failover: "PRIMARY", HealthCheckID: "abcdef11-2222-3333-4444-555555fedcba"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				WorkloadType: "Load Balanced Web Service",
+				Aliases:      tc.inAliases,
+				AliasRouting: tc.inRouting,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			if tc.inRouting == nil {
+				require.Nil(t, actual.Resources.LoadBalancerAliasRouting)
+				return
+			}
+			require.NotNil(t, actual.Resources.LoadBalancerAliasRouting)
+			props := actual.Resources.LoadBalancerAliasRouting.Properties
+			require.Equal(t, tc.wantedHostedZoneID, props.HostedZoneID)
+			require.Len(t, props.RecordSets, len(tc.wantedRecordSets))
+			for i, wanted := range tc.wantedRecordSets {
+				got := props.RecordSets[i]
+				require.Equal(t, tc.wantedSetIdentifierTag, got.SetIdentifier.Tag)
+				require.Equal(t, tc.wantedSetIdentifierName, got.SetIdentifier.Value)
+				require.True(t, got.AliasTarget.EvaluateTargetHealth)
+				require.Equal(t, wanted.Name, got.Name)
+				require.Equal(t, wanted.Type, got.Type)
+				require.Equal(t, wanted.Weight, got.Weight)
+				require.Equal(t, wanted.Failover, got.Failover)
+				require.Equal(t, wanted.HealthCheckID, got.HealthCheckID)
+			}
+		})
+	}
+}
+
+func TestTemplate_ParseAutoscaling(t *testing.T) {
+	type cfn struct {
+		Resources map[string]struct {
+			Properties struct {
+				TargetTrackingScalingPolicyConfiguration map[interface{}]interface{} `yaml:"TargetTrackingScalingPolicyConfiguration"`
+			} `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		input AutoscalingOpts
+
+		wantedPolicyName   string
+		wantedPolicyConfig string
+	}{
+		"should render a predefined ALB request count per target policy": {
+			input: AutoscalingOpts{
+				MinCapacity: aws.Int(1),
+				MaxCapacity: aws.Int(10),
+				Requests:    aws.Float64(1000),
+			},
+			wantedPolicyName: "AutoScalingPolicyALBSumRequestCountPerTarget",
+			wantedPolicyConfig: `
+PredefinedMetricSpecification:
+  PredefinedMetricType: ALBRequestCountPerTarget
+  ResourceLabel:
+    Fn::Join:
+      - '/'
+      - - !GetAtt EnvControllerAction.PublicLoadBalancerFullName
+        - !GetAtt TargetGroup.TargetGroupFullName
+ScaleInCooldown: 120
+ScaleOutCooldown: 60
+TargetValue: 1000
+`,
+		},
+		"should render an ALB average target response time policy": {
+			input: AutoscalingOpts{
+				MinCapacity:  aws.Int(1),
+				MaxCapacity:  aws.Int(10),
+				ResponseTime: aws.Float64(0.5),
+			},
+			wantedPolicyName: "AutoScalingPolicyALBAverageResponseTime",
+			wantedPolicyConfig: `
+CustomizedMetricSpecification:
+  Dimensions:
+    - Name: LoadBalancer
+      Value: !GetAtt EnvControllerAction.PublicLoadBalancerFullName
+    - Name: TargetGroup
+      Value: !GetAtt TargetGroup.TargetGroupFullName
+  MetricName: TargetResponseTime
+  Namespace: AWS/ApplicationELB
+  Statistic: Average
+ScaleInCooldown: 120
+ScaleOutCooldown: 60
+TargetValue: 0.5
+`,
+		},
+		"should render a custom metric policy": {
+			input: AutoscalingOpts{
+				MinCapacity: aws.Int(1),
+				MaxCapacity: aws.Int(10),
+				CustomMetric: &AutoscalingCustomMetricOpts{
+					Namespace:  "AWS/SQS",
+					MetricName: "ApproximateNumberOfMessagesVisible",
+					Dimensions: []AutoscalingMetricDimension{
+						{Name: "QueueName", Value: "orders"},
+					},
+					Statistic: "Sum",
+					Target:    100,
+				},
+			},
+			wantedPolicyName: "AutoScalingPolicyCustomMetric",
+			wantedPolicyConfig: `
+CustomizedMetricSpecification:
+  Namespace: AWS/SQS
+  MetricName: ApproximateNumberOfMessagesVisible
+  Dimensions:
+    - Name: QueueName
+      Value: orders
+  Statistic: Sum
+ScaleInCooldown: 120
+ScaleOutCooldown: 60
+TargetValue: 100
+`,
+		},
+		"should render custom cooldowns": {
+			input: AutoscalingOpts{
+				MinCapacity:      aws.Int(1),
+				MaxCapacity:      aws.Int(10),
+				Requests:         aws.Float64(1000),
+				ScaleInCooldown:  aws.Int64(300),
+				ScaleOutCooldown: aws.Int64(0),
+			},
+			wantedPolicyName: "AutoScalingPolicyALBSumRequestCountPerTarget",
+			wantedPolicyConfig: `
+PredefinedMetricSpecification:
+  PredefinedMetricType: ALBRequestCountPerTarget
+  ResourceLabel:
+    Fn::Join:
+      - '/'
+      - - !GetAtt EnvControllerAction.PublicLoadBalancerFullName
+        - !GetAtt TargetGroup.TargetGroupFullName
+ScaleInCooldown: 300
+ScaleOutCooldown: 0
+TargetValue: 1000
 `,
 		},
 	}
@@ -212,12 +2224,12 @@ func TestTemplate_ParseNetwork(t *testing.T) {
 			// GIVEN
 			tpl := New()
 			wanted := make(map[interface{}]interface{})
-			err := yaml.Unmarshal([]byte(tc.wantedNetworkConfig), &wanted)
+			err := yaml.Unmarshal([]byte(tc.wantedPolicyConfig), &wanted)
 			require.NoError(t, err, "unmarshal wanted config")
 
 			// WHEN
 			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
-				Network: tc.input,
+				Autoscaling: &tc.input,
 			})
 
 			// THEN
@@ -225,7 +2237,9 @@ func TestTemplate_ParseNetwork(t *testing.T) {
 			var actual cfn
 			err = yaml.Unmarshal(content.Bytes(), &actual)
 			require.NoError(t, err, "unmarshal actual config")
-			require.Equal(t, wanted, actual.Resources.Service.Properties.NetworkConfiguration)
+			policy, ok := actual.Resources[tc.wantedPolicyName]
+			require.True(t, ok, "scaling policy %s should be rendered", tc.wantedPolicyName)
+			require.Equal(t, wanted, policy.Properties.TargetTrackingScalingPolicyConfiguration)
 		})
 	}
 }
@@ -290,3 +2304,189 @@ func TestRuntimePlatformOpts_IsDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplate_ParseHTTPSRedirect(t *testing.T) {
+	type cfn struct {
+		Resources struct {
+			HTTPListenerRuleWithDomain struct {
+				Properties struct {
+					Actions []struct {
+						Type string `yaml:"Type"`
+					} `yaml:"Actions"`
+					Conditions []struct {
+						Field string `yaml:"Field"`
+					} `yaml:"Conditions"`
+				} `yaml:"Properties"`
+			} `yaml:"HTTPListenerRuleWithDomain"`
+		} `yaml:"Resources"`
+	}
+
+	testCases := map[string]struct {
+		inDisableHTTPSRedirect bool
+
+		wantedActionType string
+		wantedConditions []string
+	}{
+		"should redirect HTTP traffic to HTTPS by default": {
+			wantedActionType: "redirect",
+			wantedConditions: []string{"host-header", "path-pattern"},
+		},
+		"should forward HTTP traffic to the target group if the redirect is disabled": {
+			inDisableHTTPSRedirect: true,
+			wantedActionType:       "forward",
+			wantedConditions:       []string{"source-ip", "host-header", "path-pattern"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tpl := New()
+
+			// WHEN
+			content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+				WorkloadType:         "Load Balanced Web Service",
+				AllowedSourceIps:     []string{"10.0.0.0/24"},
+				DisableHTTPSRedirect: tc.inDisableHTTPSRedirect,
+			})
+
+			// THEN
+			require.NoError(t, err, "parse load balanced web service")
+			var actual cfn
+			err = yaml.Unmarshal(content.Bytes(), &actual)
+			require.NoError(t, err, "unmarshal actual config")
+			rule := actual.Resources.HTTPListenerRuleWithDomain.Properties
+			require.Len(t, rule.Actions, 1)
+			require.Equal(t, tc.wantedActionType, rule.Actions[0].Type)
+			var conditions []string
+			for _, condition := range rule.Conditions {
+				conditions = append(conditions, condition.Field)
+			}
+			require.Equal(t, tc.wantedConditions, conditions)
+		})
+	}
+}
+
+func TestTemplate_ParseHostedZoneAliases(t *testing.T) {
+	type recordSet struct {
+		Name string `yaml:"Name"`
+	}
+	type recordSetGroup struct {
+		Properties struct {
+			HostedZoneID string      `yaml:"HostedZoneId"`
+			RecordSets   []recordSet `yaml:"RecordSets"`
+		} `yaml:"Properties"`
+	}
+	type cfn struct {
+		Resources map[string]recordSetGroup `yaml:"Resources"`
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+		WorkloadType: "Load Balanced Web Service",
+		Aliases:      []string{"example.com", "www.example.com", "tenant.example.org"},
+		HostedZoneAliases: map[string][]string{
+			"Z0873220N255IR3MTNR4": {"example.com", "www.example.com"},
+			"Z23ABC4XYZL05B":       {"tenant.example.org"},
+		},
+	})
+
+	// THEN
+	require.NoError(t, err, "parse load balanced web service")
+	var actual cfn
+	err = yaml.Unmarshal(content.Bytes(), &actual)
+	require.NoError(t, err, "unmarshal actual config")
+	require.NotContains(t, actual.Resources, "LoadBalancerDNSAlias")
+	first := actual.Resources["LoadBalancerDNSAliasZ0873220N255IR3MTNR4"].Properties
+	require.Equal(t, "Z0873220N255IR3MTNR4", first.HostedZoneID)
+	require.Equal(t, []recordSet{{Name: "example.com"}, {Name: "www.example.com"}}, first.RecordSets)
+	second := actual.Resources["LoadBalancerDNSAliasZ23ABC4XYZL05B"].Properties
+	require.Equal(t, "Z23ABC4XYZL05B", second.HostedZoneID)
+	require.Equal(t, []recordSet{{Name: "tenant.example.org"}}, second.RecordSets)
+}
+
+func TestTemplate_ParseAdditionalRules(t *testing.T) {
+	type condition struct {
+		Field            string `yaml:"Field"`
+		HostHeaderConfig struct {
+			Values []string `yaml:"Values"`
+		} `yaml:"HostHeaderConfig"`
+		PathPatternConfig struct {
+			Values []string `yaml:"Values"`
+		} `yaml:"PathPatternConfig"`
+		SourceIPConfig struct {
+			Values []string `yaml:"Values"`
+		} `yaml:"SourceIpConfig"`
+	}
+	type listenerRule struct {
+		Properties struct {
+			Conditions []condition `yaml:"Conditions"`
+			Priority   yaml.Node   `yaml:"Priority"`
+		} `yaml:"Properties"`
+	}
+	type cfn struct {
+		Resources struct {
+			HTTPSRulePriorityAction struct {
+				Properties struct {
+					AdditionalRuleCount int `yaml:"AdditionalRuleCount"`
+				} `yaml:"Properties"`
+			} `yaml:"HTTPSRulePriorityAction"`
+			HTTPSAdditionalListenerRule0          listenerRule `yaml:"HTTPSAdditionalListenerRule0"`
+			HTTPSAdditionalListenerRule1          listenerRule `yaml:"HTTPSAdditionalListenerRule1"`
+			HTTPAdditionalListenerRuleWithDomain1 listenerRule `yaml:"HTTPAdditionalListenerRuleWithDomain1"`
+			HTTPAdditionalListenerRule1           listenerRule `yaml:"HTTPAdditionalListenerRule1"`
+		} `yaml:"Resources"`
+	}
+
+	// GIVEN
+	tpl := New()
+
+	// WHEN
+	content, err := tpl.ParseLoadBalancedWebService(WorkloadOpts{
+		WorkloadType: "Load Balanced Web Service",
+		Aliases:      []string{"example.com", "admin.example.com"},
+		AdditionalRules: []RoutingRuleOpts{
+			{
+				Path: "api",
+			},
+			{
+				Path:             "admin",
+				Aliases:          []string{"admin.example.com"},
+				AllowedSourceIps: []string{"10.1.0.0/24"},
+			},
+		},
+	})
+
+	// THEN
+	require.NoError(t, err, "parse load balanced web service")
+	var actual cfn
+	err = yaml.Unmarshal(content.Bytes(), &actual)
+	require.NoError(t, err, "unmarshal actual config")
+	require.Equal(t, 2, actual.Resources.HTTPSRulePriorityAction.Properties.AdditionalRuleCount)
+
+	api := actual.Resources.HTTPSAdditionalListenerRule0.Properties
+	require.Equal(t, "HTTPSRulePriorityAction.AdditionalPriority0", api.Priority.Value)
+	require.Len(t, api.Conditions, 2)
+	require.Equal(t, []string{"example.com", "admin.example.com"}, api.Conditions[0].HostHeaderConfig.Values)
+	require.Equal(t, []string{"/api", "/api/*"}, api.Conditions[1].PathPatternConfig.Values)
+
+	admin := actual.Resources.HTTPSAdditionalListenerRule1.Properties
+	require.Equal(t, "HTTPSRulePriorityAction.AdditionalPriority1", admin.Priority.Value)
+	require.Len(t, admin.Conditions, 3)
+	require.Equal(t, []string{"10.1.0.0/24"}, admin.Conditions[0].SourceIPConfig.Values)
+	require.Equal(t, []string{"admin.example.com"}, admin.Conditions[1].HostHeaderConfig.Values)
+	require.Equal(t, []string{"/admin", "/admin/*"}, admin.Conditions[2].PathPatternConfig.Values)
+
+	redirect := actual.Resources.HTTPAdditionalListenerRuleWithDomain1.Properties
+	require.Equal(t, "HTTPSRulePriorityAction.AdditionalPriority1", redirect.Priority.Value)
+	require.Len(t, redirect.Conditions, 2)
+
+	http := actual.Resources.HTTPAdditionalListenerRule1.Properties
+	require.Equal(t, "HTTPRulePriorityAction.AdditionalPriority1", http.Priority.Value)
+	require.Len(t, http.Conditions, 2)
+	require.Equal(t, []string{"10.1.0.0/24"}, http.Conditions[0].SourceIPConfig.Values)
+	require.Equal(t, []string{"/admin", "/admin/*"}, http.Conditions[1].PathPatternConfig.Values)
+}